@@ -0,0 +1,17 @@
+-- Password reset tokens table
+CREATE TABLE iam.password_reset_token
+(
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    token_hash TEXT        NOT NULL,
+    source     TEXT        NOT NULL CHECK (source IN ('web', 'android', 'ios')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id),            -- Only one active token per user
+    UNIQUE (user_id, token_hash) -- Unique combination of user_id and token hash
+);
+
+-- Create indexes for password reset tokens
+CREATE INDEX idx_password_reset_token_user_id_hash ON iam.password_reset_token (user_id, token_hash);
+CREATE INDEX idx_password_reset_token_expires_at ON iam.password_reset_token (expires_at);
@@ -0,0 +1,32 @@
+-- Registered OAuth/OIDC clients (SPAs, mobile apps and backends)
+CREATE TABLE iam.oauth_client
+(
+    id                 TEXT PRIMARY KEY,                   -- client_id
+    tenant_id          TEXT        NOT NULL REFERENCES iam.tenant (id) ON DELETE CASCADE,
+    name               TEXT        NOT NULL,
+    client_secret_hash TEXT        NULL,                   -- NULL for public clients
+    redirect_uris      TEXT[]      NOT NULL DEFAULT '{}',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_oauth_client_tenant_id ON iam.oauth_client (tenant_id);
+
+-- Authorization codes issued by the /oauth2/authorize endpoint
+CREATE TABLE iam.oauth_authorization_code
+(
+    id                    TEXT PRIMARY KEY,
+    client_id             TEXT        NOT NULL REFERENCES iam.oauth_client (id) ON DELETE CASCADE,
+    user_id               TEXT        NOT NULL REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    code_hash             TEXT        NOT NULL UNIQUE,
+    redirect_uri          TEXT        NOT NULL,
+    scope                 TEXT        NOT NULL,
+    nonce                 TEXT        NULL,
+    code_challenge        TEXT        NOT NULL,
+    code_challenge_method TEXT        NOT NULL CHECK (code_challenge_method IN ('S256')),
+    expires_at            TIMESTAMPTZ NOT NULL,
+    used_at               TIMESTAMPTZ NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_oauth_authorization_code_expires_at ON iam.oauth_authorization_code (expires_at);
@@ -0,0 +1,27 @@
+-- Tenants can require a second factor for users with the admin role
+ALTER TABLE iam.tenant
+    ADD COLUMN require_admin_mfa BOOLEAN NOT NULL DEFAULT false;
+
+-- TOTP authenticator of a user, MFA is enabled once enrollment is confirmed with a first code
+CREATE TABLE iam.auth_user_mfa
+(
+    user_id        TEXT PRIMARY KEY REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    totp_secret    TEXT        NOT NULL, -- base32 encoded shared secret
+    confirmed_at   TIMESTAMPTZ NULL,
+    last_used_step BIGINT      NULL,     -- last accepted TOTP time step, prevents code replay
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One-time recovery codes, replaced as a whole when MFA is (re-)enrolled
+CREATE TABLE iam.auth_user_recovery_code
+(
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT        NOT NULL REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    code_hash  TEXT        NOT NULL,
+    used_at    TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, code_hash)
+);
+
+CREATE INDEX idx_auth_user_recovery_code_user_id ON iam.auth_user_recovery_code (user_id);
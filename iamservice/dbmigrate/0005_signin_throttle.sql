@@ -0,0 +1,12 @@
+-- Failed sign in attempts per user and per source IP. Once the number of failures within the configured
+-- window reaches the threshold, the subject is locked until locked_until and the counter starts over.
+CREATE TABLE iam.signin_throttle
+(
+    subject_type      TEXT        NOT NULL, -- 'user' or 'ip'
+    subject           TEXT        NOT NULL, -- user ID or IP address
+    failed_attempts   INTEGER     NOT NULL DEFAULT 0,
+    window_started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until      TIMESTAMPTZ NULL,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subject_type, subject)
+);
@@ -0,0 +1,24 @@
+-- Security-relevant events: who (actor) did what (action) to which target, from where and what changed.
+-- tenant_id is the tenant the event belongs to, which is the tenant of the target, so that tenant admins
+-- see actions of sysadmins performed in their tenant as well.
+CREATE TABLE iam.audit_event
+(
+    id              TEXT PRIMARY KEY,
+    action          TEXT        NOT NULL, -- e.g. 'user.role_assigned', 'auth.signin_failed'
+    tenant_id       TEXT        NULL,
+    actor_user_id   TEXT        NULL,     -- NULL for unauthenticated actors, e.g. failed sign in
+    actor_tenant_id TEXT        NULL,
+    actor_email     TEXT        NULL,
+    actor_roles     TEXT[]      NOT NULL DEFAULT '{}',
+    target_type     TEXT        NULL,     -- 'user', 'tenant' or 'email'
+    target_id       TEXT        NULL,
+    ip_address      TEXT        NULL,
+    user_agent      TEXT        NULL,
+    reason          TEXT        NULL,     -- failure reason, e.g. 'invalid_credentials'
+    diff            JSONB       NOT NULL DEFAULT '{}', -- {"field": {"old": ..., "new": ...}}
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_event_tenant_id_created_at ON iam.audit_event (tenant_id, created_at DESC);
+CREATE INDEX idx_audit_event_created_at ON iam.audit_event (created_at DESC);
+CREATE INDEX idx_audit_event_target_id ON iam.audit_event (target_id);
@@ -0,0 +1,14 @@
+-- Refresh tokens are grouped into families, one per session started by a sign in. Rotation issues the next
+-- token of the same family. Revoked tokens are kept with their family, so that a replayed token is detected
+-- and the whole family can be revoked.
+ALTER TABLE iam.auth_refresh_token
+    ADD COLUMN family_id TEXT;
+
+UPDATE iam.auth_refresh_token
+SET family_id = id
+WHERE family_id IS NULL;
+
+ALTER TABLE iam.auth_refresh_token
+    ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX idx_auth_refresh_token_family_id ON iam.auth_refresh_token (family_id);
@@ -0,0 +1,24 @@
+-- Session of a signed in device, one per refresh token family. Device metadata is taken from the sign in
+-- and updated on every token refresh.
+CREATE TABLE iam.auth_session
+(
+    id           TEXT PRIMARY KEY,            -- refresh token family ID
+    user_id      TEXT        NOT NULL REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    source       TEXT        NOT NULL DEFAULT 'web', -- 'web', 'android' or 'ios'
+    user_agent   TEXT        NULL,
+    ip_address   TEXT        NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_auth_session_user_id ON iam.auth_session (user_id);
+
+-- Sessions for families issued before sessions were tracked, without device metadata
+INSERT INTO iam.auth_session (id, user_id, created_at, last_used_at)
+SELECT family_id, user_id, min(issued_at), max(issued_at)
+FROM iam.auth_refresh_token
+GROUP BY family_id, user_id;
+
+ALTER TABLE iam.auth_refresh_token
+    ADD CONSTRAINT fk_auth_refresh_token_session
+        FOREIGN KEY (family_id) REFERENCES iam.auth_session (id) ON DELETE CASCADE;
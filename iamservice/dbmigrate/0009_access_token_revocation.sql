@@ -0,0 +1,14 @@
+-- Revoked access tokens. Access tokens are not stored, so a revocation describes the tokens it covers instead:
+-- a single token by its jti, all tokens of a session or of a user, or all tokens at all. Covered tokens issued
+-- before issued_before are rejected. An entry is kept until the last token it covers has expired.
+CREATE TABLE iam.access_token_revocation
+(
+    subject_type  TEXT        NOT NULL CHECK (subject_type IN ('jti', 'session', 'user', 'all')),
+    subject       TEXT        NOT NULL, -- token ID, session ID or user ID, empty for all tokens
+    issued_before TIMESTAMPTZ NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL, -- all covered tokens are expired by this time
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (subject_type, subject)
+);
+
+CREATE INDEX idx_access_token_revocation_expires_at ON iam.access_token_revocation (expires_at);
@@ -0,0 +1,27 @@
+-- Machine clients (backend services) that obtain access tokens with the client_credentials grant
+CREATE TABLE iam.service_client
+(
+    id                 TEXT PRIMARY KEY,                   -- client_id
+    tenant_id          TEXT        NOT NULL REFERENCES iam.tenant (id) ON DELETE CASCADE,
+    name               TEXT        NOT NULL,
+    client_secret_hash TEXT        NOT NULL,
+    scopes             TEXT[]      NOT NULL DEFAULT '{}', -- scopes the client may request
+    expires_at         TIMESTAMPTZ NULL,                   -- NULL for clients that do not expire
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_service_client_tenant_id ON iam.service_client (tenant_id);
+
+CREATE TABLE iam.service_client_role
+(
+    client_id TEXT NOT NULL REFERENCES iam.service_client (id) ON DELETE CASCADE,
+    role_id   INT  NOT NULL REFERENCES iam.auth_role (id) ON DELETE CASCADE,
+    PRIMARY KEY (client_id, role_id)
+);
+
+-- Access tokens of a service client can be revoked like the ones of a user
+ALTER TABLE iam.access_token_revocation
+    DROP CONSTRAINT access_token_revocation_subject_type_check,
+    ADD CONSTRAINT access_token_revocation_subject_type_check
+        CHECK (subject_type IN ('jti', 'session', 'user', 'client', 'all'));
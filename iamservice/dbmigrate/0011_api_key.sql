@@ -0,0 +1,15 @@
+-- Long-lived personal API keys of users, for scripts and other non-interactive clients
+CREATE TABLE iam.api_key
+(
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT        NOT NULL REFERENCES iam.auth_user (id) ON DELETE CASCADE,
+    name         TEXT        NOT NULL,
+    key_prefix   TEXT        NOT NULL,            -- first characters of the key, to tell keys apart
+    key_hash     TEXT        NOT NULL UNIQUE,     -- SHA-256 hash of the key
+    roles        TEXT[]      NOT NULL DEFAULT '{}', -- subset of the roles of the user
+    expires_at   TIMESTAMPTZ NULL,                -- NULL for keys that do not expire
+    last_used_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_api_key_user_id ON iam.api_key (user_id);
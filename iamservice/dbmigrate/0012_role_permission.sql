@@ -0,0 +1,57 @@
+-- Permissions checked by the service, roles bundle them
+CREATE TABLE iam.permission
+(
+    name        TEXT PRIMARY KEY, -- e.g. 'users:read', 'tenants:update'
+    description TEXT    NOT NULL,
+    system_only BOOLEAN NOT NULL DEFAULT false -- only system roles can grant it, tenant roles cannot
+);
+
+INSERT INTO iam.permission (name, description, system_only)
+VALUES ('users:read', 'View users and their roles', false),
+       ('users:create', 'Create users', false),
+       ('users:update', 'Update users, their passwords, sessions, two-factor authentication and lockouts', false),
+       ('users:delete', 'Delete users', false),
+       ('users:assign_roles', 'Assign roles to users and remove them', false),
+       ('tenants:read', 'View tenants', false),
+       ('tenants:update', 'Update tenants', false),
+       ('tenants:create', 'Create tenants', true),
+       ('tenants:delete', 'Delete tenants', true),
+       ('tenants:all', 'Use all other permissions in every tenant, not only in the own one', true),
+       ('roles:read', 'View roles and their permissions', false),
+       ('roles:manage', 'Create, update and delete custom roles', false),
+       ('audit:read', 'View the audit log', false),
+       ('service_clients:manage', 'Create, list and delete service clients', false),
+       ('tokens:revoke', 'Reject all access tokens issued so far', true);
+
+-- Roles are either system roles available in every tenant (tenant_id NULL) or custom roles of a tenant
+ALTER TABLE iam.auth_role
+    ADD COLUMN tenant_id  TEXT        NULL REFERENCES iam.tenant (id) ON DELETE CASCADE,
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE iam.auth_role
+    DROP CONSTRAINT auth_role_name_key;
+CREATE UNIQUE INDEX idx_auth_role_tenant_name ON iam.auth_role (COALESCE(tenant_id, ''), name);
+
+CREATE TABLE iam.auth_role_permission
+(
+    role_id    INT  NOT NULL REFERENCES iam.auth_role (id) ON DELETE CASCADE,
+    permission TEXT NOT NULL REFERENCES iam.permission (name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+-- Permissions of the system roles, users need none to manage their own account
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT r.id, p.name
+FROM iam.auth_role r
+         JOIN iam.permission p ON p.name IN ('users:read', 'users:create', 'users:update', 'users:delete',
+                                             'users:assign_roles', 'tenants:read', 'tenants:update', 'roles:read',
+                                             'roles:manage', 'audit:read', 'service_clients:manage')
+WHERE r.name = 'admin'
+  AND r.tenant_id IS NULL;
+
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT r.id, p.name
+FROM iam.auth_role r
+         CROSS JOIN iam.permission p
+WHERE r.name = 'sysadmin'
+  AND r.tenant_id IS NULL;
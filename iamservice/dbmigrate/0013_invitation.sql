@@ -0,0 +1,17 @@
+-- Invitations of users to a tenant, accepted by the invitees setting their own password
+CREATE TABLE iam.invitation
+(
+    id          TEXT PRIMARY KEY,
+    tenant_id   TEXT        NOT NULL REFERENCES iam.tenant (id) ON DELETE CASCADE,
+    email       TEXT        NOT NULL,
+    roles       TEXT[]      NOT NULL DEFAULT '{}', -- roles given to the invitee
+    token_hash  TEXT        NOT NULL UNIQUE,       -- SHA-256 hash of the last token sent, older links are rejected
+    invited_by  TEXT        NULL REFERENCES iam.auth_user (id) ON DELETE SET NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- An email address can have a single pending invitation per tenant
+CREATE UNIQUE INDEX idx_invitation_tenant_email_pending ON iam.invitation (tenant_id, lower(email))
+    WHERE accepted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Email confirmation tokens confirm either the address a user signed up with or a new address the user
+-- changes to. The email column of an email change token is the pending new address, the address of the
+-- user is swapped only once the token is confirmed.
+ALTER TABLE iam.email_confirmation_token
+    ADD COLUMN purpose TEXT NOT NULL DEFAULT 'signup' CHECK (purpose IN ('signup', 'email_change'));
@@ -0,0 +1,7 @@
+-- Wrong guesses of the current code, the code is invalidated once the configured maximum is reached. Codes sent
+-- within the day window starting at send_window_started_at are counted to cap resending, created_at is the time
+-- the current code was sent.
+ALTER TABLE iam.email_confirmation_token
+    ADD COLUMN failed_attempts        INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN send_count             INTEGER     NOT NULL DEFAULT 1,
+    ADD COLUMN send_window_started_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -0,0 +1,28 @@
+-- Outgoing emails, written in the transaction of the change that sends them and delivered by a background
+-- dispatcher. Pending emails are retried with a backoff until they are sent or given up as dead.
+CREATE TABLE iam.outbox_email
+(
+    id              TEXT PRIMARY KEY,
+    recipient       TEXT        NOT NULL,
+    content_type    TEXT        NOT NULL,
+    subject         TEXT        NOT NULL,
+    body            TEXT        NOT NULL,                 -- cleared once sent, it may contain one-time codes
+    status          TEXT        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+    attempts        INTEGER     NOT NULL DEFAULT 0,       -- delivery attempts made so far
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),   -- also pushed out while an attempt is in progress
+    last_error      TEXT        NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_outbox_email_pending ON iam.outbox_email (next_attempt_at) WHERE status = 'pending';
+
+INSERT INTO iam.permission (name, description, system_only)
+VALUES ('outbox:manage', 'View outgoing emails that were not delivered yet and retry them', true);
+
+-- System roles got their permissions when they were introduced, new ones are granted explicitly
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT r.id, 'outbox:manage'
+FROM iam.auth_role r
+WHERE r.name = 'sysadmin'
+  AND r.tenant_id IS NULL;
@@ -0,0 +1,11 @@
+-- Outgoing emails carry an HTML body with its plain-text alternative, additional headers and attachments.
+-- Emails queued so far were HTML only.
+ALTER TABLE iam.outbox_email
+    RENAME COLUMN body TO html_body;
+
+ALTER TABLE iam.outbox_email
+    DROP COLUMN content_type,
+    ADD COLUMN text_body   TEXT  NOT NULL DEFAULT '', -- cleared once sent, like html_body
+    ADD COLUMN reply_to    TEXT  NULL,
+    ADD COLUMN headers     JSONB NOT NULL DEFAULT '{}', -- additional headers by name, e.g. List-Unsubscribe
+    ADD COLUMN attachments JSONB NOT NULL DEFAULT '[]'; -- base64 encoded data, cleared once sent
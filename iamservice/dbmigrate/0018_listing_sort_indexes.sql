@@ -0,0 +1,8 @@
+-- Users and tenants are listed page by page sorted by one of their columns, with the ID as a tie-breaker
+CREATE INDEX idx_auth_user_created_at ON iam.auth_user (created_at, id);
+CREATE INDEX idx_auth_user_tenant_created_at ON iam.auth_user (tenant_id, created_at, id);
+CREATE INDEX idx_auth_user_tenant_email ON iam.auth_user (tenant_id, email, id);
+CREATE INDEX idx_auth_user_tenant_last_name ON iam.auth_user (tenant_id, last_name, id);
+
+CREATE INDEX idx_tenant_created_at ON iam.tenant (created_at, id);
+CREATE INDEX idx_tenant_name ON iam.tenant (name, id);
@@ -0,0 +1,7 @@
+-- Fuzzy user search matches partial and misspelled names and emails by trigram similarity. Trigram indexes serve
+-- substring (ILIKE) filters of user listings as well.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_auth_user_email_trgm ON iam.auth_user USING gin (email gin_trgm_ops);
+CREATE INDEX idx_auth_user_first_name_trgm ON iam.auth_user USING gin (first_name gin_trgm_ops);
+CREATE INDEX idx_auth_user_last_name_trgm ON iam.auth_user USING gin (last_name gin_trgm_ops);
@@ -0,0 +1,44 @@
+-- Webhook subscriptions of tenants. The secret signs delivered events and therefore is stored as is.
+CREATE TABLE iam.webhook
+(
+    id          TEXT PRIMARY KEY,
+    tenant_id   TEXT        NOT NULL REFERENCES iam.tenant (id) ON DELETE CASCADE,
+    url         TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    event_types TEXT[]      NOT NULL, -- e.g. 'user.created', 'tenant.updated'
+    active      BOOLEAN     NOT NULL DEFAULT true,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhook_tenant ON iam.webhook (tenant_id);
+
+-- Events to deliver to webhooks, written in the transaction of the change that emits them and delivered by a
+-- background dispatcher like outgoing emails. Delivered and dead deliveries are kept as delivery log.
+CREATE TABLE iam.webhook_delivery
+(
+    id               TEXT PRIMARY KEY,
+    webhook_id       TEXT        NOT NULL REFERENCES iam.webhook (id) ON DELETE CASCADE,
+    event_id         TEXT        NOT NULL, -- shared by deliveries of the event to other webhooks and re-deliveries
+    event_type       TEXT        NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts         INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INTEGER     NULL,     -- HTTP status of the last attempt, NULL if no response was received
+    last_error       TEXT        NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at     TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_webhook_delivery_pending ON iam.webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_delivery_webhook ON iam.webhook_delivery (webhook_id, created_at DESC);
+
+INSERT INTO iam.permission (name, description, system_only)
+VALUES ('webhooks:manage', 'Create, update and delete webhooks, view their deliveries and re-deliver events', false);
+
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT r.id, 'webhooks:manage'
+FROM iam.auth_role r
+WHERE r.name IN ('admin', 'sysadmin')
+  AND r.tenant_id IS NULL;
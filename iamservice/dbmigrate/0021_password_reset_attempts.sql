@@ -0,0 +1,4 @@
+-- Wrong guesses of the current password reset code, the code is invalidated once the maximum is reached. Short
+-- codes sent to mobile users could otherwise be guessed within their lifetime.
+ALTER TABLE iam.password_reset_token
+    ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Consecutive lockouts of a subject. Every lockout that follows the previous one within the maximum lockout
+-- duration doubles the lockout duration, so that slow brute force attempts are held back progressively.
+ALTER TABLE iam.signin_throttle
+    ADD COLUMN lockout_count INTEGER NOT NULL DEFAULT 0;
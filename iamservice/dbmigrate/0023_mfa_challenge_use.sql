@@ -0,0 +1,9 @@
+-- MFA challenge tokens that completed a sign in, so that a challenge cannot be verified a second time.
+-- Rows are kept until the challenge token expires.
+CREATE TABLE iam.mfa_challenge_use
+(
+    jti        TEXT PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_mfa_challenge_use_expires_at ON iam.mfa_challenge_use (expires_at);
@@ -0,0 +1,4 @@
+-- OAuth client a session was started for by an authorization code exchange. Refresh tokens of the session
+-- can only be redeemed by the same client, NULL for sessions of a direct sign in.
+ALTER TABLE iam.auth_session
+    ADD COLUMN oauth_client_id TEXT NULL REFERENCES iam.oauth_client (id) ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+-- Time the user signed in to the session an authorization code was issued from, "auth_time" of ID tokens
+ALTER TABLE iam.oauth_authorization_code
+    ADD COLUMN auth_time TIMESTAMPTZ NULL;
+
+UPDATE iam.oauth_authorization_code
+SET auth_time = created_at;
+
+ALTER TABLE iam.oauth_authorization_code
+    ALTER COLUMN auth_time SET NOT NULL;
@@ -0,0 +1,8 @@
+INSERT INTO iam.permission (name, description, system_only)
+VALUES ('oauth_clients:manage', 'Register, view and delete OAuth clients that sign in users of the tenant', false);
+
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT r.id, 'oauth_clients:manage'
+FROM iam.auth_role r
+WHERE r.name IN ('admin', 'sysadmin')
+  AND r.tenant_id IS NULL;
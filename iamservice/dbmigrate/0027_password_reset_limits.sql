@@ -0,0 +1,6 @@
+-- Password reset codes sent within the day window starting at send_window_started_at are counted to cap
+-- re-issuing, created_at is the time the current code was sent. Wrong guesses carry over to a re-issued code
+-- within the window, so that requesting new codes does not reset the guess limit.
+ALTER TABLE iam.password_reset_token
+    ADD COLUMN send_count             INTEGER     NOT NULL DEFAULT 1,
+    ADD COLUMN send_window_started_at TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -5,6 +5,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
 	"github.com/mobiletoly/gokatana/katapp"
 	"github.com/mobiletoly/gokatana/kathttp"
@@ -35,6 +36,7 @@ func Start(ctx context.Context, uc *usecase.UseCases) *echo.Echo {
 		&uc.Config.Server,
 		logger,
 		func(e *echo.Echo) {
+			e.IPExtractor = serverhelp.ClientIPExtractor(uc.Config.TrustedProxies)
 			config := slogecho.Config{
 				WithRequestID: true,
 				WithSpanID:    true,
@@ -42,6 +44,7 @@ func Start(ctx context.Context, uc *usecase.UseCases) *echo.Echo {
 			}
 			e.Use(slogecho.NewWithConfig(logger, config))
 			e.Use(kathttp_echo.GuessHTTPErrorMiddleware)
+			e.Use(serverhelp.ClientInfoMiddleware())
 			apiRoutes(e, uc)
 			webserver.SetupWebRoutes(e, uc)
 		})
@@ -56,10 +59,20 @@ func WaitForInterruptSignal(ctx context.Context, server *echo.Echo, timeout time
 }
 
 func apiRoutes(e *echo.Echo, uc *usecase.UseCases) {
-	authMiddleware := serverhelp.NewJWTAuthApiServerMiddleware([]byte(uc.Config.Credentials.JwtSecret))
-	authLock := authMiddleware.WithAnyRole("admin", "sysadmin", "user")
-	adminAuthLock := authMiddleware.WithAnyRole("admin", "sysadmin")
-	sysadminAuthLock := authMiddleware.WithAnyRole("sysadmin")
+	authMiddleware := serverhelp.NewJWTAuthApiServerMiddleware(uc.JWTKeys, uc.Auth)
+	authLock := authMiddleware.WithAnyPermission()
+	permissionLock := authMiddleware.WithAnyPermission
+
+	// Public keys for verifying issued tokens
+	e.GET("/.well-known/jwks.json", getJWKSHandler(uc.JWTKeys))
+
+	// OpenID Connect provider routes
+	e.GET("/.well-known/openid-configuration", getOpenIDConfigurationHandler(uc.OIDC))
+	oauth2 := e.Group("/oauth2")
+	oauth2.GET("/authorize", authorizeHandler(uc.OIDC))
+	oauth2.POST("/token", tokenHandler(uc.OIDC))
+	oauth2.GET("/userinfo", getUserInfoHandler(uc.OIDC), authLock)
+	oauth2.POST("/userinfo", getUserInfoHandler(uc.OIDC), authLock)
 
 	api := e.Group("/api/v1")
 	api.GET("/version", getHttpVersionRoute())
@@ -70,32 +83,125 @@ func apiRoutes(e *echo.Echo, uc *usecase.UseCases) {
 	auth.POST("/signin", signinHandler(uc.Auth))
 	auth.POST("/signout", signoutHandler(uc.Auth), authLock)
 	auth.POST("/refresh", refreshTokenHandler(uc.Auth))
+	auth.POST("/token", clientCredentialsTokenHandler(uc.ServiceClientMgm))
+	auth.POST("/introspect", introspectTokenHandler(uc.OIDC))
+	auth.POST("/revoke-access-tokens", revokeAccessTokensHandler(uc.Auth), permissionLock(model.PermissionTokensRevoke))
 	auth.POST("/confirm-email", confirmEmailHandler(uc.Auth))
+	auth.POST("/resend-confirmation", resendConfirmationHandler(uc.Auth))
+	auth.POST("/forgot-password", forgotPasswordHandler(uc.Auth))
+	auth.POST("/reset-password", resetPasswordHandler(uc.Auth))
+	auth.POST("/mfa/verify", verifyMfaHandler(uc.Auth))
+	auth.POST("/mfa/enroll", enrollMfaWithChallengeHandler(uc.Auth))
 
 	// User profile routes (basic authentication required)
 	api.GET("/users/me", getMyUserHandler(uc.UserMgm), authLock)
 
-	// User Management API routes (sysadmin role required)
-	api.GET("/users/all", listAllUsersHandler(uc.UserMgm), sysadminAuthLock) // GET /api/v1/users/all
+	// User Management API routes (users:read permission in all tenants required)
+	api.GET("/users/all", listAllUsersHandler(uc.UserMgm), permissionLock(model.PermissionUsersRead)) // GET /api/v1/users/all
 
-	// User Management API routes (admin role required)
+	// User Management API routes (users may access themselves, permissions are required for other users)
 	users := api.Group("/users", authLock)
-	users.GET("", listAllUsersByTenantHandler(uc.UserMgm))                                     // GET /api/v1/users
-	users.GET("/:userId", getUserByIdHandler(uc.UserMgm))                                      // GET /api/v1/users/{userId}
-	users.PUT("/:userId", updateAuthUserHandler(uc.UserMgm))                                   // PUT /api/v1/users/{userId}
-	users.GET("/:userId/profile", getUserProfileHandler(uc.UserProfileMgm))                    // GET /api/v1/users/{userId}/profile
-	users.PUT("/:userId/profile", updateUserProfileHandler(uc.UserProfileMgm))                 // PUT /api/v1/users/{userId}/profile
-	users.GET("/:userId/roles", getUserRolesHandler(uc.UserMgm))                               // GET /api/v1/users/{userId}/roles
-	users.POST("/:userId/roles", assignUserRoleHandler(uc.UserMgm), adminAuthLock)             // POST /api/v1/users/{userId}/roles
-	users.DELETE("/:userId/roles/:roleName", deleteUserRoleHandler(uc.UserMgm), adminAuthLock) // DELETE /api/v1/users/{userId}/roles/{roleName}
-
-	// Tenant Management API routes (sysadmin role required)
+	assignRolesLock := permissionLock(model.PermissionUsersAssignRoles)
+	updateUsersLock := permissionLock(model.PermissionUsersUpdate)
+	users.GET("", listAllUsersByTenantHandler(uc.UserMgm))                                       // GET /api/v1/users
+	users.GET("/search", searchUsersHandler(uc.UserMgm))                                         // GET /api/v1/users/search
+	users.GET("/:userId", getUserByIdHandler(uc.UserMgm))                                        // GET /api/v1/users/{userId}
+	users.PUT("/:userId", updateAuthUserHandler(uc.UserMgm))                                     // PUT /api/v1/users/{userId}
+	users.GET("/:userId/profile", getUserProfileHandler(uc.UserProfileMgm))                      // GET /api/v1/users/{userId}/profile
+	users.PUT("/:userId/profile", updateUserProfileHandler(uc.UserProfileMgm))                   // PUT /api/v1/users/{userId}/profile
+	users.GET("/:userId/roles", getUserRolesHandler(uc.UserMgm))                                 // GET /api/v1/users/{userId}/roles
+	users.POST("/:userId/roles", assignUserRoleHandler(uc.UserMgm), assignRolesLock)             // POST /api/v1/users/{userId}/roles
+	users.DELETE("/:userId/roles/:roleName", deleteUserRoleHandler(uc.UserMgm), assignRolesLock) // DELETE /api/v1/users/{userId}/roles/{roleName}
+	users.GET("/:userId/lockout", getUserLockoutHandler(uc.Auth), updateUsersLock)               // GET /api/v1/users/{userId}/lockout
+	users.DELETE("/:userId/lockout", unlockUserHandler(uc.Auth), updateUsersLock)                // DELETE /api/v1/users/{userId}/lockout
+	users.GET("/:userId/mfa", getUserMfaStatusHandler(uc.Auth))                                  // GET /api/v1/users/{userId}/mfa
+	users.DELETE("/:userId/mfa", disableUserMfaHandler(uc.Auth))                                 // DELETE /api/v1/users/{userId}/mfa
+	users.POST("/:userId/mfa/totp", enrollUserTotpHandler(uc.Auth))                              // POST /api/v1/users/{userId}/mfa/totp
+	users.POST("/:userId/mfa/totp/confirm", confirmUserTotpHandler(uc.Auth))                     // POST /api/v1/users/{userId}/mfa/totp/confirm
+	users.GET("/:userId/sessions", listUserSessionsHandler(uc.Auth))                             // GET /api/v1/users/{userId}/sessions
+	users.DELETE("/:userId/sessions", revokeUserSessionsHandler(uc.Auth))                        // DELETE /api/v1/users/{userId}/sessions
+	users.DELETE("/:userId/sessions/:sessionId", revokeUserSessionHandler(uc.Auth))              // DELETE /api/v1/users/{userId}/sessions/{sessionId}
+	users.GET("/:userId/api-keys", listUserApiKeysHandler(uc.Auth))                              // GET /api/v1/users/{userId}/api-keys
+	users.POST("/:userId/api-keys", createUserApiKeyHandler(uc.Auth))                            // POST /api/v1/users/{userId}/api-keys
+	users.DELETE("/:userId/api-keys/:keyId", revokeUserApiKeyHandler(uc.Auth))                   // DELETE /api/v1/users/{userId}/api-keys/{keyId}
+	users.POST("/:userId/email-change", requestUserEmailChangeHandler(uc.Auth))                  // POST /api/v1/users/{userId}/email-change
+	users.POST("/:userId/email-change/confirm", confirmUserEmailChangeHandler(uc.Auth))          // POST /api/v1/users/{userId}/email-change/confirm
+
+	// Tenant Management API routes (tenants:* permissions required, users may read their own tenant)
 	tenants := api.Group("/tenants", authLock)
-	tenants.GET("", getAllTenantsHandler(uc.Auth))                               // GET /api/v1/tenants
-	tenants.POST("", createTenantHandler(uc.Auth), sysadminAuthLock)             // POST /api/v1/tenants
-	tenants.GET("/:tenantId", getTenantByIdHandler(uc.Auth))                     // GET /api/v1/tenants/{tenantId}
-	tenants.PUT("/:tenantId", updateTenantHandler(uc.Auth), adminAuthLock)       // PUT /api/v1/tenants/{tenantId}
-	tenants.DELETE("/:tenantId", deleteTenantHandler(uc.Auth), sysadminAuthLock) // DELETE /api/v1/tenants/{tenantId}
+	tenants.GET("", getAllTenantsHandler(uc.Auth))                                                            // GET /api/v1/tenants
+	tenants.POST("", createTenantHandler(uc.Auth), permissionLock(model.PermissionTenantsCreate))             // POST /api/v1/tenants
+	tenants.GET("/:tenantId", getTenantByIdHandler(uc.Auth))                                                  // GET /api/v1/tenants/{tenantId}
+	tenants.PUT("/:tenantId", updateTenantHandler(uc.Auth), permissionLock(model.PermissionTenantsUpdate))    // PUT /api/v1/tenants/{tenantId}
+	tenants.DELETE("/:tenantId", deleteTenantHandler(uc.Auth), permissionLock(model.PermissionTenantsDelete)) // DELETE /api/v1/tenants/{tenantId}
+
+	// Role management routes (roles:* permissions required)
+	rolesReadLock := permissionLock(model.PermissionRolesRead)
+	rolesManageLock := permissionLock(model.PermissionRolesManage)
+	api.GET("/permissions", listPermissionsHandler(uc.RoleMgm), rolesReadLock)                   // GET /api/v1/permissions
+	tenants.GET("/:tenantId/roles", listRolesHandler(uc.RoleMgm), rolesReadLock)                 // GET /api/v1/tenants/{tenantId}/roles
+	tenants.POST("/:tenantId/roles", createRoleHandler(uc.RoleMgm), rolesManageLock)             // POST /api/v1/tenants/{tenantId}/roles
+	tenants.GET("/:tenantId/roles/:roleName", getRoleHandler(uc.RoleMgm), rolesReadLock)         // GET /api/v1/tenants/{tenantId}/roles/{roleName}
+	tenants.PUT("/:tenantId/roles/:roleName", updateRoleHandler(uc.RoleMgm), rolesManageLock)    // PUT /api/v1/tenants/{tenantId}/roles/{roleName}
+	tenants.DELETE("/:tenantId/roles/:roleName", deleteRoleHandler(uc.RoleMgm), rolesManageLock) // DELETE /api/v1/tenants/{tenantId}/roles/{roleName}
+
+	// Invitation routes (users:create permission required, accepting an invitation is public)
+	invitationsLock := permissionLock(model.PermissionUsersCreate)
+	tenants.GET("/:tenantId/invitations", listInvitationsHandler(uc.InvitationMgm), invitationsLock)                        // GET /api/v1/tenants/{tenantId}/invitations
+	tenants.POST("/:tenantId/invitations", createInvitationHandler(uc.InvitationMgm), invitationsLock)                      // POST /api/v1/tenants/{tenantId}/invitations
+	tenants.POST("/:tenantId/invitations/:invitationId/resend", resendInvitationHandler(uc.InvitationMgm), invitationsLock) // POST /api/v1/tenants/{tenantId}/invitations/{invitationId}/resend
+	tenants.DELETE("/:tenantId/invitations/:invitationId", revokeInvitationHandler(uc.InvitationMgm), invitationsLock)      // DELETE /api/v1/tenants/{tenantId}/invitations/{invitationId}
+	api.POST("/invitations/accept", acceptInvitationHandler(uc.InvitationMgm))                                              // POST /api/v1/invitations/accept
+
+	// User import and export routes (users:create permission required to import, users:read to export)
+	tenants.POST("/:tenantId/users\\:import", importUsersHandler(uc.UserMgm), permissionLock(model.PermissionUsersCreate)) // POST /api/v1/tenants/{tenantId}/users:import
+	tenants.GET("/:tenantId/users\\:export", exportUsersHandler(uc.UserMgm), permissionLock(model.PermissionUsersRead))    // GET /api/v1/tenants/{tenantId}/users:export
+
+	// Webhook routes (webhooks:manage permission required)
+	webhooksLock := permissionLock(model.PermissionWebhooksManage)
+	tenants.GET("/:tenantId/webhooks", listWebhooksHandler(uc.WebhookMgm), webhooksLock)                                                          // GET /api/v1/tenants/{tenantId}/webhooks
+	tenants.POST("/:tenantId/webhooks", createWebhookHandler(uc.WebhookMgm), webhooksLock)                                                        // POST /api/v1/tenants/{tenantId}/webhooks
+	tenants.GET("/:tenantId/webhooks/:webhookId", getWebhookHandler(uc.WebhookMgm), webhooksLock)                                                 // GET /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+	tenants.PUT("/:tenantId/webhooks/:webhookId", updateWebhookHandler(uc.WebhookMgm), webhooksLock)                                              // PUT /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+	tenants.DELETE("/:tenantId/webhooks/:webhookId", deleteWebhookHandler(uc.WebhookMgm), webhooksLock)                                           // DELETE /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+	tenants.GET("/:tenantId/webhooks/:webhookId/deliveries", listWebhookDeliveriesHandler(uc.WebhookMgm), webhooksLock)                           // GET /api/v1/tenants/{tenantId}/webhooks/{webhookId}/deliveries
+	tenants.POST("/:tenantId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", redeliverWebhookDeliveryHandler(uc.WebhookMgm), webhooksLock) // POST /api/v1/tenants/{tenantId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+
+	// Service client management routes (service_clients:manage permission required)
+	serviceClients := api.Group("/service-clients", permissionLock(model.PermissionServiceClientsManage))
+	serviceClients.GET("", listServiceClientsHandler(uc.ServiceClientMgm))               // GET /api/v1/service-clients
+	serviceClients.POST("", createServiceClientHandler(uc.ServiceClientMgm))             // POST /api/v1/service-clients
+	serviceClients.DELETE("/:clientId", deleteServiceClientHandler(uc.ServiceClientMgm)) // DELETE /api/v1/service-clients/{clientId}
+
+	// OAuth client management routes (oauth_clients:manage permission required)
+	oauthClients := api.Group("/oauth-clients", permissionLock(model.PermissionOAuthClientsManage))
+	oauthClients.GET("", listOAuthClientsHandler(uc.OIDC))               // GET /api/v1/oauth-clients
+	oauthClients.POST("", createOAuthClientHandler(uc.OIDC))             // POST /api/v1/oauth-clients
+	oauthClients.DELETE("/:clientId", deleteOAuthClientHandler(uc.OIDC)) // DELETE /api/v1/oauth-clients/{clientId}
+
+	// Audit log routes (audit:read permission required)
+	api.GET("/audit", listAuditEventsHandler(uc.AuditMgm), permissionLock(model.PermissionAuditRead)) // GET /api/v1/audit
+
+	// Email outbox routes (outbox:manage permission required)
+	outbox := api.Group("/outbox", permissionLock(model.PermissionOutboxManage))
+	outbox.GET("/emails", listFailedOutboxEmailsHandler(uc.OutboxMgm))           // GET /api/v1/outbox/emails
+	outbox.POST("/emails/:emailId/retry", retryOutboxEmailHandler(uc.OutboxMgm)) // POST /api/v1/outbox/emails/{emailId}/retry
+
+	// SCIM 2.0 provisioning routes (permissions are checked per resource in the tenant of the token)
+	scim := e.Group(usecase.ScimBasePath, scimErrorMiddleware)
+	scim.GET("/ServiceProviderConfig", getScimServiceProviderConfigHandler(uc.ScimMgm)) // GET /scim/v2/ServiceProviderConfig
+	scim.GET("/Users", listScimUsersHandler(uc.ScimMgm), authLock)                      // GET /scim/v2/Users
+	scim.POST("/Users", createScimUserHandler(uc.ScimMgm), authLock)                    // POST /scim/v2/Users
+	scim.GET("/Users/:userId", getScimUserHandler(uc.ScimMgm), authLock)                // GET /scim/v2/Users/{userId}
+	scim.PUT("/Users/:userId", replaceScimUserHandler(uc.ScimMgm), authLock)            // PUT /scim/v2/Users/{userId}
+	scim.PATCH("/Users/:userId", patchScimUserHandler(uc.ScimMgm), authLock)            // PATCH /scim/v2/Users/{userId}
+	scim.DELETE("/Users/:userId", deleteScimUserHandler(uc.ScimMgm), authLock)          // DELETE /scim/v2/Users/{userId}
+	scim.GET("/Groups", listScimGroupsHandler(uc.ScimMgm), authLock)                    // GET /scim/v2/Groups
+	scim.POST("/Groups", createScimGroupHandler(uc.ScimMgm), authLock)                  // POST /scim/v2/Groups
+	scim.GET("/Groups/:groupId", getScimGroupHandler(uc.ScimMgm), authLock)             // GET /scim/v2/Groups/{groupId}
+	scim.PUT("/Groups/:groupId", replaceScimGroupHandler(uc.ScimMgm), authLock)         // PUT /scim/v2/Groups/{groupId}
+	scim.PATCH("/Groups/:groupId", patchScimGroupHandler(uc.ScimMgm), authLock)         // PATCH /scim/v2/Groups/{groupId}
+	scim.DELETE("/Groups/:groupId", deleteScimGroupHandler(uc.ScimMgm), authLock)       // DELETE /scim/v2/Groups/{groupId}
 }
 
 func getHttpVersionRoute() func(c echo.Context) error {
@@ -0,0 +1,74 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
+)
+
+// listAuditEventsHandler handles listing audit events with filters and pagination (admin only)
+func listAuditEventsHandler(uc *usecase.AuditMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		// Parse pagination parameters
+		page := 1
+		if pageStr := c.QueryParam("page"); pageStr != "" {
+			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+				page = p
+			}
+		}
+
+		limit := 20
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+
+		// Parse time range parameters
+		var from, to *time.Time
+		if fromStr := c.QueryParam("from"); fromStr != "" {
+			t, err := time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				return kathttp_echo.ReportBadRequest(errors.New("invalid from, RFC 3339 timestamp expected"))
+			}
+			from = &t
+		}
+		if toStr := c.QueryParam("to"); toStr != "" {
+			t, err := time.Parse(time.RFC3339, toStr)
+			if err != nil {
+				return kathttp_echo.ReportBadRequest(errors.New("invalid to, RFC 3339 timestamp expected"))
+			}
+			to = &t
+		}
+
+		params := swagger.NewListAuditEventsParamsBuilder().
+			Page(&page).
+			Limit(&limit).
+			TenantId(lo.EmptyableToPtr(c.QueryParam("tenantId"))).
+			Action(lo.EmptyableToPtr(c.QueryParam("action"))).
+			ActorUserId(lo.EmptyableToPtr(c.QueryParam("actorUserId"))).
+			TargetId(lo.EmptyableToPtr(c.QueryParam("targetId"))).
+			From(from).
+			To(to).
+			Build()
+		if events, err := uc.ListAuditEvents(ctx, principal, params); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, events)
+		}
+	}
+}
@@ -3,10 +3,13 @@ package apiserver
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/kathttp"
 	"github.com/mobiletoly/gokatana/kathttp_echo"
 )
 
@@ -35,32 +38,43 @@ func signinHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		authResponse, err := uc.SignIn(ctx, &signinReq)
+		authResponse, mfaChallenge, err := uc.SignIn(ctx, &signinReq, c.RealIP())
 		if err != nil {
-			return kathttp_echo.ReportHTTPError(err)
+			return reportSignInError(c, err)
+		}
+		if mfaChallenge != nil {
+			return c.JSON(http.StatusOK, mfaChallenge)
 		}
 
 		return c.JSON(http.StatusOK, authResponse)
 	}
 }
 
+// reportSignInError reports throttled sign in as 429 with Retry-After header and other errors as usual
+func reportSignInError(c echo.Context, err error) error {
+	var throttledErr *usecase.SignInThrottledError
+	if !errors.As(err, &throttledErr) {
+		return kathttp_echo.ReportHTTPError(err)
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(throttledErr.RetryAfterSeconds()))
+	return echo.NewHTTPError(http.StatusTooManyRequests, &kathttp.ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusTooManyRequests,
+		StatusText:     "Too many requests",
+		ErrorText:      err.Error(),
+	})
+}
+
 func signoutHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-
-		// Get user ID from the JWT token in the Authorization header
-		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" {
-			return kathttp_echo.ReportBadRequest(errors.New("authorization header is required"))
-		}
-
-		userID, err := uc.ValidateAccessToken(authHeader)
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
 		if err != nil {
 			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		// Revoke all refresh tokens for the user
-		err = uc.SignOut(ctx, userID)
+		// Revoke all refresh tokens and access tokens of the user
+		err = uc.SignOut(ctx, principal)
 		if err != nil {
 			return kathttp_echo.ReportHTTPError(err)
 		}
@@ -86,6 +100,25 @@ func refreshTokenHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 	}
 }
 
+func revokeAccessTokensHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		var revocationReq swagger.AccessTokenRevocationRequest
+		if err := c.Bind(&revocationReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.RevokeAllAccessTokens(ctx, principal, &revocationReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
 func confirmEmailHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -96,7 +129,7 @@ func confirmEmailHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 
 		err := uc.ConfirmEmail(ctx, confirmReq.UserId, confirmReq.Code)
 		if err != nil {
-			return kathttp_echo.ReportHTTPError(err)
+			return reportEmailConfirmationError(err)
 		}
 
 		response := &swagger.EmailConfirmationResponse{
@@ -106,3 +139,84 @@ func confirmEmailHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
 		return c.JSON(http.StatusOK, response)
 	}
 }
+
+func resendConfirmationHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var resendReq swagger.ResendConfirmationRequest
+		if err := c.Bind(&resendReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		err := uc.ResendConfirmation(ctx, &resendReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		response := &swagger.ResendConfirmationResponse{
+			Message: "If an unconfirmed account with this email exists, a new confirmation code has been sent.",
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// reportEmailConfirmationError reports the application code of email confirmation errors, so that clients can
+// tell the failures apart
+func reportEmailConfirmationError(err error) error {
+	var confirmationErr *usecase.EmailConfirmationError
+	if !errors.As(err, &confirmationErr) {
+		return kathttp_echo.ReportHTTPError(err)
+	}
+	errResp := kathttp.GuessHTTPError(err)
+	errResp.AppCode = confirmationErr.Code
+	return echo.NewHTTPError(errResp.HTTPStatusCode, errResp)
+}
+
+func forgotPasswordHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var forgotReq swagger.ForgotPasswordRequest
+		if err := c.Bind(&forgotReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		err := uc.ForgotPassword(ctx, &forgotReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		response := &swagger.ForgotPasswordResponse{
+			Message: "If an account with this email exists, password reset instructions have been sent.",
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func resetPasswordHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var resetReq swagger.ResetPasswordRequest
+		if err := c.Bind(&resetReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		err := uc.ResetPassword(ctx, &resetReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		response := &swagger.ResetPasswordResponse{
+			Message: "Password reset successfully",
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func getJWKSHandler(jwtKeys *usecase.JWTKeySet) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, jwtKeys.JWKS())
+	}
+}
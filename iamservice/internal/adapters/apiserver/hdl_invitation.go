@@ -0,0 +1,100 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+// listInvitationsHandler handles GET /api/v1/tenants/{tenantId}/invitations
+func listInvitationsHandler(uc *usecase.InvitationMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		invitations, err := uc.ListInvitations(ctx, principal, c.Param("tenantId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, invitations)
+	}
+}
+
+// createInvitationHandler handles POST /api/v1/tenants/{tenantId}/invitations
+func createInvitationHandler(uc *usecase.InvitationMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.CreateInvitationRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		invitation, err := uc.CreateInvitation(ctx, principal, c.Param("tenantId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, invitation)
+	}
+}
+
+// resendInvitationHandler handles POST /api/v1/tenants/{tenantId}/invitations/{invitationId}/resend
+func resendInvitationHandler(uc *usecase.InvitationMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		invitation, err := uc.ResendInvitation(ctx, principal, c.Param("tenantId"), c.Param("invitationId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, invitation)
+	}
+}
+
+// revokeInvitationHandler handles DELETE /api/v1/tenants/{tenantId}/invitations/{invitationId}
+func revokeInvitationHandler(uc *usecase.InvitationMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.RevokeInvitation(ctx, principal, c.Param("tenantId"), c.Param("invitationId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// acceptInvitationHandler handles POST /api/v1/invitations/accept
+func acceptInvitationHandler(uc *usecase.InvitationMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var req swagger.AcceptInvitationRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		resp, err := uc.AcceptInvitation(ctx, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, resp)
+	}
+}
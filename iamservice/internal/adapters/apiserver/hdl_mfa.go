@@ -0,0 +1,134 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+// verifyMfaHandler completes a two-step sign in with a TOTP or recovery code
+func verifyMfaHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var verifyReq swagger.MfaVerifyRequest
+		if err := c.Bind(&verifyReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		authResponse, err := uc.VerifyMFA(ctx, &verifyReq, c.RealIP())
+		if err != nil {
+			return reportSignInError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, authResponse)
+	}
+}
+
+// enrollMfaWithChallengeHandler starts authenticator enrollment for users required to enroll during sign in
+func enrollMfaWithChallengeHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var enrollReq swagger.MfaEnrollRequest
+		if err := c.Bind(&enrollReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		enrollment, err := uc.EnrollMFAWithChallenge(ctx, &enrollReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// getUserMfaStatusHandler handles getting two-factor authentication status of a user
+func getUserMfaStatusHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		status, err := uc.GetMFAStatus(ctx, principal, userID)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, status)
+	}
+}
+
+// enrollUserTotpHandler handles starting authenticator enrollment for the current user
+func enrollUserTotpHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		enrollment, err := uc.EnrollTOTP(ctx, principal, userID)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// confirmUserTotpHandler handles confirming a pending authenticator with its first code
+func confirmUserTotpHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		var confirmReq swagger.MfaConfirmRequest
+		if err := c.Bind(&confirmReq); err != nil {
+			return kathttp_echo.ReportBadRequest(errors.New("invalid request body"))
+		}
+
+		status, err := uc.ConfirmTOTP(ctx, principal, userID, &confirmReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, status)
+	}
+}
+
+// disableUserMfaHandler handles disabling two-factor authentication of a user
+func disableUserMfaHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		// Admins managing the user send no body
+		var disableReq swagger.MfaDisableRequest
+		if err := c.Bind(&disableReq); err != nil {
+			return kathttp_echo.ReportBadRequest(errors.New("invalid request body"))
+		}
+
+		if err := uc.DisableMFA(ctx, principal, userID, &disableReq); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
@@ -0,0 +1,66 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+// createOAuthClientHandler handles POST /api/v1/oauth-clients
+func createOAuthClientHandler(uc *usecase.OIDCMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.CreateOAuthClientRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		clientResponse, err := uc.CreateOAuthClient(ctx, principal, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, clientResponse)
+	}
+}
+
+// listOAuthClientsHandler handles GET /api/v1/oauth-clients
+func listOAuthClientsHandler(uc *usecase.OIDCMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		clients, err := uc.ListOAuthClients(ctx, principal, c.QueryParam("tenantId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, clients)
+	}
+}
+
+// deleteOAuthClientHandler handles DELETE /api/v1/oauth-clients/{clientId}
+func deleteOAuthClientHandler(uc *usecase.OIDCMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteOAuthClient(ctx, principal, c.Param("clientId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
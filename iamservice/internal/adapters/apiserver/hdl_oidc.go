@@ -0,0 +1,163 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+func getOpenIDConfigurationHandler(uc *usecase.OIDCMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, uc.Discovery())
+	}
+}
+
+// authorizeHandler handles the authorization endpoint. Users without a valid session for the client's tenant
+// are sent to the web sign-in page, which brings them back here once signed in.
+func authorizeHandler(uc *usecase.OIDCMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		authorizeReq := &usecase.OAuthAuthorizeRequest{
+			ResponseType:        c.QueryParam("response_type"),
+			ClientID:            c.QueryParam("client_id"),
+			RedirectURI:         c.QueryParam("redirect_uri"),
+			Scope:               c.QueryParam("scope"),
+			State:               c.QueryParam("state"),
+			Nonce:               c.QueryParam("nonce"),
+			CodeChallenge:       c.QueryParam("code_challenge"),
+			CodeChallengeMethod: c.QueryParam("code_challenge_method"),
+		}
+
+		client, err := uc.ValidateAuthorizeClient(ctx, authorizeReq)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var accessToken string
+		if accessCookie, err := c.Cookie("access_token"); err == nil {
+			accessToken = accessCookie.Value
+		}
+
+		redirectURL, err := uc.Authorize(ctx, client, authorizeReq, accessToken)
+		if err != nil {
+			var appErr *katapp.Err
+			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrUnauthorized {
+				signInQuery := url.Values{
+					"tenantId": {client.TenantID},
+					"returnTo": {c.Request().URL.RequestURI()},
+				}
+				return c.Redirect(http.StatusFound, "/web/user/auth/signin?"+signInQuery.Encode())
+			}
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.Redirect(http.StatusFound, redirectURL)
+	}
+}
+
+// tokenHandler handles the token endpoint. Requests are form-encoded and errors are reported in
+// the OAuth 2.0 format rather than the regular API error format.
+func tokenHandler(uc *usecase.OIDCMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		c.Response().Header().Set("Cache-Control", "no-store")
+		c.Response().Header().Set("Pragma", "no-cache")
+
+		tokenReq := &usecase.OAuthTokenRequest{
+			GrantType:    c.FormValue("grant_type"),
+			Code:         c.FormValue("code"),
+			RedirectURI:  c.FormValue("redirect_uri"),
+			CodeVerifier: c.FormValue("code_verifier"),
+			RefreshToken: c.FormValue("refresh_token"),
+			ClientID:     c.FormValue("client_id"),
+			ClientSecret: c.FormValue("client_secret"),
+		}
+		// client_secret_basic takes precedence over client_secret_post
+		if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+			tokenReq.ClientID, _ = url.QueryUnescape(clientID)
+			tokenReq.ClientSecret, _ = url.QueryUnescape(clientSecret)
+		}
+
+		tokenResponse, err := uc.Token(ctx, tokenReq)
+		if err != nil {
+			return reportOAuthError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, tokenResponse)
+	}
+}
+
+// introspectTokenHandler handles token introspection requests of resource servers (RFC 7662)
+func introspectTokenHandler(uc *usecase.OIDCMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		c.Response().Header().Set("Cache-Control", "no-store")
+
+		introspectionReq := &usecase.OAuthIntrospectionRequest{
+			Token:         c.FormValue("token"),
+			TokenTypeHint: c.FormValue("token_type_hint"),
+			ClientID:      c.FormValue("client_id"),
+			ClientSecret:  c.FormValue("client_secret"),
+		}
+		// client_secret_basic takes precedence over client_secret_post
+		if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+			introspectionReq.ClientID, _ = url.QueryUnescape(clientID)
+			introspectionReq.ClientSecret, _ = url.QueryUnescape(clientSecret)
+		}
+
+		introspection, err := uc.IntrospectToken(ctx, introspectionReq)
+		if err != nil {
+			return reportOAuthError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, introspection)
+	}
+}
+
+func getUserInfoHandler(uc *usecase.OIDCMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		userInfo, err := uc.UserInfo(ctx, principal)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, userInfo)
+	}
+}
+
+// reportOAuthError writes an OAuth 2.0 error response (RFC 6749 section 5.2)
+func reportOAuthError(c echo.Context, err error) error {
+	var oauthErr *usecase.OAuthError
+	if !errors.As(err, &oauthErr) {
+		oauthErr = &usecase.OAuthError{Code: usecase.OAuthErrServerError, Description: err.Error()}
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case usecase.OAuthErrInvalidClient:
+		status = http.StatusUnauthorized
+		if _, _, ok := c.Request().BasicAuth(); ok {
+			c.Response().Header().Set("WWW-Authenticate", `Basic realm="iamservice"`)
+		}
+	case usecase.OAuthErrServerError:
+		status = http.StatusInternalServerError
+	}
+
+	return c.JSON(status, swagger.NewOAuthErrorResponseBuilder().
+		Error(oauthErr.Code).
+		ErrorDescription(oauthErr.Description).
+		Build())
+}
@@ -0,0 +1,71 @@
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
+)
+
+// listFailedOutboxEmailsHandler handles GET /api/v1/outbox/emails
+func listFailedOutboxEmailsHandler(uc *usecase.OutboxMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		// Parse pagination parameters
+		page := 1
+		if pageStr := c.QueryParam("page"); pageStr != "" {
+			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+				page = p
+			}
+		}
+
+		limit := 20
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+
+		var status *swagger.ListFailedOutboxEmailsParamsStatus
+		if statusStr := c.QueryParam("status"); statusStr != "" {
+			status = lo.ToPtr(swagger.ListFailedOutboxEmailsParamsStatus(statusStr))
+		}
+
+		params := swagger.NewListFailedOutboxEmailsParamsBuilder().
+			Page(&page).
+			Limit(&limit).
+			Status(status).
+			Build()
+		if emails, err := uc.ListFailedOutboxEmails(ctx, principal, params); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, emails)
+		}
+	}
+}
+
+// retryOutboxEmailHandler handles POST /api/v1/outbox/emails/{emailId}/retry
+func retryOutboxEmailHandler(uc *usecase.OutboxMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.RetryOutboxEmail(ctx, principal, c.Param("emailId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
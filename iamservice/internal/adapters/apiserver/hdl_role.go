@@ -0,0 +1,121 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+// listPermissionsHandler handles GET /api/v1/permissions
+func listPermissionsHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		permissions, err := uc.ListPermissions(ctx, principal)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, permissions)
+	}
+}
+
+// listRolesHandler handles GET /api/v1/tenants/{tenantId}/roles
+func listRolesHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		roles, err := uc.ListRoles(ctx, principal, c.Param("tenantId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, roles)
+	}
+}
+
+// getRoleHandler handles GET /api/v1/tenants/{tenantId}/roles/{roleName}
+func getRoleHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		role, err := uc.GetRole(ctx, principal, c.Param("tenantId"), c.Param("roleName"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, role)
+	}
+}
+
+// createRoleHandler handles POST /api/v1/tenants/{tenantId}/roles
+func createRoleHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.CreateRoleRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		role, err := uc.CreateRole(ctx, principal, c.Param("tenantId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, role)
+	}
+}
+
+// updateRoleHandler handles PUT /api/v1/tenants/{tenantId}/roles/{roleName}
+func updateRoleHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.UpdateRoleRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		role, err := uc.UpdateRole(ctx, principal, c.Param("tenantId"), c.Param("roleName"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, role)
+	}
+}
+
+// deleteRoleHandler handles DELETE /api/v1/tenants/{tenantId}/roles/{roleName}
+func deleteRoleHandler(uc *usecase.RoleMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteRole(ctx, principal, c.Param("tenantId"), c.Param("roleName")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
@@ -0,0 +1,363 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
+)
+
+// scimContentType is the media type of SCIM requests and responses
+const scimContentType = "application/scim+json"
+
+// maxScimRequestSize limits the size of SCIM request bodies
+const maxScimRequestSize = 1 << 20
+
+// scimErrorMiddleware reports errors of SCIM routes, authentication failures included, as SCIM error responses
+func scimErrorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if err == nil || c.Response().Committed {
+			return err
+		}
+
+		var status int
+		var detail string
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+			switch msg := httpErr.Message.(type) {
+			case *kathttp.ErrResponse:
+				detail = lo.CoalesceOrEmpty(msg.ErrorText, msg.StatusText)
+			case string:
+				detail = msg
+			default:
+				detail = http.StatusText(status)
+			}
+		} else {
+			errResp := kathttp.GuessHTTPError(err)
+			status = errResp.HTTPStatusCode
+			detail = errResp.ErrorText
+		}
+		if status >= http.StatusInternalServerError {
+			katapp.Logger(c.Request().Context()).Error("SCIM request failed", "error", err)
+			detail = "internal server error"
+		}
+		var scimType *string
+		if status == http.StatusConflict {
+			scimType = lo.ToPtr("uniqueness")
+		}
+		return scimResponse(c, status, swagger.NewScimErrorBuilder().
+			Detail(&detail).
+			Schemas([]string{usecase.ScimMessageError}).
+			ScimType(scimType).
+			Status(strconv.Itoa(status)).
+			Build())
+	}
+}
+
+// scimResponse writes a SCIM response, c.JSON cannot be used as it sets the application/json content type
+func scimResponse(c echo.Context, status int, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, scimContentType, data)
+}
+
+// bindScimBody decodes a SCIM request body, echo binding does not accept the application/scim+json content type
+func bindScimBody(c echo.Context, req any) error {
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxScimRequestSize)
+	if err := json.NewDecoder(body).Decode(req); err != nil {
+		return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+	}
+	return nil
+}
+
+// scimIntQueryParam parses an optional integer query parameter
+func scimIntQueryParam(c echo.Context, name string) (*int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, kathttp_echo.ReportBadRequest(fmt.Errorf("invalid %s, integer expected", name))
+	}
+	return &n, nil
+}
+
+// getScimServiceProviderConfigHandler handles GET /scim/v2/ServiceProviderConfig
+func getScimServiceProviderConfigHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return scimResponse(c, http.StatusOK, uc.ServiceProviderConfig())
+	}
+}
+
+// listScimUsersHandler handles GET /scim/v2/Users
+func listScimUsersHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		startIndex, err := scimIntQueryParam(c, "startIndex")
+		if err != nil {
+			return err
+		}
+		count, err := scimIntQueryParam(c, "count")
+		if err != nil {
+			return err
+		}
+
+		params := swagger.NewListScimUsersParamsBuilder().
+			Filter(lo.EmptyableToPtr(c.QueryParam("filter"))).
+			StartIndex(startIndex).
+			Count(count).
+			Build()
+		users, err := uc.ListUsers(ctx, principal, params)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, users)
+	}
+}
+
+// createScimUserHandler handles POST /scim/v2/Users
+func createScimUserHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimUser
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		user, err := uc.CreateUser(ctx, principal, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		c.Response().Header().Set(echo.HeaderLocation, user.Meta.Location)
+		return scimResponse(c, http.StatusCreated, user)
+	}
+}
+
+// getScimUserHandler handles GET /scim/v2/Users/{userId}
+func getScimUserHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		user, err := uc.GetUser(ctx, principal, c.Param("userId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, user)
+	}
+}
+
+// replaceScimUserHandler handles PUT /scim/v2/Users/{userId}
+func replaceScimUserHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimUser
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		user, err := uc.ReplaceUser(ctx, principal, c.Param("userId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, user)
+	}
+}
+
+// patchScimUserHandler handles PATCH /scim/v2/Users/{userId}
+func patchScimUserHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimPatchRequest
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		user, err := uc.PatchUser(ctx, principal, c.Param("userId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, user)
+	}
+}
+
+// deleteScimUserHandler handles DELETE /scim/v2/Users/{userId}
+func deleteScimUserHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteUser(ctx, principal, c.Param("userId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// listScimGroupsHandler handles GET /scim/v2/Groups
+func listScimGroupsHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		startIndex, err := scimIntQueryParam(c, "startIndex")
+		if err != nil {
+			return err
+		}
+		count, err := scimIntQueryParam(c, "count")
+		if err != nil {
+			return err
+		}
+
+		params := swagger.NewListScimGroupsParamsBuilder().
+			Filter(lo.EmptyableToPtr(c.QueryParam("filter"))).
+			StartIndex(startIndex).
+			Count(count).
+			ExcludedAttributes(lo.EmptyableToPtr(c.QueryParam("excludedAttributes"))).
+			Build()
+		groups, err := uc.ListGroups(ctx, principal, params)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, groups)
+	}
+}
+
+// createScimGroupHandler handles POST /scim/v2/Groups
+func createScimGroupHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimGroup
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		group, err := uc.CreateGroup(ctx, principal, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		c.Response().Header().Set(echo.HeaderLocation, group.Meta.Location)
+		return scimResponse(c, http.StatusCreated, group)
+	}
+}
+
+// getScimGroupHandler handles GET /scim/v2/Groups/{groupId}
+func getScimGroupHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		params := swagger.NewGetScimGroupParamsBuilder().
+			ExcludedAttributes(lo.EmptyableToPtr(c.QueryParam("excludedAttributes"))).
+			Build()
+		group, err := uc.GetGroup(ctx, principal, c.Param("groupId"), params)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, group)
+	}
+}
+
+// replaceScimGroupHandler handles PUT /scim/v2/Groups/{groupId}
+func replaceScimGroupHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimGroup
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		group, err := uc.ReplaceGroup(ctx, principal, c.Param("groupId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, group)
+	}
+}
+
+// patchScimGroupHandler handles PATCH /scim/v2/Groups/{groupId}
+func patchScimGroupHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.ScimPatchRequest
+		if err := bindScimBody(c, &req); err != nil {
+			return err
+		}
+		group, err := uc.PatchGroup(ctx, principal, c.Param("groupId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return scimResponse(c, http.StatusOK, group)
+	}
+}
+
+// deleteScimGroupHandler handles DELETE /scim/v2/Groups/{groupId}
+func deleteScimGroupHandler(uc *usecase.ScimMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteGroup(ctx, principal, c.Param("groupId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
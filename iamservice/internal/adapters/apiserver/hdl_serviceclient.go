@@ -0,0 +1,96 @@
+package apiserver
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+)
+
+// createServiceClientHandler handles POST /api/v1/service-clients
+func createServiceClientHandler(uc *usecase.ServiceClientMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.CreateServiceClientRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		clientResponse, err := uc.CreateServiceClient(ctx, principal, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, clientResponse)
+	}
+}
+
+// listServiceClientsHandler handles GET /api/v1/service-clients
+func listServiceClientsHandler(uc *usecase.ServiceClientMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		clients, err := uc.ListServiceClients(ctx, principal, c.QueryParam("tenantId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, clients)
+	}
+}
+
+// deleteServiceClientHandler handles DELETE /api/v1/service-clients/{clientId}
+func deleteServiceClientHandler(uc *usecase.ServiceClientMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteServiceClient(ctx, principal, c.Param("clientId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// clientCredentialsTokenHandler handles the client_credentials grant of service clients. Requests are
+// form-encoded and errors are reported in the OAuth 2.0 format rather than the regular API error format.
+func clientCredentialsTokenHandler(uc *usecase.ServiceClientMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		c.Response().Header().Set("Cache-Control", "no-store")
+		c.Response().Header().Set("Pragma", "no-cache")
+
+		tokenReq := &usecase.ClientCredentialsTokenRequest{
+			GrantType:    c.FormValue("grant_type"),
+			Scope:        c.FormValue("scope"),
+			ClientID:     c.FormValue("client_id"),
+			ClientSecret: c.FormValue("client_secret"),
+		}
+		// client_secret_basic takes precedence over client_secret_post
+		if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+			tokenReq.ClientID, _ = url.QueryUnescape(clientID)
+			tokenReq.ClientSecret, _ = url.QueryUnescape(clientSecret)
+		}
+
+		tokenResponse, err := uc.IssueToken(ctx, tokenReq)
+		if err != nil {
+			return reportOAuthError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, tokenResponse)
+	}
+}
@@ -3,6 +3,7 @@ package apiserver
 import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
@@ -10,6 +11,7 @@ import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
 	"github.com/mobiletoly/gokatana/katapp"
 	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
 )
 
 // getAllTenantsHandler handles GET /api/v1/tenants
@@ -21,7 +23,30 @@ func getAllTenantsHandler(authMgm *usecase.AuthMgm) echo.HandlerFunc {
 			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		tenantsListResponse, err := authMgm.GetAllTenants(ctx, principal)
+		// Parse pagination parameters
+		page := 1
+		if pageStr := c.QueryParam("page"); pageStr != "" {
+			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+				page = p
+			}
+		}
+
+		limit := 20
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+
+		params := swagger.NewListAllTenantsParamsBuilder().
+			Page(&page).
+			Limit(&limit).
+			Cursor(lo.EmptyableToPtr(c.QueryParam("cursor"))).
+			SortBy((*swagger.TenantSortBy)(lo.EmptyableToPtr(c.QueryParam("sortBy")))).
+			SortOrder((*swagger.SortOrder)(lo.EmptyableToPtr(c.QueryParam("sortOrder")))).
+			Search(lo.EmptyableToPtr(c.QueryParam("search"))).
+			Build()
+		tenantsListResponse, err := authMgm.ListAllTenants(ctx, principal, params)
 		if err != nil {
 			return kathttp_echo.ReportHTTPError(err)
 		}
@@ -120,6 +145,7 @@ func tenantModelToTenantResponse(tenant *model.Tenant) *swagger.TenantResponse {
 		Description(tenant.Description).
 		Id(tenant.ID).
 		Name(tenant.Name).
+		RequireAdminMfa(tenant.RequireAdminMFA).
 		UpdatedAt(tenant.UpdatedAt).
 		Build()
 }
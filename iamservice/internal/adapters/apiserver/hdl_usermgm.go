@@ -2,14 +2,18 @@ package apiserver
 
 import (
 	"errors"
+	"fmt"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"mime"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
 	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
 )
 
 // getMyUserHandler handles getting current user profile
@@ -75,7 +79,7 @@ func updateAuthUserHandler(uc *usecase.UserMgm) func(c echo.Context) error {
 	}
 }
 
-// listAllUsersByTenantHandler handles listing all tenant users with pagination
+// listAllUsersByTenantHandler handles listing tenant users with filters, sorting and pagination
 func listAllUsersByTenantHandler(uc *usecase.UserMgm) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -84,22 +88,33 @@ func listAllUsersByTenantHandler(uc *usecase.UserMgm) func(c echo.Context) error
 			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		// Parse pagination parameters
-		page := 1
-		if pageStr := c.QueryParam("page"); pageStr != "" {
-			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-				page = p
-			}
+		params, err := parseListUsersParams(c)
+		if err != nil {
+			return kathttp_echo.ReportBadRequest(err)
 		}
+		if userList, err := uc.ListAllUsersByTenant(ctx, principal, principal.TenantID, params); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, userList)
+		}
+	}
+}
 
-		limit := 20
-		if limitStr := c.QueryParam("limit"); limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-				limit = l
-			}
+// listAllUsersHandler handles listing users of all tenants with filters, sorting and pagination (users:read
+// permission in all tenants only)
+func listAllUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		if userList, err := uc.ListAllUsersByTenant(ctx, principal, principal.TenantID, page, limit); err != nil {
+		params, err := parseListUsersParams(c)
+		if err != nil {
+			return kathttp_echo.ReportBadRequest(err)
+		}
+		if userList, err := uc.ListAllUsers(ctx, principal, (*swagger.ListAllUsersParams)(params)); err != nil {
 			return kathttp_echo.ReportHTTPError(err)
 		} else {
 			return c.JSON(http.StatusOK, userList)
@@ -107,8 +122,55 @@ func listAllUsersByTenantHandler(uc *usecase.UserMgm) func(c echo.Context) error
 	}
 }
 
-// listAllUsersHandler handles listing all users with pagination (sysadmin only)
-func listAllUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
+// parseListUsersParams parses query parameters of user listings, sort fields are validated by the use case
+func parseListUsersParams(c echo.Context) (*swagger.ListUsersByTenantParams, error) {
+	// Parse pagination parameters
+	page := 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	limit := 20
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	// Parse status filters
+	var emailVerified, active *bool
+	if verifiedStr := c.QueryParam("emailVerified"); verifiedStr != "" {
+		v, err := strconv.ParseBool(verifiedStr)
+		if err != nil {
+			return nil, errors.New("invalid emailVerified, true or false expected")
+		}
+		emailVerified = &v
+	}
+	if activeStr := c.QueryParam("active"); activeStr != "" {
+		v, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			return nil, errors.New("invalid active, true or false expected")
+		}
+		active = &v
+	}
+
+	return swagger.NewListUsersByTenantParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		Cursor(lo.EmptyableToPtr(c.QueryParam("cursor"))).
+		SortBy((*swagger.UserSortBy)(lo.EmptyableToPtr(c.QueryParam("sortBy")))).
+		SortOrder((*swagger.SortOrder)(lo.EmptyableToPtr(c.QueryParam("sortOrder")))).
+		Search(lo.EmptyableToPtr(c.QueryParam("search"))).
+		Role(lo.EmptyableToPtr(c.QueryParam("role"))).
+		EmailVerified(emailVerified).
+		Active(active).
+		Build(), nil
+}
+
+// searchUsersHandler handles fuzzy search of users by email and names (users:read permission required)
+func searchUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 		principal, err := serverhelp.GetUserPrincipalFromToken(c)
@@ -116,26 +178,96 @@ func listAllUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
 			return kathttp_echo.ReportHTTPError(err)
 		}
 
-		// Parse pagination parameters
-		page := 1
-		if pageStr := c.QueryParam("page"); pageStr != "" {
-			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-				page = p
+		limit := 10
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
+				limit = l
 			}
 		}
+		params := swagger.NewSearchUsersParamsBuilder().
+			Q(c.QueryParam("q")).
+			TenantId(lo.EmptyableToPtr(c.QueryParam("tenantId"))).
+			Limit(&limit).
+			Build()
+		if searchResponse, err := uc.SearchUsers(ctx, principal, params); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, searchResponse)
+		}
+	}
+}
 
-		limit := 20
-		if limitStr := c.QueryParam("limit"); limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-				limit = l
+// importUsersHandler handles importing users of a tenant from CSV or NDJSON request bodies
+func importUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		tenantID := c.Param("tenantId")
+
+		var format swagger.UserTransferFormat
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		switch mediaType {
+		case "text/csv":
+			format = swagger.UserTransferFormatCsv
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			format = swagger.UserTransferFormatNdjson
+		default:
+			return kathttp_echo.ReportBadRequest(errors.New("unsupported content type, text/csv or application/x-ndjson expected"))
+		}
+		var dryRun *bool
+		if dryRunStr := c.QueryParam("dryRun"); dryRunStr != "" {
+			v, err := strconv.ParseBool(dryRunStr)
+			if err != nil {
+				return kathttp_echo.ReportBadRequest(errors.New("invalid dryRun, true or false expected"))
 			}
+			dryRun = &v
 		}
 
-		if userList, err := uc.ListAllUsers(ctx, principal, page, limit); err != nil {
+		params := swagger.NewImportUsersParamsBuilder().
+			DryRun(dryRun).
+			Build()
+		body := http.MaxBytesReader(c.Response(), c.Request().Body, usecase.MaxUserImportSize)
+		if importResponse, err := uc.ImportUsers(ctx, principal, tenantID, format, body, params); err != nil {
 			return kathttp_echo.ReportHTTPError(err)
 		} else {
-			return c.JSON(http.StatusOK, userList)
+			return c.JSON(http.StatusOK, importResponse)
+		}
+	}
+}
+
+// exportUsersHandler handles streaming users of a tenant as CSV or NDJSON
+func exportUsersHandler(uc *usecase.UserMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		tenantID := c.Param("tenantId")
+
+		params := swagger.NewExportUsersParamsBuilder().
+			Format((*swagger.UserTransferFormat)(lo.EmptyableToPtr(c.QueryParam("format")))).
+			Build()
+		contentType, extension := "text/csv; charset=utf-8", "csv"
+		if params.Format != nil && *params.Format == swagger.UserTransferFormatNdjson {
+			contentType, extension = "application/x-ndjson", "ndjson"
 		}
+		// Headers are only sent with the first exported line, errors before it are still reported as such
+		header := c.Response().Header()
+		header.Set(echo.HeaderContentType, contentType)
+		header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="users-%s.%s"`, tenantID, extension))
+		if err := uc.ExportUsers(ctx, principal, tenantID, params, c.Response()); err != nil {
+			if c.Response().Committed {
+				katapp.Logger(ctx).Error("failed to export users", "tenantID", tenantID, "error", err)
+				return nil
+			}
+			header.Del(echo.HeaderContentDisposition)
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return nil
 	}
 }
 
@@ -239,3 +371,193 @@ func updateUserProfileHandler(uc *usecase.UserProfileMgm) func(c echo.Context) e
 		}
 	}
 }
+
+// getUserLockoutHandler handles getting failed sign in attempts and lockout status of a user (admin only)
+func getUserLockoutHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		if lockout, err := uc.GetUserLockout(ctx, principal, userID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, lockout)
+		}
+	}
+}
+
+// unlockUserHandler handles clearing sign in lockout of a user (admin only)
+func unlockUserHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		if err := uc.UnlockUser(ctx, principal, userID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// listUserSessionsHandler handles listing active sessions of a user
+func listUserSessionsHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		if sessions, err := uc.ListUserSessions(ctx, principal, userID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, sessions)
+		}
+	}
+}
+
+// revokeUserSessionsHandler handles signing a user out on all devices
+func revokeUserSessionsHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		if err := uc.RevokeUserSessions(ctx, principal, userID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// revokeUserSessionHandler handles signing a user out on one device
+func revokeUserSessionHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+		sessionID := c.Param("sessionId")
+
+		if err := uc.RevokeUserSession(ctx, principal, userID, sessionID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// listUserApiKeysHandler handles listing API keys of a user
+func listUserApiKeysHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		if apiKeys, err := uc.ListUserAPIKeys(ctx, principal, userID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		} else {
+			return c.JSON(http.StatusOK, apiKeys)
+		}
+	}
+}
+
+// createUserApiKeyHandler handles creating an API key for the current user
+func createUserApiKeyHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		var req swagger.CreateApiKeyRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(errors.New("invalid request body"))
+		}
+		apiKey, err := uc.CreateUserAPIKey(ctx, principal, userID, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, apiKey)
+	}
+}
+
+// revokeUserApiKeyHandler handles revoking an API key of a user
+func revokeUserApiKeyHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+		keyID := c.Param("keyId")
+
+		if err := uc.RevokeUserAPIKey(ctx, principal, userID, keyID); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// requestUserEmailChangeHandler handles requesting a change of the email address of a user
+func requestUserEmailChangeHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		var req swagger.EmailChangeRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(errors.New("invalid request body"))
+		}
+		emailChange, err := uc.RequestEmailChange(ctx, principal, userID, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, emailChange)
+	}
+}
+
+// confirmUserEmailChangeHandler handles confirming a change of the email address of the current user
+func confirmUserEmailChangeHandler(uc *usecase.AuthMgm) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		userID := c.Param("userId")
+
+		var req swagger.ConfirmEmailChangeRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(errors.New("invalid request body"))
+		}
+		user, err := uc.ConfirmEmailChange(ctx, principal, userID, &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, user)
+	}
+}
@@ -0,0 +1,167 @@
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/kathttp_echo"
+	"github.com/samber/lo"
+)
+
+// listWebhooksHandler handles GET /api/v1/tenants/{tenantId}/webhooks
+func listWebhooksHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		webhooks, err := uc.ListWebhooks(ctx, principal, c.Param("tenantId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, webhooks)
+	}
+}
+
+// createWebhookHandler handles POST /api/v1/tenants/{tenantId}/webhooks
+func createWebhookHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.CreateWebhookRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		webhook, err := uc.CreateWebhook(ctx, principal, c.Param("tenantId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, webhook)
+	}
+}
+
+// getWebhookHandler handles GET /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+func getWebhookHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		webhook, err := uc.GetWebhook(ctx, principal, c.Param("tenantId"), c.Param("webhookId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// updateWebhookHandler handles PUT /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+func updateWebhookHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		var req swagger.UpdateWebhookRequest
+		if err := c.Bind(&req); err != nil {
+			return kathttp_echo.ReportBadRequest(katapp.NewErr(katapp.ErrInvalidInput, "invalid request body"))
+		}
+		webhook, err := uc.UpdateWebhook(ctx, principal, c.Param("tenantId"), c.Param("webhookId"), &req)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// deleteWebhookHandler handles DELETE /api/v1/tenants/{tenantId}/webhooks/{webhookId}
+func deleteWebhookHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		if err := uc.DeleteWebhook(ctx, principal, c.Param("tenantId"), c.Param("webhookId")); err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, struct{}{})
+	}
+}
+
+// listWebhookDeliveriesHandler handles GET /api/v1/tenants/{tenantId}/webhooks/{webhookId}/deliveries
+func listWebhookDeliveriesHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		// Parse pagination parameters
+		page := 1
+		if pageStr := c.QueryParam("page"); pageStr != "" {
+			if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+				page = p
+			}
+		}
+
+		limit := 20
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+				limit = l
+			}
+		}
+
+		var status *swagger.ListWebhookDeliveriesParamsStatus
+		if statusStr := c.QueryParam("status"); statusStr != "" {
+			status = lo.ToPtr(swagger.ListWebhookDeliveriesParamsStatus(statusStr))
+		}
+
+		params := swagger.NewListWebhookDeliveriesParamsBuilder().
+			Page(&page).
+			Limit(&limit).
+			Status(status).
+			Build()
+		deliveries, err := uc.ListWebhookDeliveries(ctx, principal, c.Param("tenantId"), c.Param("webhookId"), params)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusOK, deliveries)
+	}
+}
+
+// redeliverWebhookDeliveryHandler handles
+// POST /api/v1/tenants/{tenantId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver
+func redeliverWebhookDeliveryHandler(uc *usecase.WebhookMgm) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := serverhelp.GetUserPrincipalFromToken(c)
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+
+		delivery, err := uc.RedeliverWebhookDelivery(
+			ctx, principal, c.Param("tenantId"), c.Param("webhookId"), c.Param("deliveryId"))
+		if err != nil {
+			return kathttp_echo.ReportHTTPError(err)
+		}
+		return c.JSON(http.StatusCreated, delivery)
+	}
+}
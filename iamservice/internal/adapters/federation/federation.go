@@ -0,0 +1,117 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"golang.org/x/oauth2"
+)
+
+type federationAdapter struct {
+	httpClient *http.Client
+}
+
+func NewFederationClient() outport.FederationClient {
+	return &federationAdapter{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// userInfoClaims is the subset of OIDC standard claims we use from the userinfo endpoint
+type userInfoClaims struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+func (f *federationAdapter) AuthCodeURL(
+	provider *app.IdentityProviderConfig, redirectURI string, state string, codeVerifier string,
+) string {
+	return oauth2Config(provider, redirectURI).AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (f *federationAdapter) ExchangeCode(
+	ctx context.Context, provider *app.IdentityProviderConfig, redirectURI string, code string, codeVerifier string,
+) (*outport.FederatedTokens, error) {
+	katapp.Logger(ctx).Info("exchanging authorization code with identity provider", "provider", provider.ID)
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, f.httpClient)
+	token, err := oauth2Config(provider, redirectURI).Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to exchange authorization code", "provider", provider.ID, "error", err)
+		return nil, katapp.NewErr(katapp.ErrFailedExternalService, "failed to exchange authorization code with identity provider")
+	}
+
+	tokens := outport.NewFederatedTokensBuilder().
+		AccessToken(token.AccessToken).
+		RefreshToken(nil).
+		ExpiresAt(nil).
+		Build()
+	if token.RefreshToken != "" {
+		tokens.RefreshToken = &token.RefreshToken
+	}
+	if !token.Expiry.IsZero() {
+		tokens.ExpiresAt = &token.Expiry
+	}
+	return tokens, nil
+}
+
+func (f *federationAdapter) FetchUserInfo(
+	ctx context.Context, provider *app.IdentityProviderConfig, accessToken string,
+) (*outport.FederatedUserInfo, error) {
+	katapp.Logger(ctx).Info("fetching user info from identity provider", "provider", provider.ID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.UserInfoURL, nil)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to create user info request")
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to fetch user info", "provider", provider.ID, "error", err)
+		return nil, katapp.NewErr(katapp.ErrFailedExternalService, "failed to fetch user info from identity provider")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		katapp.Logger(ctx).Error("identity provider rejected user info request",
+			"provider", provider.ID, "status", resp.StatusCode)
+		return nil, katapp.NewErr(katapp.ErrFailedExternalService, "identity provider rejected user info request")
+	}
+
+	var claims userInfoClaims
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		katapp.Logger(ctx).Error("failed to decode user info", "provider", provider.ID, "error", err)
+		return nil, katapp.NewErr(katapp.ErrFailedExternalService, "invalid user info response from identity provider")
+	}
+
+	return outport.NewFederatedUserInfoBuilder().
+		Subject(claims.Sub).
+		Email(claims.Email).
+		EmailVerified(claims.EmailVerified).
+		GivenName(claims.GivenName).
+		FamilyName(claims.FamilyName).
+		Build(), nil
+}
+
+func oauth2Config(provider *app.IdentityProviderConfig, redirectURI string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  provider.AuthURL,
+			TokenURL: provider.TokenURL,
+		},
+		RedirectURL: redirectURI,
+		Scopes:      provider.Scopes,
+	}
+}
@@ -0,0 +1,30 @@
+package serverhelp
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIPExtractor returns the extractor of the client IP for echo.Context.RealIP. X-Forwarded-For header is
+// only trusted when the request comes from one of the trusted proxy ranges, otherwise a client could spoof its
+// address and evade the sign in throttling. Without trusted proxies the address of the direct peer is used.
+// Ranges must be validated by the caller.
+func ClientIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
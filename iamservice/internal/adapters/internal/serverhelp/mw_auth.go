@@ -10,21 +10,34 @@ import (
 	"github.com/mobiletoly/gokatana/kathttp_echo"
 	"github.com/samber/lo"
 	"net/http"
+	"strings"
 )
 
 type jwtAuthUserClaims struct {
-	Roles    []string `json:"roles"`
-	TenantID string   `json:"tenantId"`
+	Type          string   `json:"type"`
+	PrincipalType string   `json:"principalType"`
+	Roles         []string `json:"roles"`
+	Permissions   []string `json:"permissions"`
+	TenantID      string   `json:"tenantId"`
+	SessionID     string   `json:"sid"`
+	Scope         string   `json:"scope"`
 	jwt.RegisteredClaims
 }
 
+// apiKeyAuthScheme is the Authorization header scheme of personal API keys, e.g. "Authorization: ApiKey iamk_..."
+const apiKeyAuthScheme = "ApiKey "
+
+// apiKeyPrincipalKey is the echo context key of the principal resolved from an API key
+const apiKeyPrincipalKey = "apiKeyPrincipal"
+
 type JWTAuthMiddleware struct {
 	adminJwtConfig *echojwt.Config
+	authMgm        *usecase.AuthMgm
 }
 
-func NewJWTAuthApiServerMiddleware(jwtSecret []byte) JWTAuthMiddleware {
+func NewJWTAuthApiServerMiddleware(jwtKeys *usecase.JWTKeySet, authMgm *usecase.AuthMgm) JWTAuthMiddleware {
 	adminJwtConfig := echojwt.Config{
-		SigningKey: jwtSecret,
+		KeyFunc: jwtKeys.Keyfunc,
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
 			return new(jwtAuthUserClaims)
 		},
@@ -34,12 +47,13 @@ func NewJWTAuthApiServerMiddleware(jwtSecret []byte) JWTAuthMiddleware {
 	}
 	return JWTAuthMiddleware{
 		adminJwtConfig: &adminJwtConfig,
+		authMgm:        authMgm,
 	}
 }
 
-func NewJWTAuthWebServerMiddleware(jwtSecret []byte) *JWTAuthMiddleware {
+func NewJWTAuthWebServerMiddleware(jwtKeys *usecase.JWTKeySet, authMgm *usecase.AuthMgm) *JWTAuthMiddleware {
 	adminJwtConfig := echojwt.Config{
-		SigningKey: jwtSecret,
+		KeyFunc: jwtKeys.Keyfunc,
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
 			return new(jwtAuthUserClaims)
 		},
@@ -50,54 +64,139 @@ func NewJWTAuthWebServerMiddleware(jwtSecret []byte) *JWTAuthMiddleware {
 	}
 	return &JWTAuthMiddleware{
 		adminJwtConfig: &adminJwtConfig,
+		authMgm:        authMgm,
 	}
 }
 
-// protectWithRoles returns a middleware that enforces at least one of the given roles.
-func protectWithRoles(roles ...string) echo.MiddlewareFunc {
+// accessTokenType is the "type" claim of access tokens. Other tokens signed with the same keys (refresh, MFA
+// challenge, ID, invitation and federation state tokens) must not authenticate requests.
+const accessTokenType = "access"
+
+// rejectRevokedTokens returns a middleware that rejects tokens other than access tokens and access tokens on
+// the revocation list. Rejected tokens are reported by the error handler of the JWT config, like any other
+// invalid token.
+func rejectRevokedTokens(
+	authMgm *usecase.AuthMgm, errorHandler func(c echo.Context, err error) error,
+) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			u := c.Get("user") // comes from echo-jwt
-			if u == nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, "missing or invalid token")
-			}
-			token, ok := u.(*jwt.Token)
+			token, ok := c.Get("user").(*jwt.Token)
 			if !ok {
 				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token type")
 			}
 			claims, ok := token.Claims.(*jwtAuthUserClaims)
-			if !ok {
-				return echo.NewHTTPError(http.StatusForbidden, "invalid JWT claims")
+			if !ok || claims.IssuedAt == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid JWT claims")
+			}
+			if claims.Type != accessTokenType {
+				katapp.Logger(c.Request().Context()).Warn("rejected token of other type than access token",
+					"subject", claims.Subject, "type", claims.Type)
+				return errorHandler(c, errors.New("invalid token type"))
+			}
+			err := authMgm.CheckAccessTokenNotRevoked(
+				c.Request().Context(), claims.ID, claims.SessionID, claims.Subject, claims.IssuedAt.Time,
+			)
+			if err != nil {
+				var appErr *katapp.Err
+				if errors.As(err, &appErr) && appErr.Scope == katapp.ErrUnauthorized {
+					return errorHandler(c, err)
+				}
+				return kathttp_echo.ReportHTTPError(err)
+			}
+			return next(c)
+		}
+	}
+}
+
+// authenticateAPIKey returns a middleware that resolves the API key of the Authorization header to the principal
+// of its user. Invalid keys are reported by the error handler of the JWT config, like invalid tokens.
+func authenticateAPIKey(
+	authMgm *usecase.AuthMgm, errorHandler func(c echo.Context, err error) error,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), apiKeyAuthScheme)
+			principal, err := authMgm.AuthenticateAPIKey(c.Request().Context(), strings.TrimSpace(key))
+			if err != nil {
+				var appErr *katapp.Err
+				if errors.As(err, &appErr) && appErr.Scope == katapp.ErrUnauthorized {
+					return errorHandler(c, err)
+				}
+				return kathttp_echo.ReportHTTPError(err)
 			}
-			// allow if any requested role is in the user's claims
-			if len(roles) == 0 {
+			c.Set(apiKeyPrincipalKey, principal)
+			return next(c)
+		}
+	}
+}
+
+// protectWithPermissions returns a middleware that enforces at least one of the given permissions, any
+// authenticated principal is allowed if none are given. Permissions are checked regardless of the tenant,
+// use cases check the tenant of the requested resources.
+func protectWithPermissions(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var principalPermissions []string
+			if principal, ok := c.Get(apiKeyPrincipalKey).(*usecase.UserPrincipal); ok {
+				principalPermissions = principal.Permissions
+			} else {
+				u := c.Get("user") // comes from echo-jwt
+				if u == nil {
+					return echo.NewHTTPError(http.StatusUnauthorized, "missing or invalid token")
+				}
+				token, ok := u.(*jwt.Token)
+				if !ok {
+					return echo.NewHTTPError(http.StatusUnauthorized, "invalid token type")
+				}
+				claims, ok := token.Claims.(*jwtAuthUserClaims)
+				if !ok {
+					return echo.NewHTTPError(http.StatusForbidden, "invalid JWT claims")
+				}
+				principalPermissions = claims.Permissions
+			}
+			// allow if any requested permission is in the principal's claims
+			if len(permissions) == 0 {
 				return next(c)
 			}
-			for _, reqRole := range roles {
-				if lo.Contains(claims.Roles, reqRole) {
+			for _, reqPermission := range permissions {
+				if lo.Contains(principalPermissions, reqPermission) {
 					return next(c)
 				}
 			}
-			return echo.NewHTTPError(http.StatusForbidden, "access denied: insufficient role")
+			return echo.NewHTTPError(http.StatusForbidden, "access denied: insufficient permissions")
 		}
 	}
 }
 
-// WithAnyRole returns a single middleware that first applies JWT parsing
-// then enforces at least one of the given roles.
-func (j JWTAuthMiddleware) WithAnyRole(roles ...string) echo.MiddlewareFunc {
+// WithAnyPermission returns a single middleware that first applies JWT parsing, then rejects tokens other than
+// access tokens and revoked ones, and enforces at least one of the given permissions. Requests with an API key
+// instead of a JWT have the key resolved to its principal, which then has to hold one of the permissions.
+func (j JWTAuthMiddleware) WithAnyPermission(permissions ...string) echo.MiddlewareFunc {
 	jwtMw := echojwt.WithConfig(*j.adminJwtConfig)
-	protect := protectWithRoles(roles...)
+	rejectRevoked := rejectRevokedTokens(j.authMgm, j.adminJwtConfig.ErrorHandler)
+	apiKeyMw := authenticateAPIKey(j.authMgm, j.adminJwtConfig.ErrorHandler)
+	protect := protectWithPermissions(permissions...)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		// First enforce the roles after JWT has run
-		handler := protect(next)
+		// First enforce the permissions after JWT has run and the token is known not to be revoked
+		handler := rejectRevoked(protect(next))
 		// Then apply JWT parsing so that c.Get("user") is populated before protect
-		return jwtMw(handler)
+		jwtHandler := jwtMw(handler)
+		apiKeyHandler := apiKeyMw(protect(next))
+		return func(c echo.Context) error {
+			if strings.HasPrefix(c.Request().Header.Get(echo.HeaderAuthorization), apiKeyAuthScheme) {
+				return apiKeyHandler(c)
+			}
+			return jwtHandler(c)
+		}
 	}
 }
 
-// GetUserPrincipalFromToken extracts UserPrincipal from JWT token
+// GetUserPrincipalFromToken extracts UserPrincipal from JWT token, or returns the principal of the API key
 func GetUserPrincipalFromToken(c echo.Context) (*usecase.UserPrincipal, error) {
+	if principal, ok := c.Get(apiKeyPrincipalKey).(*usecase.UserPrincipal); ok {
+		return principal, nil
+	}
+
 	token, ok := c.Get("user").(*jwt.Token)
 	if !ok || token == nil {
 		msg := "failed to get user principal from token: missing token"
@@ -111,8 +210,13 @@ func GetUserPrincipalFromToken(c echo.Context) (*usecase.UserPrincipal, error) {
 		katapp.Logger(c.Request().Context()).Error(msg)
 		return nil, kathttp_echo.ReportUnauthorized(errors.New(msg))
 	}
+	if claims.Type != accessTokenType {
+		msg := "failed to get user principal from token: not an access token"
+		katapp.Logger(c.Request().Context()).Error(msg, "type", claims.Type)
+		return nil, kathttp_echo.ReportUnauthorized(errors.New(msg))
+	}
 
-	// Extract user ID from Subject claim
+	// Extract user ID (client ID for service clients) from Subject claim
 	userID := claims.Subject
 	if userID == "" {
 		msg := "failed to get user principal from token: missing user ID"
@@ -123,10 +227,21 @@ func GetUserPrincipalFromToken(c echo.Context) (*usecase.UserPrincipal, error) {
 	// Extract email from Issuer claim (if available) or leave empty
 	email := claims.Issuer // This might need to be adjusted based on your JWT structure
 
+	// Tokens issued before principal types were introduced belong to users
+	principalType := claims.PrincipalType
+	if principalType == "" {
+		principalType = usecase.PrincipalTypeUser
+	}
+
 	return &usecase.UserPrincipal{
-		UserID:   userID,
-		TenantID: claims.TenantID,
-		Email:    email,
-		Roles:    claims.Roles,
+		UserID:      userID,
+		TenantID:    claims.TenantID,
+		Email:       email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		SessionID:   claims.SessionID,
+		TokenID:     claims.ID,
+		Type:        principalType,
+		Scopes:      strings.Fields(claims.Scope),
 	}, nil
 }
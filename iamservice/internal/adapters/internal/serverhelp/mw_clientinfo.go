@@ -0,0 +1,22 @@
+package serverhelp
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+)
+
+// ClientInfoMiddleware stores the client IP and user agent of the request in the request context,
+// so that use cases can record them with audit events
+func ClientInfoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := usecase.WithClientInfo(req.Context(), usecase.ClientInfo{
+				IPAddress: c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
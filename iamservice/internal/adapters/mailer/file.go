@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+// fileMailer appends emails to test-emails/emails.json instead of sending them, integration tests read them back
+type fileMailer struct{}
+
+// SendEmail saves email content to a file for testing purposes
+func (m *fileMailer) SendEmail(ctx context.Context, to string, content *outport.MailContent) error {
+	katapp.Logger(ctx).Info("mock mode: saving email to file", "to", to, "subject", content.Title)
+
+	mockEmail, err := newMockEmail(to, content)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to build email message", "error", err)
+		return err
+	}
+
+	// Create test-emails directory if it doesn't exist
+	emailDir := "test-emails"
+	if err := os.MkdirAll(emailDir, 0755); err != nil {
+		katapp.Logger(ctx).Error("failed to create test-emails directory", "error", err, "dir", emailDir)
+		return err
+	}
+
+	// Save to test-emails.json (append mode)
+	emailFile := emailDir + "/emails.json"
+	file, err := os.OpenFile(emailFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to open test emails file", "error", err, "file", emailFile)
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	if err := encoder.Encode(mockEmail); err != nil {
+		katapp.Logger(ctx).Error("failed to encode mock email", "error", err, "file", emailFile)
+		return err
+	}
+
+	katapp.Logger(ctx).Info("mock email saved successfully", "to", to, "file", emailFile)
+	return nil
+}
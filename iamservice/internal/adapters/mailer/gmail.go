@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+// gmailMailer sends emails with Gmail API on behalf of a Workspace user impersonated by a service account
+type gmailMailer struct {
+	cfg *app.GCloudConfig
+	srv *gmail.Service
+}
+
+func newGmailMailer(ctx context.Context, cfg *app.GCloudConfig) *gmailMailer {
+	// Read the service account JSON key payload
+	jsonConfig := ([]byte)(cfg.ServiceJson)
+	jwtCfg, err := google.JWTConfigFromJSON(jsonConfig, gmail.GmailSendScope)
+	if err != nil {
+		katapp.Logger(ctx).Fatalf("failed to create JWT config from JSON: %v", err)
+	}
+
+	// Impersonate the target user in your Workspace domain
+	jwtCfg.Subject = cfg.Email.User
+	client := jwtCfg.Client(ctx)
+
+	// Create the Gmail service
+	srv, err := gmail.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		katapp.Logger(ctx).Fatalf("failed to create JWT config from JSON: %v", err)
+	}
+
+	return &gmailMailer{
+		cfg: cfg,
+		srv: srv,
+	}
+}
+
+func (m *gmailMailer) SendEmail(ctx context.Context, to string, content *outport.MailContent) error {
+	katapp.Logger(ctx).Info("sending email", "to", to, "title", content.Title)
+
+	message, err := buildMessage(m.cfg.Email.From, to, content)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to build email message", "error", err)
+		return err
+	}
+	msg := &gmail.Message{
+		Raw: base64.URLEncoding.EncodeToString(message),
+	}
+
+	if _, err := m.srv.Users.Messages.Send("me", msg).Do(); err != nil {
+		katapp.Logger(ctx).Error("failed to send email", "error", err)
+		return err
+	}
+	katapp.Logger(ctx).Info("email sent successfully", "to", to)
+	return nil
+}
@@ -2,131 +2,67 @@ package mailer
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
-	"fmt"
-	"os"
 	"time"
 
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
-	"github.com/mobiletoly/gokatana/katapp"
-	"golang.org/x/oauth2/google"
-	"google.golang.org/api/gmail/v1"
-	"google.golang.org/api/option"
+	"github.com/samber/lo"
 )
 
-type mailerAdapter struct {
-	cfg *app.GCloudConfig
-	srv *gmail.Service
-}
-
 // MockEmail represents an email that was sent during testing
 type MockEmail struct {
-	To          string    `json:"to"`
-	Subject     string    `json:"subject"`
-	Body        string    `json:"body"`
-	ContentType string    `json:"contentType"`
-	SentAt      time.Time `json:"sentAt"`
+	To          string            `json:"to"`
+	Subject     string            `json:"subject"`
+	Body        string            `json:"body"`        // HTML body, or text body of emails without HTML
+	ContentType string            `json:"contentType"` // content type of Body
+	TextBody    string            `json:"textBody"`
+	ReplyTo     string            `json:"replyTo,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Attachments []string          `json:"attachments,omitempty"` // file names
+	Raw         string            `json:"raw"`                   // complete MIME message as it would be sent
+	SentAt      time.Time         `json:"sentAt"`
 }
 
-func NewMailer(ctx context.Context, cfg *app.GCloudConfig) outport.Mailer {
-	if cfg.Mock {
-		return &mailerAdapter{
-			cfg: cfg,
-			srv: nil, // No Gmail service needed in mock mode
-		}
-	}
-
-	// Read the service account JSON key payload
-	jsonConfig := ([]byte)(cfg.ServiceJson)
-	jwtCfg, err := google.JWTConfigFromJSON(jsonConfig, gmail.GmailSendScope)
-	if err != nil {
-		katapp.Logger(ctx).Fatalf("failed to create JWT config from JSON: %v", err)
-	}
-
-	// Impersonate the target user in your Workspace domain
-	jwtCfg.Subject = cfg.Email.User
-	client := jwtCfg.Client(ctx)
-
-	// Create the Gmail service
-	srv, err := gmail.NewService(ctx, option.WithHTTPClient(client))
-	if err != nil {
-		katapp.Logger(ctx).Fatalf("failed to create JWT config from JSON: %v", err)
-	}
-
-	return &mailerAdapter{
-		cfg: cfg,
-		srv: srv,
+// NewMailer creates the mailer of the configured provider, gcloudCfg is used by the Gmail provider only
+func NewMailer(ctx context.Context, cfg *app.MailerConfig, gcloudCfg *app.GCloudConfig) outport.Mailer {
+	switch cfg.Provider {
+	case app.MailerProviderSMTP:
+		return newSMTPMailer(&cfg.SMTP)
+	case app.MailerProviderFile:
+		return &fileMailer{}
+	case app.MailerProviderMemory:
+		return NewMemoryMailer()
+	default:
+		return newGmailMailer(ctx, gcloudCfg)
 	}
 }
 
-func (m *mailerAdapter) SendEmail(ctx context.Context, to string, content *outport.MailContent) error {
-	katapp.Logger(ctx).Info("sending email", "to", to, "title", content.Title)
-
-	rawMessage := fmt.Sprintf(
-		"From: %s\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: %s; charset=\"UTF-8\"\r\n\r\n%s",
-		m.cfg.Email.From,
-		to,
-		content.Title,
-		content.ContentType,
-		content.Body,
-	)
-
-	msg := &gmail.Message{
-		Raw: base64.URLEncoding.EncodeToString([]byte(rawMessage)),
-	}
-
-	if m.cfg.Mock {
-		return m.saveMockEmail(ctx, to, content)
-	}
+// mockFrom is the sender of emails that are kept instead of sent
+const mockFrom = "noreply@iamservice.local"
 
-	if _, err := m.srv.Users.Messages.Send("me", msg).Do(); err != nil {
-		katapp.Logger(ctx).Error("failed to send email", "error", err)
-		return err
+// newMockEmail builds the message the way real mailers do, so that tests see the MIME structure of sent emails
+func newMockEmail(to string, content *outport.MailContent) (*MockEmail, error) {
+	raw, err := buildMessage(mockFrom, to, content)
+	if err != nil {
+		return nil, err
 	}
-	katapp.Logger(ctx).Info("email sent successfully", "to", to)
-	return nil
-}
-
-// saveMockEmail saves email content to a file for testing purposes
-func (m *mailerAdapter) saveMockEmail(ctx context.Context, to string, content *outport.MailContent) error {
-	katapp.Logger(ctx).Info("mock mode: saving email to file", "to", to, "subject", content.Title)
-
-	mockEmail := MockEmail{
+	mockEmail := &MockEmail{
 		To:          to,
 		Subject:     content.Title,
-		Body:        content.Body,
-		ContentType: content.ContentType,
+		Body:        content.HTMLBody,
+		ContentType: "text/html",
+		TextBody:    content.TextBody,
+		ReplyTo:     lo.FromPtr(content.ReplyTo),
+		Headers:     content.Headers,
+		Raw:         string(raw),
 		SentAt:      time.Now(),
 	}
-
-	// Create test-emails directory if it doesn't exist
-	emailDir := "test-emails"
-	if err := os.MkdirAll(emailDir, 0755); err != nil {
-		katapp.Logger(ctx).Error("failed to create test-emails directory", "error", err, "dir", emailDir)
-		return err
-	}
-
-	// Save to test-emails.json (append mode)
-	emailFile := emailDir + "/emails.json"
-	file, err := os.OpenFile(emailFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		katapp.Logger(ctx).Error("failed to open test emails file", "error", err, "file", emailFile)
-		return err
+	if content.HTMLBody == "" {
+		mockEmail.Body = content.TextBody
+		mockEmail.ContentType = "text/plain"
 	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(mockEmail); err != nil {
-		katapp.Logger(ctx).Error("failed to encode mock email", "error", err, "file", emailFile)
-		return err
+	for _, attachment := range content.Attachments {
+		mockEmail.Attachments = append(mockEmail.Attachments, attachment.Filename)
 	}
-
-	katapp.Logger(ctx).Info("mock email saved successfully", "to", to, "file", emailFile)
-	return nil
+	return mockEmail, nil
 }
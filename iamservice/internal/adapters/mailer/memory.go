@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+// MemoryMailer keeps emails in process instead of sending them, for local development without a mail provider
+type MemoryMailer struct {
+	mu     sync.Mutex
+	emails []MockEmail
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) SendEmail(ctx context.Context, to string, content *outport.MailContent) error {
+	katapp.Logger(ctx).Info("memory mode: keeping email", "to", to, "subject", content.Title)
+
+	mockEmail, err := newMockEmail(to, content)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to build email message", "error", err)
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emails = append(m.emails, *mockEmail)
+	return nil
+}
+
+// SentEmails returns the emails sent so far, oldest first
+func (m *MemoryMailer) SentEmails() []MockEmail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.emails)
+}
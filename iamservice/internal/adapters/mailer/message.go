@@ -0,0 +1,200 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+)
+
+// base64LineLength is the line length of base64 encoded attachments, as recommended by RFC 2045
+const base64LineLength = 76
+
+// reservedHeaders are set by buildMessage and cannot be overridden by additional headers of the content
+var reservedHeaders = []string{"Bcc", "Cc", "Date", "From", "Message-Id", "Mime-Version", "Reply-To", "Subject", "To"}
+
+// mimeEntity is a MIME header with its encoded content
+type mimeEntity struct {
+	header  textproto.MIMEHeader
+	content []byte
+}
+
+// buildMessage builds the raw message of an email, shared by all mailers. An email with both bodies is sent as
+// multipart/alternative, and attachments wrap the body in multipart/mixed. Text parts are quoted-printable
+// encoded, so that long lines of rendered templates do not exceed the line length limit of SMTP, and non-ASCII
+// header values are RFC 2047 encoded.
+func buildMessage(from string, to string, content *outport.MailContent) ([]byte, error) {
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", to)
+	if content.ReplyTo != nil {
+		replyTo, err := mail.ParseAddress(*content.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Reply-To address: %w", err)
+		}
+		header.Set("Reply-To", replyTo.String())
+	}
+	header.Set("Subject", mime.QEncoding.Encode("UTF-8", content.Title))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header["Message-ID"] = []string{fmt.Sprintf("<%s@%s>", uuid.NewString(), messageIDDomain(from))}
+	header["MIME-Version"] = []string{"1.0"}
+	for name, value := range content.Headers {
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if err := validateHeader(name, value); err != nil {
+			return nil, err
+		}
+		header.Set(name, mime.QEncoding.Encode("UTF-8", value))
+	}
+
+	body, err := bodyEntity(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(content.Attachments) > 0 {
+		parts := []mimeEntity{body}
+		for _, attachment := range content.Attachments {
+			parts = append(parts, attachmentEntity(&attachment))
+		}
+		if body, err = multipartEntity("mixed", parts); err != nil {
+			return nil, err
+		}
+	}
+	for name, values := range body.header {
+		header[name] = values
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, header)
+	buf.Write(body.content)
+	return buf.Bytes(), nil
+}
+
+// bodyEntity returns the HTML body with its plain-text alternative, or the only body that is set
+func bodyEntity(content *outport.MailContent) (mimeEntity, error) {
+	switch {
+	case content.HTMLBody != "" && content.TextBody != "":
+		// Clients show the last alternative they support, so the preferred HTML body comes last
+		return multipartEntity("alternative", []mimeEntity{
+			textEntity("text/plain", content.TextBody),
+			textEntity("text/html", content.HTMLBody),
+		})
+	case content.HTMLBody != "":
+		return textEntity("text/html", content.HTMLBody), nil
+	case content.TextBody != "":
+		return textEntity("text/plain", content.TextBody), nil
+	default:
+		return mimeEntity{}, errors.New("email has neither HTML nor text body")
+	}
+}
+
+func textEntity(mediaType string, text string) mimeEntity {
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	_, _ = w.Write([]byte(text))
+	_ = w.Close()
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(mediaType, map[string]string{"charset": "UTF-8"})},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		content: buf.Bytes(),
+	}
+}
+
+func attachmentEntity(attachment *model.MailAttachment) mimeEntity {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	var buf bytes.Buffer
+	for len(encoded) > base64LineLength {
+		buf.WriteString(encoded[:base64LineLength] + "\r\n")
+		encoded = encoded[base64LineLength:]
+	}
+	buf.WriteString(encoded)
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType(contentType, map[string]string{"name": attachment.Filename})},
+			"Content-Disposition": {
+				mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+			},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		content: buf.Bytes(),
+	}
+}
+
+func multipartEntity(subtype string, parts []mimeEntity) (mimeEntity, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		pw, err := w.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if _, err := pw.Write(part.content); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()})},
+		},
+		content: buf.Bytes(),
+	}, nil
+}
+
+// validateHeader rejects additional headers that would override the headers of the message or inject new ones
+func validateHeader(name string, value string) error {
+	if slices.Contains(reservedHeaders, name) || strings.HasPrefix(name, "Content-") {
+		return fmt.Errorf("header %s cannot be set by mail content", name)
+	}
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool { return r <= ' ' || r > '~' || r == ':' }) {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("header %s must not contain line breaks", name)
+	}
+	return nil
+}
+
+// writeHeader writes the header in a stable order, followed by the empty line that separates it from the body
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+// messageIDDomain returns the domain of the sender, Message-ID is expected to be unique within it
+func messageIDDomain(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		if _, domain, ok := strings.Cut(address.Address, "@"); ok {
+			return domain
+		}
+	}
+	return "localhost"
+}
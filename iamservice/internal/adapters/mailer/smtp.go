@@ -0,0 +1,197 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+const (
+	smtpDialTimeout        = 10 * time.Second
+	smtpTransactionTimeout = 30 * time.Second
+	smtpDefaultIdleTimeout = 30 * time.Second
+)
+
+// smtpMailer sends emails through an SMTP relay. Emails are sent one at a time over a single connection, which
+// is reused until it has been idle for the configured idle timeout.
+type smtpMailer struct {
+	cfg *app.SMTPConfig
+
+	mu        sync.Mutex
+	conn      net.Conn
+	client    *smtp.Client
+	lastUsed  time.Time
+	idleTimer *time.Timer
+}
+
+func newSMTPMailer(cfg *app.SMTPConfig) *smtpMailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+func (m *smtpMailer) SendEmail(ctx context.Context, to string, content *outport.MailContent) error {
+	katapp.Logger(ctx).Info("sending email", "to", to, "title", content.Title)
+	message, err := buildMessage(m.cfg.From, to, content)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to build email message", "error", err)
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.send(to, message); err != nil {
+		// State of the connection is unknown after a failure, next email starts with a new one
+		m.closeConnection()
+		katapp.Logger(ctx).Error("failed to send email", "error", err)
+		return err
+	}
+
+	m.lastUsed = time.Now()
+	if m.idleTimer == nil {
+		m.idleTimer = time.AfterFunc(m.idleTimeout(), m.closeIdleConnection)
+	} else {
+		m.idleTimer.Reset(m.idleTimeout())
+	}
+	katapp.Logger(ctx).Info("email sent successfully", "to", to)
+	return nil
+}
+
+func (m *smtpMailer) send(to string, message []byte) error {
+	client, err := m.connection()
+	if err != nil {
+		return err
+	}
+	if err := m.conn.SetDeadline(time.Now().Add(smtpTransactionTimeout)); err != nil {
+		return err
+	}
+	if err := client.Mail(m.cfg.From); err != nil {
+		return fmt.Errorf("SMTP MAIL command failed: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("SMTP RCPT command failed: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA command failed: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("failed to write SMTP message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP message was not accepted: %w", err)
+	}
+	return nil
+}
+
+// connection returns the open connection if the relay still responds, or opens a new one
+func (m *smtpMailer) connection() (*smtp.Client, error) {
+	if m.client != nil {
+		err := m.conn.SetDeadline(time.Now().Add(smtpDialTimeout))
+		if err == nil {
+			err = m.client.Noop()
+		}
+		if err == nil {
+			return m.client, nil
+		}
+		// Relay may have closed the idle connection on its side
+		m.closeConnection()
+	}
+	return m.dial()
+}
+
+func (m *smtpMailer) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if m.cfg.TLS == app.SMTPTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(smtpTransactionTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session with %s: %w", addr, err)
+	}
+	if err := m.secureAndAuthenticate(client, tlsConfig); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	m.conn = conn
+	m.client = client
+	return client, nil
+}
+
+func (m *smtpMailer) secureAndAuthenticate(client *smtp.Client, tlsConfig *tls.Config) error {
+	if m.cfg.TLS == "" || m.cfg.TLS == app.SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+		}
+	}
+	if m.cfg.Username == "" {
+		return nil
+	}
+	if ok, _ := client.Extension("AUTH"); !ok {
+		return errors.New("SMTP server does not support authentication")
+	}
+	// PlainAuth refuses to send credentials over a connection without TLS unless the relay is on localhost
+	auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	if err := client.Auth(auth); err != nil {
+		return fmt.Errorf("SMTP authentication failed: %w", err)
+	}
+	return nil
+}
+
+func (m *smtpMailer) idleTimeout() time.Duration {
+	if m.cfg.IdleTimeout > 0 {
+		return m.cfg.IdleTimeout
+	}
+	return smtpDefaultIdleTimeout
+}
+
+// closeIdleConnection is run by the idle timer, the connection may have been used again in the meantime
+func (m *smtpMailer) closeIdleConnection() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.client != nil && time.Since(m.lastUsed) >= m.idleTimeout() {
+		m.closeConnection()
+	}
+}
+
+func (m *smtpMailer) closeConnection() {
+	if m.client == nil {
+		return
+	}
+	_ = m.conn.SetDeadline(time.Now().Add(smtpDialTimeout))
+	if err := m.client.Quit(); err != nil {
+		_ = m.client.Close()
+	}
+	m.client = nil
+	m.conn = nil
+}
@@ -0,0 +1,89 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// APIKeyAdapter implements the outport.APIKeyPersist outport interface
+type APIKeyAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewAPIKeyAdapter(db *katpg.DBLink) outport.APIKeyPersist {
+	return &APIKeyAdapter{db: db}
+}
+
+func (a *APIKeyAdapter) CreateAPIKey(ctx context.Context, tx pgx.Tx, key *model.APIKey) error {
+	katapp.Logger(ctx).Info("creating api key", "keyID", key.ID, "userID", key.UserID)
+
+	entity := mapper.APIKeyModelToAPIKeyEntity(key)
+	if err := repo.InsertAPIKey(ctx, tx, entity); err != nil {
+		msg := "failed to create api key"
+		katapp.Logger(ctx).Error(msg, "keyID", key.ID, "userID", key.UserID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *APIKeyAdapter) GetAPIKeysByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]*model.APIKey, error) {
+	katapp.Logger(ctx).Debug("getting api keys by user ID", "userID", userID)
+
+	keyEntities, err := repo.SelectAPIKeysByUserID(ctx, tx, userID)
+	if err != nil {
+		msg := "failed to get api keys by user ID"
+		katapp.Logger(ctx).Error(msg, "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	keys := make([]*model.APIKey, len(keyEntities))
+	for i := range keyEntities {
+		keys[i] = mapper.APIKeyEntityToAPIKeyModel(&keyEntities[i])
+	}
+	return keys, nil
+}
+
+func (a *APIKeyAdapter) GetAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*model.APIKey, error) {
+	keyEntity, err := repo.SelectAPIKeyByHash(ctx, tx, keyHash)
+	if err != nil {
+		msg := "failed to get api key by hash"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if keyEntity == nil {
+		return nil, nil
+	}
+	return mapper.APIKeyEntityToAPIKeyModel(keyEntity), nil
+}
+
+func (a *APIKeyAdapter) DeleteAPIKey(ctx context.Context, tx pgx.Tx, userID string, keyID string) error {
+	katapp.Logger(ctx).Info("deleting api key", "keyID", keyID, "userID", userID)
+
+	count, err := repo.DeleteAPIKey(ctx, tx, userID, keyID)
+	if err != nil {
+		msg := "failed to delete api key"
+		katapp.Logger(ctx).Error(msg, "keyID", keyID, "userID", userID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "api key not found")
+	}
+	return nil
+}
+
+func (a *APIKeyAdapter) TouchAPIKey(ctx context.Context, tx pgx.Tx, keyID string, usedAt time.Time) error {
+	if err := repo.TouchAPIKey(ctx, tx, keyID, usedAt); err != nil {
+		msg := "failed to record api key use"
+		katapp.Logger(ctx).Error(msg, "keyID", keyID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package persist
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// AuditAdapter implements the outport.AuditPersist outport interface
+type AuditAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewAuditAdapter(db *katpg.DBLink) outport.AuditPersist {
+	return &AuditAdapter{db: db}
+}
+
+func (a *AuditAdapter) CreateAuditEvent(
+	ctx context.Context, tx pgx.Tx, event *model.AuditEvent,
+) (*model.AuditEvent, error) {
+	katapp.Logger(ctx).Info("creating audit event", "action", event.Action, "eventID", event.ID)
+
+	eventEntity, err := mapper.AuditEventModelToAuditEventEntity(event)
+	if err != nil {
+		msg := "failed to encode audit event"
+		katapp.Logger(ctx).Error(msg, "action", event.Action, "error", err)
+		return nil, katapp.NewErr(katapp.ErrInternal, msg)
+	}
+	if err := repo.InsertAuditEvent(ctx, tx, eventEntity); err != nil {
+		msg := "failed to create audit event"
+		katapp.Logger(ctx).Error(msg, "action", event.Action, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	return event, nil
+}
+
+func (a *AuditAdapter) ListAuditEvents(
+	ctx context.Context, tx pgx.Tx, filter *model.AuditEventFilter, offset int, limit int,
+) ([]*model.AuditEvent, int, error) {
+	katapp.Logger(ctx).Debug("listing audit events", "offset", offset, "limit", limit)
+
+	total, err := repo.CountAuditEvents(ctx, tx, filter)
+	if err != nil {
+		msg := "failed to count audit events"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	eventEntities, err := repo.SelectAuditEvents(ctx, tx, filter, offset, limit)
+	if err != nil {
+		msg := "failed to list audit events"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+
+	events := make([]*model.AuditEvent, len(eventEntities))
+	for i := range eventEntities {
+		event, err := mapper.AuditEventEntityToAuditEventModel(&eventEntities[i])
+		if err != nil {
+			msg := "failed to decode audit event"
+			katapp.Logger(ctx).Error(msg, "eventID", eventEntities[i].ID, "error", err)
+			return nil, 0, katapp.NewErr(katapp.ErrInternal, msg)
+		}
+		events[i] = event
+	}
+	return events, total, nil
+}
@@ -138,29 +138,41 @@ func (a *AuthUserAdapter) GetUserWithPasswordByEmail(ctx context.Context, tx pgx
 	return mapper.AuthUserEntityToAuthUserModel(userEntity), nil
 }
 
-func (a *AuthUserAdapter) GetAllUsersByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.AuthUser, error) {
-	katapp.Logger(ctx).Debug("getting all tenant users", "tenantID", tenantID)
+func (a *AuthUserAdapter) ListUsers(
+	ctx context.Context, tx pgx.Tx, filter *model.UserFilter, page *model.PageRequest,
+) ([]*model.AuthUser, int, error) {
+	katapp.Logger(ctx).Debug("listing users", "sortBy", page.SortBy, "offset", page.Offset, "limit", page.Limit)
 
-	userEntities, err := repo.SelectAllUsersByTenantId(ctx, tx, tenantID)
+	total, err := repo.CountUsers(ctx, tx, filter)
 	if err != nil {
-		msg := "failed to get all tenant users"
-		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
-		return nil, katpg.PgToAppError(err, msg)
+		msg := "failed to count users"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	userEntities, err := repo.SelectUsers(ctx, tx, filter, page)
+	if err != nil {
+		msg := "failed to list users"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
 	}
 
 	users := make([]*model.AuthUser, len(userEntities))
 	for i, entity := range userEntities {
 		users[i] = mapper.AuthUserEntityToAuthUserModel(&entity)
 	}
-	return users, nil
+	return users, total, nil
 }
 
-func (a *AuthUserAdapter) GetAllUsers(ctx context.Context, tx pgx.Tx) ([]*model.AuthUser, error) {
-	katapp.Logger(ctx).Debug("getting all users")
+// SearchUsers returns users of the tenant (all tenants if nil) whose email or names are similar to the query,
+// most similar first
+func (a *AuthUserAdapter) SearchUsers(
+	ctx context.Context, tx pgx.Tx, query string, tenantID *string, limit int,
+) ([]*model.AuthUser, error) {
+	katapp.Logger(ctx).Debug("searching users", "tenantID", tenantID, "limit", limit)
 
-	userEntities, err := repo.SelectAllUsers(ctx, tx)
+	userEntities, err := repo.SearchUsers(ctx, tx, query, tenantID, limit)
 	if err != nil {
-		msg := "failed to get all users"
+		msg := "failed to search users"
 		katapp.Logger(ctx).Error(msg, "error", err)
 		return nil, katpg.PgToAppError(err, msg)
 	}
@@ -191,10 +203,27 @@ func (a *AuthUserAdapter) GetUserRoles(ctx context.Context, tx pgx.Tx, userID st
 	return roles, nil
 }
 
+func (a *AuthUserAdapter) GetRolesOfUsers(ctx context.Context, tx pgx.Tx, userIDs []string) (map[string][]string, error) {
+	katapp.Logger(ctx).Debug("getting roles of users", "users", len(userIDs))
+
+	roleEntities, err := repo.SelectRolesOfUsers(ctx, tx, userIDs)
+	if err != nil {
+		msg := "failed to get roles of users"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	roles := make(map[string][]string, len(userIDs))
+	for _, role := range roleEntities {
+		roles[role.UserID] = append(roles[role.UserID], role.Name)
+	}
+	return roles, nil
+}
+
 func (a *AuthUserAdapter) AssignUserRole(ctx context.Context, tx pgx.Tx, userID string, roleName string) error {
 	katapp.Logger(ctx).Info("assigning user role", "userID", userID, "roleName", roleName)
 
-	roleEntity, err := repo.SelectRoleByName(ctx, tx, roleName)
+	roleEntity, err := repo.SelectUserAssignableRoleByName(ctx, tx, userID, roleName)
 	if err != nil {
 		katapp.Logger(ctx).Error("failed to get role by name", "roleName", roleName, "error", err)
 		return katpg.PgToAppError(err, "failed to select role by name")
@@ -221,7 +250,7 @@ func (a *AuthUserAdapter) AssignUserRole(ctx context.Context, tx pgx.Tx, userID
 func (a *AuthUserAdapter) DeleteUserRole(ctx context.Context, tx pgx.Tx, userID string, roleName string) error {
 	katapp.Logger(ctx).Info("removing user role", "userID", userID, "roleName", roleName)
 
-	roleEntity, err := repo.SelectRoleByName(ctx, tx, roleName)
+	roleEntity, err := repo.SelectUserAssignableRoleByName(ctx, tx, userID, roleName)
 	if err != nil {
 		katapp.Logger(ctx).Error("failed to get role by name", "roleName", roleName, "error", err)
 		return katpg.PgToAppError(err, "failed to select role by name")
@@ -259,21 +288,30 @@ func (a *AuthUserAdapter) GetTenantByID(ctx context.Context, tx pgx.Tx, tenantID
 	return mapper.TenantEntityToTenantModel(tenantEntity), nil
 }
 
-// GetAllTenants returns all tenants
-func (a *AuthUserAdapter) GetAllTenants(ctx context.Context, tx pgx.Tx) ([]*model.Tenant, error) {
-	katapp.Logger(ctx).Debug("getting all tenants")
+// ListTenants returns a page of tenants and the number of all tenants matching the filter
+func (a *AuthUserAdapter) ListTenants(
+	ctx context.Context, tx pgx.Tx, filter *model.TenantFilter, page *model.PageRequest,
+) ([]*model.Tenant, int, error) {
+	katapp.Logger(ctx).Debug("listing tenants", "sortBy", page.SortBy, "offset", page.Offset, "limit", page.Limit)
 
-	tenantEntities, err := repo.SelectAllTenants(ctx, tx)
+	total, err := repo.CountTenants(ctx, tx, filter)
 	if err != nil {
-		katapp.Logger(ctx).Error("failed to get all tenants", "error", err)
-		return nil, katpg.PgToAppError(err, "failed to select all tenants")
+		msg := "failed to count tenants"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	tenantEntities, err := repo.SelectTenants(ctx, tx, filter, page)
+	if err != nil {
+		msg := "failed to list tenants"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
 	}
 
 	tenants := make([]*model.Tenant, len(tenantEntities))
 	for i, entity := range tenantEntities {
 		tenants[i] = mapper.TenantEntityToTenantModel(&entity)
 	}
-	return tenants, nil
+	return tenants, total, nil
 }
 
 // CreateTenant creates a new tenant
@@ -310,7 +348,7 @@ func (a *AuthUserAdapter) UpdateTenant(
 		return nil, katapp.NewErr(katapp.ErrNotFound, "tenant not found")
 	}
 
-	tenantEntity := mapper.TenantUpdateRequestToTenantEntity(tenantID, req)
+	tenantEntity := mapper.TenantUpdateRequestToTenantEntity(existingTenant, req)
 	err = repo.UpdateTenant(ctx, tx, tenantEntity)
 	if err != nil {
 		katapp.Logger(ctx).Error("failed to update tenant", "tenantID", tenantID, "error", err)
@@ -342,19 +380,24 @@ func (a *AuthUserAdapter) DeleteTenant(ctx context.Context, tx pgx.Tx, tenantID
 
 // Email confirmation methods
 
-func (a *AuthUserAdapter) CreateEmailConfirmationToken(ctx context.Context, tx pgx.Tx, userID string, email string, tokenHash string, source string, expiresAt time.Time) (*model.EmailConfirmationToken, error) {
-	katapp.Logger(ctx).Info("creating email confirmation token", "userID", userID, "email", email, "source", source)
+func (a *AuthUserAdapter) CreateEmailConfirmationToken(ctx context.Context, tx pgx.Tx, userID string, email string, purpose string, tokenHash string, source string, expiresAt time.Time) (*model.EmailConfirmationToken, error) {
+	katapp.Logger(ctx).Info("creating email confirmation token", "userID", userID, "email", email, "purpose", purpose, "source", source)
 
 	tokenID := uuid.NewString()
+	now := time.Now()
 	confirmationToken := model.NewEmailConfirmationTokenBuilder().
 		ID(tokenID).
 		UserID(userID).
 		Email(email).
+		Purpose(purpose).
 		TokenHash(tokenHash).
 		Source(source).
 		ExpiresAt(expiresAt).
 		UsedAt(nil).
-		CreatedAt(time.Now()).
+		CreatedAt(now).
+		FailedAttempts(0).
+		SendCount(1).
+		SendWindowStartedAt(now).
 		Build()
 
 	err := repo.InsertEmailConfirmationToken(ctx, tx, confirmationToken)
@@ -378,6 +421,18 @@ func (a *AuthUserAdapter) GetEmailConfirmationTokenByUserIDAndHash(ctx context.C
 	return confirmationToken, nil
 }
 
+func (a *AuthUserAdapter) GetEmailConfirmationTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.EmailConfirmationToken, error) {
+	katapp.Logger(ctx).Debug("getting email confirmation token of user", "userID", userID)
+
+	confirmationToken, err := repo.GetEmailConfirmationTokenByUserID(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to get email confirmation token of user", "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to get email confirmation token")
+	}
+
+	return confirmationToken, nil
+}
+
 func (a *AuthUserAdapter) MarkEmailConfirmationTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID string) error {
 	katapp.Logger(ctx).Info("marking email confirmation token as used", "tokenID", tokenID)
 
@@ -390,6 +445,18 @@ func (a *AuthUserAdapter) MarkEmailConfirmationTokenAsUsed(ctx context.Context,
 	return nil
 }
 
+func (a *AuthUserAdapter) RecordEmailConfirmationFailure(ctx context.Context, tx pgx.Tx, tokenID string) (int, error) {
+	katapp.Logger(ctx).Info("recording failed email confirmation attempt", "tokenID", tokenID)
+
+	failedAttempts, err := repo.IncrementEmailConfirmationFailedAttempts(ctx, tx, tokenID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record failed email confirmation attempt", "tokenID", tokenID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to record failed email confirmation attempt")
+	}
+
+	return failedAttempts, nil
+}
+
 func (a *AuthUserAdapter) SetUserEmailVerified(ctx context.Context, tx pgx.Tx, userID string, verified bool) error {
 	katapp.Logger(ctx).Info("setting user email verified status", "userID", userID, "verified", verified)
 
@@ -402,15 +469,141 @@ func (a *AuthUserAdapter) SetUserEmailVerified(ctx context.Context, tx pgx.Tx, u
 	return nil
 }
 
+// Password reset token methods
+
+func (a *AuthUserAdapter) CreatePasswordResetToken(ctx context.Context, tx pgx.Tx, userID string, tokenHash string, source string, expiresAt time.Time) (*model.PasswordResetToken, error) {
+	katapp.Logger(ctx).Info("creating password reset token", "userID", userID, "source", source)
+
+	tokenID := uuid.NewString()
+	now := time.Now()
+	resetToken := model.NewPasswordResetTokenBuilder().
+		ID(tokenID).
+		UserID(userID).
+		TokenHash(tokenHash).
+		Source(source).
+		ExpiresAt(expiresAt).
+		UsedAt(nil).
+		CreatedAt(now).
+		FailedAttempts(0).
+		SendCount(1).
+		SendWindowStartedAt(now).
+		Build()
+
+	err := repo.InsertPasswordResetToken(ctx, tx, resetToken)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to create password reset token", "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to create password reset token")
+	}
+
+	return resetToken, nil
+}
+
+func (a *AuthUserAdapter) GetPasswordResetTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.PasswordResetToken, error) {
+	katapp.Logger(ctx).Debug("getting password reset token", "userID", userID)
+
+	resetToken, err := repo.GetPasswordResetTokenByUserID(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to get password reset token", "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to get password reset token")
+	}
+
+	return resetToken, nil
+}
+
+func (a *AuthUserAdapter) MarkPasswordResetTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID string) error {
+	katapp.Logger(ctx).Info("marking password reset token as used", "tokenID", tokenID)
+
+	err := repo.MarkPasswordResetTokenAsUsed(ctx, tx, tokenID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to mark password reset token as used", "tokenID", tokenID, "error", err)
+		return katpg.PgToAppError(err, "failed to mark password reset token as used")
+	}
+
+	return nil
+}
+
+func (a *AuthUserAdapter) RecordPasswordResetFailure(ctx context.Context, tx pgx.Tx, tokenID string) (int, error) {
+	katapp.Logger(ctx).Info("recording failed password reset attempt", "tokenID", tokenID)
+
+	failedAttempts, err := repo.IncrementPasswordResetFailedAttempts(ctx, tx, tokenID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record failed password reset attempt", "tokenID", tokenID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to record failed password reset attempt")
+	}
+
+	return failedAttempts, nil
+}
+
+// Federated identity methods
+
+func (a *AuthUserAdapter) CreateUserIdentity(
+	ctx context.Context, tx pgx.Tx, userID string, provider string, providerUserID string,
+	accessToken *string, refreshToken *string, tokenExpiresAt *time.Time,
+) (*model.AuthUserIdentity, error) {
+	katapp.Logger(ctx).Info("creating user identity", "userID", userID, "provider", provider)
+
+	now := time.Now()
+	identity := model.NewAuthUserIdentityBuilder().
+		ID(uuid.NewString()).
+		UserID(userID).
+		Provider(provider).
+		ProviderUserID(providerUserID).
+		AccessToken(accessToken).
+		RefreshToken(refreshToken).
+		TokenExpiresAt(tokenExpiresAt).
+		CreatedAt(now).
+		UpdatedAt(now).
+		Build()
+
+	identityEntity := mapper.AuthUserIdentityModelToAuthUserIdentityEntity(identity)
+	err := repo.InsertUserIdentity(ctx, tx, identityEntity)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to create user identity", "userID", userID, "provider", provider, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to create user identity")
+	}
+
+	return identity, nil
+}
+
+func (a *AuthUserAdapter) GetUserIdentityByProvider(ctx context.Context, tx pgx.Tx, provider string, providerUserID string) (*model.AuthUserIdentity, error) {
+	katapp.Logger(ctx).Debug("getting user identity by provider", "provider", provider, "providerUserID", providerUserID)
+
+	identityEntity, err := repo.SelectUserIdentityByProvider(ctx, tx, provider, providerUserID)
+	if err != nil {
+		msg := "failed to get user identity by provider"
+		katapp.Logger(ctx).Error(msg, "provider", provider, "providerUserID", providerUserID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if identityEntity == nil {
+		return nil, nil
+	}
+	return mapper.AuthUserIdentityEntityToAuthUserIdentityModel(identityEntity), nil
+}
+
+func (a *AuthUserAdapter) UpdateUserIdentityTokens(
+	ctx context.Context, tx pgx.Tx, identityID string, accessToken *string, refreshToken *string, tokenExpiresAt *time.Time,
+) error {
+	katapp.Logger(ctx).Info("updating user identity tokens", "identityID", identityID)
+
+	err := repo.UpdateUserIdentityTokens(ctx, tx, identityID, accessToken, refreshToken, tokenExpiresAt)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to update user identity tokens", "identityID", identityID, "error", err)
+		return katpg.PgToAppError(err, "failed to update user identity tokens")
+	}
+
+	return nil
+}
+
 // Refresh token methods
 
-func (a *AuthUserAdapter) CreateRefreshToken(ctx context.Context, tx pgx.Tx, userID string, tokenHash string, expiresAt time.Time) (*model.RefreshToken, error) {
-	katapp.Logger(ctx).Info("creating refresh token", "userID", userID)
+func (a *AuthUserAdapter) CreateRefreshToken(ctx context.Context, tx pgx.Tx, userID string, familyID string, tokenHash string, expiresAt time.Time) (*model.RefreshToken, error) {
+	katapp.Logger(ctx).Info("creating refresh token", "userID", userID, "familyID", familyID)
 
 	tokenID := uuid.NewString()
 	refreshToken := model.NewRefreshTokenBuilder().
 		ID(tokenID).
 		UserID(userID).
+		FamilyID(familyID).
 		TokenHash(tokenHash).
 		IssuedAt(time.Now()).
 		ExpiresAt(expiresAt).
@@ -443,16 +636,16 @@ func (a *AuthUserAdapter) GetRefreshTokenByHash(ctx context.Context, tx pgx.Tx,
 	return mapper.RefreshTokenEntityToRefreshTokenModel(tokenEntity), nil
 }
 
-func (a *AuthUserAdapter) RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) error {
+func (a *AuthUserAdapter) RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) (int64, error) {
 	katapp.Logger(ctx).Info("revoking refresh token")
 
-	err := repo.RevokeRefreshToken(ctx, tx, tokenHash)
+	rowsAffected, err := repo.RevokeRefreshToken(ctx, tx, tokenHash)
 	if err != nil {
 		katapp.Logger(ctx).Error("failed to revoke refresh token", "error", err)
-		return katpg.PgToAppError(err, "failed to revoke refresh token")
+		return 0, katpg.PgToAppError(err, "failed to revoke refresh token")
 	}
 
-	return nil
+	return rowsAffected, nil
 }
 
 func (a *AuthUserAdapter) RevokeAllUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) error {
@@ -480,15 +673,95 @@ func (a *AuthUserAdapter) CleanupExpiredRefreshTokens(ctx context.Context, tx pg
 	return rowsAffected, nil
 }
 
-func (a *AuthUserAdapter) CleanupUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
-	katapp.Logger(ctx).Debug("cleaning up user refresh tokens", "userID", userID)
+func (a *AuthUserAdapter) RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string) (int64, error) {
+	katapp.Logger(ctx).Info("revoking refresh token family", "familyID", familyID)
+
+	rowsAffected, err := repo.RevokeRefreshTokenFamily(ctx, tx, familyID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to revoke refresh token family", "familyID", familyID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to revoke refresh token family")
+	}
+
+	return rowsAffected, nil
+}
+
+func (a *AuthUserAdapter) CleanupUserRefreshTokenFamilies(ctx context.Context, tx pgx.Tx, userID string, maxFamilies int) (int64, error) {
+	katapp.Logger(ctx).Debug("cleaning up user refresh token families", "userID", userID)
 
-	rowsAffected, err := repo.CleanupUserRefreshTokens(ctx, tx, userID)
+	rowsAffected, err := repo.CleanupUserRefreshTokenFamilies(ctx, tx, userID, maxFamilies)
 	if err != nil {
-		katapp.Logger(ctx).Error("failed to cleanup user refresh tokens", "userID", userID, "error", err)
-		return 0, katpg.PgToAppError(err, "failed to cleanup user refresh tokens")
+		katapp.Logger(ctx).Error("failed to cleanup user refresh token families", "userID", userID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to cleanup user refresh token families")
 	}
 
-	katapp.Logger(ctx).Info("cleaned up user refresh tokens", "userID", userID, "rowsAffected", rowsAffected)
+	katapp.Logger(ctx).Info("cleaned up user refresh token families", "userID", userID, "rowsAffected", rowsAffected)
 	return rowsAffected, nil
 }
+
+func (a *AuthUserAdapter) CleanupRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string, maxTokens int) (int64, error) {
+	katapp.Logger(ctx).Debug("cleaning up refresh token family", "familyID", familyID)
+
+	rowsAffected, err := repo.CleanupRefreshTokenFamily(ctx, tx, familyID, maxTokens)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to cleanup refresh token family", "familyID", familyID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to cleanup refresh token family")
+	}
+
+	katapp.Logger(ctx).Info("cleaned up refresh token family", "familyID", familyID, "rowsAffected", rowsAffected)
+	return rowsAffected, nil
+}
+
+func (a *AuthUserAdapter) CreateSession(ctx context.Context, tx pgx.Tx, session *model.Session) error {
+	katapp.Logger(ctx).Info("creating session", "userID", session.UserID, "sessionID", session.ID)
+
+	err := repo.InsertSession(ctx, tx, mapper.SessionModelToSessionEntity(session))
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to create session", "userID", session.UserID, "error", err)
+		return katpg.PgToAppError(err, "failed to create session")
+	}
+
+	return nil
+}
+
+func (a *AuthUserAdapter) TouchSession(ctx context.Context, tx pgx.Tx, sessionID string, userAgent *string, ipAddress *string) error {
+	katapp.Logger(ctx).Debug("updating session last use", "sessionID", sessionID)
+
+	err := repo.TouchSession(ctx, tx, sessionID, userAgent, ipAddress, time.Now())
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to update session last use", "sessionID", sessionID, "error", err)
+		return katpg.PgToAppError(err, "failed to update session")
+	}
+
+	return nil
+}
+
+func (a *AuthUserAdapter) GetActiveSessionsByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]*model.Session, error) {
+	katapp.Logger(ctx).Debug("getting active sessions", "userID", userID)
+
+	entities, err := repo.SelectActiveSessionsByUserID(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to get active sessions", "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to get sessions")
+	}
+
+	sessions := make([]*model.Session, len(entities))
+	for i := range entities {
+		sessions[i] = mapper.SessionEntityToSessionModel(&entities[i])
+	}
+	return sessions, nil
+}
+
+func (a *AuthUserAdapter) GetActiveSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) (*model.Session, error) {
+	katapp.Logger(ctx).Debug("getting active session", "sessionID", sessionID)
+
+	entity, err := repo.SelectActiveSessionByID(ctx, tx, sessionID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to get active session", "sessionID", sessionID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to get session")
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	return mapper.SessionEntityToSessionModel(entity), nil
+}
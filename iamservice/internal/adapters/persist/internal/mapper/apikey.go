@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// APIKeyEntityToAPIKeyModel converts repo.APIKeyEntity to model.APIKey
+func APIKeyEntityToAPIKeyModel(entity *repo.APIKeyEntity) *model.APIKey {
+	return model.NewAPIKeyBuilder().
+		ID(entity.ID).
+		UserID(entity.UserID).
+		Name(entity.Name).
+		KeyPrefix(entity.KeyPrefix).
+		KeyHash(entity.KeyHash).
+		Roles(entity.Roles).
+		ExpiresAt(entity.ExpiresAt).
+		LastUsedAt(entity.LastUsedAt).
+		CreatedAt(entity.CreatedAt).
+		Build()
+}
+
+// APIKeyModelToAPIKeyEntity converts model.APIKey to repo.APIKeyEntity
+func APIKeyModelToAPIKeyEntity(key *model.APIKey) *repo.APIKeyEntity {
+	return repo.NewAPIKeyEntityBuilder().
+		ID(key.ID).
+		UserID(key.UserID).
+		Name(key.Name).
+		KeyPrefix(key.KeyPrefix).
+		KeyHash(key.KeyHash).
+		Roles(key.Roles).
+		ExpiresAt(key.ExpiresAt).
+		LastUsedAt(key.LastUsedAt).
+		CreatedAt(key.CreatedAt).
+		Build()
+}
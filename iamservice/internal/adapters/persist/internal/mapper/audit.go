@@ -0,0 +1,64 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// AuditEventModelToAuditEventEntity converts model.AuditEvent to repo.AuditEventEntity
+func AuditEventModelToAuditEventEntity(event *model.AuditEvent) (*repo.AuditEventEntity, error) {
+	diff := event.Diff
+	if diff == nil {
+		diff = map[string]model.AuditChange{}
+	}
+	diffJson, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+	actorRoles := event.ActorRoles
+	if actorRoles == nil {
+		actorRoles = []string{}
+	}
+	return repo.NewAuditEventEntityBuilder().
+		ID(event.ID).
+		Action(event.Action).
+		TenantID(event.TenantID).
+		ActorUserID(event.ActorUserID).
+		ActorTenantID(event.ActorTenantID).
+		ActorEmail(event.ActorEmail).
+		ActorRoles(actorRoles).
+		TargetType(event.TargetType).
+		TargetID(event.TargetID).
+		IPAddress(event.IPAddress).
+		UserAgent(event.UserAgent).
+		Reason(event.Reason).
+		Diff(diffJson).
+		CreatedAt(event.CreatedAt).
+		Build(), nil
+}
+
+// AuditEventEntityToAuditEventModel converts repo.AuditEventEntity to model.AuditEvent
+func AuditEventEntityToAuditEventModel(entity *repo.AuditEventEntity) (*model.AuditEvent, error) {
+	var diff map[string]model.AuditChange
+	if err := json.Unmarshal(entity.Diff, &diff); err != nil {
+		return nil, err
+	}
+	return model.NewAuditEventBuilder().
+		ID(entity.ID).
+		Action(entity.Action).
+		TenantID(entity.TenantID).
+		ActorUserID(entity.ActorUserID).
+		ActorTenantID(entity.ActorTenantID).
+		ActorEmail(entity.ActorEmail).
+		ActorRoles(entity.ActorRoles).
+		TargetType(entity.TargetType).
+		TargetID(entity.TargetID).
+		IPAddress(entity.IPAddress).
+		UserAgent(entity.UserAgent).
+		Reason(entity.Reason).
+		Diff(diff).
+		CreatedAt(entity.CreatedAt).
+		Build(), nil
+}
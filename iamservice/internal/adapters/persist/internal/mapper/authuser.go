@@ -49,6 +49,7 @@ func TenantEntityToTenantModel(entity *repo.TenantEntity) *model.Tenant {
 		ID(entity.ID).
 		Name(entity.Name).
 		Description(entity.Description).
+		RequireAdminMFA(entity.RequireAdminMFA).
 		CreatedAt(entity.CreatedAt).
 		UpdatedAt(entity.UpdatedAt).
 		Build()
@@ -61,6 +62,7 @@ func TenantModelToTenantResponse(tenant *model.Tenant) *swagger.TenantResponse {
 		Description(tenant.Description).
 		Id(tenant.ID).
 		Name(tenant.Name).
+		RequireAdminMfa(tenant.RequireAdminMFA).
 		UpdatedAt(tenant.UpdatedAt).
 		Build()
 }
@@ -72,18 +74,21 @@ func TenantCreateRequestToTenantEntity(req *swagger.CreateTenantRequest) *repo.T
 		ID(req.Id).
 		Name(req.Name).
 		Description(req.Description).
+		RequireAdminMFA(false).
 		CreatedAt(now).
 		UpdatedAt(now).
 		Build()
 }
 
-// TenantUpdateRequestToTenantEntity converts swagger.TenantUpdateRequest to repo.TenantEntity
-func TenantUpdateRequestToTenantEntity(tenantID string, req *swagger.UpdateTenantRequest) *repo.TenantEntity {
+// TenantUpdateRequestToTenantEntity converts swagger.TenantUpdateRequest to repo.TenantEntity,
+// settings omitted in the request keep their values from the existing tenant
+func TenantUpdateRequestToTenantEntity(existing *model.Tenant, req *swagger.UpdateTenantRequest) *repo.TenantEntity {
 	return &repo.TenantEntity{
-		ID:          tenantID,
-		Name:        req.Name,
-		Description: req.Description,
-		UpdatedAt:   time.Now(),
+		ID:              existing.ID,
+		Name:            req.Name,
+		Description:     req.Description,
+		RequireAdminMFA: lo.FromPtrOr(req.RequireAdminMfa, existing.RequireAdminMFA),
+		UpdatedAt:       time.Now(),
 	}
 }
 
@@ -116,6 +121,7 @@ func RefreshTokenModelToRefreshTokenEntity(token *model.RefreshToken) *repo.Refr
 	return repo.NewRefreshTokenEntityBuilder().
 		ID(token.ID).
 		UserID(token.UserID).
+		FamilyID(token.FamilyID).
 		TokenHash(token.TokenHash).
 		IssuedAt(token.IssuedAt).
 		ExpiresAt(token.ExpiresAt).
@@ -128,9 +134,68 @@ func RefreshTokenEntityToRefreshTokenModel(entity *repo.RefreshTokenEntity) *mod
 	return model.NewRefreshTokenBuilder().
 		ID(entity.ID).
 		UserID(entity.UserID).
+		FamilyID(entity.FamilyID).
 		TokenHash(entity.TokenHash).
 		IssuedAt(entity.IssuedAt).
 		ExpiresAt(entity.ExpiresAt).
 		Revoked(entity.Revoked).
 		Build()
 }
+
+// SessionModelToSessionEntity converts model.Session to repo.SessionEntity
+func SessionModelToSessionEntity(session *model.Session) *repo.SessionEntity {
+	return repo.NewSessionEntityBuilder().
+		ID(session.ID).
+		UserID(session.UserID).
+		Source(session.Source).
+		UserAgent(session.UserAgent).
+		IPAddress(session.IPAddress).
+		OAuthClientID(session.OAuthClientID).
+		CreatedAt(session.CreatedAt).
+		LastUsedAt(session.LastUsedAt).
+		Build()
+}
+
+// SessionEntityToSessionModel converts repo.SessionEntity to model.Session
+func SessionEntityToSessionModel(entity *repo.SessionEntity) *model.Session {
+	return model.NewSessionBuilder().
+		ID(entity.ID).
+		UserID(entity.UserID).
+		Source(entity.Source).
+		UserAgent(entity.UserAgent).
+		IPAddress(entity.IPAddress).
+		OAuthClientID(entity.OAuthClientID).
+		CreatedAt(entity.CreatedAt).
+		LastUsedAt(entity.LastUsedAt).
+		Build()
+}
+
+// AuthUserIdentityModelToAuthUserIdentityEntity converts model.AuthUserIdentity to repo.AuthUserIdentityEntity
+func AuthUserIdentityModelToAuthUserIdentityEntity(identity *model.AuthUserIdentity) *repo.AuthUserIdentityEntity {
+	return repo.NewAuthUserIdentityEntityBuilder().
+		ID(identity.ID).
+		UserID(identity.UserID).
+		Provider(identity.Provider).
+		ProviderUserID(identity.ProviderUserID).
+		AccessToken(identity.AccessToken).
+		RefreshToken(identity.RefreshToken).
+		TokenExpiresAt(identity.TokenExpiresAt).
+		CreatedAt(identity.CreatedAt).
+		UpdatedAt(identity.UpdatedAt).
+		Build()
+}
+
+// AuthUserIdentityEntityToAuthUserIdentityModel converts repo.AuthUserIdentityEntity to model.AuthUserIdentity
+func AuthUserIdentityEntityToAuthUserIdentityModel(entity *repo.AuthUserIdentityEntity) *model.AuthUserIdentity {
+	return model.NewAuthUserIdentityBuilder().
+		ID(entity.ID).
+		UserID(entity.UserID).
+		Provider(entity.Provider).
+		ProviderUserID(entity.ProviderUserID).
+		AccessToken(entity.AccessToken).
+		RefreshToken(entity.RefreshToken).
+		TokenExpiresAt(entity.TokenExpiresAt).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
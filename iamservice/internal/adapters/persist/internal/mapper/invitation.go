@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// InvitationEntityToInvitationModel converts repo.InvitationEntity to model.Invitation
+func InvitationEntityToInvitationModel(entity *repo.InvitationEntity) *model.Invitation {
+	return model.NewInvitationBuilder().
+		ID(entity.ID).
+		TenantID(entity.TenantID).
+		Email(entity.Email).
+		Roles(entity.Roles).
+		TokenHash(entity.TokenHash).
+		InvitedBy(entity.InvitedBy).
+		ExpiresAt(entity.ExpiresAt).
+		AcceptedAt(entity.AcceptedAt).
+		CreatedAt(entity.CreatedAt).
+		Build()
+}
+
+// InvitationModelToInvitationEntity converts model.Invitation to repo.InvitationEntity
+func InvitationModelToInvitationEntity(invitation *model.Invitation) *repo.InvitationEntity {
+	return repo.NewInvitationEntityBuilder().
+		ID(invitation.ID).
+		TenantID(invitation.TenantID).
+		Email(invitation.Email).
+		Roles(invitation.Roles).
+		TokenHash(invitation.TokenHash).
+		InvitedBy(invitation.InvitedBy).
+		ExpiresAt(invitation.ExpiresAt).
+		AcceptedAt(invitation.AcceptedAt).
+		CreatedAt(invitation.CreatedAt).
+		Build()
+}
@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// UserMFAEntityToUserMFAModel converts repo.UserMFAEntity to model.UserMFA
+func UserMFAEntityToUserMFAModel(entity *repo.UserMFAEntity) *model.UserMFA {
+	return model.NewUserMFABuilder().
+		UserID(entity.UserID).
+		TOTPSecret(entity.TOTPSecret).
+		ConfirmedAt(entity.ConfirmedAt).
+		LastUsedStep(entity.LastUsedStep).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// OAuthClientModelToOAuthClientEntity converts model.OAuthClient to repo.OAuthClientEntity
+func OAuthClientModelToOAuthClientEntity(client *model.OAuthClient) *repo.OAuthClientEntity {
+	return repo.NewOAuthClientEntityBuilder().
+		ID(client.ID).
+		TenantID(client.TenantID).
+		Name(client.Name).
+		ClientSecretHash(client.ClientSecretHash).
+		RedirectURIs(client.RedirectURIs).
+		CreatedAt(client.CreatedAt).
+		UpdatedAt(client.UpdatedAt).
+		Build()
+}
+
+// OAuthClientEntityToOAuthClientModel converts repo.OAuthClientEntity to model.OAuthClient
+func OAuthClientEntityToOAuthClientModel(entity *repo.OAuthClientEntity) *model.OAuthClient {
+	return model.NewOAuthClientBuilder().
+		ID(entity.ID).
+		TenantID(entity.TenantID).
+		Name(entity.Name).
+		ClientSecretHash(entity.ClientSecretHash).
+		RedirectURIs(entity.RedirectURIs).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
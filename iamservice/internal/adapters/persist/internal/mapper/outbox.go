@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// OutboxEmailEntityToOutboxEmailModel converts repo.OutboxEmailEntity to model.OutboxEmail
+func OutboxEmailEntityToOutboxEmailModel(entity *repo.OutboxEmailEntity) (*model.OutboxEmail, error) {
+	var headers map[string]string
+	if err := json.Unmarshal(entity.Headers, &headers); err != nil {
+		return nil, err
+	}
+	var attachments []model.MailAttachment
+	if err := json.Unmarshal(entity.Attachments, &attachments); err != nil {
+		return nil, err
+	}
+	return model.NewOutboxEmailBuilder().
+		ID(entity.ID).
+		Recipient(entity.Recipient).
+		Subject(entity.Subject).
+		HTMLBody(entity.HTMLBody).
+		TextBody(entity.TextBody).
+		ReplyTo(entity.ReplyTo).
+		Headers(headers).
+		Attachments(attachments).
+		Status(entity.Status).
+		Attempts(entity.Attempts).
+		NextAttemptAt(entity.NextAttemptAt).
+		LastError(entity.LastError).
+		CreatedAt(entity.CreatedAt).
+		SentAt(entity.SentAt).
+		Build(), nil
+}
+
+// OutboxEmailModelToOutboxEmailEntity converts model.OutboxEmail to repo.OutboxEmailEntity
+func OutboxEmailModelToOutboxEmailEntity(email *model.OutboxEmail) (*repo.OutboxEmailEntity, error) {
+	headers := email.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	headersJson, err := json.Marshal(headers)
+	if err != nil {
+		return nil, err
+	}
+	attachments := email.Attachments
+	if attachments == nil {
+		attachments = []model.MailAttachment{}
+	}
+	attachmentsJson, err := json.Marshal(attachments)
+	if err != nil {
+		return nil, err
+	}
+	return repo.NewOutboxEmailEntityBuilder().
+		ID(email.ID).
+		Recipient(email.Recipient).
+		Subject(email.Subject).
+		HTMLBody(email.HTMLBody).
+		TextBody(email.TextBody).
+		ReplyTo(email.ReplyTo).
+		Headers(headersJson).
+		Attachments(attachmentsJson).
+		Status(email.Status).
+		Attempts(email.Attempts).
+		NextAttemptAt(email.NextAttemptAt).
+		LastError(email.LastError).
+		CreatedAt(email.CreatedAt).
+		SentAt(email.SentAt).
+		Build(), nil
+}
@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/samber/lo"
+)
+
+// PermissionEntityToPermissionModel converts repo.PermissionEntity to model.Permission
+func PermissionEntityToPermissionModel(entity *repo.PermissionEntity) *model.Permission {
+	return model.NewPermissionBuilder().
+		Name(entity.Name).
+		Description(entity.Description).
+		SystemOnly(entity.SystemOnly).
+		Build()
+}
+
+// RoleEntityToRoleModel converts repo.RoleEntity to model.Role
+func RoleEntityToRoleModel(entity *repo.RoleEntity) *model.Role {
+	return model.NewRoleBuilder().
+		ID(lo.FromPtr(entity.ID)).
+		TenantID(entity.TenantID).
+		Name(entity.Name).
+		Description(lo.FromPtr(entity.Description)).
+		Permissions(entity.Permissions).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
+
+// RoleModelToRoleEntity converts model.Role to repo.RoleEntity
+func RoleModelToRoleEntity(role *model.Role) *repo.RoleEntity {
+	return repo.NewRoleEntityBuilder().
+		ID(lo.EmptyableToPtr(role.ID)).
+		TenantID(role.TenantID).
+		Name(role.Name).
+		Description(lo.EmptyableToPtr(role.Description)).
+		Permissions(role.Permissions).
+		CreatedAt(role.CreatedAt).
+		UpdatedAt(role.UpdatedAt).
+		Build()
+}
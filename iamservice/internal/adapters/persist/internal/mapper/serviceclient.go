@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// ServiceClientEntityToServiceClientModel converts repo.ServiceClientEntity to model.ServiceClient
+func ServiceClientEntityToServiceClientModel(entity *repo.ServiceClientEntity) *model.ServiceClient {
+	return model.NewServiceClientBuilder().
+		ID(entity.ID).
+		TenantID(entity.TenantID).
+		Name(entity.Name).
+		ClientSecretHash(entity.ClientSecretHash).
+		Roles(entity.Roles).
+		Scopes(entity.Scopes).
+		ExpiresAt(entity.ExpiresAt).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
+
+// ServiceClientModelToServiceClientEntity converts model.ServiceClient to repo.ServiceClientEntity
+func ServiceClientModelToServiceClientEntity(client *model.ServiceClient) *repo.ServiceClientEntity {
+	return repo.NewServiceClientEntityBuilder().
+		ID(client.ID).
+		TenantID(client.TenantID).
+		Name(client.Name).
+		ClientSecretHash(client.ClientSecretHash).
+		Scopes(client.Scopes).
+		ExpiresAt(client.ExpiresAt).
+		CreatedAt(client.CreatedAt).
+		UpdatedAt(client.UpdatedAt).
+		Roles(client.Roles).
+		Build()
+}
@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// SignInThrottleEntityToSignInThrottleModel converts repo.SignInThrottleEntity to model.SignInThrottle
+func SignInThrottleEntityToSignInThrottleModel(entity *repo.SignInThrottleEntity) *model.SignInThrottle {
+	return model.NewSignInThrottleBuilder().
+		SubjectType(entity.SubjectType).
+		Subject(entity.Subject).
+		FailedAttempts(entity.FailedAttempts).
+		WindowStartedAt(entity.WindowStartedAt).
+		LockedUntil(entity.LockedUntil).
+		LockoutCount(entity.LockoutCount).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
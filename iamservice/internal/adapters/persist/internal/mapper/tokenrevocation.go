@@ -0,0 +1,20 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// AccessTokenRevocationModelToAccessTokenRevocationEntity converts model.AccessTokenRevocation to
+// repo.AccessTokenRevocationEntity
+func AccessTokenRevocationModelToAccessTokenRevocationEntity(
+	revocation *model.AccessTokenRevocation,
+) *repo.AccessTokenRevocationEntity {
+	return repo.NewAccessTokenRevocationEntityBuilder().
+		SubjectType(revocation.SubjectType).
+		Subject(revocation.Subject).
+		IssuedBefore(revocation.IssuedBefore).
+		ExpiresAt(revocation.ExpiresAt).
+		CreatedAt(revocation.CreatedAt).
+		Build()
+}
@@ -0,0 +1,80 @@
+package mapper
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// WebhookEntityToWebhookModel converts repo.WebhookEntity to model.Webhook
+func WebhookEntityToWebhookModel(entity *repo.WebhookEntity) *model.Webhook {
+	return model.NewWebhookBuilder().
+		ID(entity.ID).
+		TenantID(entity.TenantID).
+		URL(entity.URL).
+		Secret(entity.Secret).
+		EventTypes(entity.EventTypes).
+		Active(entity.Active).
+		CreatedAt(entity.CreatedAt).
+		UpdatedAt(entity.UpdatedAt).
+		Build()
+}
+
+// WebhookModelToWebhookEntity converts model.Webhook to repo.WebhookEntity
+func WebhookModelToWebhookEntity(webhook *model.Webhook) *repo.WebhookEntity {
+	return repo.NewWebhookEntityBuilder().
+		ID(webhook.ID).
+		TenantID(webhook.TenantID).
+		URL(webhook.URL).
+		Secret(webhook.Secret).
+		EventTypes(webhook.EventTypes).
+		Active(webhook.Active).
+		CreatedAt(webhook.CreatedAt).
+		UpdatedAt(webhook.UpdatedAt).
+		Build()
+}
+
+// WebhookDeliveryEntityToWebhookDeliveryModel converts repo.WebhookDeliveryEntity to model.WebhookDelivery
+func WebhookDeliveryEntityToWebhookDeliveryModel(entity *repo.WebhookDeliveryEntity) *model.WebhookDelivery {
+	return model.NewWebhookDeliveryBuilder().
+		ID(entity.ID).
+		WebhookID(entity.WebhookID).
+		EventID(entity.EventID).
+		EventType(entity.EventType).
+		Payload(entity.Payload).
+		Status(entity.Status).
+		Attempts(entity.Attempts).
+		NextAttemptAt(entity.NextAttemptAt).
+		LastStatusCode(entity.LastStatusCode).
+		LastError(entity.LastError).
+		CreatedAt(entity.CreatedAt).
+		DeliveredAt(entity.DeliveredAt).
+		Build()
+}
+
+// WebhookDeliveryModelToWebhookDeliveryEntity converts model.WebhookDelivery to repo.WebhookDeliveryEntity
+func WebhookDeliveryModelToWebhookDeliveryEntity(delivery *model.WebhookDelivery) *repo.WebhookDeliveryEntity {
+	return repo.NewWebhookDeliveryEntityBuilder().
+		ID(delivery.ID).
+		WebhookID(delivery.WebhookID).
+		EventID(delivery.EventID).
+		EventType(delivery.EventType).
+		Payload(delivery.Payload).
+		Status(delivery.Status).
+		Attempts(delivery.Attempts).
+		NextAttemptAt(delivery.NextAttemptAt).
+		LastStatusCode(delivery.LastStatusCode).
+		LastError(delivery.LastError).
+		CreatedAt(delivery.CreatedAt).
+		DeliveredAt(delivery.DeliveredAt).
+		Build()
+}
+
+// DueWebhookDeliveryEntityToDueWebhookDeliveryModel converts repo.DueWebhookDeliveryEntity to
+// model.DueWebhookDelivery
+func DueWebhookDeliveryEntityToDueWebhookDeliveryModel(entity *repo.DueWebhookDeliveryEntity) *model.DueWebhookDelivery {
+	return &model.DueWebhookDelivery{
+		WebhookDelivery: *WebhookDeliveryEntityToWebhookDeliveryModel(&entity.WebhookDeliveryEntity),
+		URL:             entity.URL,
+		Secret:          entity.Secret,
+	}
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type APIKeyEntity struct { //+gob:Constructor
+	ID         string     `db:"id"`
+	UserID     string     `db:"user_id"`
+	Name       string     `db:"name"`
+	KeyPrefix  string     `db:"key_prefix"`
+	KeyHash    string     `db:"key_hash"`
+	Roles      []string   `db:"roles"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+func InsertAPIKey(ctx context.Context, tx pgx.Tx, ent *APIKeyEntity) error {
+	_, err := tx.Exec(ctx, insertAPIKeySql, pgx.NamedArgs{
+		"id":           ent.ID,
+		"user_id":      ent.UserID,
+		"name":         ent.Name,
+		"key_prefix":   ent.KeyPrefix,
+		"key_hash":     ent.KeyHash,
+		"roles":        ent.Roles,
+		"expires_at":   ent.ExpiresAt,
+		"last_used_at": ent.LastUsedAt,
+		"created_at":   ent.CreatedAt,
+	})
+	return err
+}
+
+func SelectAPIKeysByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]APIKeyEntity, error) {
+	rows, _ := tx.Query(ctx, selectAPIKeysByUserIdSql, pgx.NamedArgs{"user_id": userID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[APIKeyEntity])
+}
+
+func SelectAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*APIKeyEntity, error) {
+	rows, _ := tx.Query(ctx, selectAPIKeyByHashSql, pgx.NamedArgs{"key_hash": keyHash})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[APIKeyEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+func DeleteAPIKey(ctx context.Context, tx pgx.Tx, userID string, keyID string) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteAPIKeySql, pgx.NamedArgs{
+		"id":      keyID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func TouchAPIKey(ctx context.Context, tx pgx.Tx, keyID string, usedAt time.Time) error {
+	_, err := tx.Exec(ctx, touchAPIKeySql, pgx.NamedArgs{
+		"id":      keyID,
+		"used_at": usedAt,
+	})
+	return err
+}
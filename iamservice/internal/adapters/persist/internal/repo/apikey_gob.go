@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewAPIKeyEntityBuilder() APIKeyEntity_Builder_ID {
+	return APIKeyEntity_Builder_ID{root: &APIKeyEntity{}}
+}
+
+type APIKeyEntity_Builder_ID struct {
+	root *APIKeyEntity
+}
+
+type APIKeyEntity_Builder_UserID struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_ID) ID(arg string) APIKeyEntity_Builder_UserID {
+	b.root.ID = arg
+	return APIKeyEntity_Builder_UserID{root: b.root}
+}
+
+type APIKeyEntity_Builder_Name struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_UserID) UserID(arg string) APIKeyEntity_Builder_Name {
+	b.root.UserID = arg
+	return APIKeyEntity_Builder_Name{root: b.root}
+}
+
+type APIKeyEntity_Builder_KeyPrefix struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_Name) Name(arg string) APIKeyEntity_Builder_KeyPrefix {
+	b.root.Name = arg
+	return APIKeyEntity_Builder_KeyPrefix{root: b.root}
+}
+
+type APIKeyEntity_Builder_KeyHash struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_KeyPrefix) KeyPrefix(arg string) APIKeyEntity_Builder_KeyHash {
+	b.root.KeyPrefix = arg
+	return APIKeyEntity_Builder_KeyHash{root: b.root}
+}
+
+type APIKeyEntity_Builder_Roles struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_KeyHash) KeyHash(arg string) APIKeyEntity_Builder_Roles {
+	b.root.KeyHash = arg
+	return APIKeyEntity_Builder_Roles{root: b.root}
+}
+
+type APIKeyEntity_Builder_ExpiresAt struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_Roles) Roles(arg []string) APIKeyEntity_Builder_ExpiresAt {
+	b.root.Roles = arg
+	return APIKeyEntity_Builder_ExpiresAt{root: b.root}
+}
+
+type APIKeyEntity_Builder_LastUsedAt struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_ExpiresAt) ExpiresAt(arg *time.Time) APIKeyEntity_Builder_LastUsedAt {
+	b.root.ExpiresAt = arg
+	return APIKeyEntity_Builder_LastUsedAt{root: b.root}
+}
+
+type APIKeyEntity_Builder_CreatedAt struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_LastUsedAt) LastUsedAt(arg *time.Time) APIKeyEntity_Builder_CreatedAt {
+	b.root.LastUsedAt = arg
+	return APIKeyEntity_Builder_CreatedAt{root: b.root}
+}
+
+type APIKeyEntity_Builder_GobFinalizer struct {
+	root *APIKeyEntity
+}
+
+func (b APIKeyEntity_Builder_CreatedAt) CreatedAt(arg time.Time) APIKeyEntity_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return APIKeyEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b APIKeyEntity_Builder_GobFinalizer) Build() *APIKeyEntity {
+	return b.root
+}
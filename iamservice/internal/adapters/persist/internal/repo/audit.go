@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type AuditEventEntity struct { //+gob:Constructor
+	ID            string    `db:"id"`
+	Action        string    `db:"action"`
+	TenantID      *string   `db:"tenant_id"`
+	ActorUserID   *string   `db:"actor_user_id"`
+	ActorTenantID *string   `db:"actor_tenant_id"`
+	ActorEmail    *string   `db:"actor_email"`
+	ActorRoles    []string  `db:"actor_roles"`
+	TargetType    *string   `db:"target_type"`
+	TargetID      *string   `db:"target_id"`
+	IPAddress     *string   `db:"ip_address"`
+	UserAgent     *string   `db:"user_agent"`
+	Reason        *string   `db:"reason"`
+	Diff          []byte    `db:"diff"` // JSON encoded map of changed fields
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+func InsertAuditEvent(ctx context.Context, tx pgx.Tx, event *AuditEventEntity) error {
+	_, err := tx.Exec(ctx, insertAuditEventSql, pgx.NamedArgs{
+		"id":              event.ID,
+		"action":          event.Action,
+		"tenant_id":       event.TenantID,
+		"actor_user_id":   event.ActorUserID,
+		"actor_tenant_id": event.ActorTenantID,
+		"actor_email":     event.ActorEmail,
+		"actor_roles":     event.ActorRoles,
+		"target_type":     event.TargetType,
+		"target_id":       event.TargetID,
+		"ip_address":      event.IPAddress,
+		"user_agent":      event.UserAgent,
+		"reason":          event.Reason,
+		"diff":            string(event.Diff),
+		"created_at":      event.CreatedAt,
+	})
+	return err
+}
+
+func SelectAuditEvents(
+	ctx context.Context, tx pgx.Tx, filter *model.AuditEventFilter, offset int, limit int,
+) ([]AuditEventEntity, error) {
+	args := auditEventFilterArgs(filter)
+	args["offset"] = offset
+	args["limit"] = limit
+	rows, _ := tx.Query(ctx, selectAuditEventsSql, args)
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[AuditEventEntity])
+	return events, err
+}
+
+func CountAuditEvents(ctx context.Context, tx pgx.Tx, filter *model.AuditEventFilter) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, countAuditEventsSql, auditEventFilterArgs(filter)).Scan(&count)
+	return count, err
+}
+
+func auditEventFilterArgs(filter *model.AuditEventFilter) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"tenant_id":     filter.TenantID,
+		"action":        filter.Action,
+		"actor_user_id": filter.ActorUserID,
+		"target_id":     filter.TargetID,
+		"from_time":     filter.From,
+		"to_time":       filter.To,
+	}
+}
@@ -0,0 +1,145 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewAuditEventEntityBuilder() AuditEventEntity_Builder_ID {
+	return AuditEventEntity_Builder_ID{root: &AuditEventEntity{}}
+}
+
+type AuditEventEntity_Builder_ID struct {
+	root *AuditEventEntity
+}
+
+type AuditEventEntity_Builder_Action struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_ID) ID(arg string) AuditEventEntity_Builder_Action {
+	b.root.ID = arg
+	return AuditEventEntity_Builder_Action{root: b.root}
+}
+
+type AuditEventEntity_Builder_TenantID struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_Action) Action(arg string) AuditEventEntity_Builder_TenantID {
+	b.root.Action = arg
+	return AuditEventEntity_Builder_TenantID{root: b.root}
+}
+
+type AuditEventEntity_Builder_ActorUserID struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_TenantID) TenantID(arg *string) AuditEventEntity_Builder_ActorUserID {
+	b.root.TenantID = arg
+	return AuditEventEntity_Builder_ActorUserID{root: b.root}
+}
+
+type AuditEventEntity_Builder_ActorTenantID struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_ActorUserID) ActorUserID(arg *string) AuditEventEntity_Builder_ActorTenantID {
+	b.root.ActorUserID = arg
+	return AuditEventEntity_Builder_ActorTenantID{root: b.root}
+}
+
+type AuditEventEntity_Builder_ActorEmail struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_ActorTenantID) ActorTenantID(arg *string) AuditEventEntity_Builder_ActorEmail {
+	b.root.ActorTenantID = arg
+	return AuditEventEntity_Builder_ActorEmail{root: b.root}
+}
+
+type AuditEventEntity_Builder_ActorRoles struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_ActorEmail) ActorEmail(arg *string) AuditEventEntity_Builder_ActorRoles {
+	b.root.ActorEmail = arg
+	return AuditEventEntity_Builder_ActorRoles{root: b.root}
+}
+
+type AuditEventEntity_Builder_TargetType struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_ActorRoles) ActorRoles(arg []string) AuditEventEntity_Builder_TargetType {
+	b.root.ActorRoles = arg
+	return AuditEventEntity_Builder_TargetType{root: b.root}
+}
+
+type AuditEventEntity_Builder_TargetID struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_TargetType) TargetType(arg *string) AuditEventEntity_Builder_TargetID {
+	b.root.TargetType = arg
+	return AuditEventEntity_Builder_TargetID{root: b.root}
+}
+
+type AuditEventEntity_Builder_IPAddress struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_TargetID) TargetID(arg *string) AuditEventEntity_Builder_IPAddress {
+	b.root.TargetID = arg
+	return AuditEventEntity_Builder_IPAddress{root: b.root}
+}
+
+type AuditEventEntity_Builder_UserAgent struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_IPAddress) IPAddress(arg *string) AuditEventEntity_Builder_UserAgent {
+	b.root.IPAddress = arg
+	return AuditEventEntity_Builder_UserAgent{root: b.root}
+}
+
+type AuditEventEntity_Builder_Reason struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_UserAgent) UserAgent(arg *string) AuditEventEntity_Builder_Reason {
+	b.root.UserAgent = arg
+	return AuditEventEntity_Builder_Reason{root: b.root}
+}
+
+type AuditEventEntity_Builder_Diff struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_Reason) Reason(arg *string) AuditEventEntity_Builder_Diff {
+	b.root.Reason = arg
+	return AuditEventEntity_Builder_Diff{root: b.root}
+}
+
+type AuditEventEntity_Builder_CreatedAt struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_Diff) Diff(arg []byte) AuditEventEntity_Builder_CreatedAt {
+	b.root.Diff = arg
+	return AuditEventEntity_Builder_CreatedAt{root: b.root}
+}
+
+type AuditEventEntity_Builder_GobFinalizer struct {
+	root *AuditEventEntity
+}
+
+func (b AuditEventEntity_Builder_CreatedAt) CreatedAt(arg time.Time) AuditEventEntity_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return AuditEventEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditEventEntity_Builder_GobFinalizer) Build() *AuditEventEntity {
+	return b.root
+}
@@ -37,23 +37,54 @@ type AuthUserRoleEntity struct { //+gob:Constructor
 	RoleID int    `db:"role_id"`
 }
 
+// UserRoleNameEntity is a name of a role assigned to a user
+type UserRoleNameEntity struct { //+gob:Constructor
+	UserID string `db:"user_id"`
+	Name   string `db:"name"`
+}
+
 type TenantEntity struct { //+gob:Constructor
-	ID          string    `db:"id"`
-	Name        string    `db:"name"`
-	Description string    `db:"description"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID              string    `db:"id"`
+	Name            string    `db:"name"`
+	Description     string    `db:"description"`
+	RequireAdminMFA bool      `db:"require_admin_mfa"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
 }
 
 type RefreshTokenEntity struct { //+gob:Constructor
 	ID        string    `db:"id"`
 	UserID    string    `db:"user_id"`
+	FamilyID  string    `db:"family_id"`
 	TokenHash string    `db:"token_hash"`
 	IssuedAt  time.Time `db:"issued_at"`
 	ExpiresAt time.Time `db:"expires_at"`
 	Revoked   bool      `db:"revoked"`
 }
 
+type SessionEntity struct { //+gob:Constructor
+	ID            string    `db:"id"`
+	UserID        string    `db:"user_id"`
+	Source        string    `db:"source"`
+	UserAgent     *string   `db:"user_agent"`
+	IPAddress     *string   `db:"ip_address"`
+	OAuthClientID *string   `db:"oauth_client_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	LastUsedAt    time.Time `db:"last_used_at"`
+}
+
+type AuthUserIdentityEntity struct { //+gob:Constructor
+	ID             string     `db:"id"`
+	UserID         string     `db:"user_id"`
+	Provider       string     `db:"provider"`
+	ProviderUserID string     `db:"provider_user_id"`
+	AccessToken    *string    `db:"access_token"`
+	RefreshToken   *string    `db:"refresh_token"`
+	TokenExpiresAt *time.Time `db:"token_expires_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
 type UserProfileEntity struct { //+gob:Constructor
 	ID        *int       `db:"id"`
 	UserID    string     `db:"user_id"`
@@ -135,13 +166,14 @@ func InsertEmailConfirmationToken(ctx context.Context, tx pgx.Tx, token *model.E
 		"id":         token.ID,
 		"user_id":    token.UserID,
 		"email":      token.Email,
+		"purpose":    token.Purpose,
 		"token_hash": token.TokenHash,
 		"source":     token.Source,
 		"expires_at": token.ExpiresAt,
 		"created_at": token.CreatedAt,
 	}
-	_, err := tx.Exec(ctx, insertEmailConfirmationTokenSql, args)
-	return err
+	// an existing token of the user is replaced and keeps counting the codes sent within its day window
+	return tx.QueryRow(ctx, insertEmailConfirmationTokenSql, args).Scan(&token.SendCount, &token.SendWindowStartedAt)
 }
 
 func GetEmailConfirmationTokenByUserIDAndHash(ctx context.Context, tx pgx.Tx, userID string, tokenHash string) (*model.EmailConfirmationToken, error) {
@@ -150,16 +182,29 @@ func GetEmailConfirmationTokenByUserIDAndHash(ctx context.Context, tx pgx.Tx, us
 		"token_hash": tokenHash,
 	}
 
+	return scanOptionalEmailConfirmationToken(tx.QueryRow(ctx, selectEmailConfirmationTokenByUserIdAndHashSql, args))
+}
+
+func GetEmailConfirmationTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.EmailConfirmationToken, error) {
+	args := pgx.NamedArgs{"user_id": userID}
+	return scanOptionalEmailConfirmationToken(tx.QueryRow(ctx, selectEmailConfirmationTokenByUserIdSql, args))
+}
+
+func scanOptionalEmailConfirmationToken(row pgx.Row) (*model.EmailConfirmationToken, error) {
 	var confirmationToken model.EmailConfirmationToken
-	err := tx.QueryRow(ctx, selectEmailConfirmationTokenByUserIdAndHashSql, args).Scan(
+	err := row.Scan(
 		&confirmationToken.ID,
 		&confirmationToken.UserID,
 		&confirmationToken.Email,
+		&confirmationToken.Purpose,
 		&confirmationToken.TokenHash,
 		&confirmationToken.Source,
 		&confirmationToken.ExpiresAt,
 		&confirmationToken.UsedAt,
 		&confirmationToken.CreatedAt,
+		&confirmationToken.FailedAttempts,
+		&confirmationToken.SendCount,
+		&confirmationToken.SendWindowStartedAt,
 	)
 
 	if err != nil {
@@ -178,6 +223,14 @@ func MarkEmailConfirmationTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID st
 	return err
 }
 
+// IncrementEmailConfirmationFailedAttempts counts a wrong guess of the confirmation code and returns the new count
+func IncrementEmailConfirmationFailedAttempts(ctx context.Context, tx pgx.Tx, tokenID string) (int, error) {
+	args := pgx.NamedArgs{"token_id": tokenID}
+	var failedAttempts int
+	err := tx.QueryRow(ctx, incrementEmailConfirmationFailedAttemptsSql, args).Scan(&failedAttempts)
+	return failedAttempts, err
+}
+
 func SetUserEmailVerified(ctx context.Context, tx pgx.Tx, userID string, verified bool) error {
 	args := pgx.NamedArgs{
 		"verified": verified,
@@ -187,6 +240,62 @@ func SetUserEmailVerified(ctx context.Context, tx pgx.Tx, userID string, verifie
 	return err
 }
 
+func InsertPasswordResetToken(ctx context.Context, tx pgx.Tx, token *model.PasswordResetToken) error {
+	args := pgx.NamedArgs{
+		"id":         token.ID,
+		"user_id":    token.UserID,
+		"token_hash": token.TokenHash,
+		"source":     token.Source,
+		"expires_at": token.ExpiresAt,
+		"created_at": token.CreatedAt,
+	}
+	// an existing token of the user is replaced and keeps counting the codes sent and the wrong guesses
+	// within its day window
+	return tx.QueryRow(ctx, insertPasswordResetTokenSql, args).Scan(
+		&token.FailedAttempts, &token.SendCount, &token.SendWindowStartedAt)
+}
+
+func GetPasswordResetTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.PasswordResetToken, error) {
+	args := pgx.NamedArgs{"user_id": userID}
+
+	var resetToken model.PasswordResetToken
+	err := tx.QueryRow(ctx, selectPasswordResetTokenByUserIdSql, args).Scan(
+		&resetToken.ID,
+		&resetToken.UserID,
+		&resetToken.TokenHash,
+		&resetToken.Source,
+		&resetToken.ExpiresAt,
+		&resetToken.UsedAt,
+		&resetToken.CreatedAt,
+		&resetToken.FailedAttempts,
+		&resetToken.SendCount,
+		&resetToken.SendWindowStartedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &resetToken, nil
+}
+
+func MarkPasswordResetTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID string) error {
+	args := pgx.NamedArgs{"token_id": tokenID}
+	_, err := tx.Exec(ctx, markPasswordResetTokenAsUsedSql, args)
+	return err
+}
+
+// IncrementPasswordResetFailedAttempts counts a wrong guess of the password reset code and returns the new count
+func IncrementPasswordResetFailedAttempts(ctx context.Context, tx pgx.Tx, tokenID string) (int, error) {
+	args := pgx.NamedArgs{"token_id": tokenID}
+	var failedAttempts int
+	err := tx.QueryRow(ctx, incrementPasswordResetFailedAttemptsSql, args).Scan(&failedAttempts)
+	return failedAttempts, err
+}
+
 // DeleteUser deletes a user from the system, returning the number of rows deleted
 func DeleteUser(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
 	cmd, err := tx.Exec(ctx, deleteUserSql, pgx.NamedArgs{"id": userID})
@@ -197,14 +306,58 @@ func DeleteUser(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
 	return rowsAffected, err
 }
 
-func SelectAllUsersByTenantId(ctx context.Context, tx pgx.Tx, tenantID string) ([]AuthUserEntity, error) {
-	rows, _ := tx.Query(ctx, selectAllUsersByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+var userSortColumns = map[string]sortColumn{
+	model.UserSortCreatedAt: {column: "u.created_at", cursorType: "timestamptz"},
+	model.UserSortEmail:     {column: "u.email", cursorType: "text"},
+	model.UserSortFirstName: {column: "u.first_name", cursorType: "text"},
+	model.UserSortLastName:  {column: "u.last_name", cursorType: "text"},
+}
+
+func SelectUsers(
+	ctx context.Context, tx pgx.Tx, filter *model.UserFilter, page *model.PageRequest,
+) ([]AuthUserEntity, error) {
+	args := userFilterArgs(filter)
+	sql, err := pageSql(userSortColumns, "u.id", page, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, _ := tx.Query(ctx, selectUsersSql+sql, args)
 	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[AuthUserEntity])
 	return users, err
 }
 
-func SelectAllUsers(ctx context.Context, tx pgx.Tx) ([]AuthUserEntity, error) {
-	rows, _ := tx.Query(ctx, selectAllUsersSql, pgx.NamedArgs{})
+func CountUsers(ctx context.Context, tx pgx.Tx, filter *model.UserFilter) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, countUsersSql, userFilterArgs(filter)).Scan(&count)
+	return count, err
+}
+
+func userFilterArgs(filter *model.UserFilter) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"tenant_id":      filter.TenantID,
+		"user_id":        filter.UserID,
+		"email":          filter.Email,
+		"search":         containsPattern(filter.Search),
+		"role":           filter.Role,
+		"email_verified": filter.EmailVerified,
+		"is_active":      filter.IsActive,
+	}
+}
+
+// userSearchThreshold is the minimal word similarity of a search query to the email or a name of found users,
+// lower than the pg_trgm default to find misspelled emails
+const userSearchThreshold = "0.4"
+
+// SearchUsers returns users whose email or names are similar to the query, most similar first
+func SearchUsers(ctx context.Context, tx pgx.Tx, query string, tenantID *string, limit int) ([]AuthUserEntity, error) {
+	if _, err := tx.Exec(ctx, setUserSearchThresholdSql, pgx.NamedArgs{"threshold": userSearchThreshold}); err != nil {
+		return nil, err
+	}
+	rows, _ := tx.Query(ctx, searchUsersSql, pgx.NamedArgs{
+		"query":     query,
+		"tenant_id": tenantID,
+		"limit":     limit,
+	})
 	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[AuthUserEntity])
 	return users, err
 }
@@ -217,6 +370,12 @@ func SelectUserRoles(ctx context.Context, tx pgx.Tx, userID string) ([]AuthRoleE
 	return roles, err
 }
 
+// SelectRolesOfUsers returns names of roles assigned to any of the users, ordered by user and role name
+func SelectRolesOfUsers(ctx context.Context, tx pgx.Tx, userIDs []string) ([]UserRoleNameEntity, error) {
+	rows, _ := tx.Query(ctx, selectRolesOfUsersSql, pgx.NamedArgs{"user_ids": userIDs})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[UserRoleNameEntity])
+}
+
 func InsertUserRole(ctx context.Context, tx pgx.Tx, userID string, roleID int) error {
 	_, err := tx.Exec(ctx, insertUserRoleSql, pgx.NamedArgs{
 		"user_id": userID,
@@ -233,8 +392,9 @@ func DeleteUserRole(ctx context.Context, tx pgx.Tx, userID string, roleID int) e
 	return err
 }
 
-func SelectRoleByName(ctx context.Context, tx pgx.Tx, roleName string) (*AuthRoleEntity, error) {
-	rows, _ := tx.Query(ctx, selectRoleByNameSql, pgx.NamedArgs{"name": roleName})
+// SelectUserAssignableRoleByName returns a system role or a custom role of the tenant of the user by its name
+func SelectUserAssignableRoleByName(ctx context.Context, tx pgx.Tx, userID string, roleName string) (*AuthRoleEntity, error) {
+	rows, _ := tx.Query(ctx, selectUserAssignableRoleByNameSql, pgx.NamedArgs{"user_id": userID, "name": roleName})
 	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[AuthRoleEntity])
 	if katpg.IsNoRows(err) {
 		return nil, nil
@@ -251,29 +411,56 @@ func SelectTenantByID(ctx context.Context, tx pgx.Tx, tenantID string) (*TenantE
 	return &ent, err
 }
 
-func SelectAllTenants(ctx context.Context, tx pgx.Tx) ([]TenantEntity, error) {
-	rows, _ := tx.Query(ctx, selectAllTenantsSql)
+var tenantSortColumns = map[string]sortColumn{
+	model.TenantSortCreatedAt: {column: "t.created_at", cursorType: "timestamptz"},
+	model.TenantSortName:      {column: "t.name", cursorType: "text"},
+}
+
+func SelectTenants(
+	ctx context.Context, tx pgx.Tx, filter *model.TenantFilter, page *model.PageRequest,
+) ([]TenantEntity, error) {
+	args := tenantFilterArgs(filter)
+	sql, err := pageSql(tenantSortColumns, "t.id", page, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, _ := tx.Query(ctx, selectTenantsSql+sql, args)
 	tenants, err := pgx.CollectRows(rows, pgx.RowToStructByName[TenantEntity])
 	return tenants, err
 }
 
+func CountTenants(ctx context.Context, tx pgx.Tx, filter *model.TenantFilter) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, countTenantsSql, tenantFilterArgs(filter)).Scan(&count)
+	return count, err
+}
+
+func tenantFilterArgs(filter *model.TenantFilter) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"tenant_id": filter.TenantID,
+		"search":    containsPattern(filter.Search),
+	}
+}
+
 func InsertTenant(ctx context.Context, tx pgx.Tx, tenant *TenantEntity) error {
 	_, err := tx.Exec(ctx, insertTenantSql, pgx.NamedArgs{
-		"id":          tenant.ID,
-		"name":        tenant.Name,
-		"description": tenant.Description,
-		"created_at":  tenant.CreatedAt,
-		"updated_at":  tenant.UpdatedAt,
+		"id":                tenant.ID,
+		"name":              tenant.Name,
+		"description":       tenant.Description,
+		"require_admin_mfa": tenant.RequireAdminMFA,
+		"created_at":        tenant.CreatedAt,
+		"updated_at":        tenant.UpdatedAt,
 	})
 	return err
 }
 
 func UpdateTenant(ctx context.Context, tx pgx.Tx, tenant *TenantEntity) error {
 	_, err := tx.Exec(ctx, updateTenantSql, pgx.NamedArgs{
-		"id":          tenant.ID,
-		"name":        tenant.Name,
-		"description": tenant.Description,
-		"updated_at":  tenant.UpdatedAt,
+		"id":                tenant.ID,
+		"name":              tenant.Name,
+		"description":       tenant.Description,
+		"require_admin_mfa": tenant.RequireAdminMFA,
+		"updated_at":        tenant.UpdatedAt,
 	})
 	return err
 }
@@ -335,6 +522,7 @@ func InsertRefreshToken(ctx context.Context, tx pgx.Tx, token *RefreshTokenEntit
 	_, err := tx.Exec(ctx, insertRefreshTokenSql, pgx.NamedArgs{
 		"id":         token.ID,
 		"user_id":    token.UserID,
+		"family_id":  token.FamilyID,
 		"token_hash": token.TokenHash,
 		"issued_at":  token.IssuedAt,
 		"expires_at": token.ExpiresAt,
@@ -352,11 +540,55 @@ func SelectRefreshTokenByHash(ctx context.Context, tx pgx.Tx, tokenHash string)
 	return &ent, err
 }
 
-func RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) error {
-	_, err := tx.Exec(ctx, revokeRefreshTokenSql, pgx.NamedArgs{"token_hash": tokenHash})
+// Federated identity methods
+
+func InsertUserIdentity(ctx context.Context, tx pgx.Tx, identity *AuthUserIdentityEntity) error {
+	_, err := tx.Exec(ctx, insertUserIdentitySql, pgx.NamedArgs{
+		"id":               identity.ID,
+		"user_id":          identity.UserID,
+		"provider":         identity.Provider,
+		"provider_user_id": identity.ProviderUserID,
+		"access_token":     identity.AccessToken,
+		"refresh_token":    identity.RefreshToken,
+		"token_expires_at": identity.TokenExpiresAt,
+		"created_at":       identity.CreatedAt,
+		"updated_at":       identity.UpdatedAt,
+	})
+	return err
+}
+
+func SelectUserIdentityByProvider(ctx context.Context, tx pgx.Tx, provider string, providerUserID string) (*AuthUserIdentityEntity, error) {
+	rows, _ := tx.Query(ctx, selectUserIdentityByProviderSql, pgx.NamedArgs{
+		"provider":         provider,
+		"provider_user_id": providerUserID,
+	})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[AuthUserIdentityEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+func UpdateUserIdentityTokens(
+	ctx context.Context, tx pgx.Tx, identityID string, accessToken *string, refreshToken *string, tokenExpiresAt *time.Time,
+) error {
+	_, err := tx.Exec(ctx, updateUserIdentityTokensSql, pgx.NamedArgs{
+		"id":               identityID,
+		"access_token":     accessToken,
+		"refresh_token":    refreshToken,
+		"token_expires_at": tokenExpiresAt,
+	})
 	return err
 }
 
+func RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) (int64, error) {
+	cmd, err := tx.Exec(ctx, revokeRefreshTokenSql, pgx.NamedArgs{"token_hash": tokenHash})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
 func RevokeAllUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) error {
 	_, err := tx.Exec(ctx, revokeAllUserRefreshTokensSql, pgx.NamedArgs{"user_id": userID})
 	return err
@@ -370,10 +602,74 @@ func DeleteExpiredRefreshTokens(ctx context.Context, tx pgx.Tx) (int64, error) {
 	return cmd.RowsAffected(), nil
 }
 
-func CleanupUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
-	cmd, err := tx.Exec(ctx, cleanupUserRefreshTokensSql, pgx.NamedArgs{"user_id": userID})
+func RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string) (int64, error) {
+	cmd, err := tx.Exec(ctx, revokeRefreshTokenFamilySql, pgx.NamedArgs{"family_id": familyID})
 	if err != nil {
 		return 0, err
 	}
 	return cmd.RowsAffected(), nil
 }
+
+func CleanupUserRefreshTokenFamilies(ctx context.Context, tx pgx.Tx, userID string, maxFamilies int) (int64, error) {
+	cmd, err := tx.Exec(ctx, cleanupUserRefreshTokenFamiliesSql, pgx.NamedArgs{
+		"user_id":      userID,
+		"max_families": maxFamilies,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func CleanupRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string, maxTokens int) (int64, error) {
+	cmd, err := tx.Exec(ctx, cleanupRefreshTokenFamilySql, pgx.NamedArgs{
+		"family_id":  familyID,
+		"max_tokens": maxTokens,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+// Session methods
+
+func InsertSession(ctx context.Context, tx pgx.Tx, session *SessionEntity) error {
+	_, err := tx.Exec(ctx, insertSessionSql, pgx.NamedArgs{
+		"id":              session.ID,
+		"user_id":         session.UserID,
+		"source":          session.Source,
+		"user_agent":      session.UserAgent,
+		"ip_address":      session.IPAddress,
+		"oauth_client_id": session.OAuthClientID,
+		"created_at":      session.CreatedAt,
+		"last_used_at":    session.LastUsedAt,
+	})
+	return err
+}
+
+func TouchSession(
+	ctx context.Context, tx pgx.Tx, sessionID string, userAgent *string, ipAddress *string, lastUsedAt time.Time,
+) error {
+	_, err := tx.Exec(ctx, touchSessionSql, pgx.NamedArgs{
+		"id":           sessionID,
+		"user_agent":   userAgent,
+		"ip_address":   ipAddress,
+		"last_used_at": lastUsedAt,
+	})
+	return err
+}
+
+func SelectActiveSessionsByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]SessionEntity, error) {
+	rows, _ := tx.Query(ctx, selectActiveSessionsByUserIDSql, pgx.NamedArgs{"user_id": userID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[SessionEntity])
+}
+
+func SelectActiveSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) (*SessionEntity, error) {
+	rows, _ := tx.Query(ctx, selectActiveSessionByIDSql, pgx.NamedArgs{"id": sessionID})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[SessionEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
@@ -177,6 +177,36 @@ func (b AuthUserRoleEntity_Builder_GobFinalizer) Build() *AuthUserRoleEntity {
 	return b.root
 }
 
+func NewUserRoleNameEntityBuilder() UserRoleNameEntity_Builder_UserID {
+	return UserRoleNameEntity_Builder_UserID{root: &UserRoleNameEntity{}}
+}
+
+type UserRoleNameEntity_Builder_UserID struct {
+	root *UserRoleNameEntity
+}
+
+type UserRoleNameEntity_Builder_Name struct {
+	root *UserRoleNameEntity
+}
+
+func (b UserRoleNameEntity_Builder_UserID) UserID(arg string) UserRoleNameEntity_Builder_Name {
+	b.root.UserID = arg
+	return UserRoleNameEntity_Builder_Name{root: b.root}
+}
+
+type UserRoleNameEntity_Builder_GobFinalizer struct {
+	root *UserRoleNameEntity
+}
+
+func (b UserRoleNameEntity_Builder_Name) Name(arg string) UserRoleNameEntity_Builder_GobFinalizer {
+	b.root.Name = arg
+	return UserRoleNameEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserRoleNameEntity_Builder_GobFinalizer) Build() *UserRoleNameEntity {
+	return b.root
+}
+
 func NewTenantEntityBuilder() TenantEntity_Builder_ID {
 	return TenantEntity_Builder_ID{root: &TenantEntity{}}
 }
@@ -203,12 +233,21 @@ func (b TenantEntity_Builder_Name) Name(arg string) TenantEntity_Builder_Descrip
 	return TenantEntity_Builder_Description{root: b.root}
 }
 
-type TenantEntity_Builder_CreatedAt struct {
+type TenantEntity_Builder_RequireAdminMFA struct {
 	root *TenantEntity
 }
 
-func (b TenantEntity_Builder_Description) Description(arg string) TenantEntity_Builder_CreatedAt {
+func (b TenantEntity_Builder_Description) Description(arg string) TenantEntity_Builder_RequireAdminMFA {
 	b.root.Description = arg
+	return TenantEntity_Builder_RequireAdminMFA{root: b.root}
+}
+
+type TenantEntity_Builder_CreatedAt struct {
+	root *TenantEntity
+}
+
+func (b TenantEntity_Builder_RequireAdminMFA) RequireAdminMFA(arg bool) TenantEntity_Builder_CreatedAt {
+	b.root.RequireAdminMFA = arg
 	return TenantEntity_Builder_CreatedAt{root: b.root}
 }
 
@@ -251,12 +290,21 @@ func (b RefreshTokenEntity_Builder_ID) ID(arg string) RefreshTokenEntity_Builder
 	return RefreshTokenEntity_Builder_UserID{root: b.root}
 }
 
-type RefreshTokenEntity_Builder_TokenHash struct {
+type RefreshTokenEntity_Builder_FamilyID struct {
 	root *RefreshTokenEntity
 }
 
-func (b RefreshTokenEntity_Builder_UserID) UserID(arg string) RefreshTokenEntity_Builder_TokenHash {
+func (b RefreshTokenEntity_Builder_UserID) UserID(arg string) RefreshTokenEntity_Builder_FamilyID {
 	b.root.UserID = arg
+	return RefreshTokenEntity_Builder_FamilyID{root: b.root}
+}
+
+type RefreshTokenEntity_Builder_TokenHash struct {
+	root *RefreshTokenEntity
+}
+
+func (b RefreshTokenEntity_Builder_FamilyID) FamilyID(arg string) RefreshTokenEntity_Builder_TokenHash {
+	b.root.FamilyID = arg
 	return RefreshTokenEntity_Builder_TokenHash{root: b.root}
 }
 
@@ -300,6 +348,183 @@ func (b RefreshTokenEntity_Builder_GobFinalizer) Build() *RefreshTokenEntity {
 	return b.root
 }
 
+func NewSessionEntityBuilder() SessionEntity_Builder_ID {
+	return SessionEntity_Builder_ID{root: &SessionEntity{}}
+}
+
+type SessionEntity_Builder_ID struct {
+	root *SessionEntity
+}
+
+type SessionEntity_Builder_UserID struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_ID) ID(arg string) SessionEntity_Builder_UserID {
+	b.root.ID = arg
+	return SessionEntity_Builder_UserID{root: b.root}
+}
+
+type SessionEntity_Builder_Source struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_UserID) UserID(arg string) SessionEntity_Builder_Source {
+	b.root.UserID = arg
+	return SessionEntity_Builder_Source{root: b.root}
+}
+
+type SessionEntity_Builder_UserAgent struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_Source) Source(arg string) SessionEntity_Builder_UserAgent {
+	b.root.Source = arg
+	return SessionEntity_Builder_UserAgent{root: b.root}
+}
+
+type SessionEntity_Builder_IPAddress struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_UserAgent) UserAgent(arg *string) SessionEntity_Builder_IPAddress {
+	b.root.UserAgent = arg
+	return SessionEntity_Builder_IPAddress{root: b.root}
+}
+
+type SessionEntity_Builder_OAuthClientID struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_IPAddress) IPAddress(arg *string) SessionEntity_Builder_OAuthClientID {
+	b.root.IPAddress = arg
+	return SessionEntity_Builder_OAuthClientID{root: b.root}
+}
+
+type SessionEntity_Builder_CreatedAt struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_OAuthClientID) OAuthClientID(arg *string) SessionEntity_Builder_CreatedAt {
+	b.root.OAuthClientID = arg
+	return SessionEntity_Builder_CreatedAt{root: b.root}
+}
+
+type SessionEntity_Builder_LastUsedAt struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_CreatedAt) CreatedAt(arg time.Time) SessionEntity_Builder_LastUsedAt {
+	b.root.CreatedAt = arg
+	return SessionEntity_Builder_LastUsedAt{root: b.root}
+}
+
+type SessionEntity_Builder_GobFinalizer struct {
+	root *SessionEntity
+}
+
+func (b SessionEntity_Builder_LastUsedAt) LastUsedAt(arg time.Time) SessionEntity_Builder_GobFinalizer {
+	b.root.LastUsedAt = arg
+	return SessionEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b SessionEntity_Builder_GobFinalizer) Build() *SessionEntity {
+	return b.root
+}
+
+func NewAuthUserIdentityEntityBuilder() AuthUserIdentityEntity_Builder_ID {
+	return AuthUserIdentityEntity_Builder_ID{root: &AuthUserIdentityEntity{}}
+}
+
+type AuthUserIdentityEntity_Builder_ID struct {
+	root *AuthUserIdentityEntity
+}
+
+type AuthUserIdentityEntity_Builder_UserID struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_ID) ID(arg string) AuthUserIdentityEntity_Builder_UserID {
+	b.root.ID = arg
+	return AuthUserIdentityEntity_Builder_UserID{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_Provider struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_UserID) UserID(arg string) AuthUserIdentityEntity_Builder_Provider {
+	b.root.UserID = arg
+	return AuthUserIdentityEntity_Builder_Provider{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_ProviderUserID struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_Provider) Provider(arg string) AuthUserIdentityEntity_Builder_ProviderUserID {
+	b.root.Provider = arg
+	return AuthUserIdentityEntity_Builder_ProviderUserID{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_AccessToken struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_ProviderUserID) ProviderUserID(arg string) AuthUserIdentityEntity_Builder_AccessToken {
+	b.root.ProviderUserID = arg
+	return AuthUserIdentityEntity_Builder_AccessToken{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_RefreshToken struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_AccessToken) AccessToken(arg *string) AuthUserIdentityEntity_Builder_RefreshToken {
+	b.root.AccessToken = arg
+	return AuthUserIdentityEntity_Builder_RefreshToken{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_TokenExpiresAt struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_RefreshToken) RefreshToken(arg *string) AuthUserIdentityEntity_Builder_TokenExpiresAt {
+	b.root.RefreshToken = arg
+	return AuthUserIdentityEntity_Builder_TokenExpiresAt{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_CreatedAt struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_TokenExpiresAt) TokenExpiresAt(arg *time.Time) AuthUserIdentityEntity_Builder_CreatedAt {
+	b.root.TokenExpiresAt = arg
+	return AuthUserIdentityEntity_Builder_CreatedAt{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_UpdatedAt struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_CreatedAt) CreatedAt(arg time.Time) AuthUserIdentityEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return AuthUserIdentityEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type AuthUserIdentityEntity_Builder_GobFinalizer struct {
+	root *AuthUserIdentityEntity
+}
+
+func (b AuthUserIdentityEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) AuthUserIdentityEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return AuthUserIdentityEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuthUserIdentityEntity_Builder_GobFinalizer) Build() *AuthUserIdentityEntity {
+	return b.root
+}
+
 func NewUserProfileEntityBuilder() UserProfileEntity_Builder_ID {
 	return UserProfileEntity_Builder_ID{root: &UserProfileEntity{}}
 }
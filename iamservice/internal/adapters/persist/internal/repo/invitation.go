@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type InvitationEntity struct { //+gob:Constructor
+	ID         string     `db:"id"`
+	TenantID   string     `db:"tenant_id"`
+	Email      string     `db:"email"`
+	Roles      []string   `db:"roles"`
+	TokenHash  string     `db:"token_hash"`
+	InvitedBy  *string    `db:"invited_by"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	AcceptedAt *time.Time `db:"accepted_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+func InsertInvitation(ctx context.Context, tx pgx.Tx, ent *InvitationEntity) error {
+	_, err := tx.Exec(ctx, insertInvitationSql, pgx.NamedArgs{
+		"id":          ent.ID,
+		"tenant_id":   ent.TenantID,
+		"email":       ent.Email,
+		"roles":       ent.Roles,
+		"token_hash":  ent.TokenHash,
+		"invited_by":  ent.InvitedBy,
+		"expires_at":  ent.ExpiresAt,
+		"accepted_at": ent.AcceptedAt,
+		"created_at":  ent.CreatedAt,
+	})
+	return err
+}
+
+func SelectPendingInvitationsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]InvitationEntity, error) {
+	rows, _ := tx.Query(ctx, selectPendingInvitationsByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[InvitationEntity])
+}
+
+func SelectPendingInvitationByID(
+	ctx context.Context, tx pgx.Tx, tenantID string, invitationID string,
+) (*InvitationEntity, error) {
+	rows, _ := tx.Query(ctx, selectPendingInvitationByIdSql, pgx.NamedArgs{
+		"id":        invitationID,
+		"tenant_id": tenantID,
+	})
+	return collectOptionalInvitation(rows)
+}
+
+func SelectPendingInvitationByTokenHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*InvitationEntity, error) {
+	rows, _ := tx.Query(ctx, selectPendingInvitationByTokenHashSql, pgx.NamedArgs{"token_hash": tokenHash})
+	return collectOptionalInvitation(rows)
+}
+
+func UpdateInvitationToken(
+	ctx context.Context, tx pgx.Tx, invitationID string, tokenHash string, expiresAt time.Time,
+) (int64, error) {
+	tag, err := tx.Exec(ctx, updateInvitationTokenSql, pgx.NamedArgs{
+		"id":         invitationID,
+		"token_hash": tokenHash,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func UpdateInvitationAcceptedAt(ctx context.Context, tx pgx.Tx, invitationID string, acceptedAt time.Time) error {
+	_, err := tx.Exec(ctx, updateInvitationAcceptedAtSql, pgx.NamedArgs{
+		"id":          invitationID,
+		"accepted_at": acceptedAt,
+	})
+	return err
+}
+
+func DeleteInvitation(ctx context.Context, tx pgx.Tx, tenantID string, invitationID string) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteInvitationSql, pgx.NamedArgs{
+		"id":        invitationID,
+		"tenant_id": tenantID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func collectOptionalInvitation(rows pgx.Rows) (*InvitationEntity, error) {
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[InvitationEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewInvitationEntityBuilder() InvitationEntity_Builder_ID {
+	return InvitationEntity_Builder_ID{root: &InvitationEntity{}}
+}
+
+type InvitationEntity_Builder_ID struct {
+	root *InvitationEntity
+}
+
+type InvitationEntity_Builder_TenantID struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_ID) ID(arg string) InvitationEntity_Builder_TenantID {
+	b.root.ID = arg
+	return InvitationEntity_Builder_TenantID{root: b.root}
+}
+
+type InvitationEntity_Builder_Email struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_TenantID) TenantID(arg string) InvitationEntity_Builder_Email {
+	b.root.TenantID = arg
+	return InvitationEntity_Builder_Email{root: b.root}
+}
+
+type InvitationEntity_Builder_Roles struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_Email) Email(arg string) InvitationEntity_Builder_Roles {
+	b.root.Email = arg
+	return InvitationEntity_Builder_Roles{root: b.root}
+}
+
+type InvitationEntity_Builder_TokenHash struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_Roles) Roles(arg []string) InvitationEntity_Builder_TokenHash {
+	b.root.Roles = arg
+	return InvitationEntity_Builder_TokenHash{root: b.root}
+}
+
+type InvitationEntity_Builder_InvitedBy struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_TokenHash) TokenHash(arg string) InvitationEntity_Builder_InvitedBy {
+	b.root.TokenHash = arg
+	return InvitationEntity_Builder_InvitedBy{root: b.root}
+}
+
+type InvitationEntity_Builder_ExpiresAt struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_InvitedBy) InvitedBy(arg *string) InvitationEntity_Builder_ExpiresAt {
+	b.root.InvitedBy = arg
+	return InvitationEntity_Builder_ExpiresAt{root: b.root}
+}
+
+type InvitationEntity_Builder_AcceptedAt struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_ExpiresAt) ExpiresAt(arg time.Time) InvitationEntity_Builder_AcceptedAt {
+	b.root.ExpiresAt = arg
+	return InvitationEntity_Builder_AcceptedAt{root: b.root}
+}
+
+type InvitationEntity_Builder_CreatedAt struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_AcceptedAt) AcceptedAt(arg *time.Time) InvitationEntity_Builder_CreatedAt {
+	b.root.AcceptedAt = arg
+	return InvitationEntity_Builder_CreatedAt{root: b.root}
+}
+
+type InvitationEntity_Builder_GobFinalizer struct {
+	root *InvitationEntity
+}
+
+func (b InvitationEntity_Builder_CreatedAt) CreatedAt(arg time.Time) InvitationEntity_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return InvitationEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b InvitationEntity_Builder_GobFinalizer) Build() *InvitationEntity {
+	return b.root
+}
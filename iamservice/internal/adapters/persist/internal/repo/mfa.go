@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type UserMFAEntity struct { //+gob:Constructor
+	UserID       string     `db:"user_id"`
+	TOTPSecret   string     `db:"totp_secret"`
+	ConfirmedAt  *time.Time `db:"confirmed_at"`
+	LastUsedStep *int64     `db:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
+
+func SelectUserMFAByUserID(ctx context.Context, tx pgx.Tx, userID string) (*UserMFAEntity, error) {
+	rows, _ := tx.Query(ctx, selectUserMfaByUserIdSql, pgx.NamedArgs{"user_id": userID})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[UserMFAEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+// UpsertUserMFA stores a new TOTP secret, an existing authenticator is replaced and must be confirmed again
+func UpsertUserMFA(ctx context.Context, tx pgx.Tx, userID string, totpSecret string, now time.Time) error {
+	_, err := tx.Exec(ctx, upsertUserMfaSql, pgx.NamedArgs{
+		"user_id":     userID,
+		"totp_secret": totpSecret,
+		"now":         now,
+	})
+	return err
+}
+
+func ConfirmUserMFA(ctx context.Context, tx pgx.Tx, userID string) error {
+	_, err := tx.Exec(ctx, confirmUserMfaSql, pgx.NamedArgs{"user_id": userID})
+	return err
+}
+
+// UpdateUserMFALastUsedStep records the accepted TOTP time step, returning the number of rows updated.
+// Steps not newer than the last accepted one are not updated, so a code cannot be used twice.
+func UpdateUserMFALastUsedStep(ctx context.Context, tx pgx.Tx, userID string, step int64) (int64, error) {
+	cmd, err := tx.Exec(ctx, updateUserMfaLastUsedStepSql, pgx.NamedArgs{
+		"user_id": userID,
+		"step":    step,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func DeleteUserMFA(ctx context.Context, tx pgx.Tx, userID string) error {
+	_, err := tx.Exec(ctx, deleteUserMfaSql, pgx.NamedArgs{"user_id": userID})
+	return err
+}
+
+func DeleteRecoveryCodesByUserID(ctx context.Context, tx pgx.Tx, userID string) error {
+	_, err := tx.Exec(ctx, deleteRecoveryCodesByUserIdSql, pgx.NamedArgs{"user_id": userID})
+	return err
+}
+
+func InsertRecoveryCode(ctx context.Context, tx pgx.Tx, id string, userID string, codeHash string, createdAt time.Time) error {
+	_, err := tx.Exec(ctx, insertRecoveryCodeSql, pgx.NamedArgs{
+		"id":         id,
+		"user_id":    userID,
+		"code_hash":  codeHash,
+		"created_at": createdAt,
+	})
+	return err
+}
+
+// MarkRecoveryCodeAsUsed marks an unused recovery code as used, returning the number of rows updated
+func MarkRecoveryCodeAsUsed(ctx context.Context, tx pgx.Tx, userID string, codeHash string) (int64, error) {
+	cmd, err := tx.Exec(ctx, markRecoveryCodeAsUsedSql, pgx.NamedArgs{
+		"user_id":   userID,
+		"code_hash": codeHash,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func CountUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string) (int, error) {
+	var count int
+	err := tx.QueryRow(ctx, countUnusedRecoveryCodesSql, pgx.NamedArgs{"user_id": userID}).Scan(&count)
+	return count, err
+}
+
+// InsertMfaChallengeUse records a used MFA challenge token, returning 0 when it was already used
+func InsertMfaChallengeUse(ctx context.Context, tx pgx.Tx, jti string, expiresAt time.Time) (int64, error) {
+	cmd, err := tx.Exec(ctx, insertMfaChallengeUseSql, pgx.NamedArgs{
+		"jti":        jti,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func DeleteExpiredMfaChallengeUses(ctx context.Context, tx pgx.Tx, now time.Time) error {
+	_, err := tx.Exec(ctx, deleteExpiredMfaChallengeUsesSql, pgx.NamedArgs{"now": now})
+	return err
+}
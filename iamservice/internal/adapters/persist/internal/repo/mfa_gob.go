@@ -0,0 +1,73 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewUserMFAEntityBuilder() UserMFAEntity_Builder_UserID {
+	return UserMFAEntity_Builder_UserID{root: &UserMFAEntity{}}
+}
+
+type UserMFAEntity_Builder_UserID struct {
+	root *UserMFAEntity
+}
+
+type UserMFAEntity_Builder_TOTPSecret struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_UserID) UserID(arg string) UserMFAEntity_Builder_TOTPSecret {
+	b.root.UserID = arg
+	return UserMFAEntity_Builder_TOTPSecret{root: b.root}
+}
+
+type UserMFAEntity_Builder_ConfirmedAt struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_TOTPSecret) TOTPSecret(arg string) UserMFAEntity_Builder_ConfirmedAt {
+	b.root.TOTPSecret = arg
+	return UserMFAEntity_Builder_ConfirmedAt{root: b.root}
+}
+
+type UserMFAEntity_Builder_LastUsedStep struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_ConfirmedAt) ConfirmedAt(arg *time.Time) UserMFAEntity_Builder_LastUsedStep {
+	b.root.ConfirmedAt = arg
+	return UserMFAEntity_Builder_LastUsedStep{root: b.root}
+}
+
+type UserMFAEntity_Builder_CreatedAt struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_LastUsedStep) LastUsedStep(arg *int64) UserMFAEntity_Builder_CreatedAt {
+	b.root.LastUsedStep = arg
+	return UserMFAEntity_Builder_CreatedAt{root: b.root}
+}
+
+type UserMFAEntity_Builder_UpdatedAt struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_CreatedAt) CreatedAt(arg time.Time) UserMFAEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return UserMFAEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type UserMFAEntity_Builder_GobFinalizer struct {
+	root *UserMFAEntity
+}
+
+func (b UserMFAEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) UserMFAEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return UserMFAEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserMFAEntity_Builder_GobFinalizer) Build() *UserMFAEntity {
+	return b.root
+}
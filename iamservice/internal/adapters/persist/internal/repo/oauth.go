@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type OAuthClientEntity struct { //+gob:Constructor
+	ID               string    `db:"id"`
+	TenantID         string    `db:"tenant_id"`
+	Name             string    `db:"name"`
+	ClientSecretHash *string   `db:"client_secret_hash"`
+	RedirectURIs     []string  `db:"redirect_uris"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func SelectOAuthClientByID(ctx context.Context, tx pgx.Tx, clientID string) (*OAuthClientEntity, error) {
+	rows, _ := tx.Query(ctx, selectOAuthClientByIdSql, pgx.NamedArgs{"id": clientID})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[OAuthClientEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+func SelectOAuthClientsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]OAuthClientEntity, error) {
+	rows, _ := tx.Query(ctx, selectOAuthClientsByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[OAuthClientEntity])
+}
+
+func InsertOAuthClient(ctx context.Context, tx pgx.Tx, ent *OAuthClientEntity) error {
+	_, err := tx.Exec(ctx, insertOAuthClientSql, pgx.NamedArgs{
+		"id":                 ent.ID,
+		"tenant_id":          ent.TenantID,
+		"name":               ent.Name,
+		"client_secret_hash": ent.ClientSecretHash,
+		"redirect_uris":      ent.RedirectURIs,
+		"created_at":         ent.CreatedAt,
+		"updated_at":         ent.UpdatedAt,
+	})
+	return err
+}
+
+func DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteOAuthClientSql, pgx.NamedArgs{"id": clientID})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteOAuthClientSessions deletes sessions started for the client and returns their IDs
+func DeleteOAuthClientSessions(ctx context.Context, tx pgx.Tx, clientID string) ([]string, error) {
+	rows, _ := tx.Query(ctx, deleteOAuthClientSessionsSql, pgx.NamedArgs{"client_id": clientID})
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func InsertOAuthAuthorizationCode(ctx context.Context, tx pgx.Tx, code *model.OAuthAuthorizationCode) error {
+	args := pgx.NamedArgs{
+		"id":                    code.ID,
+		"client_id":             code.ClientID,
+		"user_id":               code.UserID,
+		"code_hash":             code.CodeHash,
+		"redirect_uri":          code.RedirectURI,
+		"scope":                 code.Scope,
+		"nonce":                 code.Nonce,
+		"code_challenge":        code.CodeChallenge,
+		"code_challenge_method": code.CodeChallengeMethod,
+		"auth_time":             code.AuthTime,
+		"expires_at":            code.ExpiresAt,
+		"created_at":            code.CreatedAt,
+	}
+	_, err := tx.Exec(ctx, insertOAuthAuthorizationCodeSql, args)
+	return err
+}
+
+func GetOAuthAuthorizationCodeByHash(ctx context.Context, tx pgx.Tx, codeHash string) (*model.OAuthAuthorizationCode, error) {
+	args := pgx.NamedArgs{"code_hash": codeHash}
+
+	var code model.OAuthAuthorizationCode
+	err := tx.QueryRow(ctx, selectOAuthAuthorizationCodeByHashSql, args).Scan(
+		&code.ID,
+		&code.ClientID,
+		&code.UserID,
+		&code.CodeHash,
+		&code.RedirectURI,
+		&code.Scope,
+		&code.Nonce,
+		&code.CodeChallenge,
+		&code.CodeChallengeMethod,
+		&code.AuthTime,
+		&code.ExpiresAt,
+		&code.UsedAt,
+		&code.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+// MarkOAuthAuthorizationCodeAsUsed marks the code as used, returning the number of rows updated.
+// A code that was already used is not updated, so concurrent exchanges of the same code cannot both succeed.
+func MarkOAuthAuthorizationCodeAsUsed(ctx context.Context, tx pgx.Tx, codeID string) (int64, error) {
+	cmd, err := tx.Exec(ctx, markOAuthAuthorizationCodeAsUsedSql, pgx.NamedArgs{"id": codeID})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
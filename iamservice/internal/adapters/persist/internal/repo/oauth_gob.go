@@ -0,0 +1,82 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewOAuthClientEntityBuilder() OAuthClientEntity_Builder_ID {
+	return OAuthClientEntity_Builder_ID{root: &OAuthClientEntity{}}
+}
+
+type OAuthClientEntity_Builder_ID struct {
+	root *OAuthClientEntity
+}
+
+type OAuthClientEntity_Builder_TenantID struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_ID) ID(arg string) OAuthClientEntity_Builder_TenantID {
+	b.root.ID = arg
+	return OAuthClientEntity_Builder_TenantID{root: b.root}
+}
+
+type OAuthClientEntity_Builder_Name struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_TenantID) TenantID(arg string) OAuthClientEntity_Builder_Name {
+	b.root.TenantID = arg
+	return OAuthClientEntity_Builder_Name{root: b.root}
+}
+
+type OAuthClientEntity_Builder_ClientSecretHash struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_Name) Name(arg string) OAuthClientEntity_Builder_ClientSecretHash {
+	b.root.Name = arg
+	return OAuthClientEntity_Builder_ClientSecretHash{root: b.root}
+}
+
+type OAuthClientEntity_Builder_RedirectURIs struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_ClientSecretHash) ClientSecretHash(arg *string) OAuthClientEntity_Builder_RedirectURIs {
+	b.root.ClientSecretHash = arg
+	return OAuthClientEntity_Builder_RedirectURIs{root: b.root}
+}
+
+type OAuthClientEntity_Builder_CreatedAt struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_RedirectURIs) RedirectURIs(arg []string) OAuthClientEntity_Builder_CreatedAt {
+	b.root.RedirectURIs = arg
+	return OAuthClientEntity_Builder_CreatedAt{root: b.root}
+}
+
+type OAuthClientEntity_Builder_UpdatedAt struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_CreatedAt) CreatedAt(arg time.Time) OAuthClientEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return OAuthClientEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type OAuthClientEntity_Builder_GobFinalizer struct {
+	root *OAuthClientEntity
+}
+
+func (b OAuthClientEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) OAuthClientEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return OAuthClientEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthClientEntity_Builder_GobFinalizer) Build() *OAuthClientEntity {
+	return b.root
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type OutboxEmailEntity struct { //+gob:Constructor
+	ID            string     `db:"id"`
+	Recipient     string     `db:"recipient"`
+	Subject       string     `db:"subject"`
+	HTMLBody      string     `db:"html_body"`
+	TextBody      string     `db:"text_body"`
+	ReplyTo       *string    `db:"reply_to"`
+	Headers       []byte     `db:"headers"`     // JSON encoded map of header values by name
+	Attachments   []byte     `db:"attachments"` // JSON encoded list of attachments
+	Status        string     `db:"status"`
+	Attempts      int        `db:"attempts"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	LastError     *string    `db:"last_error"`
+	CreatedAt     time.Time  `db:"created_at"`
+	SentAt        *time.Time `db:"sent_at"`
+}
+
+func InsertOutboxEmail(ctx context.Context, tx pgx.Tx, ent *OutboxEmailEntity) error {
+	_, err := tx.Exec(ctx, insertOutboxEmailSql, pgx.NamedArgs{
+		"id":              ent.ID,
+		"recipient":       ent.Recipient,
+		"subject":         ent.Subject,
+		"html_body":       ent.HTMLBody,
+		"text_body":       ent.TextBody,
+		"reply_to":        ent.ReplyTo,
+		"headers":         ent.Headers,
+		"attachments":     ent.Attachments,
+		"status":          ent.Status,
+		"attempts":        ent.Attempts,
+		"next_attempt_at": ent.NextAttemptAt,
+		"last_error":      ent.LastError,
+		"created_at":      ent.CreatedAt,
+		"sent_at":         ent.SentAt,
+	})
+	return err
+}
+
+func ClaimDueOutboxEmails(
+	ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+) ([]OutboxEmailEntity, error) {
+	rows, _ := tx.Query(ctx, claimDueOutboxEmailsSql, pgx.NamedArgs{
+		"now":           now,
+		"claimed_until": claimedUntil,
+		"limit":         limit,
+	})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[OutboxEmailEntity])
+}
+
+func UpdateOutboxEmailAsSent(ctx context.Context, tx pgx.Tx, emailID string, sentAt time.Time) error {
+	_, err := tx.Exec(ctx, updateOutboxEmailAsSentSql, pgx.NamedArgs{
+		"id":      emailID,
+		"sent_at": sentAt,
+	})
+	return err
+}
+
+func UpdateOutboxEmailAsFailed(
+	ctx context.Context, tx pgx.Tx, emailID string, lastError string, nextAttemptAt *time.Time,
+) error {
+	_, err := tx.Exec(ctx, updateOutboxEmailAsFailedSql, pgx.NamedArgs{
+		"id":              emailID,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+	return err
+}
+
+func SelectFailedOutboxEmails(
+	ctx context.Context, tx pgx.Tx, status *string, offset int, limit int,
+) ([]OutboxEmailEntity, error) {
+	rows, _ := tx.Query(ctx, selectFailedOutboxEmailsSql, pgx.NamedArgs{
+		"status": status,
+		"offset": offset,
+		"limit":  limit,
+	})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[OutboxEmailEntity])
+}
+
+func CountFailedOutboxEmails(ctx context.Context, tx pgx.Tx, status *string) (int, error) {
+	var total int
+	err := tx.QueryRow(ctx, countFailedOutboxEmailsSql, pgx.NamedArgs{"status": status}).Scan(&total)
+	return total, err
+}
+
+func UpdateOutboxEmailForRetry(ctx context.Context, tx pgx.Tx, emailID string, now time.Time) (int64, error) {
+	tag, err := tx.Exec(ctx, updateOutboxEmailForRetrySql, pgx.NamedArgs{
+		"id":  emailID,
+		"now": now,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,145 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewOutboxEmailEntityBuilder() OutboxEmailEntity_Builder_ID {
+	return OutboxEmailEntity_Builder_ID{root: &OutboxEmailEntity{}}
+}
+
+type OutboxEmailEntity_Builder_ID struct {
+	root *OutboxEmailEntity
+}
+
+type OutboxEmailEntity_Builder_Recipient struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_ID) ID(arg string) OutboxEmailEntity_Builder_Recipient {
+	b.root.ID = arg
+	return OutboxEmailEntity_Builder_Recipient{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_Subject struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Recipient) Recipient(arg string) OutboxEmailEntity_Builder_Subject {
+	b.root.Recipient = arg
+	return OutboxEmailEntity_Builder_Subject{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_HTMLBody struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Subject) Subject(arg string) OutboxEmailEntity_Builder_HTMLBody {
+	b.root.Subject = arg
+	return OutboxEmailEntity_Builder_HTMLBody{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_TextBody struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_HTMLBody) HTMLBody(arg string) OutboxEmailEntity_Builder_TextBody {
+	b.root.HTMLBody = arg
+	return OutboxEmailEntity_Builder_TextBody{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_ReplyTo struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_TextBody) TextBody(arg string) OutboxEmailEntity_Builder_ReplyTo {
+	b.root.TextBody = arg
+	return OutboxEmailEntity_Builder_ReplyTo{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_Headers struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_ReplyTo) ReplyTo(arg *string) OutboxEmailEntity_Builder_Headers {
+	b.root.ReplyTo = arg
+	return OutboxEmailEntity_Builder_Headers{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_Attachments struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Headers) Headers(arg []byte) OutboxEmailEntity_Builder_Attachments {
+	b.root.Headers = arg
+	return OutboxEmailEntity_Builder_Attachments{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_Status struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Attachments) Attachments(arg []byte) OutboxEmailEntity_Builder_Status {
+	b.root.Attachments = arg
+	return OutboxEmailEntity_Builder_Status{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_Attempts struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Status) Status(arg string) OutboxEmailEntity_Builder_Attempts {
+	b.root.Status = arg
+	return OutboxEmailEntity_Builder_Attempts{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_NextAttemptAt struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_Attempts) Attempts(arg int) OutboxEmailEntity_Builder_NextAttemptAt {
+	b.root.Attempts = arg
+	return OutboxEmailEntity_Builder_NextAttemptAt{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_LastError struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) OutboxEmailEntity_Builder_LastError {
+	b.root.NextAttemptAt = arg
+	return OutboxEmailEntity_Builder_LastError{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_CreatedAt struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_LastError) LastError(arg *string) OutboxEmailEntity_Builder_CreatedAt {
+	b.root.LastError = arg
+	return OutboxEmailEntity_Builder_CreatedAt{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_SentAt struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_CreatedAt) CreatedAt(arg time.Time) OutboxEmailEntity_Builder_SentAt {
+	b.root.CreatedAt = arg
+	return OutboxEmailEntity_Builder_SentAt{root: b.root}
+}
+
+type OutboxEmailEntity_Builder_GobFinalizer struct {
+	root *OutboxEmailEntity
+}
+
+func (b OutboxEmailEntity_Builder_SentAt) SentAt(arg *time.Time) OutboxEmailEntity_Builder_GobFinalizer {
+	b.root.SentAt = arg
+	return OutboxEmailEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OutboxEmailEntity_Builder_GobFinalizer) Build() *OutboxEmailEntity {
+	return b.root
+}
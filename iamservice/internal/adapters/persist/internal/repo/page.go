@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// sortColumn is a column a listing can be sorted by and the type its cursor value is cast to
+type sortColumn struct {
+	column     string
+	cursorType string
+}
+
+// pageSql returns the keyset condition, ordering and limit of a page to append to a query that ends with its
+// WHERE clause. Only whitelisted columns are written into the SQL, cursor values are passed as arguments.
+func pageSql(columns map[string]sortColumn, idColumn string, page *model.PageRequest, args pgx.NamedArgs) (string, error) {
+	sort, ok := columns[page.SortBy]
+	if !ok {
+		return "", fmt.Errorf("unsupported sort field: %s", page.SortBy)
+	}
+	direction, comparison := "ASC", ">"
+	if page.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	var sql strings.Builder
+	if page.After != nil {
+		fmt.Fprintf(&sql, "  AND (%s, %s) %s (@cursor_value::%s, @cursor_id)\n",
+			sort.column, idColumn, comparison, sort.cursorType)
+		args["cursor_value"] = page.After.Value
+		args["cursor_id"] = page.After.ID
+		args["offset"] = 0
+	} else {
+		args["offset"] = page.Offset
+	}
+	fmt.Fprintf(&sql, "ORDER BY %s %s, %s %s\nLIMIT @limit OFFSET @offset\n", sort.column, direction, idColumn, direction)
+	args["limit"] = page.Limit
+	return sql.String(), nil
+}
+
+// containsPattern returns an ILIKE pattern matching values that contain the given text
+func containsPattern(text *string) *string {
+	if text == nil {
+		return nil
+	}
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(*text) + "%"
+	return &pattern
+}
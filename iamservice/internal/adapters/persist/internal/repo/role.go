@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type PermissionEntity struct { //+gob:Constructor
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	SystemOnly  bool   `db:"system_only"`
+}
+
+type RoleEntity struct { //+gob:Constructor
+	ID          *int      `db:"id"`
+	TenantID    *string   `db:"tenant_id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+	Permissions []string  `db:"permissions"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func SelectPermissions(ctx context.Context, tx pgx.Tx) ([]PermissionEntity, error) {
+	rows, _ := tx.Query(ctx, selectPermissionsSql)
+	return pgx.CollectRows(rows, pgx.RowToStructByName[PermissionEntity])
+}
+
+func SelectRolesByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]RoleEntity, error) {
+	rows, _ := tx.Query(ctx, selectRolesByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[RoleEntity])
+}
+
+func SelectRoleByTenantIDAndName(ctx context.Context, tx pgx.Tx, tenantID string, name string) (*RoleEntity, error) {
+	rows, _ := tx.Query(ctx, selectRoleByTenantIdAndNameSql, pgx.NamedArgs{
+		"tenant_id": tenantID,
+		"name":      name,
+	})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[RoleEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+// InsertRole inserts a role without its permissions and returns its ID
+func InsertRole(ctx context.Context, tx pgx.Tx, ent *RoleEntity) (int, error) {
+	var id int
+	err := tx.QueryRow(ctx, insertRoleSql, pgx.NamedArgs{
+		"tenant_id":   ent.TenantID,
+		"name":        ent.Name,
+		"description": ent.Description,
+		"created_at":  ent.CreatedAt,
+		"updated_at":  ent.UpdatedAt,
+	}).Scan(&id)
+	return id, err
+}
+
+func UpdateRole(ctx context.Context, tx pgx.Tx, ent *RoleEntity) (int64, error) {
+	tag, err := tx.Exec(ctx, updateRoleSql, pgx.NamedArgs{
+		"id":          ent.ID,
+		"description": ent.Description,
+		"updated_at":  ent.UpdatedAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func DeleteRole(ctx context.Context, tx pgx.Tx, roleID int) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteRoleSql, pgx.NamedArgs{"id": roleID})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// InsertRolePermissions grants the known permissions out of the given ones to the role and returns their count
+func InsertRolePermissions(ctx context.Context, tx pgx.Tx, roleID int, permissions []string) (int64, error) {
+	tag, err := tx.Exec(ctx, insertRolePermissionsSql, pgx.NamedArgs{
+		"role_id":     roleID,
+		"permissions": permissions,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func DeleteRolePermissions(ctx context.Context, tx pgx.Tx, roleID int) error {
+	_, err := tx.Exec(ctx, deleteRolePermissionsSql, pgx.NamedArgs{"role_id": roleID})
+	return err
+}
+
+func SelectPermissionsByRoles(ctx context.Context, tx pgx.Tx, tenantID string, roles []string) ([]string, error) {
+	rows, _ := tx.Query(ctx, selectPermissionsByRolesSql, pgx.NamedArgs{
+		"tenant_id": tenantID,
+		"roles":     roles,
+	})
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func SelectUserIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error) {
+	rows, _ := tx.Query(ctx, selectUserIdsByRoleIdSql, pgx.NamedArgs{"role_id": roleID})
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func SelectServiceClientIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error) {
+	rows, _ := tx.Query(ctx, selectServiceClientIdsByRoleIdSql, pgx.NamedArgs{"role_id": roleID})
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
@@ -0,0 +1,121 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewPermissionEntityBuilder() PermissionEntity_Builder_Name {
+	return PermissionEntity_Builder_Name{root: &PermissionEntity{}}
+}
+
+type PermissionEntity_Builder_Name struct {
+	root *PermissionEntity
+}
+
+type PermissionEntity_Builder_Description struct {
+	root *PermissionEntity
+}
+
+func (b PermissionEntity_Builder_Name) Name(arg string) PermissionEntity_Builder_Description {
+	b.root.Name = arg
+	return PermissionEntity_Builder_Description{root: b.root}
+}
+
+type PermissionEntity_Builder_SystemOnly struct {
+	root *PermissionEntity
+}
+
+func (b PermissionEntity_Builder_Description) Description(arg string) PermissionEntity_Builder_SystemOnly {
+	b.root.Description = arg
+	return PermissionEntity_Builder_SystemOnly{root: b.root}
+}
+
+type PermissionEntity_Builder_GobFinalizer struct {
+	root *PermissionEntity
+}
+
+func (b PermissionEntity_Builder_SystemOnly) SystemOnly(arg bool) PermissionEntity_Builder_GobFinalizer {
+	b.root.SystemOnly = arg
+	return PermissionEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b PermissionEntity_Builder_GobFinalizer) Build() *PermissionEntity {
+	return b.root
+}
+
+func NewRoleEntityBuilder() RoleEntity_Builder_ID {
+	return RoleEntity_Builder_ID{root: &RoleEntity{}}
+}
+
+type RoleEntity_Builder_ID struct {
+	root *RoleEntity
+}
+
+type RoleEntity_Builder_TenantID struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_ID) ID(arg *int) RoleEntity_Builder_TenantID {
+	b.root.ID = arg
+	return RoleEntity_Builder_TenantID{root: b.root}
+}
+
+type RoleEntity_Builder_Name struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_TenantID) TenantID(arg *string) RoleEntity_Builder_Name {
+	b.root.TenantID = arg
+	return RoleEntity_Builder_Name{root: b.root}
+}
+
+type RoleEntity_Builder_Description struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_Name) Name(arg string) RoleEntity_Builder_Description {
+	b.root.Name = arg
+	return RoleEntity_Builder_Description{root: b.root}
+}
+
+type RoleEntity_Builder_Permissions struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_Description) Description(arg *string) RoleEntity_Builder_Permissions {
+	b.root.Description = arg
+	return RoleEntity_Builder_Permissions{root: b.root}
+}
+
+type RoleEntity_Builder_CreatedAt struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_Permissions) Permissions(arg []string) RoleEntity_Builder_CreatedAt {
+	b.root.Permissions = arg
+	return RoleEntity_Builder_CreatedAt{root: b.root}
+}
+
+type RoleEntity_Builder_UpdatedAt struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_CreatedAt) CreatedAt(arg time.Time) RoleEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return RoleEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type RoleEntity_Builder_GobFinalizer struct {
+	root *RoleEntity
+}
+
+func (b RoleEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) RoleEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return RoleEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b RoleEntity_Builder_GobFinalizer) Build() *RoleEntity {
+	return b.root
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type ServiceClientEntity struct { //+gob:Constructor
+	ID               string     `db:"id"`
+	TenantID         string     `db:"tenant_id"`
+	Name             string     `db:"name"`
+	ClientSecretHash string     `db:"client_secret_hash"`
+	Scopes           []string   `db:"scopes"`
+	ExpiresAt        *time.Time `db:"expires_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+	Roles            []string   `db:"roles"`
+}
+
+func InsertServiceClient(ctx context.Context, tx pgx.Tx, ent *ServiceClientEntity) error {
+	_, err := tx.Exec(ctx, insertServiceClientSql, pgx.NamedArgs{
+		"id":                 ent.ID,
+		"tenant_id":          ent.TenantID,
+		"name":               ent.Name,
+		"client_secret_hash": ent.ClientSecretHash,
+		"scopes":             ent.Scopes,
+		"expires_at":         ent.ExpiresAt,
+		"created_at":         ent.CreatedAt,
+		"updated_at":         ent.UpdatedAt,
+	})
+	return err
+}
+
+// InsertServiceClientRoles assigns roles to the client by name and returns the number of roles found
+func InsertServiceClientRoles(ctx context.Context, tx pgx.Tx, clientID string, roles []string) (int64, error) {
+	tag, err := tx.Exec(ctx, insertServiceClientRolesSql, pgx.NamedArgs{
+		"client_id": clientID,
+		"roles":     roles,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func SelectServiceClientByID(ctx context.Context, tx pgx.Tx, clientID string) (*ServiceClientEntity, error) {
+	rows, _ := tx.Query(ctx, selectServiceClientByIdSql, pgx.NamedArgs{"id": clientID})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[ServiceClientEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+func SelectServiceClientsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]ServiceClientEntity, error) {
+	rows, _ := tx.Query(ctx, selectServiceClientsByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[ServiceClientEntity])
+}
+
+func DeleteServiceClient(ctx context.Context, tx pgx.Tx, clientID string) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteServiceClientSql, pgx.NamedArgs{"id": clientID})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewServiceClientEntityBuilder() ServiceClientEntity_Builder_ID {
+	return ServiceClientEntity_Builder_ID{root: &ServiceClientEntity{}}
+}
+
+type ServiceClientEntity_Builder_ID struct {
+	root *ServiceClientEntity
+}
+
+type ServiceClientEntity_Builder_TenantID struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_ID) ID(arg string) ServiceClientEntity_Builder_TenantID {
+	b.root.ID = arg
+	return ServiceClientEntity_Builder_TenantID{root: b.root}
+}
+
+type ServiceClientEntity_Builder_Name struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_TenantID) TenantID(arg string) ServiceClientEntity_Builder_Name {
+	b.root.TenantID = arg
+	return ServiceClientEntity_Builder_Name{root: b.root}
+}
+
+type ServiceClientEntity_Builder_ClientSecretHash struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_Name) Name(arg string) ServiceClientEntity_Builder_ClientSecretHash {
+	b.root.Name = arg
+	return ServiceClientEntity_Builder_ClientSecretHash{root: b.root}
+}
+
+type ServiceClientEntity_Builder_Scopes struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_ClientSecretHash) ClientSecretHash(arg string) ServiceClientEntity_Builder_Scopes {
+	b.root.ClientSecretHash = arg
+	return ServiceClientEntity_Builder_Scopes{root: b.root}
+}
+
+type ServiceClientEntity_Builder_ExpiresAt struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_Scopes) Scopes(arg []string) ServiceClientEntity_Builder_ExpiresAt {
+	b.root.Scopes = arg
+	return ServiceClientEntity_Builder_ExpiresAt{root: b.root}
+}
+
+type ServiceClientEntity_Builder_CreatedAt struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_ExpiresAt) ExpiresAt(arg *time.Time) ServiceClientEntity_Builder_CreatedAt {
+	b.root.ExpiresAt = arg
+	return ServiceClientEntity_Builder_CreatedAt{root: b.root}
+}
+
+type ServiceClientEntity_Builder_UpdatedAt struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_CreatedAt) CreatedAt(arg time.Time) ServiceClientEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return ServiceClientEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type ServiceClientEntity_Builder_Roles struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) ServiceClientEntity_Builder_Roles {
+	b.root.UpdatedAt = arg
+	return ServiceClientEntity_Builder_Roles{root: b.root}
+}
+
+type ServiceClientEntity_Builder_GobFinalizer struct {
+	root *ServiceClientEntity
+}
+
+func (b ServiceClientEntity_Builder_Roles) Roles(arg []string) ServiceClientEntity_Builder_GobFinalizer {
+	b.root.Roles = arg
+	return ServiceClientEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ServiceClientEntity_Builder_GobFinalizer) Build() *ServiceClientEntity {
+	return b.root
+}
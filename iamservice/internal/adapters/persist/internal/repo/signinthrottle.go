@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type SignInThrottleEntity struct { //+gob:Constructor
+	SubjectType     string     `db:"subject_type"`
+	Subject         string     `db:"subject"`
+	FailedAttempts  int        `db:"failed_attempts"`
+	WindowStartedAt time.Time  `db:"window_started_at"`
+	LockedUntil     *time.Time `db:"locked_until"`
+	LockoutCount    int        `db:"lockout_count"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+func SelectSignInThrottle(ctx context.Context, tx pgx.Tx, subjectType string, subject string) (*SignInThrottleEntity, error) {
+	rows, _ := tx.Query(ctx, selectSignInThrottleSql, pgx.NamedArgs{
+		"subject_type": subjectType,
+		"subject":      subject,
+	})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[SignInThrottleEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+// RecordSignInFailure counts a failed attempt within the window starting at windowStart and returns the
+// number of failures in the current window
+func RecordSignInFailure(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string, windowStart time.Time, now time.Time,
+) (int, error) {
+	var failedAttempts int
+	err := tx.QueryRow(ctx, recordSignInFailureSql, pgx.NamedArgs{
+		"subject_type": subjectType,
+		"subject":      subject,
+		"window_start": windowStart,
+		"now":          now,
+	}).Scan(&failedAttempts)
+	return failedAttempts, err
+}
+
+func LockSignInSubject(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string, lockedUntil time.Time, lockoutCount int,
+	now time.Time,
+) error {
+	_, err := tx.Exec(ctx, lockSignInSubjectSql, pgx.NamedArgs{
+		"subject_type":  subjectType,
+		"subject":       subject,
+		"locked_until":  lockedUntil,
+		"lockout_count": lockoutCount,
+		"now":           now,
+	})
+	return err
+}
+
+func DeleteSignInThrottle(ctx context.Context, tx pgx.Tx, subjectType string, subject string) error {
+	_, err := tx.Exec(ctx, deleteSignInThrottleSql, pgx.NamedArgs{
+		"subject_type": subjectType,
+		"subject":      subject,
+	})
+	return err
+}
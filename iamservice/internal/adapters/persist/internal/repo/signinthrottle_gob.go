@@ -0,0 +1,82 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewSignInThrottleEntityBuilder() SignInThrottleEntity_Builder_SubjectType {
+	return SignInThrottleEntity_Builder_SubjectType{root: &SignInThrottleEntity{}}
+}
+
+type SignInThrottleEntity_Builder_SubjectType struct {
+	root *SignInThrottleEntity
+}
+
+type SignInThrottleEntity_Builder_Subject struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_SubjectType) SubjectType(arg string) SignInThrottleEntity_Builder_Subject {
+	b.root.SubjectType = arg
+	return SignInThrottleEntity_Builder_Subject{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_FailedAttempts struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_Subject) Subject(arg string) SignInThrottleEntity_Builder_FailedAttempts {
+	b.root.Subject = arg
+	return SignInThrottleEntity_Builder_FailedAttempts{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_WindowStartedAt struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_FailedAttempts) FailedAttempts(arg int) SignInThrottleEntity_Builder_WindowStartedAt {
+	b.root.FailedAttempts = arg
+	return SignInThrottleEntity_Builder_WindowStartedAt{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_LockedUntil struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_WindowStartedAt) WindowStartedAt(arg time.Time) SignInThrottleEntity_Builder_LockedUntil {
+	b.root.WindowStartedAt = arg
+	return SignInThrottleEntity_Builder_LockedUntil{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_LockoutCount struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_LockedUntil) LockedUntil(arg *time.Time) SignInThrottleEntity_Builder_LockoutCount {
+	b.root.LockedUntil = arg
+	return SignInThrottleEntity_Builder_LockoutCount{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_UpdatedAt struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_LockoutCount) LockoutCount(arg int) SignInThrottleEntity_Builder_UpdatedAt {
+	b.root.LockoutCount = arg
+	return SignInThrottleEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type SignInThrottleEntity_Builder_GobFinalizer struct {
+	root *SignInThrottleEntity
+}
+
+func (b SignInThrottleEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) SignInThrottleEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return SignInThrottleEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b SignInThrottleEntity_Builder_GobFinalizer) Build() *SignInThrottleEntity {
+	return b.root
+}
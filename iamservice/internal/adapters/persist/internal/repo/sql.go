@@ -27,19 +27,55 @@ WHERE email = @email AND tenant_id = @tenant_id AND is_active = true
 LIMIT 1
 `
 
-const selectAllUsersByTenantIdSql =
+// Filters are skipped when their parameter is NULL
+const userFilterSql = `
+WHERE (@tenant_id::text IS NULL OR u.tenant_id = @tenant_id)
+  AND (@user_id::text IS NULL OR u.id = @user_id)
+  AND (@email::text IS NULL OR u.email = @email::citext)
+  AND (@search::text IS NULL OR u.email ILIKE @search OR u.first_name ILIKE @search OR u.last_name ILIKE @search)
+  AND (@email_verified::boolean IS NULL OR u.email_verified = @email_verified)
+  AND (@is_active::boolean IS NULL OR u.is_active = @is_active)
+  AND (@role::text IS NULL OR EXISTS (
+      SELECT 1
+      FROM iam.auth_user_role ur
+      JOIN iam.auth_role r ON r.id = ur.role_id
+      WHERE ur.user_id = u.id AND r.name = @role))
+`
+
+// Keyset condition, ordering and limit are appended by SelectUsers
+const selectUsersSql =
 /*language=sql*/ `
-SELECT id, email, password_hash, first_name, last_name, tenant_id, is_active, email_verified, created_at, updated_at
-FROM iam.auth_user
-WHERE tenant_id = @tenant_id
-ORDER BY created_at DESC
+SELECT u.id, u.email, u.password_hash, u.first_name, u.last_name, u.tenant_id, u.is_active, u.email_verified,
+       u.created_at, u.updated_at
+FROM iam.auth_user u
+` + userFilterSql
+
+const countUsersSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.auth_user u
+` + userFilterSql
+
+// Applies to the current transaction only
+const setUserSearchThresholdSql =
+/*language=sql*/ `
+SELECT set_config('pg_trgm.word_similarity_threshold', @threshold, true)
 `
 
-const selectAllUsersSql =
+// Query matches a part of the email or a name (<%), or a name is a part of the query (%>) like in full names.
+// All operators are served by the trigram indexes.
+const searchUsersSql =
 /*language=sql*/ `
-SELECT id, email, password_hash, first_name, last_name, tenant_id, is_active, email_verified, created_at, updated_at
-FROM iam.auth_user
-ORDER BY created_at DESC
+SELECT u.id, u.email, u.password_hash, u.first_name, u.last_name, u.tenant_id, u.is_active, u.email_verified,
+       u.created_at, u.updated_at
+FROM iam.auth_user u
+WHERE (@tenant_id::text IS NULL OR u.tenant_id = @tenant_id)
+  AND (@query <% u.email OR @query <% u.first_name OR @query <% u.last_name
+    OR @query %> u.first_name OR @query %> u.last_name)
+ORDER BY GREATEST(word_similarity(@query, u.email),
+                  word_similarity(@query, u.first_name || ' ' || u.last_name)) DESC,
+         u.email, u.id
+LIMIT @limit
 `
 
 const insertUserSql =
@@ -62,6 +98,15 @@ WHERE ur.user_id = @user_id
 ORDER BY r.name
 `
 
+const selectRolesOfUsersSql =
+/*language=sql*/ `
+SELECT ur.user_id, r.name
+FROM iam.auth_role r
+JOIN iam.auth_user_role ur ON r.id = ur.role_id
+WHERE ur.user_id = ANY (@user_ids)
+ORDER BY ur.user_id, r.name
+`
+
 const insertUserRoleSql =
 /*language=sql*/ `
 INSERT INTO iam.auth_user_role (user_id, role_id)
@@ -74,39 +119,52 @@ DELETE FROM iam.auth_user_role
 WHERE user_id = @user_id AND role_id = @role_id
 `
 
-const selectRoleByNameSql =
+const selectUserAssignableRoleByNameSql =
 /*language=sql*/ `
 SELECT id, name, description
 FROM iam.auth_role
 WHERE name = @name
+  AND (tenant_id IS NULL OR tenant_id = (SELECT tenant_id FROM iam.auth_user WHERE id = @user_id))
 LIMIT 1
 `
 
 const selectTenantByIdSql =
 /*language=sql*/ `
-SELECT id, name, description, created_at, updated_at
+SELECT id, name, description, require_admin_mfa, created_at, updated_at
 FROM iam.tenant
 WHERE id = @id
 LIMIT 1
 `
 
-const selectAllTenantsSql =
-/*language=sql*/ `
-SELECT id, name, description, created_at, updated_at
-FROM iam.tenant
-ORDER BY created_at DESC
+// Filters are skipped when their parameter is NULL
+const tenantFilterSql = `
+WHERE (@tenant_id::text IS NULL OR t.id = @tenant_id)
+  AND (@search::text IS NULL OR t.id ILIKE @search OR t.name ILIKE @search)
 `
 
+// Keyset condition, ordering and limit are appended by SelectTenants
+const selectTenantsSql =
+/*language=sql*/ `
+SELECT t.id, t.name, t.description, t.require_admin_mfa, t.created_at, t.updated_at
+FROM iam.tenant t
+` + tenantFilterSql
+
+const countTenantsSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.tenant t
+` + tenantFilterSql
+
 const insertTenantSql =
 /*language=sql*/ `
-INSERT INTO iam.tenant (id, name, description, created_at, updated_at)
-VALUES (@id, @name, @description, @created_at, @updated_at)
+INSERT INTO iam.tenant (id, name, description, require_admin_mfa, created_at, updated_at)
+VALUES (@id, @name, @description, @require_admin_mfa, @created_at, @updated_at)
 `
 
 const updateTenantSql =
 /*language=sql*/ `
 UPDATE iam.tenant
-SET name = @name, description = @description, updated_at = @updated_at
+SET name = @name, description = @description, require_admin_mfa = @require_admin_mfa, updated_at = @updated_at
 WHERE id = @id
 `
 
@@ -134,23 +192,24 @@ RETURNING id, user_id, height, weight, gender, birth_date, is_metric, created_at
 // Refresh token SQL queries
 const insertRefreshTokenSql =
 /*language=sql*/ `
-INSERT INTO iam.auth_refresh_token (id, user_id, token_hash, issued_at, expires_at, revoked)
-VALUES (@id, @user_id, @token_hash, @issued_at, @expires_at, @revoked)
+INSERT INTO iam.auth_refresh_token (id, user_id, family_id, token_hash, issued_at, expires_at, revoked)
+VALUES (@id, @user_id, @family_id, @token_hash, @issued_at, @expires_at, @revoked)
 `
 
 const selectRefreshTokenByHashSql =
 /*language=sql*/ `
-SELECT id, user_id, token_hash, issued_at, expires_at, revoked
+SELECT id, user_id, family_id, token_hash, issued_at, expires_at, revoked
 FROM iam.auth_refresh_token
-WHERE token_hash = @token_hash AND revoked = false AND expires_at > now()
+WHERE token_hash = @token_hash
 LIMIT 1
 `
 
+// Only a token that is not revoked yet is revoked, so that one of concurrent rotations of the same token wins
 const revokeRefreshTokenSql =
 /*language=sql*/ `
 UPDATE iam.auth_refresh_token
 SET revoked = true
-WHERE token_hash = @token_hash
+WHERE token_hash = @token_hash AND revoked = false
 `
 
 const revokeAllUserRefreshTokensSql =
@@ -160,48 +219,142 @@ SET revoked = true
 WHERE user_id = @user_id AND revoked = false
 `
 
+const revokeRefreshTokenFamilySql =
+/*language=sql*/ `
+UPDATE iam.auth_refresh_token
+SET revoked = true
+WHERE family_id = @family_id AND revoked = false
+`
+
 const deleteExpiredRefreshTokensSql =
 /*language=sql*/ `
-DELETE FROM iam.auth_refresh_token
-WHERE expires_at < now() OR revoked = true
+-- Sessions without a token that is still usable, their revoked tokens are no longer needed for reuse detection.
+-- Tokens are deleted with their session.
+DELETE FROM iam.auth_session s
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM iam.auth_refresh_token t
+    WHERE t.family_id = s.id AND t.revoked = false AND t.expires_at > now()
+)
+`
+
+const cleanupUserRefreshTokenFamiliesSql =
+/*language=sql*/ `
+WITH sessions_to_keep AS (
+    -- Keep the most recently used sessions of the user, so that a user is signed in on a bounded number of devices
+    SELECT s.id
+    FROM iam.auth_session s
+    WHERE s.user_id = @user_id
+      AND EXISTS (
+        SELECT 1
+        FROM iam.auth_refresh_token t
+        WHERE t.family_id = s.id AND t.revoked = false AND t.expires_at > now()
+    )
+    ORDER BY s.last_used_at DESC
+    LIMIT @max_families
+)
+DELETE FROM iam.auth_session
+WHERE user_id = @user_id
+  AND id NOT IN (SELECT id FROM sessions_to_keep)
 `
 
-const cleanupUserRefreshTokensSql =
+const cleanupRefreshTokenFamilySql =
 /*language=sql*/ `
 WITH tokens_to_keep AS (
-    -- Keep the 2 most recent non-revoked tokens (excluding the new one being created)
+    -- Keep the most recent tokens of the family. Reuse of older rotated tokens is detected by their family ID.
     SELECT id
     FROM iam.auth_refresh_token
-    WHERE user_id = @user_id AND revoked = false
+    WHERE family_id = @family_id
     ORDER BY issued_at DESC
-    LIMIT 2
+    LIMIT @max_tokens
 )
 DELETE FROM iam.auth_refresh_token
-WHERE user_id = @user_id
-  AND (revoked = true OR id NOT IN (SELECT id FROM tokens_to_keep))
+WHERE family_id = @family_id
+  AND id NOT IN (SELECT id FROM tokens_to_keep)
+`
+
+// Session SQL queries
+const insertSessionSql =
+/*language=sql*/ `
+INSERT INTO iam.auth_session (id, user_id, source, user_agent, ip_address, oauth_client_id, created_at, last_used_at)
+VALUES (@id, @user_id, @source, @user_agent, @ip_address, @oauth_client_id, @created_at, @last_used_at)
+`
+
+const touchSessionSql =
+/*language=sql*/ `
+UPDATE iam.auth_session
+SET user_agent   = COALESCE(@user_agent, user_agent),
+    ip_address   = COALESCE(@ip_address, ip_address),
+    last_used_at = @last_used_at
+WHERE id = @id
+`
+
+// Active sessions have at least one refresh token that is neither revoked nor expired
+const activeSessionFilterSql = `
+EXISTS (
+    SELECT 1
+    FROM iam.auth_refresh_token t
+    WHERE t.family_id = s.id AND t.revoked = false AND t.expires_at > now()
+)
+`
+
+const selectActiveSessionsByUserIDSql =
+/*language=sql*/ `
+SELECT s.id, s.user_id, s.source, s.user_agent, s.ip_address, s.oauth_client_id, s.created_at, s.last_used_at
+FROM iam.auth_session s
+WHERE s.user_id = @user_id AND ` + activeSessionFilterSql + `
+ORDER BY s.last_used_at DESC
+`
+
+const selectActiveSessionByIDSql =
+/*language=sql*/ `
+SELECT s.id, s.user_id, s.source, s.user_agent, s.ip_address, s.oauth_client_id, s.created_at, s.last_used_at
+FROM iam.auth_session s
+WHERE s.id = @id AND ` + activeSessionFilterSql + `
 `
 
 // Email confirmation token SQL queries
 const insertEmailConfirmationTokenSql =
 /*language=sql*/ `
-INSERT INTO iam.email_confirmation_token (id, user_id, email, token_hash, source, expires_at, created_at)
-VALUES (@id, @user_id, @email, @token_hash, @source, @expires_at, @created_at)
+INSERT INTO iam.email_confirmation_token AS t (id, user_id, email, purpose, token_hash, source, expires_at, created_at,
+                                               send_count, send_window_started_at)
+VALUES (@id, @user_id, @email, @purpose, @token_hash, @source, @expires_at, @created_at, 1, @created_at)
 ON CONFLICT (user_id) DO UPDATE SET
 	email = EXCLUDED.email,
+	purpose = EXCLUDED.purpose,
 	token_hash = EXCLUDED.token_hash,
 	source = EXCLUDED.source,
 	expires_at = EXCLUDED.expires_at,
 	created_at = EXCLUDED.created_at,
-	used_at = NULL
+	used_at = NULL,
+	failed_attempts = 0,
+	send_count = CASE
+		WHEN t.send_window_started_at > EXCLUDED.created_at - INTERVAL '1 day' THEN t.send_count + 1
+		ELSE 1
+	END,
+	send_window_started_at = CASE
+		WHEN t.send_window_started_at > EXCLUDED.created_at - INTERVAL '1 day' THEN t.send_window_started_at
+		ELSE EXCLUDED.created_at
+	END
+RETURNING send_count, send_window_started_at
 `
 
 const selectEmailConfirmationTokenByUserIdAndHashSql =
 /*language=sql*/ `
-SELECT id, user_id, email, token_hash, source, expires_at, used_at, created_at
+SELECT id, user_id, email, purpose, token_hash, source, expires_at, used_at, created_at, failed_attempts,
+       send_count, send_window_started_at
 FROM iam.email_confirmation_token
 WHERE user_id = @user_id AND token_hash = @token_hash
 `
 
+const selectEmailConfirmationTokenByUserIdSql =
+/*language=sql*/ `
+SELECT id, user_id, email, purpose, token_hash, source, expires_at, used_at, created_at, failed_attempts,
+       send_count, send_window_started_at
+FROM iam.email_confirmation_token
+WHERE user_id = @user_id
+`
+
 const markEmailConfirmationTokenAsUsedSql =
 /*language=sql*/ `
 UPDATE iam.email_confirmation_token
@@ -209,6 +362,14 @@ SET used_at = now()
 WHERE id = @token_id
 `
 
+const incrementEmailConfirmationFailedAttemptsSql =
+/*language=sql*/ `
+UPDATE iam.email_confirmation_token
+SET failed_attempts = failed_attempts + 1
+WHERE id = @token_id
+RETURNING failed_attempts
+`
+
 const setUserEmailVerifiedSql =
 /*language=sql*/ `
 UPDATE iam.auth_user
@@ -216,6 +377,56 @@ SET email_verified = @verified, updated_at = now()
 WHERE id = @user_id
 `
 
+// Password reset token SQL queries
+const insertPasswordResetTokenSql =
+/*language=sql*/ `
+INSERT INTO iam.password_reset_token AS t (id, user_id, token_hash, source, expires_at, created_at,
+                                           send_count, send_window_started_at)
+VALUES (@id, @user_id, @token_hash, @source, @expires_at, @created_at, 1, @created_at)
+ON CONFLICT (user_id) DO UPDATE SET
+	token_hash = EXCLUDED.token_hash,
+	source = EXCLUDED.source,
+	expires_at = EXCLUDED.expires_at,
+	created_at = EXCLUDED.created_at,
+	used_at = NULL,
+	failed_attempts = CASE
+		WHEN t.used_at IS NULL AND t.send_window_started_at > EXCLUDED.created_at - INTERVAL '1 day' THEN t.failed_attempts
+		ELSE 0
+	END,
+	send_count = CASE
+		WHEN t.send_window_started_at > EXCLUDED.created_at - INTERVAL '1 day' THEN t.send_count + 1
+		ELSE 1
+	END,
+	send_window_started_at = CASE
+		WHEN t.send_window_started_at > EXCLUDED.created_at - INTERVAL '1 day' THEN t.send_window_started_at
+		ELSE EXCLUDED.created_at
+	END
+RETURNING failed_attempts, send_count, send_window_started_at
+`
+
+const selectPasswordResetTokenByUserIdSql =
+/*language=sql*/ `
+SELECT id, user_id, token_hash, source, expires_at, used_at, created_at, failed_attempts, send_count,
+       send_window_started_at
+FROM iam.password_reset_token
+WHERE user_id = @user_id
+`
+
+const incrementPasswordResetFailedAttemptsSql =
+/*language=sql*/ `
+UPDATE iam.password_reset_token
+SET failed_attempts = failed_attempts + 1
+WHERE id = @token_id
+RETURNING failed_attempts
+`
+
+const markPasswordResetTokenAsUsedSql =
+/*language=sql*/ `
+UPDATE iam.password_reset_token
+SET used_at = now()
+WHERE id = @token_id
+`
+
 const updateUserProfileSql =
 /*language=sql*/ `
 UPDATE iam.user_profile
@@ -223,3 +434,730 @@ SET height = @height, weight = @weight, gender = @gender, birth_date = @birth_da
 WHERE user_id = @user_id
 RETURNING id, user_id, height, weight, gender, birth_date, is_metric, created_at, updated_at
 `
+
+// Federated identity SQL queries
+const insertUserIdentitySql =
+/*language=sql*/ `
+INSERT INTO iam.auth_user_identity (id, user_id, provider, provider_user_id, access_token, refresh_token,
+                                    token_expires_at, created_at, updated_at)
+VALUES (@id, @user_id, @provider, @provider_user_id, @access_token, @refresh_token,
+        @token_expires_at, @created_at, @updated_at)
+`
+
+const selectUserIdentityByProviderSql =
+/*language=sql*/ `
+SELECT id, user_id, provider, provider_user_id, access_token, refresh_token, token_expires_at, created_at, updated_at
+FROM iam.auth_user_identity
+WHERE provider = @provider AND provider_user_id = @provider_user_id
+`
+
+const updateUserIdentityTokensSql =
+/*language=sql*/ `
+UPDATE iam.auth_user_identity
+SET access_token = @access_token, refresh_token = @refresh_token, token_expires_at = @token_expires_at, updated_at = now()
+WHERE id = @id
+`
+
+// OAuth client SQL queries
+const selectOAuthClientByIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, name, client_secret_hash, redirect_uris, created_at, updated_at
+FROM iam.oauth_client
+WHERE id = @id
+`
+
+const selectOAuthClientsByTenantIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, name, client_secret_hash, redirect_uris, created_at, updated_at
+FROM iam.oauth_client
+WHERE tenant_id = @tenant_id
+ORDER BY created_at, id
+`
+
+const insertOAuthClientSql =
+/*language=sql*/ `
+INSERT INTO iam.oauth_client (id, tenant_id, name, client_secret_hash, redirect_uris, created_at, updated_at)
+VALUES (@id, @tenant_id, @name, @client_secret_hash, @redirect_uris, @created_at, @updated_at)
+`
+
+const deleteOAuthClientSql =
+/*language=sql*/ `
+DELETE FROM iam.oauth_client
+WHERE id = @id
+`
+
+// Sessions are removed with their client anyway, they are deleted first to learn their IDs
+const deleteOAuthClientSessionsSql =
+/*language=sql*/ `
+DELETE FROM iam.auth_session
+WHERE oauth_client_id = @client_id
+RETURNING id
+`
+
+const insertOAuthAuthorizationCodeSql =
+/*language=sql*/ `
+INSERT INTO iam.oauth_authorization_code (id, client_id, user_id, code_hash, redirect_uri, scope, nonce,
+                                          code_challenge, code_challenge_method, auth_time, expires_at, created_at)
+VALUES (@id, @client_id, @user_id, @code_hash, @redirect_uri, @scope, @nonce,
+        @code_challenge, @code_challenge_method, @auth_time, @expires_at, @created_at)
+`
+
+const selectOAuthAuthorizationCodeByHashSql =
+/*language=sql*/ `
+SELECT id, client_id, user_id, code_hash, redirect_uri, scope, nonce, code_challenge, code_challenge_method,
+       auth_time, expires_at, used_at, created_at
+FROM iam.oauth_authorization_code
+WHERE code_hash = @code_hash
+`
+
+const markOAuthAuthorizationCodeAsUsedSql =
+/*language=sql*/ `
+UPDATE iam.oauth_authorization_code
+SET used_at = now()
+WHERE id = @id AND used_at IS NULL
+`
+
+// MFA SQL queries
+
+const selectUserMfaByUserIdSql =
+/*language=sql*/ `
+SELECT user_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at
+FROM iam.auth_user_mfa
+WHERE user_id = @user_id
+`
+
+const upsertUserMfaSql =
+/*language=sql*/ `
+INSERT INTO iam.auth_user_mfa (user_id, totp_secret, confirmed_at, last_used_step, created_at, updated_at)
+VALUES (@user_id, @totp_secret, NULL, NULL, @now, @now)
+ON CONFLICT (user_id) DO UPDATE
+    SET totp_secret = EXCLUDED.totp_secret, confirmed_at = NULL, last_used_step = NULL, updated_at = EXCLUDED.updated_at
+`
+
+const confirmUserMfaSql =
+/*language=sql*/ `
+UPDATE iam.auth_user_mfa
+SET confirmed_at = now(), updated_at = now()
+WHERE user_id = @user_id
+`
+
+const updateUserMfaLastUsedStepSql =
+/*language=sql*/ `
+UPDATE iam.auth_user_mfa
+SET last_used_step = @step, updated_at = now()
+WHERE user_id = @user_id AND (last_used_step IS NULL OR last_used_step < @step)
+`
+
+const deleteUserMfaSql =
+/*language=sql*/ `
+DELETE FROM iam.auth_user_mfa
+WHERE user_id = @user_id
+`
+
+const deleteRecoveryCodesByUserIdSql =
+/*language=sql*/ `
+DELETE FROM iam.auth_user_recovery_code
+WHERE user_id = @user_id
+`
+
+const insertRecoveryCodeSql =
+/*language=sql*/ `
+INSERT INTO iam.auth_user_recovery_code (id, user_id, code_hash, created_at)
+VALUES (@id, @user_id, @code_hash, @created_at)
+`
+
+const markRecoveryCodeAsUsedSql =
+/*language=sql*/ `
+UPDATE iam.auth_user_recovery_code
+SET used_at = now()
+WHERE user_id = @user_id AND code_hash = @code_hash AND used_at IS NULL
+`
+
+const countUnusedRecoveryCodesSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.auth_user_recovery_code
+WHERE user_id = @user_id AND used_at IS NULL
+`
+
+const insertMfaChallengeUseSql =
+/*language=sql*/ `
+INSERT INTO iam.mfa_challenge_use (jti, expires_at)
+VALUES (@jti, @expires_at)
+ON CONFLICT (jti) DO NOTHING
+`
+
+const deleteExpiredMfaChallengeUsesSql =
+/*language=sql*/ `
+DELETE FROM iam.mfa_challenge_use
+WHERE expires_at < @now
+`
+
+// Sign in throttle SQL queries
+
+const selectSignInThrottleSql =
+/*language=sql*/ `
+SELECT subject_type, subject, failed_attempts, window_started_at, locked_until, lockout_count, updated_at
+FROM iam.signin_throttle
+WHERE subject_type = @subject_type AND subject = @subject
+`
+
+// Failures older than the window start a new window, otherwise the counter is incremented
+const recordSignInFailureSql =
+/*language=sql*/ `
+INSERT INTO iam.signin_throttle (subject_type, subject, failed_attempts, window_started_at, updated_at)
+VALUES (@subject_type, @subject, 1, @now, @now)
+ON CONFLICT (subject_type, subject) DO UPDATE SET
+    failed_attempts   = CASE WHEN iam.signin_throttle.window_started_at < @window_start
+                             THEN 1 ELSE iam.signin_throttle.failed_attempts + 1 END,
+    window_started_at = CASE WHEN iam.signin_throttle.window_started_at < @window_start
+                             THEN @now ELSE iam.signin_throttle.window_started_at END,
+    updated_at        = @now
+RETURNING failed_attempts
+`
+
+const lockSignInSubjectSql =
+/*language=sql*/ `
+UPDATE iam.signin_throttle
+SET locked_until      = @locked_until,
+    lockout_count     = @lockout_count,
+    failed_attempts   = 0,
+    window_started_at = @now,
+    updated_at        = @now
+WHERE subject_type = @subject_type AND subject = @subject
+`
+
+const deleteSignInThrottleSql =
+/*language=sql*/ `
+DELETE FROM iam.signin_throttle
+WHERE subject_type = @subject_type AND subject = @subject
+`
+
+// Audit event SQL queries
+
+const insertAuditEventSql =
+/*language=sql*/ `
+INSERT INTO iam.audit_event (id, action, tenant_id, actor_user_id, actor_tenant_id, actor_email, actor_roles,
+                             target_type, target_id, ip_address, user_agent, reason, diff, created_at)
+VALUES (@id, @action, @tenant_id, @actor_user_id, @actor_tenant_id, @actor_email, @actor_roles,
+        @target_type, @target_id, @ip_address, @user_agent, @reason, @diff, @created_at)
+`
+
+// Filters are skipped when their parameter is NULL
+const auditEventFilterSql = `
+WHERE (@tenant_id::text IS NULL OR tenant_id = @tenant_id)
+  AND (@action::text IS NULL OR action = @action)
+  AND (@actor_user_id::text IS NULL OR actor_user_id = @actor_user_id)
+  AND (@target_id::text IS NULL OR target_id = @target_id)
+  AND (@from_time::timestamptz IS NULL OR created_at >= @from_time)
+  AND (@to_time::timestamptz IS NULL OR created_at < @to_time)
+`
+
+const selectAuditEventsSql =
+/*language=sql*/ `
+SELECT id, action, tenant_id, actor_user_id, actor_tenant_id, actor_email, actor_roles,
+       target_type, target_id, ip_address, user_agent, reason, diff, created_at
+FROM iam.audit_event
+` + auditEventFilterSql + `
+ORDER BY created_at DESC, id
+LIMIT @limit OFFSET @offset
+`
+
+const countAuditEventsSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.audit_event
+` + auditEventFilterSql
+
+// Access token revocation SQL queries
+
+// A repeated revocation of the same subject covers tokens of both revocations
+const upsertAccessTokenRevocationSql =
+/*language=sql*/ `
+INSERT INTO iam.access_token_revocation (subject_type, subject, issued_before, expires_at, created_at)
+VALUES (@subject_type, @subject, @issued_before, @expires_at, @created_at)
+ON CONFLICT (subject_type, subject) DO UPDATE SET
+    issued_before = GREATEST(iam.access_token_revocation.issued_before, EXCLUDED.issued_before),
+    expires_at    = GREATEST(iam.access_token_revocation.expires_at, EXCLUDED.expires_at)
+`
+
+const existsAccessTokenRevocationSql =
+/*language=sql*/ `
+SELECT EXISTS (
+    SELECT 1
+    FROM iam.access_token_revocation
+    WHERE ((subject_type = 'jti' AND subject = @jti)
+        OR (subject_type = 'session' AND subject = @session_id)
+        OR (subject_type IN ('user', 'client') AND subject = @subject)
+        OR (subject_type = 'all' AND subject = ''))
+      AND issued_before > @issued_at
+)
+`
+
+const deleteExpiredAccessTokenRevocationsSql =
+/*language=sql*/ `
+DELETE FROM iam.access_token_revocation
+WHERE expires_at < @now
+`
+
+const insertServiceClientSql =
+/*language=sql*/ `
+INSERT INTO iam.service_client (id, tenant_id, name, client_secret_hash, scopes, expires_at, created_at, updated_at)
+VALUES (@id, @tenant_id, @name, @client_secret_hash, @scopes, @expires_at, @created_at, @updated_at)
+`
+
+const insertServiceClientRolesSql =
+/*language=sql*/ `
+INSERT INTO iam.service_client_role (client_id, role_id)
+SELECT @client_id, id
+FROM iam.auth_role
+WHERE name = ANY (@roles)
+  AND (tenant_id IS NULL OR tenant_id = (SELECT tenant_id FROM iam.service_client WHERE id = @client_id))
+`
+
+const selectServiceClientByIdSql =
+/*language=sql*/ `
+SELECT c.id, c.tenant_id, c.name, c.client_secret_hash, c.scopes, c.expires_at, c.created_at, c.updated_at,
+       ARRAY(SELECT r.name
+             FROM iam.service_client_role scr
+                      JOIN iam.auth_role r ON r.id = scr.role_id
+             WHERE scr.client_id = c.id
+             ORDER BY r.name) AS roles
+FROM iam.service_client c
+WHERE c.id = @id
+`
+
+const selectServiceClientsByTenantIdSql =
+/*language=sql*/ `
+SELECT c.id, c.tenant_id, c.name, c.client_secret_hash, c.scopes, c.expires_at, c.created_at, c.updated_at,
+       ARRAY(SELECT r.name
+             FROM iam.service_client_role scr
+                      JOIN iam.auth_role r ON r.id = scr.role_id
+             WHERE scr.client_id = c.id
+             ORDER BY r.name) AS roles
+FROM iam.service_client c
+WHERE c.tenant_id = @tenant_id
+ORDER BY c.created_at, c.id
+`
+
+const deleteServiceClientSql =
+/*language=sql*/ `
+DELETE FROM iam.service_client
+WHERE id = @id
+`
+
+const insertAPIKeySql =
+/*language=sql*/ `
+INSERT INTO iam.api_key (id, user_id, name, key_prefix, key_hash, roles, expires_at, last_used_at, created_at)
+VALUES (@id, @user_id, @name, @key_prefix, @key_hash, @roles, @expires_at, @last_used_at, @created_at)
+`
+
+const selectAPIKeysByUserIdSql =
+/*language=sql*/ `
+SELECT id, user_id, name, key_prefix, key_hash, roles, expires_at, last_used_at, created_at
+FROM iam.api_key
+WHERE user_id = @user_id
+ORDER BY created_at DESC, id
+`
+
+const selectAPIKeyByHashSql =
+/*language=sql*/ `
+SELECT id, user_id, name, key_prefix, key_hash, roles, expires_at, last_used_at, created_at
+FROM iam.api_key
+WHERE key_hash = @key_hash
+`
+
+const deleteAPIKeySql =
+/*language=sql*/ `
+DELETE FROM iam.api_key
+WHERE id = @id
+  AND user_id = @user_id
+`
+
+const touchAPIKeySql =
+/*language=sql*/ `
+UPDATE iam.api_key
+SET last_used_at = @used_at
+WHERE id = @id
+  AND (last_used_at IS NULL OR last_used_at < @used_at - INTERVAL '1 minute')
+`
+
+const selectPermissionsSql =
+/*language=sql*/ `
+SELECT name, description, system_only
+FROM iam.permission
+ORDER BY name
+`
+
+const selectRolesByTenantIdSql =
+/*language=sql*/ `
+SELECT r.id,
+       r.tenant_id,
+       r.name,
+       r.description,
+       ARRAY(SELECT rp.permission
+             FROM iam.auth_role_permission rp
+             WHERE rp.role_id = r.id
+             ORDER BY rp.permission) AS permissions,
+       r.created_at,
+       r.updated_at
+FROM iam.auth_role r
+WHERE r.tenant_id IS NULL
+   OR r.tenant_id = @tenant_id
+ORDER BY r.tenant_id NULLS FIRST, r.name
+`
+
+const selectRoleByTenantIdAndNameSql =
+/*language=sql*/ `
+SELECT r.id,
+       r.tenant_id,
+       r.name,
+       r.description,
+       ARRAY(SELECT rp.permission
+             FROM iam.auth_role_permission rp
+             WHERE rp.role_id = r.id
+             ORDER BY rp.permission) AS permissions,
+       r.created_at,
+       r.updated_at
+FROM iam.auth_role r
+WHERE (r.tenant_id IS NULL OR r.tenant_id = @tenant_id)
+  AND r.name = @name
+ORDER BY r.tenant_id NULLS FIRST
+LIMIT 1
+`
+
+const insertRoleSql =
+/*language=sql*/ `
+INSERT INTO iam.auth_role (tenant_id, name, description, created_at, updated_at)
+VALUES (@tenant_id, @name, @description, @created_at, @updated_at)
+RETURNING id
+`
+
+const updateRoleSql =
+/*language=sql*/ `
+UPDATE iam.auth_role
+SET description = @description,
+    updated_at  = @updated_at
+WHERE id = @id
+`
+
+const deleteRoleSql =
+/*language=sql*/ `
+DELETE FROM iam.auth_role
+WHERE id = @id
+`
+
+const insertRolePermissionsSql =
+/*language=sql*/ `
+INSERT INTO iam.auth_role_permission (role_id, permission)
+SELECT @role_id, name
+FROM iam.permission
+WHERE name = ANY (@permissions)
+`
+
+const deleteRolePermissionsSql =
+/*language=sql*/ `
+DELETE FROM iam.auth_role_permission
+WHERE role_id = @role_id
+`
+
+const selectPermissionsByRolesSql =
+/*language=sql*/ `
+SELECT DISTINCT rp.permission
+FROM iam.auth_role r
+         JOIN iam.auth_role_permission rp ON rp.role_id = r.id
+WHERE r.name = ANY (@roles)
+  AND (r.tenant_id IS NULL OR r.tenant_id = @tenant_id)
+ORDER BY rp.permission
+`
+
+const selectUserIdsByRoleIdSql =
+/*language=sql*/ `
+SELECT user_id
+FROM iam.auth_user_role
+WHERE role_id = @role_id
+`
+
+const selectServiceClientIdsByRoleIdSql =
+/*language=sql*/ `
+SELECT client_id
+FROM iam.service_client_role
+WHERE role_id = @role_id
+`
+
+const insertInvitationSql =
+/*language=sql*/ `
+INSERT INTO iam.invitation (id, tenant_id, email, roles, token_hash, invited_by, expires_at, accepted_at, created_at)
+VALUES (@id, @tenant_id, @email, @roles, @token_hash, @invited_by, @expires_at, @accepted_at, @created_at)
+`
+
+const selectPendingInvitationsByTenantIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, email, roles, token_hash, invited_by, expires_at, accepted_at, created_at
+FROM iam.invitation
+WHERE tenant_id = @tenant_id
+  AND accepted_at IS NULL
+ORDER BY created_at DESC, id
+`
+
+const selectPendingInvitationByIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, email, roles, token_hash, invited_by, expires_at, accepted_at, created_at
+FROM iam.invitation
+WHERE id = @id
+  AND tenant_id = @tenant_id
+  AND accepted_at IS NULL
+`
+
+const selectPendingInvitationByTokenHashSql =
+/*language=sql*/ `
+SELECT id, tenant_id, email, roles, token_hash, invited_by, expires_at, accepted_at, created_at
+FROM iam.invitation
+WHERE token_hash = @token_hash
+  AND accepted_at IS NULL
+`
+
+const updateInvitationTokenSql =
+/*language=sql*/ `
+UPDATE iam.invitation
+SET token_hash = @token_hash,
+    expires_at = @expires_at
+WHERE id = @id
+  AND accepted_at IS NULL
+`
+
+const updateInvitationAcceptedAtSql =
+/*language=sql*/ `
+UPDATE iam.invitation
+SET accepted_at = @accepted_at
+WHERE id = @id
+`
+
+const deleteInvitationSql =
+/*language=sql*/ `
+DELETE FROM iam.invitation
+WHERE id = @id
+  AND tenant_id = @tenant_id
+  AND accepted_at IS NULL
+`
+
+// Outbox email SQL queries
+
+const insertOutboxEmailSql =
+/*language=sql*/ `
+INSERT INTO iam.outbox_email (id, recipient, subject, html_body, text_body, reply_to, headers, attachments, status,
+                              attempts, next_attempt_at, last_error, created_at, sent_at)
+VALUES (@id, @recipient, @subject, @html_body, @text_body, @reply_to, @headers, @attachments, @status,
+        @attempts, @next_attempt_at, @last_error, @created_at, @sent_at)
+`
+
+// Claimed emails are locked until the claim commits, concurrent dispatchers skip them instead of waiting
+const claimDueOutboxEmailsSql =
+/*language=sql*/ `
+UPDATE iam.outbox_email
+SET attempts        = attempts + 1,
+    next_attempt_at = @claimed_until
+WHERE id IN (SELECT id
+             FROM iam.outbox_email
+             WHERE status = 'pending'
+               AND next_attempt_at <= @now
+             ORDER BY next_attempt_at
+             LIMIT @limit FOR UPDATE SKIP LOCKED)
+RETURNING id, recipient, subject, html_body, text_body, reply_to, headers, attachments, status, attempts,
+    next_attempt_at, last_error, created_at, sent_at
+`
+
+// Content of a sent email is no longer needed, it may contain one-time codes
+const updateOutboxEmailAsSentSql =
+/*language=sql*/ `
+UPDATE iam.outbox_email
+SET status      = 'sent',
+    html_body   = '',
+    text_body   = '',
+    attachments = '[]',
+    last_error  = NULL,
+    sent_at     = @sent_at
+WHERE id = @id
+  AND status = 'pending'
+`
+
+// Email without a next attempt is given up as dead
+const updateOutboxEmailAsFailedSql =
+/*language=sql*/ `
+UPDATE iam.outbox_email
+SET status          = CASE WHEN @next_attempt_at::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+    next_attempt_at = COALESCE(@next_attempt_at::timestamptz, next_attempt_at),
+    last_error      = @last_error
+WHERE id = @id
+  AND status = 'pending'
+`
+
+// Status filter is skipped when its parameter is NULL
+const failedOutboxEmailFilterSql = `
+WHERE status <> 'sent'
+  AND last_error IS NOT NULL
+  AND (@status::text IS NULL OR status = @status)
+`
+
+const selectFailedOutboxEmailsSql =
+/*language=sql*/ `
+SELECT id, recipient, subject, html_body, text_body, reply_to, headers, attachments, status, attempts,
+       next_attempt_at, last_error, created_at, sent_at
+FROM iam.outbox_email
+` + failedOutboxEmailFilterSql + `
+ORDER BY created_at DESC, id
+LIMIT @limit OFFSET @offset
+`
+
+const countFailedOutboxEmailsSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.outbox_email
+` + failedOutboxEmailFilterSql
+
+const updateOutboxEmailForRetrySql =
+/*language=sql*/ `
+UPDATE iam.outbox_email
+SET status          = 'pending',
+    attempts        = 0,
+    next_attempt_at = @now
+WHERE id = @id
+  AND status <> 'sent'
+`
+
+// Webhook SQL queries
+
+const insertWebhookSql =
+/*language=sql*/ `
+INSERT INTO iam.webhook (id, tenant_id, url, secret, event_types, active, created_at, updated_at)
+VALUES (@id, @tenant_id, @url, @secret, @event_types, @active, @created_at, @updated_at)
+`
+
+const selectWebhooksByTenantIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, url, secret, event_types, active, created_at, updated_at
+FROM iam.webhook
+WHERE tenant_id = @tenant_id
+ORDER BY created_at DESC, id
+`
+
+const selectWebhookByIdSql =
+/*language=sql*/ `
+SELECT id, tenant_id, url, secret, event_types, active, created_at, updated_at
+FROM iam.webhook
+WHERE id = @id
+  AND tenant_id = @tenant_id
+`
+
+const selectWebhooksByEventTypeSql =
+/*language=sql*/ `
+SELECT id, tenant_id, url, secret, event_types, active, created_at, updated_at
+FROM iam.webhook
+WHERE tenant_id = @tenant_id
+  AND @event_type = ANY (event_types)
+`
+
+const updateWebhookSql =
+/*language=sql*/ `
+UPDATE iam.webhook
+SET url         = @url,
+    secret      = @secret,
+    event_types = @event_types,
+    active      = @active,
+    updated_at  = @updated_at
+WHERE id = @id
+  AND tenant_id = @tenant_id
+`
+
+const deleteWebhookSql =
+/*language=sql*/ `
+DELETE
+FROM iam.webhook
+WHERE id = @id
+  AND tenant_id = @tenant_id
+`
+
+// Webhook delivery SQL queries
+
+const insertWebhookDeliverySql =
+/*language=sql*/ `
+INSERT INTO iam.webhook_delivery (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+                                  last_status_code, last_error, created_at, delivered_at)
+VALUES (@id, @webhook_id, @event_id, @event_type, @payload, @status, @attempts, @next_attempt_at,
+        @last_status_code, @last_error, @created_at, @delivered_at)
+`
+
+// Deliveries of inactive webhooks are held back, claimed deliveries are skipped by concurrent dispatchers
+// like claimed outgoing emails
+const claimDueWebhookDeliveriesSql =
+/*language=sql*/ `
+UPDATE iam.webhook_delivery d
+SET attempts        = d.attempts + 1,
+    next_attempt_at = @claimed_until
+FROM iam.webhook w
+WHERE w.id = d.webhook_id
+  AND d.id IN (SELECT pd.id
+               FROM iam.webhook_delivery pd
+                        JOIN iam.webhook pw ON pw.id = pd.webhook_id
+               WHERE pd.status = 'pending'
+                 AND pd.next_attempt_at <= @now
+                 AND pw.active
+               ORDER BY pd.next_attempt_at
+               LIMIT @limit FOR UPDATE OF pd SKIP LOCKED)
+RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+    d.last_status_code, d.last_error, d.created_at, d.delivered_at, w.url, w.secret
+`
+
+const updateWebhookDeliveryAsDeliveredSql =
+/*language=sql*/ `
+UPDATE iam.webhook_delivery
+SET status           = 'delivered',
+    last_status_code = @last_status_code,
+    last_error       = NULL,
+    delivered_at     = @delivered_at
+WHERE id = @id
+  AND status = 'pending'
+`
+
+// Delivery without a next attempt is given up as dead
+const updateWebhookDeliveryAsFailedSql =
+/*language=sql*/ `
+UPDATE iam.webhook_delivery
+SET status           = CASE WHEN @next_attempt_at::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+    next_attempt_at  = COALESCE(@next_attempt_at::timestamptz, next_attempt_at),
+    last_status_code = @last_status_code,
+    last_error       = @last_error
+WHERE id = @id
+  AND status = 'pending'
+`
+
+// Status filter is skipped when its parameter is NULL
+const webhookDeliveryFilterSql = `
+WHERE webhook_id = @webhook_id
+  AND (@status::text IS NULL OR status = @status)
+`
+
+const selectWebhookDeliveriesSql =
+/*language=sql*/ `
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code,
+       last_error, created_at, delivered_at
+FROM iam.webhook_delivery
+` + webhookDeliveryFilterSql + `
+ORDER BY created_at DESC, id
+LIMIT @limit OFFSET @offset
+`
+
+const countWebhookDeliveriesSql =
+/*language=sql*/ `
+SELECT count(*)
+FROM iam.webhook_delivery
+` + webhookDeliveryFilterSql
+
+const selectWebhookDeliveryByIdSql =
+/*language=sql*/ `
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code,
+       last_error, created_at, delivered_at
+FROM iam.webhook_delivery
+WHERE id = @id
+  AND webhook_id = @webhook_id
+`
@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type AccessTokenRevocationEntity struct { //+gob:Constructor
+	SubjectType  string    `db:"subject_type"`
+	Subject      string    `db:"subject"`
+	IssuedBefore time.Time `db:"issued_before"`
+	ExpiresAt    time.Time `db:"expires_at"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func UpsertAccessTokenRevocation(ctx context.Context, tx pgx.Tx, ent *AccessTokenRevocationEntity) error {
+	_, err := tx.Exec(ctx, upsertAccessTokenRevocationSql, pgx.NamedArgs{
+		"subject_type":  ent.SubjectType,
+		"subject":       ent.Subject,
+		"issued_before": ent.IssuedBefore,
+		"expires_at":    ent.ExpiresAt,
+		"created_at":    ent.CreatedAt,
+	})
+	return err
+}
+
+// ExistsAccessTokenRevocation checks if any revocation covers a token with the given ID, session and subject
+// (user or service client) issued at issuedAt. Empty token and session IDs never match.
+func ExistsAccessTokenRevocation(
+	ctx context.Context, tx pgx.Tx, jti string, sessionID string, subject string, issuedAt time.Time,
+) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, existsAccessTokenRevocationSql, pgx.NamedArgs{
+		"jti":        lo.EmptyableToPtr(jti),
+		"session_id": lo.EmptyableToPtr(sessionID),
+		"subject":    subject,
+		"issued_at":  issuedAt,
+	}).Scan(&exists)
+	return exists, err
+}
+
+func DeleteExpiredAccessTokenRevocations(ctx context.Context, tx pgx.Tx, now time.Time) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteExpiredAccessTokenRevocationsSql, pgx.NamedArgs{
+		"now": now,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
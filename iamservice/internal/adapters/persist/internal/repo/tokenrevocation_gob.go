@@ -0,0 +1,64 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewAccessTokenRevocationEntityBuilder() AccessTokenRevocationEntity_Builder_SubjectType {
+	return AccessTokenRevocationEntity_Builder_SubjectType{root: &AccessTokenRevocationEntity{}}
+}
+
+type AccessTokenRevocationEntity_Builder_SubjectType struct {
+	root *AccessTokenRevocationEntity
+}
+
+type AccessTokenRevocationEntity_Builder_Subject struct {
+	root *AccessTokenRevocationEntity
+}
+
+func (b AccessTokenRevocationEntity_Builder_SubjectType) SubjectType(arg string) AccessTokenRevocationEntity_Builder_Subject {
+	b.root.SubjectType = arg
+	return AccessTokenRevocationEntity_Builder_Subject{root: b.root}
+}
+
+type AccessTokenRevocationEntity_Builder_IssuedBefore struct {
+	root *AccessTokenRevocationEntity
+}
+
+func (b AccessTokenRevocationEntity_Builder_Subject) Subject(arg string) AccessTokenRevocationEntity_Builder_IssuedBefore {
+	b.root.Subject = arg
+	return AccessTokenRevocationEntity_Builder_IssuedBefore{root: b.root}
+}
+
+type AccessTokenRevocationEntity_Builder_ExpiresAt struct {
+	root *AccessTokenRevocationEntity
+}
+
+func (b AccessTokenRevocationEntity_Builder_IssuedBefore) IssuedBefore(arg time.Time) AccessTokenRevocationEntity_Builder_ExpiresAt {
+	b.root.IssuedBefore = arg
+	return AccessTokenRevocationEntity_Builder_ExpiresAt{root: b.root}
+}
+
+type AccessTokenRevocationEntity_Builder_CreatedAt struct {
+	root *AccessTokenRevocationEntity
+}
+
+func (b AccessTokenRevocationEntity_Builder_ExpiresAt) ExpiresAt(arg time.Time) AccessTokenRevocationEntity_Builder_CreatedAt {
+	b.root.ExpiresAt = arg
+	return AccessTokenRevocationEntity_Builder_CreatedAt{root: b.root}
+}
+
+type AccessTokenRevocationEntity_Builder_GobFinalizer struct {
+	root *AccessTokenRevocationEntity
+}
+
+func (b AccessTokenRevocationEntity_Builder_CreatedAt) CreatedAt(arg time.Time) AccessTokenRevocationEntity_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return AccessTokenRevocationEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AccessTokenRevocationEntity_Builder_GobFinalizer) Build() *AccessTokenRevocationEntity {
+	return b.root
+}
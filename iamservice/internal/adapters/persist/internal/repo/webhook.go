@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+type WebhookEntity struct { //+gob:Constructor
+	ID         string    `db:"id"`
+	TenantID   string    `db:"tenant_id"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []string  `db:"event_types"`
+	Active     bool      `db:"active"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type WebhookDeliveryEntity struct { //+gob:Constructor
+	ID             string     `db:"id"`
+	WebhookID      string     `db:"webhook_id"`
+	EventID        string     `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"` // JSON encoded event
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+}
+
+// DueWebhookDeliveryEntity is a claimed delivery with the URL and secret of its webhook
+type DueWebhookDeliveryEntity struct {
+	WebhookDeliveryEntity
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+func InsertWebhook(ctx context.Context, tx pgx.Tx, ent *WebhookEntity) error {
+	_, err := tx.Exec(ctx, insertWebhookSql, pgx.NamedArgs{
+		"id":          ent.ID,
+		"tenant_id":   ent.TenantID,
+		"url":         ent.URL,
+		"secret":      ent.Secret,
+		"event_types": ent.EventTypes,
+		"active":      ent.Active,
+		"created_at":  ent.CreatedAt,
+		"updated_at":  ent.UpdatedAt,
+	})
+	return err
+}
+
+func SelectWebhooksByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]WebhookEntity, error) {
+	rows, _ := tx.Query(ctx, selectWebhooksByTenantIdSql, pgx.NamedArgs{"tenant_id": tenantID})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[WebhookEntity])
+}
+
+func SelectWebhookByID(ctx context.Context, tx pgx.Tx, tenantID string, webhookID string) (*WebhookEntity, error) {
+	rows, _ := tx.Query(ctx, selectWebhookByIdSql, pgx.NamedArgs{
+		"id":        webhookID,
+		"tenant_id": tenantID,
+	})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[WebhookEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
+
+func SelectWebhooksByEventType(
+	ctx context.Context, tx pgx.Tx, tenantID string, eventType string,
+) ([]WebhookEntity, error) {
+	rows, _ := tx.Query(ctx, selectWebhooksByEventTypeSql, pgx.NamedArgs{
+		"tenant_id":  tenantID,
+		"event_type": eventType,
+	})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[WebhookEntity])
+}
+
+func UpdateWebhook(ctx context.Context, tx pgx.Tx, ent *WebhookEntity) (int64, error) {
+	tag, err := tx.Exec(ctx, updateWebhookSql, pgx.NamedArgs{
+		"id":          ent.ID,
+		"tenant_id":   ent.TenantID,
+		"url":         ent.URL,
+		"secret":      ent.Secret,
+		"event_types": ent.EventTypes,
+		"active":      ent.Active,
+		"updated_at":  ent.UpdatedAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func DeleteWebhook(ctx context.Context, tx pgx.Tx, tenantID string, webhookID string) (int64, error) {
+	tag, err := tx.Exec(ctx, deleteWebhookSql, pgx.NamedArgs{
+		"id":        webhookID,
+		"tenant_id": tenantID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func InsertWebhookDelivery(ctx context.Context, tx pgx.Tx, ent *WebhookDeliveryEntity) error {
+	_, err := tx.Exec(ctx, insertWebhookDeliverySql, pgx.NamedArgs{
+		"id":               ent.ID,
+		"webhook_id":       ent.WebhookID,
+		"event_id":         ent.EventID,
+		"event_type":       ent.EventType,
+		"payload":          ent.Payload,
+		"status":           ent.Status,
+		"attempts":         ent.Attempts,
+		"next_attempt_at":  ent.NextAttemptAt,
+		"last_status_code": ent.LastStatusCode,
+		"last_error":       ent.LastError,
+		"created_at":       ent.CreatedAt,
+		"delivered_at":     ent.DeliveredAt,
+	})
+	return err
+}
+
+func ClaimDueWebhookDeliveries(
+	ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+) ([]DueWebhookDeliveryEntity, error) {
+	rows, _ := tx.Query(ctx, claimDueWebhookDeliveriesSql, pgx.NamedArgs{
+		"now":           now,
+		"claimed_until": claimedUntil,
+		"limit":         limit,
+	})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[DueWebhookDeliveryEntity])
+}
+
+func UpdateWebhookDeliveryAsDelivered(
+	ctx context.Context, tx pgx.Tx, deliveryID string, statusCode int, deliveredAt time.Time,
+) error {
+	_, err := tx.Exec(ctx, updateWebhookDeliveryAsDeliveredSql, pgx.NamedArgs{
+		"id":               deliveryID,
+		"last_status_code": statusCode,
+		"delivered_at":     deliveredAt,
+	})
+	return err
+}
+
+func UpdateWebhookDeliveryAsFailed(
+	ctx context.Context, tx pgx.Tx, deliveryID string, statusCode *int, lastError string, nextAttemptAt *time.Time,
+) error {
+	_, err := tx.Exec(ctx, updateWebhookDeliveryAsFailedSql, pgx.NamedArgs{
+		"id":               deliveryID,
+		"last_status_code": statusCode,
+		"last_error":       lastError,
+		"next_attempt_at":  nextAttemptAt,
+	})
+	return err
+}
+
+func SelectWebhookDeliveries(
+	ctx context.Context, tx pgx.Tx, webhookID string, status *string, offset int, limit int,
+) ([]WebhookDeliveryEntity, error) {
+	rows, _ := tx.Query(ctx, selectWebhookDeliveriesSql, pgx.NamedArgs{
+		"webhook_id": webhookID,
+		"status":     status,
+		"offset":     offset,
+		"limit":      limit,
+	})
+	return pgx.CollectRows(rows, pgx.RowToStructByName[WebhookDeliveryEntity])
+}
+
+func CountWebhookDeliveries(ctx context.Context, tx pgx.Tx, webhookID string, status *string) (int, error) {
+	var total int
+	err := tx.QueryRow(ctx, countWebhookDeliveriesSql, pgx.NamedArgs{
+		"webhook_id": webhookID,
+		"status":     status,
+	}).Scan(&total)
+	return total, err
+}
+
+func SelectWebhookDeliveryByID(
+	ctx context.Context, tx pgx.Tx, webhookID string, deliveryID string,
+) (*WebhookDeliveryEntity, error) {
+	rows, _ := tx.Query(ctx, selectWebhookDeliveryByIdSql, pgx.NamedArgs{
+		"id":         deliveryID,
+		"webhook_id": webhookID,
+	})
+	ent, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[WebhookDeliveryEntity])
+	if katpg.IsNoRows(err) {
+		return nil, nil
+	}
+	return &ent, err
+}
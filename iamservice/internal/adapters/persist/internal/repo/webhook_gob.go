@@ -0,0 +1,211 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package repo
+
+import (
+	"time"
+)
+
+func NewWebhookEntityBuilder() WebhookEntity_Builder_ID {
+	return WebhookEntity_Builder_ID{root: &WebhookEntity{}}
+}
+
+type WebhookEntity_Builder_ID struct {
+	root *WebhookEntity
+}
+
+type WebhookEntity_Builder_TenantID struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_ID) ID(arg string) WebhookEntity_Builder_TenantID {
+	b.root.ID = arg
+	return WebhookEntity_Builder_TenantID{root: b.root}
+}
+
+type WebhookEntity_Builder_URL struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_TenantID) TenantID(arg string) WebhookEntity_Builder_URL {
+	b.root.TenantID = arg
+	return WebhookEntity_Builder_URL{root: b.root}
+}
+
+type WebhookEntity_Builder_Secret struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_URL) URL(arg string) WebhookEntity_Builder_Secret {
+	b.root.URL = arg
+	return WebhookEntity_Builder_Secret{root: b.root}
+}
+
+type WebhookEntity_Builder_EventTypes struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_Secret) Secret(arg string) WebhookEntity_Builder_EventTypes {
+	b.root.Secret = arg
+	return WebhookEntity_Builder_EventTypes{root: b.root}
+}
+
+type WebhookEntity_Builder_Active struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_EventTypes) EventTypes(arg []string) WebhookEntity_Builder_Active {
+	b.root.EventTypes = arg
+	return WebhookEntity_Builder_Active{root: b.root}
+}
+
+type WebhookEntity_Builder_CreatedAt struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_Active) Active(arg bool) WebhookEntity_Builder_CreatedAt {
+	b.root.Active = arg
+	return WebhookEntity_Builder_CreatedAt{root: b.root}
+}
+
+type WebhookEntity_Builder_UpdatedAt struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookEntity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return WebhookEntity_Builder_UpdatedAt{root: b.root}
+}
+
+type WebhookEntity_Builder_GobFinalizer struct {
+	root *WebhookEntity
+}
+
+func (b WebhookEntity_Builder_UpdatedAt) UpdatedAt(arg time.Time) WebhookEntity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return WebhookEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookEntity_Builder_GobFinalizer) Build() *WebhookEntity {
+	return b.root
+}
+
+func NewWebhookDeliveryEntityBuilder() WebhookDeliveryEntity_Builder_ID {
+	return WebhookDeliveryEntity_Builder_ID{root: &WebhookDeliveryEntity{}}
+}
+
+type WebhookDeliveryEntity_Builder_ID struct {
+	root *WebhookDeliveryEntity
+}
+
+type WebhookDeliveryEntity_Builder_WebhookID struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_ID) ID(arg string) WebhookDeliveryEntity_Builder_WebhookID {
+	b.root.ID = arg
+	return WebhookDeliveryEntity_Builder_WebhookID{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_EventID struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_WebhookID) WebhookID(arg string) WebhookDeliveryEntity_Builder_EventID {
+	b.root.WebhookID = arg
+	return WebhookDeliveryEntity_Builder_EventID{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_EventType struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_EventID) EventID(arg string) WebhookDeliveryEntity_Builder_EventType {
+	b.root.EventID = arg
+	return WebhookDeliveryEntity_Builder_EventType{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_Payload struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_EventType) EventType(arg string) WebhookDeliveryEntity_Builder_Payload {
+	b.root.EventType = arg
+	return WebhookDeliveryEntity_Builder_Payload{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_Status struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_Payload) Payload(arg []byte) WebhookDeliveryEntity_Builder_Status {
+	b.root.Payload = arg
+	return WebhookDeliveryEntity_Builder_Status{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_Attempts struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_Status) Status(arg string) WebhookDeliveryEntity_Builder_Attempts {
+	b.root.Status = arg
+	return WebhookDeliveryEntity_Builder_Attempts{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_NextAttemptAt struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_Attempts) Attempts(arg int) WebhookDeliveryEntity_Builder_NextAttemptAt {
+	b.root.Attempts = arg
+	return WebhookDeliveryEntity_Builder_NextAttemptAt{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_LastStatusCode struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) WebhookDeliveryEntity_Builder_LastStatusCode {
+	b.root.NextAttemptAt = arg
+	return WebhookDeliveryEntity_Builder_LastStatusCode{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_LastError struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_LastStatusCode) LastStatusCode(arg *int) WebhookDeliveryEntity_Builder_LastError {
+	b.root.LastStatusCode = arg
+	return WebhookDeliveryEntity_Builder_LastError{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_CreatedAt struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_LastError) LastError(arg *string) WebhookDeliveryEntity_Builder_CreatedAt {
+	b.root.LastError = arg
+	return WebhookDeliveryEntity_Builder_CreatedAt{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_DeliveredAt struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookDeliveryEntity_Builder_DeliveredAt {
+	b.root.CreatedAt = arg
+	return WebhookDeliveryEntity_Builder_DeliveredAt{root: b.root}
+}
+
+type WebhookDeliveryEntity_Builder_GobFinalizer struct {
+	root *WebhookDeliveryEntity
+}
+
+func (b WebhookDeliveryEntity_Builder_DeliveredAt) DeliveredAt(arg *time.Time) WebhookDeliveryEntity_Builder_GobFinalizer {
+	b.root.DeliveredAt = arg
+	return WebhookDeliveryEntity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookDeliveryEntity_Builder_GobFinalizer) Build() *WebhookDeliveryEntity {
+	return b.root
+}
@@ -0,0 +1,134 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// InvitationAdapter implements the outport.InvitationPersist outport interface
+type InvitationAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewInvitationAdapter(db *katpg.DBLink) outport.InvitationPersist {
+	return &InvitationAdapter{db: db}
+}
+
+func (a *InvitationAdapter) CreateInvitation(ctx context.Context, tx pgx.Tx, invitation *model.Invitation) error {
+	katapp.Logger(ctx).Info("creating invitation",
+		"invitationID", invitation.ID, "tenantID", invitation.TenantID, "email", invitation.Email)
+
+	entity := mapper.InvitationModelToInvitationEntity(invitation)
+	if err := repo.InsertInvitation(ctx, tx, entity); err != nil {
+		msg := "failed to create invitation"
+		katapp.Logger(ctx).Error(msg, "invitationID", invitation.ID, "tenantID", invitation.TenantID, "error", err)
+		appErr := katpg.PgToAppError(err, msg)
+		if appErr.Scope == katapp.ErrDuplicate {
+			return katapp.NewErr(katapp.ErrDuplicate, "email address was already invited to this tenant")
+		}
+		return appErr
+	}
+	return nil
+}
+
+func (a *InvitationAdapter) GetPendingInvitationsByTenantID(
+	ctx context.Context, tx pgx.Tx, tenantID string,
+) ([]*model.Invitation, error) {
+	katapp.Logger(ctx).Debug("getting pending invitations by tenant ID", "tenantID", tenantID)
+
+	invitationEntities, err := repo.SelectPendingInvitationsByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		msg := "failed to get pending invitations by tenant ID"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	invitations := make([]*model.Invitation, len(invitationEntities))
+	for i := range invitationEntities {
+		invitations[i] = mapper.InvitationEntityToInvitationModel(&invitationEntities[i])
+	}
+	return invitations, nil
+}
+
+func (a *InvitationAdapter) GetPendingInvitationByID(
+	ctx context.Context, tx pgx.Tx, tenantID string, invitationID string,
+) (*model.Invitation, error) {
+	invitationEntity, err := repo.SelectPendingInvitationByID(ctx, tx, tenantID, invitationID)
+	if err != nil {
+		msg := "failed to get pending invitation by ID"
+		katapp.Logger(ctx).Error(msg, "invitationID", invitationID, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if invitationEntity == nil {
+		return nil, nil
+	}
+	return mapper.InvitationEntityToInvitationModel(invitationEntity), nil
+}
+
+func (a *InvitationAdapter) GetPendingInvitationByTokenHash(
+	ctx context.Context, tx pgx.Tx, tokenHash string,
+) (*model.Invitation, error) {
+	invitationEntity, err := repo.SelectPendingInvitationByTokenHash(ctx, tx, tokenHash)
+	if err != nil {
+		msg := "failed to get pending invitation by token hash"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if invitationEntity == nil {
+		return nil, nil
+	}
+	return mapper.InvitationEntityToInvitationModel(invitationEntity), nil
+}
+
+func (a *InvitationAdapter) RenewInvitationToken(
+	ctx context.Context, tx pgx.Tx, invitationID string, tokenHash string, expiresAt time.Time,
+) error {
+	katapp.Logger(ctx).Info("renewing invitation token", "invitationID", invitationID)
+
+	count, err := repo.UpdateInvitationToken(ctx, tx, invitationID, tokenHash, expiresAt)
+	if err != nil {
+		msg := "failed to renew invitation token"
+		katapp.Logger(ctx).Error(msg, "invitationID", invitationID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "invitation not found")
+	}
+	return nil
+}
+
+func (a *InvitationAdapter) MarkInvitationAsAccepted(
+	ctx context.Context, tx pgx.Tx, invitationID string, acceptedAt time.Time,
+) error {
+	katapp.Logger(ctx).Info("marking invitation as accepted", "invitationID", invitationID)
+
+	if err := repo.UpdateInvitationAcceptedAt(ctx, tx, invitationID, acceptedAt); err != nil {
+		msg := "failed to mark invitation as accepted"
+		katapp.Logger(ctx).Error(msg, "invitationID", invitationID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *InvitationAdapter) DeleteInvitation(ctx context.Context, tx pgx.Tx, tenantID string, invitationID string) error {
+	katapp.Logger(ctx).Info("deleting invitation", "invitationID", invitationID, "tenantID", tenantID)
+
+	count, err := repo.DeleteInvitation(ctx, tx, tenantID, invitationID)
+	if err != nil {
+		msg := "failed to delete invitation"
+		katapp.Logger(ctx).Error(msg, "invitationID", invitationID, "tenantID", tenantID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "invitation not found")
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// MFAAdapter implements the outport.MFAPersist outport interface
+type MFAAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewMFAAdapter(db *katpg.DBLink) outport.MFAPersist {
+	return &MFAAdapter{db: db}
+}
+
+func (a *MFAAdapter) GetUserMFA(ctx context.Context, tx pgx.Tx, userID string) (*model.UserMFA, error) {
+	katapp.Logger(ctx).Debug("getting user mfa", "userID", userID)
+
+	mfaEntity, err := repo.SelectUserMFAByUserID(ctx, tx, userID)
+	if err != nil {
+		msg := "failed to get user mfa"
+		katapp.Logger(ctx).Error(msg, "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if mfaEntity == nil {
+		return nil, nil
+	}
+	return mapper.UserMFAEntityToUserMFAModel(mfaEntity), nil
+}
+
+func (a *MFAAdapter) UpsertUserMFA(ctx context.Context, tx pgx.Tx, userID string, totpSecret string) (*model.UserMFA, error) {
+	katapp.Logger(ctx).Info("storing user mfa secret", "userID", userID)
+
+	err := repo.UpsertUserMFA(ctx, tx, userID, totpSecret, time.Now())
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to store user mfa secret", "userID", userID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to store user mfa secret")
+	}
+
+	return a.GetUserMFA(ctx, tx, userID)
+}
+
+func (a *MFAAdapter) ConfirmUserMFA(ctx context.Context, tx pgx.Tx, userID string) error {
+	katapp.Logger(ctx).Info("confirming user mfa", "userID", userID)
+
+	err := repo.ConfirmUserMFA(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to confirm user mfa", "userID", userID, "error", err)
+		return katpg.PgToAppError(err, "failed to confirm user mfa")
+	}
+
+	return nil
+}
+
+func (a *MFAAdapter) UpdateUserMFALastUsedStep(ctx context.Context, tx pgx.Tx, userID string, step int64) (int64, error) {
+	katapp.Logger(ctx).Debug("updating user mfa last used step", "userID", userID)
+
+	rowsAffected, err := repo.UpdateUserMFALastUsedStep(ctx, tx, userID, step)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to update user mfa last used step", "userID", userID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to update user mfa last used step")
+	}
+
+	return rowsAffected, nil
+}
+
+func (a *MFAAdapter) DeleteUserMFA(ctx context.Context, tx pgx.Tx, userID string) error {
+	katapp.Logger(ctx).Info("deleting user mfa", "userID", userID)
+
+	err := repo.DeleteUserMFA(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to delete user mfa", "userID", userID, "error", err)
+		return katpg.PgToAppError(err, "failed to delete user mfa")
+	}
+	err = repo.DeleteRecoveryCodesByUserID(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to delete recovery codes", "userID", userID, "error", err)
+		return katpg.PgToAppError(err, "failed to delete recovery codes")
+	}
+
+	return nil
+}
+
+func (a *MFAAdapter) ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error {
+	katapp.Logger(ctx).Info("replacing recovery codes", "userID", userID, "count", len(codeHashes))
+
+	err := repo.DeleteRecoveryCodesByUserID(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to delete recovery codes", "userID", userID, "error", err)
+		return katpg.PgToAppError(err, "failed to delete recovery codes")
+	}
+
+	now := time.Now()
+	for _, codeHash := range codeHashes {
+		err = repo.InsertRecoveryCode(ctx, tx, uuid.NewString(), userID, codeHash, now)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to create recovery code", "userID", userID, "error", err)
+			return katpg.PgToAppError(err, "failed to create recovery code")
+		}
+	}
+
+	return nil
+}
+
+func (a *MFAAdapter) MarkRecoveryCodeAsUsed(ctx context.Context, tx pgx.Tx, userID string, codeHash string) (int64, error) {
+	katapp.Logger(ctx).Info("marking recovery code as used", "userID", userID)
+
+	rowsAffected, err := repo.MarkRecoveryCodeAsUsed(ctx, tx, userID, codeHash)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to mark recovery code as used", "userID", userID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to mark recovery code as used")
+	}
+
+	return rowsAffected, nil
+}
+
+func (a *MFAAdapter) CountUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string) (int, error) {
+	katapp.Logger(ctx).Debug("counting unused recovery codes", "userID", userID)
+
+	count, err := repo.CountUnusedRecoveryCodes(ctx, tx, userID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to count unused recovery codes", "userID", userID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to count unused recovery codes")
+	}
+
+	return count, nil
+}
+
+func (a *MFAAdapter) MarkMFAChallengeUsed(
+	ctx context.Context, tx pgx.Tx, tokenID string, expiresAt time.Time,
+) (int64, error) {
+	katapp.Logger(ctx).Info("marking mfa challenge as used", "tokenID", tokenID)
+
+	err := repo.DeleteExpiredMfaChallengeUses(ctx, tx, time.Now())
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to delete expired mfa challenge uses", "error", err)
+		return 0, katpg.PgToAppError(err, "failed to delete expired mfa challenge uses")
+	}
+	rowsAffected, err := repo.InsertMfaChallengeUse(ctx, tx, tokenID, expiresAt)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to mark mfa challenge as used", "tokenID", tokenID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to mark mfa challenge as used")
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,125 @@
+package persist
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// OAuthAdapter implements the outport.OAuthPersist outport interface
+type OAuthAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewOAuthAdapter(db *katpg.DBLink) outport.OAuthPersist {
+	return &OAuthAdapter{db: db}
+}
+
+func (a *OAuthAdapter) GetOAuthClientByID(ctx context.Context, tx pgx.Tx, clientID string) (*model.OAuthClient, error) {
+	katapp.Logger(ctx).Debug("getting oauth client by ID", "clientID", clientID)
+
+	clientEntity, err := repo.SelectOAuthClientByID(ctx, tx, clientID)
+	if err != nil {
+		msg := "failed to get oauth client by ID"
+		katapp.Logger(ctx).Error(msg, "clientID", clientID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if clientEntity == nil {
+		return nil, nil
+	}
+	return mapper.OAuthClientEntityToOAuthClientModel(clientEntity), nil
+}
+
+func (a *OAuthAdapter) GetOAuthClientsByTenantID(
+	ctx context.Context, tx pgx.Tx, tenantID string,
+) ([]*model.OAuthClient, error) {
+	katapp.Logger(ctx).Debug("getting oauth clients by tenant ID", "tenantID", tenantID)
+
+	clientEntities, err := repo.SelectOAuthClientsByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		msg := "failed to get oauth clients by tenant ID"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	clients := make([]*model.OAuthClient, len(clientEntities))
+	for i := range clientEntities {
+		clients[i] = mapper.OAuthClientEntityToOAuthClientModel(&clientEntities[i])
+	}
+	return clients, nil
+}
+
+func (a *OAuthAdapter) CreateOAuthClient(ctx context.Context, tx pgx.Tx, client *model.OAuthClient) error {
+	katapp.Logger(ctx).Info("creating oauth client", "clientID", client.ID, "tenantID", client.TenantID)
+
+	entity := mapper.OAuthClientModelToOAuthClientEntity(client)
+	if err := repo.InsertOAuthClient(ctx, tx, entity); err != nil {
+		msg := "failed to create oauth client"
+		katapp.Logger(ctx).Error(msg, "clientID", client.ID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *OAuthAdapter) DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) ([]string, error) {
+	katapp.Logger(ctx).Info("deleting oauth client", "clientID", clientID)
+
+	sessionIDs, err := repo.DeleteOAuthClientSessions(ctx, tx, clientID)
+	if err != nil {
+		msg := "failed to delete oauth client sessions"
+		katapp.Logger(ctx).Error(msg, "clientID", clientID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	count, err := repo.DeleteOAuthClient(ctx, tx, clientID)
+	if err != nil {
+		msg := "failed to delete oauth client"
+		katapp.Logger(ctx).Error(msg, "clientID", clientID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return nil, katapp.NewErr(katapp.ErrNotFound, "oauth client not found")
+	}
+	return sessionIDs, nil
+}
+
+func (a *OAuthAdapter) CreateOAuthAuthorizationCode(ctx context.Context, tx pgx.Tx, code *model.OAuthAuthorizationCode) (*model.OAuthAuthorizationCode, error) {
+	katapp.Logger(ctx).Info("creating oauth authorization code", "clientID", code.ClientID, "userID", code.UserID)
+
+	err := repo.InsertOAuthAuthorizationCode(ctx, tx, code)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to create oauth authorization code", "clientID", code.ClientID, "userID", code.UserID, "error", err)
+		return nil, katpg.PgToAppError(err, "failed to create oauth authorization code")
+	}
+
+	return code, nil
+}
+
+func (a *OAuthAdapter) GetOAuthAuthorizationCodeByHash(ctx context.Context, tx pgx.Tx, codeHash string) (*model.OAuthAuthorizationCode, error) {
+	katapp.Logger(ctx).Debug("getting oauth authorization code")
+
+	code, err := repo.GetOAuthAuthorizationCodeByHash(ctx, tx, codeHash)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to get oauth authorization code", "error", err)
+		return nil, katpg.PgToAppError(err, "failed to get oauth authorization code")
+	}
+
+	return code, nil
+}
+
+func (a *OAuthAdapter) MarkOAuthAuthorizationCodeAsUsed(ctx context.Context, tx pgx.Tx, codeID string) (int64, error) {
+	katapp.Logger(ctx).Info("marking oauth authorization code as used", "codeID", codeID)
+
+	rowsAffected, err := repo.MarkOAuthAuthorizationCodeAsUsed(ctx, tx, codeID)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to mark oauth authorization code as used", "codeID", codeID, "error", err)
+		return 0, katpg.PgToAppError(err, "failed to mark oauth authorization code as used")
+	}
+
+	return rowsAffected, nil
+}
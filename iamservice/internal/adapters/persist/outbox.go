@@ -0,0 +1,131 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// OutboxAdapter implements the outport.OutboxPersist outport interface
+type OutboxAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewOutboxAdapter(db *katpg.DBLink) outport.OutboxPersist {
+	return &OutboxAdapter{db: db}
+}
+
+func (a *OutboxAdapter) CreateOutboxEmail(ctx context.Context, tx pgx.Tx, email *model.OutboxEmail) error {
+	katapp.Logger(ctx).Info("queueing outgoing email", "emailID", email.ID, "subject", email.Subject)
+
+	emailEntity, err := mapper.OutboxEmailModelToOutboxEmailEntity(email)
+	if err != nil {
+		msg := "failed to encode outgoing email"
+		katapp.Logger(ctx).Error(msg, "emailID", email.ID, "error", err)
+		return katapp.NewErr(katapp.ErrInternal, msg)
+	}
+	if err := repo.InsertOutboxEmail(ctx, tx, emailEntity); err != nil {
+		msg := "failed to queue outgoing email"
+		katapp.Logger(ctx).Error(msg, "emailID", email.ID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *OutboxAdapter) ClaimDueOutboxEmails(
+	ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+) ([]*model.OutboxEmail, error) {
+	emailEntities, err := repo.ClaimDueOutboxEmails(ctx, tx, now, claimedUntil, limit)
+	if err != nil {
+		msg := "failed to claim due outgoing emails"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return outboxEmailEntitiesToModels(ctx, emailEntities)
+}
+
+func (a *OutboxAdapter) MarkOutboxEmailAsSent(ctx context.Context, tx pgx.Tx, emailID string, sentAt time.Time) error {
+	katapp.Logger(ctx).Debug("marking outgoing email as sent", "emailID", emailID)
+
+	if err := repo.UpdateOutboxEmailAsSent(ctx, tx, emailID, sentAt); err != nil {
+		msg := "failed to mark outgoing email as sent"
+		katapp.Logger(ctx).Error(msg, "emailID", emailID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *OutboxAdapter) MarkOutboxEmailAsFailed(
+	ctx context.Context, tx pgx.Tx, emailID string, lastError string, nextAttemptAt *time.Time,
+) error {
+	katapp.Logger(ctx).Debug("marking outgoing email as failed", "emailID", emailID, "nextAttemptAt", nextAttemptAt)
+
+	if err := repo.UpdateOutboxEmailAsFailed(ctx, tx, emailID, lastError, nextAttemptAt); err != nil {
+		msg := "failed to mark outgoing email as failed"
+		katapp.Logger(ctx).Error(msg, "emailID", emailID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *OutboxAdapter) ListFailedOutboxEmails(
+	ctx context.Context, tx pgx.Tx, status *string, offset int, limit int,
+) ([]*model.OutboxEmail, int, error) {
+	katapp.Logger(ctx).Debug("listing failed outgoing emails", "status", status, "offset", offset, "limit", limit)
+
+	total, err := repo.CountFailedOutboxEmails(ctx, tx, status)
+	if err != nil {
+		msg := "failed to count failed outgoing emails"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	emailEntities, err := repo.SelectFailedOutboxEmails(ctx, tx, status, offset, limit)
+	if err != nil {
+		msg := "failed to list failed outgoing emails"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	emails, err := outboxEmailEntitiesToModels(ctx, emailEntities)
+	if err != nil {
+		return nil, 0, err
+	}
+	return emails, total, nil
+}
+
+func (a *OutboxAdapter) RetryOutboxEmail(ctx context.Context, tx pgx.Tx, emailID string, now time.Time) error {
+	katapp.Logger(ctx).Info("retrying outgoing email", "emailID", emailID)
+
+	count, err := repo.UpdateOutboxEmailForRetry(ctx, tx, emailID, now)
+	if err != nil {
+		msg := "failed to retry outgoing email"
+		katapp.Logger(ctx).Error(msg, "emailID", emailID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "undelivered email not found")
+	}
+	return nil
+}
+
+func outboxEmailEntitiesToModels(
+	ctx context.Context, emailEntities []repo.OutboxEmailEntity,
+) ([]*model.OutboxEmail, error) {
+	emails := make([]*model.OutboxEmail, len(emailEntities))
+	for i := range emailEntities {
+		email, err := mapper.OutboxEmailEntityToOutboxEmailModel(&emailEntities[i])
+		if err != nil {
+			msg := "failed to decode outgoing email"
+			katapp.Logger(ctx).Error(msg, "emailID", emailEntities[i].ID, "error", err)
+			return nil, katapp.NewErr(katapp.ErrInternal, msg)
+		}
+		emails[i] = email
+	}
+	return emails, nil
+}
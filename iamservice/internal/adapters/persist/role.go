@@ -0,0 +1,168 @@
+package persist
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// RoleAdapter implements the outport.RolePersist outport interface
+type RoleAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewRoleAdapter(db *katpg.DBLink) outport.RolePersist {
+	return &RoleAdapter{db: db}
+}
+
+func (a *RoleAdapter) GetPermissions(ctx context.Context, tx pgx.Tx) ([]*model.Permission, error) {
+	katapp.Logger(ctx).Debug("getting permissions")
+
+	permissionEntities, err := repo.SelectPermissions(ctx, tx)
+	if err != nil {
+		msg := "failed to get permissions"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	permissions := make([]*model.Permission, len(permissionEntities))
+	for i := range permissionEntities {
+		permissions[i] = mapper.PermissionEntityToPermissionModel(&permissionEntities[i])
+	}
+	return permissions, nil
+}
+
+func (a *RoleAdapter) GetRolesByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.Role, error) {
+	katapp.Logger(ctx).Debug("getting roles by tenant ID", "tenantID", tenantID)
+
+	roleEntities, err := repo.SelectRolesByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		msg := "failed to get roles by tenant ID"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	roles := make([]*model.Role, len(roleEntities))
+	for i := range roleEntities {
+		roles[i] = mapper.RoleEntityToRoleModel(&roleEntities[i])
+	}
+	return roles, nil
+}
+
+func (a *RoleAdapter) GetRoleByName(ctx context.Context, tx pgx.Tx, tenantID string, name string) (*model.Role, error) {
+	katapp.Logger(ctx).Debug("getting role by name", "tenantID", tenantID, "name", name)
+
+	roleEntity, err := repo.SelectRoleByTenantIDAndName(ctx, tx, tenantID, name)
+	if err != nil {
+		msg := "failed to get role by name"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "name", name, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if roleEntity == nil {
+		return nil, nil
+	}
+	return mapper.RoleEntityToRoleModel(roleEntity), nil
+}
+
+func (a *RoleAdapter) CreateRole(ctx context.Context, tx pgx.Tx, role *model.Role) error {
+	katapp.Logger(ctx).Info("creating role", "tenantID", role.TenantID, "name", role.Name)
+
+	roleID, err := repo.InsertRole(ctx, tx, mapper.RoleModelToRoleEntity(role))
+	if err != nil {
+		msg := "failed to create role"
+		katapp.Logger(ctx).Error(msg, "name", role.Name, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return a.insertRolePermissions(ctx, tx, roleID, role.Permissions)
+}
+
+func (a *RoleAdapter) UpdateRole(ctx context.Context, tx pgx.Tx, role *model.Role) error {
+	katapp.Logger(ctx).Info("updating role", "roleID", role.ID, "name", role.Name)
+
+	count, err := repo.UpdateRole(ctx, tx, mapper.RoleModelToRoleEntity(role))
+	if err != nil {
+		msg := "failed to update role"
+		katapp.Logger(ctx).Error(msg, "roleID", role.ID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "role not found")
+	}
+	if err := repo.DeleteRolePermissions(ctx, tx, role.ID); err != nil {
+		msg := "failed to revoke role permissions"
+		katapp.Logger(ctx).Error(msg, "roleID", role.ID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return a.insertRolePermissions(ctx, tx, role.ID, role.Permissions)
+}
+
+func (a *RoleAdapter) DeleteRole(ctx context.Context, tx pgx.Tx, roleID int) error {
+	katapp.Logger(ctx).Info("deleting role", "roleID", roleID)
+
+	count, err := repo.DeleteRole(ctx, tx, roleID)
+	if err != nil {
+		msg := "failed to delete role"
+		katapp.Logger(ctx).Error(msg, "roleID", roleID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "role not found")
+	}
+	return nil
+}
+
+func (a *RoleAdapter) GetPermissionsByRoles(
+	ctx context.Context, tx pgx.Tx, tenantID string, roles []string,
+) ([]string, error) {
+	permissions, err := repo.SelectPermissionsByRoles(ctx, tx, tenantID, roles)
+	if err != nil {
+		msg := "failed to get permissions of roles"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "roles", roles, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return permissions, nil
+}
+
+func (a *RoleAdapter) GetUserIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error) {
+	userIDs, err := repo.SelectUserIDsByRoleID(ctx, tx, roleID)
+	if err != nil {
+		msg := "failed to get users of role"
+		katapp.Logger(ctx).Error(msg, "roleID", roleID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return userIDs, nil
+}
+
+func (a *RoleAdapter) GetServiceClientIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error) {
+	clientIDs, err := repo.SelectServiceClientIDsByRoleID(ctx, tx, roleID)
+	if err != nil {
+		msg := "failed to get service clients of role"
+		katapp.Logger(ctx).Error(msg, "roleID", roleID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return clientIDs, nil
+}
+
+func (a *RoleAdapter) insertRolePermissions(ctx context.Context, tx pgx.Tx, roleID int, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	count, err := repo.InsertRolePermissions(ctx, tx, roleID, permissions)
+	if err != nil {
+		msg := "failed to grant role permissions"
+		katapp.Logger(ctx).Error(msg, "roleID", roleID, "permissions", permissions, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count != int64(len(permissions)) {
+		msg := "unknown permission"
+		katapp.Logger(ctx).Error(msg, "roleID", roleID, "permissions", permissions)
+		return katapp.NewErr(katapp.ErrInvalidInput, msg)
+	}
+	return nil
+}
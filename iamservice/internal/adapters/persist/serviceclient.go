@@ -0,0 +1,100 @@
+package persist
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// ServiceClientAdapter implements the outport.ServiceClientPersist outport interface
+type ServiceClientAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewServiceClientAdapter(db *katpg.DBLink) outport.ServiceClientPersist {
+	return &ServiceClientAdapter{db: db}
+}
+
+func (a *ServiceClientAdapter) CreateServiceClient(ctx context.Context, tx pgx.Tx, client *model.ServiceClient) error {
+	katapp.Logger(ctx).Info("creating service client", "clientID", client.ID, "tenantID", client.TenantID)
+
+	entity := mapper.ServiceClientModelToServiceClientEntity(client)
+	if err := repo.InsertServiceClient(ctx, tx, entity); err != nil {
+		msg := "failed to create service client"
+		katapp.Logger(ctx).Error(msg, "clientID", client.ID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if len(client.Roles) == 0 {
+		return nil
+	}
+
+	count, err := repo.InsertServiceClientRoles(ctx, tx, client.ID, client.Roles)
+	if err != nil {
+		msg := "failed to assign service client roles"
+		katapp.Logger(ctx).Error(msg, "clientID", client.ID, "roles", client.Roles, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count != int64(len(client.Roles)) {
+		msg := "role not found"
+		katapp.Logger(ctx).Error(msg, "clientID", client.ID, "roles", client.Roles)
+		return katapp.NewErr(katapp.ErrNotFound, msg)
+	}
+	return nil
+}
+
+func (a *ServiceClientAdapter) GetServiceClientByID(
+	ctx context.Context, tx pgx.Tx, clientID string,
+) (*model.ServiceClient, error) {
+	katapp.Logger(ctx).Debug("getting service client by ID", "clientID", clientID)
+
+	clientEntity, err := repo.SelectServiceClientByID(ctx, tx, clientID)
+	if err != nil {
+		msg := "failed to get service client by ID"
+		katapp.Logger(ctx).Error(msg, "clientID", clientID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if clientEntity == nil {
+		return nil, nil
+	}
+	return mapper.ServiceClientEntityToServiceClientModel(clientEntity), nil
+}
+
+func (a *ServiceClientAdapter) GetServiceClientsByTenantID(
+	ctx context.Context, tx pgx.Tx, tenantID string,
+) ([]*model.ServiceClient, error) {
+	katapp.Logger(ctx).Debug("getting service clients by tenant ID", "tenantID", tenantID)
+
+	clientEntities, err := repo.SelectServiceClientsByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		msg := "failed to get service clients by tenant ID"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+
+	clients := make([]*model.ServiceClient, len(clientEntities))
+	for i := range clientEntities {
+		clients[i] = mapper.ServiceClientEntityToServiceClientModel(&clientEntities[i])
+	}
+	return clients, nil
+}
+
+func (a *ServiceClientAdapter) DeleteServiceClient(ctx context.Context, tx pgx.Tx, clientID string) error {
+	katapp.Logger(ctx).Info("deleting service client", "clientID", clientID)
+
+	count, err := repo.DeleteServiceClient(ctx, tx, clientID)
+	if err != nil {
+		msg := "failed to delete service client"
+		katapp.Logger(ctx).Error(msg, "clientID", clientID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "service client not found")
+	}
+	return nil
+}
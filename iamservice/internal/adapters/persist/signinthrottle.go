@@ -0,0 +1,87 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// SignInThrottleAdapter implements the outport.SignInThrottlePersist outport interface
+type SignInThrottleAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewSignInThrottleAdapter(db *katpg.DBLink) outport.SignInThrottlePersist {
+	return &SignInThrottleAdapter{db: db}
+}
+
+func (a *SignInThrottleAdapter) GetSignInThrottle(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string,
+) (*model.SignInThrottle, error) {
+	katapp.Logger(ctx).Debug("getting sign in throttle", "subjectType", subjectType, "subject", subject)
+
+	throttleEntity, err := repo.SelectSignInThrottle(ctx, tx, subjectType, subject)
+	if err != nil {
+		msg := "failed to get sign in throttle"
+		katapp.Logger(ctx).Error(msg, "subjectType", subjectType, "subject", subject, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if throttleEntity == nil {
+		return nil, nil
+	}
+	return mapper.SignInThrottleEntityToSignInThrottleModel(throttleEntity), nil
+}
+
+func (a *SignInThrottleAdapter) RecordSignInFailure(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string, window time.Duration,
+) (int, error) {
+	katapp.Logger(ctx).Info("recording failed sign in attempt", "subjectType", subjectType, "subject", subject)
+
+	now := time.Now()
+	failedAttempts, err := repo.RecordSignInFailure(ctx, tx, subjectType, subject, now.Add(-window), now)
+	if err != nil {
+		msg := "failed to record failed sign in attempt"
+		katapp.Logger(ctx).Error(msg, "subjectType", subjectType, "subject", subject, "error", err)
+		return 0, katpg.PgToAppError(err, msg)
+	}
+
+	return failedAttempts, nil
+}
+
+func (a *SignInThrottleAdapter) LockSignInSubject(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string, lockedUntil time.Time, lockoutCount int,
+) error {
+	katapp.Logger(ctx).Warn("locking sign in", "subjectType", subjectType, "subject", subject,
+		"lockedUntil", lockedUntil, "lockoutCount", lockoutCount)
+
+	err := repo.LockSignInSubject(ctx, tx, subjectType, subject, lockedUntil, lockoutCount, time.Now())
+	if err != nil {
+		msg := "failed to lock sign in"
+		katapp.Logger(ctx).Error(msg, "subjectType", subjectType, "subject", subject, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+
+	return nil
+}
+
+func (a *SignInThrottleAdapter) ClearSignInThrottle(
+	ctx context.Context, tx pgx.Tx, subjectType string, subject string,
+) error {
+	katapp.Logger(ctx).Debug("clearing sign in throttle", "subjectType", subjectType, "subject", subject)
+
+	err := repo.DeleteSignInThrottle(ctx, tx, subjectType, subject)
+	if err != nil {
+		msg := "failed to clear sign in throttle"
+		katapp.Logger(ctx).Error(msg, "subjectType", subjectType, "subject", subject, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// TokenRevocationAdapter implements the outport.TokenRevocationPersist outport interface
+type TokenRevocationAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewTokenRevocationAdapter(db *katpg.DBLink) outport.TokenRevocationPersist {
+	return &TokenRevocationAdapter{db: db}
+}
+
+func (a *TokenRevocationAdapter) RevokeAccessTokens(
+	ctx context.Context, tx pgx.Tx, revocation *model.AccessTokenRevocation,
+) error {
+	katapp.Logger(ctx).Info("revoking access tokens", "subjectType", revocation.SubjectType,
+		"subject", revocation.Subject, "issuedBefore", revocation.IssuedBefore)
+
+	entity := mapper.AccessTokenRevocationModelToAccessTokenRevocationEntity(revocation)
+	if err := repo.UpsertAccessTokenRevocation(ctx, tx, entity); err != nil {
+		msg := "failed to revoke access tokens"
+		katapp.Logger(ctx).Error(msg, "subjectType", revocation.SubjectType, "subject", revocation.Subject, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+
+	return nil
+}
+
+func (a *TokenRevocationAdapter) IsAccessTokenRevoked(
+	ctx context.Context, tx pgx.Tx, jti string, sessionID string, subject string, issuedAt time.Time,
+) (bool, error) {
+	revoked, err := repo.ExistsAccessTokenRevocation(ctx, tx, jti, sessionID, subject, issuedAt)
+	if err != nil {
+		msg := "failed to check access token revocation"
+		katapp.Logger(ctx).Error(msg, "jti", jti, "subject", subject, "error", err)
+		return false, katpg.PgToAppError(err, msg)
+	}
+
+	return revoked, nil
+}
+
+func (a *TokenRevocationAdapter) DeleteExpiredAccessTokenRevocations(ctx context.Context, tx pgx.Tx) (int64, error) {
+	katapp.Logger(ctx).Debug("deleting expired access token revocations")
+
+	rowsAffected, err := repo.DeleteExpiredAccessTokenRevocations(ctx, tx, time.Now())
+	if err != nil {
+		msg := "failed to delete expired access token revocations"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return 0, katpg.PgToAppError(err, msg)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,212 @@
+package persist
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/mapper"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist/internal/repo"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/mobiletoly/gokatana/katpg"
+)
+
+// WebhookAdapter implements the outport.WebhookPersist outport interface
+type WebhookAdapter struct {
+	db *katpg.DBLink
+}
+
+func NewWebhookAdapter(db *katpg.DBLink) outport.WebhookPersist {
+	return &WebhookAdapter{db: db}
+}
+
+func (a *WebhookAdapter) CreateWebhook(ctx context.Context, tx pgx.Tx, webhook *model.Webhook) error {
+	katapp.Logger(ctx).Info("creating webhook", "webhookID", webhook.ID, "tenantID", webhook.TenantID)
+
+	if err := repo.InsertWebhook(ctx, tx, mapper.WebhookModelToWebhookEntity(webhook)); err != nil {
+		msg := "failed to create webhook"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhook.ID, "tenantID", webhook.TenantID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) GetWebhooksByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.Webhook, error) {
+	katapp.Logger(ctx).Debug("getting webhooks by tenant ID", "tenantID", tenantID)
+
+	webhookEntities, err := repo.SelectWebhooksByTenantID(ctx, tx, tenantID)
+	if err != nil {
+		msg := "failed to get webhooks by tenant ID"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return webhookEntitiesToModels(webhookEntities), nil
+}
+
+func (a *WebhookAdapter) GetWebhookByID(
+	ctx context.Context, tx pgx.Tx, tenantID string, webhookID string,
+) (*model.Webhook, error) {
+	webhookEntity, err := repo.SelectWebhookByID(ctx, tx, tenantID, webhookID)
+	if err != nil {
+		msg := "failed to get webhook by ID"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhookID, "tenantID", tenantID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if webhookEntity == nil {
+		return nil, nil
+	}
+	return mapper.WebhookEntityToWebhookModel(webhookEntity), nil
+}
+
+func (a *WebhookAdapter) GetWebhooksByEventType(
+	ctx context.Context, tx pgx.Tx, tenantID string, eventType string,
+) ([]*model.Webhook, error) {
+	webhookEntities, err := repo.SelectWebhooksByEventType(ctx, tx, tenantID, eventType)
+	if err != nil {
+		msg := "failed to get webhooks by event type"
+		katapp.Logger(ctx).Error(msg, "tenantID", tenantID, "eventType", eventType, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	return webhookEntitiesToModels(webhookEntities), nil
+}
+
+func (a *WebhookAdapter) UpdateWebhook(ctx context.Context, tx pgx.Tx, webhook *model.Webhook) error {
+	katapp.Logger(ctx).Info("updating webhook", "webhookID", webhook.ID, "tenantID", webhook.TenantID)
+
+	count, err := repo.UpdateWebhook(ctx, tx, mapper.WebhookModelToWebhookEntity(webhook))
+	if err != nil {
+		msg := "failed to update webhook"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhook.ID, "tenantID", webhook.TenantID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "webhook not found")
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) DeleteWebhook(ctx context.Context, tx pgx.Tx, tenantID string, webhookID string) error {
+	katapp.Logger(ctx).Info("deleting webhook", "webhookID", webhookID, "tenantID", tenantID)
+
+	count, err := repo.DeleteWebhook(ctx, tx, tenantID, webhookID)
+	if err != nil {
+		msg := "failed to delete webhook"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhookID, "tenantID", tenantID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	if count == 0 {
+		return katapp.NewErr(katapp.ErrNotFound, "webhook not found")
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) CreateWebhookDeliveries(
+	ctx context.Context, tx pgx.Tx, deliveries []*model.WebhookDelivery,
+) error {
+	for _, delivery := range deliveries {
+		katapp.Logger(ctx).Info("queueing webhook event",
+			"deliveryID", delivery.ID, "webhookID", delivery.WebhookID, "eventType", delivery.EventType)
+
+		entity := mapper.WebhookDeliveryModelToWebhookDeliveryEntity(delivery)
+		if err := repo.InsertWebhookDelivery(ctx, tx, entity); err != nil {
+			msg := "failed to queue webhook event"
+			katapp.Logger(ctx).Error(msg, "deliveryID", delivery.ID, "webhookID", delivery.WebhookID, "error", err)
+			return katpg.PgToAppError(err, msg)
+		}
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) ClaimDueWebhookDeliveries(
+	ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+) ([]*model.DueWebhookDelivery, error) {
+	deliveryEntities, err := repo.ClaimDueWebhookDeliveries(ctx, tx, now, claimedUntil, limit)
+	if err != nil {
+		msg := "failed to claim due webhook deliveries"
+		katapp.Logger(ctx).Error(msg, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	deliveries := make([]*model.DueWebhookDelivery, len(deliveryEntities))
+	for i := range deliveryEntities {
+		deliveries[i] = mapper.DueWebhookDeliveryEntityToDueWebhookDeliveryModel(&deliveryEntities[i])
+	}
+	return deliveries, nil
+}
+
+func (a *WebhookAdapter) MarkWebhookDeliveryAsDelivered(
+	ctx context.Context, tx pgx.Tx, deliveryID string, statusCode int, deliveredAt time.Time,
+) error {
+	katapp.Logger(ctx).Debug("marking webhook delivery as delivered", "deliveryID", deliveryID)
+
+	if err := repo.UpdateWebhookDeliveryAsDelivered(ctx, tx, deliveryID, statusCode, deliveredAt); err != nil {
+		msg := "failed to mark webhook delivery as delivered"
+		katapp.Logger(ctx).Error(msg, "deliveryID", deliveryID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) MarkWebhookDeliveryAsFailed(
+	ctx context.Context, tx pgx.Tx, deliveryID string, statusCode *int, lastError string, nextAttemptAt *time.Time,
+) error {
+	katapp.Logger(ctx).Debug("marking webhook delivery as failed", "deliveryID", deliveryID,
+		"nextAttemptAt", nextAttemptAt)
+
+	err := repo.UpdateWebhookDeliveryAsFailed(ctx, tx, deliveryID, statusCode, lastError, nextAttemptAt)
+	if err != nil {
+		msg := "failed to mark webhook delivery as failed"
+		katapp.Logger(ctx).Error(msg, "deliveryID", deliveryID, "error", err)
+		return katpg.PgToAppError(err, msg)
+	}
+	return nil
+}
+
+func (a *WebhookAdapter) ListWebhookDeliveries(
+	ctx context.Context, tx pgx.Tx, webhookID string, status *string, offset int, limit int,
+) ([]*model.WebhookDelivery, int, error) {
+	katapp.Logger(ctx).Debug("listing webhook deliveries", "webhookID", webhookID, "status", status,
+		"offset", offset, "limit", limit)
+
+	total, err := repo.CountWebhookDeliveries(ctx, tx, webhookID, status)
+	if err != nil {
+		msg := "failed to count webhook deliveries"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhookID, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	deliveryEntities, err := repo.SelectWebhookDeliveries(ctx, tx, webhookID, status, offset, limit)
+	if err != nil {
+		msg := "failed to list webhook deliveries"
+		katapp.Logger(ctx).Error(msg, "webhookID", webhookID, "error", err)
+		return nil, 0, katpg.PgToAppError(err, msg)
+	}
+	deliveries := make([]*model.WebhookDelivery, len(deliveryEntities))
+	for i := range deliveryEntities {
+		deliveries[i] = mapper.WebhookDeliveryEntityToWebhookDeliveryModel(&deliveryEntities[i])
+	}
+	return deliveries, total, nil
+}
+
+func (a *WebhookAdapter) GetWebhookDeliveryByID(
+	ctx context.Context, tx pgx.Tx, webhookID string, deliveryID string,
+) (*model.WebhookDelivery, error) {
+	deliveryEntity, err := repo.SelectWebhookDeliveryByID(ctx, tx, webhookID, deliveryID)
+	if err != nil {
+		msg := "failed to get webhook delivery by ID"
+		katapp.Logger(ctx).Error(msg, "deliveryID", deliveryID, "webhookID", webhookID, "error", err)
+		return nil, katpg.PgToAppError(err, msg)
+	}
+	if deliveryEntity == nil {
+		return nil, nil
+	}
+	return mapper.WebhookDeliveryEntityToWebhookDeliveryModel(deliveryEntity), nil
+}
+
+func webhookEntitiesToModels(webhookEntities []repo.WebhookEntity) []*model.Webhook {
+	webhooks := make([]*model.Webhook, len(webhookEntities))
+	for i := range webhookEntities {
+		webhooks[i] = mapper.WebhookEntityToWebhookModel(&webhookEntities[i])
+	}
+	return webhooks
+}
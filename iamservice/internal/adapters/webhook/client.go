@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+)
+
+// maxDrainedResponseSize limits how much of a response is read, so that the connection can be reused
+const maxDrainedResponseSize = 64 << 10
+
+var errForbiddenAddress = errors.New("webhook address is not publicly routable")
+
+type webhookAdapter struct {
+	httpClient *http.Client
+}
+
+// NewWebhookClient creates a client posting events to webhooks. Redirects are not followed, a webhook that
+// moved has to be updated. Unless private networks are allowed, connections to addresses that are not publicly
+// routable are refused when they are made, so that a webhook host cannot be re-resolved to an internal address
+// after it was validated.
+func NewWebhookClient(webhookConfig *app.WebhookConfig) outport.WebhookClient {
+	dialer := &net.Dialer{
+		Timeout:   webhookConfig.RequestTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !webhookConfig.AllowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // a proxy would dial webhooks on our behalf and bypass the address check
+	transport.DialContext = dialer.DialContext
+	return &webhookAdapter{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   webhookConfig.RequestTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// deniedPrefixes are the special-purpose blocks of the IANA IPv4 and IPv6 address registries that are not
+// publicly routable, or that translate or relay to IPv4 addresses that may not be
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space (carrier-grade NAT)
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation (TEST-NET-1)
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation (TEST-NET-2)
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation (TEST-NET-3)
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including limited broadcast
+	netip.MustParsePrefix("::/96"),           // unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("::ffff:0:0/96"),   // IPv4-mapped, unmapped before the check
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64 well-known prefix
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // NAT64 local-use
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, including Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+	netip.MustParsePrefix("fc00::/7"),        // unique local
+	netip.MustParsePrefix("fe80::/10"),       // link-local
+	netip.MustParsePrefix("fec0::/10"),       // site-local
+	netip.MustParsePrefix("ff00::/8"),        // multicast
+}
+
+// rejectPrivateAddress is a net.Dialer control function that refuses to connect to addresses of deniedPrefixes.
+// IPv4-mapped IPv6 addresses are checked as the IPv4 addresses they map to.
+func rejectPrivateAddress(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errForbiddenAddress, address)
+	}
+	// Prefixes never contain addresses with a zone
+	addr := addrPort.Addr().Unmap().WithZone("")
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", errForbiddenAddress, address)
+		}
+	}
+	return nil
+}
+
+func (w *webhookAdapter) PostWebhook(
+	ctx context.Context, url string, headers map[string]string, body []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "iamservice-webhook")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseSize))
+	return resp.StatusCode, nil
+}
@@ -84,7 +84,7 @@ func GetAuthenticatedUserEmailFromCookie(c echo.Context, auth *usecase.AuthMgm)
 	}
 
 	// Validate the access token
-	_, err = auth.ValidateAccessToken(accessCookie.Value)
+	_, err = auth.ValidateAccessToken(c.Request().Context(), accessCookie.Value)
 	if err != nil {
 		return "", false
 	}
@@ -0,0 +1,74 @@
+package webadmin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/samber/lo"
+)
+
+// AuditWebHandlers handles audit log web requests
+type AuditWebHandlers struct {
+	auditMgm *usecase.AuditMgm
+}
+
+// NewAuditWebHandlers creates a new instance of AuditWebHandlers
+func NewAuditWebHandlers(auditMgm *usecase.AuditMgm) *AuditWebHandlers {
+	return &AuditWebHandlers{
+		auditMgm: auditMgm,
+	}
+}
+
+// AuditLogLoadHandler renders the audit log. Principals with audit:read in all tenants can filter by tenant,
+// others see their own tenant only.
+func (h *AuditWebHandlers) AuditLogLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	filter := admin.AuditLogFilter{
+		Action:   strings.TrimSpace(c.QueryParam("action")),
+		TargetID: strings.TrimSpace(c.QueryParam("targetId")),
+	}
+	canFilterTenant := principal.HasPermissionInAllTenants(model.PermissionAuditRead)
+	if canFilterTenant {
+		filter.TenantID = strings.TrimSpace(c.QueryParam("tenantId"))
+	}
+
+	page := 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	limit := 20
+
+	params := swagger.NewListAuditEventsParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		TenantId(lo.EmptyableToPtr(filter.TenantID)).
+		Action(lo.EmptyableToPtr(filter.Action)).
+		ActorUserId(nil).
+		TargetId(lo.EmptyableToPtr(filter.TargetID)).
+		From(nil).
+		To(nil).
+		Build()
+	events, err := h.auditMgm.ListAuditEvents(ctx, principal, params)
+	if err != nil {
+		return err
+	}
+
+	// Filter and pagination requests replace the events list only
+	if c.Request().Header.Get("HX-Target") == "audit-list" {
+		return admin.AuditLogContent(events).Render(ctx, c.Response().Writer)
+	}
+	return renderTemplateComponent(c, "Audit Log", admin.AuditLog(events, filter, canFilterTenant))
+}
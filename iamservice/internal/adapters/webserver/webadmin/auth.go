@@ -3,6 +3,7 @@ package webadmin
 import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver/mw"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/common"
 	"net/http"
 	"strings"
 
@@ -51,10 +52,49 @@ func (h *AuthWebHandlers) SignInSubmitHandler(c echo.Context) error {
 		TenantId: tenantId,
 	}
 
-	authResp, err := h.authMgm.SignIn(ctx, signinReq)
+	authResp, mfaChallenge, err := h.authMgm.SignIn(ctx, signinReq, c.RealIP())
 	if err != nil {
 		return err
 	}
+	if mfaChallenge != nil {
+		// Replace the whole sign-in form with the second step
+		c.Response().Header().Set("HX-Retarget", "#content")
+		return renderTemplateComponent(c, "Two-Factor Authentication",
+			common.MFAChallengeForm("/web/admin", mfaChallenge, email, ""))
+	}
+	return h.completeSignIn(c, authResp, email)
+}
+
+// MFAVerifySubmitHandler handles the second sign-in step
+func (h *AuthWebHandlers) MFAVerifySubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	verifyReq := &swagger.MfaVerifyRequest{
+		MfaToken: strings.TrimSpace(c.FormValue("mfaToken")),
+		Code:     strings.TrimSpace(c.FormValue("code")),
+	}
+	authResp, err := h.authMgm.VerifyMFA(ctx, verifyReq, c.RealIP())
+	if err != nil {
+		return err
+	}
+	return h.completeSignIn(c, authResp, strings.TrimSpace(c.FormValue("email")))
+}
+
+// MFAEnrollSubmitHandler registers an authenticator for admins who must enroll before signing in
+func (h *AuthWebHandlers) MFAEnrollSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	enrollment, err := h.authMgm.EnrollMFAWithChallenge(ctx, &swagger.MfaEnrollRequest{
+		MfaToken: strings.TrimSpace(c.FormValue("mfaToken")),
+	})
+	if err != nil {
+		return err
+	}
+	return common.TOTPEnrollmentPanel(enrollment).Render(ctx, c.Response().Writer)
+}
+
+// completeSignIn stores the issued tokens and redirects to the admin home page
+func (h *AuthWebHandlers) completeSignIn(c echo.Context, authResp *swagger.SignInResponse, email string) error {
 	mw.SetAuthCookies(c, authResp.AccessToken, authResp.RefreshToken, email)
 	if mw.IsHTMX(c) {
 		// For HTMX requests, redirect to home page to refresh the entire layout
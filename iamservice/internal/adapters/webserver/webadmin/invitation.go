@@ -0,0 +1,124 @@
+package webadmin
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/samber/lo"
+)
+
+// InvitationWebHandlers handles invitation-related web requests
+type InvitationWebHandlers struct {
+	invitationMgm *usecase.InvitationMgm
+	roleMgm       *usecase.RoleMgm
+}
+
+// NewInvitationWebHandlers creates a new instance of InvitationWebHandlers
+func NewInvitationWebHandlers(invitationMgm *usecase.InvitationMgm, roleMgm *usecase.RoleMgm) *InvitationWebHandlers {
+	return &InvitationWebHandlers{
+		invitationMgm: invitationMgm,
+		roleMgm:       roleMgm,
+	}
+}
+
+// InvitationsListLoadHandler renders the pending invitations of a tenant
+func (h *InvitationWebHandlers) InvitationsListLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	invitationsResponse, err := h.invitationMgm.ListInvitations(ctx, principal, tenantID)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Invitations", admin.InvitationsList(tenantID, invitationsResponse.Items))
+}
+
+// NewInvitationLoadHandler renders the form of inviting a user, offering the roles the principal may grant
+func (h *InvitationWebHandlers) NewInvitationLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	var roles []swagger.RoleResponse
+	if principal.HasTenantPermission(model.PermissionUsersAssignRoles, tenantID) &&
+		principal.HasTenantPermission(model.PermissionRolesRead, tenantID) {
+		rolesResponse, err := h.roleMgm.ListRoles(ctx, principal, tenantID)
+		if err != nil {
+			return err
+		}
+		roles = lo.Filter(rolesResponse.Items, func(role swagger.RoleResponse, _ int) bool {
+			missing, _ := lo.Difference(role.Permissions, principal.Permissions)
+			return role.Name != model.RoleUser && len(missing) == 0
+		})
+	}
+	return renderTemplateComponent(c, "Invite User", admin.InvitationForm(tenantID, roles))
+}
+
+// CreateInvitationSubmitHandler handles inviting a user
+func (h *InvitationWebHandlers) CreateInvitationSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	formParams, err := c.FormParams()
+	if err != nil {
+		return err
+	}
+	createReq := swagger.NewCreateInvitationRequestBuilder().
+		Email(strings.TrimSpace(c.FormValue("email"))).
+		Roles(lo.EmptyableToPtr(formParams["roles"])).
+		Build()
+
+	invitation, err := h.invitationMgm.CreateInvitation(ctx, principal, tenantID, createReq)
+	if err != nil {
+		return err
+	}
+	return admin.InvitationFormSuccess(tenantID, invitation.Email).Render(ctx, c.Response().Writer)
+}
+
+// ResendInvitationSubmitHandler handles resending an invitation with a new link
+func (h *InvitationWebHandlers) ResendInvitationSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	invitation, err := h.invitationMgm.ResendInvitation(ctx, principal, tenantID, c.Param("invitationId"))
+	if err != nil {
+		return err
+	}
+	return admin.InvitationRow(tenantID, *invitation).Render(ctx, c.Response().Writer)
+}
+
+// RevokeInvitationSubmitHandler handles invitation revocation
+func (h *InvitationWebHandlers) RevokeInvitationSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err = h.invitationMgm.RevokeInvitation(ctx, principal, c.Param("id"), c.Param("invitationId")); err != nil {
+		return err
+	}
+
+	// For HTMX, return empty content to remove the row from the DOM
+	c.Response().WriteHeader(200)
+	return nil
+}
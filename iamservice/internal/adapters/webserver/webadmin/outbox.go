@@ -0,0 +1,78 @@
+package webadmin
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/samber/lo"
+)
+
+// OutboxWebHandlers handles email outbox web requests
+type OutboxWebHandlers struct {
+	outboxMgm *usecase.OutboxMgm
+}
+
+// NewOutboxWebHandlers creates a new instance of OutboxWebHandlers
+func NewOutboxWebHandlers(outboxMgm *usecase.OutboxMgm) *OutboxWebHandlers {
+	return &OutboxWebHandlers{
+		outboxMgm: outboxMgm,
+	}
+}
+
+// OutboxEmailsLoadHandler renders outgoing emails that failed to be delivered
+func (h *OutboxWebHandlers) OutboxEmailsLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	page := 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	limit := 20
+	var status *swagger.ListFailedOutboxEmailsParamsStatus
+	if statusStr := c.QueryParam("status"); statusStr != "" {
+		status = lo.ToPtr(swagger.ListFailedOutboxEmailsParamsStatus(statusStr))
+	}
+
+	params := swagger.NewListFailedOutboxEmailsParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		Status(status).
+		Build()
+	emails, err := h.outboxMgm.ListFailedOutboxEmails(ctx, principal, params)
+	if err != nil {
+		return err
+	}
+
+	// Filter and pagination requests replace the emails list only
+	if c.Request().Header.Get("HX-Target") == "outbox-list" {
+		return admin.OutboxEmailsContent(emails).Render(ctx, c.Response().Writer)
+	}
+	return renderTemplateComponent(c, "Email Outbox", admin.OutboxEmails(emails))
+}
+
+// RetryOutboxEmailSubmitHandler makes an undelivered email due for delivery right away
+func (h *OutboxWebHandlers) RetryOutboxEmailSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err = h.outboxMgm.RetryOutboxEmail(ctx, principal, c.Param("emailId")); err != nil {
+		return err
+	}
+
+	// For HTMX, return empty content to remove the row from the DOM, the email is listed again if it fails
+	c.Response().WriteHeader(200)
+	return nil
+}
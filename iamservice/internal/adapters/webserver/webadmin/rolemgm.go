@@ -0,0 +1,143 @@
+package webadmin
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/samber/lo"
+)
+
+// RoleMgmWebHandlers handles role management-related web requests
+type RoleMgmWebHandlers struct {
+	roleMgm *usecase.RoleMgm
+}
+
+// NewRoleMgmWebHandlers creates a new instance of RoleMgmWebHandlers
+func NewRoleMgmWebHandlers(roleMgm *usecase.RoleMgm) *RoleMgmWebHandlers {
+	return &RoleMgmWebHandlers{
+		roleMgm: roleMgm,
+	}
+}
+
+// RolesListLoadHandler renders the roles of a tenant
+func (h *RoleMgmWebHandlers) RolesListLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	rolesResponse, err := h.roleMgm.ListRoles(ctx, principal, tenantID)
+	if err != nil {
+		return err
+	}
+	canManageRoles := principal.HasTenantPermission(model.PermissionRolesManage, tenantID)
+	return renderTemplateComponent(c, "Roles", admin.RolesList(tenantID, rolesResponse.Items, canManageRoles))
+}
+
+// NewRoleLoadHandler renders the form of a new custom role
+func (h *RoleMgmWebHandlers) NewRoleLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	permissionsResponse, err := h.roleMgm.ListPermissions(ctx, principal)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Add Role", admin.RoleForm(c.Param("id"), nil, permissionsResponse.Items))
+}
+
+// RoleEditLoadHandler renders the edit form of a custom role
+func (h *RoleMgmWebHandlers) RoleEditLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	roleResponse, err := h.roleMgm.GetRole(ctx, principal, tenantID, c.Param("roleName"))
+	if err != nil {
+		return err
+	}
+	permissionsResponse, err := h.roleMgm.ListPermissions(ctx, principal)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Edit Role", admin.RoleForm(tenantID, roleResponse, permissionsResponse.Items))
+}
+
+// CreateRoleSubmitHandler handles custom role creation
+func (h *RoleMgmWebHandlers) CreateRoleSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	formParams, err := c.FormParams()
+	if err != nil {
+		return err
+	}
+	createReq := swagger.NewCreateRoleRequestBuilder().
+		Description(lo.EmptyableToPtr(strings.TrimSpace(c.FormValue("description")))).
+		Name(strings.TrimSpace(c.FormValue("name"))).
+		Permissions(lo.CoalesceSliceOrEmpty(formParams["permissions"])).
+		Build()
+
+	role, err := h.roleMgm.CreateRole(ctx, principal, tenantID, createReq)
+	if err != nil {
+		return err
+	}
+	return admin.RoleFormSuccess(tenantID, role.Name, "created").Render(ctx, c.Response().Writer)
+}
+
+// UpdateRoleSubmitHandler handles custom role updates
+func (h *RoleMgmWebHandlers) UpdateRoleSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	formParams, err := c.FormParams()
+	if err != nil {
+		return err
+	}
+	updateReq := swagger.NewUpdateRoleRequestBuilder().
+		Description(lo.EmptyableToPtr(strings.TrimSpace(c.FormValue("description")))).
+		Permissions(lo.CoalesceSliceOrEmpty(formParams["permissions"])).
+		Build()
+
+	role, err := h.roleMgm.UpdateRole(ctx, principal, tenantID, c.Param("roleName"), updateReq)
+	if err != nil {
+		return err
+	}
+	return admin.RoleFormSuccess(tenantID, role.Name, "updated").Render(ctx, c.Response().Writer)
+}
+
+// DeleteRoleSubmitHandler handles custom role deletion
+func (h *RoleMgmWebHandlers) DeleteRoleSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err = h.roleMgm.DeleteRole(ctx, principal, c.Param("id"), c.Param("roleName")); err != nil {
+		return err
+	}
+
+	// For HTMX, return empty content to remove the row from the DOM
+	c.Response().WriteHeader(200)
+	return nil
+}
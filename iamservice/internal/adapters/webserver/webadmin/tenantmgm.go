@@ -3,11 +3,13 @@ package webadmin
 import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/samber/lo"
 )
 
 // TenantMgmWebHandlers handles tenant management-related web requests
@@ -23,7 +25,7 @@ func NewTenantMgmWebHandlers(authUC *usecase.AuthMgm) *TenantMgmWebHandlers {
 }
 
 // TenantsListLoadHandler renders the tenants list
-// Note: Sysadmin role validation is handled by middleware
+// Note: tenants:read permission validation is handled by middleware
 func (h *TenantMgmWebHandlers) TenantsListLoadHandler(c echo.Context) error {
 	ctx := c.Request().Context()
 	principal, err := serverhelp.GetUserPrincipalFromToken(c)
@@ -31,11 +33,38 @@ func (h *TenantMgmWebHandlers) TenantsListLoadHandler(c echo.Context) error {
 		return err
 	}
 
-	tenantsListResponse, err := h.authMgm.GetAllTenants(ctx, principal)
+	filter := admin.TenantsFilter{
+		Search:    strings.TrimSpace(c.QueryParam("search")),
+		SortBy:    c.QueryParam("sortBy"),
+		SortOrder: c.QueryParam("sortOrder"),
+	}
+
+	page := 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	limit := 18
+
+	params := swagger.NewListAllTenantsParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		Cursor(nil).
+		SortBy((*swagger.TenantSortBy)(lo.EmptyableToPtr(filter.SortBy))).
+		SortOrder((*swagger.SortOrder)(lo.EmptyableToPtr(filter.SortOrder))).
+		Search(lo.EmptyableToPtr(filter.Search)).
+		Build()
+	tenantsListResponse, err := h.authMgm.ListAllTenants(ctx, principal, params)
 	if err != nil {
 		return err
 	}
-	return renderTemplateComponent(c, "Tenants", admin.TenantsList(tenantsListResponse))
+
+	// Filter and pagination requests replace the tenants list only
+	if c.Request().Header.Get("HX-Target") == "tenants-list" {
+		return admin.TenantsListContent(tenantsListResponse).Render(ctx, c.Response().Writer)
+	}
+	return renderTemplateComponent(c, "Tenants", admin.TenantsList(tenantsListResponse, filter))
 }
 
 // NewTenantLoadHandler renders the tenant form
@@ -109,9 +138,11 @@ func (h *TenantMgmWebHandlers) UpdateTenantSubmitHandler(c echo.Context) error {
 	tenantID := c.Param("id")
 	name := strings.TrimSpace(c.FormValue("name"))
 	description := strings.TrimSpace(c.FormValue("description"))
+	requireAdminMfa := c.FormValue("requireAdminMfa") == "true"
 	updateReq := swagger.NewUpdateTenantRequestBuilder().
 		Description(description).
 		Name(name).
+		RequireAdminMfa(&requireAdminMfa).
 		Build()
 
 	if tenant, err := h.authMgm.UpdateTenant(ctx, principal, tenantID, updateReq); err != nil {
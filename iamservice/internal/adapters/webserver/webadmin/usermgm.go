@@ -1,28 +1,40 @@
 package webadmin
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/a-h/templ"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/common"
 	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
 	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
 )
 
 // UserMgmWebHandlers handles user management-related web requests
 type UserMgmWebHandlers struct {
 	userMgm *usecase.UserMgm
 	authMgm *usecase.AuthMgm
+	roleMgm *usecase.RoleMgm
 }
 
 // NewUserMgmWebHandlers creates a new instance of UserMgmWebHandlers
-func NewUserMgmWebHandlers(userMgm *usecase.UserMgm, authMgm *usecase.AuthMgm) *UserMgmWebHandlers {
+func NewUserMgmWebHandlers(
+	userMgm *usecase.UserMgm, authMgm *usecase.AuthMgm, roleMgm *usecase.RoleMgm,
+) *UserMgmWebHandlers {
 	return &UserMgmWebHandlers{
 		userMgm: userMgm,
 		authMgm: authMgm,
+		roleMgm: roleMgm,
 	}
 }
 
@@ -37,10 +49,11 @@ func (h *UserMgmWebHandlers) UsersListLoadHandler(c echo.Context) error {
 
 	var tenantID string
 
-	if principal.IsSysAdmin() {
-		// For sysadmin, check if tenant is specified in query param
+	canSelectTenant := principal.HasPermissionInAllTenants(model.PermissionUsersRead)
+	if canSelectTenant {
+		// For principals reading users of all tenants, check if tenant is specified in query param
 		tenantID = c.QueryParam("tenant-selector")
-		katapp.Logger(ctx).Debug("sysadmin tenant selection", "tenantParam", tenantID)
+		katapp.Logger(ctx).Debug("tenant selection", "tenantParam", tenantID)
 		if tenantID == "" {
 			katapp.Logger(ctx).Debug("using default tenant from token", "tenantID", tenantID)
 			tenantID = principal.TenantID
@@ -48,7 +61,17 @@ func (h *UserMgmWebHandlers) UsersListLoadHandler(c echo.Context) error {
 	} else {
 		tenantID = principal.TenantID
 	}
-	canCreateUser := principal.CanManageUser(tenantID)
+	canCreateUser := principal.HasTenantPermission(model.PermissionUsersCreate, tenantID)
+
+	filter := admin.UsersFilter{
+		TenantID:      tenantID,
+		Search:        strings.TrimSpace(c.QueryParam("search")),
+		Role:          strings.TrimSpace(c.QueryParam("role")),
+		EmailVerified: c.QueryParam("emailVerified"),
+		Active:        c.QueryParam("active"),
+		SortBy:        c.QueryParam("sortBy"),
+		SortOrder:     c.QueryParam("sortOrder"),
+	}
 
 	// Parse pagination parameters
 	page := 1
@@ -57,40 +80,155 @@ func (h *UserMgmWebHandlers) UsersListLoadHandler(c echo.Context) error {
 			page = p
 		}
 	}
+	limit := 18
+
+	params := swagger.NewListUsersByTenantParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		Cursor(nil).
+		SortBy((*swagger.UserSortBy)(lo.EmptyableToPtr(filter.SortBy))).
+		SortOrder((*swagger.SortOrder)(lo.EmptyableToPtr(filter.SortOrder))).
+		Search(lo.EmptyableToPtr(filter.Search)).
+		Role(lo.EmptyableToPtr(filter.Role)).
+		EmailVerified(parseOptionalBool(filter.EmailVerified)).
+		Active(parseOptionalBool(filter.Active)).
+		Build()
+	users, err := h.userMgm.ListAllUsersByTenant(ctx, principal, tenantID, params)
+	if err != nil {
+		return err
+	}
 
-	limit := 20
-	if limitStr := c.QueryParam("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
+	// Filter and pagination requests replace the users list only
+	if c.Request().Header.Get("HX-Target") == "users-list" {
+		return admin.UsersListContent(users, tenantID, canCreateUser).Render(ctx, c.Response().Writer)
+	}
+
+	// If users of all tenants can be read, show tenant selector
+	var tenants []swagger.TenantResponse
+	if canSelectTenant {
+		page, limit := 1, 100
+		tenantsParams := swagger.NewListAllTenantsParamsBuilder().
+			Page(&page).
+			Limit(&limit).
+			Cursor(nil).
+			SortBy(lo.ToPtr(swagger.TenantSortByName)).
+			SortOrder(nil).
+			Search(nil).
+			Build()
+		tenantsListResponse, err := h.authMgm.ListAllTenants(ctx, principal, tenantsParams)
+		if err != nil {
+			return err
 		}
+		tenants = tenantsListResponse.Items
 	}
+	return renderTemplateComponent(c, "Users", admin.UsersList(users, tenants, filter, canCreateUser))
+}
+
+// UsersSearchLoadHandler renders quick search results of users similar to the typed query, within the selected
+// tenant if users of all tenants can be read
+func (h *UserMgmWebHandlers) UsersSearchLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
 
-	userListResponse, err := h.userMgm.ListAllUsersByTenant(ctx, principal, tenantID, page, limit)
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
 	if err != nil {
 		return err
 	}
-	users := userListResponse.Items
 
-	// If sysadmin, show tenant selector
-	if principal.IsSysAdmin() {
-		tenantsListResponse, err := h.authMgm.GetAllTenants(ctx, principal)
-		if err != nil {
-			return err
-		}
+	// Too short queries clear the results instead of reporting an error while typing
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if len([]rune(query)) < 2 {
+		return admin.UsersSearchResults(nil).Render(ctx, c.Response().Writer)
+	}
+
+	limit := 10
+	params := swagger.NewSearchUsersParamsBuilder().
+		Q(query).
+		TenantId(lo.EmptyableToPtr(c.QueryParam("tenant-selector"))).
+		Limit(&limit).
+		Build()
+	results, err := h.userMgm.SearchUsers(ctx, principal, params)
+	if err != nil {
+		return err
+	}
+	return admin.UsersSearchResults(results).Render(ctx, c.Response().Writer)
+}
+
+// UsersExportHandler downloads users of the selected tenant as CSV or NDJSON
+func (h *UserMgmWebHandlers) UsersExportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
 
-		// Check if this is an HTMX request targeting just the users list
-		if c.Request().Header.Get("HX-Target") == "users-list" {
-			return admin.UsersListContent(users, canCreateUser).Render(ctx, c.Response().Writer)
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := lo.CoalesceOrEmpty(c.QueryParam("tenant-selector"), principal.TenantID)
+
+	params := swagger.NewExportUsersParamsBuilder().
+		Format((*swagger.UserTransferFormat)(lo.EmptyableToPtr(c.QueryParam("format")))).
+		Build()
+	contentType, extension := "text/csv; charset=utf-8", "csv"
+	if params.Format != nil && *params.Format == swagger.UserTransferFormatNdjson {
+		contentType, extension = "application/x-ndjson", "ndjson"
+	}
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="users-%s.%s"`, tenantID, extension))
+	if err := h.userMgm.ExportUsers(ctx, principal, tenantID, params, c.Response()); err != nil {
+		if c.Response().Committed {
+			katapp.Logger(ctx).Error("failed to export users", "tenantID", tenantID, "error", err)
+			return nil
 		}
-		return renderTemplateComponent(c, "Users",
-			admin.UsersListWithTenantSelector(users, tenantsListResponse.Items, tenantID, true, canCreateUser))
+		header.Del(echo.HeaderContentDisposition)
+		return err
+	}
+	return nil
+}
+
+// UsersImportSubmitHandler imports an uploaded file of users into the selected tenant, files with .ndjson or
+// .jsonl extensions are read as NDJSON and others as CSV
+func (h *UserMgmWebHandlers) UsersImportSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := lo.CoalesceOrEmpty(c.FormValue("tenant-selector"), principal.TenantID)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInvalidInput, "choose a file to import")
+	}
+	if fileHeader.Size > usecase.MaxUserImportSize {
+		return katapp.NewErr(katapp.ErrInvalidInput, "file is too large to import")
+	}
+	format := swagger.UserTransferFormatCsv
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".ndjson", ".jsonl":
+		format = swagger.UserTransferFormatNdjson
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	params := swagger.NewImportUsersParamsBuilder().
+		DryRun(lo.ToPtr(c.FormValue("dryRun") == "true")).
+		Build()
+	result, err := h.userMgm.ImportUsers(ctx, principal, tenantID, format, file, params)
+	if err != nil {
+		return err
 	}
-	return renderTemplateComponent(c, "Users", admin.UsersList(users, canCreateUser))
+	return admin.UsersImportResult(result).Render(ctx, c.Response().Writer)
 }
 
-// NewUserLoadHandler renders the user form
-func (h *UserMgmWebHandlers) NewUserLoadHandler(c echo.Context) error {
-	return renderTemplateComponent(c, "Add User", admin.UserForm())
+// parseOptionalBool returns nil for values of "any" filter options
+func parseOptionalBool(value string) *bool {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return &b
+	}
+	return nil
 }
 
 // UserDetailLoadHandler renders a single user's details
@@ -111,38 +249,78 @@ func (h *UserMgmWebHandlers) UserDetailLoadHandler(c echo.Context) error {
 		return err
 	}
 	roles := userRolesResponse.Roles
+	lockout, err := h.authMgm.GetUserLockout(ctx, principal, userID)
+	if err != nil {
+		return err
+	}
+	sessions, err := h.authMgm.ListUserSessions(ctx, principal, userID)
+	if err != nil {
+		return err
+	}
 
 	// Check if the current user can manage users (for showing admin buttons)
-	canManageUsers := principal.CanManageUsers()
-	return renderTemplateComponent(c, "User Details", admin.UserDetail(authUserResponse, roles, canManageUsers))
+	canManageUsers := principal.HasTenantPermission(model.PermissionUsersUpdate, authUserResponse.TenantId)
+	return renderTemplateComponent(c, "User Details",
+		admin.UserDetail(authUserResponse, roles, lockout, sessions.Items, canManageUsers))
 }
 
-// CreateUserLoadHandler handles user creation
-func (h *UserMgmWebHandlers) CreateUserLoadHandler(c echo.Context) error {
+// RevokeSessionSubmitHandler handles signing a user out on one device
+func (h *UserMgmWebHandlers) RevokeSessionSubmitHandler(c echo.Context) error {
 	ctx := c.Request().Context()
+	userID := c.Param("id")
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
 
-	tenantID := strings.TrimSpace(c.FormValue("tenantId"))
-	email := strings.TrimSpace(c.FormValue("email"))
-	password := strings.TrimSpace(c.FormValue("password"))
-	firstName := strings.TrimSpace(c.FormValue("firstName"))
-	lastName := strings.TrimSpace(c.FormValue("lastName"))
+	if err = h.authMgm.RevokeUserSession(ctx, principal, userID, c.Param("sessionId")); err != nil {
+		return err
+	}
+	return h.renderUserSessions(c, principal, userID)
+}
 
-	// Create SignUpRequest using the builder pattern
-	signupReq := swagger.NewSignUpRequestBuilder().
-		Email(email).
-		FirstName(firstName).
-		LastName(lastName).
-		Password(password).
-		Source("web").
-		TenantId(tenantID).
-		Build()
+// RevokeAllSessionsSubmitHandler handles signing a user out on all devices
+func (h *UserMgmWebHandlers) RevokeAllSessionsSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("id")
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
 
-	if _, err := h.authMgm.SignUp(ctx, signupReq); err != nil {
+	if err = h.authMgm.RevokeUserSessions(ctx, principal, userID); err != nil {
 		return err
 	}
+	return h.renderUserSessions(c, principal, userID)
+}
 
-	userName := firstName + " " + lastName
-	return admin.UserFormSuccess(userName).Render(ctx, c.Response().Writer)
+func (h *UserMgmWebHandlers) renderUserSessions(c echo.Context, principal *usecase.UserPrincipal, userID string) error {
+	ctx := c.Request().Context()
+	sessions, err := h.authMgm.ListUserSessions(ctx, principal, userID)
+	if err != nil {
+		return err
+	}
+	return common.SessionsSection("/web/admin/users/"+userID+"/sessions", sessions.Items, true).
+		Render(ctx, c.Response().Writer)
+}
+
+// UnlockUserSubmitHandler handles clearing sign in lockout of a user
+func (h *UserMgmWebHandlers) UnlockUserSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("id")
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err = h.authMgm.UnlockUser(ctx, principal, userID); err != nil {
+		return err
+	}
+	lockout, err := h.authMgm.GetUserLockout(ctx, principal, userID)
+	if err != nil {
+		return err
+	}
+	return admin.UserLockoutStatus(userID, lockout).Render(ctx, c.Response().Writer)
 }
 
 // UserRolesLoadHandler renders user roles management
@@ -154,12 +332,11 @@ func (h *UserMgmWebHandlers) UserRolesLoadHandler(c echo.Context) error {
 		return err
 	}
 
-	userRolesResponse, err := h.userMgm.GetUserRoles(ctx, principal, userID)
+	userRoles, err := h.userRolesComponent(ctx, principal, userID)
 	if err != nil {
 		return err
 	}
-	roles := userRolesResponse.Roles
-	return renderTemplateComponent(c, "User Roles", admin.UserRoles(userID, roles))
+	return renderTemplateComponent(c, "User Roles", userRoles)
 }
 
 // AssignRoleSubmitHandler handles role assignment
@@ -175,11 +352,11 @@ func (h *UserMgmWebHandlers) AssignRoleSubmitHandler(c echo.Context) error {
 	if err = h.userMgm.AssignUserRole(ctx, principal, userID, roleName); err != nil {
 		return err
 	}
-	userRolesResponse, err := h.userMgm.GetUserRoles(ctx, principal, userID)
+	userRoles, err := h.userRolesComponent(ctx, principal, userID)
 	if err != nil {
 		return err
 	}
-	return admin.UserRoles(userID, userRolesResponse.Roles).Render(ctx, c.Response().Writer)
+	return userRoles.Render(ctx, c.Response().Writer)
 }
 
 // DeleteRoleSubmitHandler handles role removal
@@ -195,12 +372,33 @@ func (h *UserMgmWebHandlers) DeleteRoleSubmitHandler(c echo.Context) error {
 	if err = h.userMgm.DeleteUserRole(ctx, principal, userID, roleName); err != nil {
 		return err
 	}
-	userRolesResponse, err := h.userMgm.GetUserRoles(ctx, principal, userID)
+	userRoles, err := h.userRolesComponent(ctx, principal, userID)
 	if err != nil {
 		return err
 	}
-	roles := userRolesResponse.Roles
-	return admin.UserRoles(userID, roles).Render(ctx, c.Response().Writer)
+	return userRoles.Render(ctx, c.Response().Writer)
+}
+
+// userRolesComponent builds the roles page of a user, offering the roles of the user's tenant not assigned yet
+func (h *UserMgmWebHandlers) userRolesComponent(
+	ctx context.Context, principal *usecase.UserPrincipal, userID string,
+) (templ.Component, error) {
+	authUserResponse, err := h.userMgm.LoadUserByID(ctx, principal, userID)
+	if err != nil {
+		return nil, err
+	}
+	userRolesResponse, err := h.userMgm.GetUserRoles(ctx, principal, userID)
+	if err != nil {
+		return nil, err
+	}
+	tenantRolesResponse, err := h.roleMgm.ListRoles(ctx, principal, authUserResponse.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	assignableRoles := lo.Filter(tenantRolesResponse.Items, func(role swagger.RoleResponse, _ int) bool {
+		return !lo.Contains(userRolesResponse.Roles, role.Name)
+	})
+	return admin.UserRoles(userID, userRolesResponse.Roles, assignableRoles), nil
 }
 
 // DeleteUserSubmitHandler handles user deletion
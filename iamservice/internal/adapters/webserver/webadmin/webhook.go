@@ -0,0 +1,178 @@
+package webadmin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
+	"github.com/samber/lo"
+)
+
+// WebhookWebHandlers handles webhook-related web requests
+type WebhookWebHandlers struct {
+	webhookMgm *usecase.WebhookMgm
+}
+
+// NewWebhookWebHandlers creates a new instance of WebhookWebHandlers
+func NewWebhookWebHandlers(webhookMgm *usecase.WebhookMgm) *WebhookWebHandlers {
+	return &WebhookWebHandlers{
+		webhookMgm: webhookMgm,
+	}
+}
+
+// WebhooksListLoadHandler renders the webhooks of a tenant
+func (h *WebhookWebHandlers) WebhooksListLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+
+	webhooksResponse, err := h.webhookMgm.ListWebhooks(ctx, principal, tenantID)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Webhooks", admin.WebhooksList(tenantID, webhooksResponse.Items))
+}
+
+// NewWebhookLoadHandler renders the form of adding a webhook
+func (h *WebhookWebHandlers) NewWebhookLoadHandler(c echo.Context) error {
+	return renderTemplateComponent(c, "Add Webhook", admin.WebhookForm(c.Param("id")))
+}
+
+// CreateWebhookSubmitHandler handles adding a webhook, its generated secret is shown once
+func (h *WebhookWebHandlers) CreateWebhookSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	formParams, err := c.FormParams()
+	if err != nil {
+		return err
+	}
+	eventTypes := lo.Map(formParams["eventTypes"], func(eventType string, _ int) swagger.WebhookEventType {
+		return swagger.WebhookEventType(eventType)
+	})
+	createReq := swagger.NewCreateWebhookRequestBuilder().
+		Active(nil).
+		EventTypes(eventTypes).
+		Secret(nil).
+		Url(strings.TrimSpace(c.FormValue("url"))).
+		Build()
+
+	created, err := h.webhookMgm.CreateWebhook(ctx, principal, tenantID, createReq)
+	if err != nil {
+		return err
+	}
+	return admin.WebhookFormSuccess(tenantID, created).Render(ctx, c.Response().Writer)
+}
+
+// ToggleWebhookSubmitHandler handles activating and deactivating a webhook
+func (h *WebhookWebHandlers) ToggleWebhookSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.webhookMgm.GetWebhook(ctx, principal, tenantID, webhookID)
+	if err != nil {
+		return err
+	}
+	updateReq := swagger.NewUpdateWebhookRequestBuilder().
+		Active(!webhook.Active).
+		EventTypes(webhook.EventTypes).
+		Secret(nil).
+		Url(webhook.Url).
+		Build()
+	webhook, err = h.webhookMgm.UpdateWebhook(ctx, principal, tenantID, webhookID, updateReq)
+	if err != nil {
+		return err
+	}
+	return admin.WebhookRow(tenantID, *webhook).Render(ctx, c.Response().Writer)
+}
+
+// DeleteWebhookSubmitHandler handles webhook deletion
+func (h *WebhookWebHandlers) DeleteWebhookSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err = h.webhookMgm.DeleteWebhook(ctx, principal, c.Param("id"), c.Param("webhookId")); err != nil {
+		return err
+	}
+
+	// For HTMX, return empty content to remove the row from the DOM
+	c.Response().WriteHeader(200)
+	return nil
+}
+
+// WebhookDeliveriesLoadHandler renders the delivery log of a webhook
+func (h *WebhookWebHandlers) WebhookDeliveriesLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+	tenantID := c.Param("id")
+	webhookID := c.Param("webhookId")
+
+	page := 1
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	limit := 20
+	var status *swagger.ListWebhookDeliveriesParamsStatus
+	if statusStr := c.QueryParam("status"); statusStr != "" {
+		status = lo.ToPtr(swagger.ListWebhookDeliveriesParamsStatus(statusStr))
+	}
+
+	params := swagger.NewListWebhookDeliveriesParamsBuilder().
+		Page(&page).
+		Limit(&limit).
+		Status(status).
+		Build()
+	deliveries, err := h.webhookMgm.ListWebhookDeliveries(ctx, principal, tenantID, webhookID, params)
+	if err != nil {
+		return err
+	}
+
+	// Filter and pagination requests replace the deliveries list only
+	if c.Request().Header.Get("HX-Target") == "webhook-deliveries-list" {
+		return admin.WebhookDeliveriesContent(tenantID, webhookID, deliveries).Render(ctx, c.Response().Writer)
+	}
+	webhook, err := h.webhookMgm.GetWebhook(ctx, principal, tenantID, webhookID)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Webhook Deliveries", admin.WebhookDeliveries(tenantID, webhook, deliveries))
+}
+
+// RedeliverWebhookDeliverySubmitHandler queues the event of a delivery for delivery right away
+func (h *WebhookWebHandlers) RedeliverWebhookDeliverySubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := h.webhookMgm.RedeliverWebhookDelivery(
+		ctx, principal, c.Param("id"), c.Param("webhookId"), c.Param("deliveryId"))
+	if err != nil {
+		return err
+	}
+	return admin.WebhookRedeliverySuccess(delivery).Render(ctx, c.Response().Writer)
+}
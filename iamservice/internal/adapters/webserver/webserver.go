@@ -7,6 +7,7 @@ import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver/mw"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver/webadmin"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver/webuser"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/admin"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/user"
@@ -24,7 +25,7 @@ func NewHomeWebHandlers(authHandler *webadmin.AuthWebHandlers) *HomeWebHandlers
 
 // SetupWebRoutes configures all web routes
 func SetupWebRoutes(e *echo.Echo, uc *usecase.UseCases) {
-	authMiddleware := serverhelp.NewJWTAuthWebServerMiddleware([]byte(uc.Config.Credentials.JwtSecret))
+	authMiddleware := serverhelp.NewJWTAuthWebServerMiddleware(uc.JWTKeys, uc.Auth)
 
 	// Static file serving
 	e.Static("/static", "static")
@@ -35,12 +36,16 @@ func SetupWebRoutes(e *echo.Echo, uc *usecase.UseCases) {
 
 // setupAdminRoutes wires web interface routes under /web/admin
 func setupAdminRoutes(e *echo.Echo, uc *usecase.UseCases, authMiddleware *serverhelp.JWTAuthMiddleware) {
-	adminAuthLock := authMiddleware.WithAnyRole("admin", "sysadmin")
-	sysadminAuthLock := authMiddleware.WithAnyRole("sysadmin")
+	permissionLock := authMiddleware.WithAnyPermission
 
 	authWeb := webadmin.NewAuthWebHandlers(uc.Auth)
-	userMgmWeb := webadmin.NewUserMgmWebHandlers(uc.UserMgm, uc.Auth)
+	userMgmWeb := webadmin.NewUserMgmWebHandlers(uc.UserMgm, uc.Auth, uc.RoleMgm)
 	tenantMgmWeb := webadmin.NewTenantMgmWebHandlers(uc.Auth)
+	roleMgmWeb := webadmin.NewRoleMgmWebHandlers(uc.RoleMgm)
+	invitationWeb := webadmin.NewInvitationWebHandlers(uc.InvitationMgm, uc.RoleMgm)
+	auditWeb := webadmin.NewAuditWebHandlers(uc.AuditMgm)
+	outboxWeb := webadmin.NewOutboxWebHandlers(uc.OutboxMgm)
+	webhookWeb := webadmin.NewWebhookWebHandlers(uc.WebhookMgm)
 
 	// Admin web interface routes under /web/admin
 	root := e.Group("/web/admin")
@@ -55,43 +60,85 @@ func setupAdminRoutes(e *echo.Echo, uc *usecase.UseCases, authMiddleware *server
 	root.GET("", authWeb.HomeLoadHandler)  // /web/admin
 	root.GET("/", authWeb.HomeLoadHandler) // /web/admin/
 
-	// User management routes (protected with admin role middleware)
-	users := root.Group("/users", adminAuthLock)
+	// User management routes (protected with users:read permission middleware)
+	users := root.Group("/users", permissionLock(model.PermissionUsersRead))
 	users.GET("", userMgmWeb.UsersListLoadHandler)
-	users.GET("/new", userMgmWeb.NewUserLoadHandler)
+	users.GET("/search", userMgmWeb.UsersSearchLoadHandler)
+	users.GET("/export", userMgmWeb.UsersExportHandler)
+	users.POST("/import", userMgmWeb.UsersImportSubmitHandler, permissionLock(model.PermissionUsersCreate))
 	users.GET("/:id", userMgmWeb.UserDetailLoadHandler)
 	users.GET("/:id/edit", userMgmWeb.UserEditLoadHandler)
 	users.GET("/:id/change-password", userMgmWeb.UserChangePasswordLoadHandler)
 	users.GET("/:id/roles", userMgmWeb.UserRolesLoadHandler)
-	users.POST("", userMgmWeb.CreateUserLoadHandler)
 	users.PUT("/:id", userMgmWeb.UpdateUserSubmitHandler)
 	users.POST("/:id/change-password", userMgmWeb.ChangePasswordSubmitHandler)
 	users.POST("/:id/roles", userMgmWeb.AssignRoleSubmitHandler)
 	users.DELETE("/:id", userMgmWeb.DeleteUserSubmitHandler)
 	users.DELETE("/:id/roles/:roleName", userMgmWeb.DeleteRoleSubmitHandler)
+	users.DELETE("/:id/lockout", userMgmWeb.UnlockUserSubmitHandler)
+	users.DELETE("/:id/sessions", userMgmWeb.RevokeAllSessionsSubmitHandler)
+	users.DELETE("/:id/sessions/:sessionId", userMgmWeb.RevokeSessionSubmitHandler)
 
-	// Tenant management routes (protected with sysadmin role middleware)
-	tenants := root.Group("/tenants", adminAuthLock)
+	// Tenant management routes (protected with tenants:read permission middleware)
+	tenants := root.Group("/tenants", permissionLock(model.PermissionTenantsRead))
 	tenants.GET("", tenantMgmWeb.TenantsListLoadHandler)
-	tenants.GET("/new", tenantMgmWeb.NewTenantLoadHandler, sysadminAuthLock)
+	tenants.GET("/new", tenantMgmWeb.NewTenantLoadHandler, permissionLock(model.PermissionTenantsCreate))
 	tenants.GET("/:id", tenantMgmWeb.TenantDetailLoadHandler)
 	tenants.GET("/:id/edit", tenantMgmWeb.TenantEditLoadHandler)
 	tenants.POST("", tenantMgmWeb.CreateTenantSubmitHandler)
 	tenants.PUT("/:id", tenantMgmWeb.UpdateTenantSubmitHandler)
 	tenants.DELETE("/:id", tenantMgmWeb.DeleteTenantSubmitHandler)
 
+	// Role management routes of a tenant (protected with roles:* permission middleware)
+	roles := root.Group("/tenants/:id/roles", permissionLock(model.PermissionRolesRead))
+	rolesManageLock := permissionLock(model.PermissionRolesManage)
+	roles.GET("", roleMgmWeb.RolesListLoadHandler)
+	roles.GET("/new", roleMgmWeb.NewRoleLoadHandler, rolesManageLock)
+	roles.GET("/:roleName/edit", roleMgmWeb.RoleEditLoadHandler, rolesManageLock)
+	roles.POST("", roleMgmWeb.CreateRoleSubmitHandler, rolesManageLock)
+	roles.PUT("/:roleName", roleMgmWeb.UpdateRoleSubmitHandler, rolesManageLock)
+	roles.DELETE("/:roleName", roleMgmWeb.DeleteRoleSubmitHandler, rolesManageLock)
+
+	// Invitation routes of a tenant (protected with users:create permission middleware)
+	invitations := root.Group("/tenants/:id/invitations", permissionLock(model.PermissionUsersCreate))
+	invitations.GET("", invitationWeb.InvitationsListLoadHandler)
+	invitations.GET("/new", invitationWeb.NewInvitationLoadHandler)
+	invitations.POST("", invitationWeb.CreateInvitationSubmitHandler)
+	invitations.POST("/:invitationId/resend", invitationWeb.ResendInvitationSubmitHandler)
+	invitations.DELETE("/:invitationId", invitationWeb.RevokeInvitationSubmitHandler)
+
+	// Webhook routes of a tenant (protected with webhooks:manage permission middleware)
+	webhooks := root.Group("/tenants/:id/webhooks", permissionLock(model.PermissionWebhooksManage))
+	webhooks.GET("", webhookWeb.WebhooksListLoadHandler)
+	webhooks.GET("/new", webhookWeb.NewWebhookLoadHandler)
+	webhooks.POST("", webhookWeb.CreateWebhookSubmitHandler)
+	webhooks.POST("/:webhookId/toggle", webhookWeb.ToggleWebhookSubmitHandler)
+	webhooks.DELETE("/:webhookId", webhookWeb.DeleteWebhookSubmitHandler)
+	webhooks.GET("/:webhookId/deliveries", webhookWeb.WebhookDeliveriesLoadHandler)
+	webhooks.POST("/:webhookId/deliveries/:deliveryId/redeliver", webhookWeb.RedeliverWebhookDeliverySubmitHandler)
+
+	// Audit log routes (protected with audit:read permission middleware)
+	root.GET("/audit", auditWeb.AuditLogLoadHandler, permissionLock(model.PermissionAuditRead))
+
+	// Email outbox routes (protected with outbox:manage permission middleware)
+	outbox := root.Group("/outbox", permissionLock(model.PermissionOutboxManage))
+	outbox.GET("", outboxWeb.OutboxEmailsLoadHandler)
+	outbox.POST("/:emailId/retry", outboxWeb.RetryOutboxEmailSubmitHandler)
+
 	// Authentication routes
 	auth := root.Group("/auth")
 	auth.GET("/signin", authWeb.SignInLoadHandler)
 	auth.POST("/signin", authWeb.SignInSubmitHandler)
 	auth.POST("/signout", authWeb.SignOutSubmitHandler)
+	auth.POST("/mfa/verify", authWeb.MFAVerifySubmitHandler)
+	auth.POST("/mfa/enroll", authWeb.MFAEnrollSubmitHandler)
 }
 
 // setupUserRoutes wires web interface routes under /web/user
 func setupUserRoutes(e *echo.Echo, uc *usecase.UseCases, authMiddleware *serverhelp.JWTAuthMiddleware) {
-	authLock := authMiddleware.WithAnyRole("admin", "sysadmin", "user")
+	authLock := authMiddleware.WithAnyPermission()
 
-	authWeb := webuser.NewAuthWebHandlers(uc.Auth)
+	authWeb := webuser.NewAuthWebHandlers(uc.Auth, uc.Federation, uc.InvitationMgm)
 	accountWeb := webuser.NewAccountWebHandlers(uc.Auth, uc.UserMgm, uc.UserProfileMgm)
 
 	root := e.Group("/web/user")
@@ -112,14 +159,32 @@ func setupUserRoutes(e *echo.Echo, uc *usecase.UseCases, authMiddleware *serverh
 	auth.POST("/signup", authWeb.SignUpSubmitHandler)
 	auth.POST("/signout", authWeb.SignOutSubmitHandler)
 	auth.GET("/confirm-email", authWeb.ConfirmEmailHandler)
+	auth.GET("/forgot-password", authWeb.ForgotPasswordLoadHandler)
+	auth.POST("/forgot-password", authWeb.ForgotPasswordSubmitHandler)
+	auth.GET("/reset-password", authWeb.ResetPasswordLoadHandler)
+	auth.POST("/reset-password", authWeb.ResetPasswordSubmitHandler)
+	auth.GET("/accept-invitation", authWeb.AcceptInvitationLoadHandler)
+	auth.POST("/accept-invitation", authWeb.AcceptInvitationSubmitHandler)
+	auth.GET("/federated/:providerId/start", authWeb.FederatedSignInStartHandler)
+	auth.GET("/federated/:providerId/callback", authWeb.FederatedSignInCallbackHandler)
+	auth.POST("/mfa/verify", authWeb.MFAVerifySubmitHandler)
+	auth.POST("/mfa/enroll", authWeb.MFAEnrollSubmitHandler)
 
 	// User account routes (protected)
 	account := root.Group("/account", authLock)
 	account.GET("", accountWeb.AccountLoadHandler)
 	account.GET("/edit", accountWeb.EditAccountLoadHandler)
 	account.PUT("/update", accountWeb.UpdateAccountSubmitHandler)
+	account.POST("/email-change", accountWeb.RequestEmailChangeSubmitHandler)
+	account.GET("/confirm-email-change", accountWeb.ConfirmEmailChangeLoadHandler)
 	account.GET("/change-password", accountWeb.ChangePasswordLoadHandler)
 	account.PUT("/change-password", accountWeb.UpdatePasswordSubmitHandler)
+	account.POST("/mfa/totp", accountWeb.EnrollMFASubmitHandler)
+	account.POST("/mfa/totp/confirm", accountWeb.ConfirmMFASubmitHandler)
+	account.POST("/mfa/disable", accountWeb.DisableMFASubmitHandler)
+	account.DELETE("/sessions/:sessionId", accountWeb.RevokeSessionSubmitHandler)
+	account.POST("/api-keys", accountWeb.CreateAPIKeySubmitHandler)
+	account.DELETE("/api-keys/:keyId", accountWeb.RevokeAPIKeySubmitHandler)
 
 	// User profile routes (protected)
 	profile := root.Group("/profile", authLock)
@@ -1,6 +1,7 @@
 package webuser
 
 import (
+	"errors"
 	"github.com/mobiletoly/gokatana/katapp"
 	"github.com/mobiletoly/gokatana/kathttp_echo"
 	"github.com/oapi-codegen/runtime/types"
@@ -12,7 +13,9 @@ import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/internal/serverhelp"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/common"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/user"
+	"github.com/samber/lo"
 )
 
 // AccountWebHandlers handles user dashboard web requests
@@ -47,7 +50,150 @@ func (h *AccountWebHandlers) AccountLoadHandler(c echo.Context) error {
 	if err != nil {
 		return kathttp_echo.ReportHTTPError(err)
 	}
-	return renderTemplateComponent(c, "Account", user.Account(userDetails, userProfile))
+	mfaStatus, err := h.authMgm.GetMFAStatus(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	sessions, err := h.authMgm.ListUserSessions(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	apiKeys, err := h.authMgm.ListUserAPIKeys(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Account",
+		user.Account(userDetails, userProfile, mfaStatus, sessions.Items, apiKeys.Items, principal.Roles))
+}
+
+// CreateAPIKeySubmitHandler creates an API key for the current user and shows it once
+func (h *AccountWebHandlers) CreateAPIKeySubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	createReq := &swagger.CreateApiKeyRequest{
+		Name: strings.TrimSpace(c.FormValue("name")),
+	}
+	if expiresAtStr := strings.TrimSpace(c.FormValue("expiresAt")); expiresAtStr != "" {
+		expiresAt, err := time.Parse("2006-01-02", expiresAtStr)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInvalidInput, "invalid expiration date format")
+		}
+		createReq.ExpiresAt = &expiresAt
+	}
+	// Without any role checked the key gets all roles of the user
+	if formParams, err := c.FormParams(); err == nil && len(formParams["roles"]) > 0 {
+		roles := formParams["roles"]
+		createReq.Roles = &roles
+	}
+
+	created, err := h.authMgm.CreateUserAPIKey(ctx, principal, principal.UserID, createReq)
+	if err != nil {
+		return err
+	}
+	apiKeys, err := h.authMgm.ListUserAPIKeys(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set("HX-Retarget", "#api-keys-section")
+	return user.APIKeysSection(apiKeys.Items, principal.Roles, created).Render(ctx, c.Response().Writer)
+}
+
+// RevokeAPIKeySubmitHandler revokes an API key of the current user
+func (h *AccountWebHandlers) RevokeAPIKeySubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.authMgm.RevokeUserAPIKey(ctx, principal, principal.UserID, c.Param("keyId")); err != nil {
+		return err
+	}
+	apiKeys, err := h.authMgm.ListUserAPIKeys(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	return user.APIKeysSection(apiKeys.Items, principal.Roles, nil).Render(ctx, c.Response().Writer)
+}
+
+// RevokeSessionSubmitHandler signs the current user out on another device
+func (h *AccountWebHandlers) RevokeSessionSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.authMgm.RevokeUserSession(ctx, principal, principal.UserID, c.Param("sessionId")); err != nil {
+		return err
+	}
+	sessions, err := h.authMgm.ListUserSessions(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	return common.SessionsSection("/web/user/account/sessions", sessions.Items, false).
+		Render(ctx, c.Response().Writer)
+}
+
+// EnrollMFASubmitHandler starts authenticator enrollment and shows the QR code and recovery codes
+func (h *AccountWebHandlers) EnrollMFASubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := h.authMgm.EnrollTOTP(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	return user.MFAEnrollment(enrollment).Render(ctx, c.Response().Writer)
+}
+
+// ConfirmMFASubmitHandler confirms the pending authenticator with its first code
+func (h *AccountWebHandlers) ConfirmMFASubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	confirmReq := &swagger.MfaConfirmRequest{
+		Code: strings.TrimSpace(c.FormValue("code")),
+	}
+	mfaStatus, err := h.authMgm.ConfirmTOTP(ctx, principal, principal.UserID, confirmReq)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set("HX-Retarget", "#mfa-section")
+	return user.MFASection(mfaStatus).Render(ctx, c.Response().Writer)
+}
+
+// DisableMFASubmitHandler turns off two-factor authentication for the current user once confirmed with
+// a current code
+func (h *AccountWebHandlers) DisableMFASubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	disableReq := &swagger.MfaDisableRequest{
+		Code: lo.EmptyableToPtr(strings.TrimSpace(c.FormValue("code"))),
+	}
+	if err := h.authMgm.DisableMFA(ctx, principal, principal.UserID, disableReq); err != nil {
+		return err
+	}
+	mfaStatus, err := h.authMgm.GetMFAStatus(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set("HX-Retarget", "#mfa-section")
+	return user.MFASection(mfaStatus).Render(ctx, c.Response().Writer)
 }
 
 // EditAccountLoadHandler renders the edit account form
@@ -62,7 +208,11 @@ func (h *AccountWebHandlers) EditAccountLoadHandler(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return renderTemplateComponent(c, "Edit Account", user.EditAccount(userDetails))
+	pendingEmailChange, err := h.authMgm.GetPendingEmailChange(ctx, principal, principal.UserID)
+	if err != nil {
+		return err
+	}
+	return renderTemplateComponent(c, "Edit Account", user.EditAccount(userDetails, pendingEmailChange))
 }
 
 // UpdateAccountSubmitHandler handles account updates
@@ -81,6 +231,52 @@ func (h *AccountWebHandlers) UpdateAccountSubmitHandler(c echo.Context) error {
 	return user.AccountUpdateSuccess().Render(ctx, c.Response().Writer)
 }
 
+// RequestEmailChangeSubmitHandler sends a confirmation link to the new email address of the current user
+func (h *AccountWebHandlers) RequestEmailChangeSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	currentPassword := c.FormValue("currentPassword")
+	changeReq := &swagger.EmailChangeRequest{
+		NewEmail:        types.Email(strings.TrimSpace(c.FormValue("newEmail"))),
+		CurrentPassword: &currentPassword,
+	}
+	pendingEmailChange, err := h.authMgm.RequestEmailChange(ctx, principal, principal.UserID, changeReq)
+	if err != nil {
+		return err
+	}
+	return user.EmailChangePending(pendingEmailChange).Render(ctx, c.Response().Writer)
+}
+
+// ConfirmEmailChangeLoadHandler confirms the new email address of the current user from the emailed link
+func (h *AccountWebHandlers) ConfirmEmailChangeLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	principal, err := serverhelp.GetUserPrincipalFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	code := c.QueryParam("code")
+	if code == "" {
+		return renderTemplateComponent(c, "Email Change", user.EmailChangeError("Invalid confirmation link"))
+	}
+	confirmReq := &swagger.ConfirmEmailChangeRequest{Code: code}
+	userDetails, err := h.authMgm.ConfirmEmailChange(ctx, principal, principal.UserID, confirmReq)
+	if err != nil {
+		var appErr *katapp.Err
+		if errors.As(err, &appErr) && appErr.Scope == katapp.ErrDuplicate {
+			return renderTemplateComponent(c, "Email Change",
+				user.EmailChangeError("This email address is already used by another account."))
+		}
+		return renderTemplateComponent(c, "Email Change",
+			user.EmailChangeError("Email change failed. The link may be expired or invalid."))
+	}
+	return renderTemplateComponent(c, "Email Change", user.EmailChangeSuccess(string(userDetails.Email)))
+}
+
 // EditProfileLoadHandler renders the edit profile form
 func (h *AccountWebHandlers) EditProfileLoadHandler(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -1,22 +1,34 @@
 package webuser
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webserver/mw"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/common"
 	"github.com/mobiletoly/gokatana-samples/iamservice/templates/user"
-	"net/http"
-	"strings"
+	"github.com/mobiletoly/gokatana/katapp"
 )
 
+const federationVerifierCookie = "federation_verifier"
+
 type AuthWebHandlers struct {
-	authMgm *usecase.AuthMgm
+	authMgm       *usecase.AuthMgm
+	federationMgm *usecase.FederationMgm
+	invitationMgm *usecase.InvitationMgm
 }
 
-func NewAuthWebHandlers(authMgm *usecase.AuthMgm) *AuthWebHandlers {
+func NewAuthWebHandlers(
+	authMgm *usecase.AuthMgm, federationMgm *usecase.FederationMgm, invitationMgm *usecase.InvitationMgm,
+) *AuthWebHandlers {
 	return &AuthWebHandlers{
-		authMgm: authMgm,
+		authMgm:       authMgm,
+		federationMgm: federationMgm,
+		invitationMgm: invitationMgm,
 	}
 }
 
@@ -26,14 +38,22 @@ func (a *AuthWebHandlers) HomeLoadHandler(c echo.Context) error {
 	return renderTemplateComponent(c, "Dashboard", user.Home(userEmail))
 }
 
-// SignInLoadHandler renders the sign-in form
+// SignInLoadHandler renders the sign-in form. OpenID Connect authorization requests pass the client's
+// tenant and the authorization URL to return to once signed in.
 func (a *AuthWebHandlers) SignInLoadHandler(c echo.Context) error {
 	ctx := c.Request().Context()
+	tenantId := c.QueryParam("tenantId")
+	if tenantId == "" {
+		tenantId = "default-tenant"
+	}
+	returnTo := safeReturnTo(c.QueryParam("returnTo"))
+	providers := a.federationMgm.ProvidersForTenant(tenantId)
 	if mw.IsHTMX(c) {
-		return user.SignInForm().Render(ctx, c.Response().Writer)
+		return user.SignInForm(tenantId, returnTo, providers).Render(ctx, c.Response().Writer)
 	}
 	userEmail, _ := a.GetAuthenticatedUser(c)
-	return user.Layout("Sign In", user.SignInForm(), userEmail).Render(ctx, c.Response().Writer)
+	return user.Layout("Sign In", user.SignInForm(tenantId, returnTo, providers), userEmail).
+		Render(ctx, c.Response().Writer)
 }
 
 // SignUpLoadHandler renders the sign-up form
@@ -58,21 +78,137 @@ func (a *AuthWebHandlers) SignInSubmitHandler(c echo.Context) error {
 		Password: password,
 		TenantId: tenantId,
 	}
-	authResp, err := a.authMgm.SignIn(ctx, signinReq)
+	authResp, mfaChallenge, err := a.authMgm.SignIn(ctx, signinReq, c.RealIP())
 	if err != nil {
 		return err
 	}
+	if mfaChallenge != nil {
+		// Replace the whole sign-in form with the second step
+		returnTo := safeReturnTo(c.FormValue("returnTo"))
+		c.Response().Header().Set("HX-Retarget", "#content")
+		return renderTemplateComponent(c, "Two-Factor Authentication",
+			common.MFAChallengeForm("/web/user", mfaChallenge, email, returnTo))
+	}
+
+	return a.completeSignIn(c, authResp, email, c.FormValue("returnTo"))
+}
+
+// MFAVerifySubmitHandler handles the second sign-in step
+func (a *AuthWebHandlers) MFAVerifySubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	verifyReq := &swagger.MfaVerifyRequest{
+		MfaToken: strings.TrimSpace(c.FormValue("mfaToken")),
+		Code:     strings.TrimSpace(c.FormValue("code")),
+	}
+	authResp, err := a.authMgm.VerifyMFA(ctx, verifyReq, c.RealIP())
+	if err != nil {
+		return err
+	}
+	return a.completeSignIn(c, authResp, strings.TrimSpace(c.FormValue("email")), c.FormValue("returnTo"))
+}
+
+// MFAEnrollSubmitHandler registers an authenticator for users who must enroll before signing in
+func (a *AuthWebHandlers) MFAEnrollSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	enrollment, err := a.authMgm.EnrollMFAWithChallenge(ctx, &swagger.MfaEnrollRequest{
+		MfaToken: strings.TrimSpace(c.FormValue("mfaToken")),
+	})
+	if err != nil {
+		return err
+	}
+	return common.TOTPEnrollmentPanel(enrollment).Render(ctx, c.Response().Writer)
+}
 
+// completeSignIn stores the issued tokens and leaves the sign-in pages
+func (a *AuthWebHandlers) completeSignIn(c echo.Context, authResp *swagger.SignInResponse, email string, returnTo string) error {
 	a.setAuthCookies(c, authResp.AccessToken, authResp.RefreshToken, email)
 
+	redirectTo := "/web/user"
+	if returnTo := safeReturnTo(returnTo); returnTo != "" {
+		// Continue the OpenID Connect authorization request that sent the user here
+		redirectTo = returnTo
+	}
 	if mw.IsHTMX(c) {
 		// For HTMX requests, redirect to home page to refresh the entire layout
-		c.Response().Header().Set("HX-Redirect", "/web/user")
+		c.Response().Header().Set("HX-Redirect", redirectTo)
 		return c.NoContent(http.StatusOK)
 	} else {
 		// For regular requests, redirect using standard HTTP redirect
-		return c.Redirect(http.StatusSeeOther, "/web/user")
+		return c.Redirect(http.StatusSeeOther, redirectTo)
+	}
+}
+
+// FederatedSignInStartHandler redirects to the upstream identity provider. The PKCE code verifier is kept
+// in a short-lived cookie restricted to the callback path.
+func (a *AuthWebHandlers) FederatedSignInStartHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	providerID := c.Param("providerId")
+
+	start, err := a.federationMgm.BeginSignIn(
+		ctx, c.QueryParam("tenantId"), providerID, safeReturnTo(c.QueryParam("returnTo")),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     federationVerifierCookie,
+		Value:    start.CodeVerifier,
+		Path:     fmt.Sprintf(usecase.FederatedCallbackPathFormat, providerID),
+		MaxAge:   600, // 10 minutes
+		HttpOnly: true,
+		Secure:   c.Request().TLS != nil || c.Request().Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Redirect(http.StatusFound, start.AuthURL)
+}
+
+// FederatedSignInCallbackHandler completes sign in when the upstream identity provider redirects back
+func (a *AuthWebHandlers) FederatedSignInCallbackHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	providerID := c.Param("providerId")
+
+	if errCode := c.QueryParam("error"); errCode != "" {
+		return katapp.NewErr(katapp.ErrUnauthorized, "sign in with identity provider failed: "+errCode)
+	}
+	var codeVerifier string
+	if verifierCookie, err := c.Cookie(federationVerifierCookie); err == nil {
+		codeVerifier = verifierCookie.Value
+	}
+
+	result, err := a.federationMgm.CompleteSignIn(
+		ctx, providerID, c.QueryParam("state"), c.QueryParam("code"), codeVerifier,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:   federationVerifierCookie,
+		Path:   fmt.Sprintf(usecase.FederatedCallbackPathFormat, providerID),
+		MaxAge: -1,
+	})
+	if result.MFAChallenge != nil {
+		return renderTemplateComponent(c, "Two-Factor Authentication",
+			common.MFAChallengeForm("/web/user", result.MFAChallenge, result.Email, safeReturnTo(result.ReturnTo)))
+	}
+	a.setAuthCookies(c, result.SignIn.AccessToken, result.SignIn.RefreshToken, result.Email)
+
+	redirectTo := "/web/user"
+	if returnTo := safeReturnTo(result.ReturnTo); returnTo != "" {
+		redirectTo = returnTo
 	}
+	return c.Redirect(http.StatusSeeOther, redirectTo)
+}
+
+// safeReturnTo only allows returning to the local authorization endpoint to prevent open redirects
+func safeReturnTo(returnTo string) string {
+	if strings.HasPrefix(returnTo, usecase.OIDCAuthorizePath+"?") {
+		return returnTo
+	}
+	return ""
 }
 
 // SignUpSubmitHandler handles sign-up
@@ -118,6 +254,105 @@ func (a *AuthWebHandlers) ConfirmEmailHandler(c echo.Context) error {
 	return renderTemplateComponent(c, "Email Confirmation", user.EmailConfirmationSuccess())
 }
 
+// ForgotPasswordLoadHandler renders the forgot password form
+func (a *AuthWebHandlers) ForgotPasswordLoadHandler(c echo.Context) error {
+	return renderTemplateComponent(c, "Forgot Password", user.ForgotPasswordForm())
+}
+
+// ForgotPasswordSubmitHandler handles password reset requests
+func (a *AuthWebHandlers) ForgotPasswordSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	forgotReq := &swagger.ForgotPasswordRequest{
+		Email:    strings.TrimSpace(c.FormValue("email")),
+		TenantId: strings.TrimSpace(c.FormValue("tenantId")),
+		Source:   "web",
+	}
+	err := a.authMgm.ForgotPassword(ctx, forgotReq)
+	if err != nil {
+		return err
+	}
+	return user.ForgotPasswordSuccess().Render(ctx, c.Response().Writer)
+}
+
+// ResetPasswordLoadHandler renders the reset password form from web links
+func (a *AuthWebHandlers) ResetPasswordLoadHandler(c echo.Context) error {
+	tenantID := c.QueryParam("tenantId")
+	email := c.QueryParam("email")
+	code := c.QueryParam("code")
+
+	if tenantID == "" || email == "" || code == "" {
+		return renderTemplateComponent(c, "Reset Password", user.ResetPasswordError("Invalid password reset link"))
+	}
+
+	return renderTemplateComponent(c, "Reset Password", user.ResetPasswordForm(tenantID, email, code))
+}
+
+// ResetPasswordSubmitHandler handles password reset
+func (a *AuthWebHandlers) ResetPasswordSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	newPassword := strings.TrimSpace(c.FormValue("newPassword"))
+	confirmPassword := strings.TrimSpace(c.FormValue("confirmPassword"))
+	if newPassword != confirmPassword {
+		return katapp.NewErr(katapp.ErrInvalidInput, "New password and confirmation do not match")
+	}
+
+	resetReq := &swagger.ResetPasswordRequest{
+		Email:       strings.TrimSpace(c.FormValue("email")),
+		TenantId:    strings.TrimSpace(c.FormValue("tenantId")),
+		Code:        strings.TrimSpace(c.FormValue("code")),
+		NewPassword: newPassword,
+	}
+	err := a.authMgm.ResetPassword(ctx, resetReq)
+	if err != nil {
+		return err
+	}
+
+	// All sessions were revoked, so make sure this browser is signed out as well
+	a.clearAuthCookies(c)
+	return user.ResetPasswordSuccess().Render(ctx, c.Response().Writer)
+}
+
+// AcceptInvitationLoadHandler renders the form of accepting an invitation from web links
+func (a *AuthWebHandlers) AcceptInvitationLoadHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	token := c.QueryParam("token")
+	if token == "" {
+		return renderTemplateComponent(c, "Accept Invitation", user.AcceptInvitationError("Invalid invitation link."))
+	}
+
+	invitation, err := a.invitationMgm.GetInvitationByToken(ctx, token)
+	if err != nil {
+		return renderTemplateComponent(c, "Accept Invitation",
+			user.AcceptInvitationError("The invitation link is invalid or has expired."))
+	}
+	return renderTemplateComponent(c, "Accept Invitation", user.AcceptInvitationForm(invitation, token))
+}
+
+// AcceptInvitationSubmitHandler handles creating the account of an invitee
+func (a *AuthWebHandlers) AcceptInvitationSubmitHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	password := strings.TrimSpace(c.FormValue("password"))
+	confirmPassword := strings.TrimSpace(c.FormValue("confirmPassword"))
+	if password != confirmPassword {
+		return katapp.NewErr(katapp.ErrInvalidInput, "Password and confirmation do not match")
+	}
+
+	acceptReq := &swagger.AcceptInvitationRequest{
+		Token:     strings.TrimSpace(c.FormValue("token")),
+		FirstName: strings.TrimSpace(c.FormValue("firstName")),
+		LastName:  strings.TrimSpace(c.FormValue("lastName")),
+		Password:  password,
+	}
+	resp, err := a.invitationMgm.AcceptInvitation(ctx, acceptReq)
+	if err != nil {
+		return err
+	}
+	return user.AcceptInvitationSuccess(resp.TenantId).Render(ctx, c.Response().Writer)
+}
+
 // SignOutSubmitHandler handles sign-out
 func (a *AuthWebHandlers) SignOutSubmitHandler(c echo.Context) error {
 	a.clearAuthCookies(c)
@@ -204,7 +439,7 @@ func (a *AuthWebHandlers) GetAuthenticatedUser(c echo.Context) (string, bool) {
 	}
 
 	// Validate the access token
-	_, err = a.authMgm.ValidateAccessToken(accessCookie.Value)
+	_, err = a.authMgm.ValidateAccessToken(c.Request().Context(), accessCookie.Value)
 	if err != nil {
 		return "", false
 	}
@@ -1,6 +1,10 @@
 package app
 
-import "github.com/mobiletoly/gokatana/katapp"
+import (
+	"time"
+
+	"github.com/mobiletoly/gokatana/katapp"
+)
 
 type Config struct {
 	Deployment  string
@@ -9,17 +13,140 @@ type Config struct {
 	Server      katapp.ServerConfig
 	Cache       katapp.CacheConfig
 	GCloud      GCloudConfig
+	Mailer      MailerConfig
+	Outbox      OutboxConfig
+	Webhook     WebhookConfig
+
+	// TrustedProxies lists CIDR ranges of reverse proxies whose X-Forwarded-For header is trusted to carry
+	// the client IP. Without trusted proxies the client IP is the address of the direct peer.
+	TrustedProxies []string
+
+	SignInThrottle    SignInThrottleConfig
+	EmailConfirmation EmailConfirmationConfig
+
+	IdentityProviders []IdentityProviderConfig
 }
 
+// CredentialsConfig holds the active JWT signing key and verify-only keys that are still accepted
+// (e.g. keys being rotated out). Each key is identified by an ID that is issued as "kid" token header.
 type CredentialsConfig struct {
-	JwtSecret string
+	JwtKeyID      string
+	JwtSecret     string
+	JwtAlgorithm  string // HS256, RS256 or EdDSA
+	JwtPrivateKey string // PEM-encoded private key, required for RS256 and EdDSA
+	JwtVerifyKeys []JwtKeyConfig
+}
+
+// JwtKeyConfig is a verify-only JWT key
+type JwtKeyConfig struct {
+	ID         string
+	Algorithm  string // HS256, RS256 or EdDSA
+	Secret     string // required for HS256
+	PrivateKey string // PEM-encoded private key for RS256 and EdDSA (either this or PublicKey)
+	PublicKey  string // PEM-encoded public key for RS256 and EdDSA
+}
+
+// SignInThrottleConfig limits failed sign in attempts. Once a user or a client IP reaches its maximum number of
+// failures within FailureWindow, it is locked out for LockoutDuration. Every lockout that follows the previous
+// one within MaxLockoutDuration doubles the duration, up to MaxLockoutDuration. Zero maximum failures disable
+// the check, zero MaxLockoutDuration disables the escalation.
+type SignInThrottleConfig struct {
+	MaxUserFailures    int
+	MaxIPFailures      int
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+}
+
+// EmailConfirmationConfig limits email confirmation codes. A code is invalidated after MaxFailedAttempts wrong
+// guesses. Another code can be sent ResendCooldown after the last one and at most MaxSendsPerDay times a day.
+// Zero maximums disable the checks. Password reset codes are sent within the same cooldown and daily limit.
+type EmailConfirmationConfig struct {
+	MaxFailedAttempts int
+	ResendCooldown    time.Duration
+	MaxSendsPerDay    int
 }
 
 type GCloudConfig struct {
-	Mock        bool
 	ServiceJson string
 	Email       struct {
 		User string
 		From string
 	}
 }
+
+// Mailer providers
+const (
+	MailerProviderGmail  = "gmail"  // Gmail API with the gcloud service account
+	MailerProviderSMTP   = "smtp"   // SMTP relay
+	MailerProviderFile   = "file"   // appended to test-emails/emails.json, for integration tests
+	MailerProviderMemory = "memory" // kept in process, for local development
+)
+
+// TLS modes of SMTP connections
+const (
+	SMTPTLSStartTLS = "starttls" // plain connection upgraded with STARTTLS
+	SMTPTLSImplicit = "implicit" // TLS from the start, usually port 465
+	SMTPTLSNone     = "none"     // no TLS, for relays on a trusted network
+)
+
+// MailerConfig selects how emails are sent, see MailerProvider constants
+type MailerConfig struct {
+	Provider string
+	SMTP     SMTPConfig
+}
+
+// SMTPConfig is an SMTP relay. TLS defaults to SMTPTLSStartTLS and authentication is skipped when Username is
+// empty. A connection is reused for subsequent emails until it has been idle for IdleTimeout.
+type SMTPConfig struct {
+	Host        string
+	Port        int
+	TLS         string
+	Username    string
+	Password    string
+	From        string
+	IdleTimeout time.Duration
+}
+
+// OutboxConfig controls delivery of queued outgoing emails. Every PollInterval up to BatchSize due emails are
+// claimed and sent. A failed email is retried after RetryBackoff, doubled with each further failure up to
+// MaxRetryBackoff, and given up as dead after MaxAttempts. An email claimed by a dispatcher that stopped before
+// recording the result is retried ClaimTimeout after the claim.
+type OutboxConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	ClaimTimeout    time.Duration
+}
+
+// WebhookConfig controls delivery of webhook events like OutboxConfig controls delivery of outgoing emails.
+// RequestTimeout limits every delivery attempt, a webhook that does not respond in time is retried.
+// Webhooks resolving to loopback, private, link-local or other special-purpose addresses are refused unless
+// AllowPrivateNetworks is set, so that tenant admins cannot reach internal services through webhook URLs.
+type WebhookConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	ClaimTimeout    time.Duration
+	RequestTimeout  time.Duration
+
+	AllowPrivateNetworks bool // for local development and integration tests only
+}
+
+// IdentityProviderConfig is an upstream OAuth2/OIDC identity provider that users of a tenant can sign in with.
+// ID is stored as provider of federated user identities and therefore must be unique across all tenants.
+type IdentityProviderConfig struct {
+	ID           string
+	TenantID     string
+	DisplayName  string // shown on the "Sign in with ..." button
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string // must return OIDC standard claims (sub, email, email_verified, given_name, family_name)
+	Scopes       []string
+}
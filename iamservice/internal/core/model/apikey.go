@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// APIKey is a long-lived personal key a user authenticates scripts with instead of access tokens
+type APIKey struct { //+gob:Constructor
+	ID         string
+	UserID     string
+	Name       string
+	KeyPrefix  string // first characters of the key, to tell keys apart
+	KeyHash    string
+	Roles      []string   // roles the key is limited to, a subset of the roles of the user
+	ExpiresAt  *time.Time // nil for keys that do not expire
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsExpired checks if the key can no longer be used at the given time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.After(now)
+}
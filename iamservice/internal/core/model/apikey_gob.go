@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewAPIKeyBuilder() APIKey_Builder_ID {
+	return APIKey_Builder_ID{root: &APIKey{}}
+}
+
+type APIKey_Builder_ID struct {
+	root *APIKey
+}
+
+type APIKey_Builder_UserID struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_ID) ID(arg string) APIKey_Builder_UserID {
+	b.root.ID = arg
+	return APIKey_Builder_UserID{root: b.root}
+}
+
+type APIKey_Builder_Name struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_UserID) UserID(arg string) APIKey_Builder_Name {
+	b.root.UserID = arg
+	return APIKey_Builder_Name{root: b.root}
+}
+
+type APIKey_Builder_KeyPrefix struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_Name) Name(arg string) APIKey_Builder_KeyPrefix {
+	b.root.Name = arg
+	return APIKey_Builder_KeyPrefix{root: b.root}
+}
+
+type APIKey_Builder_KeyHash struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_KeyPrefix) KeyPrefix(arg string) APIKey_Builder_KeyHash {
+	b.root.KeyPrefix = arg
+	return APIKey_Builder_KeyHash{root: b.root}
+}
+
+type APIKey_Builder_Roles struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_KeyHash) KeyHash(arg string) APIKey_Builder_Roles {
+	b.root.KeyHash = arg
+	return APIKey_Builder_Roles{root: b.root}
+}
+
+type APIKey_Builder_ExpiresAt struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_Roles) Roles(arg []string) APIKey_Builder_ExpiresAt {
+	b.root.Roles = arg
+	return APIKey_Builder_ExpiresAt{root: b.root}
+}
+
+type APIKey_Builder_LastUsedAt struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_ExpiresAt) ExpiresAt(arg *time.Time) APIKey_Builder_LastUsedAt {
+	b.root.ExpiresAt = arg
+	return APIKey_Builder_LastUsedAt{root: b.root}
+}
+
+type APIKey_Builder_CreatedAt struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_LastUsedAt) LastUsedAt(arg *time.Time) APIKey_Builder_CreatedAt {
+	b.root.LastUsedAt = arg
+	return APIKey_Builder_CreatedAt{root: b.root}
+}
+
+type APIKey_Builder_GobFinalizer struct {
+	root *APIKey
+}
+
+func (b APIKey_Builder_CreatedAt) CreatedAt(arg time.Time) APIKey_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return APIKey_Builder_GobFinalizer{root: b.root}
+}
+
+func (b APIKey_Builder_GobFinalizer) Build() *APIKey {
+	return b.root
+}
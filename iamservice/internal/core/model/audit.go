@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Audit event actions
+const (
+	AuditActionSignInSucceeded          = "auth.signin_succeeded"
+	AuditActionSignInFailed             = "auth.signin_failed"
+	AuditActionRefreshTokenReused       = "auth.refresh_token_reused"
+	AuditActionAccessTokensRevoked      = "auth.access_tokens_revoked"
+	AuditActionUserImported             = "user.imported"
+	AuditActionUserProvisioned          = "user.provisioned"
+	AuditActionUserUpdated              = "user.updated"
+	AuditActionUserDeleted              = "user.deleted"
+	AuditActionUserPasswordChanged      = "user.password_changed"
+	AuditActionUserEmailChangeRequested = "user.email_change_requested"
+	AuditActionUserEmailChanged         = "user.email_changed"
+	AuditActionUserRoleAssigned         = "user.role_assigned"
+	AuditActionUserRoleRemoved          = "user.role_removed"
+	AuditActionUserUnlocked             = "user.unlocked"
+	AuditActionUserSessionRevoked       = "user.session_revoked"
+	AuditActionUserSessionsRevoked      = "user.sessions_revoked"
+	AuditActionUserAPIKeyCreated        = "user.api_key_created"
+	AuditActionUserAPIKeyRevoked        = "user.api_key_revoked"
+	AuditActionTenantCreated            = "tenant.created"
+	AuditActionTenantUpdated            = "tenant.updated"
+	AuditActionTenantDeleted            = "tenant.deleted"
+	AuditActionServiceClientCreated     = "service_client.created"
+	AuditActionServiceClientDeleted     = "service_client.deleted"
+	AuditActionOAuthClientCreated       = "oauth_client.created"
+	AuditActionOAuthClientDeleted       = "oauth_client.deleted"
+	AuditActionRoleCreated              = "role.created"
+	AuditActionRoleUpdated              = "role.updated"
+	AuditActionRoleDeleted              = "role.deleted"
+	AuditActionInvitationCreated        = "invitation.created"
+	AuditActionInvitationResent         = "invitation.resent"
+	AuditActionInvitationRevoked        = "invitation.revoked"
+	AuditActionInvitationAccepted       = "invitation.accepted"
+	AuditActionOutboxEmailRetried       = "outbox.email_retried"
+	AuditActionWebhookCreated           = "webhook.created"
+	AuditActionWebhookUpdated           = "webhook.updated"
+	AuditActionWebhookDeleted           = "webhook.deleted"
+	AuditActionWebhookRedelivered       = "webhook.redelivered"
+)
+
+// Audit event target types
+const (
+	AuditTargetUser          = "user"
+	AuditTargetTenant        = "tenant"
+	AuditTargetServiceClient = "service_client"
+	AuditTargetOAuthClient   = "oauth_client"
+	AuditTargetRole          = "role"
+	AuditTargetInvitation    = "invitation"
+	AuditTargetOutboxEmail   = "outbox_email"
+	AuditTargetWebhook       = "webhook"
+	AuditTargetEmail         = "email" // sign in attempts for an unknown user
+)
+
+// AuditEvent records a security-relevant action, the actor who performed it and the target it affected
+type AuditEvent struct { //+gob:Constructor
+	ID            string
+	Action        string
+	TenantID      *string // tenant the event belongs to
+	ActorUserID   *string // nil for unauthenticated actors
+	ActorTenantID *string
+	ActorEmail    *string
+	ActorRoles    []string
+	TargetType    *string
+	TargetID      *string
+	IPAddress     *string
+	UserAgent     *string
+	Reason        *string // failure reason
+	Diff          map[string]AuditChange
+	CreatedAt     time.Time
+}
+
+// AuditChange is an old and new value of a changed field. Old is nil for created and New for deleted values.
+type AuditChange struct {
+	Old any `json:"old,omitempty"`
+	New any `json:"new,omitempty"`
+}
+
+// AuditEventFilter narrows down listed audit events, nil fields are not filtered on
+type AuditEventFilter struct { //+gob:Constructor
+	TenantID    *string
+	Action      *string
+	ActorUserID *string
+	TargetID    *string
+	From        *time.Time
+	To          *time.Time
+}
@@ -0,0 +1,211 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewAuditEventBuilder() AuditEvent_Builder_ID {
+	return AuditEvent_Builder_ID{root: &AuditEvent{}}
+}
+
+type AuditEvent_Builder_ID struct {
+	root *AuditEvent
+}
+
+type AuditEvent_Builder_Action struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_ID) ID(arg string) AuditEvent_Builder_Action {
+	b.root.ID = arg
+	return AuditEvent_Builder_Action{root: b.root}
+}
+
+type AuditEvent_Builder_TenantID struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_Action) Action(arg string) AuditEvent_Builder_TenantID {
+	b.root.Action = arg
+	return AuditEvent_Builder_TenantID{root: b.root}
+}
+
+type AuditEvent_Builder_ActorUserID struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_TenantID) TenantID(arg *string) AuditEvent_Builder_ActorUserID {
+	b.root.TenantID = arg
+	return AuditEvent_Builder_ActorUserID{root: b.root}
+}
+
+type AuditEvent_Builder_ActorTenantID struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_ActorUserID) ActorUserID(arg *string) AuditEvent_Builder_ActorTenantID {
+	b.root.ActorUserID = arg
+	return AuditEvent_Builder_ActorTenantID{root: b.root}
+}
+
+type AuditEvent_Builder_ActorEmail struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_ActorTenantID) ActorTenantID(arg *string) AuditEvent_Builder_ActorEmail {
+	b.root.ActorTenantID = arg
+	return AuditEvent_Builder_ActorEmail{root: b.root}
+}
+
+type AuditEvent_Builder_ActorRoles struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_ActorEmail) ActorEmail(arg *string) AuditEvent_Builder_ActorRoles {
+	b.root.ActorEmail = arg
+	return AuditEvent_Builder_ActorRoles{root: b.root}
+}
+
+type AuditEvent_Builder_TargetType struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_ActorRoles) ActorRoles(arg []string) AuditEvent_Builder_TargetType {
+	b.root.ActorRoles = arg
+	return AuditEvent_Builder_TargetType{root: b.root}
+}
+
+type AuditEvent_Builder_TargetID struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_TargetType) TargetType(arg *string) AuditEvent_Builder_TargetID {
+	b.root.TargetType = arg
+	return AuditEvent_Builder_TargetID{root: b.root}
+}
+
+type AuditEvent_Builder_IPAddress struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_TargetID) TargetID(arg *string) AuditEvent_Builder_IPAddress {
+	b.root.TargetID = arg
+	return AuditEvent_Builder_IPAddress{root: b.root}
+}
+
+type AuditEvent_Builder_UserAgent struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_IPAddress) IPAddress(arg *string) AuditEvent_Builder_UserAgent {
+	b.root.IPAddress = arg
+	return AuditEvent_Builder_UserAgent{root: b.root}
+}
+
+type AuditEvent_Builder_Reason struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_UserAgent) UserAgent(arg *string) AuditEvent_Builder_Reason {
+	b.root.UserAgent = arg
+	return AuditEvent_Builder_Reason{root: b.root}
+}
+
+type AuditEvent_Builder_Diff struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_Reason) Reason(arg *string) AuditEvent_Builder_Diff {
+	b.root.Reason = arg
+	return AuditEvent_Builder_Diff{root: b.root}
+}
+
+type AuditEvent_Builder_CreatedAt struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_Diff) Diff(arg map[string]AuditChange) AuditEvent_Builder_CreatedAt {
+	b.root.Diff = arg
+	return AuditEvent_Builder_CreatedAt{root: b.root}
+}
+
+type AuditEvent_Builder_GobFinalizer struct {
+	root *AuditEvent
+}
+
+func (b AuditEvent_Builder_CreatedAt) CreatedAt(arg time.Time) AuditEvent_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return AuditEvent_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditEvent_Builder_GobFinalizer) Build() *AuditEvent {
+	return b.root
+}
+
+func NewAuditEventFilterBuilder() AuditEventFilter_Builder_TenantID {
+	return AuditEventFilter_Builder_TenantID{root: &AuditEventFilter{}}
+}
+
+type AuditEventFilter_Builder_TenantID struct {
+	root *AuditEventFilter
+}
+
+type AuditEventFilter_Builder_Action struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_TenantID) TenantID(arg *string) AuditEventFilter_Builder_Action {
+	b.root.TenantID = arg
+	return AuditEventFilter_Builder_Action{root: b.root}
+}
+
+type AuditEventFilter_Builder_ActorUserID struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_Action) Action(arg *string) AuditEventFilter_Builder_ActorUserID {
+	b.root.Action = arg
+	return AuditEventFilter_Builder_ActorUserID{root: b.root}
+}
+
+type AuditEventFilter_Builder_TargetID struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_ActorUserID) ActorUserID(arg *string) AuditEventFilter_Builder_TargetID {
+	b.root.ActorUserID = arg
+	return AuditEventFilter_Builder_TargetID{root: b.root}
+}
+
+type AuditEventFilter_Builder_From struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_TargetID) TargetID(arg *string) AuditEventFilter_Builder_From {
+	b.root.TargetID = arg
+	return AuditEventFilter_Builder_From{root: b.root}
+}
+
+type AuditEventFilter_Builder_To struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_From) From(arg *time.Time) AuditEventFilter_Builder_To {
+	b.root.From = arg
+	return AuditEventFilter_Builder_To{root: b.root}
+}
+
+type AuditEventFilter_Builder_GobFinalizer struct {
+	root *AuditEventFilter
+}
+
+func (b AuditEventFilter_Builder_To) To(arg *time.Time) AuditEventFilter_Builder_GobFinalizer {
+	b.root.To = arg
+	return AuditEventFilter_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditEventFilter_Builder_GobFinalizer) Build() *AuditEventFilter {
+	return b.root
+}
@@ -20,23 +20,66 @@ type AuthUser struct { //+gob:Constructor
 
 // Tenant represents a tenant in the multi-tenant system
 type Tenant struct { //+gob:Constructor
-	ID          string
-	Name        string
-	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              string
+	Name            string
+	Description     string
+	RequireAdminMFA bool // users with the admin role must sign in with a second factor
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Sort fields of listed users
+const (
+	UserSortCreatedAt = "createdAt"
+	UserSortEmail     = "email"
+	UserSortFirstName = "firstName"
+	UserSortLastName  = "lastName"
+)
+
+// UserFilter narrows down listed users, nil fields are not filtered on
+type UserFilter struct { //+gob:Constructor
+	TenantID      *string
+	UserID        *string
+	Email         *string // case-insensitive email
+	Search        *string // case-insensitive part of the email, first or last name
+	Role          *string // name of an assigned role
+	EmailVerified *bool
+	IsActive      *bool
+}
+
+// Sort fields of listed tenants
+const (
+	TenantSortCreatedAt = "createdAt"
+	TenantSortName      = "name"
+)
+
+// TenantFilter narrows down listed tenants, nil fields are not filtered on
+type TenantFilter struct { //+gob:Constructor
+	TenantID *string
+	Search   *string // case-insensitive part of the ID or name
 }
 
+// Purposes of email confirmation tokens
+const (
+	EmailConfirmationPurposeSignUp      = "signup"
+	EmailConfirmationPurposeEmailChange = "email_change"
+)
+
 // EmailConfirmationToken represents an email confirmation token
 type EmailConfirmationToken struct { //+gob:Constructor
 	ID        string     `json:"id"`
 	UserID    string     `json:"user_id"`
-	Email     string     `json:"email"`
+	Email     string     `json:"email"`      // address to confirm, the pending new address for email changes
+	Purpose   string     `json:"purpose"`    // EmailConfirmationPurposeSignUp or EmailConfirmationPurposeEmailChange
 	TokenHash string     `json:"token_hash"` // Hashed token/code for database storage
 	Source    string     `json:"source"`
 	ExpiresAt time.Time  `json:"expires_at"`
 	UsedAt    *time.Time `json:"used_at,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
+	CreatedAt time.Time  `json:"created_at"` // when the current code was sent
+
+	FailedAttempts      int       `json:"failed_attempts"`        // wrong guesses of the current code
+	SendCount           int       `json:"send_count"`             // codes sent since SendWindowStartedAt
+	SendWindowStartedAt time.Time `json:"send_window_started_at"` // start of the day window for SendCount
 }
 
 // IsExpired checks if the token has expired
@@ -54,10 +97,41 @@ func (t *EmailConfirmationToken) IsValid() bool {
 	return !t.IsExpired() && !t.IsUsed()
 }
 
+// PasswordResetToken represents a password reset token
+type PasswordResetToken struct { //+gob:Constructor
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	TokenHash string     `json:"token_hash"` // Hashed token/code for database storage
+	Source    string     `json:"source"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"` // when the current code was sent
+
+	FailedAttempts      int       `json:"failed_attempts"`        // wrong guesses within the send window
+	SendCount           int       `json:"send_count"`             // codes sent since SendWindowStartedAt
+	SendWindowStartedAt time.Time `json:"send_window_started_at"` // start of the day window for SendCount
+}
+
+// IsExpired checks if the token has expired
+func (t *PasswordResetToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsUsed checks if the token has been used
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsValid checks if the token is valid (not expired and not used)
+func (t *PasswordResetToken) IsValid() bool {
+	return !t.IsExpired() && !t.IsUsed()
+}
+
 // RefreshToken represents a refresh token in the authentication system
 type RefreshToken struct { //+gob:Constructor
 	ID        string
 	UserID    string
+	FamilyID  string // session the token belongs to, shared by all tokens issued by rotation
 	TokenHash string // SHA-256 hash of the refresh token
 	IssuedAt  time.Time
 	ExpiresAt time.Time
@@ -73,3 +147,41 @@ func (rt *RefreshToken) IsExpired() bool {
 func (rt *RefreshToken) IsValid() bool {
 	return !rt.IsExpired() && !rt.Revoked
 }
+
+// Session sources, the platform a session was started from
+const (
+	SessionSourceWeb     = "web"
+	SessionSourceAndroid = "android"
+	SessionSourceIOS     = "ios"
+)
+
+// Session is a signed in device of a user, it owns one refresh token family
+type Session struct { //+gob:Constructor
+	ID            string // refresh token family ID
+	UserID        string
+	Source        string // web, android or ios
+	UserAgent     *string
+	IPAddress     *string
+	OAuthClientID *string // OAuth client the session was started for, nil for a direct sign in
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+}
+
+// AuthUserIdentity links a user to an account at an upstream identity provider
+type AuthUserIdentity struct { //+gob:Constructor
+	ID             string
+	UserID         string
+	Provider       string // identity provider ID from configuration
+	ProviderUserID string // "sub" claim of the upstream provider
+	AccessToken    *string
+	RefreshToken   *string
+	TokenExpiresAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FederatedProvider is an upstream identity provider offered on the sign-in page
+type FederatedProvider struct { //+gob:Constructor
+	ID          string
+	DisplayName string
+}
@@ -134,12 +134,21 @@ func (b Tenant_Builder_Name) Name(arg string) Tenant_Builder_Description {
 	return Tenant_Builder_Description{root: b.root}
 }
 
-type Tenant_Builder_CreatedAt struct {
+type Tenant_Builder_RequireAdminMFA struct {
 	root *Tenant
 }
 
-func (b Tenant_Builder_Description) Description(arg string) Tenant_Builder_CreatedAt {
+func (b Tenant_Builder_Description) Description(arg string) Tenant_Builder_RequireAdminMFA {
 	b.root.Description = arg
+	return Tenant_Builder_RequireAdminMFA{root: b.root}
+}
+
+type Tenant_Builder_CreatedAt struct {
+	root *Tenant
+}
+
+func (b Tenant_Builder_RequireAdminMFA) RequireAdminMFA(arg bool) Tenant_Builder_CreatedAt {
+	b.root.RequireAdminMFA = arg
 	return Tenant_Builder_CreatedAt{root: b.root}
 }
 
@@ -165,6 +174,111 @@ func (b Tenant_Builder_GobFinalizer) Build() *Tenant {
 	return b.root
 }
 
+func NewUserFilterBuilder() UserFilter_Builder_TenantID {
+	return UserFilter_Builder_TenantID{root: &UserFilter{}}
+}
+
+type UserFilter_Builder_TenantID struct {
+	root *UserFilter
+}
+
+type UserFilter_Builder_UserID struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_TenantID) TenantID(arg *string) UserFilter_Builder_UserID {
+	b.root.TenantID = arg
+	return UserFilter_Builder_UserID{root: b.root}
+}
+
+type UserFilter_Builder_Email struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_UserID) UserID(arg *string) UserFilter_Builder_Email {
+	b.root.UserID = arg
+	return UserFilter_Builder_Email{root: b.root}
+}
+
+type UserFilter_Builder_Search struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_Email) Email(arg *string) UserFilter_Builder_Search {
+	b.root.Email = arg
+	return UserFilter_Builder_Search{root: b.root}
+}
+
+type UserFilter_Builder_Role struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_Search) Search(arg *string) UserFilter_Builder_Role {
+	b.root.Search = arg
+	return UserFilter_Builder_Role{root: b.root}
+}
+
+type UserFilter_Builder_EmailVerified struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_Role) Role(arg *string) UserFilter_Builder_EmailVerified {
+	b.root.Role = arg
+	return UserFilter_Builder_EmailVerified{root: b.root}
+}
+
+type UserFilter_Builder_IsActive struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_EmailVerified) EmailVerified(arg *bool) UserFilter_Builder_IsActive {
+	b.root.EmailVerified = arg
+	return UserFilter_Builder_IsActive{root: b.root}
+}
+
+type UserFilter_Builder_GobFinalizer struct {
+	root *UserFilter
+}
+
+func (b UserFilter_Builder_IsActive) IsActive(arg *bool) UserFilter_Builder_GobFinalizer {
+	b.root.IsActive = arg
+	return UserFilter_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserFilter_Builder_GobFinalizer) Build() *UserFilter {
+	return b.root
+}
+
+func NewTenantFilterBuilder() TenantFilter_Builder_TenantID {
+	return TenantFilter_Builder_TenantID{root: &TenantFilter{}}
+}
+
+type TenantFilter_Builder_TenantID struct {
+	root *TenantFilter
+}
+
+type TenantFilter_Builder_Search struct {
+	root *TenantFilter
+}
+
+func (b TenantFilter_Builder_TenantID) TenantID(arg *string) TenantFilter_Builder_Search {
+	b.root.TenantID = arg
+	return TenantFilter_Builder_Search{root: b.root}
+}
+
+type TenantFilter_Builder_GobFinalizer struct {
+	root *TenantFilter
+}
+
+func (b TenantFilter_Builder_Search) Search(arg *string) TenantFilter_Builder_GobFinalizer {
+	b.root.Search = arg
+	return TenantFilter_Builder_GobFinalizer{root: b.root}
+}
+
+func (b TenantFilter_Builder_GobFinalizer) Build() *TenantFilter {
+	return b.root
+}
+
 func NewEmailConfirmationTokenBuilder() EmailConfirmationToken_Builder_ID {
 	return EmailConfirmationToken_Builder_ID{root: &EmailConfirmationToken{}}
 }
@@ -191,12 +305,21 @@ func (b EmailConfirmationToken_Builder_UserID) UserID(arg string) EmailConfirmat
 	return EmailConfirmationToken_Builder_Email{root: b.root}
 }
 
-type EmailConfirmationToken_Builder_TokenHash struct {
+type EmailConfirmationToken_Builder_Purpose struct {
 	root *EmailConfirmationToken
 }
 
-func (b EmailConfirmationToken_Builder_Email) Email(arg string) EmailConfirmationToken_Builder_TokenHash {
+func (b EmailConfirmationToken_Builder_Email) Email(arg string) EmailConfirmationToken_Builder_Purpose {
 	b.root.Email = arg
+	return EmailConfirmationToken_Builder_Purpose{root: b.root}
+}
+
+type EmailConfirmationToken_Builder_TokenHash struct {
+	root *EmailConfirmationToken
+}
+
+func (b EmailConfirmationToken_Builder_Purpose) Purpose(arg string) EmailConfirmationToken_Builder_TokenHash {
+	b.root.Purpose = arg
 	return EmailConfirmationToken_Builder_TokenHash{root: b.root}
 }
 
@@ -236,12 +359,39 @@ func (b EmailConfirmationToken_Builder_UsedAt) UsedAt(arg *time.Time) EmailConfi
 	return EmailConfirmationToken_Builder_CreatedAt{root: b.root}
 }
 
-type EmailConfirmationToken_Builder_GobFinalizer struct {
+type EmailConfirmationToken_Builder_FailedAttempts struct {
 	root *EmailConfirmationToken
 }
 
-func (b EmailConfirmationToken_Builder_CreatedAt) CreatedAt(arg time.Time) EmailConfirmationToken_Builder_GobFinalizer {
+func (b EmailConfirmationToken_Builder_CreatedAt) CreatedAt(arg time.Time) EmailConfirmationToken_Builder_FailedAttempts {
 	b.root.CreatedAt = arg
+	return EmailConfirmationToken_Builder_FailedAttempts{root: b.root}
+}
+
+type EmailConfirmationToken_Builder_SendCount struct {
+	root *EmailConfirmationToken
+}
+
+func (b EmailConfirmationToken_Builder_FailedAttempts) FailedAttempts(arg int) EmailConfirmationToken_Builder_SendCount {
+	b.root.FailedAttempts = arg
+	return EmailConfirmationToken_Builder_SendCount{root: b.root}
+}
+
+type EmailConfirmationToken_Builder_SendWindowStartedAt struct {
+	root *EmailConfirmationToken
+}
+
+func (b EmailConfirmationToken_Builder_SendCount) SendCount(arg int) EmailConfirmationToken_Builder_SendWindowStartedAt {
+	b.root.SendCount = arg
+	return EmailConfirmationToken_Builder_SendWindowStartedAt{root: b.root}
+}
+
+type EmailConfirmationToken_Builder_GobFinalizer struct {
+	root *EmailConfirmationToken
+}
+
+func (b EmailConfirmationToken_Builder_SendWindowStartedAt) SendWindowStartedAt(arg time.Time) EmailConfirmationToken_Builder_GobFinalizer {
+	b.root.SendWindowStartedAt = arg
 	return EmailConfirmationToken_Builder_GobFinalizer{root: b.root}
 }
 
@@ -249,6 +399,108 @@ func (b EmailConfirmationToken_Builder_GobFinalizer) Build() *EmailConfirmationT
 	return b.root
 }
 
+func NewPasswordResetTokenBuilder() PasswordResetToken_Builder_ID {
+	return PasswordResetToken_Builder_ID{root: &PasswordResetToken{}}
+}
+
+type PasswordResetToken_Builder_ID struct {
+	root *PasswordResetToken
+}
+
+type PasswordResetToken_Builder_UserID struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_ID) ID(arg string) PasswordResetToken_Builder_UserID {
+	b.root.ID = arg
+	return PasswordResetToken_Builder_UserID{root: b.root}
+}
+
+type PasswordResetToken_Builder_TokenHash struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_UserID) UserID(arg string) PasswordResetToken_Builder_TokenHash {
+	b.root.UserID = arg
+	return PasswordResetToken_Builder_TokenHash{root: b.root}
+}
+
+type PasswordResetToken_Builder_Source struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_TokenHash) TokenHash(arg string) PasswordResetToken_Builder_Source {
+	b.root.TokenHash = arg
+	return PasswordResetToken_Builder_Source{root: b.root}
+}
+
+type PasswordResetToken_Builder_ExpiresAt struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_Source) Source(arg string) PasswordResetToken_Builder_ExpiresAt {
+	b.root.Source = arg
+	return PasswordResetToken_Builder_ExpiresAt{root: b.root}
+}
+
+type PasswordResetToken_Builder_UsedAt struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_ExpiresAt) ExpiresAt(arg time.Time) PasswordResetToken_Builder_UsedAt {
+	b.root.ExpiresAt = arg
+	return PasswordResetToken_Builder_UsedAt{root: b.root}
+}
+
+type PasswordResetToken_Builder_CreatedAt struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_UsedAt) UsedAt(arg *time.Time) PasswordResetToken_Builder_CreatedAt {
+	b.root.UsedAt = arg
+	return PasswordResetToken_Builder_CreatedAt{root: b.root}
+}
+
+type PasswordResetToken_Builder_FailedAttempts struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_CreatedAt) CreatedAt(arg time.Time) PasswordResetToken_Builder_FailedAttempts {
+	b.root.CreatedAt = arg
+	return PasswordResetToken_Builder_FailedAttempts{root: b.root}
+}
+
+type PasswordResetToken_Builder_SendCount struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_FailedAttempts) FailedAttempts(arg int) PasswordResetToken_Builder_SendCount {
+	b.root.FailedAttempts = arg
+	return PasswordResetToken_Builder_SendCount{root: b.root}
+}
+
+type PasswordResetToken_Builder_SendWindowStartedAt struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_SendCount) SendCount(arg int) PasswordResetToken_Builder_SendWindowStartedAt {
+	b.root.SendCount = arg
+	return PasswordResetToken_Builder_SendWindowStartedAt{root: b.root}
+}
+
+type PasswordResetToken_Builder_GobFinalizer struct {
+	root *PasswordResetToken
+}
+
+func (b PasswordResetToken_Builder_SendWindowStartedAt) SendWindowStartedAt(arg time.Time) PasswordResetToken_Builder_GobFinalizer {
+	b.root.SendWindowStartedAt = arg
+	return PasswordResetToken_Builder_GobFinalizer{root: b.root}
+}
+
+func (b PasswordResetToken_Builder_GobFinalizer) Build() *PasswordResetToken {
+	return b.root
+}
+
 func NewRefreshTokenBuilder() RefreshToken_Builder_ID {
 	return RefreshToken_Builder_ID{root: &RefreshToken{}}
 }
@@ -266,12 +518,21 @@ func (b RefreshToken_Builder_ID) ID(arg string) RefreshToken_Builder_UserID {
 	return RefreshToken_Builder_UserID{root: b.root}
 }
 
-type RefreshToken_Builder_TokenHash struct {
+type RefreshToken_Builder_FamilyID struct {
 	root *RefreshToken
 }
 
-func (b RefreshToken_Builder_UserID) UserID(arg string) RefreshToken_Builder_TokenHash {
+func (b RefreshToken_Builder_UserID) UserID(arg string) RefreshToken_Builder_FamilyID {
 	b.root.UserID = arg
+	return RefreshToken_Builder_FamilyID{root: b.root}
+}
+
+type RefreshToken_Builder_TokenHash struct {
+	root *RefreshToken
+}
+
+func (b RefreshToken_Builder_FamilyID) FamilyID(arg string) RefreshToken_Builder_TokenHash {
+	b.root.FamilyID = arg
 	return RefreshToken_Builder_TokenHash{root: b.root}
 }
 
@@ -314,3 +575,210 @@ func (b RefreshToken_Builder_Revoked) Revoked(arg bool) RefreshToken_Builder_Gob
 func (b RefreshToken_Builder_GobFinalizer) Build() *RefreshToken {
 	return b.root
 }
+
+func NewSessionBuilder() Session_Builder_ID {
+	return Session_Builder_ID{root: &Session{}}
+}
+
+type Session_Builder_ID struct {
+	root *Session
+}
+
+type Session_Builder_UserID struct {
+	root *Session
+}
+
+func (b Session_Builder_ID) ID(arg string) Session_Builder_UserID {
+	b.root.ID = arg
+	return Session_Builder_UserID{root: b.root}
+}
+
+type Session_Builder_Source struct {
+	root *Session
+}
+
+func (b Session_Builder_UserID) UserID(arg string) Session_Builder_Source {
+	b.root.UserID = arg
+	return Session_Builder_Source{root: b.root}
+}
+
+type Session_Builder_UserAgent struct {
+	root *Session
+}
+
+func (b Session_Builder_Source) Source(arg string) Session_Builder_UserAgent {
+	b.root.Source = arg
+	return Session_Builder_UserAgent{root: b.root}
+}
+
+type Session_Builder_IPAddress struct {
+	root *Session
+}
+
+func (b Session_Builder_UserAgent) UserAgent(arg *string) Session_Builder_IPAddress {
+	b.root.UserAgent = arg
+	return Session_Builder_IPAddress{root: b.root}
+}
+
+type Session_Builder_OAuthClientID struct {
+	root *Session
+}
+
+func (b Session_Builder_IPAddress) IPAddress(arg *string) Session_Builder_OAuthClientID {
+	b.root.IPAddress = arg
+	return Session_Builder_OAuthClientID{root: b.root}
+}
+
+type Session_Builder_CreatedAt struct {
+	root *Session
+}
+
+func (b Session_Builder_OAuthClientID) OAuthClientID(arg *string) Session_Builder_CreatedAt {
+	b.root.OAuthClientID = arg
+	return Session_Builder_CreatedAt{root: b.root}
+}
+
+type Session_Builder_LastUsedAt struct {
+	root *Session
+}
+
+func (b Session_Builder_CreatedAt) CreatedAt(arg time.Time) Session_Builder_LastUsedAt {
+	b.root.CreatedAt = arg
+	return Session_Builder_LastUsedAt{root: b.root}
+}
+
+type Session_Builder_GobFinalizer struct {
+	root *Session
+}
+
+func (b Session_Builder_LastUsedAt) LastUsedAt(arg time.Time) Session_Builder_GobFinalizer {
+	b.root.LastUsedAt = arg
+	return Session_Builder_GobFinalizer{root: b.root}
+}
+
+func (b Session_Builder_GobFinalizer) Build() *Session {
+	return b.root
+}
+
+func NewAuthUserIdentityBuilder() AuthUserIdentity_Builder_ID {
+	return AuthUserIdentity_Builder_ID{root: &AuthUserIdentity{}}
+}
+
+type AuthUserIdentity_Builder_ID struct {
+	root *AuthUserIdentity
+}
+
+type AuthUserIdentity_Builder_UserID struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_ID) ID(arg string) AuthUserIdentity_Builder_UserID {
+	b.root.ID = arg
+	return AuthUserIdentity_Builder_UserID{root: b.root}
+}
+
+type AuthUserIdentity_Builder_Provider struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_UserID) UserID(arg string) AuthUserIdentity_Builder_Provider {
+	b.root.UserID = arg
+	return AuthUserIdentity_Builder_Provider{root: b.root}
+}
+
+type AuthUserIdentity_Builder_ProviderUserID struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_Provider) Provider(arg string) AuthUserIdentity_Builder_ProviderUserID {
+	b.root.Provider = arg
+	return AuthUserIdentity_Builder_ProviderUserID{root: b.root}
+}
+
+type AuthUserIdentity_Builder_AccessToken struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_ProviderUserID) ProviderUserID(arg string) AuthUserIdentity_Builder_AccessToken {
+	b.root.ProviderUserID = arg
+	return AuthUserIdentity_Builder_AccessToken{root: b.root}
+}
+
+type AuthUserIdentity_Builder_RefreshToken struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_AccessToken) AccessToken(arg *string) AuthUserIdentity_Builder_RefreshToken {
+	b.root.AccessToken = arg
+	return AuthUserIdentity_Builder_RefreshToken{root: b.root}
+}
+
+type AuthUserIdentity_Builder_TokenExpiresAt struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_RefreshToken) RefreshToken(arg *string) AuthUserIdentity_Builder_TokenExpiresAt {
+	b.root.RefreshToken = arg
+	return AuthUserIdentity_Builder_TokenExpiresAt{root: b.root}
+}
+
+type AuthUserIdentity_Builder_CreatedAt struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_TokenExpiresAt) TokenExpiresAt(arg *time.Time) AuthUserIdentity_Builder_CreatedAt {
+	b.root.TokenExpiresAt = arg
+	return AuthUserIdentity_Builder_CreatedAt{root: b.root}
+}
+
+type AuthUserIdentity_Builder_UpdatedAt struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_CreatedAt) CreatedAt(arg time.Time) AuthUserIdentity_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return AuthUserIdentity_Builder_UpdatedAt{root: b.root}
+}
+
+type AuthUserIdentity_Builder_GobFinalizer struct {
+	root *AuthUserIdentity
+}
+
+func (b AuthUserIdentity_Builder_UpdatedAt) UpdatedAt(arg time.Time) AuthUserIdentity_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return AuthUserIdentity_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuthUserIdentity_Builder_GobFinalizer) Build() *AuthUserIdentity {
+	return b.root
+}
+
+func NewFederatedProviderBuilder() FederatedProvider_Builder_ID {
+	return FederatedProvider_Builder_ID{root: &FederatedProvider{}}
+}
+
+type FederatedProvider_Builder_ID struct {
+	root *FederatedProvider
+}
+
+type FederatedProvider_Builder_DisplayName struct {
+	root *FederatedProvider
+}
+
+func (b FederatedProvider_Builder_ID) ID(arg string) FederatedProvider_Builder_DisplayName {
+	b.root.ID = arg
+	return FederatedProvider_Builder_DisplayName{root: b.root}
+}
+
+type FederatedProvider_Builder_GobFinalizer struct {
+	root *FederatedProvider
+}
+
+func (b FederatedProvider_Builder_DisplayName) DisplayName(arg string) FederatedProvider_Builder_GobFinalizer {
+	b.root.DisplayName = arg
+	return FederatedProvider_Builder_GobFinalizer{root: b.root}
+}
+
+func (b FederatedProvider_Builder_GobFinalizer) Build() *FederatedProvider {
+	return b.root
+}
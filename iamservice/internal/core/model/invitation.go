@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Invitation invites an email address to a tenant. The invitee accepts it by setting their own password.
+type Invitation struct { //+gob:Constructor
+	ID         string
+	TenantID   string
+	Email      string
+	Roles      []string // roles given to the invitee
+	TokenHash  string   // hash of the last token sent, tokens sent before are rejected
+	InvitedBy  *string  // nil if the inviting user was deleted
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsExpired checks if the invitation can no longer be accepted at the given time
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !i.ExpiresAt.After(now)
+}
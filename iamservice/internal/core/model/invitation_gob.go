@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewInvitationBuilder() Invitation_Builder_ID {
+	return Invitation_Builder_ID{root: &Invitation{}}
+}
+
+type Invitation_Builder_ID struct {
+	root *Invitation
+}
+
+type Invitation_Builder_TenantID struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_ID) ID(arg string) Invitation_Builder_TenantID {
+	b.root.ID = arg
+	return Invitation_Builder_TenantID{root: b.root}
+}
+
+type Invitation_Builder_Email struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_TenantID) TenantID(arg string) Invitation_Builder_Email {
+	b.root.TenantID = arg
+	return Invitation_Builder_Email{root: b.root}
+}
+
+type Invitation_Builder_Roles struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_Email) Email(arg string) Invitation_Builder_Roles {
+	b.root.Email = arg
+	return Invitation_Builder_Roles{root: b.root}
+}
+
+type Invitation_Builder_TokenHash struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_Roles) Roles(arg []string) Invitation_Builder_TokenHash {
+	b.root.Roles = arg
+	return Invitation_Builder_TokenHash{root: b.root}
+}
+
+type Invitation_Builder_InvitedBy struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_TokenHash) TokenHash(arg string) Invitation_Builder_InvitedBy {
+	b.root.TokenHash = arg
+	return Invitation_Builder_InvitedBy{root: b.root}
+}
+
+type Invitation_Builder_ExpiresAt struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_InvitedBy) InvitedBy(arg *string) Invitation_Builder_ExpiresAt {
+	b.root.InvitedBy = arg
+	return Invitation_Builder_ExpiresAt{root: b.root}
+}
+
+type Invitation_Builder_AcceptedAt struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_ExpiresAt) ExpiresAt(arg time.Time) Invitation_Builder_AcceptedAt {
+	b.root.ExpiresAt = arg
+	return Invitation_Builder_AcceptedAt{root: b.root}
+}
+
+type Invitation_Builder_CreatedAt struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_AcceptedAt) AcceptedAt(arg *time.Time) Invitation_Builder_CreatedAt {
+	b.root.AcceptedAt = arg
+	return Invitation_Builder_CreatedAt{root: b.root}
+}
+
+type Invitation_Builder_GobFinalizer struct {
+	root *Invitation
+}
+
+func (b Invitation_Builder_CreatedAt) CreatedAt(arg time.Time) Invitation_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return Invitation_Builder_GobFinalizer{root: b.root}
+}
+
+func (b Invitation_Builder_GobFinalizer) Build() *Invitation {
+	return b.root
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// UserMFA represents the TOTP authenticator of a user
+type UserMFA struct { //+gob:Constructor
+	UserID       string
+	TOTPSecret   string     // base32 encoded shared secret
+	ConfirmedAt  *time.Time // nil until enrollment is confirmed with a first code
+	LastUsedStep *int64     // last accepted TOTP time step
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsConfirmed checks if enrollment is complete and the second factor is required on sign in
+func (m *UserMFA) IsConfirmed() bool {
+	return m.ConfirmedAt != nil
+}
@@ -0,0 +1,73 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewUserMFABuilder() UserMFA_Builder_UserID {
+	return UserMFA_Builder_UserID{root: &UserMFA{}}
+}
+
+type UserMFA_Builder_UserID struct {
+	root *UserMFA
+}
+
+type UserMFA_Builder_TOTPSecret struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_UserID) UserID(arg string) UserMFA_Builder_TOTPSecret {
+	b.root.UserID = arg
+	return UserMFA_Builder_TOTPSecret{root: b.root}
+}
+
+type UserMFA_Builder_ConfirmedAt struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_TOTPSecret) TOTPSecret(arg string) UserMFA_Builder_ConfirmedAt {
+	b.root.TOTPSecret = arg
+	return UserMFA_Builder_ConfirmedAt{root: b.root}
+}
+
+type UserMFA_Builder_LastUsedStep struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_ConfirmedAt) ConfirmedAt(arg *time.Time) UserMFA_Builder_LastUsedStep {
+	b.root.ConfirmedAt = arg
+	return UserMFA_Builder_LastUsedStep{root: b.root}
+}
+
+type UserMFA_Builder_CreatedAt struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_LastUsedStep) LastUsedStep(arg *int64) UserMFA_Builder_CreatedAt {
+	b.root.LastUsedStep = arg
+	return UserMFA_Builder_CreatedAt{root: b.root}
+}
+
+type UserMFA_Builder_UpdatedAt struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_CreatedAt) CreatedAt(arg time.Time) UserMFA_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return UserMFA_Builder_UpdatedAt{root: b.root}
+}
+
+type UserMFA_Builder_GobFinalizer struct {
+	root *UserMFA
+}
+
+func (b UserMFA_Builder_UpdatedAt) UpdatedAt(arg time.Time) UserMFA_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return UserMFA_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserMFA_Builder_GobFinalizer) Build() *UserMFA {
+	return b.root
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// OAuthClient represents an application registered to sign in users via OpenID Connect
+type OAuthClient struct { //+gob:Constructor
+	ID               string
+	TenantID         string
+	Name             string
+	ClientSecretHash *string // nil for public clients (SPAs, mobile apps)
+	RedirectURIs     []string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsPublic checks if the client cannot keep a secret and must rely on PKCE only
+func (c *OAuthClient) IsPublic() bool {
+	return c.ClientSecretHash == nil
+}
+
+// IsRedirectURIAllowed checks if the redirect URI exactly matches one of the registered URIs
+func (c *OAuthClient) IsRedirectURIAllowed(redirectURI string) bool {
+	return slices.Contains(c.RedirectURIs, redirectURI)
+}
+
+// OAuthAuthorizationCode represents an authorization code issued to a client for a signed-in user
+type OAuthAuthorizationCode struct { //+gob:Constructor
+	ID                  string     `json:"id"`
+	ClientID            string     `json:"client_id"`
+	UserID              string     `json:"user_id"`
+	CodeHash            string     `json:"code_hash"` // Hashed code for database storage
+	RedirectURI         string     `json:"redirect_uri"`
+	Scope               string     `json:"scope"`
+	Nonce               *string    `json:"nonce,omitempty"`
+	CodeChallenge       string     `json:"code_challenge"`
+	CodeChallengeMethod string     `json:"code_challenge_method"`
+	AuthTime            time.Time  `json:"auth_time"` // sign in of the session the code was issued from
+	ExpiresAt           time.Time  `json:"expires_at"`
+	UsedAt              *time.Time `json:"used_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// IsExpired checks if the authorization code has expired
+func (c *OAuthAuthorizationCode) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// IsUsed checks if the authorization code has been exchanged already
+func (c *OAuthAuthorizationCode) IsUsed() bool {
+	return c.UsedAt != nil
+}
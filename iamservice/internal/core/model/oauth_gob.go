@@ -0,0 +1,211 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewOAuthClientBuilder() OAuthClient_Builder_ID {
+	return OAuthClient_Builder_ID{root: &OAuthClient{}}
+}
+
+type OAuthClient_Builder_ID struct {
+	root *OAuthClient
+}
+
+type OAuthClient_Builder_TenantID struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_ID) ID(arg string) OAuthClient_Builder_TenantID {
+	b.root.ID = arg
+	return OAuthClient_Builder_TenantID{root: b.root}
+}
+
+type OAuthClient_Builder_Name struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_TenantID) TenantID(arg string) OAuthClient_Builder_Name {
+	b.root.TenantID = arg
+	return OAuthClient_Builder_Name{root: b.root}
+}
+
+type OAuthClient_Builder_ClientSecretHash struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_Name) Name(arg string) OAuthClient_Builder_ClientSecretHash {
+	b.root.Name = arg
+	return OAuthClient_Builder_ClientSecretHash{root: b.root}
+}
+
+type OAuthClient_Builder_RedirectURIs struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_ClientSecretHash) ClientSecretHash(arg *string) OAuthClient_Builder_RedirectURIs {
+	b.root.ClientSecretHash = arg
+	return OAuthClient_Builder_RedirectURIs{root: b.root}
+}
+
+type OAuthClient_Builder_CreatedAt struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_RedirectURIs) RedirectURIs(arg []string) OAuthClient_Builder_CreatedAt {
+	b.root.RedirectURIs = arg
+	return OAuthClient_Builder_CreatedAt{root: b.root}
+}
+
+type OAuthClient_Builder_UpdatedAt struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_CreatedAt) CreatedAt(arg time.Time) OAuthClient_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return OAuthClient_Builder_UpdatedAt{root: b.root}
+}
+
+type OAuthClient_Builder_GobFinalizer struct {
+	root *OAuthClient
+}
+
+func (b OAuthClient_Builder_UpdatedAt) UpdatedAt(arg time.Time) OAuthClient_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return OAuthClient_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthClient_Builder_GobFinalizer) Build() *OAuthClient {
+	return b.root
+}
+
+func NewOAuthAuthorizationCodeBuilder() OAuthAuthorizationCode_Builder_ID {
+	return OAuthAuthorizationCode_Builder_ID{root: &OAuthAuthorizationCode{}}
+}
+
+type OAuthAuthorizationCode_Builder_ID struct {
+	root *OAuthAuthorizationCode
+}
+
+type OAuthAuthorizationCode_Builder_ClientID struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_ID) ID(arg string) OAuthAuthorizationCode_Builder_ClientID {
+	b.root.ID = arg
+	return OAuthAuthorizationCode_Builder_ClientID{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_UserID struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_ClientID) ClientID(arg string) OAuthAuthorizationCode_Builder_UserID {
+	b.root.ClientID = arg
+	return OAuthAuthorizationCode_Builder_UserID{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_CodeHash struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_UserID) UserID(arg string) OAuthAuthorizationCode_Builder_CodeHash {
+	b.root.UserID = arg
+	return OAuthAuthorizationCode_Builder_CodeHash{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_RedirectURI struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_CodeHash) CodeHash(arg string) OAuthAuthorizationCode_Builder_RedirectURI {
+	b.root.CodeHash = arg
+	return OAuthAuthorizationCode_Builder_RedirectURI{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_Scope struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_RedirectURI) RedirectURI(arg string) OAuthAuthorizationCode_Builder_Scope {
+	b.root.RedirectURI = arg
+	return OAuthAuthorizationCode_Builder_Scope{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_Nonce struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_Scope) Scope(arg string) OAuthAuthorizationCode_Builder_Nonce {
+	b.root.Scope = arg
+	return OAuthAuthorizationCode_Builder_Nonce{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_CodeChallenge struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_Nonce) Nonce(arg *string) OAuthAuthorizationCode_Builder_CodeChallenge {
+	b.root.Nonce = arg
+	return OAuthAuthorizationCode_Builder_CodeChallenge{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_CodeChallengeMethod struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_CodeChallenge) CodeChallenge(arg string) OAuthAuthorizationCode_Builder_CodeChallengeMethod {
+	b.root.CodeChallenge = arg
+	return OAuthAuthorizationCode_Builder_CodeChallengeMethod{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_AuthTime struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_CodeChallengeMethod) CodeChallengeMethod(arg string) OAuthAuthorizationCode_Builder_AuthTime {
+	b.root.CodeChallengeMethod = arg
+	return OAuthAuthorizationCode_Builder_AuthTime{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_ExpiresAt struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_AuthTime) AuthTime(arg time.Time) OAuthAuthorizationCode_Builder_ExpiresAt {
+	b.root.AuthTime = arg
+	return OAuthAuthorizationCode_Builder_ExpiresAt{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_UsedAt struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_ExpiresAt) ExpiresAt(arg time.Time) OAuthAuthorizationCode_Builder_UsedAt {
+	b.root.ExpiresAt = arg
+	return OAuthAuthorizationCode_Builder_UsedAt{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_CreatedAt struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_UsedAt) UsedAt(arg *time.Time) OAuthAuthorizationCode_Builder_CreatedAt {
+	b.root.UsedAt = arg
+	return OAuthAuthorizationCode_Builder_CreatedAt{root: b.root}
+}
+
+type OAuthAuthorizationCode_Builder_GobFinalizer struct {
+	root *OAuthAuthorizationCode
+}
+
+func (b OAuthAuthorizationCode_Builder_CreatedAt) CreatedAt(arg time.Time) OAuthAuthorizationCode_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return OAuthAuthorizationCode_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthAuthorizationCode_Builder_GobFinalizer) Build() *OAuthAuthorizationCode {
+	return b.root
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Delivery states of outgoing emails
+const (
+	OutboxEmailStatusPending = "pending" // waiting for the next delivery attempt
+	OutboxEmailStatusSent    = "sent"
+	OutboxEmailStatusDead    = "dead" // given up after the last delivery attempt failed, retried manually only
+)
+
+// OutboxEmail is an outgoing email queued in the transaction of the change that sends it
+type OutboxEmail struct { //+gob:Constructor
+	ID            string
+	Recipient     string
+	Subject       string
+	HTMLBody      string // empty once the email was sent
+	TextBody      string // empty once the email was sent
+	ReplyTo       *string
+	Headers       map[string]string // additional headers, e.g. List-Unsubscribe
+	Attachments   []MailAttachment  // empty once the email was sent
+	Status        string
+	Attempts      int // delivery attempts made so far
+	NextAttemptAt time.Time
+	LastError     *string // error of the last failed delivery attempt
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// MailAttachment is a file attached to an outgoing email
+type MailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
@@ -0,0 +1,145 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewOutboxEmailBuilder() OutboxEmail_Builder_ID {
+	return OutboxEmail_Builder_ID{root: &OutboxEmail{}}
+}
+
+type OutboxEmail_Builder_ID struct {
+	root *OutboxEmail
+}
+
+type OutboxEmail_Builder_Recipient struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_ID) ID(arg string) OutboxEmail_Builder_Recipient {
+	b.root.ID = arg
+	return OutboxEmail_Builder_Recipient{root: b.root}
+}
+
+type OutboxEmail_Builder_Subject struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Recipient) Recipient(arg string) OutboxEmail_Builder_Subject {
+	b.root.Recipient = arg
+	return OutboxEmail_Builder_Subject{root: b.root}
+}
+
+type OutboxEmail_Builder_HTMLBody struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Subject) Subject(arg string) OutboxEmail_Builder_HTMLBody {
+	b.root.Subject = arg
+	return OutboxEmail_Builder_HTMLBody{root: b.root}
+}
+
+type OutboxEmail_Builder_TextBody struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_HTMLBody) HTMLBody(arg string) OutboxEmail_Builder_TextBody {
+	b.root.HTMLBody = arg
+	return OutboxEmail_Builder_TextBody{root: b.root}
+}
+
+type OutboxEmail_Builder_ReplyTo struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_TextBody) TextBody(arg string) OutboxEmail_Builder_ReplyTo {
+	b.root.TextBody = arg
+	return OutboxEmail_Builder_ReplyTo{root: b.root}
+}
+
+type OutboxEmail_Builder_Headers struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_ReplyTo) ReplyTo(arg *string) OutboxEmail_Builder_Headers {
+	b.root.ReplyTo = arg
+	return OutboxEmail_Builder_Headers{root: b.root}
+}
+
+type OutboxEmail_Builder_Attachments struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Headers) Headers(arg map[string]string) OutboxEmail_Builder_Attachments {
+	b.root.Headers = arg
+	return OutboxEmail_Builder_Attachments{root: b.root}
+}
+
+type OutboxEmail_Builder_Status struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Attachments) Attachments(arg []MailAttachment) OutboxEmail_Builder_Status {
+	b.root.Attachments = arg
+	return OutboxEmail_Builder_Status{root: b.root}
+}
+
+type OutboxEmail_Builder_Attempts struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Status) Status(arg string) OutboxEmail_Builder_Attempts {
+	b.root.Status = arg
+	return OutboxEmail_Builder_Attempts{root: b.root}
+}
+
+type OutboxEmail_Builder_NextAttemptAt struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_Attempts) Attempts(arg int) OutboxEmail_Builder_NextAttemptAt {
+	b.root.Attempts = arg
+	return OutboxEmail_Builder_NextAttemptAt{root: b.root}
+}
+
+type OutboxEmail_Builder_LastError struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) OutboxEmail_Builder_LastError {
+	b.root.NextAttemptAt = arg
+	return OutboxEmail_Builder_LastError{root: b.root}
+}
+
+type OutboxEmail_Builder_CreatedAt struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_LastError) LastError(arg *string) OutboxEmail_Builder_CreatedAt {
+	b.root.LastError = arg
+	return OutboxEmail_Builder_CreatedAt{root: b.root}
+}
+
+type OutboxEmail_Builder_SentAt struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_CreatedAt) CreatedAt(arg time.Time) OutboxEmail_Builder_SentAt {
+	b.root.CreatedAt = arg
+	return OutboxEmail_Builder_SentAt{root: b.root}
+}
+
+type OutboxEmail_Builder_GobFinalizer struct {
+	root *OutboxEmail
+}
+
+func (b OutboxEmail_Builder_SentAt) SentAt(arg *time.Time) OutboxEmail_Builder_GobFinalizer {
+	b.root.SentAt = arg
+	return OutboxEmail_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OutboxEmail_Builder_GobFinalizer) Build() *OutboxEmail {
+	return b.root
+}
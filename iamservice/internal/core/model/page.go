@@ -0,0 +1,19 @@
+package model
+
+//go:generate go tool gobetter -input $GOFILE
+
+// PageRequest selects a page of a sorted listing, either by offset or by a cursor (keyset pagination). Items with
+// equal sort values are ordered by ID.
+type PageRequest struct { //+gob:Constructor
+	SortBy   string // one of the sort fields of the listing, e.g. UserSortEmail
+	SortDesc bool
+	After    *PageCursor // page starts after this item, Offset is ignored when set
+	Offset   int
+	Limit    int
+}
+
+// PageCursor is the position of the last item of a page in a sorted listing
+type PageCursor struct {
+	Value string // sort value of the item, timestamps in RFC 3339 format with nanoseconds
+	ID    string
+}
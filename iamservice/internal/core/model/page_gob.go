@@ -0,0 +1,60 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+func NewPageRequestBuilder() PageRequest_Builder_SortBy {
+	return PageRequest_Builder_SortBy{root: &PageRequest{}}
+}
+
+type PageRequest_Builder_SortBy struct {
+	root *PageRequest
+}
+
+type PageRequest_Builder_SortDesc struct {
+	root *PageRequest
+}
+
+func (b PageRequest_Builder_SortBy) SortBy(arg string) PageRequest_Builder_SortDesc {
+	b.root.SortBy = arg
+	return PageRequest_Builder_SortDesc{root: b.root}
+}
+
+type PageRequest_Builder_After struct {
+	root *PageRequest
+}
+
+func (b PageRequest_Builder_SortDesc) SortDesc(arg bool) PageRequest_Builder_After {
+	b.root.SortDesc = arg
+	return PageRequest_Builder_After{root: b.root}
+}
+
+type PageRequest_Builder_Offset struct {
+	root *PageRequest
+}
+
+func (b PageRequest_Builder_After) After(arg *PageCursor) PageRequest_Builder_Offset {
+	b.root.After = arg
+	return PageRequest_Builder_Offset{root: b.root}
+}
+
+type PageRequest_Builder_Limit struct {
+	root *PageRequest
+}
+
+func (b PageRequest_Builder_Offset) Offset(arg int) PageRequest_Builder_Limit {
+	b.root.Offset = arg
+	return PageRequest_Builder_Limit{root: b.root}
+}
+
+type PageRequest_Builder_GobFinalizer struct {
+	root *PageRequest
+}
+
+func (b PageRequest_Builder_Limit) Limit(arg int) PageRequest_Builder_GobFinalizer {
+	b.root.Limit = arg
+	return PageRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b PageRequest_Builder_GobFinalizer) Build() *PageRequest {
+	return b.root
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// System roles, available in every tenant
+const (
+	RoleUser     = "user"
+	RoleAdmin    = "admin"
+	RoleSysadmin = "sysadmin"
+)
+
+// Permissions checked by use cases and routes. A permission applies in the tenant of the principal only,
+// unless the principal also has PermissionAllTenants.
+const (
+	PermissionUsersRead            = "users:read"
+	PermissionUsersCreate          = "users:create"
+	PermissionUsersUpdate          = "users:update"
+	PermissionUsersDelete          = "users:delete"
+	PermissionUsersAssignRoles     = "users:assign_roles"
+	PermissionTenantsRead          = "tenants:read"
+	PermissionTenantsUpdate        = "tenants:update"
+	PermissionTenantsCreate        = "tenants:create"
+	PermissionTenantsDelete        = "tenants:delete"
+	PermissionAllTenants           = "tenants:all"
+	PermissionRolesRead            = "roles:read"
+	PermissionRolesManage          = "roles:manage"
+	PermissionAuditRead            = "audit:read"
+	PermissionServiceClientsManage = "service_clients:manage"
+	PermissionOAuthClientsManage   = "oauth_clients:manage"
+	PermissionTokensRevoke         = "tokens:revoke"
+	PermissionOutboxManage         = "outbox:manage"
+	PermissionWebhooksManage       = "webhooks:manage"
+)
+
+// Permission is a permission known to the service
+type Permission struct { //+gob:Constructor
+	Name        string
+	Description string
+	SystemOnly  bool // only system roles can grant it, custom roles of tenants cannot
+}
+
+// Role bundles permissions. System roles are available in every tenant, custom roles in their tenant only.
+type Role struct { //+gob:Constructor
+	ID          int
+	TenantID    *string // nil for system roles
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsSystem checks if the role is a system role rather than a custom role of a tenant
+func (r *Role) IsSystem() bool {
+	return r.TenantID == nil
+}
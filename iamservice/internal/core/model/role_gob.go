@@ -0,0 +1,121 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewPermissionBuilder() Permission_Builder_Name {
+	return Permission_Builder_Name{root: &Permission{}}
+}
+
+type Permission_Builder_Name struct {
+	root *Permission
+}
+
+type Permission_Builder_Description struct {
+	root *Permission
+}
+
+func (b Permission_Builder_Name) Name(arg string) Permission_Builder_Description {
+	b.root.Name = arg
+	return Permission_Builder_Description{root: b.root}
+}
+
+type Permission_Builder_SystemOnly struct {
+	root *Permission
+}
+
+func (b Permission_Builder_Description) Description(arg string) Permission_Builder_SystemOnly {
+	b.root.Description = arg
+	return Permission_Builder_SystemOnly{root: b.root}
+}
+
+type Permission_Builder_GobFinalizer struct {
+	root *Permission
+}
+
+func (b Permission_Builder_SystemOnly) SystemOnly(arg bool) Permission_Builder_GobFinalizer {
+	b.root.SystemOnly = arg
+	return Permission_Builder_GobFinalizer{root: b.root}
+}
+
+func (b Permission_Builder_GobFinalizer) Build() *Permission {
+	return b.root
+}
+
+func NewRoleBuilder() Role_Builder_ID {
+	return Role_Builder_ID{root: &Role{}}
+}
+
+type Role_Builder_ID struct {
+	root *Role
+}
+
+type Role_Builder_TenantID struct {
+	root *Role
+}
+
+func (b Role_Builder_ID) ID(arg int) Role_Builder_TenantID {
+	b.root.ID = arg
+	return Role_Builder_TenantID{root: b.root}
+}
+
+type Role_Builder_Name struct {
+	root *Role
+}
+
+func (b Role_Builder_TenantID) TenantID(arg *string) Role_Builder_Name {
+	b.root.TenantID = arg
+	return Role_Builder_Name{root: b.root}
+}
+
+type Role_Builder_Description struct {
+	root *Role
+}
+
+func (b Role_Builder_Name) Name(arg string) Role_Builder_Description {
+	b.root.Name = arg
+	return Role_Builder_Description{root: b.root}
+}
+
+type Role_Builder_Permissions struct {
+	root *Role
+}
+
+func (b Role_Builder_Description) Description(arg string) Role_Builder_Permissions {
+	b.root.Description = arg
+	return Role_Builder_Permissions{root: b.root}
+}
+
+type Role_Builder_CreatedAt struct {
+	root *Role
+}
+
+func (b Role_Builder_Permissions) Permissions(arg []string) Role_Builder_CreatedAt {
+	b.root.Permissions = arg
+	return Role_Builder_CreatedAt{root: b.root}
+}
+
+type Role_Builder_UpdatedAt struct {
+	root *Role
+}
+
+func (b Role_Builder_CreatedAt) CreatedAt(arg time.Time) Role_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return Role_Builder_UpdatedAt{root: b.root}
+}
+
+type Role_Builder_GobFinalizer struct {
+	root *Role
+}
+
+func (b Role_Builder_UpdatedAt) UpdatedAt(arg time.Time) Role_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return Role_Builder_GobFinalizer{root: b.root}
+}
+
+func (b Role_Builder_GobFinalizer) Build() *Role {
+	return b.root
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// ServiceClient represents a backend service that calls protected APIs on its own behalf, without a user.
+// It obtains access tokens with the OAuth2 client_credentials grant.
+type ServiceClient struct { //+gob:Constructor
+	ID               string
+	TenantID         string
+	Name             string
+	ClientSecretHash string
+	Roles            []string
+	Scopes           []string   // scopes the client may request
+	ExpiresAt        *time.Time // nil for clients that do not expire
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsExpired checks if the client can no longer obtain access tokens at the given time
+func (c *ServiceClient) IsExpired(now time.Time) bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(now)
+}
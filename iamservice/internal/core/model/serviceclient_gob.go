@@ -0,0 +1,100 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewServiceClientBuilder() ServiceClient_Builder_ID {
+	return ServiceClient_Builder_ID{root: &ServiceClient{}}
+}
+
+type ServiceClient_Builder_ID struct {
+	root *ServiceClient
+}
+
+type ServiceClient_Builder_TenantID struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_ID) ID(arg string) ServiceClient_Builder_TenantID {
+	b.root.ID = arg
+	return ServiceClient_Builder_TenantID{root: b.root}
+}
+
+type ServiceClient_Builder_Name struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_TenantID) TenantID(arg string) ServiceClient_Builder_Name {
+	b.root.TenantID = arg
+	return ServiceClient_Builder_Name{root: b.root}
+}
+
+type ServiceClient_Builder_ClientSecretHash struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_Name) Name(arg string) ServiceClient_Builder_ClientSecretHash {
+	b.root.Name = arg
+	return ServiceClient_Builder_ClientSecretHash{root: b.root}
+}
+
+type ServiceClient_Builder_Roles struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_ClientSecretHash) ClientSecretHash(arg string) ServiceClient_Builder_Roles {
+	b.root.ClientSecretHash = arg
+	return ServiceClient_Builder_Roles{root: b.root}
+}
+
+type ServiceClient_Builder_Scopes struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_Roles) Roles(arg []string) ServiceClient_Builder_Scopes {
+	b.root.Roles = arg
+	return ServiceClient_Builder_Scopes{root: b.root}
+}
+
+type ServiceClient_Builder_ExpiresAt struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_Scopes) Scopes(arg []string) ServiceClient_Builder_ExpiresAt {
+	b.root.Scopes = arg
+	return ServiceClient_Builder_ExpiresAt{root: b.root}
+}
+
+type ServiceClient_Builder_CreatedAt struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_ExpiresAt) ExpiresAt(arg *time.Time) ServiceClient_Builder_CreatedAt {
+	b.root.ExpiresAt = arg
+	return ServiceClient_Builder_CreatedAt{root: b.root}
+}
+
+type ServiceClient_Builder_UpdatedAt struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_CreatedAt) CreatedAt(arg time.Time) ServiceClient_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return ServiceClient_Builder_UpdatedAt{root: b.root}
+}
+
+type ServiceClient_Builder_GobFinalizer struct {
+	root *ServiceClient
+}
+
+func (b ServiceClient_Builder_UpdatedAt) UpdatedAt(arg time.Time) ServiceClient_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return ServiceClient_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ServiceClient_Builder_GobFinalizer) Build() *ServiceClient {
+	return b.root
+}
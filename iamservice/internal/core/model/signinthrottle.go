@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Subject types of sign in throttling
+const (
+	SignInThrottleSubjectUser = "user"
+	SignInThrottleSubjectIP   = "ip"
+)
+
+// SignInThrottle tracks failed sign in attempts of a user or a source IP
+type SignInThrottle struct { //+gob:Constructor
+	SubjectType     string
+	Subject         string // user ID or IP address
+	FailedAttempts  int    // failures since WindowStartedAt
+	WindowStartedAt time.Time
+	LockedUntil     *time.Time
+	LockoutCount    int // consecutive lockouts, each one doubles the lockout duration
+	UpdatedAt       time.Time
+}
+
+// IsLocked checks if sign in attempts are rejected at the given time
+func (t *SignInThrottle) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && t.LockedUntil.After(now)
+}
@@ -0,0 +1,82 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewSignInThrottleBuilder() SignInThrottle_Builder_SubjectType {
+	return SignInThrottle_Builder_SubjectType{root: &SignInThrottle{}}
+}
+
+type SignInThrottle_Builder_SubjectType struct {
+	root *SignInThrottle
+}
+
+type SignInThrottle_Builder_Subject struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_SubjectType) SubjectType(arg string) SignInThrottle_Builder_Subject {
+	b.root.SubjectType = arg
+	return SignInThrottle_Builder_Subject{root: b.root}
+}
+
+type SignInThrottle_Builder_FailedAttempts struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_Subject) Subject(arg string) SignInThrottle_Builder_FailedAttempts {
+	b.root.Subject = arg
+	return SignInThrottle_Builder_FailedAttempts{root: b.root}
+}
+
+type SignInThrottle_Builder_WindowStartedAt struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_FailedAttempts) FailedAttempts(arg int) SignInThrottle_Builder_WindowStartedAt {
+	b.root.FailedAttempts = arg
+	return SignInThrottle_Builder_WindowStartedAt{root: b.root}
+}
+
+type SignInThrottle_Builder_LockedUntil struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_WindowStartedAt) WindowStartedAt(arg time.Time) SignInThrottle_Builder_LockedUntil {
+	b.root.WindowStartedAt = arg
+	return SignInThrottle_Builder_LockedUntil{root: b.root}
+}
+
+type SignInThrottle_Builder_LockoutCount struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_LockedUntil) LockedUntil(arg *time.Time) SignInThrottle_Builder_LockoutCount {
+	b.root.LockedUntil = arg
+	return SignInThrottle_Builder_LockoutCount{root: b.root}
+}
+
+type SignInThrottle_Builder_UpdatedAt struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_LockoutCount) LockoutCount(arg int) SignInThrottle_Builder_UpdatedAt {
+	b.root.LockoutCount = arg
+	return SignInThrottle_Builder_UpdatedAt{root: b.root}
+}
+
+type SignInThrottle_Builder_GobFinalizer struct {
+	root *SignInThrottle
+}
+
+func (b SignInThrottle_Builder_UpdatedAt) UpdatedAt(arg time.Time) SignInThrottle_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return SignInThrottle_Builder_GobFinalizer{root: b.root}
+}
+
+func (b SignInThrottle_Builder_GobFinalizer) Build() *SignInThrottle {
+	return b.root
+}
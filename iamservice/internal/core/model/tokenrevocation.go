@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Subject types of access token revocations
+const (
+	AccessTokenRevocationJTI     = "jti"
+	AccessTokenRevocationSession = "session"
+	AccessTokenRevocationUser    = "user"
+	AccessTokenRevocationClient  = "client"
+	AccessTokenRevocationAll     = "all"
+)
+
+// AccessTokenRevocation rejects access tokens of a subject that were issued before IssuedBefore
+type AccessTokenRevocation struct { //+gob:Constructor
+	SubjectType  string
+	Subject      string // token ID, session ID, user ID or service client ID, empty for all tokens
+	IssuedBefore time.Time
+	ExpiresAt    time.Time // all covered tokens are expired by this time
+	CreatedAt    time.Time
+}
@@ -0,0 +1,64 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewAccessTokenRevocationBuilder() AccessTokenRevocation_Builder_SubjectType {
+	return AccessTokenRevocation_Builder_SubjectType{root: &AccessTokenRevocation{}}
+}
+
+type AccessTokenRevocation_Builder_SubjectType struct {
+	root *AccessTokenRevocation
+}
+
+type AccessTokenRevocation_Builder_Subject struct {
+	root *AccessTokenRevocation
+}
+
+func (b AccessTokenRevocation_Builder_SubjectType) SubjectType(arg string) AccessTokenRevocation_Builder_Subject {
+	b.root.SubjectType = arg
+	return AccessTokenRevocation_Builder_Subject{root: b.root}
+}
+
+type AccessTokenRevocation_Builder_IssuedBefore struct {
+	root *AccessTokenRevocation
+}
+
+func (b AccessTokenRevocation_Builder_Subject) Subject(arg string) AccessTokenRevocation_Builder_IssuedBefore {
+	b.root.Subject = arg
+	return AccessTokenRevocation_Builder_IssuedBefore{root: b.root}
+}
+
+type AccessTokenRevocation_Builder_ExpiresAt struct {
+	root *AccessTokenRevocation
+}
+
+func (b AccessTokenRevocation_Builder_IssuedBefore) IssuedBefore(arg time.Time) AccessTokenRevocation_Builder_ExpiresAt {
+	b.root.IssuedBefore = arg
+	return AccessTokenRevocation_Builder_ExpiresAt{root: b.root}
+}
+
+type AccessTokenRevocation_Builder_CreatedAt struct {
+	root *AccessTokenRevocation
+}
+
+func (b AccessTokenRevocation_Builder_ExpiresAt) ExpiresAt(arg time.Time) AccessTokenRevocation_Builder_CreatedAt {
+	b.root.ExpiresAt = arg
+	return AccessTokenRevocation_Builder_CreatedAt{root: b.root}
+}
+
+type AccessTokenRevocation_Builder_GobFinalizer struct {
+	root *AccessTokenRevocation
+}
+
+func (b AccessTokenRevocation_Builder_CreatedAt) CreatedAt(arg time.Time) AccessTokenRevocation_Builder_GobFinalizer {
+	b.root.CreatedAt = arg
+	return AccessTokenRevocation_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AccessTokenRevocation_Builder_GobFinalizer) Build() *AccessTokenRevocation {
+	return b.root
+}
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+//go:generate go tool gobetter -input $GOFILE
+
+// Types of events delivered to webhooks
+const (
+	WebhookEventUserCreated       = "user.created"
+	WebhookEventUserEmailVerified = "user.email_verified"
+	WebhookEventUserDeleted       = "user.deleted"
+	WebhookEventRoleAssigned      = "role.assigned"
+	WebhookEventTenantUpdated     = "tenant.updated"
+)
+
+// WebhookEventTypes lists all event types webhooks can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventUserCreated,
+	WebhookEventUserEmailVerified,
+	WebhookEventUserDeleted,
+	WebhookEventRoleAssigned,
+	WebhookEventTenantUpdated,
+}
+
+// Delivery states of webhook events
+const (
+	WebhookDeliveryStatusPending   = "pending" // waiting for the next delivery attempt
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead" // given up after the last delivery attempt failed, re-delivered manually only
+)
+
+// Webhook is a subscription of a URL to events of a tenant
+type Webhook struct { //+gob:Constructor
+	ID         string
+	TenantID   string
+	URL        string
+	Secret     string // signs delivered events
+	EventTypes []string
+	Active     bool // deliveries of inactive webhooks are held back
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDelivery is an event queued for delivery to a webhook in the transaction of the change that emits it
+type WebhookDelivery struct { //+gob:Constructor
+	ID             string
+	WebhookID      string
+	EventID        string // shared by deliveries of the event to other webhooks and re-deliveries
+	EventType      string
+	Payload        []byte // JSON encoded event as posted to the webhook
+	Status         string
+	Attempts       int // delivery attempts made so far
+	NextAttemptAt  time.Time
+	LastStatusCode *int    // HTTP status of the last delivery attempt, nil if no response was received
+	LastError      *string // error of the last failed delivery attempt
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// DueWebhookDelivery is a claimed delivery with the URL and secret of its webhook
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
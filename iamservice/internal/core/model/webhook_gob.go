@@ -0,0 +1,211 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package model
+
+import (
+	"time"
+)
+
+func NewWebhookBuilder() Webhook_Builder_ID {
+	return Webhook_Builder_ID{root: &Webhook{}}
+}
+
+type Webhook_Builder_ID struct {
+	root *Webhook
+}
+
+type Webhook_Builder_TenantID struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_ID) ID(arg string) Webhook_Builder_TenantID {
+	b.root.ID = arg
+	return Webhook_Builder_TenantID{root: b.root}
+}
+
+type Webhook_Builder_URL struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_TenantID) TenantID(arg string) Webhook_Builder_URL {
+	b.root.TenantID = arg
+	return Webhook_Builder_URL{root: b.root}
+}
+
+type Webhook_Builder_Secret struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_URL) URL(arg string) Webhook_Builder_Secret {
+	b.root.URL = arg
+	return Webhook_Builder_Secret{root: b.root}
+}
+
+type Webhook_Builder_EventTypes struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_Secret) Secret(arg string) Webhook_Builder_EventTypes {
+	b.root.Secret = arg
+	return Webhook_Builder_EventTypes{root: b.root}
+}
+
+type Webhook_Builder_Active struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_EventTypes) EventTypes(arg []string) Webhook_Builder_Active {
+	b.root.EventTypes = arg
+	return Webhook_Builder_Active{root: b.root}
+}
+
+type Webhook_Builder_CreatedAt struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_Active) Active(arg bool) Webhook_Builder_CreatedAt {
+	b.root.Active = arg
+	return Webhook_Builder_CreatedAt{root: b.root}
+}
+
+type Webhook_Builder_UpdatedAt struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_CreatedAt) CreatedAt(arg time.Time) Webhook_Builder_UpdatedAt {
+	b.root.CreatedAt = arg
+	return Webhook_Builder_UpdatedAt{root: b.root}
+}
+
+type Webhook_Builder_GobFinalizer struct {
+	root *Webhook
+}
+
+func (b Webhook_Builder_UpdatedAt) UpdatedAt(arg time.Time) Webhook_Builder_GobFinalizer {
+	b.root.UpdatedAt = arg
+	return Webhook_Builder_GobFinalizer{root: b.root}
+}
+
+func (b Webhook_Builder_GobFinalizer) Build() *Webhook {
+	return b.root
+}
+
+func NewWebhookDeliveryBuilder() WebhookDelivery_Builder_ID {
+	return WebhookDelivery_Builder_ID{root: &WebhookDelivery{}}
+}
+
+type WebhookDelivery_Builder_ID struct {
+	root *WebhookDelivery
+}
+
+type WebhookDelivery_Builder_WebhookID struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_ID) ID(arg string) WebhookDelivery_Builder_WebhookID {
+	b.root.ID = arg
+	return WebhookDelivery_Builder_WebhookID{root: b.root}
+}
+
+type WebhookDelivery_Builder_EventID struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_WebhookID) WebhookID(arg string) WebhookDelivery_Builder_EventID {
+	b.root.WebhookID = arg
+	return WebhookDelivery_Builder_EventID{root: b.root}
+}
+
+type WebhookDelivery_Builder_EventType struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_EventID) EventID(arg string) WebhookDelivery_Builder_EventType {
+	b.root.EventID = arg
+	return WebhookDelivery_Builder_EventType{root: b.root}
+}
+
+type WebhookDelivery_Builder_Payload struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_EventType) EventType(arg string) WebhookDelivery_Builder_Payload {
+	b.root.EventType = arg
+	return WebhookDelivery_Builder_Payload{root: b.root}
+}
+
+type WebhookDelivery_Builder_Status struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_Payload) Payload(arg []byte) WebhookDelivery_Builder_Status {
+	b.root.Payload = arg
+	return WebhookDelivery_Builder_Status{root: b.root}
+}
+
+type WebhookDelivery_Builder_Attempts struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_Status) Status(arg string) WebhookDelivery_Builder_Attempts {
+	b.root.Status = arg
+	return WebhookDelivery_Builder_Attempts{root: b.root}
+}
+
+type WebhookDelivery_Builder_NextAttemptAt struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_Attempts) Attempts(arg int) WebhookDelivery_Builder_NextAttemptAt {
+	b.root.Attempts = arg
+	return WebhookDelivery_Builder_NextAttemptAt{root: b.root}
+}
+
+type WebhookDelivery_Builder_LastStatusCode struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) WebhookDelivery_Builder_LastStatusCode {
+	b.root.NextAttemptAt = arg
+	return WebhookDelivery_Builder_LastStatusCode{root: b.root}
+}
+
+type WebhookDelivery_Builder_LastError struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_LastStatusCode) LastStatusCode(arg *int) WebhookDelivery_Builder_LastError {
+	b.root.LastStatusCode = arg
+	return WebhookDelivery_Builder_LastError{root: b.root}
+}
+
+type WebhookDelivery_Builder_CreatedAt struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_LastError) LastError(arg *string) WebhookDelivery_Builder_CreatedAt {
+	b.root.LastError = arg
+	return WebhookDelivery_Builder_CreatedAt{root: b.root}
+}
+
+type WebhookDelivery_Builder_DeliveredAt struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookDelivery_Builder_DeliveredAt {
+	b.root.CreatedAt = arg
+	return WebhookDelivery_Builder_DeliveredAt{root: b.root}
+}
+
+type WebhookDelivery_Builder_GobFinalizer struct {
+	root *WebhookDelivery
+}
+
+func (b WebhookDelivery_Builder_DeliveredAt) DeliveredAt(arg *time.Time) WebhookDelivery_Builder_GobFinalizer {
+	b.root.DeliveredAt = arg
+	return WebhookDelivery_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookDelivery_Builder_GobFinalizer) Build() *WebhookDelivery {
+	return b.root
+}
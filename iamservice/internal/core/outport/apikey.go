@@ -0,0 +1,20 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// APIKeyPersist defines the outport interface for personal API keys of users
+type APIKeyPersist interface {
+	CreateAPIKey(ctx context.Context, tx pgx.Tx, key *model.APIKey) error
+	GetAPIKeysByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]*model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, tx pgx.Tx, keyHash string) (*model.APIKey, error)
+	// DeleteAPIKey deletes a key of the user, katapp.ErrNotFound is returned if the user has no such key
+	DeleteAPIKey(ctx context.Context, tx pgx.Tx, userID string, keyID string) error
+	// TouchAPIKey records the use of the key, at most once a minute
+	TouchAPIKey(ctx context.Context, tx pgx.Tx, keyID string, usedAt time.Time) error
+}
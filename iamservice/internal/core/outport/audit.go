@@ -0,0 +1,15 @@
+package outport
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// AuditPersist defines the outport interface for recording and listing audit events
+type AuditPersist interface {
+	CreateAuditEvent(ctx context.Context, tx pgx.Tx, event *model.AuditEvent) (*model.AuditEvent, error)
+	// ListAuditEvents returns a page of matching events, newest first, and the total number of matching events
+	ListAuditEvents(ctx context.Context, tx pgx.Tx, filter *model.AuditEventFilter, offset int, limit int) ([]*model.AuditEvent, int, error)
+}
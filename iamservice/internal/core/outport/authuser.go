@@ -17,31 +17,54 @@ type AuthUserPersist interface {
 	DeleteUser(ctx context.Context, tx pgx.Tx, userID string) error
 
 	GetUserWithPasswordByEmail(ctx context.Context, tx pgx.Tx, email string, tenantID string) (*model.AuthUser, error)
-	GetAllUsersByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.AuthUser, error)
-	GetAllUsers(ctx context.Context, tx pgx.Tx) ([]*model.AuthUser, error)
+	ListUsers(ctx context.Context, tx pgx.Tx, filter *model.UserFilter, page *model.PageRequest) ([]*model.AuthUser, int, error)
+	SearchUsers(ctx context.Context, tx pgx.Tx, query string, tenantID *string, limit int) ([]*model.AuthUser, error)
 
 	GetUserRoles(ctx context.Context, tx pgx.Tx, userID string) ([]string, error)
+	GetRolesOfUsers(ctx context.Context, tx pgx.Tx, userIDs []string) (map[string][]string, error)
 	AssignUserRole(ctx context.Context, tx pgx.Tx, userID string, roleName string) error
 	DeleteUserRole(ctx context.Context, tx pgx.Tx, userID string, roleName string) error
 
 	// Tenant operations
 	GetTenantByID(ctx context.Context, tx pgx.Tx, tenantID string) (*model.Tenant, error)
-	GetAllTenants(ctx context.Context, tx pgx.Tx) ([]*model.Tenant, error)
+	ListTenants(ctx context.Context, tx pgx.Tx, filter *model.TenantFilter, page *model.PageRequest) ([]*model.Tenant, int, error)
 	CreateTenant(ctx context.Context, tx pgx.Tx, tenant *swagger.CreateTenantRequest) (*model.Tenant, error)
 	UpdateTenant(ctx context.Context, tx pgx.Tx, tenantID string, tenant *swagger.UpdateTenantRequest) (*model.Tenant, error)
 	DeleteTenant(ctx context.Context, tx pgx.Tx, tenantID string) error
 
 	// Email confirmation
-	CreateEmailConfirmationToken(ctx context.Context, tx pgx.Tx, userID string, email string, tokenHash string, source string, expiresAt time.Time) (*model.EmailConfirmationToken, error)
+	CreateEmailConfirmationToken(ctx context.Context, tx pgx.Tx, userID string, email string, purpose string, tokenHash string, source string, expiresAt time.Time) (*model.EmailConfirmationToken, error)
 	GetEmailConfirmationTokenByUserIDAndHash(ctx context.Context, tx pgx.Tx, userID string, tokenHash string) (*model.EmailConfirmationToken, error)
+	GetEmailConfirmationTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.EmailConfirmationToken, error)
 	MarkEmailConfirmationTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID string) error
+	RecordEmailConfirmationFailure(ctx context.Context, tx pgx.Tx, tokenID string) (int, error)
 	SetUserEmailVerified(ctx context.Context, tx pgx.Tx, userID string, verified bool) error
 
+	// Password reset
+	CreatePasswordResetToken(ctx context.Context, tx pgx.Tx, userID string, tokenHash string, source string, expiresAt time.Time) (*model.PasswordResetToken, error)
+	GetPasswordResetTokenByUserID(ctx context.Context, tx pgx.Tx, userID string) (*model.PasswordResetToken, error)
+	MarkPasswordResetTokenAsUsed(ctx context.Context, tx pgx.Tx, tokenID string) error
+	RecordPasswordResetFailure(ctx context.Context, tx pgx.Tx, tokenID string) (int, error)
+
+	// Federated identities
+	CreateUserIdentity(ctx context.Context, tx pgx.Tx, userID string, provider string, providerUserID string, accessToken *string, refreshToken *string, tokenExpiresAt *time.Time) (*model.AuthUserIdentity, error)
+	GetUserIdentityByProvider(ctx context.Context, tx pgx.Tx, provider string, providerUserID string) (*model.AuthUserIdentity, error)
+	UpdateUserIdentityTokens(ctx context.Context, tx pgx.Tx, identityID string, accessToken *string, refreshToken *string, tokenExpiresAt *time.Time) error
+
 	// Refresh tokens
-	CreateRefreshToken(ctx context.Context, tx pgx.Tx, userID string, tokenHash string, expiresAt time.Time) (*model.RefreshToken, error)
+	CreateRefreshToken(ctx context.Context, tx pgx.Tx, userID string, familyID string, tokenHash string, expiresAt time.Time) (*model.RefreshToken, error)
 	GetRefreshTokenByHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*model.RefreshToken, error)
-	RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) error
+	// RevokeRefreshToken revokes a token that is not revoked yet and returns the number of revoked tokens
+	RevokeRefreshToken(ctx context.Context, tx pgx.Tx, tokenHash string) (int64, error)
 	RevokeAllUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) error
 	CleanupExpiredRefreshTokens(ctx context.Context, tx pgx.Tx) (int64, error)
-	CleanupUserRefreshTokens(ctx context.Context, tx pgx.Tx, userID string) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string) (int64, error)
+	CleanupUserRefreshTokenFamilies(ctx context.Context, tx pgx.Tx, userID string, maxFamilies int) (int64, error)
+	CleanupRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID string, maxTokens int) (int64, error)
+
+	// Sessions
+	CreateSession(ctx context.Context, tx pgx.Tx, session *model.Session) error
+	TouchSession(ctx context.Context, tx pgx.Tx, sessionID string, userAgent *string, ipAddress *string) error
+	GetActiveSessionsByUserID(ctx context.Context, tx pgx.Tx, userID string) ([]*model.Session, error)
+	GetActiveSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) (*model.Session, error)
 }
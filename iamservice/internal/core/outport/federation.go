@@ -0,0 +1,35 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+)
+
+//go:generate go tool gobetter -input $GOFILE
+
+// FederatedTokens are the tokens issued by an upstream identity provider
+type FederatedTokens struct { //+gob:Constructor
+	AccessToken  string
+	RefreshToken *string
+	ExpiresAt    *time.Time
+}
+
+// FederatedUserInfo holds standard OIDC claims returned by an upstream identity provider
+type FederatedUserInfo struct { //+gob:Constructor
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+// FederationClient talks to upstream OAuth2/OIDC identity providers
+type FederationClient interface {
+	AuthCodeURL(provider *app.IdentityProviderConfig, redirectURI string, state string, codeVerifier string) string
+	ExchangeCode(
+		ctx context.Context, provider *app.IdentityProviderConfig, redirectURI string, code string, codeVerifier string,
+	) (*FederatedTokens, error)
+	FetchUserInfo(ctx context.Context, provider *app.IdentityProviderConfig, accessToken string) (*FederatedUserInfo, error)
+}
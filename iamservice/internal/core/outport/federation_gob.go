@@ -0,0 +1,103 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package outport
+
+import (
+	"time"
+)
+
+func NewFederatedTokensBuilder() FederatedTokens_Builder_AccessToken {
+	return FederatedTokens_Builder_AccessToken{root: &FederatedTokens{}}
+}
+
+type FederatedTokens_Builder_AccessToken struct {
+	root *FederatedTokens
+}
+
+type FederatedTokens_Builder_RefreshToken struct {
+	root *FederatedTokens
+}
+
+func (b FederatedTokens_Builder_AccessToken) AccessToken(arg string) FederatedTokens_Builder_RefreshToken {
+	b.root.AccessToken = arg
+	return FederatedTokens_Builder_RefreshToken{root: b.root}
+}
+
+type FederatedTokens_Builder_ExpiresAt struct {
+	root *FederatedTokens
+}
+
+func (b FederatedTokens_Builder_RefreshToken) RefreshToken(arg *string) FederatedTokens_Builder_ExpiresAt {
+	b.root.RefreshToken = arg
+	return FederatedTokens_Builder_ExpiresAt{root: b.root}
+}
+
+type FederatedTokens_Builder_GobFinalizer struct {
+	root *FederatedTokens
+}
+
+func (b FederatedTokens_Builder_ExpiresAt) ExpiresAt(arg *time.Time) FederatedTokens_Builder_GobFinalizer {
+	b.root.ExpiresAt = arg
+	return FederatedTokens_Builder_GobFinalizer{root: b.root}
+}
+
+func (b FederatedTokens_Builder_GobFinalizer) Build() *FederatedTokens {
+	return b.root
+}
+
+func NewFederatedUserInfoBuilder() FederatedUserInfo_Builder_Subject {
+	return FederatedUserInfo_Builder_Subject{root: &FederatedUserInfo{}}
+}
+
+type FederatedUserInfo_Builder_Subject struct {
+	root *FederatedUserInfo
+}
+
+type FederatedUserInfo_Builder_Email struct {
+	root *FederatedUserInfo
+}
+
+func (b FederatedUserInfo_Builder_Subject) Subject(arg string) FederatedUserInfo_Builder_Email {
+	b.root.Subject = arg
+	return FederatedUserInfo_Builder_Email{root: b.root}
+}
+
+type FederatedUserInfo_Builder_EmailVerified struct {
+	root *FederatedUserInfo
+}
+
+func (b FederatedUserInfo_Builder_Email) Email(arg string) FederatedUserInfo_Builder_EmailVerified {
+	b.root.Email = arg
+	return FederatedUserInfo_Builder_EmailVerified{root: b.root}
+}
+
+type FederatedUserInfo_Builder_GivenName struct {
+	root *FederatedUserInfo
+}
+
+func (b FederatedUserInfo_Builder_EmailVerified) EmailVerified(arg bool) FederatedUserInfo_Builder_GivenName {
+	b.root.EmailVerified = arg
+	return FederatedUserInfo_Builder_GivenName{root: b.root}
+}
+
+type FederatedUserInfo_Builder_FamilyName struct {
+	root *FederatedUserInfo
+}
+
+func (b FederatedUserInfo_Builder_GivenName) GivenName(arg string) FederatedUserInfo_Builder_FamilyName {
+	b.root.GivenName = arg
+	return FederatedUserInfo_Builder_FamilyName{root: b.root}
+}
+
+type FederatedUserInfo_Builder_GobFinalizer struct {
+	root *FederatedUserInfo
+}
+
+func (b FederatedUserInfo_Builder_FamilyName) FamilyName(arg string) FederatedUserInfo_Builder_GobFinalizer {
+	b.root.FamilyName = arg
+	return FederatedUserInfo_Builder_GobFinalizer{root: b.root}
+}
+
+func (b FederatedUserInfo_Builder_GobFinalizer) Build() *FederatedUserInfo {
+	return b.root
+}
@@ -0,0 +1,25 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// InvitationPersist defines the outport interface for invitations of users to tenants
+type InvitationPersist interface {
+	// CreateInvitation creates an invitation, katapp.ErrDuplicate is returned if the email address already has
+	// a pending invitation to the tenant
+	CreateInvitation(ctx context.Context, tx pgx.Tx, invitation *model.Invitation) error
+	GetPendingInvitationsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.Invitation, error)
+	GetPendingInvitationByID(ctx context.Context, tx pgx.Tx, tenantID string, invitationID string) (*model.Invitation, error)
+	GetPendingInvitationByTokenHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*model.Invitation, error)
+	// RenewInvitationToken replaces the token of a pending invitation, rejecting the tokens sent before
+	RenewInvitationToken(ctx context.Context, tx pgx.Tx, invitationID string, tokenHash string, expiresAt time.Time) error
+	MarkInvitationAsAccepted(ctx context.Context, tx pgx.Tx, invitationID string, acceptedAt time.Time) error
+	// DeleteInvitation deletes a pending invitation, katapp.ErrNotFound is returned if the tenant has no such
+	// pending invitation
+	DeleteInvitation(ctx context.Context, tx pgx.Tx, tenantID string, invitationID string) error
+}
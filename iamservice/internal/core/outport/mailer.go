@@ -1,13 +1,21 @@
 package outport
 
-import "context"
+import (
+	"context"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
 
 //go:generate go tool gobetter -input $GOFILE
 
+// MailContent is an email with an HTML body and its plain-text alternative, either body may be empty
 type MailContent struct { //+gob:Constructor
-	ContentType string
 	Title       string
-	Body        string
+	HTMLBody    string
+	TextBody    string
+	ReplyTo     *string
+	Headers     map[string]string // additional headers, e.g. List-Unsubscribe
+	Attachments []model.MailAttachment
 }
 
 type Mailer interface {
@@ -2,38 +2,69 @@
 
 package outport
 
-func NewMailContentBuilder() MailContent_Builder_ContentType {
-	return MailContent_Builder_ContentType{root: &MailContent{}}
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+func NewMailContentBuilder() MailContent_Builder_Title {
+	return MailContent_Builder_Title{root: &MailContent{}}
 }
 
-type MailContent_Builder_ContentType struct {
+type MailContent_Builder_Title struct {
 	root *MailContent
 }
 
-type MailContent_Builder_Title struct {
+type MailContent_Builder_HTMLBody struct {
 	root *MailContent
 }
 
-func (b MailContent_Builder_ContentType) ContentType(arg string) MailContent_Builder_Title {
-	b.root.ContentType = arg
-	return MailContent_Builder_Title{root: b.root}
+func (b MailContent_Builder_Title) Title(arg string) MailContent_Builder_HTMLBody {
+	b.root.Title = arg
+	return MailContent_Builder_HTMLBody{root: b.root}
 }
 
-type MailContent_Builder_Body struct {
+type MailContent_Builder_TextBody struct {
 	root *MailContent
 }
 
-func (b MailContent_Builder_Title) Title(arg string) MailContent_Builder_Body {
-	b.root.Title = arg
-	return MailContent_Builder_Body{root: b.root}
+func (b MailContent_Builder_HTMLBody) HTMLBody(arg string) MailContent_Builder_TextBody {
+	b.root.HTMLBody = arg
+	return MailContent_Builder_TextBody{root: b.root}
+}
+
+type MailContent_Builder_ReplyTo struct {
+	root *MailContent
+}
+
+func (b MailContent_Builder_TextBody) TextBody(arg string) MailContent_Builder_ReplyTo {
+	b.root.TextBody = arg
+	return MailContent_Builder_ReplyTo{root: b.root}
+}
+
+type MailContent_Builder_Headers struct {
+	root *MailContent
+}
+
+func (b MailContent_Builder_ReplyTo) ReplyTo(arg *string) MailContent_Builder_Headers {
+	b.root.ReplyTo = arg
+	return MailContent_Builder_Headers{root: b.root}
+}
+
+type MailContent_Builder_Attachments struct {
+	root *MailContent
+}
+
+func (b MailContent_Builder_Headers) Headers(arg map[string]string) MailContent_Builder_Attachments {
+	b.root.Headers = arg
+	return MailContent_Builder_Attachments{root: b.root}
 }
 
 type MailContent_Builder_GobFinalizer struct {
 	root *MailContent
 }
 
-func (b MailContent_Builder_Body) Body(arg string) MailContent_Builder_GobFinalizer {
-	b.root.Body = arg
+func (b MailContent_Builder_Attachments) Attachments(arg []model.MailAttachment) MailContent_Builder_GobFinalizer {
+	b.root.Attachments = arg
 	return MailContent_Builder_GobFinalizer{root: b.root}
 }
 
@@ -0,0 +1,27 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// MFAPersist defines the outport interface for TOTP authenticators and recovery codes
+type MFAPersist interface {
+	// TOTP authenticators
+	GetUserMFA(ctx context.Context, tx pgx.Tx, userID string) (*model.UserMFA, error)
+	UpsertUserMFA(ctx context.Context, tx pgx.Tx, userID string, totpSecret string) (*model.UserMFA, error)
+	ConfirmUserMFA(ctx context.Context, tx pgx.Tx, userID string) error
+	UpdateUserMFALastUsedStep(ctx context.Context, tx pgx.Tx, userID string, step int64) (int64, error)
+	DeleteUserMFA(ctx context.Context, tx pgx.Tx, userID string) error
+
+	// Recovery codes
+	ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error
+	MarkRecoveryCodeAsUsed(ctx context.Context, tx pgx.Tx, userID string, codeHash string) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string) (int, error)
+
+	// MFA challenge tokens, returns 0 when the challenge was already used
+	MarkMFAChallengeUsed(ctx context.Context, tx pgx.Tx, tokenID string, expiresAt time.Time) (int64, error)
+}
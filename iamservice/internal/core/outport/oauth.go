@@ -0,0 +1,23 @@
+package outport
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// OAuthPersist defines the outport interface for registered OAuth clients and authorization codes
+type OAuthPersist interface {
+	// OAuth clients
+	GetOAuthClientByID(ctx context.Context, tx pgx.Tx, clientID string) (*model.OAuthClient, error)
+	GetOAuthClientsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.OAuthClient, error)
+	CreateOAuthClient(ctx context.Context, tx pgx.Tx, client *model.OAuthClient) error
+	// DeleteOAuthClient deletes the client with its authorization codes and sessions, returns IDs of the sessions
+	DeleteOAuthClient(ctx context.Context, tx pgx.Tx, clientID string) ([]string, error)
+
+	// Authorization codes
+	CreateOAuthAuthorizationCode(ctx context.Context, tx pgx.Tx, code *model.OAuthAuthorizationCode) (*model.OAuthAuthorizationCode, error)
+	GetOAuthAuthorizationCodeByHash(ctx context.Context, tx pgx.Tx, codeHash string) (*model.OAuthAuthorizationCode, error)
+	MarkOAuthAuthorizationCodeAsUsed(ctx context.Context, tx pgx.Tx, codeID string) (int64, error)
+}
@@ -0,0 +1,35 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// OutboxPersist defines the outport interface for the outbox of outgoing emails
+type OutboxPersist interface {
+	// CreateOutboxEmail queues an email, it becomes visible to the dispatcher once the transaction commits
+	CreateOutboxEmail(ctx context.Context, tx pgx.Tx, email *model.OutboxEmail) error
+	// ClaimDueOutboxEmails returns up to limit pending emails due for delivery at now and counts the delivery
+	// attempt. Next attempt of the claimed emails is moved to claimedUntil, so that they are skipped by other
+	// dispatchers while being sent and retried if the dispatcher stops before recording the result.
+	ClaimDueOutboxEmails(
+		ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+	) ([]*model.OutboxEmail, error)
+	MarkOutboxEmailAsSent(ctx context.Context, tx pgx.Tx, emailID string, sentAt time.Time) error
+	// MarkOutboxEmailAsFailed records a failed delivery attempt, the email is retried at nextAttemptAt or given
+	// up as dead if nextAttemptAt is nil
+	MarkOutboxEmailAsFailed(
+		ctx context.Context, tx pgx.Tx, emailID string, lastError string, nextAttemptAt *time.Time,
+	) error
+	// ListFailedOutboxEmails returns a page of undelivered emails with at least one failed delivery attempt,
+	// newest first, and the total number of such emails. Status narrows them down to pending or dead emails.
+	ListFailedOutboxEmails(
+		ctx context.Context, tx pgx.Tx, status *string, offset int, limit int,
+	) ([]*model.OutboxEmail, int, error)
+	// RetryOutboxEmail makes an undelivered email due for delivery at now with a new set of attempts,
+	// katapp.ErrNotFound is returned if there is no such undelivered email
+	RetryOutboxEmail(ctx context.Context, tx pgx.Tx, emailID string, now time.Time) error
+}
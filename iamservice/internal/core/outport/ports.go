@@ -3,8 +3,21 @@ package outport
 //go:generate go tool gobetter -input $GOFILE
 
 type Ports struct { //+gob:Constructor
-	AuthUserPersist    AuthUserPersist
-	UserProfilePersist UserProfilePersist
-	Tx                 TxPort
-	Mailer             Mailer
+	AuthUserPersist        AuthUserPersist
+	UserProfilePersist     UserProfilePersist
+	OAuthPersist           OAuthPersist
+	MFAPersist             MFAPersist
+	SignInThrottlePersist  SignInThrottlePersist
+	AuditPersist           AuditPersist
+	TokenRevocationPersist TokenRevocationPersist
+	ServiceClientPersist   ServiceClientPersist
+	APIKeyPersist          APIKeyPersist
+	RolePersist            RolePersist
+	InvitationPersist      InvitationPersist
+	OutboxPersist          OutboxPersist
+	WebhookPersist         WebhookPersist
+	Federation             FederationClient
+	Tx                     TxPort
+	Mailer                 Mailer
+	WebhookClient          WebhookClient
 }
@@ -19,12 +19,120 @@ func (b Ports_Builder_AuthUserPersist) AuthUserPersist(arg AuthUserPersist) Port
 	return Ports_Builder_UserProfilePersist{root: b.root}
 }
 
-type Ports_Builder_Tx struct {
+type Ports_Builder_OAuthPersist struct {
 	root *Ports
 }
 
-func (b Ports_Builder_UserProfilePersist) UserProfilePersist(arg UserProfilePersist) Ports_Builder_Tx {
+func (b Ports_Builder_UserProfilePersist) UserProfilePersist(arg UserProfilePersist) Ports_Builder_OAuthPersist {
 	b.root.UserProfilePersist = arg
+	return Ports_Builder_OAuthPersist{root: b.root}
+}
+
+type Ports_Builder_MFAPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_OAuthPersist) OAuthPersist(arg OAuthPersist) Ports_Builder_MFAPersist {
+	b.root.OAuthPersist = arg
+	return Ports_Builder_MFAPersist{root: b.root}
+}
+
+type Ports_Builder_SignInThrottlePersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_MFAPersist) MFAPersist(arg MFAPersist) Ports_Builder_SignInThrottlePersist {
+	b.root.MFAPersist = arg
+	return Ports_Builder_SignInThrottlePersist{root: b.root}
+}
+
+type Ports_Builder_AuditPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_SignInThrottlePersist) SignInThrottlePersist(arg SignInThrottlePersist) Ports_Builder_AuditPersist {
+	b.root.SignInThrottlePersist = arg
+	return Ports_Builder_AuditPersist{root: b.root}
+}
+
+type Ports_Builder_TokenRevocationPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_AuditPersist) AuditPersist(arg AuditPersist) Ports_Builder_TokenRevocationPersist {
+	b.root.AuditPersist = arg
+	return Ports_Builder_TokenRevocationPersist{root: b.root}
+}
+
+type Ports_Builder_ServiceClientPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_TokenRevocationPersist) TokenRevocationPersist(arg TokenRevocationPersist) Ports_Builder_ServiceClientPersist {
+	b.root.TokenRevocationPersist = arg
+	return Ports_Builder_ServiceClientPersist{root: b.root}
+}
+
+type Ports_Builder_APIKeyPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_ServiceClientPersist) ServiceClientPersist(arg ServiceClientPersist) Ports_Builder_APIKeyPersist {
+	b.root.ServiceClientPersist = arg
+	return Ports_Builder_APIKeyPersist{root: b.root}
+}
+
+type Ports_Builder_RolePersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_APIKeyPersist) APIKeyPersist(arg APIKeyPersist) Ports_Builder_RolePersist {
+	b.root.APIKeyPersist = arg
+	return Ports_Builder_RolePersist{root: b.root}
+}
+
+type Ports_Builder_InvitationPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_RolePersist) RolePersist(arg RolePersist) Ports_Builder_InvitationPersist {
+	b.root.RolePersist = arg
+	return Ports_Builder_InvitationPersist{root: b.root}
+}
+
+type Ports_Builder_OutboxPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_InvitationPersist) InvitationPersist(arg InvitationPersist) Ports_Builder_OutboxPersist {
+	b.root.InvitationPersist = arg
+	return Ports_Builder_OutboxPersist{root: b.root}
+}
+
+type Ports_Builder_WebhookPersist struct {
+	root *Ports
+}
+
+func (b Ports_Builder_OutboxPersist) OutboxPersist(arg OutboxPersist) Ports_Builder_WebhookPersist {
+	b.root.OutboxPersist = arg
+	return Ports_Builder_WebhookPersist{root: b.root}
+}
+
+type Ports_Builder_Federation struct {
+	root *Ports
+}
+
+func (b Ports_Builder_WebhookPersist) WebhookPersist(arg WebhookPersist) Ports_Builder_Federation {
+	b.root.WebhookPersist = arg
+	return Ports_Builder_Federation{root: b.root}
+}
+
+type Ports_Builder_Tx struct {
+	root *Ports
+}
+
+func (b Ports_Builder_Federation) Federation(arg FederationClient) Ports_Builder_Tx {
+	b.root.Federation = arg
 	return Ports_Builder_Tx{root: b.root}
 }
 
@@ -37,12 +145,21 @@ func (b Ports_Builder_Tx) Tx(arg TxPort) Ports_Builder_Mailer {
 	return Ports_Builder_Mailer{root: b.root}
 }
 
-type Ports_Builder_GobFinalizer struct {
+type Ports_Builder_WebhookClient struct {
 	root *Ports
 }
 
-func (b Ports_Builder_Mailer) Mailer(arg Mailer) Ports_Builder_GobFinalizer {
+func (b Ports_Builder_Mailer) Mailer(arg Mailer) Ports_Builder_WebhookClient {
 	b.root.Mailer = arg
+	return Ports_Builder_WebhookClient{root: b.root}
+}
+
+type Ports_Builder_GobFinalizer struct {
+	root *Ports
+}
+
+func (b Ports_Builder_WebhookClient) WebhookClient(arg WebhookClient) Ports_Builder_GobFinalizer {
+	b.root.WebhookClient = arg
 	return Ports_Builder_GobFinalizer{root: b.root}
 }
 
@@ -0,0 +1,27 @@
+package outport
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// RolePersist defines the outport interface for permissions and the roles bundling them
+type RolePersist interface {
+	GetPermissions(ctx context.Context, tx pgx.Tx) ([]*model.Permission, error)
+	// GetRolesByTenantID returns the system roles followed by the custom roles of the tenant
+	GetRolesByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.Role, error)
+	// GetRoleByName returns a system role or a custom role of the tenant, nil if there is none with the name
+	GetRoleByName(ctx context.Context, tx pgx.Tx, tenantID string, name string) (*model.Role, error)
+	// CreateRole creates a custom role, katapp.ErrInvalidInput is returned for unknown permissions
+	CreateRole(ctx context.Context, tx pgx.Tx, role *model.Role) error
+	// UpdateRole updates description and permissions of a role, katapp.ErrInvalidInput is returned for unknown
+	// permissions
+	UpdateRole(ctx context.Context, tx pgx.Tx, role *model.Role) error
+	DeleteRole(ctx context.Context, tx pgx.Tx, roleID int) error
+	// GetPermissionsByRoles returns the permissions granted by the roles in the tenant
+	GetPermissionsByRoles(ctx context.Context, tx pgx.Tx, tenantID string, roles []string) ([]string, error)
+	GetUserIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error)
+	GetServiceClientIDsByRoleID(ctx context.Context, tx pgx.Tx, roleID int) ([]string, error)
+}
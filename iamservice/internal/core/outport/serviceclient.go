@@ -0,0 +1,17 @@
+package outport
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// ServiceClientPersist defines the outport interface for machine clients of the client_credentials grant
+type ServiceClientPersist interface {
+	// CreateServiceClient creates the client with its roles, katapp.ErrNotFound is returned for unknown roles
+	CreateServiceClient(ctx context.Context, tx pgx.Tx, client *model.ServiceClient) error
+	GetServiceClientByID(ctx context.Context, tx pgx.Tx, clientID string) (*model.ServiceClient, error)
+	GetServiceClientsByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.ServiceClient, error)
+	DeleteServiceClient(ctx context.Context, tx pgx.Tx, clientID string) error
+}
@@ -0,0 +1,17 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// SignInThrottlePersist defines the outport interface for tracking failed sign in attempts per user and source IP
+type SignInThrottlePersist interface {
+	GetSignInThrottle(ctx context.Context, tx pgx.Tx, subjectType string, subject string) (*model.SignInThrottle, error)
+	RecordSignInFailure(ctx context.Context, tx pgx.Tx, subjectType string, subject string, window time.Duration) (int, error)
+	LockSignInSubject(ctx context.Context, tx pgx.Tx, subjectType string, subject string, lockedUntil time.Time, lockoutCount int) error
+	ClearSignInThrottle(ctx context.Context, tx pgx.Tx, subjectType string, subject string) error
+}
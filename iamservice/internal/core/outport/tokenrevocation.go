@@ -0,0 +1,19 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// TokenRevocationPersist defines the outport interface for the access token revocation list
+type TokenRevocationPersist interface {
+	// RevokeAccessTokens records the revocation, an existing revocation of the same subject is extended
+	RevokeAccessTokens(ctx context.Context, tx pgx.Tx, revocation *model.AccessTokenRevocation) error
+	// IsAccessTokenRevoked checks if a token with the given ID, session and subject (user or service client)
+	// issued at issuedAt is revoked
+	IsAccessTokenRevoked(ctx context.Context, tx pgx.Tx, jti string, sessionID string, subject string, issuedAt time.Time) (bool, error)
+	DeleteExpiredAccessTokenRevocations(ctx context.Context, tx pgx.Tx) (int64, error)
+}
@@ -0,0 +1,55 @@
+package outport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// WebhookPersist defines the outport interface for webhook subscriptions and the deliveries of their events
+type WebhookPersist interface {
+	CreateWebhook(ctx context.Context, tx pgx.Tx, webhook *model.Webhook) error
+	GetWebhooksByTenantID(ctx context.Context, tx pgx.Tx, tenantID string) ([]*model.Webhook, error)
+	GetWebhookByID(ctx context.Context, tx pgx.Tx, tenantID string, webhookID string) (*model.Webhook, error)
+	// GetWebhooksByEventType returns the webhooks of the tenant subscribed to the event type, inactive ones too
+	GetWebhooksByEventType(ctx context.Context, tx pgx.Tx, tenantID string, eventType string) ([]*model.Webhook, error)
+	// UpdateWebhook stores URL, secret, event types and active state of a webhook, katapp.ErrNotFound is returned
+	// if the tenant has no such webhook
+	UpdateWebhook(ctx context.Context, tx pgx.Tx, webhook *model.Webhook) error
+	// DeleteWebhook deletes a webhook with its deliveries, katapp.ErrNotFound is returned if the tenant has no
+	// such webhook
+	DeleteWebhook(ctx context.Context, tx pgx.Tx, tenantID string, webhookID string) error
+	// CreateWebhookDeliveries queues events, they become visible to the dispatcher once the transaction commits
+	CreateWebhookDeliveries(ctx context.Context, tx pgx.Tx, deliveries []*model.WebhookDelivery) error
+	// ClaimDueWebhookDeliveries returns up to limit pending deliveries of active webhooks due at now and counts
+	// the delivery attempt. Next attempt of the claimed deliveries is moved to claimedUntil, like the one of
+	// claimed outgoing emails.
+	ClaimDueWebhookDeliveries(
+		ctx context.Context, tx pgx.Tx, now time.Time, claimedUntil time.Time, limit int,
+	) ([]*model.DueWebhookDelivery, error)
+	MarkWebhookDeliveryAsDelivered(
+		ctx context.Context, tx pgx.Tx, deliveryID string, statusCode int, deliveredAt time.Time,
+	) error
+	// MarkWebhookDeliveryAsFailed records a failed delivery attempt, the delivery is retried at nextAttemptAt or
+	// given up as dead if nextAttemptAt is nil. Status code is nil if no response was received.
+	MarkWebhookDeliveryAsFailed(
+		ctx context.Context, tx pgx.Tx, deliveryID string, statusCode *int, lastError string, nextAttemptAt *time.Time,
+	) error
+	// ListWebhookDeliveries returns a page of deliveries of a webhook, newest first, and the total number of its
+	// deliveries. Status narrows them down to deliveries in that state.
+	ListWebhookDeliveries(
+		ctx context.Context, tx pgx.Tx, webhookID string, status *string, offset int, limit int,
+	) ([]*model.WebhookDelivery, int, error)
+	GetWebhookDeliveryByID(
+		ctx context.Context, tx pgx.Tx, webhookID string, deliveryID string,
+	) (*model.WebhookDelivery, error)
+}
+
+// WebhookClient posts events to webhooks
+type WebhookClient interface {
+	// PostWebhook posts the body with the headers to the URL and returns the HTTP status of the response, an
+	// error is returned if no response was received
+	PostWebhook(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
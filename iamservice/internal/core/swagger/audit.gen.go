@@ -0,0 +1,95 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// AuditChange Old and new value of a changed field
+type AuditChange struct {
+	// New Value after the change, absent for deleted values
+	New *interface{} `json:"new,omitempty"`
+
+	// Old Value before the change, absent for created values
+	Old *interface{} `json:"old,omitempty"`
+}
+
+// AuditEventResponse Audit event
+type AuditEventResponse struct {
+	// Action Performed action
+	Action string `json:"action"`
+
+	// ActorEmail Email of the actor
+	ActorEmail *string `json:"actorEmail"`
+
+	// ActorRoles Roles of the actor at the time of the action
+	ActorRoles []string `json:"actorRoles"`
+
+	// ActorTenantId Tenant of the actor
+	ActorTenantId *string `json:"actorTenantId"`
+
+	// ActorUserId User who performed the action, null for unauthenticated actors
+	ActorUserId *string `json:"actorUserId"`
+
+	// CreatedAt Event timestamp
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Diff Changed fields
+	Diff map[string]AuditChange `json:"diff"`
+
+	// Id Audit event unique identifier
+	Id string `json:"id"`
+
+	// IpAddress IP address of the client
+	IpAddress *string `json:"ipAddress"`
+
+	// Reason Failure reason
+	Reason *string `json:"reason"`
+
+	// TargetId Identifier of the affected target
+	TargetId *string `json:"targetId"`
+
+	// TargetType Type of the affected target: user, tenant, service_client, oauth_client, role or email
+	TargetType *string `json:"targetType"`
+
+	// TenantId Tenant the event belongs to
+	TenantId *string `json:"tenantId"`
+
+	// UserAgent User agent of the client
+	UserAgent *string `json:"userAgent"`
+}
+
+// AuditEventsResponse defines model for AuditEventsResponse.
+type AuditEventsResponse struct {
+	Items      []AuditEventResponse `json:"items"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// Page Page number for pagination
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of events per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// TenantId Only events of this tenant (requires audit:read permission in all tenants, others are limited to their own tenant)
+	TenantId *string `form:"tenantId,omitempty" json:"tenantId,omitempty"`
+
+	// Action Only events with this action, e.g. user.role_assigned
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// ActorUserId Only events performed by this user
+	ActorUserId *string `form:"actorUserId,omitempty" json:"actorUserId,omitempty"`
+
+	// TargetId Only events affecting this user, tenant, service client or email
+	TargetId *string `form:"targetId,omitempty" json:"targetId,omitempty"`
+
+	// From Only events at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only events before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
@@ -0,0 +1,289 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewAuditChangeBuilder() AuditChange_Builder_New {
+	return AuditChange_Builder_New{root: &AuditChange{}}
+}
+
+type AuditChange_Builder_New struct {
+	root *AuditChange
+}
+
+type AuditChange_Builder_Old struct {
+	root *AuditChange
+}
+
+func (b AuditChange_Builder_New) New(arg *interface{}) AuditChange_Builder_Old {
+	b.root.New = arg
+	return AuditChange_Builder_Old{root: b.root}
+}
+
+type AuditChange_Builder_GobFinalizer struct {
+	root *AuditChange
+}
+
+func (b AuditChange_Builder_Old) Old(arg *interface{}) AuditChange_Builder_GobFinalizer {
+	b.root.Old = arg
+	return AuditChange_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditChange_Builder_GobFinalizer) Build() *AuditChange {
+	return b.root
+}
+
+func NewAuditEventResponseBuilder() AuditEventResponse_Builder_Action {
+	return AuditEventResponse_Builder_Action{root: &AuditEventResponse{}}
+}
+
+type AuditEventResponse_Builder_Action struct {
+	root *AuditEventResponse
+}
+
+type AuditEventResponse_Builder_ActorEmail struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_Action) Action(arg string) AuditEventResponse_Builder_ActorEmail {
+	b.root.Action = arg
+	return AuditEventResponse_Builder_ActorEmail{root: b.root}
+}
+
+type AuditEventResponse_Builder_ActorRoles struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_ActorEmail) ActorEmail(arg *string) AuditEventResponse_Builder_ActorRoles {
+	b.root.ActorEmail = arg
+	return AuditEventResponse_Builder_ActorRoles{root: b.root}
+}
+
+type AuditEventResponse_Builder_ActorTenantId struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_ActorRoles) ActorRoles(arg []string) AuditEventResponse_Builder_ActorTenantId {
+	b.root.ActorRoles = arg
+	return AuditEventResponse_Builder_ActorTenantId{root: b.root}
+}
+
+type AuditEventResponse_Builder_ActorUserId struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_ActorTenantId) ActorTenantId(arg *string) AuditEventResponse_Builder_ActorUserId {
+	b.root.ActorTenantId = arg
+	return AuditEventResponse_Builder_ActorUserId{root: b.root}
+}
+
+type AuditEventResponse_Builder_CreatedAt struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_ActorUserId) ActorUserId(arg *string) AuditEventResponse_Builder_CreatedAt {
+	b.root.ActorUserId = arg
+	return AuditEventResponse_Builder_CreatedAt{root: b.root}
+}
+
+type AuditEventResponse_Builder_Diff struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_CreatedAt) CreatedAt(arg time.Time) AuditEventResponse_Builder_Diff {
+	b.root.CreatedAt = arg
+	return AuditEventResponse_Builder_Diff{root: b.root}
+}
+
+type AuditEventResponse_Builder_Id struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_Diff) Diff(arg map[string]AuditChange) AuditEventResponse_Builder_Id {
+	b.root.Diff = arg
+	return AuditEventResponse_Builder_Id{root: b.root}
+}
+
+type AuditEventResponse_Builder_IpAddress struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_Id) Id(arg string) AuditEventResponse_Builder_IpAddress {
+	b.root.Id = arg
+	return AuditEventResponse_Builder_IpAddress{root: b.root}
+}
+
+type AuditEventResponse_Builder_Reason struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_IpAddress) IpAddress(arg *string) AuditEventResponse_Builder_Reason {
+	b.root.IpAddress = arg
+	return AuditEventResponse_Builder_Reason{root: b.root}
+}
+
+type AuditEventResponse_Builder_TargetId struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_Reason) Reason(arg *string) AuditEventResponse_Builder_TargetId {
+	b.root.Reason = arg
+	return AuditEventResponse_Builder_TargetId{root: b.root}
+}
+
+type AuditEventResponse_Builder_TargetType struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_TargetId) TargetId(arg *string) AuditEventResponse_Builder_TargetType {
+	b.root.TargetId = arg
+	return AuditEventResponse_Builder_TargetType{root: b.root}
+}
+
+type AuditEventResponse_Builder_TenantId struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_TargetType) TargetType(arg *string) AuditEventResponse_Builder_TenantId {
+	b.root.TargetType = arg
+	return AuditEventResponse_Builder_TenantId{root: b.root}
+}
+
+type AuditEventResponse_Builder_UserAgent struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_TenantId) TenantId(arg *string) AuditEventResponse_Builder_UserAgent {
+	b.root.TenantId = arg
+	return AuditEventResponse_Builder_UserAgent{root: b.root}
+}
+
+type AuditEventResponse_Builder_GobFinalizer struct {
+	root *AuditEventResponse
+}
+
+func (b AuditEventResponse_Builder_UserAgent) UserAgent(arg *string) AuditEventResponse_Builder_GobFinalizer {
+	b.root.UserAgent = arg
+	return AuditEventResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditEventResponse_Builder_GobFinalizer) Build() *AuditEventResponse {
+	return b.root
+}
+
+func NewAuditEventsResponseBuilder() AuditEventsResponse_Builder_Items {
+	return AuditEventsResponse_Builder_Items{root: &AuditEventsResponse{}}
+}
+
+type AuditEventsResponse_Builder_Items struct {
+	root *AuditEventsResponse
+}
+
+type AuditEventsResponse_Builder_Pagination struct {
+	root *AuditEventsResponse
+}
+
+func (b AuditEventsResponse_Builder_Items) Items(arg []AuditEventResponse) AuditEventsResponse_Builder_Pagination {
+	b.root.Items = arg
+	return AuditEventsResponse_Builder_Pagination{root: b.root}
+}
+
+type AuditEventsResponse_Builder_GobFinalizer struct {
+	root *AuditEventsResponse
+}
+
+func (b AuditEventsResponse_Builder_Pagination) Pagination(arg PaginationInfo) AuditEventsResponse_Builder_GobFinalizer {
+	b.root.Pagination = arg
+	return AuditEventsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuditEventsResponse_Builder_GobFinalizer) Build() *AuditEventsResponse {
+	return b.root
+}
+
+func NewListAuditEventsParamsBuilder() ListAuditEventsParams_Builder_Page {
+	return ListAuditEventsParams_Builder_Page{root: &ListAuditEventsParams{}}
+}
+
+type ListAuditEventsParams_Builder_Page struct {
+	root *ListAuditEventsParams
+}
+
+type ListAuditEventsParams_Builder_Limit struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_Page) Page(arg *int) ListAuditEventsParams_Builder_Limit {
+	b.root.Page = arg
+	return ListAuditEventsParams_Builder_Limit{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_TenantId struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_Limit) Limit(arg *int) ListAuditEventsParams_Builder_TenantId {
+	b.root.Limit = arg
+	return ListAuditEventsParams_Builder_TenantId{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_Action struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_TenantId) TenantId(arg *string) ListAuditEventsParams_Builder_Action {
+	b.root.TenantId = arg
+	return ListAuditEventsParams_Builder_Action{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_ActorUserId struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_Action) Action(arg *string) ListAuditEventsParams_Builder_ActorUserId {
+	b.root.Action = arg
+	return ListAuditEventsParams_Builder_ActorUserId{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_TargetId struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_ActorUserId) ActorUserId(arg *string) ListAuditEventsParams_Builder_TargetId {
+	b.root.ActorUserId = arg
+	return ListAuditEventsParams_Builder_TargetId{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_From struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_TargetId) TargetId(arg *string) ListAuditEventsParams_Builder_From {
+	b.root.TargetId = arg
+	return ListAuditEventsParams_Builder_From{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_To struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_From) From(arg *time.Time) ListAuditEventsParams_Builder_To {
+	b.root.From = arg
+	return ListAuditEventsParams_Builder_To{root: b.root}
+}
+
+type ListAuditEventsParams_Builder_GobFinalizer struct {
+	root *ListAuditEventsParams
+}
+
+func (b ListAuditEventsParams_Builder_To) To(arg *time.Time) ListAuditEventsParams_Builder_GobFinalizer {
+	b.root.To = arg
+	return ListAuditEventsParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListAuditEventsParams_Builder_GobFinalizer) Build() *ListAuditEventsParams {
+	return b.root
+}
@@ -3,6 +3,10 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package swagger
 
+import (
+	"time"
+)
+
 // Defines values for SignUpRequestSource.
 const (
 	Android SignUpRequestSource = "android"
@@ -10,6 +14,40 @@ const (
 	Web     SignUpRequestSource = "web"
 )
 
+// AccessTokenRevocationRequest Request payload for revoking all access tokens
+type AccessTokenRevocationRequest struct {
+	// IssuedBefore Access tokens issued before this time are rejected, defaults to now
+	IssuedBefore *time.Time `json:"issuedBefore"`
+}
+
+// ClientCredentialsTokenRequest Token request of the client_credentials grant (RFC 6749 section 4.4)
+type ClientCredentialsTokenRequest struct {
+	// ClientId Client ID, when not using HTTP Basic authentication
+	ClientId *string `json:"client_id,omitempty"`
+
+	// ClientSecret Client secret, when not using HTTP Basic authentication
+	ClientSecret *string `json:"client_secret,omitempty"`
+
+	// GrantType Must be client_credentials
+	GrantType string `json:"grant_type"`
+
+	// Scope Space-delimited scopes, defaults to all scopes of the client
+	Scope *string `json:"scope,omitempty"`
+}
+
+// ClientCredentialsTokenResponse Access token issued to a service client
+type ClientCredentialsTokenResponse struct {
+	// AccessToken JWT access token
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Access token expiration time in seconds
+	ExpiresIn int64 `json:"expires_in"`
+
+	// Scope Space-delimited granted scopes
+	Scope     string `json:"scope"`
+	TokenType string `json:"token_type"`
+}
+
 // EmailConfirmationRequest Request payload for email confirmation
 type EmailConfirmationRequest struct {
 	// Code Email confirmation code (6-digit for mobile, long token for web)
@@ -25,6 +63,63 @@ type EmailConfirmationResponse struct {
 	Message string `json:"message"`
 }
 
+// ForgotPasswordRequest Request payload for initiating password reset
+type ForgotPasswordRequest struct {
+	// Email User email address
+	Email string `json:"email"`
+
+	// Source Platform source (web, android or ios): web receives a reset link, mobile receives a 6-digit code
+	Source string `json:"source"`
+
+	// TenantId Tenant identifier for multi-tenant support
+	TenantId string `json:"tenantId"`
+}
+
+// ForgotPasswordResponse Response after password reset request
+type ForgotPasswordResponse struct {
+	// Message Success message
+	Message string `json:"message"`
+}
+
+// ResendConfirmationRequest Request payload for resending the email confirmation code
+type ResendConfirmationRequest struct {
+	// Email User email address
+	Email string `json:"email"`
+
+	// Source Platform source (web, android or ios): web receives a confirmation link, mobile receives a 6-digit code
+	Source string `json:"source"`
+
+	// TenantId Tenant identifier for multi-tenant support
+	TenantId string `json:"tenantId"`
+}
+
+// ResendConfirmationResponse Response after resend confirmation request
+type ResendConfirmationResponse struct {
+	// Message Success message
+	Message string `json:"message"`
+}
+
+// ResetPasswordRequest Request payload for resetting password
+type ResetPasswordRequest struct {
+	// Code Password reset code (6-digit for mobile, long token for web)
+	Code string `json:"code"`
+
+	// Email User email address
+	Email string `json:"email"`
+
+	// NewPassword New password (minimum 8 characters, will be hashed)
+	NewPassword string `json:"newPassword"`
+
+	// TenantId Tenant identifier for multi-tenant support
+	TenantId string `json:"tenantId"`
+}
+
+// ResetPasswordResponse Response after successful password reset
+type ResetPasswordResponse struct {
+	// Message Success message
+	Message string `json:"message"`
+}
+
 // SignInRequest defines model for SignInRequest.
 type SignInRequest struct {
 	// Email User email address
@@ -33,6 +128,9 @@ type SignInRequest struct {
 	// Password User password
 	Password string `json:"password"`
 
+	// Source Platform source (web, android or ios) recorded with the session, defaults to web
+	Source *string `json:"source,omitempty"`
+
 	// TenantId Tenant identifier for multi-tenant support
 	TenantId string `json:"tenantId"`
 }
@@ -91,6 +189,58 @@ type SignUpResponse struct {
 	UserId string `json:"userId"`
 }
 
+// TokenIntrospectionRequest Token introspection request (RFC 7662)
+type TokenIntrospectionRequest struct {
+	// ClientId Client ID, when not using HTTP Basic authentication
+	ClientId *string `json:"client_id,omitempty"`
+
+	// ClientSecret Client secret, when not using HTTP Basic authentication
+	ClientSecret *string `json:"client_secret,omitempty"`
+
+	// Token Access token to introspect
+	Token string `json:"token"`
+
+	// TokenTypeHint Type of the token, only access tokens can be introspected
+	TokenTypeHint *string `json:"token_type_hint,omitempty"`
+}
+
+// TokenIntrospectionResponse Token introspection response (RFC 7662), only "active" is returned for inactive tokens
+type TokenIntrospectionResponse struct {
+	// Active Whether the token is valid and not revoked
+	Active bool `json:"active"`
+
+	// ClientId Service client the token was issued to, absent for user tokens
+	ClientId *string `json:"client_id,omitempty"`
+
+	// Exp Expiration time (seconds since epoch)
+	Exp *int64 `json:"exp,omitempty"`
+
+	// Iat Issue time (seconds since epoch)
+	Iat *int64 `json:"iat,omitempty"`
+
+	// Jti Token identifier
+	Jti *string `json:"jti,omitempty"`
+
+	// Permissions Permissions granted by the roles when the token was issued
+	Permissions *[]string `json:"permissions,omitempty"`
+
+	// Roles Roles of the user or service client when the token was issued
+	Roles *[]string `json:"roles,omitempty"`
+
+	// Scope Space-delimited scopes granted to a service client
+	Scope *string `json:"scope,omitempty"`
+
+	// Sid Session the token was issued for
+	Sid *string `json:"sid,omitempty"`
+
+	// Sub User or service client the token was issued to
+	Sub *string `json:"sub,omitempty"`
+
+	// TenantId Tenant of the user or service client
+	TenantId  *string `json:"tenantId,omitempty"`
+	TokenType *string `json:"token_type,omitempty"`
+}
+
 // TokenRefreshRequest defines model for TokenRefreshRequest.
 type TokenRefreshRequest struct {
 	// RefreshToken Refresh token
@@ -100,11 +250,29 @@ type TokenRefreshRequest struct {
 // ConfirmEmailJSONRequestBody defines body for ConfirmEmail for application/json ContentType.
 type ConfirmEmailJSONRequestBody = EmailConfirmationRequest
 
+// ForgotPasswordJSONRequestBody defines body for ForgotPassword for application/json ContentType.
+type ForgotPasswordJSONRequestBody = ForgotPasswordRequest
+
+// IntrospectTokenFormdataRequestBody defines body for IntrospectToken for application/x-www-form-urlencoded ContentType.
+type IntrospectTokenFormdataRequestBody = TokenIntrospectionRequest
+
 // RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
 type RefreshTokenJSONRequestBody = TokenRefreshRequest
 
+// ResendConfirmationJSONRequestBody defines body for ResendConfirmation for application/json ContentType.
+type ResendConfirmationJSONRequestBody = ResendConfirmationRequest
+
+// ResetPasswordJSONRequestBody defines body for ResetPassword for application/json ContentType.
+type ResetPasswordJSONRequestBody = ResetPasswordRequest
+
+// RevokeAccessTokensJSONRequestBody defines body for RevokeAccessTokens for application/json ContentType.
+type RevokeAccessTokensJSONRequestBody = AccessTokenRevocationRequest
+
 // SignInJSONRequestBody defines body for SignIn for application/json ContentType.
 type SignInJSONRequestBody = SignInRequest
 
 // SignUpJSONRequestBody defines body for SignUp for application/json ContentType.
 type SignUpJSONRequestBody = SignUpRequest
+
+// IssueClientCredentialsTokenFormdataRequestBody defines body for IssueClientCredentialsToken for application/x-www-form-urlencoded ContentType.
+type IssueClientCredentialsTokenFormdataRequestBody = ClientCredentialsTokenRequest
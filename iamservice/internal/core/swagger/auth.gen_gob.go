@@ -2,6 +2,127 @@
 
 package swagger
 
+import (
+	"time"
+)
+
+func NewAccessTokenRevocationRequestBuilder() AccessTokenRevocationRequest_Builder_IssuedBefore {
+	return AccessTokenRevocationRequest_Builder_IssuedBefore{root: &AccessTokenRevocationRequest{}}
+}
+
+type AccessTokenRevocationRequest_Builder_IssuedBefore struct {
+	root *AccessTokenRevocationRequest
+}
+
+type AccessTokenRevocationRequest_Builder_GobFinalizer struct {
+	root *AccessTokenRevocationRequest
+}
+
+func (b AccessTokenRevocationRequest_Builder_IssuedBefore) IssuedBefore(arg *time.Time) AccessTokenRevocationRequest_Builder_GobFinalizer {
+	b.root.IssuedBefore = arg
+	return AccessTokenRevocationRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AccessTokenRevocationRequest_Builder_GobFinalizer) Build() *AccessTokenRevocationRequest {
+	return b.root
+}
+
+func NewClientCredentialsTokenRequestBuilder() ClientCredentialsTokenRequest_Builder_ClientId {
+	return ClientCredentialsTokenRequest_Builder_ClientId{root: &ClientCredentialsTokenRequest{}}
+}
+
+type ClientCredentialsTokenRequest_Builder_ClientId struct {
+	root *ClientCredentialsTokenRequest
+}
+
+type ClientCredentialsTokenRequest_Builder_ClientSecret struct {
+	root *ClientCredentialsTokenRequest
+}
+
+func (b ClientCredentialsTokenRequest_Builder_ClientId) ClientId(arg *string) ClientCredentialsTokenRequest_Builder_ClientSecret {
+	b.root.ClientId = arg
+	return ClientCredentialsTokenRequest_Builder_ClientSecret{root: b.root}
+}
+
+type ClientCredentialsTokenRequest_Builder_GrantType struct {
+	root *ClientCredentialsTokenRequest
+}
+
+func (b ClientCredentialsTokenRequest_Builder_ClientSecret) ClientSecret(arg *string) ClientCredentialsTokenRequest_Builder_GrantType {
+	b.root.ClientSecret = arg
+	return ClientCredentialsTokenRequest_Builder_GrantType{root: b.root}
+}
+
+type ClientCredentialsTokenRequest_Builder_Scope struct {
+	root *ClientCredentialsTokenRequest
+}
+
+func (b ClientCredentialsTokenRequest_Builder_GrantType) GrantType(arg string) ClientCredentialsTokenRequest_Builder_Scope {
+	b.root.GrantType = arg
+	return ClientCredentialsTokenRequest_Builder_Scope{root: b.root}
+}
+
+type ClientCredentialsTokenRequest_Builder_GobFinalizer struct {
+	root *ClientCredentialsTokenRequest
+}
+
+func (b ClientCredentialsTokenRequest_Builder_Scope) Scope(arg *string) ClientCredentialsTokenRequest_Builder_GobFinalizer {
+	b.root.Scope = arg
+	return ClientCredentialsTokenRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ClientCredentialsTokenRequest_Builder_GobFinalizer) Build() *ClientCredentialsTokenRequest {
+	return b.root
+}
+
+func NewClientCredentialsTokenResponseBuilder() ClientCredentialsTokenResponse_Builder_AccessToken {
+	return ClientCredentialsTokenResponse_Builder_AccessToken{root: &ClientCredentialsTokenResponse{}}
+}
+
+type ClientCredentialsTokenResponse_Builder_AccessToken struct {
+	root *ClientCredentialsTokenResponse
+}
+
+type ClientCredentialsTokenResponse_Builder_ExpiresIn struct {
+	root *ClientCredentialsTokenResponse
+}
+
+func (b ClientCredentialsTokenResponse_Builder_AccessToken) AccessToken(arg string) ClientCredentialsTokenResponse_Builder_ExpiresIn {
+	b.root.AccessToken = arg
+	return ClientCredentialsTokenResponse_Builder_ExpiresIn{root: b.root}
+}
+
+type ClientCredentialsTokenResponse_Builder_Scope struct {
+	root *ClientCredentialsTokenResponse
+}
+
+func (b ClientCredentialsTokenResponse_Builder_ExpiresIn) ExpiresIn(arg int64) ClientCredentialsTokenResponse_Builder_Scope {
+	b.root.ExpiresIn = arg
+	return ClientCredentialsTokenResponse_Builder_Scope{root: b.root}
+}
+
+type ClientCredentialsTokenResponse_Builder_TokenType struct {
+	root *ClientCredentialsTokenResponse
+}
+
+func (b ClientCredentialsTokenResponse_Builder_Scope) Scope(arg string) ClientCredentialsTokenResponse_Builder_TokenType {
+	b.root.Scope = arg
+	return ClientCredentialsTokenResponse_Builder_TokenType{root: b.root}
+}
+
+type ClientCredentialsTokenResponse_Builder_GobFinalizer struct {
+	root *ClientCredentialsTokenResponse
+}
+
+func (b ClientCredentialsTokenResponse_Builder_TokenType) TokenType(arg string) ClientCredentialsTokenResponse_Builder_GobFinalizer {
+	b.root.TokenType = arg
+	return ClientCredentialsTokenResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ClientCredentialsTokenResponse_Builder_GobFinalizer) Build() *ClientCredentialsTokenResponse {
+	return b.root
+}
+
 func NewEmailConfirmationRequestBuilder() EmailConfirmationRequest_Builder_Code {
 	return EmailConfirmationRequest_Builder_Code{root: &EmailConfirmationRequest{}}
 }
@@ -53,6 +174,195 @@ func (b EmailConfirmationResponse_Builder_GobFinalizer) Build() *EmailConfirmati
 	return b.root
 }
 
+func NewForgotPasswordRequestBuilder() ForgotPasswordRequest_Builder_Email {
+	return ForgotPasswordRequest_Builder_Email{root: &ForgotPasswordRequest{}}
+}
+
+type ForgotPasswordRequest_Builder_Email struct {
+	root *ForgotPasswordRequest
+}
+
+type ForgotPasswordRequest_Builder_Source struct {
+	root *ForgotPasswordRequest
+}
+
+func (b ForgotPasswordRequest_Builder_Email) Email(arg string) ForgotPasswordRequest_Builder_Source {
+	b.root.Email = arg
+	return ForgotPasswordRequest_Builder_Source{root: b.root}
+}
+
+type ForgotPasswordRequest_Builder_TenantId struct {
+	root *ForgotPasswordRequest
+}
+
+func (b ForgotPasswordRequest_Builder_Source) Source(arg string) ForgotPasswordRequest_Builder_TenantId {
+	b.root.Source = arg
+	return ForgotPasswordRequest_Builder_TenantId{root: b.root}
+}
+
+type ForgotPasswordRequest_Builder_GobFinalizer struct {
+	root *ForgotPasswordRequest
+}
+
+func (b ForgotPasswordRequest_Builder_TenantId) TenantId(arg string) ForgotPasswordRequest_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ForgotPasswordRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ForgotPasswordRequest_Builder_GobFinalizer) Build() *ForgotPasswordRequest {
+	return b.root
+}
+
+func NewForgotPasswordResponseBuilder() ForgotPasswordResponse_Builder_Message {
+	return ForgotPasswordResponse_Builder_Message{root: &ForgotPasswordResponse{}}
+}
+
+type ForgotPasswordResponse_Builder_Message struct {
+	root *ForgotPasswordResponse
+}
+
+type ForgotPasswordResponse_Builder_GobFinalizer struct {
+	root *ForgotPasswordResponse
+}
+
+func (b ForgotPasswordResponse_Builder_Message) Message(arg string) ForgotPasswordResponse_Builder_GobFinalizer {
+	b.root.Message = arg
+	return ForgotPasswordResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ForgotPasswordResponse_Builder_GobFinalizer) Build() *ForgotPasswordResponse {
+	return b.root
+}
+
+func NewResendConfirmationRequestBuilder() ResendConfirmationRequest_Builder_Email {
+	return ResendConfirmationRequest_Builder_Email{root: &ResendConfirmationRequest{}}
+}
+
+type ResendConfirmationRequest_Builder_Email struct {
+	root *ResendConfirmationRequest
+}
+
+type ResendConfirmationRequest_Builder_Source struct {
+	root *ResendConfirmationRequest
+}
+
+func (b ResendConfirmationRequest_Builder_Email) Email(arg string) ResendConfirmationRequest_Builder_Source {
+	b.root.Email = arg
+	return ResendConfirmationRequest_Builder_Source{root: b.root}
+}
+
+type ResendConfirmationRequest_Builder_TenantId struct {
+	root *ResendConfirmationRequest
+}
+
+func (b ResendConfirmationRequest_Builder_Source) Source(arg string) ResendConfirmationRequest_Builder_TenantId {
+	b.root.Source = arg
+	return ResendConfirmationRequest_Builder_TenantId{root: b.root}
+}
+
+type ResendConfirmationRequest_Builder_GobFinalizer struct {
+	root *ResendConfirmationRequest
+}
+
+func (b ResendConfirmationRequest_Builder_TenantId) TenantId(arg string) ResendConfirmationRequest_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ResendConfirmationRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ResendConfirmationRequest_Builder_GobFinalizer) Build() *ResendConfirmationRequest {
+	return b.root
+}
+
+func NewResendConfirmationResponseBuilder() ResendConfirmationResponse_Builder_Message {
+	return ResendConfirmationResponse_Builder_Message{root: &ResendConfirmationResponse{}}
+}
+
+type ResendConfirmationResponse_Builder_Message struct {
+	root *ResendConfirmationResponse
+}
+
+type ResendConfirmationResponse_Builder_GobFinalizer struct {
+	root *ResendConfirmationResponse
+}
+
+func (b ResendConfirmationResponse_Builder_Message) Message(arg string) ResendConfirmationResponse_Builder_GobFinalizer {
+	b.root.Message = arg
+	return ResendConfirmationResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ResendConfirmationResponse_Builder_GobFinalizer) Build() *ResendConfirmationResponse {
+	return b.root
+}
+
+func NewResetPasswordRequestBuilder() ResetPasswordRequest_Builder_Code {
+	return ResetPasswordRequest_Builder_Code{root: &ResetPasswordRequest{}}
+}
+
+type ResetPasswordRequest_Builder_Code struct {
+	root *ResetPasswordRequest
+}
+
+type ResetPasswordRequest_Builder_Email struct {
+	root *ResetPasswordRequest
+}
+
+func (b ResetPasswordRequest_Builder_Code) Code(arg string) ResetPasswordRequest_Builder_Email {
+	b.root.Code = arg
+	return ResetPasswordRequest_Builder_Email{root: b.root}
+}
+
+type ResetPasswordRequest_Builder_NewPassword struct {
+	root *ResetPasswordRequest
+}
+
+func (b ResetPasswordRequest_Builder_Email) Email(arg string) ResetPasswordRequest_Builder_NewPassword {
+	b.root.Email = arg
+	return ResetPasswordRequest_Builder_NewPassword{root: b.root}
+}
+
+type ResetPasswordRequest_Builder_TenantId struct {
+	root *ResetPasswordRequest
+}
+
+func (b ResetPasswordRequest_Builder_NewPassword) NewPassword(arg string) ResetPasswordRequest_Builder_TenantId {
+	b.root.NewPassword = arg
+	return ResetPasswordRequest_Builder_TenantId{root: b.root}
+}
+
+type ResetPasswordRequest_Builder_GobFinalizer struct {
+	root *ResetPasswordRequest
+}
+
+func (b ResetPasswordRequest_Builder_TenantId) TenantId(arg string) ResetPasswordRequest_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ResetPasswordRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ResetPasswordRequest_Builder_GobFinalizer) Build() *ResetPasswordRequest {
+	return b.root
+}
+
+func NewResetPasswordResponseBuilder() ResetPasswordResponse_Builder_Message {
+	return ResetPasswordResponse_Builder_Message{root: &ResetPasswordResponse{}}
+}
+
+type ResetPasswordResponse_Builder_Message struct {
+	root *ResetPasswordResponse
+}
+
+type ResetPasswordResponse_Builder_GobFinalizer struct {
+	root *ResetPasswordResponse
+}
+
+func (b ResetPasswordResponse_Builder_Message) Message(arg string) ResetPasswordResponse_Builder_GobFinalizer {
+	b.root.Message = arg
+	return ResetPasswordResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ResetPasswordResponse_Builder_GobFinalizer) Build() *ResetPasswordResponse {
+	return b.root
+}
+
 func NewSignInRequestBuilder() SignInRequest_Builder_Email {
 	return SignInRequest_Builder_Email{root: &SignInRequest{}}
 }
@@ -70,12 +380,21 @@ func (b SignInRequest_Builder_Email) Email(arg string) SignInRequest_Builder_Pas
 	return SignInRequest_Builder_Password{root: b.root}
 }
 
-type SignInRequest_Builder_TenantId struct {
+type SignInRequest_Builder_Source struct {
 	root *SignInRequest
 }
 
-func (b SignInRequest_Builder_Password) Password(arg string) SignInRequest_Builder_TenantId {
+func (b SignInRequest_Builder_Password) Password(arg string) SignInRequest_Builder_Source {
 	b.root.Password = arg
+	return SignInRequest_Builder_Source{root: b.root}
+}
+
+type SignInRequest_Builder_TenantId struct {
+	root *SignInRequest
+}
+
+func (b SignInRequest_Builder_Source) Source(arg *string) SignInRequest_Builder_TenantId {
+	b.root.Source = arg
 	return SignInRequest_Builder_TenantId{root: b.root}
 }
 
@@ -254,6 +573,174 @@ func (b SignUpResponse_Builder_GobFinalizer) Build() *SignUpResponse {
 	return b.root
 }
 
+func NewTokenIntrospectionRequestBuilder() TokenIntrospectionRequest_Builder_ClientId {
+	return TokenIntrospectionRequest_Builder_ClientId{root: &TokenIntrospectionRequest{}}
+}
+
+type TokenIntrospectionRequest_Builder_ClientId struct {
+	root *TokenIntrospectionRequest
+}
+
+type TokenIntrospectionRequest_Builder_ClientSecret struct {
+	root *TokenIntrospectionRequest
+}
+
+func (b TokenIntrospectionRequest_Builder_ClientId) ClientId(arg *string) TokenIntrospectionRequest_Builder_ClientSecret {
+	b.root.ClientId = arg
+	return TokenIntrospectionRequest_Builder_ClientSecret{root: b.root}
+}
+
+type TokenIntrospectionRequest_Builder_Token struct {
+	root *TokenIntrospectionRequest
+}
+
+func (b TokenIntrospectionRequest_Builder_ClientSecret) ClientSecret(arg *string) TokenIntrospectionRequest_Builder_Token {
+	b.root.ClientSecret = arg
+	return TokenIntrospectionRequest_Builder_Token{root: b.root}
+}
+
+type TokenIntrospectionRequest_Builder_TokenTypeHint struct {
+	root *TokenIntrospectionRequest
+}
+
+func (b TokenIntrospectionRequest_Builder_Token) Token(arg string) TokenIntrospectionRequest_Builder_TokenTypeHint {
+	b.root.Token = arg
+	return TokenIntrospectionRequest_Builder_TokenTypeHint{root: b.root}
+}
+
+type TokenIntrospectionRequest_Builder_GobFinalizer struct {
+	root *TokenIntrospectionRequest
+}
+
+func (b TokenIntrospectionRequest_Builder_TokenTypeHint) TokenTypeHint(arg *string) TokenIntrospectionRequest_Builder_GobFinalizer {
+	b.root.TokenTypeHint = arg
+	return TokenIntrospectionRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b TokenIntrospectionRequest_Builder_GobFinalizer) Build() *TokenIntrospectionRequest {
+	return b.root
+}
+
+func NewTokenIntrospectionResponseBuilder() TokenIntrospectionResponse_Builder_Active {
+	return TokenIntrospectionResponse_Builder_Active{root: &TokenIntrospectionResponse{}}
+}
+
+type TokenIntrospectionResponse_Builder_Active struct {
+	root *TokenIntrospectionResponse
+}
+
+type TokenIntrospectionResponse_Builder_ClientId struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Active) Active(arg bool) TokenIntrospectionResponse_Builder_ClientId {
+	b.root.Active = arg
+	return TokenIntrospectionResponse_Builder_ClientId{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Exp struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_ClientId) ClientId(arg *string) TokenIntrospectionResponse_Builder_Exp {
+	b.root.ClientId = arg
+	return TokenIntrospectionResponse_Builder_Exp{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Iat struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Exp) Exp(arg *int64) TokenIntrospectionResponse_Builder_Iat {
+	b.root.Exp = arg
+	return TokenIntrospectionResponse_Builder_Iat{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Jti struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Iat) Iat(arg *int64) TokenIntrospectionResponse_Builder_Jti {
+	b.root.Iat = arg
+	return TokenIntrospectionResponse_Builder_Jti{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Permissions struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Jti) Jti(arg *string) TokenIntrospectionResponse_Builder_Permissions {
+	b.root.Jti = arg
+	return TokenIntrospectionResponse_Builder_Permissions{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Roles struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Permissions) Permissions(arg *[]string) TokenIntrospectionResponse_Builder_Roles {
+	b.root.Permissions = arg
+	return TokenIntrospectionResponse_Builder_Roles{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Scope struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Roles) Roles(arg *[]string) TokenIntrospectionResponse_Builder_Scope {
+	b.root.Roles = arg
+	return TokenIntrospectionResponse_Builder_Scope{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Sid struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Scope) Scope(arg *string) TokenIntrospectionResponse_Builder_Sid {
+	b.root.Scope = arg
+	return TokenIntrospectionResponse_Builder_Sid{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_Sub struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Sid) Sid(arg *string) TokenIntrospectionResponse_Builder_Sub {
+	b.root.Sid = arg
+	return TokenIntrospectionResponse_Builder_Sub{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_TenantId struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_Sub) Sub(arg *string) TokenIntrospectionResponse_Builder_TenantId {
+	b.root.Sub = arg
+	return TokenIntrospectionResponse_Builder_TenantId{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_TokenType struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_TenantId) TenantId(arg *string) TokenIntrospectionResponse_Builder_TokenType {
+	b.root.TenantId = arg
+	return TokenIntrospectionResponse_Builder_TokenType{root: b.root}
+}
+
+type TokenIntrospectionResponse_Builder_GobFinalizer struct {
+	root *TokenIntrospectionResponse
+}
+
+func (b TokenIntrospectionResponse_Builder_TokenType) TokenType(arg *string) TokenIntrospectionResponse_Builder_GobFinalizer {
+	b.root.TokenType = arg
+	return TokenIntrospectionResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b TokenIntrospectionResponse_Builder_GobFinalizer) Build() *TokenIntrospectionResponse {
+	return b.root
+}
+
 func NewTokenRefreshRequestBuilder() TokenRefreshRequest_Builder_RefreshToken {
 	return TokenRefreshRequest_Builder_RefreshToken{root: &TokenRefreshRequest{}}
 }
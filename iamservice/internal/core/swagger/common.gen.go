@@ -7,6 +7,12 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for SortOrder.
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
 // PaginationInfo defines model for PaginationInfo.
 type PaginationInfo struct {
 	// Limit Number of items per page
@@ -21,3 +27,6 @@ type PaginationInfo struct {
 	// TotalPages Total number of pages
 	TotalPages int `json:"totalPages"`
 }
+
+// SortOrder defines model for SortOrder.
+type SortOrder string
@@ -0,0 +1,82 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// AcceptInvitationRequest Request payload for accepting an invitation
+type AcceptInvitationRequest struct {
+	// FirstName First name of the invitee
+	FirstName string `json:"firstName"`
+
+	// LastName Last name of the invitee
+	LastName string `json:"lastName"`
+
+	// Password Password of the invitee (minimum 8 characters, will be hashed)
+	Password string `json:"password"`
+
+	// Token Invitation token from the link sent via email
+	Token string `json:"token"`
+}
+
+// AcceptInvitationResponse Account created by accepting an invitation
+type AcceptInvitationResponse struct {
+	// Email Verified email address of the created user
+	Email string `json:"email"`
+
+	// TenantId Tenant of the created user
+	TenantId string `json:"tenantId"`
+
+	// UserId Identifier of the created user
+	UserId string `json:"userId"`
+}
+
+// CreateInvitationRequest Request payload for inviting a user
+type CreateInvitationRequest struct {
+	// Email Email address of the invitee
+	Email string `json:"email"`
+
+	// Roles Roles given to the invitee in addition to the user role
+	Roles *[]string `json:"roles"`
+}
+
+// InvitationResponse Invitation of a user to a tenant
+type InvitationResponse struct {
+	// CreatedAt When the invitation was first sent
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Email Email address of the invitee
+	Email string `json:"email"`
+
+	// Expired Whether the link of the invitation has expired, the invitation can be resent
+	Expired bool `json:"expired"`
+
+	// ExpiresAt When the link of the invitation expires
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Id Invitation identifier
+	Id string `json:"id"`
+
+	// InvitedBy User who sent the invitation
+	InvitedBy *string `json:"invitedBy"`
+
+	// Roles Roles given to the invitee
+	Roles []string `json:"roles"`
+
+	// TenantId Tenant the user is invited to
+	TenantId string `json:"tenantId"`
+}
+
+// InvitationsResponse defines model for InvitationsResponse.
+type InvitationsResponse struct {
+	Items []InvitationResponse `json:"items"`
+}
+
+// AcceptInvitationJSONRequestBody defines body for AcceptInvitation for application/json ContentType.
+type AcceptInvitationJSONRequestBody = AcceptInvitationRequest
+
+// CreateInvitationJSONRequestBody defines body for CreateInvitation for application/json ContentType.
+type CreateInvitationJSONRequestBody = CreateInvitationRequest
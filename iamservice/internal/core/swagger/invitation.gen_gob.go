@@ -0,0 +1,229 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewAcceptInvitationRequestBuilder() AcceptInvitationRequest_Builder_FirstName {
+	return AcceptInvitationRequest_Builder_FirstName{root: &AcceptInvitationRequest{}}
+}
+
+type AcceptInvitationRequest_Builder_FirstName struct {
+	root *AcceptInvitationRequest
+}
+
+type AcceptInvitationRequest_Builder_LastName struct {
+	root *AcceptInvitationRequest
+}
+
+func (b AcceptInvitationRequest_Builder_FirstName) FirstName(arg string) AcceptInvitationRequest_Builder_LastName {
+	b.root.FirstName = arg
+	return AcceptInvitationRequest_Builder_LastName{root: b.root}
+}
+
+type AcceptInvitationRequest_Builder_Password struct {
+	root *AcceptInvitationRequest
+}
+
+func (b AcceptInvitationRequest_Builder_LastName) LastName(arg string) AcceptInvitationRequest_Builder_Password {
+	b.root.LastName = arg
+	return AcceptInvitationRequest_Builder_Password{root: b.root}
+}
+
+type AcceptInvitationRequest_Builder_Token struct {
+	root *AcceptInvitationRequest
+}
+
+func (b AcceptInvitationRequest_Builder_Password) Password(arg string) AcceptInvitationRequest_Builder_Token {
+	b.root.Password = arg
+	return AcceptInvitationRequest_Builder_Token{root: b.root}
+}
+
+type AcceptInvitationRequest_Builder_GobFinalizer struct {
+	root *AcceptInvitationRequest
+}
+
+func (b AcceptInvitationRequest_Builder_Token) Token(arg string) AcceptInvitationRequest_Builder_GobFinalizer {
+	b.root.Token = arg
+	return AcceptInvitationRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AcceptInvitationRequest_Builder_GobFinalizer) Build() *AcceptInvitationRequest {
+	return b.root
+}
+
+func NewAcceptInvitationResponseBuilder() AcceptInvitationResponse_Builder_Email {
+	return AcceptInvitationResponse_Builder_Email{root: &AcceptInvitationResponse{}}
+}
+
+type AcceptInvitationResponse_Builder_Email struct {
+	root *AcceptInvitationResponse
+}
+
+type AcceptInvitationResponse_Builder_TenantId struct {
+	root *AcceptInvitationResponse
+}
+
+func (b AcceptInvitationResponse_Builder_Email) Email(arg string) AcceptInvitationResponse_Builder_TenantId {
+	b.root.Email = arg
+	return AcceptInvitationResponse_Builder_TenantId{root: b.root}
+}
+
+type AcceptInvitationResponse_Builder_UserId struct {
+	root *AcceptInvitationResponse
+}
+
+func (b AcceptInvitationResponse_Builder_TenantId) TenantId(arg string) AcceptInvitationResponse_Builder_UserId {
+	b.root.TenantId = arg
+	return AcceptInvitationResponse_Builder_UserId{root: b.root}
+}
+
+type AcceptInvitationResponse_Builder_GobFinalizer struct {
+	root *AcceptInvitationResponse
+}
+
+func (b AcceptInvitationResponse_Builder_UserId) UserId(arg string) AcceptInvitationResponse_Builder_GobFinalizer {
+	b.root.UserId = arg
+	return AcceptInvitationResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AcceptInvitationResponse_Builder_GobFinalizer) Build() *AcceptInvitationResponse {
+	return b.root
+}
+
+func NewCreateInvitationRequestBuilder() CreateInvitationRequest_Builder_Email {
+	return CreateInvitationRequest_Builder_Email{root: &CreateInvitationRequest{}}
+}
+
+type CreateInvitationRequest_Builder_Email struct {
+	root *CreateInvitationRequest
+}
+
+type CreateInvitationRequest_Builder_Roles struct {
+	root *CreateInvitationRequest
+}
+
+func (b CreateInvitationRequest_Builder_Email) Email(arg string) CreateInvitationRequest_Builder_Roles {
+	b.root.Email = arg
+	return CreateInvitationRequest_Builder_Roles{root: b.root}
+}
+
+type CreateInvitationRequest_Builder_GobFinalizer struct {
+	root *CreateInvitationRequest
+}
+
+func (b CreateInvitationRequest_Builder_Roles) Roles(arg *[]string) CreateInvitationRequest_Builder_GobFinalizer {
+	b.root.Roles = arg
+	return CreateInvitationRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateInvitationRequest_Builder_GobFinalizer) Build() *CreateInvitationRequest {
+	return b.root
+}
+
+func NewInvitationResponseBuilder() InvitationResponse_Builder_CreatedAt {
+	return InvitationResponse_Builder_CreatedAt{root: &InvitationResponse{}}
+}
+
+type InvitationResponse_Builder_CreatedAt struct {
+	root *InvitationResponse
+}
+
+type InvitationResponse_Builder_Email struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_CreatedAt) CreatedAt(arg time.Time) InvitationResponse_Builder_Email {
+	b.root.CreatedAt = arg
+	return InvitationResponse_Builder_Email{root: b.root}
+}
+
+type InvitationResponse_Builder_Expired struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_Email) Email(arg string) InvitationResponse_Builder_Expired {
+	b.root.Email = arg
+	return InvitationResponse_Builder_Expired{root: b.root}
+}
+
+type InvitationResponse_Builder_ExpiresAt struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_Expired) Expired(arg bool) InvitationResponse_Builder_ExpiresAt {
+	b.root.Expired = arg
+	return InvitationResponse_Builder_ExpiresAt{root: b.root}
+}
+
+type InvitationResponse_Builder_Id struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_ExpiresAt) ExpiresAt(arg time.Time) InvitationResponse_Builder_Id {
+	b.root.ExpiresAt = arg
+	return InvitationResponse_Builder_Id{root: b.root}
+}
+
+type InvitationResponse_Builder_InvitedBy struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_Id) Id(arg string) InvitationResponse_Builder_InvitedBy {
+	b.root.Id = arg
+	return InvitationResponse_Builder_InvitedBy{root: b.root}
+}
+
+type InvitationResponse_Builder_Roles struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_InvitedBy) InvitedBy(arg *string) InvitationResponse_Builder_Roles {
+	b.root.InvitedBy = arg
+	return InvitationResponse_Builder_Roles{root: b.root}
+}
+
+type InvitationResponse_Builder_TenantId struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_Roles) Roles(arg []string) InvitationResponse_Builder_TenantId {
+	b.root.Roles = arg
+	return InvitationResponse_Builder_TenantId{root: b.root}
+}
+
+type InvitationResponse_Builder_GobFinalizer struct {
+	root *InvitationResponse
+}
+
+func (b InvitationResponse_Builder_TenantId) TenantId(arg string) InvitationResponse_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return InvitationResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b InvitationResponse_Builder_GobFinalizer) Build() *InvitationResponse {
+	return b.root
+}
+
+func NewInvitationsResponseBuilder() InvitationsResponse_Builder_Items {
+	return InvitationsResponse_Builder_Items{root: &InvitationsResponse{}}
+}
+
+type InvitationsResponse_Builder_Items struct {
+	root *InvitationsResponse
+}
+
+type InvitationsResponse_Builder_GobFinalizer struct {
+	root *InvitationsResponse
+}
+
+func (b InvitationsResponse_Builder_Items) Items(arg []InvitationResponse) InvitationsResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return InvitationsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b InvitationsResponse_Builder_GobFinalizer) Build() *InvitationsResponse {
+	return b.root
+}
@@ -0,0 +1,82 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+// MfaChallengeResponse Returned by signin instead of tokens when a second factor is required
+type MfaChallengeResponse struct {
+	// EnrollmentRequired True when the user must enroll an authenticator before signing in
+	EnrollmentRequired bool `json:"enrollmentRequired"`
+
+	// ExpiresIn Challenge token expiration time in seconds
+	ExpiresIn int64 `json:"expiresIn"`
+
+	// MfaRequired Always true, distinguishes the challenge from SignInResponse
+	MfaRequired bool `json:"mfaRequired"`
+
+	// MfaToken Short-lived challenge token to pass to /auth/mfa/verify
+	MfaToken string `json:"mfaToken"`
+}
+
+// MfaConfirmRequest defines model for MfaConfirmRequest.
+type MfaConfirmRequest struct {
+	// Code Current TOTP code from the authenticator app
+	Code string `json:"code"`
+}
+
+// MfaDisableRequest defines model for MfaDisableRequest.
+type MfaDisableRequest struct {
+	// Code Current TOTP code or a recovery code, required when users disable their own authenticator without currentPassword
+	Code *string `json:"code"`
+
+	// CurrentPassword Current password, required when users disable their own authenticator without code
+	CurrentPassword *string `json:"currentPassword"`
+}
+
+// MfaEnrollRequest defines model for MfaEnrollRequest.
+type MfaEnrollRequest struct {
+	// MfaToken Enrollment challenge token returned by signin
+	MfaToken string `json:"mfaToken"`
+}
+
+// MfaEnrollmentResponse defines model for MfaEnrollmentResponse.
+type MfaEnrollmentResponse struct {
+	// OtpauthUri otpauth:// URI to render as a QR code
+	OtpauthUri string `json:"otpauthUri"`
+
+	// RecoveryCodes One-time recovery codes, shown only once
+	RecoveryCodes []string `json:"recoveryCodes"`
+
+	// Secret Base32 encoded TOTP secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// MfaStatusResponse defines model for MfaStatusResponse.
+type MfaStatusResponse struct {
+	// Enabled True when a confirmed authenticator is registered
+	Enabled bool `json:"enabled"`
+
+	// RecoveryCodesRemaining Number of unused recovery codes
+	RecoveryCodesRemaining int `json:"recoveryCodesRemaining"`
+}
+
+// MfaVerifyRequest defines model for MfaVerifyRequest.
+type MfaVerifyRequest struct {
+	// Code TOTP code or recovery code
+	Code string `json:"code"`
+
+	// MfaToken Challenge token returned by signin
+	MfaToken string `json:"mfaToken"`
+}
+
+// EnrollMfaWithChallengeJSONRequestBody defines body for EnrollMfaWithChallenge for application/json ContentType.
+type EnrollMfaWithChallengeJSONRequestBody = MfaEnrollRequest
+
+// VerifyMfaJSONRequestBody defines body for VerifyMfa for application/json ContentType.
+type VerifyMfaJSONRequestBody = MfaVerifyRequest
+
+// DisableUserMfaJSONRequestBody defines body for DisableUserMfa for application/json ContentType.
+type DisableUserMfaJSONRequestBody = MfaDisableRequest
+
+// ConfirmUserTotpJSONRequestBody defines body for ConfirmUserTotp for application/json ContentType.
+type ConfirmUserTotpJSONRequestBody = MfaConfirmRequest
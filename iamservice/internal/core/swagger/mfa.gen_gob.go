@@ -0,0 +1,222 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+func NewMfaChallengeResponseBuilder() MfaChallengeResponse_Builder_EnrollmentRequired {
+	return MfaChallengeResponse_Builder_EnrollmentRequired{root: &MfaChallengeResponse{}}
+}
+
+type MfaChallengeResponse_Builder_EnrollmentRequired struct {
+	root *MfaChallengeResponse
+}
+
+type MfaChallengeResponse_Builder_ExpiresIn struct {
+	root *MfaChallengeResponse
+}
+
+func (b MfaChallengeResponse_Builder_EnrollmentRequired) EnrollmentRequired(arg bool) MfaChallengeResponse_Builder_ExpiresIn {
+	b.root.EnrollmentRequired = arg
+	return MfaChallengeResponse_Builder_ExpiresIn{root: b.root}
+}
+
+type MfaChallengeResponse_Builder_MfaRequired struct {
+	root *MfaChallengeResponse
+}
+
+func (b MfaChallengeResponse_Builder_ExpiresIn) ExpiresIn(arg int64) MfaChallengeResponse_Builder_MfaRequired {
+	b.root.ExpiresIn = arg
+	return MfaChallengeResponse_Builder_MfaRequired{root: b.root}
+}
+
+type MfaChallengeResponse_Builder_MfaToken struct {
+	root *MfaChallengeResponse
+}
+
+func (b MfaChallengeResponse_Builder_MfaRequired) MfaRequired(arg bool) MfaChallengeResponse_Builder_MfaToken {
+	b.root.MfaRequired = arg
+	return MfaChallengeResponse_Builder_MfaToken{root: b.root}
+}
+
+type MfaChallengeResponse_Builder_GobFinalizer struct {
+	root *MfaChallengeResponse
+}
+
+func (b MfaChallengeResponse_Builder_MfaToken) MfaToken(arg string) MfaChallengeResponse_Builder_GobFinalizer {
+	b.root.MfaToken = arg
+	return MfaChallengeResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaChallengeResponse_Builder_GobFinalizer) Build() *MfaChallengeResponse {
+	return b.root
+}
+
+func NewMfaConfirmRequestBuilder() MfaConfirmRequest_Builder_Code {
+	return MfaConfirmRequest_Builder_Code{root: &MfaConfirmRequest{}}
+}
+
+type MfaConfirmRequest_Builder_Code struct {
+	root *MfaConfirmRequest
+}
+
+type MfaConfirmRequest_Builder_GobFinalizer struct {
+	root *MfaConfirmRequest
+}
+
+func (b MfaConfirmRequest_Builder_Code) Code(arg string) MfaConfirmRequest_Builder_GobFinalizer {
+	b.root.Code = arg
+	return MfaConfirmRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaConfirmRequest_Builder_GobFinalizer) Build() *MfaConfirmRequest {
+	return b.root
+}
+
+func NewMfaDisableRequestBuilder() MfaDisableRequest_Builder_Code {
+	return MfaDisableRequest_Builder_Code{root: &MfaDisableRequest{}}
+}
+
+type MfaDisableRequest_Builder_Code struct {
+	root *MfaDisableRequest
+}
+
+type MfaDisableRequest_Builder_CurrentPassword struct {
+	root *MfaDisableRequest
+}
+
+func (b MfaDisableRequest_Builder_Code) Code(arg *string) MfaDisableRequest_Builder_CurrentPassword {
+	b.root.Code = arg
+	return MfaDisableRequest_Builder_CurrentPassword{root: b.root}
+}
+
+type MfaDisableRequest_Builder_GobFinalizer struct {
+	root *MfaDisableRequest
+}
+
+func (b MfaDisableRequest_Builder_CurrentPassword) CurrentPassword(arg *string) MfaDisableRequest_Builder_GobFinalizer {
+	b.root.CurrentPassword = arg
+	return MfaDisableRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaDisableRequest_Builder_GobFinalizer) Build() *MfaDisableRequest {
+	return b.root
+}
+
+func NewMfaEnrollRequestBuilder() MfaEnrollRequest_Builder_MfaToken {
+	return MfaEnrollRequest_Builder_MfaToken{root: &MfaEnrollRequest{}}
+}
+
+type MfaEnrollRequest_Builder_MfaToken struct {
+	root *MfaEnrollRequest
+}
+
+type MfaEnrollRequest_Builder_GobFinalizer struct {
+	root *MfaEnrollRequest
+}
+
+func (b MfaEnrollRequest_Builder_MfaToken) MfaToken(arg string) MfaEnrollRequest_Builder_GobFinalizer {
+	b.root.MfaToken = arg
+	return MfaEnrollRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaEnrollRequest_Builder_GobFinalizer) Build() *MfaEnrollRequest {
+	return b.root
+}
+
+func NewMfaEnrollmentResponseBuilder() MfaEnrollmentResponse_Builder_OtpauthUri {
+	return MfaEnrollmentResponse_Builder_OtpauthUri{root: &MfaEnrollmentResponse{}}
+}
+
+type MfaEnrollmentResponse_Builder_OtpauthUri struct {
+	root *MfaEnrollmentResponse
+}
+
+type MfaEnrollmentResponse_Builder_RecoveryCodes struct {
+	root *MfaEnrollmentResponse
+}
+
+func (b MfaEnrollmentResponse_Builder_OtpauthUri) OtpauthUri(arg string) MfaEnrollmentResponse_Builder_RecoveryCodes {
+	b.root.OtpauthUri = arg
+	return MfaEnrollmentResponse_Builder_RecoveryCodes{root: b.root}
+}
+
+type MfaEnrollmentResponse_Builder_Secret struct {
+	root *MfaEnrollmentResponse
+}
+
+func (b MfaEnrollmentResponse_Builder_RecoveryCodes) RecoveryCodes(arg []string) MfaEnrollmentResponse_Builder_Secret {
+	b.root.RecoveryCodes = arg
+	return MfaEnrollmentResponse_Builder_Secret{root: b.root}
+}
+
+type MfaEnrollmentResponse_Builder_GobFinalizer struct {
+	root *MfaEnrollmentResponse
+}
+
+func (b MfaEnrollmentResponse_Builder_Secret) Secret(arg string) MfaEnrollmentResponse_Builder_GobFinalizer {
+	b.root.Secret = arg
+	return MfaEnrollmentResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaEnrollmentResponse_Builder_GobFinalizer) Build() *MfaEnrollmentResponse {
+	return b.root
+}
+
+func NewMfaStatusResponseBuilder() MfaStatusResponse_Builder_Enabled {
+	return MfaStatusResponse_Builder_Enabled{root: &MfaStatusResponse{}}
+}
+
+type MfaStatusResponse_Builder_Enabled struct {
+	root *MfaStatusResponse
+}
+
+type MfaStatusResponse_Builder_RecoveryCodesRemaining struct {
+	root *MfaStatusResponse
+}
+
+func (b MfaStatusResponse_Builder_Enabled) Enabled(arg bool) MfaStatusResponse_Builder_RecoveryCodesRemaining {
+	b.root.Enabled = arg
+	return MfaStatusResponse_Builder_RecoveryCodesRemaining{root: b.root}
+}
+
+type MfaStatusResponse_Builder_GobFinalizer struct {
+	root *MfaStatusResponse
+}
+
+func (b MfaStatusResponse_Builder_RecoveryCodesRemaining) RecoveryCodesRemaining(arg int) MfaStatusResponse_Builder_GobFinalizer {
+	b.root.RecoveryCodesRemaining = arg
+	return MfaStatusResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaStatusResponse_Builder_GobFinalizer) Build() *MfaStatusResponse {
+	return b.root
+}
+
+func NewMfaVerifyRequestBuilder() MfaVerifyRequest_Builder_Code {
+	return MfaVerifyRequest_Builder_Code{root: &MfaVerifyRequest{}}
+}
+
+type MfaVerifyRequest_Builder_Code struct {
+	root *MfaVerifyRequest
+}
+
+type MfaVerifyRequest_Builder_MfaToken struct {
+	root *MfaVerifyRequest
+}
+
+func (b MfaVerifyRequest_Builder_Code) Code(arg string) MfaVerifyRequest_Builder_MfaToken {
+	b.root.Code = arg
+	return MfaVerifyRequest_Builder_MfaToken{root: b.root}
+}
+
+type MfaVerifyRequest_Builder_GobFinalizer struct {
+	root *MfaVerifyRequest
+}
+
+func (b MfaVerifyRequest_Builder_MfaToken) MfaToken(arg string) MfaVerifyRequest_Builder_GobFinalizer {
+	b.root.MfaToken = arg
+	return MfaVerifyRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b MfaVerifyRequest_Builder_GobFinalizer) Build() *MfaVerifyRequest {
+	return b.root
+}
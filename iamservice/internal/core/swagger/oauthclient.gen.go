@@ -0,0 +1,67 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// CreateOAuthClientRequest Request payload for registering an OAuth client
+type CreateOAuthClientRequest struct {
+	// Name Human readable client name
+	Name string `json:"name"`
+
+	// Public Public clients cannot keep a secret and authenticate with PKCE only
+	Public bool `json:"public"`
+
+	// RedirectUris Absolute redirect URIs without fragment, matched exactly. Plain http is accepted for localhost only.
+	RedirectUris []string `json:"redirectUris"`
+
+	// TenantId Tenant of the client, defaults to the tenant of the caller (requires tenants:all permission for other tenants)
+	TenantId *string `json:"tenantId"`
+}
+
+// CreateOAuthClientResponse Registered OAuth client with its secret
+type CreateOAuthClientResponse struct {
+	// Client OAuth client
+	Client OAuthClientResponse `json:"client"`
+
+	// ClientSecret Client secret of a confidential client, returned only once
+	ClientSecret *string `json:"clientSecret"`
+}
+
+// OAuthClientResponse OAuth client
+type OAuthClientResponse struct {
+	// ClientId Client ID used with the authorization endpoint and the token endpoint
+	ClientId string `json:"clientId"`
+
+	// CreatedAt Creation timestamp
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Name Human readable client name
+	Name string `json:"name"`
+
+	// Public Client has no secret and authenticates with PKCE only
+	Public bool `json:"public"`
+
+	// RedirectUris Registered redirect URIs
+	RedirectUris []string `json:"redirectUris"`
+
+	// TenantId Tenant the client signs in users of
+	TenantId string `json:"tenantId"`
+}
+
+// OAuthClientsResponse defines model for OAuthClientsResponse.
+type OAuthClientsResponse struct {
+	Items []OAuthClientResponse `json:"items"`
+}
+
+// ListOAuthClientsParams defines parameters for ListOAuthClients.
+type ListOAuthClientsParams struct {
+	// TenantId Tenant of the clients, defaults to the tenant of the caller
+	TenantId *string `form:"tenantId,omitempty" json:"tenantId,omitempty"`
+}
+
+// CreateOAuthClientJSONRequestBody defines body for CreateOAuthClient for application/json ContentType.
+type CreateOAuthClientJSONRequestBody = CreateOAuthClientRequest
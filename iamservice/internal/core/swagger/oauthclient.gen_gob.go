@@ -0,0 +1,193 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewCreateOAuthClientRequestBuilder() CreateOAuthClientRequest_Builder_Name {
+	return CreateOAuthClientRequest_Builder_Name{root: &CreateOAuthClientRequest{}}
+}
+
+type CreateOAuthClientRequest_Builder_Name struct {
+	root *CreateOAuthClientRequest
+}
+
+type CreateOAuthClientRequest_Builder_Public struct {
+	root *CreateOAuthClientRequest
+}
+
+func (b CreateOAuthClientRequest_Builder_Name) Name(arg string) CreateOAuthClientRequest_Builder_Public {
+	b.root.Name = arg
+	return CreateOAuthClientRequest_Builder_Public{root: b.root}
+}
+
+type CreateOAuthClientRequest_Builder_RedirectUris struct {
+	root *CreateOAuthClientRequest
+}
+
+func (b CreateOAuthClientRequest_Builder_Public) Public(arg bool) CreateOAuthClientRequest_Builder_RedirectUris {
+	b.root.Public = arg
+	return CreateOAuthClientRequest_Builder_RedirectUris{root: b.root}
+}
+
+type CreateOAuthClientRequest_Builder_TenantId struct {
+	root *CreateOAuthClientRequest
+}
+
+func (b CreateOAuthClientRequest_Builder_RedirectUris) RedirectUris(arg []string) CreateOAuthClientRequest_Builder_TenantId {
+	b.root.RedirectUris = arg
+	return CreateOAuthClientRequest_Builder_TenantId{root: b.root}
+}
+
+type CreateOAuthClientRequest_Builder_GobFinalizer struct {
+	root *CreateOAuthClientRequest
+}
+
+func (b CreateOAuthClientRequest_Builder_TenantId) TenantId(arg *string) CreateOAuthClientRequest_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return CreateOAuthClientRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateOAuthClientRequest_Builder_GobFinalizer) Build() *CreateOAuthClientRequest {
+	return b.root
+}
+
+func NewCreateOAuthClientResponseBuilder() CreateOAuthClientResponse_Builder_Client {
+	return CreateOAuthClientResponse_Builder_Client{root: &CreateOAuthClientResponse{}}
+}
+
+type CreateOAuthClientResponse_Builder_Client struct {
+	root *CreateOAuthClientResponse
+}
+
+type CreateOAuthClientResponse_Builder_ClientSecret struct {
+	root *CreateOAuthClientResponse
+}
+
+func (b CreateOAuthClientResponse_Builder_Client) Client(arg OAuthClientResponse) CreateOAuthClientResponse_Builder_ClientSecret {
+	b.root.Client = arg
+	return CreateOAuthClientResponse_Builder_ClientSecret{root: b.root}
+}
+
+type CreateOAuthClientResponse_Builder_GobFinalizer struct {
+	root *CreateOAuthClientResponse
+}
+
+func (b CreateOAuthClientResponse_Builder_ClientSecret) ClientSecret(arg *string) CreateOAuthClientResponse_Builder_GobFinalizer {
+	b.root.ClientSecret = arg
+	return CreateOAuthClientResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateOAuthClientResponse_Builder_GobFinalizer) Build() *CreateOAuthClientResponse {
+	return b.root
+}
+
+func NewOAuthClientResponseBuilder() OAuthClientResponse_Builder_ClientId {
+	return OAuthClientResponse_Builder_ClientId{root: &OAuthClientResponse{}}
+}
+
+type OAuthClientResponse_Builder_ClientId struct {
+	root *OAuthClientResponse
+}
+
+type OAuthClientResponse_Builder_CreatedAt struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_ClientId) ClientId(arg string) OAuthClientResponse_Builder_CreatedAt {
+	b.root.ClientId = arg
+	return OAuthClientResponse_Builder_CreatedAt{root: b.root}
+}
+
+type OAuthClientResponse_Builder_Name struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_CreatedAt) CreatedAt(arg time.Time) OAuthClientResponse_Builder_Name {
+	b.root.CreatedAt = arg
+	return OAuthClientResponse_Builder_Name{root: b.root}
+}
+
+type OAuthClientResponse_Builder_Public struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_Name) Name(arg string) OAuthClientResponse_Builder_Public {
+	b.root.Name = arg
+	return OAuthClientResponse_Builder_Public{root: b.root}
+}
+
+type OAuthClientResponse_Builder_RedirectUris struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_Public) Public(arg bool) OAuthClientResponse_Builder_RedirectUris {
+	b.root.Public = arg
+	return OAuthClientResponse_Builder_RedirectUris{root: b.root}
+}
+
+type OAuthClientResponse_Builder_TenantId struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_RedirectUris) RedirectUris(arg []string) OAuthClientResponse_Builder_TenantId {
+	b.root.RedirectUris = arg
+	return OAuthClientResponse_Builder_TenantId{root: b.root}
+}
+
+type OAuthClientResponse_Builder_GobFinalizer struct {
+	root *OAuthClientResponse
+}
+
+func (b OAuthClientResponse_Builder_TenantId) TenantId(arg string) OAuthClientResponse_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return OAuthClientResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthClientResponse_Builder_GobFinalizer) Build() *OAuthClientResponse {
+	return b.root
+}
+
+func NewOAuthClientsResponseBuilder() OAuthClientsResponse_Builder_Items {
+	return OAuthClientsResponse_Builder_Items{root: &OAuthClientsResponse{}}
+}
+
+type OAuthClientsResponse_Builder_Items struct {
+	root *OAuthClientsResponse
+}
+
+type OAuthClientsResponse_Builder_GobFinalizer struct {
+	root *OAuthClientsResponse
+}
+
+func (b OAuthClientsResponse_Builder_Items) Items(arg []OAuthClientResponse) OAuthClientsResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return OAuthClientsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthClientsResponse_Builder_GobFinalizer) Build() *OAuthClientsResponse {
+	return b.root
+}
+
+func NewListOAuthClientsParamsBuilder() ListOAuthClientsParams_Builder_TenantId {
+	return ListOAuthClientsParams_Builder_TenantId{root: &ListOAuthClientsParams{}}
+}
+
+type ListOAuthClientsParams_Builder_TenantId struct {
+	root *ListOAuthClientsParams
+}
+
+type ListOAuthClientsParams_Builder_GobFinalizer struct {
+	root *ListOAuthClientsParams
+}
+
+func (b ListOAuthClientsParams_Builder_TenantId) TenantId(arg *string) ListOAuthClientsParams_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ListOAuthClientsParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListOAuthClientsParams_Builder_GobFinalizer) Build() *ListOAuthClientsParams {
+	return b.root
+}
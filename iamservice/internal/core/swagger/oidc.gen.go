@@ -0,0 +1,112 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+// OAuthErrorResponse OAuth 2.0 error response (RFC 6749 section 5.2)
+type OAuthErrorResponse struct {
+	// Error Error code
+	Error string `json:"error"`
+
+	// ErrorDescription Human-readable error description
+	ErrorDescription string `json:"error_description"`
+}
+
+// OAuthTokenResponse Token endpoint response
+type OAuthTokenResponse struct {
+	// AccessToken JWT access token
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Access token expiration time in seconds
+	ExpiresIn int64 `json:"expires_in"`
+
+	// IdToken OpenID Connect ID token
+	IdToken string `json:"id_token"`
+
+	// RefreshToken Refresh token
+	RefreshToken string `json:"refresh_token"`
+
+	// Scope Granted scopes
+	Scope string `json:"scope"`
+
+	// TokenType Token type
+	TokenType string `json:"token_type"`
+}
+
+// OIDCDiscoveryResponse OpenID Connect provider metadata
+type OIDCDiscoveryResponse struct {
+	// AuthorizationEndpoint URL of the authorization endpoint
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+
+	// ClaimsSupported Claims that can be returned
+	ClaimsSupported []string `json:"claims_supported"`
+
+	// CodeChallengeMethodsSupported Supported PKCE code challenge methods
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+
+	// GrantTypesSupported Supported grant types
+	GrantTypesSupported []string `json:"grant_types_supported"`
+
+	// IdTokenSigningAlgValuesSupported Algorithms used to sign ID tokens
+	IdTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+
+	// Issuer Issuer identifier
+	Issuer string `json:"issuer"`
+
+	// JwksUri URL of the JSON Web Key Set
+	JwksUri string `json:"jwks_uri"`
+
+	// ResponseTypesSupported Supported response types
+	ResponseTypesSupported []string `json:"response_types_supported"`
+
+	// ScopesSupported Supported scopes
+	ScopesSupported []string `json:"scopes_supported"`
+
+	// SubjectTypesSupported Supported subject identifier types
+	SubjectTypesSupported []string `json:"subject_types_supported"`
+
+	// TokenEndpoint URL of the token endpoint
+	TokenEndpoint string `json:"token_endpoint"`
+
+	// TokenEndpointAuthMethodsSupported Supported client authentication methods
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+
+	// UserinfoEndpoint URL of the userinfo endpoint
+	UserinfoEndpoint string `json:"userinfo_endpoint"`
+}
+
+// OIDCUserInfoResponse Claims about the authenticated user
+type OIDCUserInfoResponse struct {
+	// Email Email address
+	Email string `json:"email"`
+
+	// EmailVerified Whether the email address has been verified
+	EmailVerified bool `json:"email_verified"`
+
+	// FamilyName Last name
+	FamilyName string `json:"family_name"`
+
+	// GivenName First name
+	GivenName string `json:"given_name"`
+
+	// Name Full name
+	Name string `json:"name"`
+
+	// Sub Subject identifier (user ID)
+	Sub string `json:"sub"`
+
+	// TenantId Tenant the user belongs to
+	TenantId string `json:"tenantId"`
+}
+
+// AuthorizeParams defines parameters for Authorize.
+type AuthorizeParams struct {
+	ResponseType        string  `form:"response_type" json:"response_type"`
+	ClientId            string  `form:"client_id" json:"client_id"`
+	RedirectUri         string  `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string  `form:"scope" json:"scope"`
+	State               *string `form:"state,omitempty" json:"state,omitempty"`
+	Nonce               *string `form:"nonce,omitempty" json:"nonce,omitempty"`
+	CodeChallenge       string  `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string  `form:"code_challenge_method" json:"code_challenge_method"`
+}
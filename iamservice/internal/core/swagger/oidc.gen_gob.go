@@ -0,0 +1,387 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+func NewOAuthErrorResponseBuilder() OAuthErrorResponse_Builder_Error {
+	return OAuthErrorResponse_Builder_Error{root: &OAuthErrorResponse{}}
+}
+
+type OAuthErrorResponse_Builder_Error struct {
+	root *OAuthErrorResponse
+}
+
+type OAuthErrorResponse_Builder_ErrorDescription struct {
+	root *OAuthErrorResponse
+}
+
+func (b OAuthErrorResponse_Builder_Error) Error(arg string) OAuthErrorResponse_Builder_ErrorDescription {
+	b.root.Error = arg
+	return OAuthErrorResponse_Builder_ErrorDescription{root: b.root}
+}
+
+type OAuthErrorResponse_Builder_GobFinalizer struct {
+	root *OAuthErrorResponse
+}
+
+func (b OAuthErrorResponse_Builder_ErrorDescription) ErrorDescription(arg string) OAuthErrorResponse_Builder_GobFinalizer {
+	b.root.ErrorDescription = arg
+	return OAuthErrorResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthErrorResponse_Builder_GobFinalizer) Build() *OAuthErrorResponse {
+	return b.root
+}
+
+func NewOAuthTokenResponseBuilder() OAuthTokenResponse_Builder_AccessToken {
+	return OAuthTokenResponse_Builder_AccessToken{root: &OAuthTokenResponse{}}
+}
+
+type OAuthTokenResponse_Builder_AccessToken struct {
+	root *OAuthTokenResponse
+}
+
+type OAuthTokenResponse_Builder_ExpiresIn struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_AccessToken) AccessToken(arg string) OAuthTokenResponse_Builder_ExpiresIn {
+	b.root.AccessToken = arg
+	return OAuthTokenResponse_Builder_ExpiresIn{root: b.root}
+}
+
+type OAuthTokenResponse_Builder_IdToken struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_ExpiresIn) ExpiresIn(arg int64) OAuthTokenResponse_Builder_IdToken {
+	b.root.ExpiresIn = arg
+	return OAuthTokenResponse_Builder_IdToken{root: b.root}
+}
+
+type OAuthTokenResponse_Builder_RefreshToken struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_IdToken) IdToken(arg string) OAuthTokenResponse_Builder_RefreshToken {
+	b.root.IdToken = arg
+	return OAuthTokenResponse_Builder_RefreshToken{root: b.root}
+}
+
+type OAuthTokenResponse_Builder_Scope struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_RefreshToken) RefreshToken(arg string) OAuthTokenResponse_Builder_Scope {
+	b.root.RefreshToken = arg
+	return OAuthTokenResponse_Builder_Scope{root: b.root}
+}
+
+type OAuthTokenResponse_Builder_TokenType struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_Scope) Scope(arg string) OAuthTokenResponse_Builder_TokenType {
+	b.root.Scope = arg
+	return OAuthTokenResponse_Builder_TokenType{root: b.root}
+}
+
+type OAuthTokenResponse_Builder_GobFinalizer struct {
+	root *OAuthTokenResponse
+}
+
+func (b OAuthTokenResponse_Builder_TokenType) TokenType(arg string) OAuthTokenResponse_Builder_GobFinalizer {
+	b.root.TokenType = arg
+	return OAuthTokenResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OAuthTokenResponse_Builder_GobFinalizer) Build() *OAuthTokenResponse {
+	return b.root
+}
+
+func NewOIDCDiscoveryResponseBuilder() OIDCDiscoveryResponse_Builder_AuthorizationEndpoint {
+	return OIDCDiscoveryResponse_Builder_AuthorizationEndpoint{root: &OIDCDiscoveryResponse{}}
+}
+
+type OIDCDiscoveryResponse_Builder_AuthorizationEndpoint struct {
+	root *OIDCDiscoveryResponse
+}
+
+type OIDCDiscoveryResponse_Builder_ClaimsSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_AuthorizationEndpoint) AuthorizationEndpoint(arg string) OIDCDiscoveryResponse_Builder_ClaimsSupported {
+	b.root.AuthorizationEndpoint = arg
+	return OIDCDiscoveryResponse_Builder_ClaimsSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_CodeChallengeMethodsSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_ClaimsSupported) ClaimsSupported(arg []string) OIDCDiscoveryResponse_Builder_CodeChallengeMethodsSupported {
+	b.root.ClaimsSupported = arg
+	return OIDCDiscoveryResponse_Builder_CodeChallengeMethodsSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_GrantTypesSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_CodeChallengeMethodsSupported) CodeChallengeMethodsSupported(arg []string) OIDCDiscoveryResponse_Builder_GrantTypesSupported {
+	b.root.CodeChallengeMethodsSupported = arg
+	return OIDCDiscoveryResponse_Builder_GrantTypesSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_IdTokenSigningAlgValuesSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_GrantTypesSupported) GrantTypesSupported(arg []string) OIDCDiscoveryResponse_Builder_IdTokenSigningAlgValuesSupported {
+	b.root.GrantTypesSupported = arg
+	return OIDCDiscoveryResponse_Builder_IdTokenSigningAlgValuesSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_Issuer struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_IdTokenSigningAlgValuesSupported) IdTokenSigningAlgValuesSupported(arg []string) OIDCDiscoveryResponse_Builder_Issuer {
+	b.root.IdTokenSigningAlgValuesSupported = arg
+	return OIDCDiscoveryResponse_Builder_Issuer{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_JwksUri struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_Issuer) Issuer(arg string) OIDCDiscoveryResponse_Builder_JwksUri {
+	b.root.Issuer = arg
+	return OIDCDiscoveryResponse_Builder_JwksUri{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_ResponseTypesSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_JwksUri) JwksUri(arg string) OIDCDiscoveryResponse_Builder_ResponseTypesSupported {
+	b.root.JwksUri = arg
+	return OIDCDiscoveryResponse_Builder_ResponseTypesSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_ScopesSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_ResponseTypesSupported) ResponseTypesSupported(arg []string) OIDCDiscoveryResponse_Builder_ScopesSupported {
+	b.root.ResponseTypesSupported = arg
+	return OIDCDiscoveryResponse_Builder_ScopesSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_SubjectTypesSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_ScopesSupported) ScopesSupported(arg []string) OIDCDiscoveryResponse_Builder_SubjectTypesSupported {
+	b.root.ScopesSupported = arg
+	return OIDCDiscoveryResponse_Builder_SubjectTypesSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_TokenEndpoint struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_SubjectTypesSupported) SubjectTypesSupported(arg []string) OIDCDiscoveryResponse_Builder_TokenEndpoint {
+	b.root.SubjectTypesSupported = arg
+	return OIDCDiscoveryResponse_Builder_TokenEndpoint{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_TokenEndpointAuthMethodsSupported struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_TokenEndpoint) TokenEndpoint(arg string) OIDCDiscoveryResponse_Builder_TokenEndpointAuthMethodsSupported {
+	b.root.TokenEndpoint = arg
+	return OIDCDiscoveryResponse_Builder_TokenEndpointAuthMethodsSupported{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_UserinfoEndpoint struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_TokenEndpointAuthMethodsSupported) TokenEndpointAuthMethodsSupported(arg []string) OIDCDiscoveryResponse_Builder_UserinfoEndpoint {
+	b.root.TokenEndpointAuthMethodsSupported = arg
+	return OIDCDiscoveryResponse_Builder_UserinfoEndpoint{root: b.root}
+}
+
+type OIDCDiscoveryResponse_Builder_GobFinalizer struct {
+	root *OIDCDiscoveryResponse
+}
+
+func (b OIDCDiscoveryResponse_Builder_UserinfoEndpoint) UserinfoEndpoint(arg string) OIDCDiscoveryResponse_Builder_GobFinalizer {
+	b.root.UserinfoEndpoint = arg
+	return OIDCDiscoveryResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OIDCDiscoveryResponse_Builder_GobFinalizer) Build() *OIDCDiscoveryResponse {
+	return b.root
+}
+
+func NewOIDCUserInfoResponseBuilder() OIDCUserInfoResponse_Builder_Email {
+	return OIDCUserInfoResponse_Builder_Email{root: &OIDCUserInfoResponse{}}
+}
+
+type OIDCUserInfoResponse_Builder_Email struct {
+	root *OIDCUserInfoResponse
+}
+
+type OIDCUserInfoResponse_Builder_EmailVerified struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_Email) Email(arg string) OIDCUserInfoResponse_Builder_EmailVerified {
+	b.root.Email = arg
+	return OIDCUserInfoResponse_Builder_EmailVerified{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_FamilyName struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_EmailVerified) EmailVerified(arg bool) OIDCUserInfoResponse_Builder_FamilyName {
+	b.root.EmailVerified = arg
+	return OIDCUserInfoResponse_Builder_FamilyName{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_GivenName struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_FamilyName) FamilyName(arg string) OIDCUserInfoResponse_Builder_GivenName {
+	b.root.FamilyName = arg
+	return OIDCUserInfoResponse_Builder_GivenName{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_Name struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_GivenName) GivenName(arg string) OIDCUserInfoResponse_Builder_Name {
+	b.root.GivenName = arg
+	return OIDCUserInfoResponse_Builder_Name{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_Sub struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_Name) Name(arg string) OIDCUserInfoResponse_Builder_Sub {
+	b.root.Name = arg
+	return OIDCUserInfoResponse_Builder_Sub{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_TenantId struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_Sub) Sub(arg string) OIDCUserInfoResponse_Builder_TenantId {
+	b.root.Sub = arg
+	return OIDCUserInfoResponse_Builder_TenantId{root: b.root}
+}
+
+type OIDCUserInfoResponse_Builder_GobFinalizer struct {
+	root *OIDCUserInfoResponse
+}
+
+func (b OIDCUserInfoResponse_Builder_TenantId) TenantId(arg string) OIDCUserInfoResponse_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return OIDCUserInfoResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OIDCUserInfoResponse_Builder_GobFinalizer) Build() *OIDCUserInfoResponse {
+	return b.root
+}
+
+func NewAuthorizeParamsBuilder() AuthorizeParams_Builder_ResponseType {
+	return AuthorizeParams_Builder_ResponseType{root: &AuthorizeParams{}}
+}
+
+type AuthorizeParams_Builder_ResponseType struct {
+	root *AuthorizeParams
+}
+
+type AuthorizeParams_Builder_ClientId struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_ResponseType) ResponseType(arg string) AuthorizeParams_Builder_ClientId {
+	b.root.ResponseType = arg
+	return AuthorizeParams_Builder_ClientId{root: b.root}
+}
+
+type AuthorizeParams_Builder_RedirectUri struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_ClientId) ClientId(arg string) AuthorizeParams_Builder_RedirectUri {
+	b.root.ClientId = arg
+	return AuthorizeParams_Builder_RedirectUri{root: b.root}
+}
+
+type AuthorizeParams_Builder_Scope struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_RedirectUri) RedirectUri(arg string) AuthorizeParams_Builder_Scope {
+	b.root.RedirectUri = arg
+	return AuthorizeParams_Builder_Scope{root: b.root}
+}
+
+type AuthorizeParams_Builder_State struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_Scope) Scope(arg string) AuthorizeParams_Builder_State {
+	b.root.Scope = arg
+	return AuthorizeParams_Builder_State{root: b.root}
+}
+
+type AuthorizeParams_Builder_Nonce struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_State) State(arg *string) AuthorizeParams_Builder_Nonce {
+	b.root.State = arg
+	return AuthorizeParams_Builder_Nonce{root: b.root}
+}
+
+type AuthorizeParams_Builder_CodeChallenge struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_Nonce) Nonce(arg *string) AuthorizeParams_Builder_CodeChallenge {
+	b.root.Nonce = arg
+	return AuthorizeParams_Builder_CodeChallenge{root: b.root}
+}
+
+type AuthorizeParams_Builder_CodeChallengeMethod struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_CodeChallenge) CodeChallenge(arg string) AuthorizeParams_Builder_CodeChallengeMethod {
+	b.root.CodeChallenge = arg
+	return AuthorizeParams_Builder_CodeChallengeMethod{root: b.root}
+}
+
+type AuthorizeParams_Builder_GobFinalizer struct {
+	root *AuthorizeParams
+}
+
+func (b AuthorizeParams_Builder_CodeChallengeMethod) CodeChallengeMethod(arg string) AuthorizeParams_Builder_GobFinalizer {
+	b.root.CodeChallengeMethod = arg
+	return AuthorizeParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b AuthorizeParams_Builder_GobFinalizer) Build() *AuthorizeParams {
+	return b.root
+}
@@ -0,0 +1,71 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// Defines values for OutboxEmailResponseStatus.
+const (
+	OutboxEmailResponseStatusDead    OutboxEmailResponseStatus = "dead"
+	OutboxEmailResponseStatusPending OutboxEmailResponseStatus = "pending"
+)
+
+// Defines values for ListFailedOutboxEmailsParamsStatus.
+const (
+	ListFailedOutboxEmailsParamsStatusDead    ListFailedOutboxEmailsParamsStatus = "dead"
+	ListFailedOutboxEmailsParamsStatusPending ListFailedOutboxEmailsParamsStatus = "pending"
+)
+
+// OutboxEmailResponse Undelivered outgoing email
+type OutboxEmailResponse struct {
+	// Attempts Delivery attempts made so far
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Time the email was queued
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id Outgoing email unique identifier
+	Id string `json:"id"`
+
+	// LastError Error of the last failed delivery attempt
+	LastError *string `json:"lastError"`
+
+	// NextAttemptAt Time of the next delivery attempt of pending emails
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+
+	// Recipient Email address the email is sent to
+	Recipient string `json:"recipient"`
+
+	// Status Delivery state, dead emails are no longer retried automatically
+	Status OutboxEmailResponseStatus `json:"status"`
+
+	// Subject Subject of the email
+	Subject string `json:"subject"`
+}
+
+// OutboxEmailResponseStatus Delivery state, dead emails are no longer retried automatically
+type OutboxEmailResponseStatus string
+
+// OutboxEmailsResponse defines model for OutboxEmailsResponse.
+type OutboxEmailsResponse struct {
+	Items      []OutboxEmailResponse `json:"items"`
+	Pagination PaginationInfo        `json:"pagination"`
+}
+
+// ListFailedOutboxEmailsParams defines parameters for ListFailedOutboxEmails.
+type ListFailedOutboxEmailsParams struct {
+	// Page Page number for pagination
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of emails per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Status Only emails in this delivery state
+	Status *ListFailedOutboxEmailsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListFailedOutboxEmailsParamsStatus defines parameters for ListFailedOutboxEmails.
+type ListFailedOutboxEmailsParamsStatus string
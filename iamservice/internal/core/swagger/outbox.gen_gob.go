@@ -0,0 +1,160 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewOutboxEmailResponseBuilder() OutboxEmailResponse_Builder_Attempts {
+	return OutboxEmailResponse_Builder_Attempts{root: &OutboxEmailResponse{}}
+}
+
+type OutboxEmailResponse_Builder_Attempts struct {
+	root *OutboxEmailResponse
+}
+
+type OutboxEmailResponse_Builder_CreatedAt struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_Attempts) Attempts(arg int) OutboxEmailResponse_Builder_CreatedAt {
+	b.root.Attempts = arg
+	return OutboxEmailResponse_Builder_CreatedAt{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_Id struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_CreatedAt) CreatedAt(arg time.Time) OutboxEmailResponse_Builder_Id {
+	b.root.CreatedAt = arg
+	return OutboxEmailResponse_Builder_Id{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_LastError struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_Id) Id(arg string) OutboxEmailResponse_Builder_LastError {
+	b.root.Id = arg
+	return OutboxEmailResponse_Builder_LastError{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_NextAttemptAt struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_LastError) LastError(arg *string) OutboxEmailResponse_Builder_NextAttemptAt {
+	b.root.LastError = arg
+	return OutboxEmailResponse_Builder_NextAttemptAt{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_Recipient struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) OutboxEmailResponse_Builder_Recipient {
+	b.root.NextAttemptAt = arg
+	return OutboxEmailResponse_Builder_Recipient{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_Status struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_Recipient) Recipient(arg string) OutboxEmailResponse_Builder_Status {
+	b.root.Recipient = arg
+	return OutboxEmailResponse_Builder_Status{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_Subject struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_Status) Status(arg OutboxEmailResponseStatus) OutboxEmailResponse_Builder_Subject {
+	b.root.Status = arg
+	return OutboxEmailResponse_Builder_Subject{root: b.root}
+}
+
+type OutboxEmailResponse_Builder_GobFinalizer struct {
+	root *OutboxEmailResponse
+}
+
+func (b OutboxEmailResponse_Builder_Subject) Subject(arg string) OutboxEmailResponse_Builder_GobFinalizer {
+	b.root.Subject = arg
+	return OutboxEmailResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OutboxEmailResponse_Builder_GobFinalizer) Build() *OutboxEmailResponse {
+	return b.root
+}
+
+func NewOutboxEmailsResponseBuilder() OutboxEmailsResponse_Builder_Items {
+	return OutboxEmailsResponse_Builder_Items{root: &OutboxEmailsResponse{}}
+}
+
+type OutboxEmailsResponse_Builder_Items struct {
+	root *OutboxEmailsResponse
+}
+
+type OutboxEmailsResponse_Builder_Pagination struct {
+	root *OutboxEmailsResponse
+}
+
+func (b OutboxEmailsResponse_Builder_Items) Items(arg []OutboxEmailResponse) OutboxEmailsResponse_Builder_Pagination {
+	b.root.Items = arg
+	return OutboxEmailsResponse_Builder_Pagination{root: b.root}
+}
+
+type OutboxEmailsResponse_Builder_GobFinalizer struct {
+	root *OutboxEmailsResponse
+}
+
+func (b OutboxEmailsResponse_Builder_Pagination) Pagination(arg PaginationInfo) OutboxEmailsResponse_Builder_GobFinalizer {
+	b.root.Pagination = arg
+	return OutboxEmailsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b OutboxEmailsResponse_Builder_GobFinalizer) Build() *OutboxEmailsResponse {
+	return b.root
+}
+
+func NewListFailedOutboxEmailsParamsBuilder() ListFailedOutboxEmailsParams_Builder_Page {
+	return ListFailedOutboxEmailsParams_Builder_Page{root: &ListFailedOutboxEmailsParams{}}
+}
+
+type ListFailedOutboxEmailsParams_Builder_Page struct {
+	root *ListFailedOutboxEmailsParams
+}
+
+type ListFailedOutboxEmailsParams_Builder_Limit struct {
+	root *ListFailedOutboxEmailsParams
+}
+
+func (b ListFailedOutboxEmailsParams_Builder_Page) Page(arg *int) ListFailedOutboxEmailsParams_Builder_Limit {
+	b.root.Page = arg
+	return ListFailedOutboxEmailsParams_Builder_Limit{root: b.root}
+}
+
+type ListFailedOutboxEmailsParams_Builder_Status struct {
+	root *ListFailedOutboxEmailsParams
+}
+
+func (b ListFailedOutboxEmailsParams_Builder_Limit) Limit(arg *int) ListFailedOutboxEmailsParams_Builder_Status {
+	b.root.Limit = arg
+	return ListFailedOutboxEmailsParams_Builder_Status{root: b.root}
+}
+
+type ListFailedOutboxEmailsParams_Builder_GobFinalizer struct {
+	root *ListFailedOutboxEmailsParams
+}
+
+func (b ListFailedOutboxEmailsParams_Builder_Status) Status(arg *ListFailedOutboxEmailsParamsStatus) ListFailedOutboxEmailsParams_Builder_GobFinalizer {
+	b.root.Status = arg
+	return ListFailedOutboxEmailsParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListFailedOutboxEmailsParams_Builder_GobFinalizer) Build() *ListFailedOutboxEmailsParams {
+	return b.root
+}
@@ -0,0 +1,71 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+// CreateRoleRequest Request payload for creating a custom role
+type CreateRoleRequest struct {
+	// Description Human readable role description
+	Description *string `json:"description"`
+
+	// Name Role name, unique among the system roles and the custom roles of the tenant
+	Name string `json:"name"`
+
+	// Permissions Permissions granted by the role
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionResponse Permission known to the service
+type PermissionResponse struct {
+	// Description What the permission allows
+	Description string `json:"description"`
+
+	// Name Permission name
+	Name string `json:"name"`
+
+	// SystemOnly Whether only system roles can grant the permission
+	SystemOnly bool `json:"systemOnly"`
+}
+
+// PermissionsResponse defines model for PermissionsResponse.
+type PermissionsResponse struct {
+	Items []PermissionResponse `json:"items"`
+}
+
+// RoleResponse Role
+type RoleResponse struct {
+	// Description Human readable role description
+	Description string `json:"description"`
+
+	// Name Role name
+	Name string `json:"name"`
+
+	// Permissions Permissions granted by the role
+	Permissions []string `json:"permissions"`
+
+	// System Whether the role is a system role, available in every tenant and not editable
+	System bool `json:"system"`
+
+	// TenantId Tenant of a custom role, null for system roles
+	TenantId *string `json:"tenantId"`
+}
+
+// RolesResponse defines model for RolesResponse.
+type RolesResponse struct {
+	Items []RoleResponse `json:"items"`
+}
+
+// UpdateRoleRequest Request payload for updating a custom role
+type UpdateRoleRequest struct {
+	// Description Human readable role description
+	Description *string `json:"description"`
+
+	// Permissions Permissions granted by the role, replacing the current ones
+	Permissions []string `json:"permissions"`
+}
+
+// CreateRoleJSONRequestBody defines body for CreateRole for application/json ContentType.
+type CreateRoleJSONRequestBody = CreateRoleRequest
+
+// UpdateRoleJSONRequestBody defines body for UpdateRole for application/json ContentType.
+type UpdateRoleJSONRequestBody = UpdateRoleRequest
@@ -0,0 +1,210 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+func NewCreateRoleRequestBuilder() CreateRoleRequest_Builder_Description {
+	return CreateRoleRequest_Builder_Description{root: &CreateRoleRequest{}}
+}
+
+type CreateRoleRequest_Builder_Description struct {
+	root *CreateRoleRequest
+}
+
+type CreateRoleRequest_Builder_Name struct {
+	root *CreateRoleRequest
+}
+
+func (b CreateRoleRequest_Builder_Description) Description(arg *string) CreateRoleRequest_Builder_Name {
+	b.root.Description = arg
+	return CreateRoleRequest_Builder_Name{root: b.root}
+}
+
+type CreateRoleRequest_Builder_Permissions struct {
+	root *CreateRoleRequest
+}
+
+func (b CreateRoleRequest_Builder_Name) Name(arg string) CreateRoleRequest_Builder_Permissions {
+	b.root.Name = arg
+	return CreateRoleRequest_Builder_Permissions{root: b.root}
+}
+
+type CreateRoleRequest_Builder_GobFinalizer struct {
+	root *CreateRoleRequest
+}
+
+func (b CreateRoleRequest_Builder_Permissions) Permissions(arg []string) CreateRoleRequest_Builder_GobFinalizer {
+	b.root.Permissions = arg
+	return CreateRoleRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateRoleRequest_Builder_GobFinalizer) Build() *CreateRoleRequest {
+	return b.root
+}
+
+func NewPermissionResponseBuilder() PermissionResponse_Builder_Description {
+	return PermissionResponse_Builder_Description{root: &PermissionResponse{}}
+}
+
+type PermissionResponse_Builder_Description struct {
+	root *PermissionResponse
+}
+
+type PermissionResponse_Builder_Name struct {
+	root *PermissionResponse
+}
+
+func (b PermissionResponse_Builder_Description) Description(arg string) PermissionResponse_Builder_Name {
+	b.root.Description = arg
+	return PermissionResponse_Builder_Name{root: b.root}
+}
+
+type PermissionResponse_Builder_SystemOnly struct {
+	root *PermissionResponse
+}
+
+func (b PermissionResponse_Builder_Name) Name(arg string) PermissionResponse_Builder_SystemOnly {
+	b.root.Name = arg
+	return PermissionResponse_Builder_SystemOnly{root: b.root}
+}
+
+type PermissionResponse_Builder_GobFinalizer struct {
+	root *PermissionResponse
+}
+
+func (b PermissionResponse_Builder_SystemOnly) SystemOnly(arg bool) PermissionResponse_Builder_GobFinalizer {
+	b.root.SystemOnly = arg
+	return PermissionResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b PermissionResponse_Builder_GobFinalizer) Build() *PermissionResponse {
+	return b.root
+}
+
+func NewPermissionsResponseBuilder() PermissionsResponse_Builder_Items {
+	return PermissionsResponse_Builder_Items{root: &PermissionsResponse{}}
+}
+
+type PermissionsResponse_Builder_Items struct {
+	root *PermissionsResponse
+}
+
+type PermissionsResponse_Builder_GobFinalizer struct {
+	root *PermissionsResponse
+}
+
+func (b PermissionsResponse_Builder_Items) Items(arg []PermissionResponse) PermissionsResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return PermissionsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b PermissionsResponse_Builder_GobFinalizer) Build() *PermissionsResponse {
+	return b.root
+}
+
+func NewRoleResponseBuilder() RoleResponse_Builder_Description {
+	return RoleResponse_Builder_Description{root: &RoleResponse{}}
+}
+
+type RoleResponse_Builder_Description struct {
+	root *RoleResponse
+}
+
+type RoleResponse_Builder_Name struct {
+	root *RoleResponse
+}
+
+func (b RoleResponse_Builder_Description) Description(arg string) RoleResponse_Builder_Name {
+	b.root.Description = arg
+	return RoleResponse_Builder_Name{root: b.root}
+}
+
+type RoleResponse_Builder_Permissions struct {
+	root *RoleResponse
+}
+
+func (b RoleResponse_Builder_Name) Name(arg string) RoleResponse_Builder_Permissions {
+	b.root.Name = arg
+	return RoleResponse_Builder_Permissions{root: b.root}
+}
+
+type RoleResponse_Builder_System struct {
+	root *RoleResponse
+}
+
+func (b RoleResponse_Builder_Permissions) Permissions(arg []string) RoleResponse_Builder_System {
+	b.root.Permissions = arg
+	return RoleResponse_Builder_System{root: b.root}
+}
+
+type RoleResponse_Builder_TenantId struct {
+	root *RoleResponse
+}
+
+func (b RoleResponse_Builder_System) System(arg bool) RoleResponse_Builder_TenantId {
+	b.root.System = arg
+	return RoleResponse_Builder_TenantId{root: b.root}
+}
+
+type RoleResponse_Builder_GobFinalizer struct {
+	root *RoleResponse
+}
+
+func (b RoleResponse_Builder_TenantId) TenantId(arg *string) RoleResponse_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return RoleResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b RoleResponse_Builder_GobFinalizer) Build() *RoleResponse {
+	return b.root
+}
+
+func NewRolesResponseBuilder() RolesResponse_Builder_Items {
+	return RolesResponse_Builder_Items{root: &RolesResponse{}}
+}
+
+type RolesResponse_Builder_Items struct {
+	root *RolesResponse
+}
+
+type RolesResponse_Builder_GobFinalizer struct {
+	root *RolesResponse
+}
+
+func (b RolesResponse_Builder_Items) Items(arg []RoleResponse) RolesResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return RolesResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b RolesResponse_Builder_GobFinalizer) Build() *RolesResponse {
+	return b.root
+}
+
+func NewUpdateRoleRequestBuilder() UpdateRoleRequest_Builder_Description {
+	return UpdateRoleRequest_Builder_Description{root: &UpdateRoleRequest{}}
+}
+
+type UpdateRoleRequest_Builder_Description struct {
+	root *UpdateRoleRequest
+}
+
+type UpdateRoleRequest_Builder_Permissions struct {
+	root *UpdateRoleRequest
+}
+
+func (b UpdateRoleRequest_Builder_Description) Description(arg *string) UpdateRoleRequest_Builder_Permissions {
+	b.root.Description = arg
+	return UpdateRoleRequest_Builder_Permissions{root: b.root}
+}
+
+type UpdateRoleRequest_Builder_GobFinalizer struct {
+	root *UpdateRoleRequest
+}
+
+func (b UpdateRoleRequest_Builder_Permissions) Permissions(arg []string) UpdateRoleRequest_Builder_GobFinalizer {
+	b.root.Permissions = arg
+	return UpdateRoleRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UpdateRoleRequest_Builder_GobFinalizer) Build() *UpdateRoleRequest {
+	return b.root
+}
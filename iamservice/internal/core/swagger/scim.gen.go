@@ -0,0 +1,226 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// ScimAuthenticationScheme defines model for ScimAuthenticationScheme.
+type ScimAuthenticationScheme struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+}
+
+// ScimBulkSupport defines model for ScimBulkSupport.
+type ScimBulkSupport struct {
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+	Supported      bool `json:"supported"`
+}
+
+// ScimEmail defines model for ScimEmail.
+type ScimEmail struct {
+	Primary *bool   `json:"primary,omitempty"`
+	Type    *string `json:"type,omitempty"`
+
+	// Value Email address
+	Value string `json:"value"`
+}
+
+// ScimError defines model for ScimError.
+type ScimError struct {
+	Detail  *string  `json:"detail,omitempty"`
+	Schemas []string `json:"schemas"`
+
+	// ScimType SCIM error type of 400 and 409 errors
+	ScimType *string `json:"scimType,omitempty"`
+
+	// Status HTTP status code
+	Status string `json:"status"`
+}
+
+// ScimFilterSupport defines model for ScimFilterSupport.
+type ScimFilterSupport struct {
+	MaxResults int  `json:"maxResults"`
+	Supported  bool `json:"supported"`
+}
+
+// ScimGroup SCIM group resource, displayName is the name of the role
+type ScimGroup struct {
+	DisplayName string `json:"displayName"`
+
+	// Id Name of the role, returned only
+	Id      *string          `json:"id,omitempty"`
+	Members *[]ScimMemberRef `json:"members,omitempty"`
+
+	// Meta Resource metadata
+	Meta    *ScimMeta `json:"meta,omitempty"`
+	Schemas []string  `json:"schemas"`
+}
+
+// ScimGroupListResponse defines model for ScimGroupListResponse.
+type ScimGroupListResponse struct {
+	Resources    []ScimGroup `json:"Resources"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Schemas      []string    `json:"schemas"`
+	StartIndex   int         `json:"startIndex"`
+	TotalResults int         `json:"totalResults"`
+}
+
+// ScimMemberRef Member of a group, or group of a user
+type ScimMemberRef struct {
+	// Display userName of the user or name of the role, returned only
+	Display *string `json:"display,omitempty"`
+
+	// Value ID of the user or name of the role
+	Value string `json:"value"`
+}
+
+// ScimMeta Resource metadata
+type ScimMeta struct {
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+
+	// Location URI of the resource
+	Location     string `json:"location"`
+	ResourceType string `json:"resourceType"`
+}
+
+// ScimPatchOperation defines model for ScimPatchOperation.
+type ScimPatchOperation struct {
+	// Op add, remove or replace, case-insensitive
+	Op string `json:"op"`
+
+	// Path Attribute path, operations without path apply the attributes of the value
+	Path *string `json:"path,omitempty"`
+
+	// Value New value, of any JSON type
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// ScimPatchRequest defines model for ScimPatchRequest.
+type ScimPatchRequest struct {
+	Operations []ScimPatchOperation `json:"Operations"`
+	Schemas    []string             `json:"schemas"`
+}
+
+// ScimServiceProviderConfig defines model for ScimServiceProviderConfig.
+type ScimServiceProviderConfig struct {
+	AuthenticationSchemes []ScimAuthenticationScheme `json:"authenticationSchemes"`
+	Bulk                  ScimBulkSupport            `json:"bulk"`
+	ChangePassword        ScimSupported              `json:"changePassword"`
+	Etag                  ScimSupported              `json:"etag"`
+	Filter                ScimFilterSupport          `json:"filter"`
+	Patch                 ScimSupported              `json:"patch"`
+	Schemas               []string                   `json:"schemas"`
+	Sort                  ScimSupported              `json:"sort"`
+}
+
+// ScimSupported defines model for ScimSupported.
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// ScimUser SCIM user resource, userName is the email of the user
+type ScimUser struct {
+	// Active Deactivated users cannot sign in, defaults to true
+	Active *bool `json:"active,omitempty"`
+
+	// DisplayName Full name of the user, returned only
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Emails The email of the user, returned only
+	Emails *[]ScimEmail `json:"emails,omitempty"`
+
+	// Groups Roles of the user, returned only
+	Groups *[]ScimMemberRef `json:"groups,omitempty"`
+
+	// Id User unique identifier, returned only
+	Id *string `json:"id,omitempty"`
+
+	// Meta Resource metadata
+	Meta *ScimMeta `json:"meta,omitempty"`
+
+	// Name Components of the name of a user
+	Name *ScimUserName `json:"name,omitempty"`
+
+	// Password Initial password of a created user, never returned
+	Password *string  `json:"password,omitempty"`
+	Schemas  []string `json:"schemas"`
+	UserName string   `json:"userName"`
+}
+
+// ScimUserListResponse defines model for ScimUserListResponse.
+type ScimUserListResponse struct {
+	Resources    []ScimUser `json:"Resources"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Schemas      []string   `json:"schemas"`
+	StartIndex   int        `json:"startIndex"`
+	TotalResults int        `json:"totalResults"`
+}
+
+// ScimUserName Components of the name of a user
+type ScimUserName struct {
+	// FamilyName Last name of the user
+	FamilyName *string `json:"familyName,omitempty"`
+
+	// Formatted Full name, returned only
+	Formatted *string `json:"formatted,omitempty"`
+
+	// GivenName First name of the user
+	GivenName *string `json:"givenName,omitempty"`
+}
+
+// ListScimGroupsParams defines parameters for ListScimGroups.
+type ListScimGroupsParams struct {
+	// Filter Filter of the form `attribute eq "value"`, supported attributes are id and displayName
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// StartIndex 1-based index of the first returned group
+	StartIndex *int `form:"startIndex,omitempty" json:"startIndex,omitempty"`
+
+	// Count Maximal number of returned groups
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+
+	// ExcludedAttributes Comma-separated attributes left out of the groups, only members is supported
+	ExcludedAttributes *string `form:"excludedAttributes,omitempty" json:"excludedAttributes,omitempty"`
+}
+
+// GetScimGroupParams defines parameters for GetScimGroup.
+type GetScimGroupParams struct {
+	// ExcludedAttributes Comma-separated attributes left out of the group, only members is supported
+	ExcludedAttributes *string `form:"excludedAttributes,omitempty" json:"excludedAttributes,omitempty"`
+}
+
+// ListScimUsersParams defines parameters for ListScimUsers.
+type ListScimUsersParams struct {
+	// Filter Filter of the form `attribute eq "value"`, supported attributes are id, userName, emails.value and active
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty"`
+
+	// StartIndex 1-based index of the first returned user
+	StartIndex *int `form:"startIndex,omitempty" json:"startIndex,omitempty"`
+
+	// Count Maximal number of returned users
+	Count *int `form:"count,omitempty" json:"count,omitempty"`
+}
+
+// CreateScimGroupApplicationScimPlusJSONRequestBody defines body for CreateScimGroup for application/scim+json ContentType.
+type CreateScimGroupApplicationScimPlusJSONRequestBody = ScimGroup
+
+// PatchScimGroupApplicationScimPlusJSONRequestBody defines body for PatchScimGroup for application/scim+json ContentType.
+type PatchScimGroupApplicationScimPlusJSONRequestBody = ScimPatchRequest
+
+// ReplaceScimGroupApplicationScimPlusJSONRequestBody defines body for ReplaceScimGroup for application/scim+json ContentType.
+type ReplaceScimGroupApplicationScimPlusJSONRequestBody = ScimGroup
+
+// CreateScimUserApplicationScimPlusJSONRequestBody defines body for CreateScimUser for application/scim+json ContentType.
+type CreateScimUserApplicationScimPlusJSONRequestBody = ScimUser
+
+// PatchScimUserApplicationScimPlusJSONRequestBody defines body for PatchScimUser for application/scim+json ContentType.
+type PatchScimUserApplicationScimPlusJSONRequestBody = ScimPatchRequest
+
+// ReplaceScimUserApplicationScimPlusJSONRequestBody defines body for ReplaceScimUser for application/scim+json ContentType.
+type ReplaceScimUserApplicationScimPlusJSONRequestBody = ScimUser
@@ -0,0 +1,874 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewScimAuthenticationSchemeBuilder() ScimAuthenticationScheme_Builder_Description {
+	return ScimAuthenticationScheme_Builder_Description{root: &ScimAuthenticationScheme{}}
+}
+
+type ScimAuthenticationScheme_Builder_Description struct {
+	root *ScimAuthenticationScheme
+}
+
+type ScimAuthenticationScheme_Builder_Name struct {
+	root *ScimAuthenticationScheme
+}
+
+func (b ScimAuthenticationScheme_Builder_Description) Description(arg string) ScimAuthenticationScheme_Builder_Name {
+	b.root.Description = arg
+	return ScimAuthenticationScheme_Builder_Name{root: b.root}
+}
+
+type ScimAuthenticationScheme_Builder_Type struct {
+	root *ScimAuthenticationScheme
+}
+
+func (b ScimAuthenticationScheme_Builder_Name) Name(arg string) ScimAuthenticationScheme_Builder_Type {
+	b.root.Name = arg
+	return ScimAuthenticationScheme_Builder_Type{root: b.root}
+}
+
+type ScimAuthenticationScheme_Builder_GobFinalizer struct {
+	root *ScimAuthenticationScheme
+}
+
+func (b ScimAuthenticationScheme_Builder_Type) Type(arg string) ScimAuthenticationScheme_Builder_GobFinalizer {
+	b.root.Type = arg
+	return ScimAuthenticationScheme_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimAuthenticationScheme_Builder_GobFinalizer) Build() *ScimAuthenticationScheme {
+	return b.root
+}
+
+func NewScimBulkSupportBuilder() ScimBulkSupport_Builder_MaxOperations {
+	return ScimBulkSupport_Builder_MaxOperations{root: &ScimBulkSupport{}}
+}
+
+type ScimBulkSupport_Builder_MaxOperations struct {
+	root *ScimBulkSupport
+}
+
+type ScimBulkSupport_Builder_MaxPayloadSize struct {
+	root *ScimBulkSupport
+}
+
+func (b ScimBulkSupport_Builder_MaxOperations) MaxOperations(arg int) ScimBulkSupport_Builder_MaxPayloadSize {
+	b.root.MaxOperations = arg
+	return ScimBulkSupport_Builder_MaxPayloadSize{root: b.root}
+}
+
+type ScimBulkSupport_Builder_Supported struct {
+	root *ScimBulkSupport
+}
+
+func (b ScimBulkSupport_Builder_MaxPayloadSize) MaxPayloadSize(arg int) ScimBulkSupport_Builder_Supported {
+	b.root.MaxPayloadSize = arg
+	return ScimBulkSupport_Builder_Supported{root: b.root}
+}
+
+type ScimBulkSupport_Builder_GobFinalizer struct {
+	root *ScimBulkSupport
+}
+
+func (b ScimBulkSupport_Builder_Supported) Supported(arg bool) ScimBulkSupport_Builder_GobFinalizer {
+	b.root.Supported = arg
+	return ScimBulkSupport_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimBulkSupport_Builder_GobFinalizer) Build() *ScimBulkSupport {
+	return b.root
+}
+
+func NewScimEmailBuilder() ScimEmail_Builder_Primary {
+	return ScimEmail_Builder_Primary{root: &ScimEmail{}}
+}
+
+type ScimEmail_Builder_Primary struct {
+	root *ScimEmail
+}
+
+type ScimEmail_Builder_Type struct {
+	root *ScimEmail
+}
+
+func (b ScimEmail_Builder_Primary) Primary(arg *bool) ScimEmail_Builder_Type {
+	b.root.Primary = arg
+	return ScimEmail_Builder_Type{root: b.root}
+}
+
+type ScimEmail_Builder_Value struct {
+	root *ScimEmail
+}
+
+func (b ScimEmail_Builder_Type) Type(arg *string) ScimEmail_Builder_Value {
+	b.root.Type = arg
+	return ScimEmail_Builder_Value{root: b.root}
+}
+
+type ScimEmail_Builder_GobFinalizer struct {
+	root *ScimEmail
+}
+
+func (b ScimEmail_Builder_Value) Value(arg string) ScimEmail_Builder_GobFinalizer {
+	b.root.Value = arg
+	return ScimEmail_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimEmail_Builder_GobFinalizer) Build() *ScimEmail {
+	return b.root
+}
+
+func NewScimErrorBuilder() ScimError_Builder_Detail {
+	return ScimError_Builder_Detail{root: &ScimError{}}
+}
+
+type ScimError_Builder_Detail struct {
+	root *ScimError
+}
+
+type ScimError_Builder_Schemas struct {
+	root *ScimError
+}
+
+func (b ScimError_Builder_Detail) Detail(arg *string) ScimError_Builder_Schemas {
+	b.root.Detail = arg
+	return ScimError_Builder_Schemas{root: b.root}
+}
+
+type ScimError_Builder_ScimType struct {
+	root *ScimError
+}
+
+func (b ScimError_Builder_Schemas) Schemas(arg []string) ScimError_Builder_ScimType {
+	b.root.Schemas = arg
+	return ScimError_Builder_ScimType{root: b.root}
+}
+
+type ScimError_Builder_Status struct {
+	root *ScimError
+}
+
+func (b ScimError_Builder_ScimType) ScimType(arg *string) ScimError_Builder_Status {
+	b.root.ScimType = arg
+	return ScimError_Builder_Status{root: b.root}
+}
+
+type ScimError_Builder_GobFinalizer struct {
+	root *ScimError
+}
+
+func (b ScimError_Builder_Status) Status(arg string) ScimError_Builder_GobFinalizer {
+	b.root.Status = arg
+	return ScimError_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimError_Builder_GobFinalizer) Build() *ScimError {
+	return b.root
+}
+
+func NewScimFilterSupportBuilder() ScimFilterSupport_Builder_MaxResults {
+	return ScimFilterSupport_Builder_MaxResults{root: &ScimFilterSupport{}}
+}
+
+type ScimFilterSupport_Builder_MaxResults struct {
+	root *ScimFilterSupport
+}
+
+type ScimFilterSupport_Builder_Supported struct {
+	root *ScimFilterSupport
+}
+
+func (b ScimFilterSupport_Builder_MaxResults) MaxResults(arg int) ScimFilterSupport_Builder_Supported {
+	b.root.MaxResults = arg
+	return ScimFilterSupport_Builder_Supported{root: b.root}
+}
+
+type ScimFilterSupport_Builder_GobFinalizer struct {
+	root *ScimFilterSupport
+}
+
+func (b ScimFilterSupport_Builder_Supported) Supported(arg bool) ScimFilterSupport_Builder_GobFinalizer {
+	b.root.Supported = arg
+	return ScimFilterSupport_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimFilterSupport_Builder_GobFinalizer) Build() *ScimFilterSupport {
+	return b.root
+}
+
+func NewScimGroupBuilder() ScimGroup_Builder_DisplayName {
+	return ScimGroup_Builder_DisplayName{root: &ScimGroup{}}
+}
+
+type ScimGroup_Builder_DisplayName struct {
+	root *ScimGroup
+}
+
+type ScimGroup_Builder_Id struct {
+	root *ScimGroup
+}
+
+func (b ScimGroup_Builder_DisplayName) DisplayName(arg string) ScimGroup_Builder_Id {
+	b.root.DisplayName = arg
+	return ScimGroup_Builder_Id{root: b.root}
+}
+
+type ScimGroup_Builder_Members struct {
+	root *ScimGroup
+}
+
+func (b ScimGroup_Builder_Id) Id(arg *string) ScimGroup_Builder_Members {
+	b.root.Id = arg
+	return ScimGroup_Builder_Members{root: b.root}
+}
+
+type ScimGroup_Builder_Meta struct {
+	root *ScimGroup
+}
+
+func (b ScimGroup_Builder_Members) Members(arg *[]ScimMemberRef) ScimGroup_Builder_Meta {
+	b.root.Members = arg
+	return ScimGroup_Builder_Meta{root: b.root}
+}
+
+type ScimGroup_Builder_Schemas struct {
+	root *ScimGroup
+}
+
+func (b ScimGroup_Builder_Meta) Meta(arg *ScimMeta) ScimGroup_Builder_Schemas {
+	b.root.Meta = arg
+	return ScimGroup_Builder_Schemas{root: b.root}
+}
+
+type ScimGroup_Builder_GobFinalizer struct {
+	root *ScimGroup
+}
+
+func (b ScimGroup_Builder_Schemas) Schemas(arg []string) ScimGroup_Builder_GobFinalizer {
+	b.root.Schemas = arg
+	return ScimGroup_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimGroup_Builder_GobFinalizer) Build() *ScimGroup {
+	return b.root
+}
+
+func NewScimGroupListResponseBuilder() ScimGroupListResponse_Builder_Resources {
+	return ScimGroupListResponse_Builder_Resources{root: &ScimGroupListResponse{}}
+}
+
+type ScimGroupListResponse_Builder_Resources struct {
+	root *ScimGroupListResponse
+}
+
+type ScimGroupListResponse_Builder_ItemsPerPage struct {
+	root *ScimGroupListResponse
+}
+
+func (b ScimGroupListResponse_Builder_Resources) Resources(arg []ScimGroup) ScimGroupListResponse_Builder_ItemsPerPage {
+	b.root.Resources = arg
+	return ScimGroupListResponse_Builder_ItemsPerPage{root: b.root}
+}
+
+type ScimGroupListResponse_Builder_Schemas struct {
+	root *ScimGroupListResponse
+}
+
+func (b ScimGroupListResponse_Builder_ItemsPerPage) ItemsPerPage(arg int) ScimGroupListResponse_Builder_Schemas {
+	b.root.ItemsPerPage = arg
+	return ScimGroupListResponse_Builder_Schemas{root: b.root}
+}
+
+type ScimGroupListResponse_Builder_StartIndex struct {
+	root *ScimGroupListResponse
+}
+
+func (b ScimGroupListResponse_Builder_Schemas) Schemas(arg []string) ScimGroupListResponse_Builder_StartIndex {
+	b.root.Schemas = arg
+	return ScimGroupListResponse_Builder_StartIndex{root: b.root}
+}
+
+type ScimGroupListResponse_Builder_TotalResults struct {
+	root *ScimGroupListResponse
+}
+
+func (b ScimGroupListResponse_Builder_StartIndex) StartIndex(arg int) ScimGroupListResponse_Builder_TotalResults {
+	b.root.StartIndex = arg
+	return ScimGroupListResponse_Builder_TotalResults{root: b.root}
+}
+
+type ScimGroupListResponse_Builder_GobFinalizer struct {
+	root *ScimGroupListResponse
+}
+
+func (b ScimGroupListResponse_Builder_TotalResults) TotalResults(arg int) ScimGroupListResponse_Builder_GobFinalizer {
+	b.root.TotalResults = arg
+	return ScimGroupListResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimGroupListResponse_Builder_GobFinalizer) Build() *ScimGroupListResponse {
+	return b.root
+}
+
+func NewScimMemberRefBuilder() ScimMemberRef_Builder_Display {
+	return ScimMemberRef_Builder_Display{root: &ScimMemberRef{}}
+}
+
+type ScimMemberRef_Builder_Display struct {
+	root *ScimMemberRef
+}
+
+type ScimMemberRef_Builder_Value struct {
+	root *ScimMemberRef
+}
+
+func (b ScimMemberRef_Builder_Display) Display(arg *string) ScimMemberRef_Builder_Value {
+	b.root.Display = arg
+	return ScimMemberRef_Builder_Value{root: b.root}
+}
+
+type ScimMemberRef_Builder_GobFinalizer struct {
+	root *ScimMemberRef
+}
+
+func (b ScimMemberRef_Builder_Value) Value(arg string) ScimMemberRef_Builder_GobFinalizer {
+	b.root.Value = arg
+	return ScimMemberRef_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimMemberRef_Builder_GobFinalizer) Build() *ScimMemberRef {
+	return b.root
+}
+
+func NewScimMetaBuilder() ScimMeta_Builder_Created {
+	return ScimMeta_Builder_Created{root: &ScimMeta{}}
+}
+
+type ScimMeta_Builder_Created struct {
+	root *ScimMeta
+}
+
+type ScimMeta_Builder_LastModified struct {
+	root *ScimMeta
+}
+
+func (b ScimMeta_Builder_Created) Created(arg time.Time) ScimMeta_Builder_LastModified {
+	b.root.Created = arg
+	return ScimMeta_Builder_LastModified{root: b.root}
+}
+
+type ScimMeta_Builder_Location struct {
+	root *ScimMeta
+}
+
+func (b ScimMeta_Builder_LastModified) LastModified(arg time.Time) ScimMeta_Builder_Location {
+	b.root.LastModified = arg
+	return ScimMeta_Builder_Location{root: b.root}
+}
+
+type ScimMeta_Builder_ResourceType struct {
+	root *ScimMeta
+}
+
+func (b ScimMeta_Builder_Location) Location(arg string) ScimMeta_Builder_ResourceType {
+	b.root.Location = arg
+	return ScimMeta_Builder_ResourceType{root: b.root}
+}
+
+type ScimMeta_Builder_GobFinalizer struct {
+	root *ScimMeta
+}
+
+func (b ScimMeta_Builder_ResourceType) ResourceType(arg string) ScimMeta_Builder_GobFinalizer {
+	b.root.ResourceType = arg
+	return ScimMeta_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimMeta_Builder_GobFinalizer) Build() *ScimMeta {
+	return b.root
+}
+
+func NewScimPatchOperationBuilder() ScimPatchOperation_Builder_Op {
+	return ScimPatchOperation_Builder_Op{root: &ScimPatchOperation{}}
+}
+
+type ScimPatchOperation_Builder_Op struct {
+	root *ScimPatchOperation
+}
+
+type ScimPatchOperation_Builder_Path struct {
+	root *ScimPatchOperation
+}
+
+func (b ScimPatchOperation_Builder_Op) Op(arg string) ScimPatchOperation_Builder_Path {
+	b.root.Op = arg
+	return ScimPatchOperation_Builder_Path{root: b.root}
+}
+
+type ScimPatchOperation_Builder_Value struct {
+	root *ScimPatchOperation
+}
+
+func (b ScimPatchOperation_Builder_Path) Path(arg *string) ScimPatchOperation_Builder_Value {
+	b.root.Path = arg
+	return ScimPatchOperation_Builder_Value{root: b.root}
+}
+
+type ScimPatchOperation_Builder_GobFinalizer struct {
+	root *ScimPatchOperation
+}
+
+func (b ScimPatchOperation_Builder_Value) Value(arg *interface{}) ScimPatchOperation_Builder_GobFinalizer {
+	b.root.Value = arg
+	return ScimPatchOperation_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimPatchOperation_Builder_GobFinalizer) Build() *ScimPatchOperation {
+	return b.root
+}
+
+func NewScimPatchRequestBuilder() ScimPatchRequest_Builder_Operations {
+	return ScimPatchRequest_Builder_Operations{root: &ScimPatchRequest{}}
+}
+
+type ScimPatchRequest_Builder_Operations struct {
+	root *ScimPatchRequest
+}
+
+type ScimPatchRequest_Builder_Schemas struct {
+	root *ScimPatchRequest
+}
+
+func (b ScimPatchRequest_Builder_Operations) Operations(arg []ScimPatchOperation) ScimPatchRequest_Builder_Schemas {
+	b.root.Operations = arg
+	return ScimPatchRequest_Builder_Schemas{root: b.root}
+}
+
+type ScimPatchRequest_Builder_GobFinalizer struct {
+	root *ScimPatchRequest
+}
+
+func (b ScimPatchRequest_Builder_Schemas) Schemas(arg []string) ScimPatchRequest_Builder_GobFinalizer {
+	b.root.Schemas = arg
+	return ScimPatchRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimPatchRequest_Builder_GobFinalizer) Build() *ScimPatchRequest {
+	return b.root
+}
+
+func NewScimServiceProviderConfigBuilder() ScimServiceProviderConfig_Builder_AuthenticationSchemes {
+	return ScimServiceProviderConfig_Builder_AuthenticationSchemes{root: &ScimServiceProviderConfig{}}
+}
+
+type ScimServiceProviderConfig_Builder_AuthenticationSchemes struct {
+	root *ScimServiceProviderConfig
+}
+
+type ScimServiceProviderConfig_Builder_Bulk struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_AuthenticationSchemes) AuthenticationSchemes(arg []ScimAuthenticationScheme) ScimServiceProviderConfig_Builder_Bulk {
+	b.root.AuthenticationSchemes = arg
+	return ScimServiceProviderConfig_Builder_Bulk{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_ChangePassword struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Bulk) Bulk(arg ScimBulkSupport) ScimServiceProviderConfig_Builder_ChangePassword {
+	b.root.Bulk = arg
+	return ScimServiceProviderConfig_Builder_ChangePassword{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_Etag struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_ChangePassword) ChangePassword(arg ScimSupported) ScimServiceProviderConfig_Builder_Etag {
+	b.root.ChangePassword = arg
+	return ScimServiceProviderConfig_Builder_Etag{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_Filter struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Etag) Etag(arg ScimSupported) ScimServiceProviderConfig_Builder_Filter {
+	b.root.Etag = arg
+	return ScimServiceProviderConfig_Builder_Filter{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_Patch struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Filter) Filter(arg ScimFilterSupport) ScimServiceProviderConfig_Builder_Patch {
+	b.root.Filter = arg
+	return ScimServiceProviderConfig_Builder_Patch{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_Schemas struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Patch) Patch(arg ScimSupported) ScimServiceProviderConfig_Builder_Schemas {
+	b.root.Patch = arg
+	return ScimServiceProviderConfig_Builder_Schemas{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_Sort struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Schemas) Schemas(arg []string) ScimServiceProviderConfig_Builder_Sort {
+	b.root.Schemas = arg
+	return ScimServiceProviderConfig_Builder_Sort{root: b.root}
+}
+
+type ScimServiceProviderConfig_Builder_GobFinalizer struct {
+	root *ScimServiceProviderConfig
+}
+
+func (b ScimServiceProviderConfig_Builder_Sort) Sort(arg ScimSupported) ScimServiceProviderConfig_Builder_GobFinalizer {
+	b.root.Sort = arg
+	return ScimServiceProviderConfig_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimServiceProviderConfig_Builder_GobFinalizer) Build() *ScimServiceProviderConfig {
+	return b.root
+}
+
+func NewScimSupportedBuilder() ScimSupported_Builder_Supported {
+	return ScimSupported_Builder_Supported{root: &ScimSupported{}}
+}
+
+type ScimSupported_Builder_Supported struct {
+	root *ScimSupported
+}
+
+type ScimSupported_Builder_GobFinalizer struct {
+	root *ScimSupported
+}
+
+func (b ScimSupported_Builder_Supported) Supported(arg bool) ScimSupported_Builder_GobFinalizer {
+	b.root.Supported = arg
+	return ScimSupported_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimSupported_Builder_GobFinalizer) Build() *ScimSupported {
+	return b.root
+}
+
+func NewScimUserBuilder() ScimUser_Builder_Active {
+	return ScimUser_Builder_Active{root: &ScimUser{}}
+}
+
+type ScimUser_Builder_Active struct {
+	root *ScimUser
+}
+
+type ScimUser_Builder_DisplayName struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Active) Active(arg *bool) ScimUser_Builder_DisplayName {
+	b.root.Active = arg
+	return ScimUser_Builder_DisplayName{root: b.root}
+}
+
+type ScimUser_Builder_Emails struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_DisplayName) DisplayName(arg *string) ScimUser_Builder_Emails {
+	b.root.DisplayName = arg
+	return ScimUser_Builder_Emails{root: b.root}
+}
+
+type ScimUser_Builder_Groups struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Emails) Emails(arg *[]ScimEmail) ScimUser_Builder_Groups {
+	b.root.Emails = arg
+	return ScimUser_Builder_Groups{root: b.root}
+}
+
+type ScimUser_Builder_Id struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Groups) Groups(arg *[]ScimMemberRef) ScimUser_Builder_Id {
+	b.root.Groups = arg
+	return ScimUser_Builder_Id{root: b.root}
+}
+
+type ScimUser_Builder_Meta struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Id) Id(arg *string) ScimUser_Builder_Meta {
+	b.root.Id = arg
+	return ScimUser_Builder_Meta{root: b.root}
+}
+
+type ScimUser_Builder_Name struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Meta) Meta(arg *ScimMeta) ScimUser_Builder_Name {
+	b.root.Meta = arg
+	return ScimUser_Builder_Name{root: b.root}
+}
+
+type ScimUser_Builder_Password struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Name) Name(arg *ScimUserName) ScimUser_Builder_Password {
+	b.root.Name = arg
+	return ScimUser_Builder_Password{root: b.root}
+}
+
+type ScimUser_Builder_Schemas struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Password) Password(arg *string) ScimUser_Builder_Schemas {
+	b.root.Password = arg
+	return ScimUser_Builder_Schemas{root: b.root}
+}
+
+type ScimUser_Builder_UserName struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_Schemas) Schemas(arg []string) ScimUser_Builder_UserName {
+	b.root.Schemas = arg
+	return ScimUser_Builder_UserName{root: b.root}
+}
+
+type ScimUser_Builder_GobFinalizer struct {
+	root *ScimUser
+}
+
+func (b ScimUser_Builder_UserName) UserName(arg string) ScimUser_Builder_GobFinalizer {
+	b.root.UserName = arg
+	return ScimUser_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimUser_Builder_GobFinalizer) Build() *ScimUser {
+	return b.root
+}
+
+func NewScimUserListResponseBuilder() ScimUserListResponse_Builder_Resources {
+	return ScimUserListResponse_Builder_Resources{root: &ScimUserListResponse{}}
+}
+
+type ScimUserListResponse_Builder_Resources struct {
+	root *ScimUserListResponse
+}
+
+type ScimUserListResponse_Builder_ItemsPerPage struct {
+	root *ScimUserListResponse
+}
+
+func (b ScimUserListResponse_Builder_Resources) Resources(arg []ScimUser) ScimUserListResponse_Builder_ItemsPerPage {
+	b.root.Resources = arg
+	return ScimUserListResponse_Builder_ItemsPerPage{root: b.root}
+}
+
+type ScimUserListResponse_Builder_Schemas struct {
+	root *ScimUserListResponse
+}
+
+func (b ScimUserListResponse_Builder_ItemsPerPage) ItemsPerPage(arg int) ScimUserListResponse_Builder_Schemas {
+	b.root.ItemsPerPage = arg
+	return ScimUserListResponse_Builder_Schemas{root: b.root}
+}
+
+type ScimUserListResponse_Builder_StartIndex struct {
+	root *ScimUserListResponse
+}
+
+func (b ScimUserListResponse_Builder_Schemas) Schemas(arg []string) ScimUserListResponse_Builder_StartIndex {
+	b.root.Schemas = arg
+	return ScimUserListResponse_Builder_StartIndex{root: b.root}
+}
+
+type ScimUserListResponse_Builder_TotalResults struct {
+	root *ScimUserListResponse
+}
+
+func (b ScimUserListResponse_Builder_StartIndex) StartIndex(arg int) ScimUserListResponse_Builder_TotalResults {
+	b.root.StartIndex = arg
+	return ScimUserListResponse_Builder_TotalResults{root: b.root}
+}
+
+type ScimUserListResponse_Builder_GobFinalizer struct {
+	root *ScimUserListResponse
+}
+
+func (b ScimUserListResponse_Builder_TotalResults) TotalResults(arg int) ScimUserListResponse_Builder_GobFinalizer {
+	b.root.TotalResults = arg
+	return ScimUserListResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimUserListResponse_Builder_GobFinalizer) Build() *ScimUserListResponse {
+	return b.root
+}
+
+func NewScimUserNameBuilder() ScimUserName_Builder_FamilyName {
+	return ScimUserName_Builder_FamilyName{root: &ScimUserName{}}
+}
+
+type ScimUserName_Builder_FamilyName struct {
+	root *ScimUserName
+}
+
+type ScimUserName_Builder_Formatted struct {
+	root *ScimUserName
+}
+
+func (b ScimUserName_Builder_FamilyName) FamilyName(arg *string) ScimUserName_Builder_Formatted {
+	b.root.FamilyName = arg
+	return ScimUserName_Builder_Formatted{root: b.root}
+}
+
+type ScimUserName_Builder_GivenName struct {
+	root *ScimUserName
+}
+
+func (b ScimUserName_Builder_Formatted) Formatted(arg *string) ScimUserName_Builder_GivenName {
+	b.root.Formatted = arg
+	return ScimUserName_Builder_GivenName{root: b.root}
+}
+
+type ScimUserName_Builder_GobFinalizer struct {
+	root *ScimUserName
+}
+
+func (b ScimUserName_Builder_GivenName) GivenName(arg *string) ScimUserName_Builder_GobFinalizer {
+	b.root.GivenName = arg
+	return ScimUserName_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ScimUserName_Builder_GobFinalizer) Build() *ScimUserName {
+	return b.root
+}
+
+func NewListScimGroupsParamsBuilder() ListScimGroupsParams_Builder_Filter {
+	return ListScimGroupsParams_Builder_Filter{root: &ListScimGroupsParams{}}
+}
+
+type ListScimGroupsParams_Builder_Filter struct {
+	root *ListScimGroupsParams
+}
+
+type ListScimGroupsParams_Builder_StartIndex struct {
+	root *ListScimGroupsParams
+}
+
+func (b ListScimGroupsParams_Builder_Filter) Filter(arg *string) ListScimGroupsParams_Builder_StartIndex {
+	b.root.Filter = arg
+	return ListScimGroupsParams_Builder_StartIndex{root: b.root}
+}
+
+type ListScimGroupsParams_Builder_Count struct {
+	root *ListScimGroupsParams
+}
+
+func (b ListScimGroupsParams_Builder_StartIndex) StartIndex(arg *int) ListScimGroupsParams_Builder_Count {
+	b.root.StartIndex = arg
+	return ListScimGroupsParams_Builder_Count{root: b.root}
+}
+
+type ListScimGroupsParams_Builder_ExcludedAttributes struct {
+	root *ListScimGroupsParams
+}
+
+func (b ListScimGroupsParams_Builder_Count) Count(arg *int) ListScimGroupsParams_Builder_ExcludedAttributes {
+	b.root.Count = arg
+	return ListScimGroupsParams_Builder_ExcludedAttributes{root: b.root}
+}
+
+type ListScimGroupsParams_Builder_GobFinalizer struct {
+	root *ListScimGroupsParams
+}
+
+func (b ListScimGroupsParams_Builder_ExcludedAttributes) ExcludedAttributes(arg *string) ListScimGroupsParams_Builder_GobFinalizer {
+	b.root.ExcludedAttributes = arg
+	return ListScimGroupsParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListScimGroupsParams_Builder_GobFinalizer) Build() *ListScimGroupsParams {
+	return b.root
+}
+
+func NewGetScimGroupParamsBuilder() GetScimGroupParams_Builder_ExcludedAttributes {
+	return GetScimGroupParams_Builder_ExcludedAttributes{root: &GetScimGroupParams{}}
+}
+
+type GetScimGroupParams_Builder_ExcludedAttributes struct {
+	root *GetScimGroupParams
+}
+
+type GetScimGroupParams_Builder_GobFinalizer struct {
+	root *GetScimGroupParams
+}
+
+func (b GetScimGroupParams_Builder_ExcludedAttributes) ExcludedAttributes(arg *string) GetScimGroupParams_Builder_GobFinalizer {
+	b.root.ExcludedAttributes = arg
+	return GetScimGroupParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b GetScimGroupParams_Builder_GobFinalizer) Build() *GetScimGroupParams {
+	return b.root
+}
+
+func NewListScimUsersParamsBuilder() ListScimUsersParams_Builder_Filter {
+	return ListScimUsersParams_Builder_Filter{root: &ListScimUsersParams{}}
+}
+
+type ListScimUsersParams_Builder_Filter struct {
+	root *ListScimUsersParams
+}
+
+type ListScimUsersParams_Builder_StartIndex struct {
+	root *ListScimUsersParams
+}
+
+func (b ListScimUsersParams_Builder_Filter) Filter(arg *string) ListScimUsersParams_Builder_StartIndex {
+	b.root.Filter = arg
+	return ListScimUsersParams_Builder_StartIndex{root: b.root}
+}
+
+type ListScimUsersParams_Builder_Count struct {
+	root *ListScimUsersParams
+}
+
+func (b ListScimUsersParams_Builder_StartIndex) StartIndex(arg *int) ListScimUsersParams_Builder_Count {
+	b.root.StartIndex = arg
+	return ListScimUsersParams_Builder_Count{root: b.root}
+}
+
+type ListScimUsersParams_Builder_GobFinalizer struct {
+	root *ListScimUsersParams
+}
+
+func (b ListScimUsersParams_Builder_Count) Count(arg *int) ListScimUsersParams_Builder_GobFinalizer {
+	b.root.Count = arg
+	return ListScimUsersParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListScimUsersParams_Builder_GobFinalizer) Build() *ListScimUsersParams {
+	return b.root
+}
@@ -0,0 +1,73 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// CreateServiceClientRequest Request payload for creating a service client
+type CreateServiceClientRequest struct {
+	// ExpiresAt Time after which the client can no longer obtain access tokens, never if null
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	// Name Human readable client name
+	Name string `json:"name"`
+
+	// Roles Roles of the client, system roles or custom roles of its tenant that do not grant tenants:all
+	Roles []string `json:"roles"`
+
+	// Scopes Scopes the client may request
+	Scopes []string `json:"scopes"`
+
+	// TenantId Tenant of the client, defaults to the tenant of the caller (requires tenants:all permission for other tenants)
+	TenantId *string `json:"tenantId"`
+}
+
+// CreateServiceClientResponse Created service client with its secret
+type CreateServiceClientResponse struct {
+	// Client Service client
+	Client ServiceClientResponse `json:"client"`
+
+	// ClientSecret Client secret, returned only once
+	ClientSecret string `json:"clientSecret"`
+}
+
+// ServiceClientResponse Service client
+type ServiceClientResponse struct {
+	// ClientId Client ID used with the client_credentials grant
+	ClientId string `json:"clientId"`
+
+	// CreatedAt Creation timestamp
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt Time after which the client can no longer obtain access tokens, never if null
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	// Name Human readable client name
+	Name string `json:"name"`
+
+	// Roles Roles of the client
+	Roles []string `json:"roles"`
+
+	// Scopes Scopes the client may request
+	Scopes []string `json:"scopes"`
+
+	// TenantId Tenant of the client
+	TenantId string `json:"tenantId"`
+}
+
+// ServiceClientsResponse defines model for ServiceClientsResponse.
+type ServiceClientsResponse struct {
+	Items []ServiceClientResponse `json:"items"`
+}
+
+// ListServiceClientsParams defines parameters for ListServiceClients.
+type ListServiceClientsParams struct {
+	// TenantId Tenant of the clients, defaults to the tenant of the caller
+	TenantId *string `form:"tenantId,omitempty" json:"tenantId,omitempty"`
+}
+
+// CreateServiceClientJSONRequestBody defines body for CreateServiceClient for application/json ContentType.
+type CreateServiceClientJSONRequestBody = CreateServiceClientRequest
@@ -0,0 +1,211 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewCreateServiceClientRequestBuilder() CreateServiceClientRequest_Builder_ExpiresAt {
+	return CreateServiceClientRequest_Builder_ExpiresAt{root: &CreateServiceClientRequest{}}
+}
+
+type CreateServiceClientRequest_Builder_ExpiresAt struct {
+	root *CreateServiceClientRequest
+}
+
+type CreateServiceClientRequest_Builder_Name struct {
+	root *CreateServiceClientRequest
+}
+
+func (b CreateServiceClientRequest_Builder_ExpiresAt) ExpiresAt(arg *time.Time) CreateServiceClientRequest_Builder_Name {
+	b.root.ExpiresAt = arg
+	return CreateServiceClientRequest_Builder_Name{root: b.root}
+}
+
+type CreateServiceClientRequest_Builder_Roles struct {
+	root *CreateServiceClientRequest
+}
+
+func (b CreateServiceClientRequest_Builder_Name) Name(arg string) CreateServiceClientRequest_Builder_Roles {
+	b.root.Name = arg
+	return CreateServiceClientRequest_Builder_Roles{root: b.root}
+}
+
+type CreateServiceClientRequest_Builder_Scopes struct {
+	root *CreateServiceClientRequest
+}
+
+func (b CreateServiceClientRequest_Builder_Roles) Roles(arg []string) CreateServiceClientRequest_Builder_Scopes {
+	b.root.Roles = arg
+	return CreateServiceClientRequest_Builder_Scopes{root: b.root}
+}
+
+type CreateServiceClientRequest_Builder_TenantId struct {
+	root *CreateServiceClientRequest
+}
+
+func (b CreateServiceClientRequest_Builder_Scopes) Scopes(arg []string) CreateServiceClientRequest_Builder_TenantId {
+	b.root.Scopes = arg
+	return CreateServiceClientRequest_Builder_TenantId{root: b.root}
+}
+
+type CreateServiceClientRequest_Builder_GobFinalizer struct {
+	root *CreateServiceClientRequest
+}
+
+func (b CreateServiceClientRequest_Builder_TenantId) TenantId(arg *string) CreateServiceClientRequest_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return CreateServiceClientRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateServiceClientRequest_Builder_GobFinalizer) Build() *CreateServiceClientRequest {
+	return b.root
+}
+
+func NewCreateServiceClientResponseBuilder() CreateServiceClientResponse_Builder_Client {
+	return CreateServiceClientResponse_Builder_Client{root: &CreateServiceClientResponse{}}
+}
+
+type CreateServiceClientResponse_Builder_Client struct {
+	root *CreateServiceClientResponse
+}
+
+type CreateServiceClientResponse_Builder_ClientSecret struct {
+	root *CreateServiceClientResponse
+}
+
+func (b CreateServiceClientResponse_Builder_Client) Client(arg ServiceClientResponse) CreateServiceClientResponse_Builder_ClientSecret {
+	b.root.Client = arg
+	return CreateServiceClientResponse_Builder_ClientSecret{root: b.root}
+}
+
+type CreateServiceClientResponse_Builder_GobFinalizer struct {
+	root *CreateServiceClientResponse
+}
+
+func (b CreateServiceClientResponse_Builder_ClientSecret) ClientSecret(arg string) CreateServiceClientResponse_Builder_GobFinalizer {
+	b.root.ClientSecret = arg
+	return CreateServiceClientResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateServiceClientResponse_Builder_GobFinalizer) Build() *CreateServiceClientResponse {
+	return b.root
+}
+
+func NewServiceClientResponseBuilder() ServiceClientResponse_Builder_ClientId {
+	return ServiceClientResponse_Builder_ClientId{root: &ServiceClientResponse{}}
+}
+
+type ServiceClientResponse_Builder_ClientId struct {
+	root *ServiceClientResponse
+}
+
+type ServiceClientResponse_Builder_CreatedAt struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_ClientId) ClientId(arg string) ServiceClientResponse_Builder_CreatedAt {
+	b.root.ClientId = arg
+	return ServiceClientResponse_Builder_CreatedAt{root: b.root}
+}
+
+type ServiceClientResponse_Builder_ExpiresAt struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_CreatedAt) CreatedAt(arg time.Time) ServiceClientResponse_Builder_ExpiresAt {
+	b.root.CreatedAt = arg
+	return ServiceClientResponse_Builder_ExpiresAt{root: b.root}
+}
+
+type ServiceClientResponse_Builder_Name struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_ExpiresAt) ExpiresAt(arg *time.Time) ServiceClientResponse_Builder_Name {
+	b.root.ExpiresAt = arg
+	return ServiceClientResponse_Builder_Name{root: b.root}
+}
+
+type ServiceClientResponse_Builder_Roles struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_Name) Name(arg string) ServiceClientResponse_Builder_Roles {
+	b.root.Name = arg
+	return ServiceClientResponse_Builder_Roles{root: b.root}
+}
+
+type ServiceClientResponse_Builder_Scopes struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_Roles) Roles(arg []string) ServiceClientResponse_Builder_Scopes {
+	b.root.Roles = arg
+	return ServiceClientResponse_Builder_Scopes{root: b.root}
+}
+
+type ServiceClientResponse_Builder_TenantId struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_Scopes) Scopes(arg []string) ServiceClientResponse_Builder_TenantId {
+	b.root.Scopes = arg
+	return ServiceClientResponse_Builder_TenantId{root: b.root}
+}
+
+type ServiceClientResponse_Builder_GobFinalizer struct {
+	root *ServiceClientResponse
+}
+
+func (b ServiceClientResponse_Builder_TenantId) TenantId(arg string) ServiceClientResponse_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ServiceClientResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ServiceClientResponse_Builder_GobFinalizer) Build() *ServiceClientResponse {
+	return b.root
+}
+
+func NewServiceClientsResponseBuilder() ServiceClientsResponse_Builder_Items {
+	return ServiceClientsResponse_Builder_Items{root: &ServiceClientsResponse{}}
+}
+
+type ServiceClientsResponse_Builder_Items struct {
+	root *ServiceClientsResponse
+}
+
+type ServiceClientsResponse_Builder_GobFinalizer struct {
+	root *ServiceClientsResponse
+}
+
+func (b ServiceClientsResponse_Builder_Items) Items(arg []ServiceClientResponse) ServiceClientsResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return ServiceClientsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ServiceClientsResponse_Builder_GobFinalizer) Build() *ServiceClientsResponse {
+	return b.root
+}
+
+func NewListServiceClientsParamsBuilder() ListServiceClientsParams_Builder_TenantId {
+	return ListServiceClientsParams_Builder_TenantId{root: &ListServiceClientsParams{}}
+}
+
+type ListServiceClientsParams_Builder_TenantId struct {
+	root *ListServiceClientsParams
+}
+
+type ListServiceClientsParams_Builder_GobFinalizer struct {
+	root *ListServiceClientsParams
+}
+
+func (b ListServiceClientsParams_Builder_TenantId) TenantId(arg *string) ListServiceClientsParams_Builder_GobFinalizer {
+	b.root.TenantId = arg
+	return ListServiceClientsParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListServiceClientsParams_Builder_GobFinalizer) Build() *ListServiceClientsParams {
+	return b.root
+}
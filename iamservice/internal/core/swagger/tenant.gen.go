@@ -7,6 +7,12 @@ import (
 	"time"
 )
 
+// Defines values for TenantSortBy.
+const (
+	TenantSortByCreatedAt TenantSortBy = "createdAt"
+	TenantSortByName      TenantSortBy = "name"
+)
+
 // CreateTenantRequest Request payload for creating a new tenant
 type CreateTenantRequest struct {
 	// Description Optional description of the tenant
@@ -33,14 +39,23 @@ type TenantResponse struct {
 	// Name Human-readable tenant name
 	Name string `json:"name"`
 
+	// RequireAdminMfa Users with the admin role must sign in with a second factor
+	RequireAdminMfa bool `json:"requireAdminMfa"`
+
 	// UpdatedAt Last tenant update timestamp
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// TenantSortBy defines model for TenantSortBy.
+type TenantSortBy string
+
 // TenantsResponse defines model for TenantsResponse.
 type TenantsResponse struct {
-	Items      []TenantResponse `json:"items"`
-	Pagination PaginationInfo   `json:"pagination"`
+	Items []TenantResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string        `json:"nextCursor"`
+	Pagination PaginationInfo `json:"pagination"`
 }
 
 // UpdateTenantRequest Request payload for updating a tenant
@@ -50,18 +65,30 @@ type UpdateTenantRequest struct {
 
 	// Name Human-readable tenant name
 	Name string `json:"name"`
+
+	// RequireAdminMfa Users with the admin role must sign in with a second factor, unchanged if omitted
+	RequireAdminMfa *bool `json:"requireAdminMfa,omitempty"`
 }
 
 // ListAllTenantsParams defines parameters for ListAllTenants.
 type ListAllTenantsParams struct {
-	// Page Page number for pagination
+	// Page Page number for pagination, ignored when cursor is given
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
 	// Limit Number of tenants per page
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Active Filter by active status
-	Active *bool `form:"active,omitempty" json:"active,omitempty"`
+	// Cursor Returns the page after the one that returned this nextCursor, with the same sorting
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// SortBy Field to sort tenants by
+	SortBy *TenantSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Sort order, descending by default for createdAt and ascending for name
+	SortOrder *SortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Search Case-insensitive part of the tenant ID or name
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
 }
 
 // CreateTenantJSONRequestBody defines body for CreateTenant for application/json ContentType.
@@ -80,12 +80,21 @@ func (b TenantResponse_Builder_Id) Id(arg string) TenantResponse_Builder_Name {
 	return TenantResponse_Builder_Name{root: b.root}
 }
 
-type TenantResponse_Builder_UpdatedAt struct {
+type TenantResponse_Builder_RequireAdminMfa struct {
 	root *TenantResponse
 }
 
-func (b TenantResponse_Builder_Name) Name(arg string) TenantResponse_Builder_UpdatedAt {
+func (b TenantResponse_Builder_Name) Name(arg string) TenantResponse_Builder_RequireAdminMfa {
 	b.root.Name = arg
+	return TenantResponse_Builder_RequireAdminMfa{root: b.root}
+}
+
+type TenantResponse_Builder_UpdatedAt struct {
+	root *TenantResponse
+}
+
+func (b TenantResponse_Builder_RequireAdminMfa) RequireAdminMfa(arg bool) TenantResponse_Builder_UpdatedAt {
+	b.root.RequireAdminMfa = arg
 	return TenantResponse_Builder_UpdatedAt{root: b.root}
 }
 
@@ -110,12 +119,21 @@ type TenantsResponse_Builder_Items struct {
 	root *TenantsResponse
 }
 
-type TenantsResponse_Builder_Pagination struct {
+type TenantsResponse_Builder_NextCursor struct {
 	root *TenantsResponse
 }
 
-func (b TenantsResponse_Builder_Items) Items(arg []TenantResponse) TenantsResponse_Builder_Pagination {
+func (b TenantsResponse_Builder_Items) Items(arg []TenantResponse) TenantsResponse_Builder_NextCursor {
 	b.root.Items = arg
+	return TenantsResponse_Builder_NextCursor{root: b.root}
+}
+
+type TenantsResponse_Builder_Pagination struct {
+	root *TenantsResponse
+}
+
+func (b TenantsResponse_Builder_NextCursor) NextCursor(arg *string) TenantsResponse_Builder_Pagination {
+	b.root.NextCursor = arg
 	return TenantsResponse_Builder_Pagination{root: b.root}
 }
 
@@ -149,12 +167,21 @@ func (b UpdateTenantRequest_Builder_Description) Description(arg string) UpdateT
 	return UpdateTenantRequest_Builder_Name{root: b.root}
 }
 
-type UpdateTenantRequest_Builder_GobFinalizer struct {
+type UpdateTenantRequest_Builder_RequireAdminMfa struct {
 	root *UpdateTenantRequest
 }
 
-func (b UpdateTenantRequest_Builder_Name) Name(arg string) UpdateTenantRequest_Builder_GobFinalizer {
+func (b UpdateTenantRequest_Builder_Name) Name(arg string) UpdateTenantRequest_Builder_RequireAdminMfa {
 	b.root.Name = arg
+	return UpdateTenantRequest_Builder_RequireAdminMfa{root: b.root}
+}
+
+type UpdateTenantRequest_Builder_GobFinalizer struct {
+	root *UpdateTenantRequest
+}
+
+func (b UpdateTenantRequest_Builder_RequireAdminMfa) RequireAdminMfa(arg *bool) UpdateTenantRequest_Builder_GobFinalizer {
+	b.root.RequireAdminMfa = arg
 	return UpdateTenantRequest_Builder_GobFinalizer{root: b.root}
 }
 
@@ -179,21 +206,48 @@ func (b ListAllTenantsParams_Builder_Page) Page(arg *int) ListAllTenantsParams_B
 	return ListAllTenantsParams_Builder_Limit{root: b.root}
 }
 
-type ListAllTenantsParams_Builder_Active struct {
+type ListAllTenantsParams_Builder_Cursor struct {
 	root *ListAllTenantsParams
 }
 
-func (b ListAllTenantsParams_Builder_Limit) Limit(arg *int) ListAllTenantsParams_Builder_Active {
+func (b ListAllTenantsParams_Builder_Limit) Limit(arg *int) ListAllTenantsParams_Builder_Cursor {
 	b.root.Limit = arg
-	return ListAllTenantsParams_Builder_Active{root: b.root}
+	return ListAllTenantsParams_Builder_Cursor{root: b.root}
+}
+
+type ListAllTenantsParams_Builder_SortBy struct {
+	root *ListAllTenantsParams
+}
+
+func (b ListAllTenantsParams_Builder_Cursor) Cursor(arg *string) ListAllTenantsParams_Builder_SortBy {
+	b.root.Cursor = arg
+	return ListAllTenantsParams_Builder_SortBy{root: b.root}
+}
+
+type ListAllTenantsParams_Builder_SortOrder struct {
+	root *ListAllTenantsParams
+}
+
+func (b ListAllTenantsParams_Builder_SortBy) SortBy(arg *TenantSortBy) ListAllTenantsParams_Builder_SortOrder {
+	b.root.SortBy = arg
+	return ListAllTenantsParams_Builder_SortOrder{root: b.root}
+}
+
+type ListAllTenantsParams_Builder_Search struct {
+	root *ListAllTenantsParams
+}
+
+func (b ListAllTenantsParams_Builder_SortOrder) SortOrder(arg *SortOrder) ListAllTenantsParams_Builder_Search {
+	b.root.SortOrder = arg
+	return ListAllTenantsParams_Builder_Search{root: b.root}
 }
 
 type ListAllTenantsParams_Builder_GobFinalizer struct {
 	root *ListAllTenantsParams
 }
 
-func (b ListAllTenantsParams_Builder_Active) Active(arg *bool) ListAllTenantsParams_Builder_GobFinalizer {
-	b.root.Active = arg
+func (b ListAllTenantsParams_Builder_Search) Search(arg *string) ListAllTenantsParams_Builder_GobFinalizer {
+	b.root.Search = arg
 	return ListAllTenantsParams_Builder_GobFinalizer{root: b.root}
 }
 
@@ -23,6 +23,49 @@ const (
 	Other  UserProfileGender = "other"
 )
 
+// Defines values for UserSortBy.
+const (
+	UserSortByCreatedAt UserSortBy = "createdAt"
+	UserSortByEmail     UserSortBy = "email"
+	UserSortByFirstName UserSortBy = "firstName"
+	UserSortByLastName  UserSortBy = "lastName"
+)
+
+// Defines values for UserTransferFormat.
+const (
+	UserTransferFormatCsv    UserTransferFormat = "csv"
+	UserTransferFormatNdjson UserTransferFormat = "ndjson"
+)
+
+// ApiKeyResponse defines model for ApiKeyResponse.
+type ApiKeyResponse struct {
+	// CreatedAt Time the key was created at
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt Time the key expires at, null for keys that do not expire
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	// Id API key ID
+	Id string `json:"id"`
+
+	// LastUsedAt Time the key was last used at, null if it was never used
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+
+	// Name Name of the key
+	Name string `json:"name"`
+
+	// Prefix First characters of the key, to tell keys apart
+	Prefix string `json:"prefix"`
+
+	// Roles Roles the key is limited to
+	Roles []string `json:"roles"`
+}
+
+// ApiKeysResponse defines model for ApiKeysResponse.
+type ApiKeysResponse struct {
+	Items []ApiKeyResponse `json:"items"`
+}
+
 // AssignUserRoleRequest defines model for AssignUserRoleRequest.
 type AssignUserRoleRequest struct {
 	// RoleName Name of the role to assign
@@ -58,8 +101,58 @@ type AuthUserResponse struct {
 
 // AuthUsersResponse defines model for AuthUsersResponse.
 type AuthUsersResponse struct {
-	Items      []AuthUserResponse `json:"items"`
-	Pagination PaginationInfo     `json:"pagination"`
+	Items []AuthUserResponse `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string        `json:"nextCursor"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// ConfirmEmailChangeRequest defines model for ConfirmEmailChangeRequest.
+type ConfirmEmailChangeRequest struct {
+	// Code Confirmation token of the link or 6-digit code sent to the new address
+	Code string `json:"code"`
+}
+
+// CreateApiKeyRequest defines model for CreateApiKeyRequest.
+type CreateApiKeyRequest struct {
+	// ExpiresAt Time the key expires at, the key does not expire if omitted
+	ExpiresAt *time.Time `json:"expiresAt"`
+
+	// Name Name to tell the key apart
+	Name string `json:"name"`
+
+	// Roles Roles the key is limited to, all roles of the user if omitted
+	Roles *[]string `json:"roles,omitempty"`
+}
+
+// CreateApiKeyResponse defines model for CreateApiKeyResponse.
+type CreateApiKeyResponse struct {
+	ApiKey ApiKeyResponse `json:"apiKey"`
+
+	// Key The API key, shown only once
+	Key string `json:"key"`
+}
+
+// EmailChangeRequest defines model for EmailChangeRequest.
+type EmailChangeRequest struct {
+	// CurrentPassword Current password, required when users change their own address
+	CurrentPassword *string `json:"currentPassword"`
+
+	// NewEmail New email address of the user
+	NewEmail openapi_types.Email `json:"newEmail"`
+
+	// Source Platform source (web, android or ios): web receives a confirmation link, mobile a 6-digit code
+	Source *string `json:"source"`
+}
+
+// EmailChangeResponse defines model for EmailChangeResponse.
+type EmailChangeResponse struct {
+	// ExpiresAt Time the confirmation expires at
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// PendingEmail New email address awaiting confirmation
+	PendingEmail openapi_types.Email `json:"pendingEmail"`
 }
 
 // UpdateAuthUserRequest defines model for UpdateAuthUserRequest.
@@ -87,6 +180,57 @@ type UpdateUserProfileRequest struct {
 	Weight *int `json:"weight"`
 }
 
+// UserImportRecord A user of an import or export, also the columns of the CSV format
+type UserImportRecord struct {
+	Email string `json:"email"`
+
+	// EmailVerified Whether the email address is known to belong to the user, unverified users confirm it first
+	EmailVerified *bool  `json:"emailVerified,omitempty"`
+	FirstName     string `json:"firstName"`
+	LastName      string `json:"lastName"`
+
+	// Roles Roles besides the user role every user has
+	Roles *[]string `json:"roles,omitempty"`
+}
+
+// UserImportResponse defines model for UserImportResponse.
+type UserImportResponse struct {
+	DryRun bool `json:"dryRun"`
+
+	// Errors Records that were not or would not be imported, in line order
+	Errors []UserImportRowError `json:"errors"`
+
+	// Imported Number of users created, always 0 for dry runs
+	Imported int `json:"imported"`
+
+	// Total Number of records
+	Total int `json:"total"`
+
+	// Valid Number of records that passed the validation
+	Valid int `json:"valid"`
+}
+
+// UserImportRowError defines model for UserImportRowError.
+type UserImportRowError struct {
+	Email *string `json:"email"`
+
+	// Line Line of the record in the imported data, the CSV header is line 1
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// UserLockoutResponse defines model for UserLockoutResponse.
+type UserLockoutResponse struct {
+	// FailedAttempts Failed sign in attempts within the current failure window
+	FailedAttempts int `json:"failedAttempts"`
+
+	// Locked Whether sign in of the user is locked out
+	Locked bool `json:"locked"`
+
+	// LockedUntil End of the lockout, if the user is locked out
+	LockedUntil *time.Time `json:"lockedUntil"`
+}
+
 // UserProfileGender defines model for UserProfileGender.
 type UserProfileGender string
 
@@ -122,27 +266,142 @@ type UserRolesResponse struct {
 	UserId string   `json:"userId"`
 }
 
-// ListAllUsersParams defines parameters for ListAllUsers.
-type ListAllUsersParams struct {
-	// Page Page number for pagination
+// UserSearchResponse defines model for UserSearchResponse.
+type UserSearchResponse struct {
+	// Items Found users, most similar first
+	Items []AuthUserResponse `json:"items"`
+}
+
+// UserSessionResponse defines model for UserSessionResponse.
+type UserSessionResponse struct {
+	// CreatedAt Time of the sign in that started the session
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current Whether the request was made with an access token of this session
+	Current bool `json:"current"`
+
+	// Id Session ID
+	Id string `json:"id"`
+
+	// IpAddress Client IP address of the most recent sign in or token refresh
+	IpAddress *string `json:"ipAddress"`
+
+	// LastUsedAt Time of the most recent sign in or token refresh
+	LastUsedAt time.Time `json:"lastUsedAt"`
+
+	// Source Platform the session was started from (web, android or ios)
+	Source string `json:"source"`
+
+	// UserAgent User agent of the most recent sign in or token refresh
+	UserAgent *string `json:"userAgent"`
+}
+
+// UserSessionsResponse defines model for UserSessionsResponse.
+type UserSessionsResponse struct {
+	Items []UserSessionResponse `json:"items"`
+}
+
+// UserSortBy defines model for UserSortBy.
+type UserSortBy string
+
+// UserTransferFormat Format of imported and exported users, CSV with a header row or JSON records separated by newlines
+type UserTransferFormat string
+
+// ExportUsersParams defines parameters for ExportUsers.
+type ExportUsersParams struct {
+	Format *UserTransferFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ImportUsersParams defines parameters for ImportUsers.
+type ImportUsersParams struct {
+	// DryRun Only validates the records and reports what would be imported
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// ListUsersByTenantParams defines parameters for ListUsersByTenant.
+type ListUsersByTenantParams struct {
+	// Page Page number for pagination, ignored when cursor is given
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
 	// Limit Number of users per page
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Returns the page after the one that returned this nextCursor, with the same sorting
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// SortBy Field to sort users by
+	SortBy *UserSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Sort order, descending by default for createdAt and ascending for other fields
+	SortOrder *SortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Search Case-insensitive part of the email, first or last name
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// Role Name of a role assigned to the users
+	Role *string `form:"role,omitempty" json:"role,omitempty"`
+
+	// EmailVerified Filter by verified email address
+	EmailVerified *bool `form:"emailVerified,omitempty" json:"emailVerified,omitempty"`
+
+	// Active Filter by active status
+	Active *bool `form:"active,omitempty" json:"active,omitempty"`
 }
 
-// ListUsersByTenantParams defines parameters for ListUsersByTenant.
-type ListUsersByTenantParams struct {
-	// Page Page number for pagination
+// ListAllUsersParams defines parameters for ListAllUsers.
+type ListAllUsersParams struct {
+	// Page Page number for pagination, ignored when cursor is given
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
 	// Limit Number of users per page
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Returns the page after the one that returned this nextCursor, with the same sorting
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// SortBy Field to sort users by
+	SortBy *UserSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Sort order, descending by default for createdAt and ascending for other fields
+	SortOrder *SortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
+	// Search Case-insensitive part of the email, first or last name
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// Role Name of a role assigned to the users
+	Role *string `form:"role,omitempty" json:"role,omitempty"`
+
+	// EmailVerified Filter by verified email address
+	EmailVerified *bool `form:"emailVerified,omitempty" json:"emailVerified,omitempty"`
+
+	// Active Filter by active status
+	Active *bool `form:"active,omitempty" json:"active,omitempty"`
+}
+
+// SearchUsersParams defines parameters for SearchUsers.
+type SearchUsersParams struct {
+	// Q Part of an email or name, at least 2 characters
+	Q string `form:"q" json:"q"`
+
+	// TenantId Searches this tenant only
+	TenantId *string `form:"tenantId,omitempty" json:"tenantId,omitempty"`
+
+	// Limit Maximal number of users
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // UpdateAuthUserJSONRequestBody defines body for UpdateAuthUser for application/json ContentType.
 type UpdateAuthUserJSONRequestBody = UpdateAuthUserRequest
 
+// CreateUserApiKeyJSONRequestBody defines body for CreateUserApiKey for application/json ContentType.
+type CreateUserApiKeyJSONRequestBody = CreateApiKeyRequest
+
+// RequestUserEmailChangeJSONRequestBody defines body for RequestUserEmailChange for application/json ContentType.
+type RequestUserEmailChangeJSONRequestBody = EmailChangeRequest
+
+// ConfirmUserEmailChangeJSONRequestBody defines body for ConfirmUserEmailChange for application/json ContentType.
+type ConfirmUserEmailChangeJSONRequestBody = ConfirmEmailChangeRequest
+
 // UpdateUserProfileJSONRequestBody defines body for UpdateUserProfile for application/json ContentType.
 type UpdateUserProfileJSONRequestBody = UpdateUserProfileRequest
 
@@ -8,6 +8,102 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+func NewApiKeyResponseBuilder() ApiKeyResponse_Builder_CreatedAt {
+	return ApiKeyResponse_Builder_CreatedAt{root: &ApiKeyResponse{}}
+}
+
+type ApiKeyResponse_Builder_CreatedAt struct {
+	root *ApiKeyResponse
+}
+
+type ApiKeyResponse_Builder_ExpiresAt struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_CreatedAt) CreatedAt(arg time.Time) ApiKeyResponse_Builder_ExpiresAt {
+	b.root.CreatedAt = arg
+	return ApiKeyResponse_Builder_ExpiresAt{root: b.root}
+}
+
+type ApiKeyResponse_Builder_Id struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_ExpiresAt) ExpiresAt(arg *time.Time) ApiKeyResponse_Builder_Id {
+	b.root.ExpiresAt = arg
+	return ApiKeyResponse_Builder_Id{root: b.root}
+}
+
+type ApiKeyResponse_Builder_LastUsedAt struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_Id) Id(arg string) ApiKeyResponse_Builder_LastUsedAt {
+	b.root.Id = arg
+	return ApiKeyResponse_Builder_LastUsedAt{root: b.root}
+}
+
+type ApiKeyResponse_Builder_Name struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_LastUsedAt) LastUsedAt(arg *time.Time) ApiKeyResponse_Builder_Name {
+	b.root.LastUsedAt = arg
+	return ApiKeyResponse_Builder_Name{root: b.root}
+}
+
+type ApiKeyResponse_Builder_Prefix struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_Name) Name(arg string) ApiKeyResponse_Builder_Prefix {
+	b.root.Name = arg
+	return ApiKeyResponse_Builder_Prefix{root: b.root}
+}
+
+type ApiKeyResponse_Builder_Roles struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_Prefix) Prefix(arg string) ApiKeyResponse_Builder_Roles {
+	b.root.Prefix = arg
+	return ApiKeyResponse_Builder_Roles{root: b.root}
+}
+
+type ApiKeyResponse_Builder_GobFinalizer struct {
+	root *ApiKeyResponse
+}
+
+func (b ApiKeyResponse_Builder_Roles) Roles(arg []string) ApiKeyResponse_Builder_GobFinalizer {
+	b.root.Roles = arg
+	return ApiKeyResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ApiKeyResponse_Builder_GobFinalizer) Build() *ApiKeyResponse {
+	return b.root
+}
+
+func NewApiKeysResponseBuilder() ApiKeysResponse_Builder_Items {
+	return ApiKeysResponse_Builder_Items{root: &ApiKeysResponse{}}
+}
+
+type ApiKeysResponse_Builder_Items struct {
+	root *ApiKeysResponse
+}
+
+type ApiKeysResponse_Builder_GobFinalizer struct {
+	root *ApiKeysResponse
+}
+
+func (b ApiKeysResponse_Builder_Items) Items(arg []ApiKeyResponse) ApiKeysResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return ApiKeysResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ApiKeysResponse_Builder_GobFinalizer) Build() *ApiKeysResponse {
+	return b.root
+}
+
 func NewAssignUserRoleRequestBuilder() AssignUserRoleRequest_Builder_RoleName {
 	return AssignUserRoleRequest_Builder_RoleName{root: &AssignUserRoleRequest{}}
 }
@@ -112,12 +208,21 @@ type AuthUsersResponse_Builder_Items struct {
 	root *AuthUsersResponse
 }
 
-type AuthUsersResponse_Builder_Pagination struct {
+type AuthUsersResponse_Builder_NextCursor struct {
 	root *AuthUsersResponse
 }
 
-func (b AuthUsersResponse_Builder_Items) Items(arg []AuthUserResponse) AuthUsersResponse_Builder_Pagination {
+func (b AuthUsersResponse_Builder_Items) Items(arg []AuthUserResponse) AuthUsersResponse_Builder_NextCursor {
 	b.root.Items = arg
+	return AuthUsersResponse_Builder_NextCursor{root: b.root}
+}
+
+type AuthUsersResponse_Builder_Pagination struct {
+	root *AuthUsersResponse
+}
+
+func (b AuthUsersResponse_Builder_NextCursor) NextCursor(arg *string) AuthUsersResponse_Builder_Pagination {
+	b.root.NextCursor = arg
 	return AuthUsersResponse_Builder_Pagination{root: b.root}
 }
 
@@ -134,6 +239,165 @@ func (b AuthUsersResponse_Builder_GobFinalizer) Build() *AuthUsersResponse {
 	return b.root
 }
 
+func NewConfirmEmailChangeRequestBuilder() ConfirmEmailChangeRequest_Builder_Code {
+	return ConfirmEmailChangeRequest_Builder_Code{root: &ConfirmEmailChangeRequest{}}
+}
+
+type ConfirmEmailChangeRequest_Builder_Code struct {
+	root *ConfirmEmailChangeRequest
+}
+
+type ConfirmEmailChangeRequest_Builder_GobFinalizer struct {
+	root *ConfirmEmailChangeRequest
+}
+
+func (b ConfirmEmailChangeRequest_Builder_Code) Code(arg string) ConfirmEmailChangeRequest_Builder_GobFinalizer {
+	b.root.Code = arg
+	return ConfirmEmailChangeRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ConfirmEmailChangeRequest_Builder_GobFinalizer) Build() *ConfirmEmailChangeRequest {
+	return b.root
+}
+
+func NewCreateApiKeyRequestBuilder() CreateApiKeyRequest_Builder_ExpiresAt {
+	return CreateApiKeyRequest_Builder_ExpiresAt{root: &CreateApiKeyRequest{}}
+}
+
+type CreateApiKeyRequest_Builder_ExpiresAt struct {
+	root *CreateApiKeyRequest
+}
+
+type CreateApiKeyRequest_Builder_Name struct {
+	root *CreateApiKeyRequest
+}
+
+func (b CreateApiKeyRequest_Builder_ExpiresAt) ExpiresAt(arg *time.Time) CreateApiKeyRequest_Builder_Name {
+	b.root.ExpiresAt = arg
+	return CreateApiKeyRequest_Builder_Name{root: b.root}
+}
+
+type CreateApiKeyRequest_Builder_Roles struct {
+	root *CreateApiKeyRequest
+}
+
+func (b CreateApiKeyRequest_Builder_Name) Name(arg string) CreateApiKeyRequest_Builder_Roles {
+	b.root.Name = arg
+	return CreateApiKeyRequest_Builder_Roles{root: b.root}
+}
+
+type CreateApiKeyRequest_Builder_GobFinalizer struct {
+	root *CreateApiKeyRequest
+}
+
+func (b CreateApiKeyRequest_Builder_Roles) Roles(arg *[]string) CreateApiKeyRequest_Builder_GobFinalizer {
+	b.root.Roles = arg
+	return CreateApiKeyRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateApiKeyRequest_Builder_GobFinalizer) Build() *CreateApiKeyRequest {
+	return b.root
+}
+
+func NewCreateApiKeyResponseBuilder() CreateApiKeyResponse_Builder_ApiKey {
+	return CreateApiKeyResponse_Builder_ApiKey{root: &CreateApiKeyResponse{}}
+}
+
+type CreateApiKeyResponse_Builder_ApiKey struct {
+	root *CreateApiKeyResponse
+}
+
+type CreateApiKeyResponse_Builder_Key struct {
+	root *CreateApiKeyResponse
+}
+
+func (b CreateApiKeyResponse_Builder_ApiKey) ApiKey(arg ApiKeyResponse) CreateApiKeyResponse_Builder_Key {
+	b.root.ApiKey = arg
+	return CreateApiKeyResponse_Builder_Key{root: b.root}
+}
+
+type CreateApiKeyResponse_Builder_GobFinalizer struct {
+	root *CreateApiKeyResponse
+}
+
+func (b CreateApiKeyResponse_Builder_Key) Key(arg string) CreateApiKeyResponse_Builder_GobFinalizer {
+	b.root.Key = arg
+	return CreateApiKeyResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateApiKeyResponse_Builder_GobFinalizer) Build() *CreateApiKeyResponse {
+	return b.root
+}
+
+func NewEmailChangeRequestBuilder() EmailChangeRequest_Builder_CurrentPassword {
+	return EmailChangeRequest_Builder_CurrentPassword{root: &EmailChangeRequest{}}
+}
+
+type EmailChangeRequest_Builder_CurrentPassword struct {
+	root *EmailChangeRequest
+}
+
+type EmailChangeRequest_Builder_NewEmail struct {
+	root *EmailChangeRequest
+}
+
+func (b EmailChangeRequest_Builder_CurrentPassword) CurrentPassword(arg *string) EmailChangeRequest_Builder_NewEmail {
+	b.root.CurrentPassword = arg
+	return EmailChangeRequest_Builder_NewEmail{root: b.root}
+}
+
+type EmailChangeRequest_Builder_Source struct {
+	root *EmailChangeRequest
+}
+
+func (b EmailChangeRequest_Builder_NewEmail) NewEmail(arg openapi_types.Email) EmailChangeRequest_Builder_Source {
+	b.root.NewEmail = arg
+	return EmailChangeRequest_Builder_Source{root: b.root}
+}
+
+type EmailChangeRequest_Builder_GobFinalizer struct {
+	root *EmailChangeRequest
+}
+
+func (b EmailChangeRequest_Builder_Source) Source(arg *string) EmailChangeRequest_Builder_GobFinalizer {
+	b.root.Source = arg
+	return EmailChangeRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b EmailChangeRequest_Builder_GobFinalizer) Build() *EmailChangeRequest {
+	return b.root
+}
+
+func NewEmailChangeResponseBuilder() EmailChangeResponse_Builder_ExpiresAt {
+	return EmailChangeResponse_Builder_ExpiresAt{root: &EmailChangeResponse{}}
+}
+
+type EmailChangeResponse_Builder_ExpiresAt struct {
+	root *EmailChangeResponse
+}
+
+type EmailChangeResponse_Builder_PendingEmail struct {
+	root *EmailChangeResponse
+}
+
+func (b EmailChangeResponse_Builder_ExpiresAt) ExpiresAt(arg time.Time) EmailChangeResponse_Builder_PendingEmail {
+	b.root.ExpiresAt = arg
+	return EmailChangeResponse_Builder_PendingEmail{root: b.root}
+}
+
+type EmailChangeResponse_Builder_GobFinalizer struct {
+	root *EmailChangeResponse
+}
+
+func (b EmailChangeResponse_Builder_PendingEmail) PendingEmail(arg openapi_types.Email) EmailChangeResponse_Builder_GobFinalizer {
+	b.root.PendingEmail = arg
+	return EmailChangeResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b EmailChangeResponse_Builder_GobFinalizer) Build() *EmailChangeResponse {
+	return b.root
+}
+
 func NewUpdateAuthUserRequestBuilder() UpdateAuthUserRequest_Builder_FirstName {
 	return UpdateAuthUserRequest_Builder_FirstName{root: &UpdateAuthUserRequest{}}
 }
@@ -221,6 +485,198 @@ func (b UpdateUserProfileRequest_Builder_GobFinalizer) Build() *UpdateUserProfil
 	return b.root
 }
 
+func NewUserImportRecordBuilder() UserImportRecord_Builder_Email {
+	return UserImportRecord_Builder_Email{root: &UserImportRecord{}}
+}
+
+type UserImportRecord_Builder_Email struct {
+	root *UserImportRecord
+}
+
+type UserImportRecord_Builder_EmailVerified struct {
+	root *UserImportRecord
+}
+
+func (b UserImportRecord_Builder_Email) Email(arg string) UserImportRecord_Builder_EmailVerified {
+	b.root.Email = arg
+	return UserImportRecord_Builder_EmailVerified{root: b.root}
+}
+
+type UserImportRecord_Builder_FirstName struct {
+	root *UserImportRecord
+}
+
+func (b UserImportRecord_Builder_EmailVerified) EmailVerified(arg *bool) UserImportRecord_Builder_FirstName {
+	b.root.EmailVerified = arg
+	return UserImportRecord_Builder_FirstName{root: b.root}
+}
+
+type UserImportRecord_Builder_LastName struct {
+	root *UserImportRecord
+}
+
+func (b UserImportRecord_Builder_FirstName) FirstName(arg string) UserImportRecord_Builder_LastName {
+	b.root.FirstName = arg
+	return UserImportRecord_Builder_LastName{root: b.root}
+}
+
+type UserImportRecord_Builder_Roles struct {
+	root *UserImportRecord
+}
+
+func (b UserImportRecord_Builder_LastName) LastName(arg string) UserImportRecord_Builder_Roles {
+	b.root.LastName = arg
+	return UserImportRecord_Builder_Roles{root: b.root}
+}
+
+type UserImportRecord_Builder_GobFinalizer struct {
+	root *UserImportRecord
+}
+
+func (b UserImportRecord_Builder_Roles) Roles(arg *[]string) UserImportRecord_Builder_GobFinalizer {
+	b.root.Roles = arg
+	return UserImportRecord_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserImportRecord_Builder_GobFinalizer) Build() *UserImportRecord {
+	return b.root
+}
+
+func NewUserImportResponseBuilder() UserImportResponse_Builder_DryRun {
+	return UserImportResponse_Builder_DryRun{root: &UserImportResponse{}}
+}
+
+type UserImportResponse_Builder_DryRun struct {
+	root *UserImportResponse
+}
+
+type UserImportResponse_Builder_Errors struct {
+	root *UserImportResponse
+}
+
+func (b UserImportResponse_Builder_DryRun) DryRun(arg bool) UserImportResponse_Builder_Errors {
+	b.root.DryRun = arg
+	return UserImportResponse_Builder_Errors{root: b.root}
+}
+
+type UserImportResponse_Builder_Imported struct {
+	root *UserImportResponse
+}
+
+func (b UserImportResponse_Builder_Errors) Errors(arg []UserImportRowError) UserImportResponse_Builder_Imported {
+	b.root.Errors = arg
+	return UserImportResponse_Builder_Imported{root: b.root}
+}
+
+type UserImportResponse_Builder_Total struct {
+	root *UserImportResponse
+}
+
+func (b UserImportResponse_Builder_Imported) Imported(arg int) UserImportResponse_Builder_Total {
+	b.root.Imported = arg
+	return UserImportResponse_Builder_Total{root: b.root}
+}
+
+type UserImportResponse_Builder_Valid struct {
+	root *UserImportResponse
+}
+
+func (b UserImportResponse_Builder_Total) Total(arg int) UserImportResponse_Builder_Valid {
+	b.root.Total = arg
+	return UserImportResponse_Builder_Valid{root: b.root}
+}
+
+type UserImportResponse_Builder_GobFinalizer struct {
+	root *UserImportResponse
+}
+
+func (b UserImportResponse_Builder_Valid) Valid(arg int) UserImportResponse_Builder_GobFinalizer {
+	b.root.Valid = arg
+	return UserImportResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserImportResponse_Builder_GobFinalizer) Build() *UserImportResponse {
+	return b.root
+}
+
+func NewUserImportRowErrorBuilder() UserImportRowError_Builder_Email {
+	return UserImportRowError_Builder_Email{root: &UserImportRowError{}}
+}
+
+type UserImportRowError_Builder_Email struct {
+	root *UserImportRowError
+}
+
+type UserImportRowError_Builder_Line struct {
+	root *UserImportRowError
+}
+
+func (b UserImportRowError_Builder_Email) Email(arg *string) UserImportRowError_Builder_Line {
+	b.root.Email = arg
+	return UserImportRowError_Builder_Line{root: b.root}
+}
+
+type UserImportRowError_Builder_Message struct {
+	root *UserImportRowError
+}
+
+func (b UserImportRowError_Builder_Line) Line(arg int) UserImportRowError_Builder_Message {
+	b.root.Line = arg
+	return UserImportRowError_Builder_Message{root: b.root}
+}
+
+type UserImportRowError_Builder_GobFinalizer struct {
+	root *UserImportRowError
+}
+
+func (b UserImportRowError_Builder_Message) Message(arg string) UserImportRowError_Builder_GobFinalizer {
+	b.root.Message = arg
+	return UserImportRowError_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserImportRowError_Builder_GobFinalizer) Build() *UserImportRowError {
+	return b.root
+}
+
+func NewUserLockoutResponseBuilder() UserLockoutResponse_Builder_FailedAttempts {
+	return UserLockoutResponse_Builder_FailedAttempts{root: &UserLockoutResponse{}}
+}
+
+type UserLockoutResponse_Builder_FailedAttempts struct {
+	root *UserLockoutResponse
+}
+
+type UserLockoutResponse_Builder_Locked struct {
+	root *UserLockoutResponse
+}
+
+func (b UserLockoutResponse_Builder_FailedAttempts) FailedAttempts(arg int) UserLockoutResponse_Builder_Locked {
+	b.root.FailedAttempts = arg
+	return UserLockoutResponse_Builder_Locked{root: b.root}
+}
+
+type UserLockoutResponse_Builder_LockedUntil struct {
+	root *UserLockoutResponse
+}
+
+func (b UserLockoutResponse_Builder_Locked) Locked(arg bool) UserLockoutResponse_Builder_LockedUntil {
+	b.root.Locked = arg
+	return UserLockoutResponse_Builder_LockedUntil{root: b.root}
+}
+
+type UserLockoutResponse_Builder_GobFinalizer struct {
+	root *UserLockoutResponse
+}
+
+func (b UserLockoutResponse_Builder_LockedUntil) LockedUntil(arg *time.Time) UserLockoutResponse_Builder_GobFinalizer {
+	b.root.LockedUntil = arg
+	return UserLockoutResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserLockoutResponse_Builder_GobFinalizer) Build() *UserLockoutResponse {
+	return b.root
+}
+
 func NewUserProfileResponseBuilder() UserProfileResponse_Builder_BirthDate {
 	return UserProfileResponse_Builder_BirthDate{root: &UserProfileResponse{}}
 }
@@ -335,6 +791,258 @@ func (b UserRolesResponse_Builder_GobFinalizer) Build() *UserRolesResponse {
 	return b.root
 }
 
+func NewUserSearchResponseBuilder() UserSearchResponse_Builder_Items {
+	return UserSearchResponse_Builder_Items{root: &UserSearchResponse{}}
+}
+
+type UserSearchResponse_Builder_Items struct {
+	root *UserSearchResponse
+}
+
+type UserSearchResponse_Builder_GobFinalizer struct {
+	root *UserSearchResponse
+}
+
+func (b UserSearchResponse_Builder_Items) Items(arg []AuthUserResponse) UserSearchResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return UserSearchResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserSearchResponse_Builder_GobFinalizer) Build() *UserSearchResponse {
+	return b.root
+}
+
+func NewUserSessionResponseBuilder() UserSessionResponse_Builder_CreatedAt {
+	return UserSessionResponse_Builder_CreatedAt{root: &UserSessionResponse{}}
+}
+
+type UserSessionResponse_Builder_CreatedAt struct {
+	root *UserSessionResponse
+}
+
+type UserSessionResponse_Builder_Current struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_CreatedAt) CreatedAt(arg time.Time) UserSessionResponse_Builder_Current {
+	b.root.CreatedAt = arg
+	return UserSessionResponse_Builder_Current{root: b.root}
+}
+
+type UserSessionResponse_Builder_Id struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_Current) Current(arg bool) UserSessionResponse_Builder_Id {
+	b.root.Current = arg
+	return UserSessionResponse_Builder_Id{root: b.root}
+}
+
+type UserSessionResponse_Builder_IpAddress struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_Id) Id(arg string) UserSessionResponse_Builder_IpAddress {
+	b.root.Id = arg
+	return UserSessionResponse_Builder_IpAddress{root: b.root}
+}
+
+type UserSessionResponse_Builder_LastUsedAt struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_IpAddress) IpAddress(arg *string) UserSessionResponse_Builder_LastUsedAt {
+	b.root.IpAddress = arg
+	return UserSessionResponse_Builder_LastUsedAt{root: b.root}
+}
+
+type UserSessionResponse_Builder_Source struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_LastUsedAt) LastUsedAt(arg time.Time) UserSessionResponse_Builder_Source {
+	b.root.LastUsedAt = arg
+	return UserSessionResponse_Builder_Source{root: b.root}
+}
+
+type UserSessionResponse_Builder_UserAgent struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_Source) Source(arg string) UserSessionResponse_Builder_UserAgent {
+	b.root.Source = arg
+	return UserSessionResponse_Builder_UserAgent{root: b.root}
+}
+
+type UserSessionResponse_Builder_GobFinalizer struct {
+	root *UserSessionResponse
+}
+
+func (b UserSessionResponse_Builder_UserAgent) UserAgent(arg *string) UserSessionResponse_Builder_GobFinalizer {
+	b.root.UserAgent = arg
+	return UserSessionResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserSessionResponse_Builder_GobFinalizer) Build() *UserSessionResponse {
+	return b.root
+}
+
+func NewUserSessionsResponseBuilder() UserSessionsResponse_Builder_Items {
+	return UserSessionsResponse_Builder_Items{root: &UserSessionsResponse{}}
+}
+
+type UserSessionsResponse_Builder_Items struct {
+	root *UserSessionsResponse
+}
+
+type UserSessionsResponse_Builder_GobFinalizer struct {
+	root *UserSessionsResponse
+}
+
+func (b UserSessionsResponse_Builder_Items) Items(arg []UserSessionResponse) UserSessionsResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return UserSessionsResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UserSessionsResponse_Builder_GobFinalizer) Build() *UserSessionsResponse {
+	return b.root
+}
+
+func NewExportUsersParamsBuilder() ExportUsersParams_Builder_Format {
+	return ExportUsersParams_Builder_Format{root: &ExportUsersParams{}}
+}
+
+type ExportUsersParams_Builder_Format struct {
+	root *ExportUsersParams
+}
+
+type ExportUsersParams_Builder_GobFinalizer struct {
+	root *ExportUsersParams
+}
+
+func (b ExportUsersParams_Builder_Format) Format(arg *UserTransferFormat) ExportUsersParams_Builder_GobFinalizer {
+	b.root.Format = arg
+	return ExportUsersParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ExportUsersParams_Builder_GobFinalizer) Build() *ExportUsersParams {
+	return b.root
+}
+
+func NewImportUsersParamsBuilder() ImportUsersParams_Builder_DryRun {
+	return ImportUsersParams_Builder_DryRun{root: &ImportUsersParams{}}
+}
+
+type ImportUsersParams_Builder_DryRun struct {
+	root *ImportUsersParams
+}
+
+type ImportUsersParams_Builder_GobFinalizer struct {
+	root *ImportUsersParams
+}
+
+func (b ImportUsersParams_Builder_DryRun) DryRun(arg *bool) ImportUsersParams_Builder_GobFinalizer {
+	b.root.DryRun = arg
+	return ImportUsersParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ImportUsersParams_Builder_GobFinalizer) Build() *ImportUsersParams {
+	return b.root
+}
+
+func NewListUsersByTenantParamsBuilder() ListUsersByTenantParams_Builder_Page {
+	return ListUsersByTenantParams_Builder_Page{root: &ListUsersByTenantParams{}}
+}
+
+type ListUsersByTenantParams_Builder_Page struct {
+	root *ListUsersByTenantParams
+}
+
+type ListUsersByTenantParams_Builder_Limit struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Page) Page(arg *int) ListUsersByTenantParams_Builder_Limit {
+	b.root.Page = arg
+	return ListUsersByTenantParams_Builder_Limit{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_Cursor struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Limit) Limit(arg *int) ListUsersByTenantParams_Builder_Cursor {
+	b.root.Limit = arg
+	return ListUsersByTenantParams_Builder_Cursor{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_SortBy struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Cursor) Cursor(arg *string) ListUsersByTenantParams_Builder_SortBy {
+	b.root.Cursor = arg
+	return ListUsersByTenantParams_Builder_SortBy{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_SortOrder struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_SortBy) SortBy(arg *UserSortBy) ListUsersByTenantParams_Builder_SortOrder {
+	b.root.SortBy = arg
+	return ListUsersByTenantParams_Builder_SortOrder{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_Search struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_SortOrder) SortOrder(arg *SortOrder) ListUsersByTenantParams_Builder_Search {
+	b.root.SortOrder = arg
+	return ListUsersByTenantParams_Builder_Search{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_Role struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Search) Search(arg *string) ListUsersByTenantParams_Builder_Role {
+	b.root.Search = arg
+	return ListUsersByTenantParams_Builder_Role{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_EmailVerified struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Role) Role(arg *string) ListUsersByTenantParams_Builder_EmailVerified {
+	b.root.Role = arg
+	return ListUsersByTenantParams_Builder_EmailVerified{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_Active struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_EmailVerified) EmailVerified(arg *bool) ListUsersByTenantParams_Builder_Active {
+	b.root.EmailVerified = arg
+	return ListUsersByTenantParams_Builder_Active{root: b.root}
+}
+
+type ListUsersByTenantParams_Builder_GobFinalizer struct {
+	root *ListUsersByTenantParams
+}
+
+func (b ListUsersByTenantParams_Builder_Active) Active(arg *bool) ListUsersByTenantParams_Builder_GobFinalizer {
+	b.root.Active = arg
+	return ListUsersByTenantParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListUsersByTenantParams_Builder_GobFinalizer) Build() *ListUsersByTenantParams {
+	return b.root
+}
+
 func NewListAllUsersParamsBuilder() ListAllUsersParams_Builder_Page {
 	return ListAllUsersParams_Builder_Page{root: &ListAllUsersParams{}}
 }
@@ -352,12 +1060,75 @@ func (b ListAllUsersParams_Builder_Page) Page(arg *int) ListAllUsersParams_Build
 	return ListAllUsersParams_Builder_Limit{root: b.root}
 }
 
-type ListAllUsersParams_Builder_GobFinalizer struct {
+type ListAllUsersParams_Builder_Cursor struct {
 	root *ListAllUsersParams
 }
 
-func (b ListAllUsersParams_Builder_Limit) Limit(arg *int) ListAllUsersParams_Builder_GobFinalizer {
+func (b ListAllUsersParams_Builder_Limit) Limit(arg *int) ListAllUsersParams_Builder_Cursor {
 	b.root.Limit = arg
+	return ListAllUsersParams_Builder_Cursor{root: b.root}
+}
+
+type ListAllUsersParams_Builder_SortBy struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_Cursor) Cursor(arg *string) ListAllUsersParams_Builder_SortBy {
+	b.root.Cursor = arg
+	return ListAllUsersParams_Builder_SortBy{root: b.root}
+}
+
+type ListAllUsersParams_Builder_SortOrder struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_SortBy) SortBy(arg *UserSortBy) ListAllUsersParams_Builder_SortOrder {
+	b.root.SortBy = arg
+	return ListAllUsersParams_Builder_SortOrder{root: b.root}
+}
+
+type ListAllUsersParams_Builder_Search struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_SortOrder) SortOrder(arg *SortOrder) ListAllUsersParams_Builder_Search {
+	b.root.SortOrder = arg
+	return ListAllUsersParams_Builder_Search{root: b.root}
+}
+
+type ListAllUsersParams_Builder_Role struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_Search) Search(arg *string) ListAllUsersParams_Builder_Role {
+	b.root.Search = arg
+	return ListAllUsersParams_Builder_Role{root: b.root}
+}
+
+type ListAllUsersParams_Builder_EmailVerified struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_Role) Role(arg *string) ListAllUsersParams_Builder_EmailVerified {
+	b.root.Role = arg
+	return ListAllUsersParams_Builder_EmailVerified{root: b.root}
+}
+
+type ListAllUsersParams_Builder_Active struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_EmailVerified) EmailVerified(arg *bool) ListAllUsersParams_Builder_Active {
+	b.root.EmailVerified = arg
+	return ListAllUsersParams_Builder_Active{root: b.root}
+}
+
+type ListAllUsersParams_Builder_GobFinalizer struct {
+	root *ListAllUsersParams
+}
+
+func (b ListAllUsersParams_Builder_Active) Active(arg *bool) ListAllUsersParams_Builder_GobFinalizer {
+	b.root.Active = arg
 	return ListAllUsersParams_Builder_GobFinalizer{root: b.root}
 }
 
@@ -365,32 +1136,41 @@ func (b ListAllUsersParams_Builder_GobFinalizer) Build() *ListAllUsersParams {
 	return b.root
 }
 
-func NewListUsersByTenantParamsBuilder() ListUsersByTenantParams_Builder_Page {
-	return ListUsersByTenantParams_Builder_Page{root: &ListUsersByTenantParams{}}
+func NewSearchUsersParamsBuilder() SearchUsersParams_Builder_Q {
+	return SearchUsersParams_Builder_Q{root: &SearchUsersParams{}}
 }
 
-type ListUsersByTenantParams_Builder_Page struct {
-	root *ListUsersByTenantParams
+type SearchUsersParams_Builder_Q struct {
+	root *SearchUsersParams
 }
 
-type ListUsersByTenantParams_Builder_Limit struct {
-	root *ListUsersByTenantParams
+type SearchUsersParams_Builder_TenantId struct {
+	root *SearchUsersParams
 }
 
-func (b ListUsersByTenantParams_Builder_Page) Page(arg *int) ListUsersByTenantParams_Builder_Limit {
-	b.root.Page = arg
-	return ListUsersByTenantParams_Builder_Limit{root: b.root}
+func (b SearchUsersParams_Builder_Q) Q(arg string) SearchUsersParams_Builder_TenantId {
+	b.root.Q = arg
+	return SearchUsersParams_Builder_TenantId{root: b.root}
 }
 
-type ListUsersByTenantParams_Builder_GobFinalizer struct {
-	root *ListUsersByTenantParams
+type SearchUsersParams_Builder_Limit struct {
+	root *SearchUsersParams
 }
 
-func (b ListUsersByTenantParams_Builder_Limit) Limit(arg *int) ListUsersByTenantParams_Builder_GobFinalizer {
+func (b SearchUsersParams_Builder_TenantId) TenantId(arg *string) SearchUsersParams_Builder_Limit {
+	b.root.TenantId = arg
+	return SearchUsersParams_Builder_Limit{root: b.root}
+}
+
+type SearchUsersParams_Builder_GobFinalizer struct {
+	root *SearchUsersParams
+}
+
+func (b SearchUsersParams_Builder_Limit) Limit(arg *int) SearchUsersParams_Builder_GobFinalizer {
 	b.root.Limit = arg
-	return ListUsersByTenantParams_Builder_GobFinalizer{root: b.root}
+	return SearchUsersParams_Builder_GobFinalizer{root: b.root}
 }
 
-func (b ListUsersByTenantParams_Builder_GobFinalizer) Build() *ListUsersByTenantParams {
+func (b SearchUsersParams_Builder_GobFinalizer) Build() *SearchUsersParams {
 	return b.root
 }
@@ -0,0 +1,182 @@
+// Package swagger provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package swagger
+
+import (
+	"time"
+)
+
+// Defines values for WebhookDeliveryResponseStatus.
+const (
+	WebhookDeliveryResponseStatusDead      WebhookDeliveryResponseStatus = "dead"
+	WebhookDeliveryResponseStatusDelivered WebhookDeliveryResponseStatus = "delivered"
+	WebhookDeliveryResponseStatusPending   WebhookDeliveryResponseStatus = "pending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeRoleAssigned      WebhookEventType = "role.assigned"
+	WebhookEventTypeTenantUpdated     WebhookEventType = "tenant.updated"
+	WebhookEventTypeUserCreated       WebhookEventType = "user.created"
+	WebhookEventTypeUserDeleted       WebhookEventType = "user.deleted"
+	WebhookEventTypeUserEmailVerified WebhookEventType = "user.email_verified"
+)
+
+// Defines values for ListWebhookDeliveriesParamsStatus.
+const (
+	ListWebhookDeliveriesParamsStatusDead      ListWebhookDeliveriesParamsStatus = "dead"
+	ListWebhookDeliveriesParamsStatusDelivered ListWebhookDeliveriesParamsStatus = "delivered"
+	ListWebhookDeliveriesParamsStatusPending   ListWebhookDeliveriesParamsStatus = "pending"
+)
+
+// CreateWebhookRequest Request payload for creating a webhook
+type CreateWebhookRequest struct {
+	// Active Whether events are delivered, true if not given
+	Active *bool `json:"active"`
+
+	// EventTypes Event types posted to the URL, at least one
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// Secret Secret signing the events, at least 16 characters, generated if not given
+	Secret *string `json:"secret"`
+
+	// Url HTTP or HTTPS URL events are posted to
+	Url string `json:"url"`
+}
+
+// CreateWebhookResponse Created webhook with its secret, the secret cannot be retrieved later
+type CreateWebhookResponse struct {
+	// Secret Secret signing the events
+	Secret string `json:"secret"`
+
+	// Webhook Webhook subscription of a tenant
+	Webhook WebhookResponse `json:"webhook"`
+}
+
+// UpdateWebhookRequest Request payload for updating a webhook
+type UpdateWebhookRequest struct {
+	// Active Whether events are delivered
+	Active bool `json:"active"`
+
+	// EventTypes Event types posted to the URL, at least one
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// Secret New secret signing the events, at least 16 characters, the secret is kept if not given
+	Secret *string `json:"secret"`
+
+	// Url HTTP or HTTPS URL events are posted to
+	Url string `json:"url"`
+}
+
+// WebhookDeliveriesResponse defines model for WebhookDeliveriesResponse.
+type WebhookDeliveriesResponse struct {
+	Items      []WebhookDeliveryResponse `json:"items"`
+	Pagination PaginationInfo            `json:"pagination"`
+}
+
+// WebhookDeliveryResponse Delivery of an event to a webhook
+type WebhookDeliveryResponse struct {
+	// Attempts Delivery attempts made so far
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Time the event was queued
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeliveredAt Time the event was delivered
+	DeliveredAt *time.Time `json:"deliveredAt"`
+
+	// EventId Identifier of the event, shared by re-deliveries of the event
+	EventId string `json:"eventId"`
+
+	// EventType Type of an identity event
+	EventType WebhookEventType `json:"eventType"`
+
+	// Id Delivery unique identifier
+	Id string `json:"id"`
+
+	// LastError Error of the last failed delivery attempt
+	LastError *string `json:"lastError"`
+
+	// LastStatusCode HTTP status code of the last delivery attempt, null if no response was received
+	LastStatusCode *int `json:"lastStatusCode"`
+
+	// NextAttemptAt Time of the next delivery attempt of pending deliveries
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+
+	// Status Delivery state, dead deliveries are no longer retried automatically
+	Status WebhookDeliveryResponseStatus `json:"status"`
+
+	// WebhookId Webhook the event is delivered to
+	WebhookId string `json:"webhookId"`
+}
+
+// WebhookDeliveryResponseStatus Delivery state, dead deliveries are no longer retried automatically
+type WebhookDeliveryResponseStatus string
+
+// WebhookEvent Body of a webhook request. The request carries the X-Webhook-Signature header "t=<unix time>,v1=<signature>", where the signature is the hex encoded HMAC-SHA256 of "<unix time>.<body>" keyed with the secret of the webhook.
+type WebhookEvent struct {
+	// CreatedAt Time the event occurred
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Data Subject of the event. User events carry the user (id, email, firstName, lastName, emailVerified, active), role.assigned carries userId and role, tenant.updated carries the tenant (id, name, description).
+	Data map[string]interface{} `json:"data"`
+
+	// Id Event unique identifier, the same for every delivery of the event
+	Id string `json:"id"`
+
+	// TenantId Tenant the event occurred in
+	TenantId string `json:"tenantId"`
+
+	// Type Type of an identity event
+	Type WebhookEventType `json:"type"`
+}
+
+// WebhookEventType Type of an identity event
+type WebhookEventType string
+
+// WebhookResponse Webhook subscription of a tenant
+type WebhookResponse struct {
+	// Active Whether events are delivered
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventTypes Event types posted to the URL
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// Id Webhook unique identifier
+	Id string `json:"id"`
+
+	// TenantId Tenant whose events are delivered
+	TenantId  string    `json:"tenantId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Url URL events are posted to
+	Url string `json:"url"`
+}
+
+// WebhooksResponse defines model for WebhooksResponse.
+type WebhooksResponse struct {
+	Items []WebhookResponse `json:"items"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Page Page number for pagination
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Number of deliveries per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Status Only deliveries in this state
+	Status *ListWebhookDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// ListWebhookDeliveriesParamsStatus defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParamsStatus string
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = UpdateWebhookRequest
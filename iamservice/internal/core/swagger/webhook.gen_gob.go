@@ -0,0 +1,466 @@
+// Code generated by gobetter; DO NOT EDIT.
+
+package swagger
+
+import (
+	"time"
+)
+
+func NewCreateWebhookRequestBuilder() CreateWebhookRequest_Builder_Active {
+	return CreateWebhookRequest_Builder_Active{root: &CreateWebhookRequest{}}
+}
+
+type CreateWebhookRequest_Builder_Active struct {
+	root *CreateWebhookRequest
+}
+
+type CreateWebhookRequest_Builder_EventTypes struct {
+	root *CreateWebhookRequest
+}
+
+func (b CreateWebhookRequest_Builder_Active) Active(arg *bool) CreateWebhookRequest_Builder_EventTypes {
+	b.root.Active = arg
+	return CreateWebhookRequest_Builder_EventTypes{root: b.root}
+}
+
+type CreateWebhookRequest_Builder_Secret struct {
+	root *CreateWebhookRequest
+}
+
+func (b CreateWebhookRequest_Builder_EventTypes) EventTypes(arg []WebhookEventType) CreateWebhookRequest_Builder_Secret {
+	b.root.EventTypes = arg
+	return CreateWebhookRequest_Builder_Secret{root: b.root}
+}
+
+type CreateWebhookRequest_Builder_Url struct {
+	root *CreateWebhookRequest
+}
+
+func (b CreateWebhookRequest_Builder_Secret) Secret(arg *string) CreateWebhookRequest_Builder_Url {
+	b.root.Secret = arg
+	return CreateWebhookRequest_Builder_Url{root: b.root}
+}
+
+type CreateWebhookRequest_Builder_GobFinalizer struct {
+	root *CreateWebhookRequest
+}
+
+func (b CreateWebhookRequest_Builder_Url) Url(arg string) CreateWebhookRequest_Builder_GobFinalizer {
+	b.root.Url = arg
+	return CreateWebhookRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateWebhookRequest_Builder_GobFinalizer) Build() *CreateWebhookRequest {
+	return b.root
+}
+
+func NewCreateWebhookResponseBuilder() CreateWebhookResponse_Builder_Secret {
+	return CreateWebhookResponse_Builder_Secret{root: &CreateWebhookResponse{}}
+}
+
+type CreateWebhookResponse_Builder_Secret struct {
+	root *CreateWebhookResponse
+}
+
+type CreateWebhookResponse_Builder_Webhook struct {
+	root *CreateWebhookResponse
+}
+
+func (b CreateWebhookResponse_Builder_Secret) Secret(arg string) CreateWebhookResponse_Builder_Webhook {
+	b.root.Secret = arg
+	return CreateWebhookResponse_Builder_Webhook{root: b.root}
+}
+
+type CreateWebhookResponse_Builder_GobFinalizer struct {
+	root *CreateWebhookResponse
+}
+
+func (b CreateWebhookResponse_Builder_Webhook) Webhook(arg WebhookResponse) CreateWebhookResponse_Builder_GobFinalizer {
+	b.root.Webhook = arg
+	return CreateWebhookResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b CreateWebhookResponse_Builder_GobFinalizer) Build() *CreateWebhookResponse {
+	return b.root
+}
+
+func NewUpdateWebhookRequestBuilder() UpdateWebhookRequest_Builder_Active {
+	return UpdateWebhookRequest_Builder_Active{root: &UpdateWebhookRequest{}}
+}
+
+type UpdateWebhookRequest_Builder_Active struct {
+	root *UpdateWebhookRequest
+}
+
+type UpdateWebhookRequest_Builder_EventTypes struct {
+	root *UpdateWebhookRequest
+}
+
+func (b UpdateWebhookRequest_Builder_Active) Active(arg bool) UpdateWebhookRequest_Builder_EventTypes {
+	b.root.Active = arg
+	return UpdateWebhookRequest_Builder_EventTypes{root: b.root}
+}
+
+type UpdateWebhookRequest_Builder_Secret struct {
+	root *UpdateWebhookRequest
+}
+
+func (b UpdateWebhookRequest_Builder_EventTypes) EventTypes(arg []WebhookEventType) UpdateWebhookRequest_Builder_Secret {
+	b.root.EventTypes = arg
+	return UpdateWebhookRequest_Builder_Secret{root: b.root}
+}
+
+type UpdateWebhookRequest_Builder_Url struct {
+	root *UpdateWebhookRequest
+}
+
+func (b UpdateWebhookRequest_Builder_Secret) Secret(arg *string) UpdateWebhookRequest_Builder_Url {
+	b.root.Secret = arg
+	return UpdateWebhookRequest_Builder_Url{root: b.root}
+}
+
+type UpdateWebhookRequest_Builder_GobFinalizer struct {
+	root *UpdateWebhookRequest
+}
+
+func (b UpdateWebhookRequest_Builder_Url) Url(arg string) UpdateWebhookRequest_Builder_GobFinalizer {
+	b.root.Url = arg
+	return UpdateWebhookRequest_Builder_GobFinalizer{root: b.root}
+}
+
+func (b UpdateWebhookRequest_Builder_GobFinalizer) Build() *UpdateWebhookRequest {
+	return b.root
+}
+
+func NewWebhookDeliveriesResponseBuilder() WebhookDeliveriesResponse_Builder_Items {
+	return WebhookDeliveriesResponse_Builder_Items{root: &WebhookDeliveriesResponse{}}
+}
+
+type WebhookDeliveriesResponse_Builder_Items struct {
+	root *WebhookDeliveriesResponse
+}
+
+type WebhookDeliveriesResponse_Builder_Pagination struct {
+	root *WebhookDeliveriesResponse
+}
+
+func (b WebhookDeliveriesResponse_Builder_Items) Items(arg []WebhookDeliveryResponse) WebhookDeliveriesResponse_Builder_Pagination {
+	b.root.Items = arg
+	return WebhookDeliveriesResponse_Builder_Pagination{root: b.root}
+}
+
+type WebhookDeliveriesResponse_Builder_GobFinalizer struct {
+	root *WebhookDeliveriesResponse
+}
+
+func (b WebhookDeliveriesResponse_Builder_Pagination) Pagination(arg PaginationInfo) WebhookDeliveriesResponse_Builder_GobFinalizer {
+	b.root.Pagination = arg
+	return WebhookDeliveriesResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookDeliveriesResponse_Builder_GobFinalizer) Build() *WebhookDeliveriesResponse {
+	return b.root
+}
+
+func NewWebhookDeliveryResponseBuilder() WebhookDeliveryResponse_Builder_Attempts {
+	return WebhookDeliveryResponse_Builder_Attempts{root: &WebhookDeliveryResponse{}}
+}
+
+type WebhookDeliveryResponse_Builder_Attempts struct {
+	root *WebhookDeliveryResponse
+}
+
+type WebhookDeliveryResponse_Builder_CreatedAt struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_Attempts) Attempts(arg int) WebhookDeliveryResponse_Builder_CreatedAt {
+	b.root.Attempts = arg
+	return WebhookDeliveryResponse_Builder_CreatedAt{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_DeliveredAt struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookDeliveryResponse_Builder_DeliveredAt {
+	b.root.CreatedAt = arg
+	return WebhookDeliveryResponse_Builder_DeliveredAt{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_EventId struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_DeliveredAt) DeliveredAt(arg *time.Time) WebhookDeliveryResponse_Builder_EventId {
+	b.root.DeliveredAt = arg
+	return WebhookDeliveryResponse_Builder_EventId{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_EventType struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_EventId) EventId(arg string) WebhookDeliveryResponse_Builder_EventType {
+	b.root.EventId = arg
+	return WebhookDeliveryResponse_Builder_EventType{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_Id struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_EventType) EventType(arg WebhookEventType) WebhookDeliveryResponse_Builder_Id {
+	b.root.EventType = arg
+	return WebhookDeliveryResponse_Builder_Id{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_LastError struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_Id) Id(arg string) WebhookDeliveryResponse_Builder_LastError {
+	b.root.Id = arg
+	return WebhookDeliveryResponse_Builder_LastError{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_LastStatusCode struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_LastError) LastError(arg *string) WebhookDeliveryResponse_Builder_LastStatusCode {
+	b.root.LastError = arg
+	return WebhookDeliveryResponse_Builder_LastStatusCode{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_NextAttemptAt struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_LastStatusCode) LastStatusCode(arg *int) WebhookDeliveryResponse_Builder_NextAttemptAt {
+	b.root.LastStatusCode = arg
+	return WebhookDeliveryResponse_Builder_NextAttemptAt{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_Status struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_NextAttemptAt) NextAttemptAt(arg time.Time) WebhookDeliveryResponse_Builder_Status {
+	b.root.NextAttemptAt = arg
+	return WebhookDeliveryResponse_Builder_Status{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_WebhookId struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_Status) Status(arg WebhookDeliveryResponseStatus) WebhookDeliveryResponse_Builder_WebhookId {
+	b.root.Status = arg
+	return WebhookDeliveryResponse_Builder_WebhookId{root: b.root}
+}
+
+type WebhookDeliveryResponse_Builder_GobFinalizer struct {
+	root *WebhookDeliveryResponse
+}
+
+func (b WebhookDeliveryResponse_Builder_WebhookId) WebhookId(arg string) WebhookDeliveryResponse_Builder_GobFinalizer {
+	b.root.WebhookId = arg
+	return WebhookDeliveryResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookDeliveryResponse_Builder_GobFinalizer) Build() *WebhookDeliveryResponse {
+	return b.root
+}
+
+func NewWebhookEventBuilder() WebhookEvent_Builder_CreatedAt {
+	return WebhookEvent_Builder_CreatedAt{root: &WebhookEvent{}}
+}
+
+type WebhookEvent_Builder_CreatedAt struct {
+	root *WebhookEvent
+}
+
+type WebhookEvent_Builder_Data struct {
+	root *WebhookEvent
+}
+
+func (b WebhookEvent_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookEvent_Builder_Data {
+	b.root.CreatedAt = arg
+	return WebhookEvent_Builder_Data{root: b.root}
+}
+
+type WebhookEvent_Builder_Id struct {
+	root *WebhookEvent
+}
+
+func (b WebhookEvent_Builder_Data) Data(arg map[string]interface{}) WebhookEvent_Builder_Id {
+	b.root.Data = arg
+	return WebhookEvent_Builder_Id{root: b.root}
+}
+
+type WebhookEvent_Builder_TenantId struct {
+	root *WebhookEvent
+}
+
+func (b WebhookEvent_Builder_Id) Id(arg string) WebhookEvent_Builder_TenantId {
+	b.root.Id = arg
+	return WebhookEvent_Builder_TenantId{root: b.root}
+}
+
+type WebhookEvent_Builder_Type struct {
+	root *WebhookEvent
+}
+
+func (b WebhookEvent_Builder_TenantId) TenantId(arg string) WebhookEvent_Builder_Type {
+	b.root.TenantId = arg
+	return WebhookEvent_Builder_Type{root: b.root}
+}
+
+type WebhookEvent_Builder_GobFinalizer struct {
+	root *WebhookEvent
+}
+
+func (b WebhookEvent_Builder_Type) Type(arg WebhookEventType) WebhookEvent_Builder_GobFinalizer {
+	b.root.Type = arg
+	return WebhookEvent_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookEvent_Builder_GobFinalizer) Build() *WebhookEvent {
+	return b.root
+}
+
+func NewWebhookResponseBuilder() WebhookResponse_Builder_Active {
+	return WebhookResponse_Builder_Active{root: &WebhookResponse{}}
+}
+
+type WebhookResponse_Builder_Active struct {
+	root *WebhookResponse
+}
+
+type WebhookResponse_Builder_CreatedAt struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_Active) Active(arg bool) WebhookResponse_Builder_CreatedAt {
+	b.root.Active = arg
+	return WebhookResponse_Builder_CreatedAt{root: b.root}
+}
+
+type WebhookResponse_Builder_EventTypes struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_CreatedAt) CreatedAt(arg time.Time) WebhookResponse_Builder_EventTypes {
+	b.root.CreatedAt = arg
+	return WebhookResponse_Builder_EventTypes{root: b.root}
+}
+
+type WebhookResponse_Builder_Id struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_EventTypes) EventTypes(arg []WebhookEventType) WebhookResponse_Builder_Id {
+	b.root.EventTypes = arg
+	return WebhookResponse_Builder_Id{root: b.root}
+}
+
+type WebhookResponse_Builder_TenantId struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_Id) Id(arg string) WebhookResponse_Builder_TenantId {
+	b.root.Id = arg
+	return WebhookResponse_Builder_TenantId{root: b.root}
+}
+
+type WebhookResponse_Builder_UpdatedAt struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_TenantId) TenantId(arg string) WebhookResponse_Builder_UpdatedAt {
+	b.root.TenantId = arg
+	return WebhookResponse_Builder_UpdatedAt{root: b.root}
+}
+
+type WebhookResponse_Builder_Url struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_UpdatedAt) UpdatedAt(arg time.Time) WebhookResponse_Builder_Url {
+	b.root.UpdatedAt = arg
+	return WebhookResponse_Builder_Url{root: b.root}
+}
+
+type WebhookResponse_Builder_GobFinalizer struct {
+	root *WebhookResponse
+}
+
+func (b WebhookResponse_Builder_Url) Url(arg string) WebhookResponse_Builder_GobFinalizer {
+	b.root.Url = arg
+	return WebhookResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhookResponse_Builder_GobFinalizer) Build() *WebhookResponse {
+	return b.root
+}
+
+func NewWebhooksResponseBuilder() WebhooksResponse_Builder_Items {
+	return WebhooksResponse_Builder_Items{root: &WebhooksResponse{}}
+}
+
+type WebhooksResponse_Builder_Items struct {
+	root *WebhooksResponse
+}
+
+type WebhooksResponse_Builder_GobFinalizer struct {
+	root *WebhooksResponse
+}
+
+func (b WebhooksResponse_Builder_Items) Items(arg []WebhookResponse) WebhooksResponse_Builder_GobFinalizer {
+	b.root.Items = arg
+	return WebhooksResponse_Builder_GobFinalizer{root: b.root}
+}
+
+func (b WebhooksResponse_Builder_GobFinalizer) Build() *WebhooksResponse {
+	return b.root
+}
+
+func NewListWebhookDeliveriesParamsBuilder() ListWebhookDeliveriesParams_Builder_Page {
+	return ListWebhookDeliveriesParams_Builder_Page{root: &ListWebhookDeliveriesParams{}}
+}
+
+type ListWebhookDeliveriesParams_Builder_Page struct {
+	root *ListWebhookDeliveriesParams
+}
+
+type ListWebhookDeliveriesParams_Builder_Limit struct {
+	root *ListWebhookDeliveriesParams
+}
+
+func (b ListWebhookDeliveriesParams_Builder_Page) Page(arg *int) ListWebhookDeliveriesParams_Builder_Limit {
+	b.root.Page = arg
+	return ListWebhookDeliveriesParams_Builder_Limit{root: b.root}
+}
+
+type ListWebhookDeliveriesParams_Builder_Status struct {
+	root *ListWebhookDeliveriesParams
+}
+
+func (b ListWebhookDeliveriesParams_Builder_Limit) Limit(arg *int) ListWebhookDeliveriesParams_Builder_Status {
+	b.root.Limit = arg
+	return ListWebhookDeliveriesParams_Builder_Status{root: b.root}
+}
+
+type ListWebhookDeliveriesParams_Builder_GobFinalizer struct {
+	root *ListWebhookDeliveriesParams
+}
+
+func (b ListWebhookDeliveriesParams_Builder_Status) Status(arg *ListWebhookDeliveriesParamsStatus) ListWebhookDeliveriesParams_Builder_GobFinalizer {
+	b.root.Status = arg
+	return ListWebhookDeliveriesParams_Builder_GobFinalizer{root: b.root}
+}
+
+func (b ListWebhookDeliveriesParams_Builder_GobFinalizer) Build() *ListWebhookDeliveriesParams {
+	return b.root
+}
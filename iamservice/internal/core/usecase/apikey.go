@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+const (
+	// apiKeyPrefix marks personal API keys, so they are easy to recognize in scripts and secret scanners
+	apiKeyPrefix = "iamk_"
+	// apiKeyDisplayPrefixLen is the number of leading characters of a key shown in key listings
+	apiKeyDisplayPrefixLen = 12
+)
+
+// CreateUserAPIKey creates a personal API key for the principal. The key is limited to the requested roles, which
+// must be held by both the principal and the user, or to all of them if none are requested.
+// The key is returned in the response only, the user keeps its hash.
+func (a *AuthMgm) CreateUserAPIKey(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.CreateApiKeyRequest,
+) (*swagger.CreateApiKeyResponse, error) {
+	katapp.Logger(ctx).Info("creating api key", "principal", principal.String(), "userID", userID, "name", req.Name)
+
+	if principal.IsServiceClient() || principal.UserID != userID {
+		msg := "api keys can be created only by the user for themselves"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	// A leaked key must not be able to mint further keys that outlive its revocation
+	if !principal.IsSignedInUser() {
+		msg := "api keys can be created only by a signed in user"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "apiKeyID", principal.APIKeyID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "api key name is required")
+	}
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "expiresAt must be in the future")
+	}
+
+	key := generateAPIKey()
+	apiKey, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*model.APIKey, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		userRoles, err := a.authUserPersist.GetUserRoles(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user roles")
+		}
+		// Roles removed from the user after the access token was issued cannot be granted to the key
+		grantableRoles := lo.Intersect(principal.Roles, userRoles)
+		roles := grantableRoles
+		if req.Roles != nil {
+			roles = lo.Uniq(*req.Roles)
+			if len(roles) == 0 {
+				return nil, katapp.NewErr(katapp.ErrInvalidInput, "at least one role is required")
+			}
+			for _, role := range roles {
+				if !lo.Contains(grantableRoles, role) {
+					return nil, katapp.NewErr(katapp.ErrInvalidInput,
+						fmt.Sprintf("role %q is not assigned to the user", role))
+				}
+			}
+		}
+		if len(roles) == 0 {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "user has no roles to grant to the api key")
+		}
+
+		apiKey := model.NewAPIKeyBuilder().
+			ID("key-" + uuid.NewString()).
+			UserID(user.ID).
+			Name(name).
+			KeyPrefix(key[:apiKeyDisplayPrefixLen]).
+			KeyHash(hashAPIKey(key)).
+			Roles(roles).
+			ExpiresAt(req.ExpiresAt).
+			LastUsedAt(nil).
+			CreatedAt(now).
+			Build()
+		if err := a.apiKeyPersist.CreateAPIKey(ctx, tx, apiKey); err != nil {
+			return nil, err
+		}
+		diff := auditDiff{}.
+			created("name", apiKey.Name).
+			created("roles", apiKey.Roles)
+		if apiKey.ExpiresAt != nil {
+			diff.created("expires_at", apiKey.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		err = recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserAPIKeyCreated,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       diff.created("api_key", apiKey.ID),
+		})
+		return apiKey, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swagger.NewCreateApiKeyResponseBuilder().
+		ApiKey(*apiKeyToApiKeyResponse(apiKey)).
+		Key(key).
+		Build(), nil
+}
+
+// ListUserAPIKeys returns the API keys of a user, most recently created first
+func (a *AuthMgm) ListUserAPIKeys(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.ApiKeysResponse, error) {
+	katapp.Logger(ctx).Info("listing api keys", "principal", principal.String(), "userID", userID)
+
+	apiKeys, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) ([]*model.APIKey, error) {
+		if _, err := a.getUserForSessionManagement(ctx, tx, principal, userID); err != nil {
+			return nil, err
+		}
+		return a.apiKeyPersist.GetAPIKeysByUserID(ctx, tx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]swagger.ApiKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		items[i] = *apiKeyToApiKeyResponse(apiKey)
+	}
+	return swagger.NewApiKeysResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// RevokeUserAPIKey deletes an API key of a user, requests made with the key are rejected from now on
+func (a *AuthMgm) RevokeUserAPIKey(ctx context.Context, principal *UserPrincipal, userID string, keyID string) error {
+	katapp.Logger(ctx).Info("revoking api key", "principal", principal.String(), "userID", userID, "keyID", keyID)
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := a.getUserForSessionManagement(ctx, tx, principal, userID)
+		if err != nil {
+			return err
+		}
+		if err := a.apiKeyPersist.DeleteAPIKey(ctx, tx, user.ID, keyID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserAPIKeyRevoked,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.deleted("api_key", keyID),
+		})
+	})
+}
+
+// AuthenticateAPIKey resolves an API key to the principal of its user. The principal carries the roles of the
+// key that the user still has with their permissions, and the use of the key is recorded.
+func (a *AuthMgm) AuthenticateAPIKey(ctx context.Context, key string) (*UserPrincipal, error) {
+	unauthorized := katapp.NewErr(katapp.ErrUnauthorized, "invalid api key")
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, unauthorized
+	}
+
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*UserPrincipal, error) {
+		apiKey, err := a.apiKeyPersist.GetAPIKeyByHash(ctx, tx, hashAPIKey(key))
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		if apiKey == nil || apiKey.IsExpired(now) {
+			katapp.Logger(ctx).Warn("unknown or expired api key")
+			return nil, unauthorized
+		}
+		user, err := a.authUserPersist.GetUserByID(ctx, tx, apiKey.UserID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
+		}
+		if user == nil || !user.IsActive {
+			katapp.Logger(ctx).Warn("api key of inactive user", "keyID", apiKey.ID, "userID", apiKey.UserID)
+			return nil, unauthorized
+		}
+		userRoles, err := a.authUserPersist.GetUserRoles(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user roles")
+		}
+		roles := lo.Intersect(apiKey.Roles, userRoles)
+		permissions, err := a.rolePersist.GetPermissionsByRoles(ctx, tx, user.TenantID, roles)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user permissions")
+		}
+		if err := a.apiKeyPersist.TouchAPIKey(ctx, tx, apiKey.ID, now); err != nil {
+			return nil, err
+		}
+		return &UserPrincipal{
+			UserID:      user.ID,
+			TenantID:    user.TenantID,
+			Email:       user.Email,
+			Roles:       roles,
+			Permissions: permissions,
+			Type:        PrincipalTypeUser,
+			APIKeyID:    apiKey.ID,
+		}, nil
+	})
+}
+
+// generateAPIKey generates a secure random API key
+func generateAPIKey() string {
+	b := make([]byte, 32) // 256-bit key
+	_, _ = rand.Read(b)
+	return apiKeyPrefix + hex.EncodeToString(b)
+}
+
+// hashAPIKey creates SHA-256 hash of the API key for secure database storage. Keys are random, so unlike
+// passwords they need no salted hash, and the hash can be looked up directly.
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func apiKeyToApiKeyResponse(apiKey *model.APIKey) *swagger.ApiKeyResponse {
+	return swagger.NewApiKeyResponseBuilder().
+		CreatedAt(apiKey.CreatedAt).
+		ExpiresAt(apiKey.ExpiresAt).
+		Id(apiKey.ID).
+		LastUsedAt(apiKey.LastUsedAt).
+		Name(apiKey.Name).
+		Prefix(apiKey.KeyPrefix).
+		Roles(apiKey.Roles).
+		Build()
+}
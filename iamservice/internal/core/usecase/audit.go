@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// ClientInfo describes the client a request comes from. It is recorded with audit events.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo returns a context carrying client information of the current request
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+func clientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// auditEntry describes an audit event to record, the client information is taken from the context
+type auditEntry struct {
+	action     string
+	principal  *UserPrincipal // nil for unauthenticated actors
+	tenantID   string
+	targetType string
+	targetID   string
+	reason     string
+	diff       map[string]model.AuditChange
+}
+
+// recordAuditEvent records the event in the given transaction, so that it is only kept if the audited
+// change is committed
+func recordAuditEvent(ctx context.Context, auditPersist outport.AuditPersist, tx pgx.Tx, entry auditEntry) error {
+	clientInfo := clientInfoFromContext(ctx)
+	var actorUserID, actorTenantID, actorEmail *string
+	var actorRoles []string
+	if entry.principal != nil {
+		actorUserID = &entry.principal.UserID
+		actorTenantID = lo.EmptyableToPtr(entry.principal.TenantID)
+		actorEmail = lo.EmptyableToPtr(entry.principal.Email)
+		actorRoles = entry.principal.Roles
+	}
+	event := model.NewAuditEventBuilder().
+		ID(uuid.NewString()).
+		Action(entry.action).
+		TenantID(lo.EmptyableToPtr(entry.tenantID)).
+		ActorUserID(actorUserID).
+		ActorTenantID(actorTenantID).
+		ActorEmail(actorEmail).
+		ActorRoles(actorRoles).
+		TargetType(lo.EmptyableToPtr(entry.targetType)).
+		TargetID(lo.EmptyableToPtr(entry.targetID)).
+		IPAddress(lo.EmptyableToPtr(clientInfo.IPAddress)).
+		UserAgent(lo.EmptyableToPtr(clientInfo.UserAgent)).
+		Reason(lo.EmptyableToPtr(entry.reason)).
+		Diff(entry.diff).
+		CreatedAt(time.Now()).
+		Build()
+	if _, err := auditPersist.CreateAuditEvent(ctx, tx, event); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to record audit event")
+	}
+	return nil
+}
+
+// recordDetachedAuditEvent records the event in its own transaction. Used for events of failed operations,
+// whose transaction is rolled back. Errors are logged only so that they do not mask the original error.
+func recordDetachedAuditEvent(
+	ctx context.Context, auditPersist outport.AuditPersist, txPort outport.TxPort, entry auditEntry,
+) {
+	err := txPort.Run(ctx, func(tx pgx.Tx) error {
+		return recordAuditEvent(ctx, auditPersist, tx, entry)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record audit event", "action", entry.action, "error", err)
+	}
+}
+
+// auditDiff collects changed fields, fields with equal old and new values are skipped. Values are compared
+// deeply, so that slices such as permissions can be diffed as well.
+type auditDiff map[string]model.AuditChange
+
+func (d auditDiff) changed(field string, oldValue any, newValue any) auditDiff {
+	if !reflect.DeepEqual(oldValue, newValue) {
+		d[field] = model.AuditChange{Old: oldValue, New: newValue}
+	}
+	return d
+}
+
+func (d auditDiff) created(field string, newValue any) auditDiff {
+	d[field] = model.AuditChange{New: newValue}
+	return d
+}
+
+func (d auditDiff) deleted(field string, oldValue any) auditDiff {
+	d[field] = model.AuditChange{Old: oldValue}
+	return d
+}
+
+// AuditMgm provides access to the audit log
+type AuditMgm struct {
+	auditPersist outport.AuditPersist
+	txPort       outport.TxPort
+}
+
+// NewAuditMgm creates a new AuditMgm use case
+func NewAuditMgm(auditPort outport.AuditPersist, databasePort outport.TxPort) *AuditMgm {
+	return &AuditMgm{
+		auditPersist: auditPort,
+		txPort:       databasePort,
+	}
+}
+
+// ListAuditEvents returns a filtered, paginated list of audit events. Principals with audit:read in all tenants
+// see all tenants, others their own tenant only.
+func (a *AuditMgm) ListAuditEvents(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListAuditEventsParams,
+) (*swagger.AuditEventsResponse, error) {
+	katapp.Logger(ctx).Info("listing audit events", "principal", principal.String())
+
+	tenantID := params.TenantId
+	if !principal.HasPermissionInAllTenants(model.PermissionAuditRead) {
+		if !principal.HasPermission(model.PermissionAuditRead) || (tenantID != nil && *tenantID != principal.TenantID) {
+			msg := "insufficient permissions to list audit events"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", lo.FromPtr(tenantID))
+			return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		tenantID = &principal.TenantID
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "from must be before to")
+	}
+
+	page := lo.FromPtrOr(params.Page, 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := lo.FromPtrOr(params.Limit, 20)
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	filter := model.NewAuditEventFilterBuilder().
+		TenantID(tenantID).
+		Action(params.Action).
+		ActorUserID(params.ActorUserId).
+		TargetID(params.TargetId).
+		From(params.From).
+		To(params.To).
+		Build()
+
+	var events []*model.AuditEvent
+	var total int
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		var err error
+		events, total, err = a.auditPersist.ListAuditEvents(ctx, tx, filter, (page-1)*limit, limit)
+		return err
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list audit events")
+	}
+
+	items := make([]swagger.AuditEventResponse, len(events))
+	for i, event := range events {
+		items[i] = *auditEventToAuditEventResponse(event)
+	}
+	pagination := swagger.NewPaginationInfoBuilder().
+		Limit(limit).
+		Page(page).
+		Total(total).
+		TotalPages((total + limit - 1) / limit).
+		Build()
+	return swagger.NewAuditEventsResponseBuilder().
+		Items(items).
+		Pagination(*pagination).
+		Build(), nil
+}
+
+func auditEventToAuditEventResponse(event *model.AuditEvent) *swagger.AuditEventResponse {
+	diff := make(map[string]swagger.AuditChange, len(event.Diff))
+	for field, change := range event.Diff {
+		diff[field] = *swagger.NewAuditChangeBuilder().
+			New(lo.Ternary(change.New != nil, &change.New, nil)).
+			Old(lo.Ternary(change.Old != nil, &change.Old, nil)).
+			Build()
+	}
+	actorRoles := event.ActorRoles
+	if actorRoles == nil {
+		actorRoles = []string{}
+	}
+	return swagger.NewAuditEventResponseBuilder().
+		Action(event.Action).
+		ActorEmail(event.ActorEmail).
+		ActorRoles(actorRoles).
+		ActorTenantId(event.ActorTenantID).
+		ActorUserId(event.ActorUserID).
+		CreatedAt(event.CreatedAt).
+		Diff(diff).
+		Id(event.ID).
+		IpAddress(event.IPAddress).
+		Reason(event.Reason).
+		TargetId(event.TargetID).
+		TargetType(event.TargetType).
+		TenantId(event.TenantID).
+		UserAgent(event.UserAgent).
+		Build()
+}
@@ -13,43 +13,79 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthMgm provides authentication use cases
 type AuthMgm struct {
-	serverConfig    *katapp.ServerConfig
-	authUserPersist outport.AuthUserPersist
-	txPort          outport.TxPort
-	mailer          outport.Mailer
-	jwtSecret       []byte
+	serverConfig           *katapp.ServerConfig
+	throttleConfig         *app.SignInThrottleConfig
+	confirmationConfig     *app.EmailConfirmationConfig
+	authUserPersist        outport.AuthUserPersist
+	mfaPersist             outport.MFAPersist
+	signInThrottlePersist  outport.SignInThrottlePersist
+	auditPersist           outport.AuditPersist
+	tokenRevocationPersist outport.TokenRevocationPersist
+	apiKeyPersist          outport.APIKeyPersist
+	rolePersist            outport.RolePersist
+	txPort                 outport.TxPort
+	outboxPersist          outport.OutboxPersist
+	webhookPersist         outport.WebhookPersist
+	jwtKeys                *JWTKeySet
 }
 
 // NewAuthUser creates a new AuthMgm use case
 func NewAuthUser(
-	serverConfig *katapp.ServerConfig, authUserPort outport.AuthUserPersist, databasePort outport.TxPort,
-	mailer outport.Mailer, jwtSecret string,
+	serverConfig *katapp.ServerConfig, throttleConfig *app.SignInThrottleConfig,
+	confirmationConfig *app.EmailConfirmationConfig,
+	authUserPort outport.AuthUserPersist, mfaPort outport.MFAPersist, signInThrottlePort outport.SignInThrottlePersist,
+	auditPort outport.AuditPersist, tokenRevocationPort outport.TokenRevocationPersist, apiKeyPort outport.APIKeyPersist,
+	rolePort outport.RolePersist, outboxPort outport.OutboxPersist, webhookPort outport.WebhookPersist,
+	databasePort outport.TxPort, jwtKeys *JWTKeySet,
 ) *AuthMgm {
 	return &AuthMgm{
-		serverConfig:    serverConfig,
-		authUserPersist: authUserPort,
-		txPort:          databasePort,
-		mailer:          mailer,
-		jwtSecret:       []byte(jwtSecret),
+		serverConfig:           serverConfig,
+		throttleConfig:         throttleConfig,
+		confirmationConfig:     confirmationConfig,
+		authUserPersist:        authUserPort,
+		mfaPersist:             mfaPort,
+		signInThrottlePersist:  signInThrottlePort,
+		auditPersist:           auditPort,
+		tokenRevocationPersist: tokenRevocationPort,
+		apiKeyPersist:          apiKeyPort,
+		rolePersist:            rolePort,
+		txPort:                 databasePort,
+		outboxPersist:          outboxPort,
+		webhookPersist:         webhookPort,
+		jwtKeys:                jwtKeys,
 	}
 }
 
-// RefreshToken generates new tokens using a refresh token with rotation
+// RefreshToken generates new tokens using a refresh token with rotation. Refresh tokens issued to OAuth clients
+// are redeemed at the OAuth token endpoint only.
 func (a *AuthMgm) RefreshToken(ctx context.Context, req *swagger.TokenRefreshRequest) (*swagger.SignInResponse, error) {
 	if req.RefreshToken == "" {
 		return nil, katapp.NewErr(katapp.ErrInvalidInput, "refresh token is required")
 	}
+	return a.rotateRefreshToken(ctx, req.RefreshToken, nil)
+}
 
+// rotateRefreshToken replaces a refresh token with new tokens of the same session. The session must have been
+// started for oauthClientID, nil for sessions of a direct sign in.
+func (a *AuthMgm) rotateRefreshToken(
+	ctx context.Context, refreshToken string, oauthClientID *string,
+) (*swagger.SignInResponse, error) {
 	// Hash the provided refresh token for database lookup
-	tokenHash := a.hashRefreshToken(req.RefreshToken)
+	tokenHash := a.hashRefreshToken(refreshToken)
+
+	// Already rotated refresh token presented again, its family is revoked after the transaction is rolled back
+	var reusedUserID, reusedFamilyID string
 
 	// Validate refresh token and get user in a transaction
 	result, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.SignInResponse, error) {
@@ -58,10 +94,29 @@ func (a *AuthMgm) RefreshToken(ctx context.Context, req *swagger.TokenRefreshReq
 		if err != nil {
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to validate refresh token")
 		}
+		if refreshTokenRecord != nil && refreshTokenRecord.Revoked {
+			reusedUserID, reusedFamilyID = refreshTokenRecord.UserID, refreshTokenRecord.FamilyID
+		}
+		if refreshTokenRecord == nil {
+			// Rotated tokens are removed from their family after a while, a signed token that is no longer
+			// stored is a rotated one as well
+			reusedUserID, reusedFamilyID = a.getRefreshTokenFamily(refreshToken)
+		}
 		if refreshTokenRecord == nil || !refreshTokenRecord.IsValid() {
 			return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired refresh token")
 		}
 
+		// Refresh tokens are bound to the client their session was started for
+		session, err := a.authUserPersist.GetActiveSessionByID(ctx, tx, refreshTokenRecord.FamilyID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get session")
+		}
+		if session == nil || lo.FromPtr(session.OAuthClientID) != lo.FromPtr(oauthClientID) {
+			katapp.Logger(ctx).Warn("refresh token presented by another client",
+				"familyID", refreshTokenRecord.FamilyID, "clientID", lo.FromPtr(oauthClientID))
+			return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired refresh token")
+		}
+
 		// Get user
 		user, err := a.authUserPersist.GetUserByID(ctx, tx, refreshTokenRecord.UserID)
 		if err != nil {
@@ -71,15 +126,41 @@ func (a *AuthMgm) RefreshToken(ctx context.Context, req *swagger.TokenRefreshReq
 			return nil, katapp.NewErr(katapp.ErrNotFound, "user not found")
 		}
 
-		// Revoke the old refresh token immediately (rotation)
-		err = a.authUserPersist.RevokeRefreshToken(ctx, tx, tokenHash)
+		// Revoke the old refresh token immediately (rotation). A concurrent rotation of the same token that
+		// revoked it first wins, this one is handled as reuse of the rotated token.
+		revoked, err := a.authUserPersist.RevokeRefreshToken(ctx, tx, tokenHash)
 		if err != nil {
 			katapp.Logger(ctx).Error("failed to revoke old refresh token", "error", err)
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to revoke old refresh token")
 		}
+		if revoked != 1 {
+			reusedUserID, reusedFamilyID = refreshTokenRecord.UserID, refreshTokenRecord.FamilyID
+			return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired refresh token")
+		}
+
+		// A rotation keeps only the most recent tokens of its session and records the session as used
+		rowsAffected, err := a.authUserPersist.CleanupRefreshTokenFamily(
+			ctx, tx, refreshTokenRecord.FamilyID, maxRefreshTokensPerFamily-1,
+		)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to cleanup session refresh tokens",
+				"familyID", refreshTokenRecord.FamilyID, "error", err)
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to cleanup session refresh tokens")
+		}
+		katapp.Logger(ctx).Debug("cleaned up old refresh tokens",
+			"familyID", refreshTokenRecord.FamilyID, "rowsAffected", rowsAffected)
+		clientInfo := clientInfoFromContext(ctx)
+		if err := a.authUserPersist.TouchSession(ctx, tx, refreshTokenRecord.FamilyID,
+			lo.EmptyableToPtr(clientInfo.UserAgent), lo.EmptyableToPtr(clientInfo.IPAddress),
+		); err != nil {
+			katapp.Logger(ctx).Error("failed to update session", "familyID", refreshTokenRecord.FamilyID, "error", err)
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to update session")
+		}
 
-		// Generate new tokens with roles
-		accessToken, newRefreshToken, expiresIn, err := a.generateJWTTokenForUserWithTx(ctx, tx, user)
+		// Generate new tokens with roles, the new refresh token continues the family of the old one
+		accessToken, newRefreshToken, expiresIn, err := a.generateJWTTokenInFamilyWithTx(
+			ctx, tx, user, refreshTokenRecord.FamilyID,
+		)
 		if err != nil {
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate tokens")
 		}
@@ -95,14 +176,63 @@ func (a *AuthMgm) RefreshToken(ctx context.Context, req *swagger.TokenRefreshReq
 				Build(),
 			nil
 	})
+	if reusedFamilyID != "" {
+		a.revokeReusedRefreshTokenFamily(ctx, reusedUserID, reusedFamilyID)
+	}
 
 	return result, err
 }
 
-// ValidateAccessToken validates an access token and returns the user ID
-func (a *AuthMgm) ValidateAccessToken(token string) (string, error) {
+// revokeReusedRefreshTokenFamily handles a replayed refresh token that was already rotated. Either the legitimate
+// client or an attacker holds the newer token of the family, so the whole family and access tokens of its session
+// are revoked and both have to sign in again. Errors are logged only so that they do not mask the refresh error.
+func (a *AuthMgm) revokeReusedRefreshTokenFamily(ctx context.Context, userID string, familyID string) {
+	katapp.Logger(ctx).Warn("revoked refresh token reused, revoking its family",
+		"userID", userID, "familyID", familyID)
+
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return err
+		}
+		revoked, err := a.authUserPersist.RevokeRefreshTokenFamily(ctx, tx, familyID)
+		if err != nil {
+			return err
+		}
+		// No tokens are issued in the session anymore, so tokens issued within the current second are rejected too
+		err = revokeAccessTokensIssuedBefore(ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationSession,
+			familyID, time.Now().Truncate(time.Second).Add(time.Second))
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionRefreshTokenReused,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			reason:     lo.Ternary(revoked > 0, "family_revoked", "family_already_revoked"),
+		})
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to revoke refresh token family",
+			"userID", userID, "familyID", familyID, "error", err)
+	}
+}
+
+// ValidateAccessToken validates an access token of a user, including that it is not revoked, and returns
+// the user ID. Tokens of service clients are rejected.
+func (a *AuthMgm) ValidateAccessToken(ctx context.Context, token string) (string, error) {
+	claims, err := a.validateUserAccessToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// validateUserAccessToken validates an access token of a user like ValidateAccessToken and returns its claims
+func (a *AuthMgm) validateUserAccessToken(ctx context.Context, token string) (*accessTokenClaims, error) {
 	if token == "" {
-		return "", katapp.NewErr(katapp.ErrUnauthorized, "access token is required")
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "access token is required")
 	}
 
 	// Remove "Bearer " prefix if present
@@ -110,12 +240,20 @@ func (a *AuthMgm) ValidateAccessToken(token string) (string, error) {
 		token = strings.TrimPrefix(token, "Bearer ")
 	}
 
-	userID, err := a.getUserIDFromAccessToken(token)
+	claims, err := a.parseAccessToken(token)
 	if err != nil {
-		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired access token")
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired access token")
+	}
+	if claims.isServiceClient() {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "access token of a service client cannot be used here")
+	}
+	if err := a.CheckAccessTokenNotRevoked(
+		ctx, claims.ID, claims.SessionID, claims.Subject, claims.IssuedAt.Time,
+	); err != nil {
+		return nil, err
 	}
 
-	return userID, nil
+	return claims, nil
 }
 
 // ValidateUserPasswordMatches validates a user's password
@@ -149,14 +287,14 @@ func (a *AuthMgm) ValidateUserPasswordMatches(
 
 // generateJWTTokenForUser generates JWT access and refresh tokens including user roles and tenant ID
 func (a *AuthMgm) generateJWTTokenForUser(
-	ctx context.Context, user *model.AuthUser,
+	ctx context.Context, user *model.AuthUser, source string,
 ) (accessToken string, refreshToken string, expiresIn int64, err error) {
 	result, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (struct {
 		AccessToken  string
 		RefreshToken string
 		ExpiresIn    int64
 	}, error) {
-		accessToken, refreshToken, expiresIn, err := a.generateJWTTokenForUserWithTx(ctx, tx, user)
+		accessToken, refreshToken, expiresIn, err := a.generateJWTTokenForUserWithTx(ctx, tx, user, source)
 		return struct {
 			AccessToken  string
 			RefreshToken string
@@ -169,36 +307,95 @@ func (a *AuthMgm) generateJWTTokenForUser(
 	return result.AccessToken, result.RefreshToken, result.ExpiresIn, nil
 }
 
+// Refresh token retention. Refresh tokens carry their family ID, so that reuse of a rotated token is detected
+// even after the token was removed from its family. Families without a usable token are removed when the user
+// signs in again.
+const (
+	maxRefreshTokenFamiliesPerUser = 10 // sessions a user can be signed in with at the same time
+	maxRefreshTokensPerFamily      = 20 // most recent tokens of a session, including rotated ones
+)
+
 // generateJWTTokenForUserWithTx generates JWT tokens within a transaction and persists refresh token
+// as the first token of a new family (session) started from the given source
 func (a *AuthMgm) generateJWTTokenForUserWithTx(
-	ctx context.Context, tx pgx.Tx, user *model.AuthUser,
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser, source string,
+) (accessToken string, refreshToken string, expiresIn int64, err error) {
+	return a.generateJWTTokenInNewSessionWithTx(ctx, tx, user, source, nil)
+}
+
+// generateJWTTokenInNewSessionWithTx generates JWT tokens of a new session started from the given source.
+// Sessions started for an OAuth client can only be refreshed by that client.
+func (a *AuthMgm) generateJWTTokenInNewSessionWithTx(
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser, source string, oauthClientID *string,
 ) (accessToken string, refreshToken string, expiresIn int64, err error) {
+	// A new session drops dead sessions and the least recently used ones beyond the limit
+	rowsAffected, err := a.authUserPersist.CleanupUserRefreshTokenFamilies(
+		ctx, tx, user.ID, maxRefreshTokenFamiliesPerUser-1,
+	)
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to cleanup user refresh tokens", "userID", user.ID, "error", err)
+		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to cleanup user refresh tokens")
+	}
+	katapp.Logger(ctx).Debug("cleaned up old sessions", "userID", user.ID, "rowsAffected", rowsAffected)
+
 	now := time.Now()
-	expiresIn = 3600 // 1 hour
+	clientInfo := clientInfoFromContext(ctx)
+	session := model.NewSessionBuilder().
+		ID(uuid.NewString()).
+		UserID(user.ID).
+		Source(source).
+		UserAgent(lo.EmptyableToPtr(clientInfo.UserAgent)).
+		IPAddress(lo.EmptyableToPtr(clientInfo.IPAddress)).
+		OAuthClientID(oauthClientID).
+		CreatedAt(now).
+		LastUsedAt(now).
+		Build()
+	if err := a.authUserPersist.CreateSession(ctx, tx, session); err != nil {
+		katapp.Logger(ctx).Error("failed to create session", "userID", user.ID, "error", err)
+		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to create session")
+	}
+
+	return a.generateJWTTokenInFamilyWithTx(ctx, tx, user, session.ID)
+}
+
+// generateJWTTokenInFamilyWithTx generates JWT tokens within a transaction and persists refresh token
+// in the given family. Access token carries the family ID as session ID ("sid" claim).
+func (a *AuthMgm) generateJWTTokenInFamilyWithTx(
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser, familyID string,
+) (accessToken string, refreshToken string, expiresIn int64, err error) {
+	now := time.Now()
+	expiresIn = int64(accessTokenTTL / time.Second)
 
 	roles, err := a.authUserPersist.GetUserRoles(ctx, tx, user.ID)
 	if err != nil {
 		katapp.Logger(ctx).Warn("failed to get user roles for token generation", "userID", user.ID, "error", err)
 		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to get user roles")
 	}
+	permissions, err := a.rolePersist.GetPermissionsByRoles(ctx, tx, user.TenantID, roles)
+	if err != nil {
+		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to get user permissions")
+	}
 
 	// Generate unique nonces to ensure tokens are always different
 	accessNonce := a.generateTokenNonce()
 	refreshNonce := a.generateTokenNonce()
 
-	// Generate access token with roles and tenant ID
+	// Generate access token with roles, their permissions and tenant ID
 	accessClaims := jwt.MapClaims{
-		"sub":      user.ID,
-		"iat":      now.Unix(),
-		"exp":      now.Add(time.Duration(expiresIn) * time.Second).Unix(),
-		"type":     "access",
-		"roles":    roles,
-		"tenantId": user.TenantID,
-		"nonce":    accessNonce,
+		"sub":           user.ID,
+		"iat":           now.Unix(),
+		"exp":           now.Add(accessTokenTTL).Unix(),
+		"jti":           uuid.NewString(),
+		"type":          "access",
+		"principalType": PrincipalTypeUser,
+		"roles":         roles,
+		"permissions":   permissions,
+		"tenantId":      user.TenantID,
+		"sid":           familyID,
+		"nonce":         accessNonce,
 	}
 
-	accessTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessToken, err = accessTokenObj.SignedString(a.jwtSecret)
+	accessToken, err = a.jwtKeys.SignedString(accessClaims)
 	if err != nil {
 		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to generate access token")
 	}
@@ -210,26 +407,18 @@ func (a *AuthMgm) generateJWTTokenForUserWithTx(
 		"iat":   now.Unix(),
 		"exp":   refreshExpiresAt.Unix(),
 		"type":  "refresh",
+		"sid":   familyID,
 		"nonce": refreshNonce,
 	}
 
-	refreshTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshToken, err = refreshTokenObj.SignedString(a.jwtSecret)
+	refreshToken, err = a.jwtKeys.SignedString(refreshClaims)
 	if err != nil {
 		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to generate refresh token")
 	}
 
-	// Clean up old refresh tokens: remove all revoked tokens and keep only the 2 most recent non-revoked tokens
-	rowsAffected, err := a.authUserPersist.CleanupUserRefreshTokens(ctx, tx, user.ID)
-	if err != nil {
-		katapp.Logger(ctx).Error("failed to cleanup user refresh tokens", "userID", user.ID, "error", err)
-		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to cleanup user refresh tokens")
-	}
-	katapp.Logger(ctx).Debug("cleaned up old refresh tokens", "userID", user.ID, "rowsAffected", rowsAffected)
-
 	// Persist refresh token in database
 	tokenHash := a.hashRefreshToken(refreshToken)
-	_, err = a.authUserPersist.CreateRefreshToken(ctx, tx, user.ID, tokenHash, refreshExpiresAt)
+	_, err = a.authUserPersist.CreateRefreshToken(ctx, tx, user.ID, familyID, tokenHash, refreshExpiresAt)
 	if err != nil {
 		katapp.Logger(ctx).Error("failed to persist refresh token", "userID", user.ID, "error", err)
 		return "", "", 0, katapp.NewErr(katapp.ErrInternal, "failed to persist refresh token")
@@ -238,40 +427,28 @@ func (a *AuthMgm) generateJWTTokenForUserWithTx(
 	return accessToken, refreshToken, expiresIn, nil
 }
 
-// getUserIDFromAccessToken validates an access token and returns the user ID
-func (a *AuthMgm) getUserIDFromAccessToken(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return a.jwtSecret, nil
-	})
-
-	if err != nil {
-		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid token")
+// getRefreshTokenFamily validates a refresh token and returns the user ID and the family ID it was issued in.
+// Empty IDs are returned for invalid tokens and tokens issued before they carried their family.
+func (a *AuthMgm) getRefreshTokenFamily(tokenString string) (userID string, familyID string) {
+	token, err := jwt.Parse(tokenString, a.jwtKeys.Keyfunc)
+	if err != nil || !token.Valid {
+		return "", ""
 	}
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if tokenType, ok := claims["type"].(string); !ok || tokenType != "access" {
-			return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid token type")
-		}
-
-		if userID, ok := claims["sub"].(string); ok {
-			return userID, nil
-		}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["type"] != "refresh" {
+		return "", ""
 	}
-
-	return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid token claims")
+	userID, _ = claims["sub"].(string)
+	familyID, _ = claims["sid"].(string)
+	if userID == "" || familyID == "" {
+		return "", ""
+	}
+	return userID, familyID
 }
 
 // getUserIDFromRefreshToken validates a refresh token and returns the user ID
 func (a *AuthMgm) getUserIDFromRefreshToken(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return a.jwtSecret, nil
-	})
+	token, err := jwt.Parse(tokenString, a.jwtKeys.Keyfunc)
 
 	if err != nil {
 		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid refresh token")
@@ -290,15 +467,24 @@ func (a *AuthMgm) getUserIDFromRefreshToken(tokenString string) (string, error)
 	return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid refresh token claims")
 }
 
-// SignOut revokes all refresh tokens for a user
-func (a *AuthMgm) SignOut(ctx context.Context, userID string) error {
+// SignOut signs the user out on all devices. Refresh tokens are revoked and access tokens issued so far,
+// including the one of the principal, are rejected.
+func (a *AuthMgm) SignOut(ctx context.Context, principal *UserPrincipal) error {
+	if principal.IsServiceClient() {
+		return katapp.NewErr(katapp.ErrInvalidInput, "service clients cannot sign out")
+	}
 	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
-		err := a.authUserPersist.RevokeAllUserRefreshTokens(ctx, tx, userID)
+		err := a.authUserPersist.RevokeAllUserRefreshTokens(ctx, tx, principal.UserID)
 		if err != nil {
-			katapp.Logger(ctx).Error("failed to revoke all user refresh tokens", "userID", userID, "error", err)
+			katapp.Logger(ctx).Error("failed to revoke all user refresh tokens", "userID", principal.UserID, "error", err)
 			return katapp.NewErr(katapp.ErrInternal, "failed to sign out user")
 		}
-		return nil
+		if principal.TokenID != "" {
+			if err := revokeAccessToken(ctx, a.tokenRevocationPersist, tx, principal.TokenID); err != nil {
+				return err
+			}
+		}
+		return revokeAccessTokens(ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, principal.UserID)
 	})
 }
 
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/email"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// emailChangeTTL is how long the confirmation of a new email address is valid
+const emailChangeTTL = 24 * time.Hour
+
+// confirmEmailChangePath is the web page confirming an email change with the token of the link
+const confirmEmailChangePath = "/web/user/account/confirm-email-change"
+
+// RequestEmailChange starts changing the email address of a user. The new address is kept with a confirmation
+// token, receives a link (web) or a 6-digit code (mobile) and the current address is notified. The address of
+// the user is swapped by ConfirmEmailChange only. Users changing their own address must provide their password.
+func (a *AuthMgm) RequestEmailChange(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.EmailChangeRequest,
+) (*swagger.EmailChangeResponse, error) {
+	katapp.Logger(ctx).Info("requesting email change", "principal", principal.String(), "userID", userID)
+
+	newEmail := strings.TrimSpace(string(req.NewEmail))
+	if newEmail == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "new email is required")
+	}
+	if !strings.Contains(newEmail, "@") {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid email format")
+	}
+	if err := validateSessionSource(req.Source); err != nil {
+		return nil, err
+	}
+	source := sessionSourceOrDefault(req.Source)
+
+	token, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*model.EmailConfirmationToken, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.HasUserPermission(model.PermissionUsersUpdate, userID, user.TenantID) {
+			msg := "insufficient permissions to change user email"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
+			return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		if principal.UserID == user.ID {
+			if req.CurrentPassword == nil || a.verifyPassword(user.PasswordHash, *req.CurrentPassword) != nil {
+				return nil, katapp.NewErr(katapp.ErrInvalidInput, "current password is incorrect")
+			}
+		}
+		if strings.EqualFold(newEmail, user.Email) {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "new email must be different from the current email")
+		}
+		if _, err := getEmailChangeConflict(ctx, a.authUserPersist, tx, user, newEmail); err != nil {
+			return nil, err
+		}
+
+		var code string
+		if source == model.SessionSourceWeb {
+			code, err = a.generateEmailConfirmationToken()
+			if err != nil {
+				return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate email confirmation token")
+			}
+		} else {
+			code = a.generateSixDigitCode()
+		}
+		// A user has a single confirmation token, so a new request replaces the pending one
+		token, err := a.authUserPersist.CreateEmailConfirmationToken(
+			ctx, tx, user.ID, newEmail, model.EmailConfirmationPurposeEmailChange, a.hashToken(user.ID, code),
+			source, time.Now().Add(emailChangeTTL))
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to create email confirmation token")
+		}
+
+		if err := a.queueEmailChangeConfirmationEmail(ctx, tx, user, newEmail, code, source); err != nil {
+			return nil, err
+		}
+		if err := queueEmailChangeNoticeEmail(ctx, a.outboxPersist, tx, user, newEmail, false); err != nil {
+			return nil, err
+		}
+		return token, recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserEmailChangeRequested,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.created("pendingEmail", newEmail),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swagger.NewEmailChangeResponseBuilder().
+		ExpiresAt(token.ExpiresAt).
+		PendingEmail(types.Email(token.Email)).
+		Build(), nil
+}
+
+// GetPendingEmailChange returns the new email address of a user awaiting confirmation, nil if there is none
+func (a *AuthMgm) GetPendingEmailChange(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.EmailChangeResponse, error) {
+	token, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*model.EmailConfirmationToken, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.HasUserPermission(model.PermissionUsersRead, userID, user.TenantID) {
+			return nil, katapp.NewErr(katapp.ErrNoPermissions, "insufficient permissions to read user email change")
+		}
+		token, err := a.authUserPersist.GetEmailConfirmationTokenByUserID(ctx, tx, userID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get email confirmation token")
+		}
+		return token, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.Purpose != model.EmailConfirmationPurposeEmailChange || !token.IsValid() ||
+		a.confirmationAttemptsExhausted(token) {
+		return nil, nil
+	}
+	return swagger.NewEmailChangeResponseBuilder().
+		ExpiresAt(token.ExpiresAt).
+		PendingEmail(types.Email(token.Email)).
+		Build(), nil
+}
+
+// ConfirmEmailChange swaps the email address of the principal for the pending new address once the code sent to
+// the new address is confirmed. All sessions of the user except the current one are signed out. The pending change
+// is invalidated after too many wrong codes.
+func (a *AuthMgm) ConfirmEmailChange(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.ConfirmEmailChangeRequest,
+) (*swagger.AuthUserResponse, error) {
+	katapp.Logger(ctx).Info("confirming email change", "principal", principal.String(), "userID", userID)
+
+	if principal.IsServiceClient() || principal.UserID != userID {
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, "users can confirm changes of their own email only")
+	}
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "confirmation code is required")
+	}
+
+	// Token of a wrong code, the failed attempt is recorded after the transaction is rolled back
+	var wrongCodeTokenID string
+
+	user, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*model.AuthUser, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		token, err := a.authUserPersist.GetEmailConfirmationTokenByUserID(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get confirmation token")
+		}
+		if token == nil || token.Purpose != model.EmailConfirmationPurposeEmailChange {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid or expired confirmation code")
+		}
+		if a.confirmationAttemptsExhausted(token) {
+			return nil, newEmailConfirmationError(EmailConfirmationErrCodeInvalidated, katapp.ErrInvalidInput,
+				"too many failed attempts, request a new email change")
+		}
+
+		// Compare hashes of the provided code and the code sent in constant time
+		if subtle.ConstantTimeCompare([]byte(a.hashToken(user.ID, code)), []byte(token.TokenHash)) != 1 {
+			wrongCodeTokenID = token.ID
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid or expired confirmation code")
+		}
+		if !token.IsValid() {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid or expired confirmation code")
+		}
+
+		// The address may have been taken since the change was requested
+		err = deleteEmailChangeConflict(ctx, a.authUserPersist, a.webhookPersist, tx, user, token.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.authUserPersist.MarkEmailConfirmationTokenAsUsed(ctx, tx, token.ID); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to mark token as used")
+		}
+		updated, err := a.authUserPersist.UpdateUser(ctx, tx, user.ID, map[string]interface{}{
+			"email":          token.Email,
+			"email_verified": true,
+			"updated_at":     time.Now(),
+		})
+		if err != nil {
+			var appErr *katapp.Err
+			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrDuplicate {
+				return nil, katapp.NewErr(katapp.ErrDuplicate, "email address is already in use")
+			}
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to change email")
+		}
+		if err := a.revokeOtherUserSessions(ctx, tx, user.ID, principal.SessionID); err != nil {
+			return nil, err
+		}
+		return updated, recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserEmailChanged,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.changed("email", user.Email, updated.Email),
+		})
+	})
+	if wrongCodeTokenID != "" {
+		return nil, a.recordEmailConfirmationFailure(ctx, wrongCodeTokenID, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return authUserToAuthUserResponse(user), nil
+}
+
+// getEmailChangeConflict returns katapp.ErrDuplicate if another verified user of the tenant has the new address.
+// An unverified user with the address is returned, it does not prevent the change.
+func getEmailChangeConflict(
+	ctx context.Context, authUserPersist outport.AuthUserPersist, tx pgx.Tx, user *model.AuthUser, newEmail string,
+) (*model.AuthUser, error) {
+	existingUser, err := authUserPersist.GetUserByEmail(ctx, tx, newEmail, user.TenantID)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to check existing user")
+	}
+	if existingUser == nil || existingUser.ID == user.ID {
+		return nil, nil
+	}
+	if existingUser.EmailVerified {
+		katapp.Logger(ctx).Warn("email change to address of another user",
+			"userID", user.ID, "email", newEmail, "tenantID", user.TenantID)
+		return nil, katapp.NewErr(katapp.ErrDuplicate, "email address is already in use")
+	}
+	return existingUser, nil
+}
+
+// deleteEmailChangeConflict returns katapp.ErrDuplicate if another verified user of the tenant has the new address.
+// Unverified sign ups of the address are abandoned, so they are dropped the same way signing up again drops them.
+func deleteEmailChangeConflict(
+	ctx context.Context, authUserPersist outport.AuthUserPersist, webhookPersist outport.WebhookPersist, tx pgx.Tx,
+	user *model.AuthUser, newEmail string,
+) error {
+	conflictingUser, err := getEmailChangeConflict(ctx, authUserPersist, tx, user, newEmail)
+	if err != nil || conflictingUser == nil {
+		return err
+	}
+	katapp.Logger(ctx).Info("deleting unverified user holding the new email",
+		"userID", conflictingUser.ID, "email", newEmail)
+	if err := authUserPersist.DeleteUser(ctx, tx, conflictingUser.ID); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to delete existing unverified user")
+	}
+	return queueWebhookEvent(ctx, webhookPersist, tx, conflictingUser.TenantID,
+		model.WebhookEventUserDeleted, webhookUserData(conflictingUser))
+}
+
+// revokeOtherUserSessions signs a user out on all devices except the one of the current session. Access tokens
+// issued for the other sessions so far are rejected.
+func (a *AuthMgm) revokeOtherUserSessions(
+	ctx context.Context, tx pgx.Tx, userID string, currentSessionID string,
+) error {
+	sessions, err := a.authUserPersist.GetActiveSessionsByUserID(ctx, tx, userID)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to get sessions")
+	}
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if _, err := a.authUserPersist.RevokeRefreshTokenFamily(ctx, tx, session.ID); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to revoke session")
+		}
+		err = revokeAccessTokens(ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationSession, session.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueEmailChangeConfirmationEmail queues a confirmation link (web) or a 6-digit code (mobile) to the new address
+func (a *AuthMgm) queueEmailChangeConfirmationEmail(
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser, newEmail string, code string, source string,
+) error {
+	data := &email.EmailChangeConfirmationData{
+		FirstName: user.FirstName,
+		NewEmail:  newEmail,
+		ExpiresIn: "24 hours",
+	}
+	if source == model.SessionSourceWeb {
+		data.ConfirmationURL = fmt.Sprintf("%s%s?code=%s", a.serverConfig.Domain, confirmEmailChangePath, url.QueryEscape(code))
+	} else {
+		data.ConfirmationCode = code
+	}
+
+	// Render the email template
+	var buf strings.Builder
+	if err := email.EmailChangeConfirmation(data).Render(ctx, &buf); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+	text, err := email.EmailChangeConfirmationText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+
+	mailContent := outport.NewMailContentBuilder().
+		Title("Confirm Your New Email Address - IAMService").
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
+		Build()
+
+	if err := queueEmail(ctx, a.outboxPersist, tx, newEmail, mailContent); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue email change confirmation email")
+	}
+
+	katapp.Logger(ctx).Info("email change confirmation email queued", "userID", user.ID, "email", newEmail, "source", source)
+	return nil
+}
+
+// queueEmailChangeNoticeEmail notifies the current address of a user about a requested email change, or about
+// a change that already took effect if changed is set
+func queueEmailChangeNoticeEmail(
+	ctx context.Context, outboxPersist outport.OutboxPersist, tx pgx.Tx, user *model.AuthUser, newEmail string,
+	changed bool,
+) error {
+	data := &email.EmailChangeNoticeData{
+		FirstName:    user.FirstName,
+		CurrentEmail: user.Email,
+		NewEmail:     newEmail,
+		Changed:      changed,
+	}
+	title := "Email Address Change Requested - IAMService"
+	if changed {
+		title = "Email Address Changed - IAMService"
+	}
+
+	// Render the email template
+	var buf strings.Builder
+	if err := email.EmailChangeNotice(data).Render(ctx, &buf); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+	text, err := email.EmailChangeNoticeText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+
+	mailContent := outport.NewMailContentBuilder().
+		Title(title).
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
+		Build()
+
+	if err := queueEmail(ctx, outboxPersist, tx, user.Email, mailContent); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue email change notice email")
+	}
+
+	katapp.Logger(ctx).Info("email change notice email queued", "userID", user.ID, "email", user.Email)
+	return nil
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+// Application codes of email confirmation errors, reported in the "code" field of error responses so that
+// clients can tell the failures apart
+const (
+	EmailConfirmationErrCodeInvalid     int64 = 1001
+	EmailConfirmationErrCodeExpired     int64 = 1002
+	EmailConfirmationErrCodeUsed        int64 = 1003
+	EmailConfirmationErrCodeInvalidated int64 = 1004
+)
+
+// emailConfirmationSendWindow is the window of the daily limit of sent codes, it matches the window counted by
+// the persistence of confirmation tokens
+const emailConfirmationSendWindow = 24 * time.Hour
+
+// EmailConfirmationError is returned by email confirmation. It unwraps to an application error of Scope.
+type EmailConfirmationError struct {
+	Code  int64
+	Scope katapp.ErrScope
+	Msg   string
+}
+
+func (e *EmailConfirmationError) Error() string {
+	return e.Msg
+}
+
+func (e *EmailConfirmationError) Unwrap() error {
+	return katapp.NewErr(e.Scope, e.Msg)
+}
+
+func newEmailConfirmationError(code int64, scope katapp.ErrScope, msg string) *EmailConfirmationError {
+	return &EmailConfirmationError{Code: code, Scope: scope, Msg: msg}
+}
+
+// ResendConfirmation sends a new confirmation link (web) or code (mobile) to a user who has not confirmed the
+// email address yet, the previous code stops working. To avoid leaking which accounts exist, no error is returned
+// when the user is not found, is already confirmed or no code can be sent yet.
+func (a *AuthMgm) ResendConfirmation(ctx context.Context, req *swagger.ResendConfirmationRequest) error {
+	katapp.Logger(ctx).Info("resending email confirmation", "email", req.Email, "tenantID", req.TenantId, "source", req.Source)
+	if err := a.validateResendConfirmationRequest(req); err != nil {
+		return err
+	}
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := internal.EnsureTenantExistsById(ctx, a.authUserPersist, tx, req.TenantId); err != nil {
+			return err
+		}
+
+		user, err := a.authUserPersist.GetUserByEmail(ctx, tx, req.Email, req.TenantId)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get user for confirmation resend", "email", req.Email, "tenantID", req.TenantId, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get user")
+		}
+		if user == nil || !user.IsActive || user.EmailVerified {
+			katapp.Logger(ctx).Warn("confirmation resend requested for unknown or confirmed user", "email", req.Email, "tenantID", req.TenantId)
+			return nil
+		}
+
+		confirmationToken, err := a.authUserPersist.GetEmailConfirmationTokenByUserID(ctx, tx, user.ID)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get email confirmation token", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get confirmation token")
+		}
+		if confirmationToken != nil && a.codeResendLimited(
+			confirmationToken.CreatedAt, confirmationToken.SendCount, confirmationToken.SendWindowStartedAt, time.Now(),
+		) {
+			katapp.Logger(ctx).Warn("confirmation resend throttled, no code sent",
+				"userID", user.ID, "sendCount", confirmationToken.SendCount, "sentAt", confirmationToken.CreatedAt)
+			return nil
+		}
+
+		tokenForEmail, err := a.generateConfirmationCode(req.Source)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to generate email confirmation token", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to generate email confirmation token")
+		}
+		expiresAt := time.Now().Add(24 * time.Hour)
+		_, err = a.authUserPersist.CreateEmailConfirmationToken(
+			ctx, tx, user.ID, user.Email, model.EmailConfirmationPurposeSignUp, a.hashToken(user.ID, tokenForEmail),
+			req.Source, expiresAt)
+		if err != nil {
+			msg := "failed to create email confirmation token"
+			katapp.Logger(ctx).Error(msg, "userID", user.ID, "source", req.Source, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, msg)
+		}
+
+		if err := a.queueConfirmationEmail(ctx, tx, user, tokenForEmail, req.Source); err != nil {
+			msg := "failed to queue confirmation email"
+			katapp.Logger(ctx).Error(msg, "userID", user.ID, "source", req.Source, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, msg)
+		}
+
+		katapp.Logger(ctx).Info("email confirmation resent", "userID", user.ID, "source", req.Source)
+		return nil
+	})
+}
+
+func (a *AuthMgm) validateResendConfirmationRequest(req *swagger.ResendConfirmationRequest) error {
+	if req.Email == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "email is required")
+	}
+	if req.TenantId == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "tenant ID is required")
+	}
+	switch req.Source {
+	case "web", "android", "ios":
+	case "":
+		return katapp.NewErr(katapp.ErrInvalidInput, "source is required")
+	default:
+		return katapp.NewErr(katapp.ErrInvalidInput, "invalid source platform")
+	}
+	return nil
+}
+
+// codeResendLimited reports whether the daily limit of sent codes is reached (sendCount codes were sent within the
+// window starting at sendWindowStartedAt) or the previous code was sent at sentAt less than the resend cooldown ago
+func (a *AuthMgm) codeResendLimited(sentAt time.Time, sendCount int, sendWindowStartedAt time.Time, now time.Time) bool {
+	maxSends := a.confirmationConfig.MaxSendsPerDay
+	if maxSends > 0 && sendCount >= maxSends && sendWindowStartedAt.Add(emailConfirmationSendWindow).After(now) {
+		return true
+	}
+	return sentAt.Add(a.confirmationConfig.ResendCooldown).After(now)
+}
+
+// confirmationAttemptsExhausted reports whether the code of the token was invalidated by too many wrong guesses
+func (a *AuthMgm) confirmationAttemptsExhausted(token *model.EmailConfirmationToken) bool {
+	maxAttempts := a.confirmationConfig.MaxFailedAttempts
+	return maxAttempts > 0 && token.FailedAttempts >= maxAttempts
+}
+
+// recordEmailConfirmationFailure counts a wrong guess of the confirmation code and returns the error to report:
+// confirmErr, or the invalidated code error once the guess used up the last attempt. It runs in its own
+// transaction, because the transaction of the failed confirmation is rolled back.
+func (a *AuthMgm) recordEmailConfirmationFailure(ctx context.Context, tokenID string, confirmErr error) error {
+	if a.confirmationConfig.MaxFailedAttempts <= 0 {
+		return confirmErr
+	}
+	failedAttempts, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (int, error) {
+		return a.authUserPersist.RecordEmailConfirmationFailure(ctx, tx, tokenID)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record failed email confirmation attempt", "tokenID", tokenID, "error", err)
+		return confirmErr
+	}
+	if failedAttempts < a.confirmationConfig.MaxFailedAttempts {
+		return confirmErr
+	}
+	katapp.Logger(ctx).Warn("invalidating confirmation code after too many failed attempts",
+		"tokenID", tokenID, "failedAttempts", failedAttempts)
+	return newEmailConfirmationError(EmailConfirmationErrCodeInvalidated, katapp.ErrInvalidInput,
+		"too many failed attempts, request a new confirmation code")
+}
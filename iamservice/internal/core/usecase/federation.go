@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// FederatedCallbackPathFormat is the path upstream identity providers redirect back to, %s is the provider ID
+const FederatedCallbackPathFormat = "/web/user/auth/federated/%s/callback"
+
+const federationStateTTL = 10 * time.Minute
+
+// FederationMgm provides sign-in through upstream OAuth2/OIDC identity providers
+type FederationMgm struct {
+	serverConfig     *katapp.ServerConfig
+	providers        []app.IdentityProviderConfig
+	authMgm          *AuthMgm
+	authUserPersist  outport.AuthUserPersist
+	federationClient outport.FederationClient
+	txPort           outport.TxPort
+	jwtKeys          *JWTKeySet
+}
+
+// FederatedSignInStart is where to send the user agent to sign in with an upstream provider. CodeVerifier must be
+// kept by the user agent (e.g. in a cookie) and presented again in the callback.
+type FederatedSignInStart struct {
+	AuthURL      string
+	CodeVerifier string
+}
+
+// FederatedSignInResult holds our own token pair issued after a successful upstream sign-in, or the challenge
+// for the second factor the user must pass before tokens are issued
+type FederatedSignInResult struct {
+	SignIn       *swagger.SignInResponse
+	MFAChallenge *swagger.MfaChallengeResponse
+	Email        string
+	ReturnTo     string
+}
+
+// NewFederationMgm creates a new FederationMgm use case
+func NewFederationMgm(
+	serverConfig *katapp.ServerConfig, providers []app.IdentityProviderConfig, authMgm *AuthMgm,
+	authUserPersist outport.AuthUserPersist, federationClient outport.FederationClient, txPort outport.TxPort,
+	jwtKeys *JWTKeySet,
+) *FederationMgm {
+	return &FederationMgm{
+		serverConfig:     serverConfig,
+		providers:        providers,
+		authMgm:          authMgm,
+		authUserPersist:  authUserPersist,
+		federationClient: federationClient,
+		txPort:           txPort,
+		jwtKeys:          jwtKeys,
+	}
+}
+
+// ProvidersForTenant returns identity providers users of the tenant can sign in with
+func (f *FederationMgm) ProvidersForTenant(tenantID string) []model.FederatedProvider {
+	var providers []model.FederatedProvider
+	for _, provider := range f.providers {
+		if provider.TenantID == tenantID {
+			providers = append(providers, *model.NewFederatedProviderBuilder().
+				ID(provider.ID).
+				DisplayName(provider.DisplayName).
+				Build())
+		}
+	}
+	return providers
+}
+
+// BeginSignIn builds the authorization URL of the upstream provider. The state parameter is a signed token binding
+// the request to the tenant, the return location and the PKCE code verifier.
+func (f *FederationMgm) BeginSignIn(
+	ctx context.Context, tenantID string, providerID string, returnTo string,
+) (*FederatedSignInStart, error) {
+	katapp.Logger(ctx).Info("starting federated sign in", "providerID", providerID, "tenantID", tenantID)
+
+	provider, err := f.getProvider(providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.TenantID != tenantID {
+		return nil, katapp.NewErr(katapp.ErrNotFound, "identity provider not found for tenant")
+	}
+
+	codeVerifier, err := f.authMgm.generateEmailConfirmationToken()
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate code verifier")
+	}
+
+	now := time.Now()
+	state, err := f.jwtKeys.SignedString(jwt.MapClaims{
+		"iat":      now.Unix(),
+		"exp":      now.Add(federationStateTTL).Unix(),
+		"type":     "federation_state",
+		"provider": provider.ID,
+		"tenantId": provider.TenantID,
+		"returnTo": returnTo,
+		"cvh":      hashOAuthCode(codeVerifier),
+		"nonce":    f.authMgm.generateTokenNonce(),
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate state")
+	}
+
+	return &FederatedSignInStart{
+		AuthURL:      f.federationClient.AuthCodeURL(provider, f.redirectURI(provider), state, codeVerifier),
+		CodeVerifier: codeVerifier,
+	}, nil
+}
+
+// CompleteSignIn exchanges the authorization code with the upstream provider and signs the user in. The user is
+// found by the linked identity, or else linked by a verified email within the provider's tenant, or else created.
+// Users who must pass a second factor get a challenge instead of tokens, like with password sign in.
+func (f *FederationMgm) CompleteSignIn(
+	ctx context.Context, providerID string, state string, code string, codeVerifier string,
+) (*FederatedSignInResult, error) {
+	katapp.Logger(ctx).Info("completing federated sign in", "providerID", providerID)
+
+	provider, err := f.getProvider(providerID)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "authorization code is required")
+	}
+	returnTo, err := f.validateState(provider, state, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := f.federationClient.ExchangeCode(ctx, provider, f.redirectURI(provider), code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, err := f.federationClient.FetchUserInfo(ctx, provider, tokens.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if userInfo.Subject == "" {
+		return nil, katapp.NewErr(katapp.ErrFailedExternalService, "identity provider did not return a subject")
+	}
+
+	user, err := outport.TxWithResult(ctx, f.txPort, func(tx pgx.Tx) (*model.AuthUser, error) {
+		user, err := f.findOrCreateFederatedUser(ctx, tx, provider, userInfo, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !user.IsActive {
+			return nil, katapp.NewErr(katapp.ErrUnauthorized, "user account is disabled")
+		}
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The upstream provider replaces the password only, enrolled authenticators and the tenant's requirement
+	// for admins apply to federated sign in as well
+	challenge, err := f.authMgm.mfaChallengeForUser(ctx, user, model.SessionSourceWeb)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		katapp.Logger(ctx).Info("federated sign in requires second factor", "providerID", provider.ID, "userID", user.ID)
+		return &FederatedSignInResult{
+			MFAChallenge: challenge,
+			Email:        user.Email,
+			ReturnTo:     returnTo,
+		}, nil
+	}
+
+	accessToken, refreshToken, expiresIn, err := f.authMgm.generateJWTTokenForUser(ctx, user, model.SessionSourceWeb)
+	if err != nil {
+		return nil, err
+	}
+
+	katapp.Logger(ctx).Info("federated sign in completed", "providerID", provider.ID, "userID", user.ID)
+	return &FederatedSignInResult{
+		SignIn: swagger.NewSignInResponseBuilder().
+			AccessToken(accessToken).
+			ExpiresIn(expiresIn).
+			RefreshToken(refreshToken).
+			TokenType("Bearer").
+			UserId(user.ID).
+			Build(),
+		Email:    user.Email,
+		ReturnTo: returnTo,
+	}, nil
+}
+
+func (f *FederationMgm) findOrCreateFederatedUser(
+	ctx context.Context, tx pgx.Tx, provider *app.IdentityProviderConfig,
+	userInfo *outport.FederatedUserInfo, tokens *outport.FederatedTokens,
+) (*model.AuthUser, error) {
+	identity, err := f.authUserPersist.GetUserIdentityByProvider(ctx, tx, provider.ID, userInfo.Subject)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user identity")
+	}
+	if identity != nil {
+		user, err := internal.GetExistingUserById(ctx, f.authUserPersist, tx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		err = f.authUserPersist.UpdateUserIdentityTokens(
+			ctx, tx, identity.ID, &tokens.AccessToken, tokens.RefreshToken, tokens.ExpiresAt,
+		)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to update user identity")
+		}
+		return user, nil
+	}
+
+	// Linking by email is only safe when the upstream provider vouches for the address
+	if userInfo.Email == "" || !userInfo.EmailVerified {
+		katapp.Logger(ctx).Warn("identity provider did not return a verified email",
+			"providerID", provider.ID, "subject", userInfo.Subject)
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "identity provider did not return a verified email address")
+	}
+
+	user, err := f.authUserPersist.GetUserByEmail(ctx, tx, userInfo.Email, provider.TenantID)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to check existing user")
+	}
+	created := user == nil
+	if created {
+		user, err = f.createFederatedUser(ctx, tx, provider, userInfo)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		katapp.Logger(ctx).Info("linking identity to existing user", "providerID", provider.ID, "userID", user.ID)
+	}
+
+	if !user.EmailVerified {
+		if err := f.authUserPersist.SetUserEmailVerified(ctx, tx, user.ID, true); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to verify email")
+		}
+		user.EmailVerified = true
+		// Created users are announced as verified right away, linked users have verified their email now
+		eventType := lo.Ternary(created, model.WebhookEventUserCreated, model.WebhookEventUserEmailVerified)
+		err = queueWebhookEvent(ctx, f.authMgm.webhookPersist, tx, user.TenantID, eventType, webhookUserData(user))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = f.authUserPersist.CreateUserIdentity(
+		ctx, tx, user.ID, provider.ID, userInfo.Subject, &tokens.AccessToken, tokens.RefreshToken, tokens.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// createFederatedUser creates a user with an unusable random password, the user can still set a password
+// later through the password reset flow
+func (f *FederationMgm) createFederatedUser(
+	ctx context.Context, tx pgx.Tx, provider *app.IdentityProviderConfig, userInfo *outport.FederatedUserInfo,
+) (*model.AuthUser, error) {
+	katapp.Logger(ctx).Info("creating user for federated identity", "providerID", provider.ID, "email", userInfo.Email)
+
+	if err := internal.EnsureTenantExistsById(ctx, f.authUserPersist, tx, provider.TenantID); err != nil {
+		return nil, err
+	}
+
+	randomPassword, err := f.authMgm.generateEmailConfirmationToken()
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate password")
+	}
+	hashedPassword, err := internal.HashPassword(randomPassword)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to hash password")
+	}
+
+	user, err := f.authUserPersist.CreateUser(ctx, tx, &swagger.SignUpRequest{
+		Email:     userInfo.Email,
+		FirstName: userInfo.GivenName,
+		LastName:  userInfo.FamilyName,
+		Password:  hashedPassword,
+		Source:    swagger.Web,
+		TenantId:  provider.TenantID,
+	}, provider.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.authUserPersist.AssignUserRole(ctx, tx, user.ID, model.RoleUser); err != nil {
+		katapp.Logger(ctx).Warn("failed to assign default role to user", "userID", user.ID, "error", err)
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to assign default role")
+	}
+	return user, nil
+}
+
+// validateState verifies the signed state and its binding to the provider and the code verifier,
+// returns the location to return to after sign in
+func (f *FederationMgm) validateState(
+	provider *app.IdentityProviderConfig, state string, codeVerifier string,
+) (string, error) {
+	token, err := jwt.Parse(state, f.jwtKeys.Keyfunc)
+	if err != nil || !token.Valid {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired state")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid state claims")
+	}
+	if tokenType, _ := claims["type"].(string); tokenType != "federation_state" {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "invalid state type")
+	}
+	if providerID, _ := claims["provider"].(string); providerID != provider.ID {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "state was issued for a different identity provider")
+	}
+	if codeVerifier == "" {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "code verifier is missing")
+	}
+	if cvh, _ := claims["cvh"].(string); cvh != hashOAuthCode(codeVerifier) {
+		return "", katapp.NewErr(katapp.ErrUnauthorized, "state does not match code verifier")
+	}
+	returnTo, _ := claims["returnTo"].(string)
+	return returnTo, nil
+}
+
+func (f *FederationMgm) getProvider(providerID string) (*app.IdentityProviderConfig, error) {
+	for i := range f.providers {
+		if f.providers[i].ID == providerID {
+			return &f.providers[i], nil
+		}
+	}
+	return nil, katapp.NewErr(katapp.ErrNotFound, "identity provider not found")
+}
+
+func (f *FederationMgm) redirectURI(provider *app.IdentityProviderConfig) string {
+	return fmt.Sprintf("%s"+FederatedCallbackPathFormat, f.serverConfig.Domain, provider.ID)
+}
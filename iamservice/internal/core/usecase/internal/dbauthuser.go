@@ -59,3 +59,8 @@ func HashPassword(password string) (string, error) {
 	}
 	return string(hashedBytes), nil
 }
+
+// VerifyPassword verifies a password or secret against its bcrypt hash
+func VerifyPassword(hashedPassword string, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
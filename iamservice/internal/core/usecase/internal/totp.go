@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238 defaults understood by all common authenticator apps)
+const (
+	TOTPPeriod = 30
+	TOTPDigits = 6
+	// TOTPSkew is the number of time steps accepted before and after the current one
+	TOTPSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret generates a random 160-bit secret encoded as unpadded base32
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPCode computes the code for the given time step (RFC 4226 HOTP with HMAC-SHA1)
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1_000_000), nil
+}
+
+// TOTPStep returns the time step for the given moment
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / TOTPPeriod
+}
+
+// ValidateTOTPCode checks the code against the steps around now and returns the matched step,
+// so that callers can reject replays of an already used code
+func ValidateTOTPCode(secret string, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	current := TOTPStep(now)
+	for step := current - TOTPSkew; step <= current+TOTPSkew; step++ {
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPProvisioningURI builds the otpauth:// URI that authenticator apps import from a QR code
+func TOTPProvisioningURI(issuer string, accountName string, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(TOTPPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateRecoveryCode generates a one-time recovery code formatted as xxxxx-xxxxx
+func GenerateRecoveryCode() (string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+// NormalizeRecoveryCode lowercases the code and strips separators and spaces the user may have typed
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, " ", "")
+	code = strings.ReplaceAll(code, "-", "")
+	if len(code) == 10 {
+		return code[:5] + "-" + code[5:]
+	}
+	return code
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// OAuthIntrospectionRequest holds the form parameters of a token introspection request (RFC 7662)
+type OAuthIntrospectionRequest struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+// IntrospectToken tells a resource server whether an access token is active, together with its user or
+// service client, roles and tenant. Only confidential clients can introspect tokens, and only tokens of users
+// and service clients in their tenant.
+// Client errors are returned as *OAuthError, any token that cannot be used is reported as inactive.
+func (o *OIDCMgm) IntrospectToken(
+	ctx context.Context, req *OAuthIntrospectionRequest,
+) (*swagger.TokenIntrospectionResponse, error) {
+	katapp.Logger(ctx).Info("introspecting token", "clientID", req.ClientID, "tokenTypeHint", req.TokenTypeHint)
+
+	if req.Token == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "token is required")
+	}
+
+	return outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*swagger.TokenIntrospectionResponse, error) {
+		client, err := o.authenticateClient(ctx, tx, req.ClientID, req.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		if client.IsPublic() {
+			katapp.Logger(ctx).Warn("public oauth client cannot introspect tokens", "clientID", client.ID)
+			return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+		}
+
+		inactive := &swagger.TokenIntrospectionResponse{Active: false}
+
+		// Refresh tokens and anything else that is not a valid access token is inactive, whatever the hint says
+		claims, err := o.authMgm.parseAccessToken(req.Token)
+		if err != nil {
+			return inactive, nil
+		}
+		revoked, err := o.authMgm.tokenRevocationPersist.IsAccessTokenRevoked(
+			ctx, tx, claims.ID, claims.SessionID, claims.Subject, claims.IssuedAt.Time,
+		)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "failed to check access token revocation")
+		}
+		if revoked {
+			return inactive, nil
+		}
+		active, err := o.isTokenSubjectActive(ctx, tx, claims, client.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return inactive, nil
+		}
+
+		return swagger.NewTokenIntrospectionResponseBuilder().
+			Active(true).
+			ClientId(lo.EmptyableToPtr(claims.ClientID)).
+			Exp(lo.ToPtr(claims.ExpiresAt.Unix())).
+			Iat(lo.ToPtr(claims.IssuedAt.Unix())).
+			Jti(lo.EmptyableToPtr(claims.ID)).
+			Permissions(&claims.Permissions).
+			Roles(&claims.Roles).
+			Scope(lo.EmptyableToPtr(claims.Scope)).
+			Sid(lo.EmptyableToPtr(claims.SessionID)).
+			Sub(&claims.Subject).
+			TenantId(&claims.TenantID).
+			TokenType(lo.ToPtr("Bearer")).
+			Build(), nil
+	})
+}
+
+// isTokenSubjectActive checks that the user or service client the token was issued to still exists, is active
+// and belongs to the given tenant
+func (o *OIDCMgm) isTokenSubjectActive(
+	ctx context.Context, tx pgx.Tx, claims *accessTokenClaims, tenantID string,
+) (bool, error) {
+	if claims.isServiceClient() {
+		serviceClient, err := o.serviceClientPersist.GetServiceClientByID(ctx, tx, claims.Subject)
+		if err != nil {
+			return false, newOAuthError(OAuthErrServerError, "failed to get service client")
+		}
+		return serviceClient != nil && !serviceClient.IsExpired(time.Now()) && serviceClient.TenantID == tenantID, nil
+	}
+	user, err := o.authUserPersist.GetUserByID(ctx, tx, claims.Subject)
+	if err != nil {
+		return false, newOAuthError(OAuthErrServerError, "failed to get user")
+	}
+	return user != nil && user.IsActive && user.TenantID == tenantID, nil
+}
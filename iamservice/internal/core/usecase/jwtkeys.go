@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"math/big"
+	"strings"
+)
+
+// Supported JWT signing algorithms
+const (
+	JwtAlgorithmHS256 = "HS256"
+	JwtAlgorithmRS256 = "RS256"
+	JwtAlgorithmEdDSA = "EdDSA"
+)
+
+// defaultJwtKeyID is used when no key ID is configured for the active key
+const defaultJwtKeyID = "default"
+
+// JWTKeySet is a keyring that signs tokens with the active key and verifies them with the key
+// selected by the "kid" token header. Verify-only keys allow rotating keys without invalidating
+// tokens that were issued before the rotation.
+type JWTKeySet struct {
+	activeKey *jwtKey
+	keys      map[string]*jwtKey
+	keyList   []*jwtKey // active key first, then verify-only keys in config order
+}
+
+type jwtKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{} // nil for verify-only keys without private key
+	verifyKey interface{}
+}
+
+// JSONWebKey is a public key in JWK format (RFC 7517)
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is a set of public keys published at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewJWTKeySet creates a keyring from credentials config. HS256 is used when no algorithm is configured.
+func NewJWTKeySet(cfg *app.CredentialsConfig) (*JWTKeySet, error) {
+	activeKeyID := cfg.JwtKeyID
+	if activeKeyID == "" {
+		activeKeyID = defaultJwtKeyID
+	}
+	activeKey, err := newJwtKey(activeKeyID, cfg.JwtAlgorithm, cfg.JwtSecret, cfg.JwtPrivateKey, "")
+	if err != nil {
+		return nil, err
+	}
+	if activeKey.signKey == nil {
+		return nil, fmt.Errorf("active jwt key %s has no signing key", activeKeyID)
+	}
+
+	keySet := &JWTKeySet{
+		activeKey: activeKey,
+		keys:      map[string]*jwtKey{activeKeyID: activeKey},
+		keyList:   []*jwtKey{activeKey},
+	}
+	for _, keyCfg := range cfg.JwtVerifyKeys {
+		if keyCfg.ID == "" {
+			return nil, fmt.Errorf("jwt verify key id is required")
+		}
+		if _, exists := keySet.keys[keyCfg.ID]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id: %s", keyCfg.ID)
+		}
+		key, err := newJwtKey(keyCfg.ID, keyCfg.Algorithm, keyCfg.Secret, keyCfg.PrivateKey, keyCfg.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keySet.keys[keyCfg.ID] = key
+		keySet.keyList = append(keySet.keyList, key)
+	}
+	return keySet, nil
+}
+
+// MustNewJWTKeySet creates a keyring from credentials config and panics on failure
+func MustNewJWTKeySet(cfg *app.CredentialsConfig) *JWTKeySet {
+	keySet, err := NewJWTKeySet(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create jwt key set: %v", err))
+	}
+	return keySet
+}
+
+func newJwtKey(id string, algorithm string, secret string, privateKeyPEM string, publicKeyPEM string) (*jwtKey, error) {
+	privateKeyPEM = strings.TrimSpace(privateKeyPEM)
+	publicKeyPEM = strings.TrimSpace(publicKeyPEM)
+
+	switch algorithm {
+	case "", JwtAlgorithmHS256:
+		if secret == "" {
+			return nil, fmt.Errorf("jwt key %s: secret is required for %s", id, JwtAlgorithmHS256)
+		}
+		return &jwtKey{id: id, method: jwt.SigningMethodHS256, signKey: []byte(secret), verifyKey: []byte(secret)}, nil
+	case JwtAlgorithmRS256:
+		if privateKeyPEM == "" {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(publicKeyPEM))
+			if err != nil {
+				return nil, fmt.Errorf("jwt key %s: failed to parse RSA public key: %w", id, err)
+			}
+			return &jwtKey{id: id, method: jwt.SigningMethodRS256, verifyKey: publicKey}, nil
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: failed to parse RSA private key: %w", id, err)
+		}
+		return &jwtKey{id: id, method: jwt.SigningMethodRS256, signKey: privateKey, verifyKey: &privateKey.PublicKey}, nil
+	case JwtAlgorithmEdDSA:
+		if privateKeyPEM == "" {
+			publicKey, err := jwt.ParseEdPublicKeyFromPEM([]byte(publicKeyPEM))
+			if err != nil {
+				return nil, fmt.Errorf("jwt key %s: failed to parse Ed25519 public key: %w", id, err)
+			}
+			return &jwtKey{id: id, method: jwt.SigningMethodEdDSA, verifyKey: publicKey}, nil
+		}
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM([]byte(privateKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: failed to parse Ed25519 private key: %w", id, err)
+		}
+		edKey, ok := privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt key %s: private key is not an Ed25519 key", id)
+		}
+		return &jwtKey{id: id, method: jwt.SigningMethodEdDSA, signKey: edKey, verifyKey: edKey.Public()}, nil
+	default:
+		return nil, fmt.Errorf("jwt key %s: unsupported jwt algorithm: %s", id, algorithm)
+	}
+}
+
+// SignedString signs claims with the active key and sets the "kid" header
+func (k *JWTKeySet) SignedString(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.activeKey.method, claims)
+	token.Header["kid"] = k.activeKey.id
+	return token.SignedString(k.activeKey.signKey)
+}
+
+// Algorithm returns the signing algorithm of the active key
+func (k *JWTKeySet) Algorithm() string {
+	return k.activeKey.method.Alg()
+}
+
+// Keyfunc selects the verification key by the "kid" token header, rejecting tokens signed with
+// an unexpected algorithm. Tokens without "kid" are verified with the active key.
+func (k *JWTKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := k.activeKey
+	if kid, ok := token.Header["kid"]; ok {
+		kidStr, ok := kid.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid kid header: %v", kid)
+		}
+		key, ok = k.keys[kidStr]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key: %s", kidStr)
+		}
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
+
+// JWKS returns public keys for token verification, active key first. Symmetric keys are never published.
+func (k *JWTKeySet) JWKS() *JSONWebKeySet {
+	jwks := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range k.keyList {
+		if jwk := key.toJSONWebKey(); jwk != nil {
+			jwks.Keys = append(jwks.Keys, *jwk)
+		}
+	}
+	return jwks
+}
+
+func (k *jwtKey) toJSONWebKey() *JSONWebKey {
+	switch key := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		return &JSONWebKey{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: k.method.Alg(),
+			Kid: k.id,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return &JSONWebKey{
+			Kty: "OKP",
+			Use: "sig",
+			Alg: k.method.Alg(),
+			Kid: k.id,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+const (
+	mfaChallengeTTL   = 5 * time.Minute
+	mfaIssuer         = "IAMService"
+	recoveryCodeCount = 10
+)
+
+// mfaChallenge is the content of a validated MFA challenge token
+type mfaChallenge struct {
+	tokenID            string // jti, a challenge completes a sign in only once
+	expiresAt          time.Time
+	userID             string
+	enrollmentRequired bool
+	source             string // platform the sign in was started from
+}
+
+// VerifyMFA completes a two-step sign in. The code is either a TOTP code or one of the user's unused
+// recovery codes. For an enrollment challenge the code confirms the pending authenticator. Rejected codes
+// count as failed sign in attempts of the user and client IP.
+func (a *AuthMgm) VerifyMFA(
+	ctx context.Context, req *swagger.MfaVerifyRequest, clientIP string,
+) (*swagger.SignInResponse, error) {
+	if req.MfaToken == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "mfa token is required")
+	}
+	if req.Code == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "code is required")
+	}
+	challenge, err := a.parseMFAChallenge(req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	katapp.Logger(ctx).Info("verifying mfa challenge", "userID", challenge.userID)
+
+	codeRejected := false
+	var codeRejectedUser *model.AuthUser
+	resp, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.SignInResponse, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, challenge.userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.checkSignInThrottle(ctx, tx, model.SignInThrottleSubjectUser, user.ID); err != nil {
+			return nil, err
+		}
+		userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+		}
+		if userMFA == nil || (!userMFA.IsConfirmed() && !challenge.enrollmentRequired) {
+			return nil, katapp.NewErr(katapp.ErrUnauthorized, "two-factor authentication is not enrolled")
+		}
+
+		// Recovery codes are only usable once the authenticator has been confirmed
+		if err := a.verifyMFACode(ctx, tx, user.ID, userMFA, req.Code, userMFA.IsConfirmed()); err != nil {
+			codeRejected = true
+			codeRejectedUser = user
+			return nil, err
+		}
+		used, err := a.mfaPersist.MarkMFAChallengeUsed(ctx, tx, challenge.tokenID, challenge.expiresAt)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to verify mfa token")
+		}
+		if used == 0 {
+			katapp.Logger(ctx).Warn("mfa token reused", "userID", user.ID)
+			return nil, katapp.NewErr(katapp.ErrUnauthorized, "mfa token has already been used")
+		}
+		if !userMFA.IsConfirmed() {
+			if err := a.mfaPersist.ConfirmUserMFA(ctx, tx, user.ID); err != nil {
+				return nil, katapp.NewErr(katapp.ErrInternal, "failed to enable two-factor authentication")
+			}
+		}
+
+		accessToken, refreshToken, expiresIn, err := a.generateJWTTokenForUserWithTx(ctx, tx, user, challenge.source)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate tokens")
+		}
+		if err := a.signInThrottlePersist.ClearSignInThrottle(
+			ctx, tx, model.SignInThrottleSubjectUser, user.ID,
+		); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to reset failed sign in attempts")
+		}
+		if err := recordAuditEvent(ctx, a.auditPersist, tx, signInSucceededAuditEntry(user)); err != nil {
+			return nil, err
+		}
+		return swagger.NewSignInResponseBuilder().
+			AccessToken(accessToken).
+			ExpiresIn(expiresIn).
+			RefreshToken(refreshToken).
+			TokenType("Bearer").
+			UserId(user.ID).
+			Build(), nil
+	})
+	if codeRejected {
+		// Recorded outside of the rolled back transaction
+		a.recordSignInFailure(ctx, challenge.userID, clientIP)
+		a.auditSignInFailure(ctx, codeRejectedUser.TenantID, codeRejectedUser, codeRejectedUser.Email,
+			signInFailureInvalidMFACode)
+	}
+	return resp, err
+}
+
+// EnrollMFAWithChallenge starts TOTP enrollment for a user who must enroll before signing in
+func (a *AuthMgm) EnrollMFAWithChallenge(
+	ctx context.Context, req *swagger.MfaEnrollRequest,
+) (*swagger.MfaEnrollmentResponse, error) {
+	if req.MfaToken == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "mfa token is required")
+	}
+	challenge, err := a.parseMFAChallenge(req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.enrollmentRequired {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "enrollment is not allowed for this challenge")
+	}
+	katapp.Logger(ctx).Info("enrolling authenticator during sign in", "userID", challenge.userID)
+
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.MfaEnrollmentResponse, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, challenge.userID)
+		if err != nil {
+			return nil, err
+		}
+		return a.startTOTPEnrollment(ctx, tx, user)
+	})
+}
+
+// GetMFAStatus returns whether two-factor authentication is enabled for the user
+func (a *AuthMgm) GetMFAStatus(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.MfaStatusResponse, error) {
+	katapp.Logger(ctx).Info("getting mfa status", "principal", principal.String(), "userID", userID)
+
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.MfaStatusResponse, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.HasUserPermission(model.PermissionUsersRead, user.ID, user.TenantID) {
+			msg := "insufficient permissions to fetch two-factor authentication status"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+			return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		return a.loadMFAStatus(ctx, tx, user.ID)
+	})
+}
+
+// EnrollTOTP generates a new TOTP secret and recovery codes for the principal's own account. The
+// authenticator stays pending until confirmed with ConfirmTOTP.
+func (a *AuthMgm) EnrollTOTP(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.MfaEnrollmentResponse, error) {
+	katapp.Logger(ctx).Info("enrolling authenticator", "principal", principal.String(), "userID", userID)
+	if principal.UserID != userID {
+		msg := "authenticators can only be enrolled by the account owner"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.MfaEnrollmentResponse, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+		}
+		if userMFA != nil && userMFA.IsConfirmed() {
+			return nil, katapp.NewErr(katapp.ErrDuplicate, "two-factor authentication is already enabled")
+		}
+		return a.startTOTPEnrollment(ctx, tx, user)
+	})
+}
+
+// ConfirmTOTP activates a pending authenticator once the user proves it produces valid codes
+func (a *AuthMgm) ConfirmTOTP(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.MfaConfirmRequest,
+) (*swagger.MfaStatusResponse, error) {
+	katapp.Logger(ctx).Info("confirming authenticator", "principal", principal.String(), "userID", userID)
+	if principal.UserID != userID {
+		msg := "authenticators can only be confirmed by the account owner"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	if req.Code == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "code is required")
+	}
+
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.MfaStatusResponse, error) {
+		userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, userID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+		}
+		if userMFA == nil {
+			return nil, katapp.NewErr(katapp.ErrNotFound, "no pending authenticator enrollment")
+		}
+		if userMFA.IsConfirmed() {
+			return nil, katapp.NewErr(katapp.ErrDuplicate, "two-factor authentication is already enabled")
+		}
+		if err := a.verifyMFACode(ctx, tx, userID, userMFA, req.Code, false); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid authentication code")
+		}
+		if err := a.mfaPersist.ConfirmUserMFA(ctx, tx, userID); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to enable two-factor authentication")
+		}
+		return a.loadMFAStatus(ctx, tx, userID)
+	})
+}
+
+// DisableMFA removes the authenticator and recovery codes of a user. Allowed for the user and for principals
+// with users:update permission in the user's tenant. Users disabling their own authenticator confirm it with
+// a current code or their password, so that a stolen access token cannot turn off the second factor. Rejected
+// confirmations count as failed sign in attempts of the user and client IP.
+func (a *AuthMgm) DisableMFA(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.MfaDisableRequest,
+) error {
+	katapp.Logger(ctx).Info("disabling two-factor authentication", "principal", principal.String(), "userID", userID)
+	if principal.UserID == userID && lo.FromPtr(req.Code) == "" && lo.FromPtr(req.CurrentPassword) == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "authentication code or current password is required")
+	}
+
+	confirmationRejected := false
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return err
+		}
+		if !principal.HasUserPermission(model.PermissionUsersUpdate, user.ID, user.TenantID) {
+			msg := "insufficient permissions to disable two-factor authentication"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+			return katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		if principal.UserID == user.ID {
+			if err := a.checkSignInThrottle(ctx, tx, model.SignInThrottleSubjectUser, user.ID); err != nil {
+				return err
+			}
+			if err := a.confirmDisableMFA(ctx, tx, user, req); err != nil {
+				confirmationRejected = true
+				return err
+			}
+		}
+		if err := a.mfaPersist.DeleteUserMFA(ctx, tx, user.ID); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to disable two-factor authentication")
+		}
+		return nil
+	})
+	if confirmationRejected {
+		// Recorded outside of the rolled back transaction
+		a.recordSignInFailure(ctx, userID, clientInfoFromContext(ctx).IPAddress)
+	}
+	return err
+}
+
+// confirmDisableMFA accepts a current TOTP code, an unused recovery code or the password of the user
+func (a *AuthMgm) confirmDisableMFA(
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser, req *swagger.MfaDisableRequest,
+) error {
+	if code := lo.FromPtr(req.Code); code != "" {
+		userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, user.ID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+		}
+		if userMFA == nil || a.verifyMFACode(ctx, tx, user.ID, userMFA, code, userMFA.IsConfirmed()) != nil {
+			return katapp.NewErr(katapp.ErrInvalidInput, "invalid authentication code")
+		}
+		return nil
+	}
+	if a.verifyPassword(user.PasswordHash, lo.FromPtr(req.CurrentPassword)) != nil {
+		return katapp.NewErr(katapp.ErrInvalidInput, "current password is incorrect")
+	}
+	return nil
+}
+
+// mfaChallengeForUser returns a challenge when the user must pass a second factor: either an authenticator
+// is enrolled, or the user is an admin of a tenant that requires two-factor authentication for admins.
+// Returns nil when the password alone is sufficient.
+func (a *AuthMgm) mfaChallengeForUser(
+	ctx context.Context, user *model.AuthUser, source string,
+) (*swagger.MfaChallengeResponse, error) {
+	enrollmentRequired, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*bool, error) {
+		userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+		}
+		if userMFA != nil && userMFA.IsConfirmed() {
+			required := false
+			return &required, nil
+		}
+
+		roles, err := a.authUserPersist.GetUserRoles(ctx, tx, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user roles")
+		}
+		if !slices.Contains(roles, model.RoleAdmin) {
+			return nil, nil
+		}
+		tenant, err := internal.GetExistingTenantById(ctx, a.authUserPersist, tx, user.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if !tenant.RequireAdminMFA {
+			return nil, nil
+		}
+		required := true
+		return &required, nil
+	})
+	if err != nil || enrollmentRequired == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mfaToken, err := a.jwtKeys.SignedString(jwt.MapClaims{
+		"sub":      user.ID,
+		"iat":      now.Unix(),
+		"exp":      now.Add(mfaChallengeTTL).Unix(),
+		"type":     "mfa_challenge",
+		"tenantId": user.TenantID,
+		"enroll":   *enrollmentRequired,
+		"source":   source,
+		"nonce":    a.generateTokenNonce(),
+		"jti":      uuid.NewString(),
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate mfa token")
+	}
+	katapp.Logger(ctx).Info("issued mfa challenge", "userID", user.ID, "enrollmentRequired", *enrollmentRequired)
+
+	return swagger.NewMfaChallengeResponseBuilder().
+		EnrollmentRequired(*enrollmentRequired).
+		ExpiresIn(int64(mfaChallengeTTL.Seconds())).
+		MfaRequired(true).
+		MfaToken(mfaToken).
+		Build(), nil
+}
+
+// parseMFAChallenge validates a challenge token issued by mfaChallengeForUser
+func (a *AuthMgm) parseMFAChallenge(tokenString string) (*mfaChallenge, error) {
+	token, err := jwt.Parse(tokenString, a.jwtKeys.Keyfunc)
+	if err != nil || !token.Valid {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid or expired mfa token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid mfa token claims")
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "mfa_challenge" {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid token type")
+	}
+	userID, ok := claims["sub"].(string)
+	if !ok || userID == "" {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid mfa token claims")
+	}
+	tokenID, ok := claims["jti"].(string)
+	if !ok || tokenID == "" {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid mfa token claims")
+	}
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid mfa token claims")
+	}
+	enroll, _ := claims["enroll"].(bool)
+	source, _ := claims["source"].(string)
+	return &mfaChallenge{
+		tokenID:            tokenID,
+		expiresAt:          expiresAt.Time,
+		userID:             userID,
+		enrollmentRequired: enroll,
+		source:             sessionSourceOrDefault(&source),
+	}, nil
+}
+
+// verifyMFACode accepts a TOTP code that was not used before, or (when allowed) an unused recovery code
+func (a *AuthMgm) verifyMFACode(
+	ctx context.Context, tx pgx.Tx, userID string, userMFA *model.UserMFA, code string, allowRecoveryCode bool,
+) error {
+	if step, ok := internal.ValidateTOTPCode(userMFA.TOTPSecret, code, time.Now()); ok {
+		// Advancing the last used step atomically rejects replays of the same code
+		rowsAffected, err := a.mfaPersist.UpdateUserMFALastUsedStep(ctx, tx, userID, step)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to verify authentication code")
+		}
+		if rowsAffected == 0 {
+			katapp.Logger(ctx).Warn("rejected reused totp code", "userID", userID)
+			return katapp.NewErr(katapp.ErrUnauthorized, "authentication code has already been used")
+		}
+		return nil
+	}
+
+	if allowRecoveryCode {
+		codeHash := a.hashToken(userID, internal.NormalizeRecoveryCode(code))
+		rowsAffected, err := a.mfaPersist.MarkRecoveryCodeAsUsed(ctx, tx, userID, codeHash)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to verify recovery code")
+		}
+		if rowsAffected == 1 {
+			katapp.Logger(ctx).Info("recovery code used", "userID", userID)
+			return nil
+		}
+	}
+
+	katapp.Logger(ctx).Warn("invalid two-factor authentication code", "userID", userID)
+	return katapp.NewErr(katapp.ErrUnauthorized, "invalid authentication code")
+}
+
+// startTOTPEnrollment stores a new pending secret and replaces the user's recovery codes
+func (a *AuthMgm) startTOTPEnrollment(
+	ctx context.Context, tx pgx.Tx, user *model.AuthUser,
+) (*swagger.MfaEnrollmentResponse, error) {
+	secret, err := internal.GenerateTOTPSecret()
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate authenticator secret")
+	}
+	if _, err := a.mfaPersist.UpsertUserMFA(ctx, tx, user.ID, secret); err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to store authenticator secret")
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	codeHashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := internal.GenerateRecoveryCode()
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate recovery codes")
+		}
+		recoveryCodes = append(recoveryCodes, code)
+		codeHashes = append(codeHashes, a.hashToken(user.ID, code))
+	}
+	if err := a.mfaPersist.ReplaceRecoveryCodes(ctx, tx, user.ID, codeHashes); err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to store recovery codes")
+	}
+
+	return swagger.NewMfaEnrollmentResponseBuilder().
+		OtpauthUri(internal.TOTPProvisioningURI(mfaIssuer, user.Email, secret)).
+		RecoveryCodes(recoveryCodes).
+		Secret(secret).
+		Build(), nil
+}
+
+func (a *AuthMgm) loadMFAStatus(ctx context.Context, tx pgx.Tx, userID string) (*swagger.MfaStatusResponse, error) {
+	userMFA, err := a.mfaPersist.GetUserMFA(ctx, tx, userID)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get two-factor authentication settings")
+	}
+	enabled := userMFA != nil && userMFA.IsConfirmed()
+	remaining := 0
+	if enabled {
+		remaining, err = a.mfaPersist.CountUnusedRecoveryCodes(ctx, tx, userID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to count recovery codes")
+		}
+	}
+	return swagger.NewMfaStatusResponseBuilder().
+		Enabled(enabled).
+		RecoveryCodesRemaining(remaining).
+		Build(), nil
+}
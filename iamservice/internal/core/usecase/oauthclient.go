@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// CreateOAuthClient registers an OAuth client in the tenant of the principal, or in any tenant with tenants:all.
+// Confidential clients get a generated secret that is returned in the response only, the client keeps its hash.
+func (o *OIDCMgm) CreateOAuthClient(
+	ctx context.Context, principal *UserPrincipal, req *swagger.CreateOAuthClientRequest,
+) (*swagger.CreateOAuthClientResponse, error) {
+	tenantID := lo.FromPtrOr(req.TenantId, principal.TenantID)
+	katapp.Logger(ctx).Info("creating oauth client",
+		"principal", principal.String(), "tenantID", tenantID, "name", req.Name, "public", req.Public)
+
+	if err := o.checkCanManageOAuthClients(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "oauth client name is required")
+	}
+	if len(req.RedirectUris) == 0 {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "at least one redirect URI is required")
+	}
+	for _, redirectURI := range req.RedirectUris {
+		if err := validateOAuthRedirectURI(redirectURI); err != nil {
+			return nil, err
+		}
+	}
+
+	var clientSecret, secretHash *string
+	if !req.Public {
+		secret := generateServiceClientSecret()
+		hash, err := internal.HashPassword(secret)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to hash client secret")
+		}
+		clientSecret, secretHash = &secret, &hash
+	}
+	now := time.Now()
+	client := model.NewOAuthClientBuilder().
+		ID("oauth-" + uuid.NewString()).
+		TenantID(tenantID).
+		Name(name).
+		ClientSecretHash(secretHash).
+		RedirectURIs(lo.Uniq(req.RedirectUris)).
+		CreatedAt(now).
+		UpdatedAt(now).
+		Build()
+
+	err := o.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := internal.EnsureTenantExistsById(ctx, o.authUserPersist, tx, tenantID); err != nil {
+			return err
+		}
+		if err := o.oauthPersist.CreateOAuthClient(ctx, tx, client); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, o.auditPersist, tx, auditEntry{
+			action:     model.AuditActionOAuthClientCreated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetOAuthClient,
+			targetID:   client.ID,
+			diff: auditDiff{}.
+				created("name", client.Name).
+				created("redirect_uris", client.RedirectURIs).
+				created("public", client.IsPublic()),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swagger.NewCreateOAuthClientResponseBuilder().
+		Client(*oauthClientToOAuthClientResponse(client)).
+		ClientSecret(clientSecret).
+		Build(), nil
+}
+
+// ListOAuthClients returns OAuth clients of a tenant, the tenant of the principal if empty
+func (o *OIDCMgm) ListOAuthClients(
+	ctx context.Context, principal *UserPrincipal, tenantID string,
+) (*swagger.OAuthClientsResponse, error) {
+	if tenantID == "" {
+		tenantID = principal.TenantID
+	}
+	katapp.Logger(ctx).Info("listing oauth clients", "principal", principal.String(), "tenantID", tenantID)
+
+	if err := o.checkCanManageOAuthClients(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+
+	clients, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) ([]*model.OAuthClient, error) {
+		return o.oauthPersist.GetOAuthClientsByTenantID(ctx, tx, tenantID)
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list oauth clients")
+	}
+
+	items := make([]swagger.OAuthClientResponse, len(clients))
+	for i, client := range clients {
+		items[i] = *oauthClientToOAuthClientResponse(client)
+	}
+	return swagger.NewOAuthClientsResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// DeleteOAuthClient deletes an OAuth client with the sessions started for it and rejects their access tokens
+func (o *OIDCMgm) DeleteOAuthClient(ctx context.Context, principal *UserPrincipal, clientID string) error {
+	katapp.Logger(ctx).Info("deleting oauth client", "principal", principal.String(), "clientID", clientID)
+
+	return o.txPort.Run(ctx, func(tx pgx.Tx) error {
+		client, err := o.oauthPersist.GetOAuthClientByID(ctx, tx, clientID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get oauth client")
+		}
+		if client == nil {
+			return katapp.NewErr(katapp.ErrNotFound, "oauth client not found")
+		}
+		if err := o.checkCanManageOAuthClients(ctx, principal, client.TenantID); err != nil {
+			return err
+		}
+
+		sessionIDs, err := o.oauthPersist.DeleteOAuthClient(ctx, tx, client.ID)
+		if err != nil {
+			return err
+		}
+		// The sessions are gone, so tokens issued within the current second are rejected as well
+		issuedBefore := time.Now().Truncate(time.Second).Add(time.Second)
+		for _, sessionID := range sessionIDs {
+			err := revokeAccessTokensIssuedBefore(ctx, o.tokenRevocationPersist, tx,
+				model.AccessTokenRevocationSession, sessionID, issuedBefore)
+			if err != nil {
+				return err
+			}
+		}
+		return recordAuditEvent(ctx, o.auditPersist, tx, auditEntry{
+			action:     model.AuditActionOAuthClientDeleted,
+			principal:  principal,
+			tenantID:   client.TenantID,
+			targetType: model.AuditTargetOAuthClient,
+			targetID:   client.ID,
+			diff:       auditDiff{}.deleted("name", client.Name),
+		})
+	})
+}
+
+// checkCanManageOAuthClients allows managing OAuth clients of a tenant only to users with oauth_clients:manage
+// in it. Service clients cannot manage OAuth clients, whatever their permissions.
+func (o *OIDCMgm) checkCanManageOAuthClients(ctx context.Context, principal *UserPrincipal, tenantID string) error {
+	if principal.IsServiceClient() || !principal.HasTenantPermission(model.PermissionOAuthClientsManage, tenantID) {
+		msg := "insufficient permissions to manage oauth clients"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+// validateOAuthRedirectURI accepts absolute URIs without fragment (RFC 6749 section 3.1.2). Plain http is
+// accepted for loopback redirects of native apps only, custom schemes of mobile apps are accepted as well.
+func validateOAuthRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Opaque != "" || strings.Contains(redirectURI, "#") {
+		return katapp.NewErr(katapp.ErrInvalidInput, "redirect URI must be an absolute URI without fragment")
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		if u.Host == "" {
+			return katapp.NewErr(katapp.ErrInvalidInput, "redirect URI must have a host")
+		}
+	case "http":
+		if host := u.Hostname(); host != "localhost" && host != "127.0.0.1" && host != "::1" {
+			return katapp.NewErr(katapp.ErrInvalidInput, "redirect URI must use https unless it points to localhost")
+		}
+	case "javascript", "data", "file", "vbscript":
+		return katapp.NewErr(katapp.ErrInvalidInput, "redirect URI scheme is not allowed")
+	}
+	return nil
+}
+
+func oauthClientToOAuthClientResponse(client *model.OAuthClient) *swagger.OAuthClientResponse {
+	return swagger.NewOAuthClientResponseBuilder().
+		ClientId(client.ID).
+		CreatedAt(client.CreatedAt).
+		Name(client.Name).
+		Public(client.IsPublic()).
+		RedirectUris(lo.CoalesceSliceOrEmpty(client.RedirectURIs)).
+		TenantId(client.TenantID).
+		Build()
+}
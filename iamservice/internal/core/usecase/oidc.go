@@ -0,0 +1,542 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+// OAuth 2.0 error codes (RFC 6749)
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrServerError             = "server_error"
+)
+
+// Paths of the OpenID Connect provider endpoints
+const (
+	OIDCAuthorizePath = "/oauth2/authorize"
+	OIDCTokenPath     = "/oauth2/token"
+	OIDCUserInfoPath  = "/oauth2/userinfo"
+	OIDCJWKSPath      = "/.well-known/jwks.json"
+)
+
+const (
+	oauthCodeChallengeMethodS256 = "S256"
+	oauthAuthorizationCodeTTL    = 10 * time.Minute
+)
+
+// oidcSupportedScopes lists scopes that can be granted, "openid" is mandatory
+var oidcSupportedScopes = []string{"openid", "profile", "email"}
+
+// OAuthError is an OAuth 2.0 protocol error reported to clients as error/error_description
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(code string, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// OAuthAuthorizeRequest holds the query parameters of an authorization request
+type OAuthAuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthTokenRequest holds the form parameters of a token request
+type OAuthTokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	ClientID     string
+	ClientSecret string
+}
+
+// OIDCMgm provides OpenID Connect provider use cases (authorization code flow with PKCE) and management
+// of the registered OAuth clients
+type OIDCMgm struct {
+	serverConfig           *katapp.ServerConfig
+	authMgm                *AuthMgm
+	oauthPersist           outport.OAuthPersist
+	authUserPersist        outport.AuthUserPersist
+	serviceClientPersist   outport.ServiceClientPersist
+	auditPersist           outport.AuditPersist
+	tokenRevocationPersist outport.TokenRevocationPersist
+	txPort                 outport.TxPort
+	jwtKeys                *JWTKeySet
+}
+
+// NewOIDCMgm creates a new OIDCMgm use case
+func NewOIDCMgm(
+	serverConfig *katapp.ServerConfig, authMgm *AuthMgm, oauthPersist outport.OAuthPersist,
+	authUserPersist outport.AuthUserPersist, serviceClientPersist outport.ServiceClientPersist,
+	auditPersist outport.AuditPersist, tokenRevocationPersist outport.TokenRevocationPersist,
+	txPort outport.TxPort, jwtKeys *JWTKeySet,
+) *OIDCMgm {
+	return &OIDCMgm{
+		serverConfig:           serverConfig,
+		authMgm:                authMgm,
+		oauthPersist:           oauthPersist,
+		authUserPersist:        authUserPersist,
+		serviceClientPersist:   serviceClientPersist,
+		auditPersist:           auditPersist,
+		tokenRevocationPersist: tokenRevocationPersist,
+		txPort:                 txPort,
+		jwtKeys:                jwtKeys,
+	}
+}
+
+// Issuer returns the issuer identifier used in ID tokens and discovery metadata
+func (o *OIDCMgm) Issuer() string {
+	return strings.TrimSuffix(o.serverConfig.Domain, "/")
+}
+
+// Discovery returns OpenID Connect provider metadata
+func (o *OIDCMgm) Discovery() *swagger.OIDCDiscoveryResponse {
+	issuer := o.Issuer()
+	return swagger.NewOIDCDiscoveryResponseBuilder().
+		AuthorizationEndpoint(issuer + OIDCAuthorizePath).
+		ClaimsSupported([]string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce",
+			"email", "email_verified", "given_name", "family_name", "name", "tenantId",
+		}).
+		CodeChallengeMethodsSupported([]string{oauthCodeChallengeMethodS256}).
+		GrantTypesSupported([]string{"authorization_code", "refresh_token"}).
+		IdTokenSigningAlgValuesSupported([]string{o.jwtKeys.Algorithm()}).
+		Issuer(issuer).
+		JwksUri(issuer + OIDCJWKSPath).
+		ResponseTypesSupported([]string{"code"}).
+		ScopesSupported(oidcSupportedScopes).
+		SubjectTypesSupported([]string{"public"}).
+		TokenEndpoint(issuer + OIDCTokenPath).
+		TokenEndpointAuthMethodsSupported([]string{"none", "client_secret_basic", "client_secret_post"}).
+		UserinfoEndpoint(issuer + OIDCUserInfoPath).
+		Build()
+}
+
+// ValidateAuthorizeClient checks that the client exists and the redirect URI is registered for it.
+// These errors must be shown to the user agent instead of being sent to the (untrusted) redirect URI.
+func (o *OIDCMgm) ValidateAuthorizeClient(ctx context.Context, req *OAuthAuthorizeRequest) (*model.OAuthClient, error) {
+	if req.ClientID == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "client_id is required")
+	}
+	if req.RedirectURI == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "redirect_uri is required")
+	}
+
+	client, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*model.OAuthClient, error) {
+		return o.oauthPersist.GetOAuthClientByID(ctx, tx, req.ClientID)
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get oauth client")
+	}
+	if client == nil {
+		katapp.Logger(ctx).Warn("authorization requested for unknown oauth client", "clientID", req.ClientID)
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "unknown client_id")
+	}
+	if !client.IsRedirectURIAllowed(req.RedirectURI) {
+		katapp.Logger(ctx).Warn("authorization requested with unregistered redirect URI",
+			"clientID", req.ClientID, "redirectURI", req.RedirectURI)
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "redirect_uri is not registered for the client")
+	}
+	return client, nil
+}
+
+// Authorize issues an authorization code to the client for the user signed in with accessToken and returns
+// the URL to redirect the user agent to. Protocol errors are delivered to the client in the redirect URL.
+// katapp.ErrUnauthorized is returned when the user must sign in (again) to the client's tenant.
+func (o *OIDCMgm) Authorize(
+	ctx context.Context, client *model.OAuthClient, req *OAuthAuthorizeRequest, accessToken string,
+) (string, error) {
+	katapp.Logger(ctx).Info("authorizing oauth client", "clientID", client.ID)
+
+	if oauthErr := o.validateAuthorizeRequest(req); oauthErr != nil {
+		katapp.Logger(ctx).Warn("invalid authorization request", "clientID", client.ID, "error", oauthErr)
+		return buildAuthorizeRedirectURL(req.RedirectURI, req.State, url.Values{
+			"error":             {oauthErr.Code},
+			"error_description": {oauthErr.Description},
+		})
+	}
+
+	claims, err := o.authMgm.validateUserAccessToken(ctx, accessToken)
+	if err != nil {
+		return "", err
+	}
+	userID := claims.Subject
+
+	code, err := o.authMgm.generateEmailConfirmationToken()
+	if err != nil {
+		return "", katapp.NewErr(katapp.ErrInternal, "failed to generate authorization code")
+	}
+
+	err = o.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := o.authUserPersist.GetUserByID(ctx, tx, userID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get user")
+		}
+		if user == nil || !user.IsActive || user.TenantID != client.TenantID {
+			katapp.Logger(ctx).Info("user must sign in to the client's tenant", "userID", userID, "clientID", client.ID)
+			return katapp.NewErr(katapp.ErrUnauthorized, "sign in to the client's tenant is required")
+		}
+		// The user authenticated when the session of the access token was started, not when the code is issued
+		session, err := o.authUserPersist.GetActiveSessionByID(ctx, tx, claims.SessionID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get session")
+		}
+		if session == nil {
+			katapp.Logger(ctx).Info("user must sign in again, session has ended", "userID", userID, "clientID", client.ID)
+			return katapp.NewErr(katapp.ErrUnauthorized, "sign in is required")
+		}
+
+		var nonce *string
+		if req.Nonce != "" {
+			nonce = &req.Nonce
+		}
+		now := time.Now()
+		authCode := model.NewOAuthAuthorizationCodeBuilder().
+			ID(uuid.NewString()).
+			ClientID(client.ID).
+			UserID(user.ID).
+			CodeHash(hashOAuthCode(code)).
+			RedirectURI(req.RedirectURI).
+			Scope(grantedOAuthScope(req.Scope)).
+			Nonce(nonce).
+			CodeChallenge(req.CodeChallenge).
+			CodeChallengeMethod(req.CodeChallengeMethod).
+			AuthTime(session.CreatedAt).
+			ExpiresAt(now.Add(oauthAuthorizationCodeTTL)).
+			UsedAt(nil).
+			CreatedAt(now).
+			Build()
+		if _, err := o.oauthPersist.CreateOAuthAuthorizationCode(ctx, tx, authCode); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to create authorization code")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	katapp.Logger(ctx).Info("authorization code issued", "clientID", client.ID, "userID", userID)
+	return buildAuthorizeRedirectURL(req.RedirectURI, req.State, url.Values{"code": {code}})
+}
+
+func (o *OIDCMgm) validateAuthorizeRequest(req *OAuthAuthorizeRequest) *OAuthError {
+	if req.ResponseType != "code" {
+		return newOAuthError(OAuthErrUnsupportedResponseType, "only response_type=code is supported")
+	}
+	if !slices.Contains(strings.Fields(req.Scope), "openid") {
+		return newOAuthError(OAuthErrInvalidScope, "scope must include openid")
+	}
+	if req.CodeChallenge == "" {
+		return newOAuthError(OAuthErrInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != oauthCodeChallengeMethodS256 {
+		return newOAuthError(OAuthErrInvalidRequest, "code_challenge_method must be S256")
+	}
+	return nil
+}
+
+// Token handles the token endpoint for authorization_code and refresh_token grants.
+// All errors are returned as *OAuthError.
+func (o *OIDCMgm) Token(ctx context.Context, req *OAuthTokenRequest) (*swagger.OAuthTokenResponse, error) {
+	katapp.Logger(ctx).Info("handling oauth token request", "clientID", req.ClientID, "grantType", req.GrantType)
+
+	switch req.GrantType {
+	case "authorization_code":
+		return o.exchangeAuthorizationCode(ctx, req)
+	case "refresh_token":
+		return o.refreshToken(ctx, req)
+	case "":
+		return nil, newOAuthError(OAuthErrInvalidRequest, "grant_type is required")
+	default:
+		return nil, newOAuthError(OAuthErrUnsupportedGrantType, "grant_type must be authorization_code or refresh_token")
+	}
+}
+
+func (o *OIDCMgm) exchangeAuthorizationCode(ctx context.Context, req *OAuthTokenRequest) (*swagger.OAuthTokenResponse, error) {
+	if req.Code == "" || req.RedirectURI == "" || req.CodeVerifier == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code, redirect_uri and code_verifier are required")
+	}
+
+	return outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*swagger.OAuthTokenResponse, error) {
+		client, err := o.authenticateClient(ctx, tx, req.ClientID, req.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		authCode, err := o.oauthPersist.GetOAuthAuthorizationCodeByHash(ctx, tx, hashOAuthCode(req.Code))
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "failed to get authorization code")
+		}
+		if authCode == nil || authCode.ClientID != client.ID {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "invalid authorization code")
+		}
+		if authCode.IsExpired() || authCode.IsUsed() {
+			katapp.Logger(ctx).Warn("expired or already used authorization code presented",
+				"clientID", client.ID, "codeID", authCode.ID)
+			return nil, newOAuthError(OAuthErrInvalidGrant, "authorization code has expired or has already been used")
+		}
+		if authCode.RedirectURI != req.RedirectURI {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "redirect_uri does not match the authorization request")
+		}
+		if !verifyPKCECodeVerifier(authCode.CodeChallenge, req.CodeVerifier) {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "code_verifier does not match code_challenge")
+		}
+
+		rowsAffected, err := o.oauthPersist.MarkOAuthAuthorizationCodeAsUsed(ctx, tx, authCode.ID)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "failed to mark authorization code as used")
+		}
+		if rowsAffected == 0 {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "authorization code has already been used")
+		}
+
+		user, err := o.getClientTenantUser(ctx, tx, client, authCode.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		accessToken, refreshToken, expiresIn, err := o.authMgm.generateJWTTokenInNewSessionWithTx(
+			ctx, tx, user, model.SessionSourceWeb, &client.ID,
+		)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "failed to generate tokens")
+		}
+		idToken, err := o.generateIDToken(user, client.ID, authCode.Nonce, authCode.AuthTime)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "failed to generate id token")
+		}
+
+		katapp.Logger(ctx).Info("authorization code exchanged for tokens", "clientID", client.ID, "userID", user.ID)
+		return swagger.NewOAuthTokenResponseBuilder().
+			AccessToken(accessToken).
+			ExpiresIn(expiresIn).
+			IdToken(idToken).
+			RefreshToken(refreshToken).
+			Scope(authCode.Scope).
+			TokenType("Bearer").
+			Build(), nil
+	})
+}
+
+func (o *OIDCMgm) refreshToken(ctx context.Context, req *OAuthTokenRequest) (*swagger.OAuthTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "refresh_token is required")
+	}
+	userID, err := o.authMgm.getUserIDFromRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "invalid refresh token")
+	}
+
+	client, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*model.OAuthClient, error) {
+		client, err := o.authenticateClient(ctx, tx, req.ClientID, req.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		// Refresh tokens must not cross tenant boundaries, the binding to the client is checked on rotation
+		if _, err := o.getClientTenantUser(ctx, tx, client, userID); err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := o.authMgm.rotateRefreshToken(ctx, req.RefreshToken, &client.ID)
+	if err != nil {
+		var appErr *katapp.Err
+		if errors.As(err, &appErr) && appErr.Scope == katapp.ErrUnauthorized {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "invalid or expired refresh token")
+		}
+		return nil, newOAuthError(OAuthErrServerError, "failed to refresh tokens")
+	}
+
+	user, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*model.AuthUser, error) {
+		return o.authUserPersist.GetUserByID(ctx, tx, tokens.UserId)
+	})
+	if err != nil || user == nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to get user")
+	}
+	idToken, err := o.generateIDToken(user, client.ID, nil, time.Time{})
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to generate id token")
+	}
+
+	return swagger.NewOAuthTokenResponseBuilder().
+		AccessToken(tokens.AccessToken).
+		ExpiresIn(tokens.ExpiresIn).
+		IdToken(idToken).
+		RefreshToken(tokens.RefreshToken).
+		Scope(strings.Join(oidcSupportedScopes, " ")).
+		TokenType(tokens.TokenType).
+		Build(), nil
+}
+
+// UserInfo returns claims about the user identified by the access token
+func (o *OIDCMgm) UserInfo(ctx context.Context, principal *UserPrincipal) (*swagger.OIDCUserInfoResponse, error) {
+	katapp.Logger(ctx).Info("getting oidc user info", "principal", principal.String())
+
+	user, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) (*model.AuthUser, error) {
+		return o.authUserPersist.GetUserByID(ctx, tx, principal.UserID)
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
+	}
+	if user == nil || !user.IsActive {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "user not found or inactive")
+	}
+
+	return swagger.NewOIDCUserInfoResponseBuilder().
+		Email(user.Email).
+		EmailVerified(user.EmailVerified).
+		FamilyName(user.LastName).
+		GivenName(user.FirstName).
+		Name(strings.TrimSpace(user.FirstName + " " + user.LastName)).
+		Sub(user.ID).
+		TenantId(user.TenantID).
+		Build(), nil
+}
+
+// authenticateClient verifies the client. Confidential clients must present their secret,
+// public clients must not (they are authenticated by PKCE).
+func (o *OIDCMgm) authenticateClient(
+	ctx context.Context, tx pgx.Tx, clientID string, clientSecret string,
+) (*model.OAuthClient, error) {
+	if clientID == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client_id is required")
+	}
+	client, err := o.oauthPersist.GetOAuthClientByID(ctx, tx, clientID)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to get oauth client")
+	}
+	if client == nil {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	if client.IsPublic() {
+		if clientSecret != "" {
+			return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+		}
+		return client, nil
+	}
+	if clientSecret == "" || o.authMgm.verifyPassword(*client.ClientSecretHash, clientSecret) != nil {
+		katapp.Logger(ctx).Warn("oauth client authentication failed", "clientID", clientID)
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+// getClientTenantUser loads an active user that belongs to the client's tenant
+func (o *OIDCMgm) getClientTenantUser(
+	ctx context.Context, tx pgx.Tx, client *model.OAuthClient, userID string,
+) (*model.AuthUser, error) {
+	user, err := o.authUserPersist.GetUserByID(ctx, tx, userID)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to get user")
+	}
+	if user == nil || !user.IsActive || user.TenantID != client.TenantID {
+		katapp.Logger(ctx).Warn("user is not available for oauth client", "userID", userID, "clientID", client.ID)
+		return nil, newOAuthError(OAuthErrInvalidGrant, "user is not available for the client")
+	}
+	return user, nil
+}
+
+// generateIDToken generates an OpenID Connect ID token signed with the active JWT key
+func (o *OIDCMgm) generateIDToken(user *model.AuthUser, clientID string, nonce *string, authTime time.Time) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            o.Issuer(),
+		"sub":            user.ID,
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"type":           "id",
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"given_name":     user.FirstName,
+		"family_name":    user.LastName,
+		"name":           strings.TrimSpace(user.FirstName + " " + user.LastName),
+		"tenantId":       user.TenantID,
+	}
+	if !authTime.IsZero() {
+		claims["auth_time"] = authTime.Unix()
+	}
+	if nonce != nil {
+		claims["nonce"] = *nonce
+	}
+	return o.jwtKeys.SignedString(claims)
+}
+
+// grantedOAuthScope keeps supported scopes from the requested ones, unknown scopes are ignored
+func grantedOAuthScope(requested string) string {
+	var granted []string
+	for _, scope := range strings.Fields(requested) {
+		if slices.Contains(oidcSupportedScopes, scope) && !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return strings.Join(granted, " ")
+}
+
+// verifyPKCECodeVerifier checks the code verifier against an S256 code challenge (RFC 7636)
+func verifyPKCECodeVerifier(codeChallenge string, codeVerifier string) bool {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// hashOAuthCode creates SHA-256 hash of the authorization code for secure database storage
+func hashOAuthCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+func buildAuthorizeRedirectURL(redirectURI string, state string, params url.Values) (string, error) {
+	redirectURL, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", katapp.NewErr(katapp.ErrInvalidInput, "invalid redirect_uri")
+	}
+	query := redirectURL.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	redirectURL.RawQuery = query.Encode()
+	return redirectURL.String(), nil
+}
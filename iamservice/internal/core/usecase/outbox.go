@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// maxDeliveryErrorLength caps the stored error of a failed delivery attempt, relays and webhook receivers may
+// return long responses
+const maxDeliveryErrorLength = 1000
+
+// queueEmail writes the email to the outbox in the given transaction, so that it is only sent if the change that
+// sends it is committed. Emails are delivered by the dispatcher of OutboxMgm.
+func queueEmail(
+	ctx context.Context, outboxPersist outport.OutboxPersist, tx pgx.Tx, to string, content *outport.MailContent,
+) error {
+	now := time.Now()
+	outboxEmail := model.NewOutboxEmailBuilder().
+		ID(uuid.NewString()).
+		Recipient(to).
+		Subject(content.Title).
+		HTMLBody(content.HTMLBody).
+		TextBody(content.TextBody).
+		ReplyTo(content.ReplyTo).
+		Headers(content.Headers).
+		Attachments(content.Attachments).
+		Status(model.OutboxEmailStatusPending).
+		Attempts(0).
+		NextAttemptAt(now).
+		LastError(nil).
+		CreatedAt(now).
+		SentAt(nil).
+		Build()
+	return outboxPersist.CreateOutboxEmail(ctx, tx, outboxEmail)
+}
+
+// OutboxMgm delivers queued outgoing emails and lets sysadmins retry emails that failed to be delivered
+type OutboxMgm struct {
+	outboxConfig  *app.OutboxConfig
+	outboxPersist outport.OutboxPersist
+	auditPersist  outport.AuditPersist
+	txPort        outport.TxPort
+	mailer        outport.Mailer
+}
+
+// NewOutboxMgm creates a new OutboxMgm use case
+func NewOutboxMgm(
+	outboxConfig *app.OutboxConfig, outboxPort outport.OutboxPersist, auditPort outport.AuditPersist,
+	databasePort outport.TxPort, mailer outport.Mailer,
+) *OutboxMgm {
+	return &OutboxMgm{
+		outboxConfig:  outboxConfig,
+		outboxPersist: outboxPort,
+		auditPersist:  auditPort,
+		txPort:        databasePort,
+		mailer:        mailer,
+	}
+}
+
+// RunDispatcher delivers due emails every poll interval until the context is cancelled
+func (o *OutboxMgm) RunDispatcher(ctx context.Context) {
+	katapp.Logger(ctx).Info("starting email outbox dispatcher", "pollInterval", o.outboxConfig.PollInterval)
+	ticker := time.NewTicker(o.outboxConfig.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			katapp.Logger(ctx).Info("email outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+			// More emails may be due after a full batch, they are sent without waiting for the next tick
+			for ctx.Err() == nil {
+				claimed, err := o.DispatchDueEmails(ctx)
+				if err != nil || claimed < o.outboxConfig.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DispatchDueEmails claims a batch of due emails, sends them and records the results. Emails are sent outside
+// of any transaction, a slow mail provider does not hold database locks. Returns the number of claimed emails.
+func (o *OutboxMgm) DispatchDueEmails(ctx context.Context) (int, error) {
+	now := time.Now()
+	emails, err := outport.TxWithResult(ctx, o.txPort, func(tx pgx.Tx) ([]*model.OutboxEmail, error) {
+		return o.outboxPersist.ClaimDueOutboxEmails(
+			ctx, tx, now, now.Add(o.outboxConfig.ClaimTimeout), o.outboxConfig.BatchSize)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to claim due outgoing emails", "error", err)
+		return 0, err
+	}
+
+	for _, outboxEmail := range emails {
+		o.deliverEmail(ctx, outboxEmail)
+	}
+	return len(emails), nil
+}
+
+// deliverEmail sends a claimed email and records the result. Failures are logged only, an email whose result
+// cannot be recorded is sent again once its claim times out.
+func (o *OutboxMgm) deliverEmail(ctx context.Context, outboxEmail *model.OutboxEmail) {
+	content := outport.NewMailContentBuilder().
+		Title(outboxEmail.Subject).
+		HTMLBody(outboxEmail.HTMLBody).
+		TextBody(outboxEmail.TextBody).
+		ReplyTo(outboxEmail.ReplyTo).
+		Headers(outboxEmail.Headers).
+		Attachments(outboxEmail.Attachments).
+		Build()
+	sendErr := o.mailer.SendEmail(ctx, outboxEmail.Recipient, content)
+
+	now := time.Now()
+	var err error
+	if sendErr == nil {
+		err = o.txPort.Run(ctx, func(tx pgx.Tx) error {
+			return o.outboxPersist.MarkOutboxEmailAsSent(ctx, tx, outboxEmail.ID, now)
+		})
+	} else {
+		nextAttemptAt := nextRetryAt(
+			outboxEmail.Attempts, o.outboxConfig.MaxAttempts, o.outboxConfig.RetryBackoff,
+			o.outboxConfig.MaxRetryBackoff, now,
+		)
+		if nextAttemptAt == nil {
+			katapp.Logger(ctx).Error("giving up outgoing email after the last delivery attempt",
+				"emailID", outboxEmail.ID, "attempts", outboxEmail.Attempts, "error", sendErr)
+		} else {
+			katapp.Logger(ctx).Warn("failed to deliver outgoing email, retrying later",
+				"emailID", outboxEmail.ID, "attempts", outboxEmail.Attempts, "nextAttemptAt", *nextAttemptAt,
+				"error", sendErr)
+		}
+		lastError := truncateDeliveryError(sendErr.Error())
+		err = o.txPort.Run(ctx, func(tx pgx.Tx) error {
+			return o.outboxPersist.MarkOutboxEmailAsFailed(ctx, tx, outboxEmail.ID, lastError, nextAttemptAt)
+		})
+	}
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record delivery of outgoing email", "emailID", outboxEmail.ID, "error", err)
+	}
+}
+
+// nextRetryAt returns the time of the next delivery attempt after the given number of failed attempts, the
+// backoff doubles with each attempt. Nil is returned once all attempts are used up. Shared by the dispatchers
+// of outgoing emails and webhook events.
+func nextRetryAt(
+	attempts int, maxAttempts int, retryBackoff time.Duration, maxRetryBackoff time.Duration, now time.Time,
+) *time.Time {
+	if attempts >= maxAttempts {
+		return nil
+	}
+	backoff := retryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return lo.ToPtr(now.Add(min(backoff, maxRetryBackoff)))
+}
+
+// truncateDeliveryError caps the stored error of a failed delivery attempt, keeping it valid UTF-8
+func truncateDeliveryError(lastError string) string {
+	if len(lastError) > maxDeliveryErrorLength {
+		return strings.ToValidUTF8(lastError[:maxDeliveryErrorLength], "")
+	}
+	return lastError
+}
+
+// ListFailedOutboxEmails returns a paginated list of undelivered emails that failed at least once
+func (o *OutboxMgm) ListFailedOutboxEmails(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListFailedOutboxEmailsParams,
+) (*swagger.OutboxEmailsResponse, error) {
+	katapp.Logger(ctx).Info("listing failed outgoing emails", "principal", principal.String())
+
+	if err := checkOutboxPermission(ctx, principal); err != nil {
+		return nil, err
+	}
+	var status *string
+	if params.Status != nil {
+		switch *params.Status {
+		case swagger.ListFailedOutboxEmailsParamsStatusPending, swagger.ListFailedOutboxEmailsParamsStatusDead:
+			status = lo.ToPtr(string(*params.Status))
+		default:
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid status, pending or dead expected")
+		}
+	}
+
+	page := lo.FromPtrOr(params.Page, 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := lo.FromPtrOr(params.Limit, 20)
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	var emails []*model.OutboxEmail
+	var total int
+	err := o.txPort.Run(ctx, func(tx pgx.Tx) error {
+		var err error
+		emails, total, err = o.outboxPersist.ListFailedOutboxEmails(ctx, tx, status, (page-1)*limit, limit)
+		return err
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list failed outgoing emails")
+	}
+
+	items := make([]swagger.OutboxEmailResponse, len(emails))
+	for i, outboxEmail := range emails {
+		items[i] = *outboxEmailToOutboxEmailResponse(outboxEmail)
+	}
+	pagination := swagger.NewPaginationInfoBuilder().
+		Limit(limit).
+		Page(page).
+		Total(total).
+		TotalPages((total + limit - 1) / limit).
+		Build()
+	return swagger.NewOutboxEmailsResponseBuilder().
+		Items(items).
+		Pagination(*pagination).
+		Build(), nil
+}
+
+// RetryOutboxEmail makes an undelivered email due for delivery right away, with all attempts available again
+func (o *OutboxMgm) RetryOutboxEmail(ctx context.Context, principal *UserPrincipal, emailID string) error {
+	katapp.Logger(ctx).Info("retrying outgoing email", "principal", principal.String(), "emailID", emailID)
+
+	if err := checkOutboxPermission(ctx, principal); err != nil {
+		return err
+	}
+	return o.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := o.outboxPersist.RetryOutboxEmail(ctx, tx, emailID, time.Now()); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, o.auditPersist, tx, auditEntry{
+			action:     model.AuditActionOutboxEmailRetried,
+			principal:  principal,
+			targetType: model.AuditTargetOutboxEmail,
+			targetID:   emailID,
+		})
+	})
+}
+
+// checkOutboxPermission allows managing the outbox of all tenants only to principals with outbox:manage
+func checkOutboxPermission(ctx context.Context, principal *UserPrincipal) error {
+	if !principal.HasPermission(model.PermissionOutboxManage) {
+		msg := "insufficient permissions to manage outgoing emails"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String())
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+func outboxEmailToOutboxEmailResponse(outboxEmail *model.OutboxEmail) *swagger.OutboxEmailResponse {
+	return swagger.NewOutboxEmailResponseBuilder().
+		Attempts(outboxEmail.Attempts).
+		CreatedAt(outboxEmail.CreatedAt).
+		Id(outboxEmail.ID).
+		LastError(outboxEmail.LastError).
+		NextAttemptAt(outboxEmail.NextAttemptAt).
+		Recipient(outboxEmail.Recipient).
+		Status(swagger.OutboxEmailResponseStatus(outboxEmail.Status)).
+		Subject(outboxEmail.Subject).
+		Build()
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// pageCursor is the opaque cursor returned to clients, it is only valid for the sorting it was returned with
+type pageCursor struct {
+	SortBy   string `json:"s"`
+	SortDesc bool   `json:"d"`
+	Value    string `json:"v"`
+	ID       string `json:"i"`
+}
+
+// newPageRequest validates pagination and sorting parameters of a listing sortable by the given fields, the first
+// one is the default. Returns the page request with the requested page number, the page request fetches one item
+// more than the limit to tell if there is a next page.
+func newPageRequest(
+	page *int, limit *int, cursor *string, sortBy string, sortOrder *swagger.SortOrder, sortFields []string,
+) (*model.PageRequest, int, error) {
+	pageNum := lo.FromPtrOr(page, 1)
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	limitNum := lo.FromPtrOr(limit, 20)
+	if limitNum < 1 || limitNum > 100 {
+		limitNum = 100
+	}
+	if sortBy == "" {
+		sortBy = sortFields[0]
+	} else if !lo.Contains(sortFields, sortBy) {
+		return nil, 0, katapp.NewErr(katapp.ErrInvalidInput, "invalid sortBy, one of "+strings.Join(sortFields, ", ")+" expected")
+	}
+	// Default field is the creation time with the newest items first, other fields are sorted alphabetically
+	sortDesc := sortBy == sortFields[0]
+	if sortOrder != nil {
+		switch *sortOrder {
+		case swagger.SortOrderAsc:
+			sortDesc = false
+		case swagger.SortOrderDesc:
+			sortDesc = true
+		default:
+			return nil, 0, katapp.NewErr(katapp.ErrInvalidInput, "invalid sortOrder, asc or desc expected")
+		}
+	}
+
+	var after *model.PageCursor
+	if cursor != nil && *cursor != "" {
+		data, err := base64.RawURLEncoding.DecodeString(*cursor)
+		var decoded pageCursor
+		if err == nil {
+			err = json.Unmarshal(data, &decoded)
+		}
+		if err != nil || decoded.ID == "" {
+			return nil, 0, katapp.NewErr(katapp.ErrInvalidInput, "invalid cursor")
+		}
+		if decoded.SortBy != sortBy || decoded.SortDesc != sortDesc {
+			return nil, 0, katapp.NewErr(katapp.ErrInvalidInput, "cursor was returned for a different sorting")
+		}
+		after = &model.PageCursor{Value: decoded.Value, ID: decoded.ID}
+	}
+
+	pageRequest := model.NewPageRequestBuilder().
+		SortBy(sortBy).
+		SortDesc(sortDesc).
+		After(after).
+		Offset((pageNum - 1) * limitNum).
+		Limit(limitNum + 1).
+		Build()
+	return pageRequest, pageNum, nil
+}
+
+// pageItems drops the extra item fetched by the page request and returns the cursor of the next page, nil on the
+// last page
+func pageItems[T any](items []T, page *model.PageRequest, cursorOf func(T) model.PageCursor) ([]T, *string) {
+	limit := page.Limit - 1
+	if len(items) <= limit {
+		return items, nil
+	}
+	items = items[:limit]
+	last := cursorOf(items[limit-1])
+	data, _ := json.Marshal(pageCursor{SortBy: page.SortBy, SortDesc: page.SortDesc, Value: last.Value, ID: last.ID})
+	return items, lo.ToPtr(base64.RawURLEncoding.EncodeToString(data))
+}
+
+// paginationInfo describes the requested page of a listing with total items matching its filters
+func paginationInfo(page int, pageRequest *model.PageRequest, total int) *swagger.PaginationInfo {
+	limit := pageRequest.Limit - 1
+	return swagger.NewPaginationInfoBuilder().
+		Limit(limit).
+		Page(page).
+		Total(total).
+		TotalPages((total + limit - 1) / limit).
+		Build()
+}
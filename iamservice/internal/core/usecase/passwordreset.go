@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana-samples/iamservice/templates/email"
+	"github.com/mobiletoly/gokatana/katapp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ForgotPassword sends a password reset link (web) or code (mobile) to the user's email address. Codes are sent
+// within the resend cooldown and daily limit of confirmation codes. To avoid leaking which accounts exist, no error
+// is returned when the user is not found or no code can be sent yet.
+func (a *AuthMgm) ForgotPassword(ctx context.Context, req *swagger.ForgotPasswordRequest) error {
+	katapp.Logger(ctx).Info("requesting password reset", "email", req.Email, "tenantID", req.TenantId, "source", req.Source)
+	if err := a.validateForgotPasswordRequest(req); err != nil {
+		return err
+	}
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := internal.EnsureTenantExistsById(ctx, a.authUserPersist, tx, req.TenantId); err != nil {
+			return err
+		}
+
+		user, err := a.authUserPersist.GetUserByEmail(ctx, tx, req.Email, req.TenantId)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get user for password reset", "email", req.Email, "tenantID", req.TenantId, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get user")
+		}
+		if user == nil || !user.IsActive {
+			katapp.Logger(ctx).Warn("password reset requested for unknown or inactive user", "email", req.Email, "tenantID", req.TenantId)
+			return nil
+		}
+
+		previousToken, err := a.authUserPersist.GetPasswordResetTokenByUserID(ctx, tx, user.ID)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get password reset token", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get password reset token")
+		}
+		if previousToken != nil && a.codeResendLimited(
+			previousToken.CreatedAt, previousToken.SendCount, previousToken.SendWindowStartedAt, time.Now(),
+		) {
+			katapp.Logger(ctx).Warn("password reset throttled, no code sent",
+				"userID", user.ID, "sendCount", previousToken.SendCount, "sentAt", previousToken.CreatedAt)
+			return nil
+		}
+
+		// Generate reset token/code and hash it
+		var tokenForEmail string
+		if req.Source == "web" {
+			// For web: generate long token
+			tokenForEmail, err = a.generateEmailConfirmationToken()
+			if err != nil {
+				katapp.Logger(ctx).Error("failed to generate password reset token", "userID", user.ID, "error", err)
+				return katapp.NewErr(katapp.ErrInternal, "failed to generate password reset token")
+			}
+		} else {
+			// For mobile: generate 6-digit code
+			tokenForEmail = a.generateSixDigitCode()
+		}
+		tokenHash := a.hashToken(user.ID, tokenForEmail)
+
+		// Create password reset token in database (expires in 1 hour)
+		expiresAt := time.Now().Add(time.Hour)
+		_, err = a.authUserPersist.CreatePasswordResetToken(ctx, tx, user.ID, tokenHash, req.Source, expiresAt)
+		if err != nil {
+			msg := "failed to create password reset token"
+			katapp.Logger(ctx).Error(msg,
+				"userID", user.ID,
+				"source", req.Source,
+				"error", err)
+			return katapp.NewErr(katapp.ErrInternal, msg)
+		}
+
+		err = a.queuePasswordResetEmail(ctx, tx, user, tokenForEmail, req.Source)
+		if err != nil {
+			msg := "failed to queue password reset email"
+			katapp.Logger(ctx).Error(msg,
+				"userID", user.ID,
+				"source", req.Source,
+				"error", err)
+			return katapp.NewErr(katapp.ErrInternal, msg)
+		}
+
+		katapp.Logger(ctx).Info("password reset token created and email queued",
+			"userID", user.ID,
+			"source", req.Source)
+		return nil
+	})
+}
+
+func (a *AuthMgm) validateForgotPasswordRequest(req *swagger.ForgotPasswordRequest) error {
+	if req.Email == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "email is required")
+	}
+	if req.TenantId == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "tenant ID is required")
+	}
+	switch req.Source {
+	case "web", "android", "ios":
+	case "":
+		return katapp.NewErr(katapp.ErrInvalidInput, "source is required")
+	default:
+		return katapp.NewErr(katapp.ErrInvalidInput, "invalid source platform")
+	}
+	return nil
+}
+
+// maxPasswordResetFailedAttempts is the number of wrong guesses after which a password reset code is invalidated.
+// 6-digit codes sent to mobile users would otherwise be guessable within the lifetime of the code. Guesses are
+// counted across the codes sent within a day, so that requesting new codes does not reset the limit.
+const maxPasswordResetFailedAttempts = 5
+
+// ResetPassword sets a new password using the reset token/code and revokes all refresh tokens of the user.
+// Wrong codes are counted, the code is invalidated once maxPasswordResetFailedAttempts is reached.
+func (a *AuthMgm) ResetPassword(ctx context.Context, req *swagger.ResetPasswordRequest) error {
+	katapp.Logger(ctx).Info("resetting password", "email", req.Email, "tenantID", req.TenantId)
+	if err := a.validateResetPasswordRequest(req); err != nil {
+		return err
+	}
+
+	// Token of a wrong code, the failed attempt is recorded after the transaction is rolled back
+	var wrongCodeTokenID string
+
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := a.authUserPersist.GetUserByEmail(ctx, tx, req.Email, req.TenantId)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get user for password reset", "email", req.Email, "tenantID", req.TenantId, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get user")
+		}
+		if user == nil {
+			return katapp.NewErr(katapp.ErrNotFound, "invalid password reset code")
+		}
+
+		resetToken, err := a.authUserPersist.GetPasswordResetTokenByUserID(ctx, tx, user.ID)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to get password reset token", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get password reset token")
+		}
+		if resetToken == nil {
+			return katapp.NewErr(katapp.ErrNotFound, "invalid password reset code")
+		}
+		if resetToken.FailedAttempts >= maxPasswordResetFailedAttempts {
+			return katapp.NewErr(katapp.ErrInvalidInput, "too many failed attempts, request a new password reset code later")
+		}
+
+		// Compare hashes of the provided code and the code sent in constant time
+		codeHash := a.hashToken(user.ID, req.Code)
+		if subtle.ConstantTimeCompare([]byte(codeHash), []byte(resetToken.TokenHash)) != 1 {
+			wrongCodeTokenID = resetToken.ID
+			return katapp.NewErr(katapp.ErrNotFound, "invalid password reset code")
+		}
+		if resetToken.IsExpired() {
+			return katapp.NewErr(katapp.ErrInvalidInput, "password reset code has expired")
+		}
+		if resetToken.IsUsed() {
+			return katapp.NewErr(katapp.ErrInvalidInput, "password reset code has already been used")
+		}
+
+		hashedPassword, err := internal.HashPassword(req.NewPassword)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to hash password")
+		}
+		updates := map[string]interface{}{
+			"password_hash": hashedPassword,
+			"updated_at":    time.Now(),
+		}
+		_, err = a.authUserPersist.UpdateUser(ctx, tx, user.ID, updates)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to update password", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to update password")
+		}
+
+		err = a.authUserPersist.MarkPasswordResetTokenAsUsed(ctx, tx, resetToken.ID)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to mark password reset token as used", "tokenID", resetToken.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to mark token as used")
+		}
+
+		// Sign out all existing sessions since the old password may have been compromised
+		err = a.authUserPersist.RevokeAllUserRefreshTokens(ctx, tx, user.ID)
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to revoke all user refresh tokens", "userID", user.ID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to revoke refresh tokens")
+		}
+
+		katapp.Logger(ctx).Info("password reset successfully", "userID", user.ID)
+		return nil
+	})
+
+	if wrongCodeTokenID != "" {
+		return a.recordPasswordResetFailure(ctx, wrongCodeTokenID, err)
+	}
+	return err
+}
+
+// recordPasswordResetFailure counts a wrong guess of the password reset code and returns the error to report:
+// resetErr, or the invalidated code error once the guess used up the last attempt. It runs in its own
+// transaction, because the transaction of the failed reset is rolled back.
+func (a *AuthMgm) recordPasswordResetFailure(ctx context.Context, tokenID string, resetErr error) error {
+	failedAttempts, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (int, error) {
+		return a.authUserPersist.RecordPasswordResetFailure(ctx, tx, tokenID)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record failed password reset attempt", "tokenID", tokenID, "error", err)
+		return resetErr
+	}
+	if failedAttempts < maxPasswordResetFailedAttempts {
+		return resetErr
+	}
+	katapp.Logger(ctx).Warn("invalidating password reset code after too many failed attempts",
+		"tokenID", tokenID, "failedAttempts", failedAttempts)
+	return katapp.NewErr(katapp.ErrInvalidInput, "too many failed attempts, request a new password reset code")
+}
+
+func (a *AuthMgm) validateResetPasswordRequest(req *swagger.ResetPasswordRequest) error {
+	if req.Email == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "email is required")
+	}
+	if req.TenantId == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "tenant ID is required")
+	}
+	if req.Code == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "password reset code is required")
+	}
+	if req.NewPassword == "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, "new password is required")
+	}
+	if len(req.NewPassword) < 8 {
+		return katapp.NewErr(katapp.ErrInvalidInput, "password must be at least 8 characters")
+	}
+	return nil
+}
+
+// Password reset helper methods
+
+// queuePasswordResetEmail queues platform-specific password reset emails
+func (a *AuthMgm) queuePasswordResetEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, token string, source string) error {
+	switch source {
+	case "web":
+		return a.queueWebPasswordResetEmail(ctx, tx, user, token)
+	case "android", "ios":
+		return a.queueMobilePasswordResetEmail(ctx, tx, user, token, source)
+	default:
+		return katapp.NewErr(katapp.ErrInvalidInput, "invalid source platform")
+	}
+}
+
+// queueWebPasswordResetEmail queues a clickable password reset link for web users
+func (a *AuthMgm) queueWebPasswordResetEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, token string) error {
+	baseURL := a.serverConfig.Domain
+	resetURL := fmt.Sprintf("%s/web/user/auth/reset-password?tenantId=%s&email=%s&code=%s",
+		baseURL, url.QueryEscape(user.TenantID), url.QueryEscape(user.Email), token)
+
+	data := &email.WebPasswordResetData{
+		User:      user,
+		ResetURL:  resetURL,
+		ExpiresIn: "1 hour",
+	}
+
+	// Render the email template
+	var buf strings.Builder
+	err := email.WebPasswordReset(data).Render(ctx, &buf)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+	text, err := email.WebPasswordResetText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+
+	mailContent := outport.NewMailContentBuilder().
+		Title("Reset Your Password - IAMService").
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
+		Build()
+
+	err = queueEmail(ctx, a.outboxPersist, tx, user.Email, mailContent)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue password reset email")
+	}
+
+	katapp.Logger(ctx).Info("web password reset email queued", "userID", user.ID, "email", user.Email)
+	return nil
+}
+
+// queueMobilePasswordResetEmail queues a 6-digit password reset code for mobile users
+func (a *AuthMgm) queueMobilePasswordResetEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, resetCode string, platform string) error {
+	data := &email.MobilePasswordResetData{
+		User:      user,
+		ResetCode: resetCode,
+		ExpiresIn: "1 hour",
+		Platform:  platform,
+	}
+
+	// Render the email template
+	var buf strings.Builder
+	err := email.MobilePasswordReset(data).Render(ctx, &buf)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+	text, err := email.MobilePasswordResetText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
+
+	mailContent := outport.NewMailContentBuilder().
+		Title(fmt.Sprintf("Your Password Reset Code - IAMService (%s)", strings.Title(platform))).
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
+		Build()
+
+	err = queueEmail(ctx, a.outboxPersist, tx, user.Email, mailContent)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue password reset email")
+	}
+
+	katapp.Logger(ctx).Info("mobile password reset email queued", "userID", user.ID, "email", user.Email, "platform", platform)
+	return nil
+}
@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// roleNameRegexp limits custom role names to lowercase identifiers, so they are safe in tokens and URLs
+var roleNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+// RoleMgm provides management of permissions and the custom roles of tenants
+type RoleMgm struct {
+	rolePersist            outport.RolePersist
+	authUserPersist        outport.AuthUserPersist
+	auditPersist           outport.AuditPersist
+	tokenRevocationPersist outport.TokenRevocationPersist
+	txPort                 outport.TxPort
+}
+
+// NewRoleMgm creates a new RoleMgm use case
+func NewRoleMgm(
+	rolePort outport.RolePersist, authUserPort outport.AuthUserPersist, auditPort outport.AuditPersist,
+	tokenRevocationPort outport.TokenRevocationPersist, databasePort outport.TxPort,
+) *RoleMgm {
+	return &RoleMgm{
+		rolePersist:            rolePort,
+		authUserPersist:        authUserPort,
+		auditPersist:           auditPort,
+		tokenRevocationPersist: tokenRevocationPort,
+		txPort:                 databasePort,
+	}
+}
+
+// ListPermissions returns all permissions known to the service
+func (r *RoleMgm) ListPermissions(ctx context.Context, principal *UserPrincipal) (*swagger.PermissionsResponse, error) {
+	katapp.Logger(ctx).Debug("listing permissions", "principal", principal.String())
+
+	if !principal.HasPermission(model.PermissionRolesRead) {
+		msg := "insufficient permissions to list permissions"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String())
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+
+	permissions, err := outport.TxWithResult(ctx, r.txPort, func(tx pgx.Tx) ([]*model.Permission, error) {
+		return r.rolePersist.GetPermissions(ctx, tx)
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list permissions")
+	}
+
+	items := make([]swagger.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		items[i] = *swagger.NewPermissionResponseBuilder().
+			Description(permission.Description).
+			Name(permission.Name).
+			SystemOnly(permission.SystemOnly).
+			Build()
+	}
+	return swagger.NewPermissionsResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// ListRoles returns the system roles followed by the custom roles of a tenant
+func (r *RoleMgm) ListRoles(
+	ctx context.Context, principal *UserPrincipal, tenantID string,
+) (*swagger.RolesResponse, error) {
+	katapp.Logger(ctx).Debug("listing roles", "principal", principal.String(), "tenantID", tenantID)
+
+	if err := checkRolePermission(ctx, principal, model.PermissionRolesRead, tenantID); err != nil {
+		return nil, err
+	}
+
+	roles, err := outport.TxWithResult(ctx, r.txPort, func(tx pgx.Tx) ([]*model.Role, error) {
+		if err := internal.EnsureTenantExistsById(ctx, r.authUserPersist, tx, tenantID); err != nil {
+			return nil, err
+		}
+		return r.rolePersist.GetRolesByTenantID(ctx, tx, tenantID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]swagger.RoleResponse, len(roles))
+	for i, role := range roles {
+		items[i] = *roleToRoleResponse(role)
+	}
+	return swagger.NewRolesResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// GetRole returns a system role or a custom role of a tenant
+func (r *RoleMgm) GetRole(
+	ctx context.Context, principal *UserPrincipal, tenantID string, roleName string,
+) (*swagger.RoleResponse, error) {
+	katapp.Logger(ctx).Debug("getting role", "principal", principal.String(), "tenantID", tenantID, "name", roleName)
+
+	if err := checkRolePermission(ctx, principal, model.PermissionRolesRead, tenantID); err != nil {
+		return nil, err
+	}
+
+	role, err := outport.TxWithResult(ctx, r.txPort, func(tx pgx.Tx) (*model.Role, error) {
+		return getExistingRole(ctx, r.rolePersist, tx, tenantID, roleName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roleToRoleResponse(role), nil
+}
+
+// CreateRole creates a custom role of a tenant. The role can grant only permissions the principal has itself and
+// no system-only permissions, so that nobody can extend their own permissions by creating a role.
+func (r *RoleMgm) CreateRole(
+	ctx context.Context, principal *UserPrincipal, tenantID string, req *swagger.CreateRoleRequest,
+) (*swagger.RoleResponse, error) {
+	katapp.Logger(ctx).Info("creating role", "principal", principal.String(), "tenantID", tenantID, "name", req.Name)
+
+	if err := checkRolePermission(ctx, principal, model.PermissionRolesManage, tenantID); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if !roleNameRegexp.MatchString(name) {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput,
+			"role name must start with a lowercase letter and contain lowercase letters, digits, '-' and '_' only")
+	}
+	permissions := lo.Uniq(req.Permissions)
+
+	now := time.Now()
+	role := model.NewRoleBuilder().
+		ID(0).
+		TenantID(&tenantID).
+		Name(name).
+		Description(strings.TrimSpace(lo.FromPtr(req.Description))).
+		Permissions(permissions).
+		CreatedAt(now).
+		UpdatedAt(now).
+		Build()
+
+	err := r.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := internal.EnsureTenantExistsById(ctx, r.authUserPersist, tx, tenantID); err != nil {
+			return err
+		}
+		existing, err := r.rolePersist.GetRoleByName(ctx, tx, tenantID, name)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get role")
+		}
+		if existing != nil {
+			return katapp.NewErr(katapp.ErrDuplicate, fmt.Sprintf("role %q already exists", name))
+		}
+		if err := r.checkCanGrantPermissions(ctx, tx, principal, permissions); err != nil {
+			return err
+		}
+		if err := r.rolePersist.CreateRole(ctx, tx, role); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, r.auditPersist, tx, auditEntry{
+			action:     model.AuditActionRoleCreated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetRole,
+			targetID:   role.Name,
+			diff: auditDiff{}.
+				created("description", role.Description).
+				created("permissions", role.Permissions),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roleToRoleResponse(role), nil
+}
+
+// UpdateRole replaces description and permissions of a custom role. Access tokens of the holders of the role
+// are rejected from now on, so that they continue with the new permissions after signing in again.
+func (r *RoleMgm) UpdateRole(
+	ctx context.Context, principal *UserPrincipal, tenantID string, roleName string, req *swagger.UpdateRoleRequest,
+) (*swagger.RoleResponse, error) {
+	katapp.Logger(ctx).Info("updating role", "principal", principal.String(), "tenantID", tenantID, "name", roleName)
+
+	if err := checkRolePermission(ctx, principal, model.PermissionRolesManage, tenantID); err != nil {
+		return nil, err
+	}
+	permissions := lo.Uniq(req.Permissions)
+
+	role, err := outport.TxWithResult(ctx, r.txPort, func(tx pgx.Tx) (*model.Role, error) {
+		role, err := r.getCustomRole(ctx, tx, tenantID, roleName)
+		if err != nil {
+			return nil, err
+		}
+		// Permissions the role already grants can be kept, only added ones must be held by the principal
+		added, removed := lo.Difference(permissions, role.Permissions)
+		if err := r.checkCanGrantPermissions(ctx, tx, principal, added); err != nil {
+			return nil, err
+		}
+
+		diff := auditDiff{}
+		description := strings.TrimSpace(lo.FromPtr(req.Description))
+		diff.changed("description", role.Description, description)
+		if len(added) > 0 || len(removed) > 0 {
+			diff.changed("permissions", role.Permissions, permissions)
+		}
+		role.Description = description
+		role.Permissions = permissions
+		role.UpdatedAt = time.Now()
+		if err := r.rolePersist.UpdateRole(ctx, tx, role); err != nil {
+			return nil, err
+		}
+		if err := r.revokeAccessTokensOfRoleHolders(ctx, tx, role); err != nil {
+			return nil, err
+		}
+		err = recordAuditEvent(ctx, r.auditPersist, tx, auditEntry{
+			action:     model.AuditActionRoleUpdated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetRole,
+			targetID:   role.Name,
+			diff:       diff,
+		})
+		return role, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roleToRoleResponse(role), nil
+}
+
+// DeleteRole deletes a custom role, users and service clients holding it lose it together with their access tokens
+func (r *RoleMgm) DeleteRole(ctx context.Context, principal *UserPrincipal, tenantID string, roleName string) error {
+	katapp.Logger(ctx).Info("deleting role", "principal", principal.String(), "tenantID", tenantID, "name", roleName)
+
+	if err := checkRolePermission(ctx, principal, model.PermissionRolesManage, tenantID); err != nil {
+		return err
+	}
+
+	return r.txPort.Run(ctx, func(tx pgx.Tx) error {
+		role, err := r.getCustomRole(ctx, tx, tenantID, roleName)
+		if err != nil {
+			return err
+		}
+		// Holders must be collected before the role assignments are deleted with the role
+		if err := r.revokeAccessTokensOfRoleHolders(ctx, tx, role); err != nil {
+			return err
+		}
+		if err := r.rolePersist.DeleteRole(ctx, tx, role.ID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, r.auditPersist, tx, auditEntry{
+			action:     model.AuditActionRoleDeleted,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetRole,
+			targetID:   role.Name,
+			diff:       auditDiff{}.deleted("permissions", role.Permissions),
+		})
+	})
+}
+
+// getCustomRole returns a custom role of the tenant, system roles are rejected as they cannot be modified
+func (r *RoleMgm) getCustomRole(ctx context.Context, tx pgx.Tx, tenantID string, roleName string) (*model.Role, error) {
+	role, err := getExistingRole(ctx, r.rolePersist, tx, tenantID, roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role.IsSystem() {
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, "system roles cannot be modified")
+	}
+	return role, nil
+}
+
+// checkCanGrantPermissions rejects unknown and system-only permissions, and permissions the principal does not have
+func (r *RoleMgm) checkCanGrantPermissions(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, permissions []string,
+) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	known, err := r.rolePersist.GetPermissions(ctx, tx)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to get permissions")
+	}
+	knownByName := lo.KeyBy(known, func(p *model.Permission) string { return p.Name })
+	for _, name := range permissions {
+		permission, ok := knownByName[name]
+		if !ok {
+			return katapp.NewErr(katapp.ErrInvalidInput, fmt.Sprintf("unknown permission %q", name))
+		}
+		if permission.SystemOnly {
+			return katapp.NewErr(katapp.ErrInvalidInput,
+				fmt.Sprintf("permission %q can be granted by system roles only", name))
+		}
+		if !principal.HasPermission(name) {
+			msg := "cannot grant permissions the principal does not have"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "permission", name)
+			return katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+	}
+	return nil
+}
+
+func (r *RoleMgm) revokeAccessTokensOfRoleHolders(ctx context.Context, tx pgx.Tx, role *model.Role) error {
+	userIDs, err := r.rolePersist.GetUserIDsByRoleID(ctx, tx, role.ID)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to get users of role")
+	}
+	for _, userID := range userIDs {
+		if err := revokeAccessTokens(ctx, r.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, userID); err != nil {
+			return err
+		}
+	}
+	clientIDs, err := r.rolePersist.GetServiceClientIDsByRoleID(ctx, tx, role.ID)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to get service clients of role")
+	}
+	for _, clientID := range clientIDs {
+		if err := revokeAccessTokens(ctx, r.tokenRevocationPersist, tx, model.AccessTokenRevocationClient, clientID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRolePermission checks a roles permission in the tenant. Service clients cannot manage roles, whatever their
+// permissions, but they can read them.
+func checkRolePermission(ctx context.Context, principal *UserPrincipal, permission string, tenantID string) error {
+	if !principal.HasTenantPermission(permission, tenantID) ||
+		(permission == model.PermissionRolesManage && principal.IsServiceClient()) {
+		msg := "insufficient permissions for roles of the tenant"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID, "permission", permission)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+// checkCanGrantRole allows granting a role only to principals having all permissions of the role, so that nobody
+// can extend their own permissions by assigning roles
+func checkCanGrantRole(
+	ctx context.Context, rolePersist outport.RolePersist, tx pgx.Tx, principal *UserPrincipal,
+	tenantID string, roleName string,
+) error {
+	role, err := getExistingRole(ctx, rolePersist, tx, tenantID, roleName)
+	if err != nil {
+		return err
+	}
+	return checkHasRolePermissions(ctx, principal, role)
+}
+
+func checkHasRolePermissions(ctx context.Context, principal *UserPrincipal, role *model.Role) error {
+	if missing, _ := lo.Difference(role.Permissions, principal.Permissions); len(missing) > 0 {
+		msg := "cannot grant a role with permissions the principal does not have"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "role", role.Name, "missing", missing)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+func getExistingRole(
+	ctx context.Context, rolePersist outport.RolePersist, tx pgx.Tx, tenantID string, roleName string,
+) (*model.Role, error) {
+	role, err := rolePersist.GetRoleByName(ctx, tx, tenantID, roleName)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get role")
+	}
+	if role == nil {
+		return nil, katapp.NewErr(katapp.ErrNotFound, "role not found")
+	}
+	return role, nil
+}
+
+func roleToRoleResponse(role *model.Role) *swagger.RoleResponse {
+	return swagger.NewRoleResponseBuilder().
+		Description(role.Description).
+		Name(role.Name).
+		Permissions(lo.CoalesceSliceOrEmpty(role.Permissions)).
+		System(role.IsSystem()).
+		TenantId(role.TenantID).
+		Build()
+}
@@ -0,0 +1,1094 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// SCIM schemas and messages (RFC 7643, RFC 7644)
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimMessageListResponse         = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimMessagePatchOp              = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimMessageError                = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ScimBasePath is the path SCIM endpoints are served under
+const ScimBasePath = "/scim/v2"
+
+const (
+	// scimMaxResults limits the number of resources returned by a listing
+	scimMaxResults = 100
+	// scimMembersPageSize is the number of group members read from the database at once
+	scimMembersPageSize = 500
+	// scimGroupDescription describes roles created by SCIM clients
+	scimGroupDescription = "Provisioned by SCIM"
+)
+
+// scimFilterRegexp matches filters comparing an attribute to a string or boolean literal, the only filters supported
+var scimFilterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.:-]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*"|(?i:true|false))\s*$`)
+
+// scimMemberPathRegexp matches patch paths selecting a member of a group, e.g. members[value eq "id"]
+var scimMemberPathRegexp = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+("(?:[^"\\]|\\.)*")\s*]$`)
+
+// ScimMgm provides SCIM 2.0 provisioning of users and groups. Requests always operate on the tenant of the
+// principal, groups are the roles of the tenant and their members the users of the tenant holding them.
+type ScimMgm struct {
+	serverConfig           *katapp.ServerConfig
+	roleMgm                *RoleMgm
+	authUserPersist        outport.AuthUserPersist
+	rolePersist            outport.RolePersist
+	auditPersist           outport.AuditPersist
+	tokenRevocationPersist outport.TokenRevocationPersist
+	webhookPersist         outport.WebhookPersist
+	outboxPersist          outport.OutboxPersist
+	txPort                 outport.TxPort
+}
+
+// NewScimMgm creates a new ScimMgm use case
+func NewScimMgm(
+	serverConfig *katapp.ServerConfig, roleMgm *RoleMgm, authUserPersist outport.AuthUserPersist,
+	rolePersist outport.RolePersist, auditPersist outport.AuditPersist,
+	tokenRevocationPersist outport.TokenRevocationPersist, webhookPersist outport.WebhookPersist,
+	outboxPersist outport.OutboxPersist, txPort outport.TxPort,
+) *ScimMgm {
+	return &ScimMgm{
+		serverConfig:           serverConfig,
+		roleMgm:                roleMgm,
+		authUserPersist:        authUserPersist,
+		rolePersist:            rolePersist,
+		auditPersist:           auditPersist,
+		tokenRevocationPersist: tokenRevocationPersist,
+		webhookPersist:         webhookPersist,
+		outboxPersist:          outboxPersist,
+		txPort:                 txPort,
+	}
+}
+
+// ServiceProviderConfig returns the SCIM features supported by the service
+func (s *ScimMgm) ServiceProviderConfig() *swagger.ScimServiceProviderConfig {
+	unsupported := *swagger.NewScimSupportedBuilder().Supported(false).Build()
+	return swagger.NewScimServiceProviderConfigBuilder().
+		AuthenticationSchemes([]swagger.ScimAuthenticationScheme{
+			*swagger.NewScimAuthenticationSchemeBuilder().
+				Description("Access token of a service client, issued by the client_credentials grant").
+				Name("OAuth Bearer Token").
+				Type("oauthbearertoken").
+				Build(),
+		}).
+		Bulk(*swagger.NewScimBulkSupportBuilder().MaxOperations(0).MaxPayloadSize(0).Supported(false).Build()).
+		ChangePassword(unsupported).
+		Etag(unsupported).
+		Filter(*swagger.NewScimFilterSupportBuilder().MaxResults(scimMaxResults).Supported(true).Build()).
+		Patch(*swagger.NewScimSupportedBuilder().Supported(true).Build()).
+		Schemas([]string{ScimSchemaServiceProviderConfig}).
+		Sort(unsupported).
+		Build()
+}
+
+// ListUsers returns users of the tenant ordered by userName, optionally filtered by id, userName or active state
+func (s *ScimMgm) ListUsers(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListScimUsersParams,
+) (*swagger.ScimUserListResponse, error) {
+	katapp.Logger(ctx).Debug("listing SCIM users", "principal", principal.String(), "filter", lo.FromPtr(params.Filter))
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	filter, err := parseScimFilter(params.Filter, "id", "username", "emails", "emails.value", "active")
+	if err != nil {
+		return nil, err
+	}
+	userFilter := model.NewUserFilterBuilder().
+		TenantID(&principal.TenantID).
+		UserID(nil).
+		Email(nil).
+		Search(nil).
+		Role(nil).
+		EmailVerified(nil).
+		IsActive(nil).
+		Build()
+	if filter != nil {
+		switch filter.attribute {
+		case "id":
+			userFilter.UserID = &filter.value
+		case "active":
+			active, err := strconv.ParseBool(filter.value)
+			if err != nil {
+				return nil, katapp.NewErr(katapp.ErrInvalidInput, "active must be compared to true or false")
+			}
+			userFilter.IsActive = &active
+		default:
+			userFilter.Email = &filter.value
+		}
+	}
+	startIndex, count := scimPageBounds(params.StartIndex, params.Count)
+	pageRequest := model.NewPageRequestBuilder().
+		SortBy(model.UserSortEmail).
+		SortDesc(false).
+		After(nil).
+		Offset(startIndex - 1).
+		Limit(count).
+		Build()
+
+	var total int
+	var roles map[string][]string
+	users, err := outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) ([]*model.AuthUser, error) {
+		users, n, err := s.authUserPersist.ListUsers(ctx, tx, userFilter, pageRequest)
+		total = n
+		if err != nil || len(users) == 0 {
+			return users, err
+		}
+		roles, err = s.authUserPersist.GetRolesOfUsers(ctx, tx, lo.Map(users, func(user *model.AuthUser, _ int) string {
+			return user.ID
+		}))
+		return users, err
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list users")
+	}
+
+	resources := make([]swagger.ScimUser, len(users))
+	for i, user := range users {
+		resources[i] = *s.userToScimUser(user, roles[user.ID])
+	}
+	return swagger.NewScimUserListResponseBuilder().
+		Resources(resources).
+		ItemsPerPage(len(resources)).
+		Schemas([]string{ScimMessageListResponse}).
+		StartIndex(startIndex).
+		TotalResults(total).
+		Build(), nil
+}
+
+// GetUser returns a user of the tenant, deactivated users included
+func (s *ScimMgm) GetUser(ctx context.Context, principal *UserPrincipal, userID string) (*swagger.ScimUser, error) {
+	katapp.Logger(ctx).Debug("getting SCIM user", "principal", principal.String(), "userID", userID)
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimUser, error) {
+		user, err := s.getTenantUser(ctx, tx, principal.TenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		return s.loadScimUser(ctx, tx, user)
+	})
+}
+
+// CreateUser creates a user with the user role in the tenant. Emails of provisioned users are vouched for by the
+// identity provider, so they are verified right away. Users without a password have to reset it before signing in.
+func (s *ScimMgm) CreateUser(
+	ctx context.Context, principal *UserPrincipal, req *swagger.ScimUser,
+) (*swagger.ScimUser, error) {
+	katapp.Logger(ctx).Info("creating SCIM user", "principal", principal.String(), "userName", req.UserName)
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersCreate); err != nil {
+		return nil, err
+	}
+	attributes, err := scimUserAttributesOf(req)
+	if err != nil {
+		return nil, err
+	}
+	password := lo.FromPtr(req.Password)
+	if password == "" {
+		b := make([]byte, 32)
+		_, _ = rand.Read(b)
+		password = fmt.Sprintf("%x", b)
+	} else if len(password) < 8 {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "password must be at least 8 characters")
+	}
+	passwordHash, err := internal.HashPassword(password)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to hash password")
+	}
+
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimUser, error) {
+		user, err := s.authUserPersist.CreateUser(ctx, tx, &swagger.SignUpRequest{
+			Email:     attributes.email,
+			FirstName: attributes.firstName,
+			LastName:  attributes.lastName,
+			Password:  passwordHash,
+			Source:    swagger.Web,
+			TenantId:  principal.TenantID,
+		}, principal.TenantID)
+		if err != nil {
+			var appErr *katapp.Err
+			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrDuplicate {
+				return nil, katapp.NewErr(katapp.ErrDuplicate, "user with this userName already exists")
+			}
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to create user")
+		}
+		if err := s.authUserPersist.SetUserEmailVerified(ctx, tx, user.ID, true); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to verify email")
+		}
+		if !attributes.active {
+			if _, err := s.authUserPersist.UpdateUser(ctx, tx, user.ID, map[string]interface{}{"is_active": false}); err != nil {
+				return nil, katapp.NewErr(katapp.ErrInternal, "failed to deactivate user")
+			}
+		}
+		if err := s.authUserPersist.AssignUserRole(ctx, tx, user.ID, model.RoleUser); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to assign role")
+		}
+		err = recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserProvisioned,
+			principal:  principal,
+			tenantID:   principal.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff: auditDiff{}.
+				created("email", user.Email).
+				created("active", attributes.active),
+		})
+		if err != nil {
+			return nil, err
+		}
+		user, err = s.getTenantUser(ctx, tx, principal.TenantID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		err = queueWebhookEvent(ctx, s.webhookPersist, tx, user.TenantID, model.WebhookEventUserCreated,
+			webhookUserData(user))
+		if err != nil {
+			return nil, err
+		}
+		return s.loadScimUser(ctx, tx, user)
+	})
+}
+
+// ReplaceUser replaces userName, name and active state of a user of the tenant
+func (s *ScimMgm) ReplaceUser(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.ScimUser,
+) (*swagger.ScimUser, error) {
+	katapp.Logger(ctx).Info("replacing SCIM user", "principal", principal.String(), "userID", userID)
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersUpdate); err != nil {
+		return nil, err
+	}
+	attributes, err := scimUserAttributesOf(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, principal, userID, func(current *scimUserAttributes) error {
+		*current = *attributes
+		return nil
+	})
+}
+
+// PatchUser applies add and replace operations to userName, name and active state of a user of the tenant.
+// Attributes the service does not store, like externalId or phone numbers, are ignored.
+func (s *ScimMgm) PatchUser(
+	ctx context.Context, principal *UserPrincipal, userID string, req *swagger.ScimPatchRequest,
+) (*swagger.ScimUser, error) {
+	katapp.Logger(ctx).Info("patching SCIM user", "principal", principal.String(), "userID", userID)
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersUpdate); err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, principal, userID, func(attributes *scimUserAttributes) error {
+		for _, operation := range req.Operations {
+			op := strings.ToLower(operation.Op)
+			if op != "add" && op != "replace" {
+				return katapp.NewErr(katapp.ErrInvalidInput,
+					fmt.Sprintf("unsupported operation %q on users, add or replace expected", operation.Op))
+			}
+			value := lo.FromPtr(operation.Value)
+			if path := lo.FromPtr(operation.Path); path != "" {
+				if err := attributes.set(path, value); err != nil {
+					return err
+				}
+				continue
+			}
+			values, ok := value.(map[string]any)
+			if !ok {
+				return katapp.NewErr(katapp.ErrInvalidInput, "value of an operation without path must be an object")
+			}
+			for path, value := range values {
+				if err := attributes.set(path, value); err != nil {
+					return err
+				}
+			}
+		}
+		return attributes.validate()
+	})
+}
+
+// DeleteUser deletes a user of the tenant and rejects access tokens issued to the user so far
+func (s *ScimMgm) DeleteUser(ctx context.Context, principal *UserPrincipal, userID string) error {
+	katapp.Logger(ctx).Info("deleting SCIM user", "principal", principal.String(), "userID", userID)
+
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersDelete); err != nil {
+		return err
+	}
+	return s.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := s.getTenantUser(ctx, tx, principal.TenantID, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.authUserPersist.DeleteUser(ctx, tx, user.ID); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to delete user")
+		}
+		err = revokeAccessTokens(ctx, s.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, user.ID)
+		if err != nil {
+			return err
+		}
+		err = queueWebhookEvent(ctx, s.webhookPersist, tx, user.TenantID, model.WebhookEventUserDeleted,
+			webhookUserData(user))
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserDeleted,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.deleted("email", user.Email),
+		})
+	})
+}
+
+// ListGroups returns the system roles followed by the custom roles of the tenant, optionally filtered by name
+func (s *ScimMgm) ListGroups(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListScimGroupsParams,
+) (*swagger.ScimGroupListResponse, error) {
+	katapp.Logger(ctx).Debug("listing SCIM groups", "principal", principal.String(), "filter", lo.FromPtr(params.Filter))
+
+	withMembers := !scimExcludesMembers(params.ExcludedAttributes)
+	if err := checkScimGroupReadPermission(ctx, principal, withMembers); err != nil {
+		return nil, err
+	}
+	filter, err := parseScimFilter(params.Filter, "id", "displayname")
+	if err != nil {
+		return nil, err
+	}
+	startIndex, count := scimPageBounds(params.StartIndex, params.Count)
+
+	var total int
+	groups, err := outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) ([]swagger.ScimGroup, error) {
+		roles, err := s.rolePersist.GetRolesByTenantID(ctx, tx, principal.TenantID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to list roles")
+		}
+		if filter != nil {
+			roles = lo.Filter(roles, func(role *model.Role, _ int) bool {
+				return strings.EqualFold(role.Name, filter.value)
+			})
+		}
+		total = len(roles)
+		roles = lo.Slice(roles, startIndex-1, startIndex-1+count)
+
+		groups := make([]swagger.ScimGroup, len(roles))
+		for i, role := range roles {
+			group, err := s.roleToScimGroup(ctx, tx, principal.TenantID, role, withMembers)
+			if err != nil {
+				return nil, err
+			}
+			groups[i] = *group
+		}
+		return groups, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swagger.NewScimGroupListResponseBuilder().
+		Resources(groups).
+		ItemsPerPage(len(groups)).
+		Schemas([]string{ScimMessageListResponse}).
+		StartIndex(startIndex).
+		TotalResults(total).
+		Build(), nil
+}
+
+// GetGroup returns a role of the tenant with its members
+func (s *ScimMgm) GetGroup(
+	ctx context.Context, principal *UserPrincipal, groupID string, params *swagger.GetScimGroupParams,
+) (*swagger.ScimGroup, error) {
+	katapp.Logger(ctx).Debug("getting SCIM group", "principal", principal.String(), "groupID", groupID)
+
+	withMembers := !scimExcludesMembers(params.ExcludedAttributes)
+	if err := checkScimGroupReadPermission(ctx, principal, withMembers); err != nil {
+		return nil, err
+	}
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimGroup, error) {
+		role, err := getExistingRole(ctx, s.rolePersist, tx, principal.TenantID, groupID)
+		if err != nil {
+			return nil, err
+		}
+		return s.roleToScimGroup(ctx, tx, principal.TenantID, role, withMembers)
+	})
+}
+
+// CreateGroup creates a custom role without permissions and assigns it to the members. Permissions are granted to
+// the role by the role management API, the role is created by RoleMgm with all its checks.
+func (s *ScimMgm) CreateGroup(
+	ctx context.Context, principal *UserPrincipal, req *swagger.ScimGroup,
+) (*swagger.ScimGroup, error) {
+	katapp.Logger(ctx).Info("creating SCIM group", "principal", principal.String(), "displayName", req.DisplayName)
+
+	name := strings.TrimSpace(req.DisplayName)
+	memberIDs := scimMemberIDsOf(lo.FromPtr(req.Members))
+	if len(memberIDs) > 0 {
+		// Members are checked before the role is created, so that invalid members do not leave a role behind
+		if err := checkScimPermission(ctx, principal, model.PermissionUsersAssignRoles); err != nil {
+			return nil, err
+		}
+		if err := s.txPort.Run(ctx, func(tx pgx.Tx) error {
+			_, err := s.getTenantMembers(ctx, tx, principal.TenantID, memberIDs)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := s.roleMgm.CreateRole(ctx, principal, principal.TenantID, swagger.NewCreateRoleRequestBuilder().
+		Description(lo.ToPtr(scimGroupDescription)).
+		Name(name).
+		Permissions([]string{}).
+		Build())
+	if err != nil {
+		return nil, err
+	}
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimGroup, error) {
+		role, err := getExistingRole(ctx, s.rolePersist, tx, principal.TenantID, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.updateMembers(ctx, tx, principal, role, memberIDs, nil); err != nil {
+			return nil, err
+		}
+		return s.roleToScimGroup(ctx, tx, principal.TenantID, role, canReadScimMembers(principal))
+	})
+}
+
+// ReplaceGroup replaces the members of a role of the tenant, roles cannot be renamed
+func (s *ScimMgm) ReplaceGroup(
+	ctx context.Context, principal *UserPrincipal, groupID string, req *swagger.ScimGroup,
+) (*swagger.ScimGroup, error) {
+	katapp.Logger(ctx).Info("replacing SCIM group", "principal", principal.String(), "groupID", groupID)
+
+	return s.updateGroup(ctx, principal, groupID, func(role *model.Role, _ []string) ([]string, error) {
+		if err := checkScimGroupName(role, req.DisplayName); err != nil {
+			return nil, err
+		}
+		return scimMemberIDsOf(lo.FromPtr(req.Members)), nil
+	})
+}
+
+// PatchGroup applies add, remove and replace operations to the members of a role of the tenant
+func (s *ScimMgm) PatchGroup(
+	ctx context.Context, principal *UserPrincipal, groupID string, req *swagger.ScimPatchRequest,
+) (*swagger.ScimGroup, error) {
+	katapp.Logger(ctx).Info("patching SCIM group", "principal", principal.String(), "groupID", groupID)
+
+	return s.updateGroup(ctx, principal, groupID, func(role *model.Role, members []string) ([]string, error) {
+		for _, operation := range req.Operations {
+			op := strings.ToLower(operation.Op)
+			if op != "add" && op != "remove" && op != "replace" {
+				return nil, katapp.NewErr(katapp.ErrInvalidInput,
+					fmt.Sprintf("unsupported operation %q, add, remove or replace expected", operation.Op))
+			}
+			value := lo.FromPtr(operation.Value)
+			path := strings.TrimSpace(lo.FromPtr(operation.Path))
+			var err error
+			switch {
+			case path == "" && op != "remove":
+				values, ok := value.(map[string]any)
+				if !ok {
+					return nil, katapp.NewErr(katapp.ErrInvalidInput,
+						"value of an operation without path must be an object")
+				}
+				for name, value := range values {
+					switch strings.ToLower(name) {
+					case "members":
+						members, err = patchScimMembers(members, op, value)
+					case "displayname":
+						err = checkScimGroupName(role, value)
+					}
+					if err != nil {
+						return nil, err
+					}
+				}
+			case strings.EqualFold(path, "members"):
+				members, err = patchScimMembers(members, op, value)
+			case strings.EqualFold(path, "displayName") && op != "remove":
+				err = checkScimGroupName(role, value)
+			case scimMemberPathRegexp.MatchString(path) && op == "remove":
+				var memberID string
+				err = json.Unmarshal([]byte(scimMemberPathRegexp.FindStringSubmatch(path)[1]), &memberID)
+				members = lo.Without(members, memberID)
+			default:
+				err = katapp.NewErr(katapp.ErrInvalidInput, fmt.Sprintf("unsupported %s operation on path %q", op, path))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return members, nil
+	})
+}
+
+// DeleteGroup deletes a custom role of the tenant, the role is deleted by RoleMgm with all its checks
+func (s *ScimMgm) DeleteGroup(ctx context.Context, principal *UserPrincipal, groupID string) error {
+	katapp.Logger(ctx).Info("deleting SCIM group", "principal", principal.String(), "groupID", groupID)
+
+	return s.roleMgm.DeleteRole(ctx, principal, principal.TenantID, groupID)
+}
+
+// scimUserAttributes are the attributes of a user SCIM clients can change
+type scimUserAttributes struct {
+	email     string
+	firstName string
+	lastName  string
+	active    bool
+}
+
+// scimUserAttributesOf returns the attributes of a created or replaced user, active unless stated otherwise
+func scimUserAttributesOf(user *swagger.ScimUser) (*scimUserAttributes, error) {
+	attributes := &scimUserAttributes{
+		email:  strings.TrimSpace(user.UserName),
+		active: lo.FromPtrOr(user.Active, true),
+	}
+	if user.Name != nil {
+		attributes.firstName = strings.TrimSpace(lo.FromPtr(user.Name.GivenName))
+		attributes.lastName = strings.TrimSpace(lo.FromPtr(user.Name.FamilyName))
+	}
+	return attributes, attributes.validate()
+}
+
+func (a *scimUserAttributes) validate() error {
+	msg := ""
+	switch {
+	case a.email == "":
+		msg = "userName is required"
+	case !strings.Contains(a.email, "@"):
+		msg = "userName must be an email address"
+	case a.firstName == "":
+		msg = "name.givenName is required"
+	case a.lastName == "":
+		msg = "name.familyName is required"
+	}
+	if msg != "" {
+		return katapp.NewErr(katapp.ErrInvalidInput, msg)
+	}
+	return nil
+}
+
+// set sets the attribute at a patch path, optionally prefixed by the schema URN. The name attribute can be set as
+// a whole, attributes the service does not store are ignored.
+func (a *scimUserAttributes) set(path string, value any) error {
+	var err error
+	switch strings.ToLower(path[strings.LastIndex(path, ":")+1:]) {
+	case "username":
+		a.email, err = scimStringValue(path, value)
+	case "name.givenname":
+		a.firstName, err = scimStringValue(path, value)
+	case "name.familyname":
+		a.lastName, err = scimStringValue(path, value)
+	case "active":
+		a.active, err = scimBoolValue(path, value)
+	case "name":
+		name, ok := value.(map[string]any)
+		if !ok {
+			return katapp.NewErr(katapp.ErrInvalidInput, path+" must be an object")
+		}
+		for key, value := range name {
+			if err := a.set("name."+key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// updateUser loads a user of the tenant, updates its attributes by the given function and stores the changed ones.
+// Deactivated users are signed out everywhere. Email addresses set by the identity provider count as verified, the
+// same way addresses of provisioned users do, so a changed address stays verified and the previous address is
+// notified of the change.
+func (s *ScimMgm) updateUser(
+	ctx context.Context, principal *UserPrincipal, userID string, update func(*scimUserAttributes) error,
+) (*swagger.ScimUser, error) {
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimUser, error) {
+		user, err := s.getTenantUser(ctx, tx, principal.TenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		attributes := &scimUserAttributes{
+			email:     user.Email,
+			firstName: user.FirstName,
+			lastName:  user.LastName,
+			active:    user.IsActive,
+		}
+		if err := update(attributes); err != nil {
+			return nil, err
+		}
+
+		diff := auditDiff{}.
+			changed("email", user.Email, attributes.email).
+			changed("firstName", user.FirstName, attributes.firstName).
+			changed("lastName", user.LastName, attributes.lastName).
+			changed("active", user.IsActive, attributes.active)
+		if len(diff) == 0 {
+			return s.loadScimUser(ctx, tx, user)
+		}
+		emailChanged := !strings.EqualFold(attributes.email, user.Email)
+		if emailChanged {
+			err := deleteEmailChangeConflict(ctx, s.authUserPersist, s.webhookPersist, tx, user, attributes.email)
+			if err != nil {
+				var appErr *katapp.Err
+				if errors.As(err, &appErr) && appErr.Scope == katapp.ErrDuplicate {
+					return nil, katapp.NewErr(katapp.ErrDuplicate, "user with this userName already exists")
+				}
+				return nil, err
+			}
+		}
+		_, err = s.authUserPersist.UpdateUser(ctx, tx, user.ID, map[string]interface{}{
+			"email":      attributes.email,
+			"first_name": attributes.firstName,
+			"last_name":  attributes.lastName,
+			"is_active":  attributes.active,
+			"updated_at": time.Now(),
+		})
+		if err != nil {
+			var appErr *katapp.Err
+			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrDuplicate {
+				return nil, katapp.NewErr(katapp.ErrDuplicate, "user with this userName already exists")
+			}
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to update user")
+		}
+		if emailChanged {
+			if err := queueEmailChangeNoticeEmail(ctx, s.outboxPersist, tx, user, attributes.email, true); err != nil {
+				return nil, err
+			}
+		}
+		if user.IsActive && !attributes.active {
+			if err := s.authUserPersist.RevokeAllUserRefreshTokens(ctx, tx, user.ID); err != nil {
+				return nil, katapp.NewErr(katapp.ErrInternal, "failed to revoke sessions")
+			}
+			err = revokeAccessTokens(ctx, s.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, user.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserUpdated,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       diff,
+		})
+		if err != nil {
+			return nil, err
+		}
+		user, err = s.getTenantUser(ctx, tx, principal.TenantID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return s.loadScimUser(ctx, tx, user)
+	})
+}
+
+// updateGroup loads a role of the tenant, computes its new members from the current ones by the given function
+// and assigns or removes the role accordingly
+func (s *ScimMgm) updateGroup(
+	ctx context.Context, principal *UserPrincipal, groupID string,
+	update func(role *model.Role, members []string) ([]string, error),
+) (*swagger.ScimGroup, error) {
+	if err := checkScimPermission(ctx, principal, model.PermissionUsersAssignRoles); err != nil {
+		return nil, err
+	}
+	return outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*swagger.ScimGroup, error) {
+		role, err := getExistingRole(ctx, s.rolePersist, tx, principal.TenantID, groupID)
+		if err != nil {
+			return nil, err
+		}
+		current, err := s.listMembers(ctx, tx, principal.TenantID, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		currentIDs := lo.Map(current, func(user *model.AuthUser, _ int) string { return user.ID })
+		members, err := update(role, slices.Clone(currentIDs))
+		if err != nil {
+			return nil, err
+		}
+		added, removed := lo.Difference(lo.Uniq(members), currentIDs)
+		if err := s.updateMembers(ctx, tx, principal, role, added, removed); err != nil {
+			return nil, err
+		}
+		return s.roleToScimGroup(ctx, tx, principal.TenantID, role, canReadScimMembers(principal))
+	})
+}
+
+// updateMembers assigns the role to the added users of the tenant and removes it from the removed ones. Removed
+// members lose their access tokens, so that they cannot use the permissions of the role anymore.
+func (s *ScimMgm) updateMembers(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, role *model.Role, added []string, removed []string,
+) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if err := checkHasRolePermissions(ctx, principal, role); err != nil {
+		return err
+	}
+	users, err := s.getTenantMembers(ctx, tx, principal.TenantID, added)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := s.authUserPersist.AssignUserRole(ctx, tx, user.ID, role.Name); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to assign role")
+		}
+		err = recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserRoleAssigned,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.created("role", role.Name),
+		})
+		if err != nil {
+			return err
+		}
+		err = queueWebhookEvent(ctx, s.webhookPersist, tx, user.TenantID, model.WebhookEventRoleAssigned,
+			map[string]any{"userId": user.ID, "role": role.Name})
+		if err != nil {
+			return err
+		}
+	}
+	for _, userID := range removed {
+		if err := s.authUserPersist.DeleteUserRole(ctx, tx, userID, role.Name); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to remove role")
+		}
+		err := revokeAccessTokens(ctx, s.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, userID)
+		if err != nil {
+			return err
+		}
+		err = recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserRoleRemoved,
+			principal:  principal,
+			tenantID:   principal.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   userID,
+			diff:       auditDiff{}.deleted("role", role.Name),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTenantUser returns a user of the tenant, deactivated users included
+func (s *ScimMgm) getTenantUser(ctx context.Context, tx pgx.Tx, tenantID string, userID string) (*model.AuthUser, error) {
+	filter := model.NewUserFilterBuilder().
+		TenantID(&tenantID).
+		UserID(&userID).
+		Email(nil).
+		Search(nil).
+		Role(nil).
+		EmailVerified(nil).
+		IsActive(nil).
+		Build()
+	pageRequest := model.NewPageRequestBuilder().
+		SortBy(model.UserSortEmail).
+		SortDesc(false).
+		After(nil).
+		Offset(0).
+		Limit(1).
+		Build()
+	users, _, err := s.authUserPersist.ListUsers(ctx, tx, filter, pageRequest)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
+	}
+	if len(users) == 0 {
+		return nil, katapp.NewErr(katapp.ErrNotFound, "user not found")
+	}
+	return users[0], nil
+}
+
+// getTenantMembers returns the users of the tenant with the given IDs, members of other tenants are rejected
+func (s *ScimMgm) getTenantMembers(
+	ctx context.Context, tx pgx.Tx, tenantID string, userIDs []string,
+) ([]*model.AuthUser, error) {
+	users := make([]*model.AuthUser, len(userIDs))
+	for i, userID := range userIDs {
+		user, err := s.getTenantUser(ctx, tx, tenantID, userID)
+		if err != nil {
+			var appErr *katapp.Err
+			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrNotFound {
+				return nil, katapp.NewErr(katapp.ErrInvalidInput,
+					fmt.Sprintf("member %q is not a user of the tenant", userID))
+			}
+			return nil, err
+		}
+		users[i] = user
+	}
+	return users, nil
+}
+
+// listMembers returns the users of the tenant holding the role ordered by email, reading them page by page
+func (s *ScimMgm) listMembers(ctx context.Context, tx pgx.Tx, tenantID string, roleName string) ([]*model.AuthUser, error) {
+	filter := model.NewUserFilterBuilder().
+		TenantID(&tenantID).
+		UserID(nil).
+		Email(nil).
+		Search(nil).
+		Role(&roleName).
+		EmailVerified(nil).
+		IsActive(nil).
+		Build()
+	var members []*model.AuthUser
+	var after *model.PageCursor
+	for {
+		pageRequest := model.NewPageRequestBuilder().
+			SortBy(model.UserSortEmail).
+			SortDesc(false).
+			After(after).
+			Offset(0).
+			Limit(scimMembersPageSize).
+			Build()
+		users, _, err := s.authUserPersist.ListUsers(ctx, tx, filter, pageRequest)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to list members")
+		}
+		members = append(members, users...)
+		if len(users) < scimMembersPageSize {
+			return members, nil
+		}
+		last := users[len(users)-1]
+		after = &model.PageCursor{Value: last.Email, ID: last.ID}
+	}
+}
+
+func (s *ScimMgm) loadScimUser(ctx context.Context, tx pgx.Tx, user *model.AuthUser) (*swagger.ScimUser, error) {
+	roles, err := s.authUserPersist.GetUserRoles(ctx, tx, user.ID)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user roles")
+	}
+	return s.userToScimUser(user, roles), nil
+}
+
+func (s *ScimMgm) userToScimUser(user *model.AuthUser, roles []string) *swagger.ScimUser {
+	displayName := user.FirstName + " " + user.LastName
+	groups := lo.Map(roles, func(role string, _ int) swagger.ScimMemberRef {
+		return *swagger.NewScimMemberRefBuilder().
+			Display(&role).
+			Value(role).
+			Build()
+	})
+	return swagger.NewScimUserBuilder().
+		Active(&user.IsActive).
+		DisplayName(&displayName).
+		Emails(&[]swagger.ScimEmail{
+			*swagger.NewScimEmailBuilder().
+				Primary(lo.ToPtr(true)).
+				Type(lo.ToPtr("work")).
+				Value(user.Email).
+				Build(),
+		}).
+		Groups(&groups).
+		Id(&user.ID).
+		Meta(s.scimMeta("User", "/Users/"+url.PathEscape(user.ID), user.CreatedAt, user.UpdatedAt)).
+		Name(swagger.NewScimUserNameBuilder().
+			FamilyName(&user.LastName).
+			Formatted(&displayName).
+			GivenName(&user.FirstName).
+			Build()).
+		Password(nil).
+		Schemas([]string{ScimSchemaUser}).
+		UserName(user.Email).
+		Build()
+}
+
+func (s *ScimMgm) roleToScimGroup(
+	ctx context.Context, tx pgx.Tx, tenantID string, role *model.Role, withMembers bool,
+) (*swagger.ScimGroup, error) {
+	var members *[]swagger.ScimMemberRef
+	if withMembers {
+		users, err := s.listMembers(ctx, tx, tenantID, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		members = lo.ToPtr(lo.Map(users, func(user *model.AuthUser, _ int) swagger.ScimMemberRef {
+			return *swagger.NewScimMemberRefBuilder().
+				Display(&user.Email).
+				Value(user.ID).
+				Build()
+		}))
+	}
+	return swagger.NewScimGroupBuilder().
+		DisplayName(role.Name).
+		Id(&role.Name).
+		Members(members).
+		Meta(s.scimMeta("Group", "/Groups/"+url.PathEscape(role.Name), role.CreatedAt, role.UpdatedAt)).
+		Schemas([]string{ScimSchemaGroup}).
+		Build(), nil
+}
+
+func (s *ScimMgm) scimMeta(resourceType string, path string, created time.Time, lastModified time.Time) *swagger.ScimMeta {
+	return swagger.NewScimMetaBuilder().
+		Created(created).
+		LastModified(lastModified).
+		Location(strings.TrimSuffix(s.serverConfig.Domain, "/") + ScimBasePath + path).
+		ResourceType(resourceType).
+		Build()
+}
+
+// scimFilter is a parsed `attribute eq "value"` filter, the attribute is lowercase and without schema URN
+type scimFilter struct {
+	attribute string
+	value     string
+}
+
+// parseScimFilter parses a filter comparing one of the given lowercase attributes, nil is returned for no filter
+func parseScimFilter(filter *string, attributes ...string) (*scimFilter, error) {
+	if strings.TrimSpace(lo.FromPtr(filter)) == "" {
+		return nil, nil
+	}
+	match := scimFilterRegexp.FindStringSubmatch(*filter)
+	if match == nil {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, `unsupported filter, 'attribute eq "value"' expected`)
+	}
+	attribute := strings.ToLower(match[1])
+	attribute = attribute[strings.LastIndex(attribute, ":")+1:]
+	if !slices.Contains(attributes, attribute) {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, fmt.Sprintf("filtering by %s is not supported", match[1]))
+	}
+	value := strings.ToLower(match[2])
+	if strings.HasPrefix(match[2], `"`) {
+		if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid string in filter")
+		}
+	}
+	return &scimFilter{attribute: attribute, value: value}, nil
+}
+
+// scimPageBounds returns the 1-based index of the first listed resource and the number of listed resources
+func scimPageBounds(startIndex *int, count *int) (int, int) {
+	return max(lo.FromPtrOr(startIndex, 1), 1), min(max(lo.FromPtrOr(count, scimMaxResults), 0), scimMaxResults)
+}
+
+// scimExcludesMembers checks if members are among the comma-separated excluded attributes
+func scimExcludesMembers(excludedAttributes *string) bool {
+	return lo.ContainsBy(strings.Split(lo.FromPtr(excludedAttributes), ","), func(attribute string) bool {
+		return strings.EqualFold(strings.TrimSpace(attribute), "members")
+	})
+}
+
+// patchScimMembers applies a patch operation to member IDs, removing without value removes all members
+func patchScimMembers(members []string, op string, value any) ([]string, error) {
+	if op == "remove" && value == nil {
+		return nil, nil
+	}
+	var refs []any
+	switch value := value.(type) {
+	case []any:
+		refs = value
+	case map[string]any:
+		refs = []any{value}
+	default:
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "members must be a list of objects with a value")
+	}
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		member, _ := ref.(map[string]any)
+		id, ok := member["value"].(string)
+		if !ok || id == "" {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "members must be a list of objects with a value")
+		}
+		ids[i] = id
+	}
+	switch op {
+	case "add":
+		return append(members, ids...), nil
+	case "remove":
+		return lo.Without(members, ids...), nil
+	default:
+		return ids, nil
+	}
+}
+
+func scimMemberIDsOf(members []swagger.ScimMemberRef) []string {
+	return lo.Uniq(lo.Map(members, func(member swagger.ScimMemberRef, _ int) string {
+		return member.Value
+	}))
+}
+
+// checkScimGroupName rejects renaming of roles, the group name can only be repeated
+func checkScimGroupName(role *model.Role, displayName any) error {
+	name, ok := displayName.(string)
+	if !ok || !strings.EqualFold(strings.TrimSpace(name), role.Name) {
+		return katapp.NewErr(katapp.ErrInvalidInput, fmt.Sprintf("roles cannot be renamed, displayName must be %q",
+			role.Name))
+	}
+	return nil
+}
+
+func scimStringValue(path string, value any) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", katapp.NewErr(katapp.ErrInvalidInput, path+" must be a string")
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// scimBoolValue accepts booleans, and strings like "False" sent by some identity providers
+func scimBoolValue(path string, value any) (bool, error) {
+	switch value := value.(type) {
+	case bool:
+		return value, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.ToLower(value)); err == nil {
+			return b, nil
+		}
+	}
+	return false, katapp.NewErr(katapp.ErrInvalidInput, path+" must be a boolean")
+}
+
+// checkScimPermission checks a permission in the tenant of the principal, the only tenant SCIM requests operate on
+func checkScimPermission(ctx context.Context, principal *UserPrincipal, permission string) error {
+	if !principal.HasTenantPermission(permission, principal.TenantID) {
+		msg := "insufficient permissions for SCIM provisioning"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "permission", permission)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+// checkScimGroupReadPermission checks the permissions to read roles, and their members unless they are excluded
+func checkScimGroupReadPermission(ctx context.Context, principal *UserPrincipal, withMembers bool) error {
+	if err := checkScimPermission(ctx, principal, model.PermissionRolesRead); err != nil {
+		return err
+	}
+	if withMembers {
+		return checkScimPermission(ctx, principal, model.PermissionUsersRead)
+	}
+	return nil
+}
+
+// canReadScimMembers checks if groups returned by changes of roles include their members
+func canReadScimMembers(principal *UserPrincipal) bool {
+	return principal.HasTenantPermission(model.PermissionUsersRead, principal.TenantID)
+}
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// OAuthGrantClientCredentials is the grant type service clients obtain access tokens with
+const OAuthGrantClientCredentials = "client_credentials"
+
+// ClientCredentialsTokenRequest holds the form parameters of a client_credentials token request
+type ClientCredentialsTokenRequest struct {
+	GrantType    string
+	Scope        string
+	ClientID     string
+	ClientSecret string
+}
+
+// ServiceClientMgm provides management of service clients and issues their access tokens
+type ServiceClientMgm struct {
+	serviceClientPersist   outport.ServiceClientPersist
+	authUserPersist        outport.AuthUserPersist
+	rolePersist            outport.RolePersist
+	auditPersist           outport.AuditPersist
+	tokenRevocationPersist outport.TokenRevocationPersist
+	txPort                 outport.TxPort
+	jwtKeys                *JWTKeySet
+}
+
+// NewServiceClientMgm creates a new ServiceClientMgm use case
+func NewServiceClientMgm(
+	serviceClientPort outport.ServiceClientPersist, authUserPort outport.AuthUserPersist, rolePort outport.RolePersist,
+	auditPort outport.AuditPersist, tokenRevocationPort outport.TokenRevocationPersist, databasePort outport.TxPort,
+	jwtKeys *JWTKeySet,
+) *ServiceClientMgm {
+	return &ServiceClientMgm{
+		serviceClientPersist:   serviceClientPort,
+		authUserPersist:        authUserPort,
+		rolePersist:            rolePort,
+		auditPersist:           auditPort,
+		tokenRevocationPersist: tokenRevocationPort,
+		txPort:                 databasePort,
+		jwtKeys:                jwtKeys,
+	}
+}
+
+// CreateServiceClient creates a service client in the tenant of the principal, or in any tenant with tenants:all.
+// The generated secret is returned in the response only, the client keeps its hash.
+func (s *ServiceClientMgm) CreateServiceClient(
+	ctx context.Context, principal *UserPrincipal, req *swagger.CreateServiceClientRequest,
+) (*swagger.CreateServiceClientResponse, error) {
+	tenantID := lo.FromPtrOr(req.TenantId, principal.TenantID)
+	katapp.Logger(ctx).Info("creating service client",
+		"principal", principal.String(), "tenantID", tenantID, "name", req.Name)
+
+	if err := s.checkCanManageServiceClients(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "service client name is required")
+	}
+	if len(req.Roles) == 0 {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "at least one role is required")
+	}
+	for _, scope := range req.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "scopes must be non-empty and must not contain spaces")
+		}
+	}
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "expiresAt must be in the future")
+	}
+
+	clientSecret := generateServiceClientSecret()
+	secretHash, err := internal.HashPassword(clientSecret)
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to hash client secret")
+	}
+	client := model.NewServiceClientBuilder().
+		ID("svc-" + uuid.NewString()).
+		TenantID(tenantID).
+		Name(name).
+		ClientSecretHash(secretHash).
+		Roles(lo.Uniq(req.Roles)).
+		Scopes(lo.Uniq(req.Scopes)).
+		ExpiresAt(req.ExpiresAt).
+		CreatedAt(now).
+		UpdatedAt(now).
+		Build()
+
+	err = s.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := internal.EnsureTenantExistsById(ctx, s.authUserPersist, tx, tenantID); err != nil {
+			return err
+		}
+		if err := s.checkCanAssignRoles(ctx, tx, principal, tenantID, client.Roles); err != nil {
+			return err
+		}
+		if err := s.serviceClientPersist.CreateServiceClient(ctx, tx, client); err != nil {
+			return err
+		}
+		diff := auditDiff{}.
+			created("name", client.Name).
+			created("roles", client.Roles).
+			created("scopes", client.Scopes)
+		if client.ExpiresAt != nil {
+			diff.created("expires_at", client.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		return recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionServiceClientCreated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetServiceClient,
+			targetID:   client.ID,
+			diff:       diff,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return swagger.NewCreateServiceClientResponseBuilder().
+		Client(*serviceClientToServiceClientResponse(client)).
+		ClientSecret(clientSecret).
+		Build(), nil
+}
+
+// ListServiceClients returns service clients of a tenant, the tenant of the principal if empty
+func (s *ServiceClientMgm) ListServiceClients(
+	ctx context.Context, principal *UserPrincipal, tenantID string,
+) (*swagger.ServiceClientsResponse, error) {
+	if tenantID == "" {
+		tenantID = principal.TenantID
+	}
+	katapp.Logger(ctx).Info("listing service clients", "principal", principal.String(), "tenantID", tenantID)
+
+	if err := s.checkCanManageServiceClients(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+
+	clients, err := outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) ([]*model.ServiceClient, error) {
+		return s.serviceClientPersist.GetServiceClientsByTenantID(ctx, tx, tenantID)
+	})
+	if err != nil {
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to list service clients")
+	}
+
+	items := make([]swagger.ServiceClientResponse, len(clients))
+	for i, client := range clients {
+		items[i] = *serviceClientToServiceClientResponse(client)
+	}
+	return swagger.NewServiceClientsResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// DeleteServiceClient deletes a service client and rejects access tokens issued to it so far
+func (s *ServiceClientMgm) DeleteServiceClient(ctx context.Context, principal *UserPrincipal, clientID string) error {
+	katapp.Logger(ctx).Info("deleting service client", "principal", principal.String(), "clientID", clientID)
+
+	return s.txPort.Run(ctx, func(tx pgx.Tx) error {
+		client, err := s.serviceClientPersist.GetServiceClientByID(ctx, tx, clientID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get service client")
+		}
+		if client == nil {
+			return katapp.NewErr(katapp.ErrNotFound, "service client not found")
+		}
+		if err := s.checkCanManageServiceClients(ctx, principal, client.TenantID); err != nil {
+			return err
+		}
+
+		if err := s.serviceClientPersist.DeleteServiceClient(ctx, tx, client.ID); err != nil {
+			return err
+		}
+		// The client cannot obtain new tokens, so tokens issued within the current second are rejected as well
+		err = revokeAccessTokensIssuedBefore(ctx, s.tokenRevocationPersist, tx, model.AccessTokenRevocationClient,
+			client.ID, time.Now().Truncate(time.Second).Add(time.Second))
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, s.auditPersist, tx, auditEntry{
+			action:     model.AuditActionServiceClientDeleted,
+			principal:  principal,
+			tenantID:   client.TenantID,
+			targetType: model.AuditTargetServiceClient,
+			targetID:   client.ID,
+			diff:       auditDiff{}.deleted("name", client.Name),
+		})
+	})
+}
+
+// IssueToken handles the client_credentials grant. The access token carries the roles of the client, their
+// permissions and the requested scopes, all scopes of the client if none are requested. No refresh token is issued,
+// the client requests a new access token with its credentials instead. All errors are returned as *OAuthError.
+func (s *ServiceClientMgm) IssueToken(
+	ctx context.Context, req *ClientCredentialsTokenRequest,
+) (*swagger.ClientCredentialsTokenResponse, error) {
+	katapp.Logger(ctx).Info("handling client credentials token request", "clientID", req.ClientID)
+
+	switch req.GrantType {
+	case OAuthGrantClientCredentials:
+	case "":
+		return nil, newOAuthError(OAuthErrInvalidRequest, "grant_type is required")
+	default:
+		return nil, newOAuthError(OAuthErrUnsupportedGrantType, "grant_type must be client_credentials")
+	}
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client_id and client_secret are required")
+	}
+
+	var permissions []string
+	client, err := outport.TxWithResult(ctx, s.txPort, func(tx pgx.Tx) (*model.ServiceClient, error) {
+		client, err := s.serviceClientPersist.GetServiceClientByID(ctx, tx, req.ClientID)
+		if err != nil || client == nil {
+			return client, err
+		}
+		permissions, err = s.rolePersist.GetPermissionsByRoles(ctx, tx, client.TenantID, client.Roles)
+		return client, err
+	})
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to get service client")
+	}
+	now := time.Now()
+	if client == nil || internal.VerifyPassword(client.ClientSecretHash, req.ClientSecret) != nil {
+		katapp.Logger(ctx).Warn("service client authentication failed", "clientID", req.ClientID)
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	if client.IsExpired(now) {
+		katapp.Logger(ctx).Warn("expired service client requested token", "clientID", client.ID)
+		return nil, newOAuthError(OAuthErrInvalidClient, "client has expired")
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if unknown, _ := lo.Difference(scopes, client.Scopes); len(unknown) > 0 {
+		return nil, newOAuthError(OAuthErrInvalidScope, fmt.Sprintf("scope %q is not granted to the client", unknown[0]))
+	}
+	scope := strings.Join(lo.Uniq(scopes), " ")
+
+	// Tokens of expiring clients do not outlive the client
+	expiresAt := now.Add(accessTokenTTL)
+	if client.ExpiresAt != nil && client.ExpiresAt.Before(expiresAt) {
+		expiresAt = *client.ExpiresAt
+	}
+	accessClaims := jwt.MapClaims{
+		"sub":           client.ID,
+		"iat":           now.Unix(),
+		"exp":           expiresAt.Unix(),
+		"jti":           uuid.NewString(),
+		"type":          "access",
+		"principalType": PrincipalTypeServiceClient,
+		"roles":         client.Roles,
+		"permissions":   permissions,
+		"tenantId":      client.TenantID,
+		"client_id":     client.ID,
+		"scope":         scope,
+	}
+	accessToken, err := s.jwtKeys.SignedString(accessClaims)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "failed to generate access token")
+	}
+
+	katapp.Logger(ctx).Info("access token issued to service client", "clientID", client.ID, "scope", scope)
+	return swagger.NewClientCredentialsTokenResponseBuilder().
+		AccessToken(accessToken).
+		ExpiresIn(int64(expiresAt.Sub(now) / time.Second)).
+		Scope(scope).
+		TokenType("Bearer").
+		Build(), nil
+}
+
+// checkCanAssignRoles allows roles of the tenant only, and rejects roles granting permissions in all tenants,
+// as service clients always act within their tenant
+func (s *ServiceClientMgm) checkCanAssignRoles(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, tenantID string, roles []string,
+) error {
+	for _, roleName := range roles {
+		role, err := getExistingRole(ctx, s.rolePersist, tx, tenantID, roleName)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(role.Permissions, model.PermissionAllTenants) {
+			return katapp.NewErr(katapp.ErrInvalidInput,
+				fmt.Sprintf("role %q cannot be assigned to service clients", roleName))
+		}
+		if err := checkHasRolePermissions(ctx, principal, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCanManageServiceClients requires service_clients:manage in the tenant. Service clients cannot manage
+// service clients, whatever their permissions.
+func (s *ServiceClientMgm) checkCanManageServiceClients(
+	ctx context.Context, principal *UserPrincipal, tenantID string,
+) error {
+	if principal.IsServiceClient() || !principal.HasTenantPermission(model.PermissionServiceClientsManage, tenantID) {
+		msg := "insufficient permissions to manage service clients"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+// generateServiceClientSecret generates a secure random client secret
+func generateServiceClientSecret() string {
+	b := make([]byte, 32) // 256-bit secret
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+func serviceClientToServiceClientResponse(client *model.ServiceClient) *swagger.ServiceClientResponse {
+	return swagger.NewServiceClientResponseBuilder().
+		ClientId(client.ID).
+		CreatedAt(client.CreatedAt).
+		ExpiresAt(client.ExpiresAt).
+		Name(client.Name).
+		Roles(lo.CoalesceSliceOrEmpty(client.Roles)).
+		Scopes(lo.CoalesceSliceOrEmpty(client.Scopes)).
+		TenantId(client.TenantID).
+		Build()
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+var sessionSources = []string{model.SessionSourceWeb, model.SessionSourceAndroid, model.SessionSourceIOS}
+
+// ListUserSessions returns the active sessions of a user, most recently used first. The session of the
+// principal's access token is marked as current.
+func (a *AuthMgm) ListUserSessions(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.UserSessionsResponse, error) {
+	katapp.Logger(ctx).Info("listing user sessions", "principal", principal.String(), "userID", userID)
+
+	sessions, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) ([]*model.Session, error) {
+		if _, err := a.getUserForSessionManagement(ctx, tx, principal, userID); err != nil {
+			return nil, err
+		}
+		sessions, err := a.authUserPersist.GetActiveSessionsByUserID(ctx, tx, userID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get sessions")
+		}
+		return sessions, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]swagger.UserSessionResponse, len(sessions))
+	for i, session := range sessions {
+		items[i] = *swagger.NewUserSessionResponseBuilder().
+			CreatedAt(session.CreatedAt).
+			Current(principal.SessionID != "" && principal.SessionID == session.ID).
+			Id(session.ID).
+			IpAddress(session.IPAddress).
+			LastUsedAt(session.LastUsedAt).
+			Source(session.Source).
+			UserAgent(session.UserAgent).
+			Build()
+	}
+	return swagger.NewUserSessionsResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// RevokeUserSession signs a user out on one device by revoking the refresh tokens of the session. Access tokens
+// issued for the session so far are rejected.
+func (a *AuthMgm) RevokeUserSession(
+	ctx context.Context, principal *UserPrincipal, userID string, sessionID string,
+) error {
+	katapp.Logger(ctx).Info("revoking user session",
+		"principal", principal.String(), "userID", userID, "sessionID", sessionID)
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := a.getUserForSessionManagement(ctx, tx, principal, userID)
+		if err != nil {
+			return err
+		}
+		session, err := a.authUserPersist.GetActiveSessionByID(ctx, tx, sessionID)
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to get session")
+		}
+		if session == nil || session.UserID != user.ID {
+			return katapp.NewErr(katapp.ErrNotFound, "session not found")
+		}
+		if _, err := a.authUserPersist.RevokeRefreshTokenFamily(ctx, tx, session.ID); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to revoke session")
+		}
+		err = revokeAccessTokens(ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationSession, session.ID)
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserSessionRevoked,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.deleted("session", session.ID),
+		})
+	})
+}
+
+// RevokeUserSessions signs a user out on all devices by revoking the refresh tokens of all sessions. Access
+// tokens issued to the user so far are rejected.
+func (a *AuthMgm) RevokeUserSessions(ctx context.Context, principal *UserPrincipal, userID string) error {
+	katapp.Logger(ctx).Info("revoking all user sessions", "principal", principal.String(), "userID", userID)
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := a.getUserForSessionManagement(ctx, tx, principal, userID)
+		if err != nil {
+			return err
+		}
+		if err := a.authUserPersist.RevokeAllUserRefreshTokens(ctx, tx, user.ID); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to revoke sessions")
+		}
+		err = revokeAccessTokens(ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationUser, user.ID)
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserSessionsRevoked,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+		})
+	})
+}
+
+// getUserForSessionManagement returns the user whose sessions are managed, if the principal is allowed to
+func (a *AuthMgm) getUserForSessionManagement(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, userID string,
+) (*model.AuthUser, error) {
+	if userID == "" {
+		msg := "user id cannot be empty"
+		katapp.Logger(ctx).Error(msg, "principal", principal.String(), "userID", userID)
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, msg)
+	}
+	user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !principal.HasUserPermission(model.PermissionUsersUpdate, userID, user.TenantID) {
+		msg := "insufficient permissions to manage user sessions"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return user, nil
+}
+
+// sessionSourceOrDefault returns the platform a session is started from, web unless given otherwise
+func sessionSourceOrDefault(source *string) string {
+	if source == nil || *source == "" {
+		return model.SessionSourceWeb
+	}
+	return *source
+}
+
+func validateSessionSource(source *string) error {
+	if !slices.Contains(sessionSources, sessionSourceOrDefault(source)) {
+		return katapp.NewErr(katapp.ErrInvalidInput, "source must be one of web, android or ios")
+	}
+	return nil
+}
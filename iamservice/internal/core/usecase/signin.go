@@ -11,10 +11,14 @@ import (
 	"github.com/mobiletoly/gokatana/katapp"
 )
 
-// SignIn authenticates a user and returns tokens
-func (a *AuthMgm) SignIn(ctx context.Context, req *swagger.SignInRequest) (*swagger.SignInResponse, error) {
+// SignIn authenticates a user and returns tokens. When a second factor is required, no tokens are issued
+// and an MFA challenge is returned instead, to be completed with VerifyMFA. Failed attempts are counted per
+// user and per client IP, and a SignInThrottledError is returned while either of them is locked out.
+func (a *AuthMgm) SignIn(
+	ctx context.Context, req *swagger.SignInRequest, clientIP string,
+) (*swagger.SignInResponse, *swagger.MfaChallengeResponse, error) {
 	if err := a.validateSigninRequest(req); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	tenantID := req.TenantId
 
@@ -24,36 +28,70 @@ func (a *AuthMgm) SignIn(ctx context.Context, req *swagger.SignInRequest) (*swag
 			return nil, err
 		}
 
+		// Reject attempts from a locked out client before doing any work
+		if err := a.checkSignInThrottle(ctx, tx, model.SignInThrottleSubjectIP, clientIP); err != nil {
+			return nil, err
+		}
+
 		// Get user with password hash
 		user, err := a.authUserPersist.GetUserWithPasswordByEmail(ctx, tx, string(req.Email), tenantID)
 		if err != nil {
 			var appErr *katapp.Err
 			if errors.As(err, &appErr) && appErr.Scope == katapp.ErrNotFound {
-				return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid credentials")
+				return nil, nil
 			}
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
 		}
-		return user, err
+
+		// Reject attempts for a locked out account before checking the password
+		if err := a.checkSignInThrottle(ctx, tx, model.SignInThrottleSubjectUser, user.ID); err != nil {
+			return nil, err
+		}
+		return user, nil
 	})
 	if err != nil {
-		return nil, err
+		var throttledErr *SignInThrottledError
+		if errors.As(err, &throttledErr) {
+			a.auditSignInFailure(ctx, tenantID, nil, string(req.Email), signInFailureLockedOut)
+		}
+		return nil, nil, err
+	}
+	if user == nil {
+		a.recordSignInFailure(ctx, "", clientIP)
+		a.auditSignInFailure(ctx, tenantID, nil, string(req.Email), signInFailureUnknownUser)
+		return nil, nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid credentials")
 	}
 
 	// Verify password
 	if err := a.verifyPassword(user.PasswordHash, req.Password); err != nil {
-		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid credentials")
+		a.recordSignInFailure(ctx, user.ID, clientIP)
+		a.auditSignInFailure(ctx, tenantID, user, user.Email, signInFailureInvalidCredentials)
+		return nil, nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid credentials")
 	}
 
 	// Check if email is verified
 	if !user.EmailVerified {
-		return nil, katapp.NewErr(katapp.ErrUnauthorized, "email address not verified. Please check your email for confirmation instructions")
+		a.auditSignInFailure(ctx, tenantID, user, user.Email, signInFailureEmailNotVerified)
+		return nil, nil, katapp.NewErr(katapp.ErrUnauthorized, "email address not verified. Please check your email for confirmation instructions")
+	}
+
+	// Require a second factor before issuing tokens. Failed attempts of the user are only reset once
+	// the second factor is verified as well.
+	challenge, err := a.mfaChallengeForUser(ctx, user, sessionSourceOrDefault(req.Source))
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
 	}
 
 	// Generate tokens with roles
-	accessToken, refreshToken, expiresIn, err := a.generateJWTTokenForUser(ctx, user)
+	accessToken, refreshToken, expiresIn, err := a.generateJWTTokenForUser(ctx, user, sessionSourceOrDefault(req.Source))
 	if err != nil {
-		return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate tokens")
+		return nil, nil, katapp.NewErr(katapp.ErrInternal, "failed to generate tokens")
 	}
+	a.resetSignInFailures(ctx, user.ID)
+	recordDetachedAuditEvent(ctx, a.auditPersist, a.txPort, signInSucceededAuditEntry(user))
 
 	// Build response
 	tokenType := "Bearer"
@@ -63,7 +101,50 @@ func (a *AuthMgm) SignIn(ctx context.Context, req *swagger.SignInRequest) (*swag
 		RefreshToken(refreshToken).
 		TokenType(tokenType).
 		UserId(user.ID).
-		Build(), nil
+		Build(), nil, nil
+}
+
+// Sign in failure reasons recorded in the audit log
+const (
+	signInFailureUnknownUser        = "unknown_user"
+	signInFailureInvalidCredentials = "invalid_credentials"
+	signInFailureEmailNotVerified   = "email_not_verified"
+	signInFailureLockedOut          = "locked_out"
+	signInFailureInvalidMFACode     = "invalid_mfa_code"
+)
+
+// auditSignInFailure records a failed sign in. The target is the user if known, otherwise the email
+// the sign in was attempted with.
+func (a *AuthMgm) auditSignInFailure(
+	ctx context.Context, tenantID string, user *model.AuthUser, email string, reason string,
+) {
+	entry := auditEntry{
+		action:     model.AuditActionSignInFailed,
+		tenantID:   tenantID,
+		targetType: model.AuditTargetEmail,
+		targetID:   email,
+		reason:     reason,
+	}
+	if user != nil {
+		entry.targetType = model.AuditTargetUser
+		entry.targetID = user.ID
+	}
+	recordDetachedAuditEvent(ctx, a.auditPersist, a.txPort, entry)
+}
+
+// signInSucceededAuditEntry describes a successful sign in, performed by the user on themselves
+func signInSucceededAuditEntry(user *model.AuthUser) auditEntry {
+	return auditEntry{
+		action: model.AuditActionSignInSucceeded,
+		principal: &UserPrincipal{
+			UserID:   user.ID,
+			TenantID: user.TenantID,
+			Email:    user.Email,
+		},
+		tenantID:   user.TenantID,
+		targetType: model.AuditTargetUser,
+		targetID:   user.ID,
+	}
 }
 
 func (a *AuthMgm) validateSigninRequest(req *swagger.SignInRequest) error {
@@ -76,6 +157,9 @@ func (a *AuthMgm) validateSigninRequest(req *swagger.SignInRequest) error {
 	if req.TenantId == "" {
 		return katapp.NewErr(katapp.ErrInvalidInput, "tenant ID is required")
 	}
+	if err := validateSessionSource(req.Source); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+)
+
+// SignInThrottledError is returned by sign in while the user or the client IP is locked out after too many
+// failed attempts. It unwraps to an unauthorized application error.
+type SignInThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *SignInThrottledError) Error() string {
+	return "too many failed sign in attempts, try again later"
+}
+
+func (e *SignInThrottledError) Unwrap() error {
+	return katapp.NewErr(katapp.ErrUnauthorized, e.Error())
+}
+
+// RetryAfterSeconds returns the lockout remainder rounded up to whole seconds, as used by Retry-After header
+func (e *SignInThrottledError) RetryAfterSeconds() int {
+	return max(1, int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// GetUserLockout returns the failed sign in attempts and the lockout status of a user
+func (a *AuthMgm) GetUserLockout(
+	ctx context.Context, principal *UserPrincipal, userID string,
+) (*swagger.UserLockoutResponse, error) {
+	return outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (*swagger.UserLockoutResponse, error) {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.HasTenantPermission(model.PermissionUsersUpdate, user.TenantID) {
+			msg := "insufficient permissions to view sign in lockout"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+			return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		throttle, err := a.signInThrottlePersist.GetSignInThrottle(ctx, tx, model.SignInThrottleSubjectUser, user.ID)
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get sign in lockout")
+		}
+
+		var failedAttempts int
+		var lockedUntil *time.Time
+		now := time.Now()
+		if throttle != nil {
+			if throttle.WindowStartedAt.Add(a.throttleConfig.FailureWindow).After(now) {
+				failedAttempts = throttle.FailedAttempts
+			}
+			if throttle.IsLocked(now) {
+				lockedUntil = throttle.LockedUntil
+			}
+		}
+		return swagger.NewUserLockoutResponseBuilder().
+			FailedAttempts(failedAttempts).
+			Locked(lockedUntil != nil).
+			LockedUntil(lockedUntil).
+			Build(), nil
+	})
+}
+
+// UnlockUser clears the lockout and failed sign in attempts of a user
+func (a *AuthMgm) UnlockUser(ctx context.Context, principal *UserPrincipal, userID string) error {
+	katapp.Logger(ctx).Info("unlocking user sign in", "principal", principal.String(), "userID", userID)
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		user, err := internal.GetExistingUserById(ctx, a.authUserPersist, tx, userID)
+		if err != nil {
+			return err
+		}
+		if !principal.HasTenantPermission(model.PermissionUsersUpdate, user.TenantID) {
+			msg := "insufficient permissions to unlock user"
+			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
+			return katapp.NewErr(katapp.ErrNoPermissions, msg)
+		}
+		if err := a.signInThrottlePersist.ClearSignInThrottle(
+			ctx, tx, model.SignInThrottleSubjectUser, user.ID,
+		); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to unlock user")
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionUserUnlocked,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+		})
+	})
+}
+
+// checkSignInThrottle returns SignInThrottledError if the subject is currently locked out
+func (a *AuthMgm) checkSignInThrottle(ctx context.Context, tx pgx.Tx, subjectType string, subject string) error {
+	if subject == "" || a.maxSignInFailures(subjectType) <= 0 {
+		return nil
+	}
+	throttle, err := a.signInThrottlePersist.GetSignInThrottle(ctx, tx, subjectType, subject)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to check failed sign in attempts")
+	}
+	now := time.Now()
+	if throttle == nil || !throttle.IsLocked(now) {
+		return nil
+	}
+	katapp.Logger(ctx).Warn("rejected sign in of locked out subject",
+		"subjectType", subjectType, "subject", subject, "lockedUntil", throttle.LockedUntil)
+	return &SignInThrottledError{RetryAfter: throttle.LockedUntil.Sub(now)}
+}
+
+// recordSignInFailure counts a failed sign in attempt of the user (if known) and of the client IP, and locks
+// out the ones that reached their maximum. It runs in its own transaction, because the transaction of the
+// failed sign in is rolled back. Errors are logged only so that they do not mask the sign in error.
+func (a *AuthMgm) recordSignInFailure(ctx context.Context, userID string, clientIP string) {
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if err := a.recordSubjectFailure(ctx, tx, model.SignInThrottleSubjectUser, userID); err != nil {
+			return err
+		}
+		return a.recordSubjectFailure(ctx, tx, model.SignInThrottleSubjectIP, clientIP)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record failed sign in attempt",
+			"userID", userID, "clientIP", clientIP, "error", err)
+	}
+}
+
+func (a *AuthMgm) recordSubjectFailure(ctx context.Context, tx pgx.Tx, subjectType string, subject string) error {
+	maxFailures := a.maxSignInFailures(subjectType)
+	if subject == "" || maxFailures <= 0 {
+		return nil
+	}
+	failedAttempts, err := a.signInThrottlePersist.RecordSignInFailure(
+		ctx, tx, subjectType, subject, a.throttleConfig.FailureWindow,
+	)
+	if err != nil {
+		return err
+	}
+	if failedAttempts < maxFailures {
+		return nil
+	}
+	throttle, err := a.signInThrottlePersist.GetSignInThrottle(ctx, tx, subjectType, subject)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	lockoutCount := a.nextLockoutCount(throttle, now)
+	lockedUntil := now.Add(a.lockoutDuration(lockoutCount))
+	katapp.Logger(ctx).Warn("locking out sign in after too many failed attempts",
+		"subjectType", subjectType, "subject", subject, "failedAttempts", failedAttempts,
+		"lockoutCount", lockoutCount, "lockedUntil", lockedUntil)
+	return a.signInThrottlePersist.LockSignInSubject(ctx, tx, subjectType, subject, lockedUntil, lockoutCount)
+}
+
+// nextLockoutCount returns the number of consecutive lockouts including the one starting now. Lockouts are
+// consecutive while each starts within MaxLockoutDuration after the previous one ended.
+func (a *AuthMgm) nextLockoutCount(throttle *model.SignInThrottle, now time.Time) int {
+	if throttle == nil || throttle.LockedUntil == nil || a.throttleConfig.MaxLockoutDuration <= 0 {
+		return 1
+	}
+	if throttle.LockedUntil.Add(a.throttleConfig.MaxLockoutDuration).Before(now) {
+		return 1
+	}
+	return throttle.LockoutCount + 1
+}
+
+// lockoutDuration doubles LockoutDuration with every consecutive lockout, up to MaxLockoutDuration
+func (a *AuthMgm) lockoutDuration(lockoutCount int) time.Duration {
+	duration := a.throttleConfig.LockoutDuration
+	maxDuration := max(a.throttleConfig.MaxLockoutDuration, duration)
+	for i := 1; i < lockoutCount && duration < maxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, maxDuration)
+}
+
+// resetSignInFailures clears failed sign in attempts of a user after a successful sign in
+func (a *AuthMgm) resetSignInFailures(ctx context.Context, userID string) {
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		return a.signInThrottlePersist.ClearSignInThrottle(ctx, tx, model.SignInThrottleSubjectUser, userID)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to reset failed sign in attempts", "userID", userID, "error", err)
+	}
+}
+
+func (a *AuthMgm) maxSignInFailures(subjectType string) int {
+	if subjectType == model.SignInThrottleSubjectIP {
+		return a.throttleConfig.MaxIPFailures
+	}
+	return a.throttleConfig.MaxUserFailures
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
@@ -59,6 +60,11 @@ func (a *AuthMgm) SignUp(ctx context.Context, req *swagger.SignUpRequest) (*swag
 				katapp.Logger(ctx).Error("failed to delete existing unverified user", "userID", existingUser.ID, "error", err)
 				return nil, katapp.NewErr(katapp.ErrInternal, "failed to delete existing unverified user")
 			}
+			err = queueWebhookEvent(ctx, a.webhookPersist, tx, tenantID, model.WebhookEventUserDeleted,
+				webhookUserData(existingUser))
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// Create new user (either first time or replacing unverified user)
@@ -70,11 +76,15 @@ func (a *AuthMgm) SignUp(ctx context.Context, req *swagger.SignUpRequest) (*swag
 		}
 
 		// Assign default 'user' role to new user
-		err = a.authUserPersist.AssignUserRole(ctx, tx, user.ID, "user")
+		err = a.authUserPersist.AssignUserRole(ctx, tx, user.ID, model.RoleUser)
 		if err != nil {
 			katapp.Logger(ctx).Warn("failed to assign default role to user", "userID", user.ID, "error", err)
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to assign default role")
 		}
+		err = queueWebhookEvent(ctx, a.webhookPersist, tx, tenantID, model.WebhookEventUserCreated, webhookUserData(user))
+		if err != nil {
+			return nil, err
+		}
 
 		if existingUser != nil && !existingUser.EmailVerified {
 			katapp.Logger(ctx).Info("replaced existing unverified user", "oldUserID", existingUser.ID, "newUserID", user.ID, "email", string(req.Email))
@@ -83,27 +93,17 @@ func (a *AuthMgm) SignUp(ctx context.Context, req *swagger.SignUpRequest) (*swag
 		}
 
 		// Generate confirmation token/code and hash it
-		var tokenForEmail string
-		var tokenHash string
-
-		if req.Source == "web" {
-			// For web: generate long token
-			tokenForEmail, err = a.generateEmailConfirmationToken()
-			if err != nil {
-				katapp.Logger(ctx).Error("failed to generate email confirmation token", "userID", user.ID, "error", err)
-				return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate email confirmation token")
-			}
-			tokenHash = a.hashToken(user.ID, tokenForEmail)
-		} else {
-			// For mobile: generate 6-digit code
-			tokenForEmail = a.generateSixDigitCode()
-			tokenHash = a.hashToken(user.ID, tokenForEmail)
+		tokenForEmail, err := a.generateConfirmationCode(string(req.Source))
+		if err != nil {
+			katapp.Logger(ctx).Error("failed to generate email confirmation token", "userID", user.ID, "error", err)
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to generate email confirmation token")
 		}
+		tokenHash := a.hashToken(user.ID, tokenForEmail)
 
 		// Create email confirmation token in database (expires in 24 hours)
 		expiresAt := time.Now().Add(24 * time.Hour)
 		_, err = a.authUserPersist.CreateEmailConfirmationToken(
-			ctx, tx, user.ID, user.Email, tokenHash,
+			ctx, tx, user.ID, user.Email, model.EmailConfirmationPurposeSignUp, tokenHash,
 			string(req.Source),
 			expiresAt)
 		if err != nil {
@@ -116,9 +116,9 @@ func (a *AuthMgm) SignUp(ctx context.Context, req *swagger.SignUpRequest) (*swag
 		}
 
 		// Send confirmation email based on source
-		err = a.sendConfirmationEmail(ctx, user, tokenForEmail, string(req.Source))
+		err = a.queueConfirmationEmail(ctx, tx, user, tokenForEmail, string(req.Source))
 		if err != nil {
-			msg := "failed to send confirmation email"
+			msg := "failed to queue confirmation email"
 			katapp.Logger(ctx).Error(msg,
 				"userID", user.ID,
 				"source", req.Source,
@@ -126,7 +126,7 @@ func (a *AuthMgm) SignUp(ctx context.Context, req *swagger.SignUpRequest) (*swag
 			return nil, katapp.NewErr(katapp.ErrInternal, msg)
 		}
 
-		katapp.Logger(ctx).Info("email confirmation token created and email sent",
+		katapp.Logger(ctx).Info("email confirmation token created and email queued",
 			"userID", user.ID,
 			"source", req.Source)
 		return user, nil
@@ -176,7 +176,8 @@ func (a *AuthMgm) validateSignUpRequest(req *swagger.SignUpRequest) error {
 	return nil
 }
 
-// ConfirmEmail confirms a user's email address using the confirmation token/code
+// ConfirmEmail confirms a user's email address using the confirmation token/code. Wrong codes are counted and
+// the code is invalidated once the maximum of failed attempts is reached.
 func (a *AuthMgm) ConfirmEmail(ctx context.Context, userID string, code string) error {
 	katapp.Logger(ctx).Info("confirming email", "userID", userID)
 
@@ -184,29 +185,40 @@ func (a *AuthMgm) ConfirmEmail(ctx context.Context, userID string, code string)
 		return katapp.NewErr(katapp.ErrInvalidInput, "user ID and confirmation code are required")
 	}
 
-	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
-		// Hash the provided code with user ID for database lookup
-		codeHash := a.hashToken(userID, code)
+	// Token of a wrong code, the failed attempt is recorded after the transaction is rolled back
+	var wrongCodeTokenID string
 
-		// Get the confirmation token by user ID and hash
-		confirmationToken, err := a.authUserPersist.GetEmailConfirmationTokenByUserIDAndHash(ctx, tx, userID, codeHash)
+	err := a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		confirmationToken, err := a.authUserPersist.GetEmailConfirmationTokenByUserID(ctx, tx, userID)
 		if err != nil {
 			katapp.Logger(ctx).Error("failed to get email confirmation token", "userID", userID, "error", err)
 			return katapp.NewErr(katapp.ErrInternal, "failed to get confirmation token")
 		}
 
-		if confirmationToken == nil {
-			return katapp.NewErr(katapp.ErrNotFound, "invalid confirmation code")
+		// Codes of email changes are confirmed by ConfirmEmailChange only
+		if confirmationToken == nil || confirmationToken.Purpose != model.EmailConfirmationPurposeSignUp {
+			return newEmailConfirmationError(EmailConfirmationErrCodeInvalid, katapp.ErrNotFound, "invalid confirmation code")
+		}
+		if a.confirmationAttemptsExhausted(confirmationToken) {
+			return newEmailConfirmationError(EmailConfirmationErrCodeInvalidated, katapp.ErrInvalidInput,
+				"too many failed attempts, request a new confirmation code")
+		}
+
+		// Compare hashes of the provided code and the code sent in constant time
+		codeHash := a.hashToken(userID, code)
+		if subtle.ConstantTimeCompare([]byte(codeHash), []byte(confirmationToken.TokenHash)) != 1 {
+			wrongCodeTokenID = confirmationToken.ID
+			return newEmailConfirmationError(EmailConfirmationErrCodeInvalid, katapp.ErrNotFound, "invalid confirmation code")
 		}
 
 		// Check if token is valid
-		if !confirmationToken.IsValid() {
-			if confirmationToken.IsExpired() {
-				return katapp.NewErr(katapp.ErrInvalidInput, "confirmation token has expired")
-			}
-			if confirmationToken.IsUsed() {
-				return katapp.NewErr(katapp.ErrInvalidInput, "confirmation token has already been used")
-			}
+		if confirmationToken.IsExpired() {
+			return newEmailConfirmationError(EmailConfirmationErrCodeExpired, katapp.ErrInvalidInput,
+				"confirmation token has expired")
+		}
+		if confirmationToken.IsUsed() {
+			return newEmailConfirmationError(EmailConfirmationErrCodeUsed, katapp.ErrInvalidInput,
+				"confirmation token has already been used")
 		}
 
 		// Mark token as used
@@ -222,30 +234,43 @@ func (a *AuthMgm) ConfirmEmail(ctx context.Context, userID string, code string)
 			katapp.Logger(ctx).Error("failed to set user email as verified", "userID", confirmationToken.UserID, "error", err)
 			return katapp.NewErr(katapp.ErrInternal, "failed to verify email")
 		}
+		user, err := a.authUserPersist.GetUserByID(ctx, tx, confirmationToken.UserID)
+		if err != nil || user == nil {
+			katapp.Logger(ctx).Error("failed to get confirmed user", "userID", confirmationToken.UserID, "error", err)
+			return katapp.NewErr(katapp.ErrInternal, "failed to get confirmed user")
+		}
+		err = queueWebhookEvent(ctx, a.webhookPersist, tx, user.TenantID, model.WebhookEventUserEmailVerified,
+			webhookUserData(user))
+		if err != nil {
+			return err
+		}
 
 		katapp.Logger(ctx).Info("email confirmed successfully", "userID", confirmationToken.UserID)
 		return nil
 	})
 
+	if wrongCodeTokenID != "" {
+		return a.recordEmailConfirmationFailure(ctx, wrongCodeTokenID, err)
+	}
 	return err
 }
 
 // Email confirmation helper methods
 
-// sendConfirmationEmail sends platform-specific confirmation emails
-func (a *AuthMgm) sendConfirmationEmail(ctx context.Context, user *model.AuthUser, token string, source string) error {
+// queueConfirmationEmail queues platform-specific confirmation emails
+func (a *AuthMgm) queueConfirmationEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, token string, source string) error {
 	switch source {
 	case "web":
-		return a.sendWebConfirmationEmail(ctx, user, token)
+		return a.queueWebConfirmationEmail(ctx, tx, user, token)
 	case "android", "ios":
-		return a.sendMobileConfirmationEmail(ctx, user, token, source)
+		return a.queueMobileConfirmationEmail(ctx, tx, user, token, source)
 	default:
 		return katapp.NewErr(katapp.ErrInvalidInput, "invalid source platform")
 	}
 }
 
-// sendWebConfirmationEmail sends a clickable confirmation link for web users
-func (a *AuthMgm) sendWebConfirmationEmail(ctx context.Context, user *model.AuthUser, token string) error {
+// queueWebConfirmationEmail queues a clickable confirmation link for web users
+func (a *AuthMgm) queueWebConfirmationEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, token string) error {
 	baseURL := a.serverConfig.Domain
 	confirmationURL := fmt.Sprintf("%s/web/user/auth/confirm-email?userId=%s&code=%s", baseURL, user.ID, token)
 
@@ -261,26 +286,33 @@ func (a *AuthMgm) sendWebConfirmationEmail(ctx context.Context, user *model.Auth
 	if err != nil {
 		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
 	}
+	text, err := email.WebConfirmationText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
 
 	// Create mail content
 	mailContent := outport.NewMailContentBuilder().
-		ContentType("text/html").
 		Title("Confirm Your Email Address - IAMService").
-		Body(buf.String()).
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
 		Build()
 
-	// Send email
-	err = a.mailer.SendEmail(ctx, user.Email, mailContent)
+	// Queue email, it is sent once the transaction commits
+	err = queueEmail(ctx, a.outboxPersist, tx, user.Email, mailContent)
 	if err != nil {
-		return katapp.NewErr(katapp.ErrInternal, "failed to send confirmation email")
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue confirmation email")
 	}
 
-	katapp.Logger(ctx).Info("web confirmation email sent", "userID", user.ID, "email", user.Email)
+	katapp.Logger(ctx).Info("web confirmation email queued", "userID", user.ID, "email", user.Email)
 	return nil
 }
 
-// sendMobileConfirmationEmail sends a 6-digit confirmation code for mobile users
-func (a *AuthMgm) sendMobileConfirmationEmail(ctx context.Context, user *model.AuthUser, confirmationCode string, platform string) error {
+// queueMobileConfirmationEmail queues a 6-digit confirmation code for mobile users
+func (a *AuthMgm) queueMobileConfirmationEmail(ctx context.Context, tx pgx.Tx, user *model.AuthUser, confirmationCode string, platform string) error {
 	// Use the confirmation code that was already generated and stored in the database
 
 	data := &email.MobileConfirmationData{
@@ -296,24 +328,39 @@ func (a *AuthMgm) sendMobileConfirmationEmail(ctx context.Context, user *model.A
 	if err != nil {
 		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
 	}
+	text, err := email.MobileConfirmationText(data)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to render email template")
+	}
 
 	// Create mail content
 	mailContent := outport.NewMailContentBuilder().
-		ContentType("text/html").
 		Title(fmt.Sprintf("Your Confirmation Code - IAMService (%s)", strings.Title(platform))).
-		Body(buf.String()).
+		HTMLBody(buf.String()).
+		TextBody(text).
+		ReplyTo(nil).
+		Headers(nil).
+		Attachments(nil).
 		Build()
 
-	// Send email
-	err = a.mailer.SendEmail(ctx, user.Email, mailContent)
+	// Queue email, it is sent once the transaction commits
+	err = queueEmail(ctx, a.outboxPersist, tx, user.Email, mailContent)
 	if err != nil {
-		return katapp.NewErr(katapp.ErrInternal, "failed to send confirmation email")
+		return katapp.NewErr(katapp.ErrInternal, "failed to queue confirmation email")
 	}
 
-	katapp.Logger(ctx).Info("mobile confirmation email sent", "userID", user.ID, "email", user.Email, "platform", platform, "code", confirmationCode)
+	katapp.Logger(ctx).Info("mobile confirmation email queued", "userID", user.ID, "email", user.Email, "platform", platform, "code", confirmationCode)
 	return nil
 }
 
+// generateConfirmationCode generates a long token for web or a 6-digit code for mobile platforms
+func (a *AuthMgm) generateConfirmationCode(source string) (string, error) {
+	if source == "web" {
+		return a.generateEmailConfirmationToken()
+	}
+	return a.generateSixDigitCode(), nil
+}
+
 // generateSixDigitCode generates a random 6-digit confirmation code
 func (a *AuthMgm) generateSixDigitCode() string {
 	b := make([]byte, 3) // 3 bytes = 24 bits, enough for 6 digits
@@ -7,46 +7,70 @@ import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
-	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
 	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+	"strings"
+	"time"
 )
 
-// GetAllTenants returns all tenants
-func (a *AuthMgm) GetAllTenants(ctx context.Context, principal *UserPrincipal) (*swagger.TenantsResponse, error) {
-	katapp.Logger(ctx).Debug("getting all tenants",
+// tenantSortFields are the fields tenants can be sorted by, the first one is the default
+var tenantSortFields = []string{model.TenantSortCreatedAt, model.TenantSortName}
+
+// ListAllTenants returns a filtered and sorted page of tenants. Principals with tenants:read permission in all
+// tenants see every tenant, other principals their own tenant only.
+func (a *AuthMgm) ListAllTenants(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListAllTenantsParams,
+) (*swagger.TenantsResponse, error) {
+	katapp.Logger(ctx).Debug("listing tenants",
 		"principal", principal.String(),
 	)
 
-	tenants, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) ([]*model.Tenant, error) {
-		return a.authUserPersist.GetAllTenants(ctx, tx)
-	})
+	pageRequest, page, err := newPageRequest(params.Page, params.Limit, params.Cursor,
+		string(lo.FromPtr(params.SortBy)), params.SortOrder, tenantSortFields)
 	if err != nil {
-		katapp.Logger(ctx).Error("failed to get all tenants", "error", err)
 		return nil, err
 	}
+	var tenantID *string
+	if !principal.HasPermissionInAllTenants(model.PermissionTenantsRead) {
+		tenantID = &principal.TenantID
+	}
+	filter := model.NewTenantFilterBuilder().
+		TenantID(tenantID).
+		Search(lo.EmptyableToPtr(strings.TrimSpace(lo.FromPtr(params.Search)))).
+		Build()
 
-	filteredTenants := make([]*model.Tenant, 0)
-	for _, tenant := range tenants {
-		if principal.CanReadTenant(tenant.ID) {
-			filteredTenants = append(filteredTenants, tenant)
-		}
+	var tenants []*model.Tenant
+	var total int
+	err = a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		var err error
+		tenants, total, err = a.authUserPersist.ListTenants(ctx, tx, filter, pageRequest)
+		return err
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to list tenants", "error", err)
+		return nil, err
 	}
 
-	tenantResponses := make([]swagger.TenantResponse, len(filteredTenants))
-	for i, tenant := range filteredTenants {
+	tenants, nextCursor := pageItems(tenants, pageRequest, func(tenant *model.Tenant) model.PageCursor {
+		return model.PageCursor{Value: tenantSortValue(tenant, pageRequest.SortBy), ID: tenant.ID}
+	})
+	tenantResponses := make([]swagger.TenantResponse, len(tenants))
+	for i, tenant := range tenants {
 		tenantResponses[i] = *tenantModelToTenantResponse(tenant)
 	}
-
-	// Pagination (we hardcode for now)
-	page := 1
-	limit := 20
-	tenants, pagination := internal.Paginate(filteredTenants, page, limit)
-	response := swagger.NewTenantsResponseBuilder().
+	return swagger.NewTenantsResponseBuilder().
 		Items(tenantResponses).
-		Pagination(*pagination).
-		Build()
+		NextCursor(nextCursor).
+		Pagination(*paginationInfo(page, pageRequest, total)).
+		Build(), nil
+}
 
-	return response, nil
+// tenantSortValue returns the value the tenant is sorted by in a listing
+func tenantSortValue(tenant *model.Tenant, sortBy string) string {
+	if sortBy == model.TenantSortName {
+		return tenant.Name
+	}
+	return tenant.CreatedAt.Format(time.RFC3339Nano)
 }
 
 // GetTenantByID returns a tenant by ID
@@ -81,7 +105,7 @@ func (a *AuthMgm) GetTenantByID(
 	return tenantModelToTenantResponse(tenant), nil
 }
 
-// CreateTenant creates a new tenant (sysadmin only)
+// CreateTenant creates a new tenant (tenants:create permission only)
 func (a *AuthMgm) CreateTenant(
 	ctx context.Context, principal *UserPrincipal, req *swagger.CreateTenantRequest,
 ) (*swagger.TenantResponse, error) {
@@ -90,7 +114,7 @@ func (a *AuthMgm) CreateTenant(
 		"tenantID", req.Id,
 		"name", req.Name)
 
-	if !principal.IsSysAdmin() {
+	if !principal.HasPermission(model.PermissionTenantsCreate) {
 		panic("insufficient permissions to create tenant, must be handled on API level")
 	}
 
@@ -120,7 +144,21 @@ func (a *AuthMgm) CreateTenant(
 		if existingTenant != nil {
 			return nil, katapp.NewErr(katapp.ErrDuplicate, "tenant with this ID already exists")
 		}
-		return a.authUserPersist.CreateTenant(ctx, tx, req)
+		tenant, err := a.authUserPersist.CreateTenant(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+		err = recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionTenantCreated,
+			principal:  principal,
+			tenantID:   tenant.ID,
+			targetType: model.AuditTargetTenant,
+			targetID:   tenant.ID,
+			diff: auditDiff{}.
+				created("name", tenant.Name).
+				created("description", tenant.Description),
+		})
+		return tenant, err
 	})
 	if err != nil {
 		return nil, err
@@ -146,7 +184,7 @@ func (a *AuthMgm) UpdateTenant(
 		return nil, katapp.NewErr(katapp.ErrInvalidInput, "tenant name is required")
 	}
 
-	if !principal.CanManageTenant(tenantID) {
+	if !principal.HasTenantPermission(model.PermissionTenantsUpdate, tenantID) {
 		msg := "insufficient permissions to update tenant"
 		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
 		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -171,7 +209,27 @@ func (a *AuthMgm) UpdateTenant(
 		tenant, err := a.authUserPersist.UpdateTenant(ctx, tx, tenantID, req)
 		if err != nil {
 			katapp.Logger(ctx).Error("failed to update tenant", "tenantID", tenantID, "error", err)
+			return nil, err
 		}
+		err = recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionTenantUpdated,
+			principal:  principal,
+			tenantID:   tenant.ID,
+			targetType: model.AuditTargetTenant,
+			targetID:   tenant.ID,
+			diff: auditDiff{}.
+				changed("name", existingTenant.Name, tenant.Name).
+				changed("description", existingTenant.Description, tenant.Description).
+				changed("requireAdminMfa", existingTenant.RequireAdminMFA, tenant.RequireAdminMFA),
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = queueWebhookEvent(ctx, a.webhookPersist, tx, tenant.ID, model.WebhookEventTenantUpdated, map[string]any{
+			"id":          tenant.ID,
+			"name":        tenant.Name,
+			"description": tenant.Description,
+		})
 		return tenant, err
 	})
 	if err != nil {
@@ -194,7 +252,7 @@ func (a *AuthMgm) DeleteTenant(ctx context.Context, principal *UserPrincipal, te
 		return katapp.NewErr(katapp.ErrInvalidInput, "tenant ID is required")
 	}
 
-	if !principal.CanManageTenant(tenantID) {
+	if !principal.HasTenantPermission(model.PermissionTenantsDelete, tenantID) {
 		msg := "insufficient permissions to delete tenant"
 		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
 		return katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -216,16 +274,42 @@ func (a *AuthMgm) DeleteTenant(ctx context.Context, principal *UserPrincipal, te
 		}
 
 		// Check if tenant has users
-		users, err := a.authUserPersist.GetAllUsersByTenantID(ctx, tx, tenantID)
+		usersFilter := model.NewUserFilterBuilder().
+			TenantID(&tenantID).
+			UserID(nil).
+			Email(nil).
+			Search(nil).
+			Role(nil).
+			EmailVerified(nil).
+			IsActive(nil).
+			Build()
+		firstUser := model.NewPageRequestBuilder().
+			SortBy(model.UserSortCreatedAt).
+			SortDesc(false).
+			After(nil).
+			Offset(0).
+			Limit(1).
+			Build()
+		_, usersCount, err := a.authUserPersist.ListUsers(ctx, tx, usersFilter, firstUser)
 		if err != nil {
 			return katapp.NewErr(katapp.ErrInternal, "failed to check tenant users")
 		}
-		if len(users) > 0 {
+		if usersCount > 0 {
 			return katapp.NewErr(katapp.ErrInvalidInput, "cannot delete tenant with existing users")
 		}
 
 		// Delete the tenant
-		return a.authUserPersist.DeleteTenant(ctx, tx, tenantID)
+		if err := a.authUserPersist.DeleteTenant(ctx, tx, tenantID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:     model.AuditActionTenantDeleted,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetTenant,
+			targetID:   tenantID,
+			diff:       auditDiff{}.deleted("name", existingTenant.Name),
+		})
 	})
 	if err != nil {
 		return err
@@ -238,10 +322,11 @@ func (a *AuthMgm) DeleteTenant(ctx context.Context, principal *UserPrincipal, te
 // tenantModelToTenantResponse converts model.Tenant to swagger.TenantResponse
 func tenantModelToTenantResponse(tenant *model.Tenant) *swagger.TenantResponse {
 	return &swagger.TenantResponse{
-		Id:          tenant.ID,
-		Name:        tenant.Name,
-		Description: tenant.Description,
-		CreatedAt:   tenant.CreatedAt,
-		UpdatedAt:   tenant.UpdatedAt,
+		Id:              tenant.ID,
+		Name:            tenant.Name,
+		Description:     tenant.Description,
+		RequireAdminMfa: tenant.RequireAdminMFA,
+		CreatedAt:       tenant.CreatedAt,
+		UpdatedAt:       tenant.UpdatedAt,
 	}
 }
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// accessTokenTTL is the lifetime of access tokens. Revocations are kept until the tokens they cover expire.
+const accessTokenTTL = time.Hour
+
+// accessTokenClaims are the claims of access tokens issued to users by generateJWTTokenInFamilyWithTx
+// and to service clients by ServiceClientMgm.IssueToken
+type accessTokenClaims struct {
+	Type          string   `json:"type"`
+	PrincipalType string   `json:"principalType"`
+	Roles         []string `json:"roles"`
+	Permissions   []string `json:"permissions"`
+	TenantID      string   `json:"tenantId"`
+	SessionID     string   `json:"sid"`
+	ClientID      string   `json:"client_id"`
+	Scope         string   `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// isServiceClient checks if the token was issued to a service client
+func (c *accessTokenClaims) isServiceClient() bool {
+	return c.PrincipalType == PrincipalTypeServiceClient
+}
+
+// parseAccessToken verifies the signature and expiration of an access token and returns its claims.
+// Revocation is not checked.
+func (a *AuthMgm) parseAccessToken(tokenString string) (*accessTokenClaims, error) {
+	claims := new(accessTokenClaims)
+	token, err := jwt.ParseWithClaims(tokenString, claims, a.jwtKeys.Keyfunc, jwt.WithIssuedAt())
+	if err != nil || !token.Valid {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid token")
+	}
+	if claims.Type != "access" {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid token type")
+	}
+	if claims.Subject == "" || claims.IssuedAt == nil {
+		return nil, katapp.NewErr(katapp.ErrUnauthorized, "invalid token claims")
+	}
+	return claims, nil
+}
+
+// CheckAccessTokenNotRevoked returns katapp.ErrUnauthorized if the access token with the given ID, session
+// and subject (user or service client) issued at issuedAt is revoked. Tokens issued before access tokens
+// carried an ID or a session are only checked against subject and all tokens revocations.
+func (a *AuthMgm) CheckAccessTokenNotRevoked(
+	ctx context.Context, tokenID string, sessionID string, subject string, issuedAt time.Time,
+) error {
+	revoked, err := outport.TxWithResult(ctx, a.txPort, func(tx pgx.Tx) (bool, error) {
+		return a.tokenRevocationPersist.IsAccessTokenRevoked(ctx, tx, tokenID, sessionID, subject, issuedAt)
+	})
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to check access token revocation")
+	}
+	if revoked {
+		katapp.Logger(ctx).Warn("revoked access token presented", "subject", subject, "jti", tokenID)
+		return katapp.NewErr(katapp.ErrUnauthorized, "access token has been revoked")
+	}
+	return nil
+}
+
+// RevokeAllAccessTokens rejects access tokens of all users issued before the given time, now if nil.
+// Refresh tokens stay valid, so clients can obtain new access tokens (tokens:revoke permission only).
+func (a *AuthMgm) RevokeAllAccessTokens(
+	ctx context.Context, principal *UserPrincipal, req *swagger.AccessTokenRevocationRequest,
+) error {
+	katapp.Logger(ctx).Info("revoking all access tokens", "principal", principal.String())
+
+	if !principal.HasPermission(model.PermissionTokensRevoke) {
+		msg := "insufficient permissions to revoke all access tokens"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String())
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	now := time.Now()
+	issuedBefore := lo.FromPtrOr(req.IssuedBefore, now)
+	if issuedBefore.After(now) {
+		return katapp.NewErr(katapp.ErrInvalidInput, "issuedBefore cannot be in the future")
+	}
+
+	return a.txPort.Run(ctx, func(tx pgx.Tx) error {
+		err := revokeAccessTokensIssuedBefore(
+			ctx, a.tokenRevocationPersist, tx, model.AccessTokenRevocationAll, "", issuedBefore.Truncate(time.Second),
+		)
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, a.auditPersist, tx, auditEntry{
+			action:    model.AuditActionAccessTokensRevoked,
+			principal: principal,
+			diff:      auditDiff{}.created("issued_before", issuedBefore.UTC().Format(time.RFC3339)),
+		})
+	})
+}
+
+// revokeAccessToken rejects the access token with the given ID
+func revokeAccessToken(
+	ctx context.Context, persist outport.TokenRevocationPersist, tx pgx.Tx, tokenID string,
+) error {
+	// The token expires within accessTokenTTL, whenever it was issued
+	expiresAt := time.Now().Add(accessTokenTTL)
+	return recordAccessTokenRevocation(ctx, persist, tx, model.AccessTokenRevocationJTI, tokenID, expiresAt, expiresAt)
+}
+
+// revokeAccessTokens rejects access tokens of a session, a user or a service client issued so far. Issue times of tokens have
+// a precision of a second. Tokens issued within the second of the revocation stay valid, so that tokens
+// issued right after it (after a role change or when the user signs in again) are not rejected.
+func revokeAccessTokens(
+	ctx context.Context, persist outport.TokenRevocationPersist, tx pgx.Tx, subjectType string, subject string,
+) error {
+	return revokeAccessTokensIssuedBefore(ctx, persist, tx, subjectType, subject, time.Now().Truncate(time.Second))
+}
+
+func revokeAccessTokensIssuedBefore(
+	ctx context.Context, persist outport.TokenRevocationPersist, tx pgx.Tx,
+	subjectType string, subject string, issuedBefore time.Time,
+) error {
+	return recordAccessTokenRevocation(
+		ctx, persist, tx, subjectType, subject, issuedBefore, issuedBefore.Add(accessTokenTTL),
+	)
+}
+
+// recordAccessTokenRevocation records a revocation and drops the ones whose tokens have all expired
+func recordAccessTokenRevocation(
+	ctx context.Context, persist outport.TokenRevocationPersist, tx pgx.Tx,
+	subjectType string, subject string, issuedBefore time.Time, expiresAt time.Time,
+) error {
+	rowsAffected, err := persist.DeleteExpiredAccessTokenRevocations(ctx, tx)
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to delete expired access token revocations")
+	}
+	katapp.Logger(ctx).Debug("deleted expired access token revocations", "rowsAffected", rowsAffected)
+
+	revocation := model.NewAccessTokenRevocationBuilder().
+		SubjectType(subjectType).
+		Subject(subject).
+		IssuedBefore(issuedBefore).
+		ExpiresAt(expiresAt).
+		CreatedAt(time.Now()).
+		Build()
+	if err := persist.RevokeAccessTokens(ctx, tx, revocation); err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to revoke access tokens")
+	}
+	return nil
+}
@@ -6,19 +6,66 @@ import (
 )
 
 type UseCases struct {
-	Config         *app.Config
-	Auth           *AuthMgm
-	UserMgm        *UserMgm
-	UserProfileMgm *UserProfileMgm
+	Config           *app.Config
+	JWTKeys          *JWTKeySet
+	Auth             *AuthMgm
+	OIDC             *OIDCMgm
+	Federation       *FederationMgm
+	UserMgm          *UserMgm
+	UserProfileMgm   *UserProfileMgm
+	AuditMgm         *AuditMgm
+	ServiceClientMgm *ServiceClientMgm
+	RoleMgm          *RoleMgm
+	InvitationMgm    *InvitationMgm
+	OutboxMgm        *OutboxMgm
+	ScimMgm          *ScimMgm
+	WebhookMgm       *WebhookMgm
 }
 
 func NewUseCases(cfg *app.Config, ports *outport.Ports) *UseCases {
+	jwtKeys := MustNewJWTKeySet(&cfg.Credentials)
+	authMgm := NewAuthUser(
+		&cfg.Server, &cfg.SignInThrottle, &cfg.EmailConfirmation, ports.AuthUserPersist, ports.MFAPersist,
+		ports.SignInThrottlePersist, ports.AuditPersist, ports.TokenRevocationPersist, ports.APIKeyPersist, ports.RolePersist,
+		ports.OutboxPersist, ports.WebhookPersist, ports.Tx, jwtKeys,
+	)
+	roleMgm := NewRoleMgm(
+		ports.RolePersist, ports.AuthUserPersist, ports.AuditPersist, ports.TokenRevocationPersist, ports.Tx,
+	)
 	return &UseCases{
-		Config: cfg,
-		Auth: NewAuthUser(
-			&cfg.Server, ports.AuthUserPersist, ports.Tx, ports.Mailer, cfg.Credentials.JwtSecret,
+		Config:  cfg,
+		JWTKeys: jwtKeys,
+		Auth:    authMgm,
+		OIDC: NewOIDCMgm(
+			&cfg.Server, authMgm, ports.OAuthPersist, ports.AuthUserPersist, ports.ServiceClientPersist,
+			ports.AuditPersist, ports.TokenRevocationPersist, ports.Tx, jwtKeys,
 		),
-		UserMgm:        NewUserMgm(ports.AuthUserPersist, ports.Tx),
+		Federation: NewFederationMgm(
+			&cfg.Server, cfg.IdentityProviders, authMgm, ports.AuthUserPersist, ports.Federation, ports.Tx, jwtKeys,
+		),
+		UserMgm: NewUserMgm(
+			ports.AuthUserPersist, ports.RolePersist, ports.AuditPersist, ports.TokenRevocationPersist,
+			ports.WebhookPersist, ports.Tx,
+		),
+		AuditMgm:       NewAuditMgm(ports.AuditPersist, ports.Tx),
 		UserProfileMgm: NewUserProfileMgm(ports),
+		ServiceClientMgm: NewServiceClientMgm(
+			ports.ServiceClientPersist, ports.AuthUserPersist, ports.RolePersist, ports.AuditPersist,
+			ports.TokenRevocationPersist, ports.Tx, jwtKeys,
+		),
+		RoleMgm: roleMgm,
+		InvitationMgm: NewInvitationMgm(
+			&cfg.Server, ports.InvitationPersist, ports.AuthUserPersist, ports.RolePersist, ports.AuditPersist,
+			ports.OutboxPersist, ports.WebhookPersist, ports.Tx, jwtKeys,
+		),
+		OutboxMgm: NewOutboxMgm(&cfg.Outbox, ports.OutboxPersist, ports.AuditPersist, ports.Tx, ports.Mailer),
+		ScimMgm: NewScimMgm(
+			&cfg.Server, roleMgm, ports.AuthUserPersist, ports.RolePersist, ports.AuditPersist,
+			ports.TokenRevocationPersist, ports.WebhookPersist, ports.OutboxPersist, ports.Tx,
+		),
+		WebhookMgm: NewWebhookMgm(
+			&cfg.Webhook, ports.WebhookPersist, ports.AuthUserPersist, ports.AuditPersist, ports.Tx,
+			ports.WebhookClient,
+		),
 	}
 }
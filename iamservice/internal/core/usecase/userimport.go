@@ -0,0 +1,543 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+// MaxUserImportSize is the maximal size of imported data in bytes, adapters reject larger uploads
+const MaxUserImportSize = 16 << 20
+
+const (
+	// maxUserImportRecords limits the number of users imported at once, larger imports are split by the caller
+	maxUserImportRecords = 10000
+	// maxUserImportLineSize limits the length of an NDJSON line
+	maxUserImportLineSize = 64 * 1024
+	// userImportBatchSize is the number of users created in one transaction
+	userImportBatchSize = 100
+	// userExportPageSize is the number of users read from the database at once while exporting
+	userExportPageSize = 500
+)
+
+// userTransferColumns are the CSV columns of imported and exported users
+var userTransferColumns = []string{"email", "firstName", "lastName", "roles", "emailVerified"}
+
+// userImportRow is an imported record and the line it starts on
+type userImportRow struct {
+	line   int
+	record swagger.UserImportRecord
+	roles  []string // roles besides the user role
+}
+
+// ImportUsers reads users in the given format and validates all of them, then creates the valid ones in the tenant
+// in batches unless it is a dry run. Invalid records are reported per line instead of failing the import.
+func (u *UserMgm) ImportUsers(
+	ctx context.Context, principal *UserPrincipal, tenantID string, format swagger.UserTransferFormat,
+	data io.Reader, params *swagger.ImportUsersParams,
+) (*swagger.UserImportResponse, error) {
+	dryRun := lo.FromPtr(params.DryRun)
+	katapp.Logger(ctx).Info("importing users",
+		"principal", principal.String(), "tenantID", tenantID, "format", format, "dryRun", dryRun)
+
+	if !principal.HasTenantPermission(model.PermissionUsersCreate, tenantID) {
+		msg := "insufficient permissions to import users"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+
+	var rows []userImportRow
+	var rowErrors []swagger.UserImportRowError
+	var err error
+	switch format {
+	case swagger.UserTransferFormatCsv:
+		rows, rowErrors, err = readUserImportCsv(data)
+	case swagger.UserTransferFormatNdjson:
+		rows, rowErrors, err = readUserImportNdjson(data)
+	default:
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "unsupported import format, csv or ndjson expected")
+	}
+	if err != nil {
+		return nil, err
+	}
+	total := len(rows) + len(rowErrors)
+
+	var validRows []userImportRow
+	var validationErrors []swagger.UserImportRowError
+	err = u.txPort.Run(ctx, func(tx pgx.Tx) error {
+		validRows, validationErrors, err = u.validateUserImportRows(ctx, tx, principal, tenantID, rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	rowErrors = append(rowErrors, validationErrors...)
+
+	imported := 0
+	if !dryRun && len(validRows) > 0 {
+		// Users are never signed in with this password, it only fills the password of users who did not set one
+		// yet. Hashing it once keeps large imports fast.
+		b := make([]byte, 32)
+		_, _ = rand.Read(b)
+		passwordHash, err := internal.HashPassword(fmt.Sprintf("%x", b))
+		if err != nil {
+			return nil, katapp.NewErr(katapp.ErrInternal, "failed to hash password")
+		}
+		for batch := range slices.Chunk(validRows, userImportBatchSize) {
+			batchImported, batchErrors := u.importUserBatch(ctx, principal, tenantID, passwordHash, batch)
+			imported += batchImported
+			rowErrors = append(rowErrors, batchErrors...)
+		}
+	}
+	slices.SortStableFunc(rowErrors, func(a, b swagger.UserImportRowError) int {
+		return a.Line - b.Line
+	})
+
+	katapp.Logger(ctx).Info("users imported",
+		"tenantID", tenantID, "dryRun", dryRun, "total", total, "valid", len(validRows), "imported", imported)
+	return swagger.NewUserImportResponseBuilder().
+		DryRun(dryRun).
+		Errors(rowErrors).
+		Imported(imported).
+		Total(total).
+		Valid(len(validRows)).
+		Build(), nil
+}
+
+// ExportUsers writes all users of the tenant ordered by email in the given format, reading them page by page.
+// Nothing is written if the principal cannot read users of the tenant.
+func (u *UserMgm) ExportUsers(
+	ctx context.Context, principal *UserPrincipal, tenantID string, params *swagger.ExportUsersParams, w io.Writer,
+) error {
+	format := lo.FromPtrOr(params.Format, swagger.UserTransferFormatCsv)
+	katapp.Logger(ctx).Info("exporting users", "principal", principal.String(), "tenantID", tenantID, "format", format)
+
+	var encoder userRecordEncoder
+	switch format {
+	case swagger.UserTransferFormatCsv:
+		encoder = &csvUserRecordEncoder{writer: csv.NewWriter(w)}
+	case swagger.UserTransferFormatNdjson:
+		encoder = &ndjsonUserRecordEncoder{encoder: json.NewEncoder(w)}
+	default:
+		return katapp.NewErr(katapp.ErrInvalidInput, "unsupported export format, csv or ndjson expected")
+	}
+	if !principal.CanListUsersForTenant(tenantID) {
+		msg := "insufficient permissions to export users"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	if err := u.txPort.Run(ctx, func(tx pgx.Tx) error {
+		return internal.EnsureTenantExistsById(ctx, u.authUserPort, tx, tenantID)
+	}); err != nil {
+		return err
+	}
+
+	exported := 0
+	var after *model.PageCursor
+	for {
+		pageRequest := model.NewPageRequestBuilder().
+			SortBy(model.UserSortEmail).
+			SortDesc(false).
+			After(after).
+			Offset(0).
+			Limit(userExportPageSize).
+			Build()
+		var roles map[string][]string
+		users, err := outport.TxWithResult(ctx, u.txPort, func(tx pgx.Tx) ([]*model.AuthUser, error) {
+			filter := model.NewUserFilterBuilder().
+				TenantID(&tenantID).
+				UserID(nil).
+				Email(nil).
+				Search(nil).
+				Role(nil).
+				EmailVerified(nil).
+				IsActive(nil).
+				Build()
+			users, _, err := u.authUserPort.ListUsers(ctx, tx, filter, pageRequest)
+			if err != nil || len(users) == 0 {
+				return users, err
+			}
+			roles, err = u.authUserPort.GetRolesOfUsers(ctx, tx, lo.Map(users, func(user *model.AuthUser, _ int) string {
+				return user.ID
+			}))
+			return users, err
+		})
+		if err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to list users")
+		}
+
+		for _, user := range users {
+			record := swagger.NewUserImportRecordBuilder().
+				Email(user.Email).
+				EmailVerified(&user.EmailVerified).
+				FirstName(user.FirstName).
+				LastName(user.LastName).
+				Roles(lo.ToPtr(lo.Without(roles[user.ID], model.RoleUser))).
+				Build()
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to write exported users: %w", err)
+			}
+		}
+		if err := encoder.Flush(); err != nil {
+			return fmt.Errorf("failed to write exported users: %w", err)
+		}
+		// Send every page to the client as soon as it is read
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+		exported += len(users)
+
+		if len(users) < userExportPageSize {
+			break
+		}
+		last := users[len(users)-1]
+		after = &model.PageCursor{Value: last.Email, ID: last.ID}
+	}
+
+	katapp.Logger(ctx).Info("users exported", "tenantID", tenantID, "users", exported)
+	return nil
+}
+
+// validateUserImportRows checks all imported records against each other and the users and roles of the tenant,
+// returns the valid rows with normalized values and an error of every invalid row
+func (u *UserMgm) validateUserImportRows(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, tenantID string, rows []userImportRow,
+) ([]userImportRow, []swagger.UserImportRowError, error) {
+	if err := internal.EnsureTenantExistsById(ctx, u.authUserPort, tx, tenantID); err != nil {
+		return nil, nil, err
+	}
+
+	canAssignRoles := principal.HasTenantPermission(model.PermissionUsersAssignRoles, tenantID)
+	roleErrors := make(map[string]error)
+	lineOfEmail := make(map[string]int)
+	var validRows []userImportRow
+	var rowErrors []swagger.UserImportRowError
+	for _, row := range rows {
+		row.record.Email = strings.TrimSpace(row.record.Email)
+		row.record.FirstName = strings.TrimSpace(row.record.FirstName)
+		row.record.LastName = strings.TrimSpace(row.record.LastName)
+		row.roles = lo.Without(lo.Uniq(lo.Compact(lo.Map(lo.FromPtr(row.record.Roles), func(role string, _ int) string {
+			return strings.TrimSpace(role)
+		}))), model.RoleUser)
+
+		msg := ""
+		switch {
+		case row.record.Email == "":
+			msg = "email is required"
+		case !strings.Contains(row.record.Email, "@"):
+			msg = "invalid email format"
+		case row.record.FirstName == "":
+			msg = "first name is required"
+		case row.record.LastName == "":
+			msg = "last name is required"
+		case len(row.roles) > 0 && !canAssignRoles:
+			msg = "insufficient permissions to assign roles"
+		}
+		if msg == "" {
+			if line, ok := lineOfEmail[strings.ToLower(row.record.Email)]; ok {
+				msg = "email is already imported on line " + strconv.Itoa(line)
+			} else {
+				lineOfEmail[strings.ToLower(row.record.Email)] = row.line
+			}
+		}
+		if msg == "" {
+			for _, roleName := range row.roles {
+				roleErr, checked := roleErrors[roleName]
+				if !checked {
+					roleErr = checkCanGrantRole(ctx, u.rolePort, tx, principal, tenantID, roleName)
+					roleErrors[roleName] = roleErr
+				}
+				if roleErr != nil {
+					msg = "role " + roleName + ": " + roleErr.Error()
+					break
+				}
+			}
+		}
+		if msg == "" {
+			existingUser, err := u.authUserPort.GetUserByEmail(ctx, tx, row.record.Email, tenantID)
+			if err != nil {
+				return nil, nil, katapp.NewErr(katapp.ErrInternal, "failed to check existing user")
+			}
+			if existingUser != nil {
+				msg = "user with this email already exists"
+			}
+		}
+
+		if msg != "" {
+			rowErrors = append(rowErrors, userImportRowError(row.line, row.record.Email, msg))
+		} else {
+			validRows = append(validRows, row)
+		}
+	}
+	return validRows, rowErrors, nil
+}
+
+// importUserBatch creates users of the rows in one transaction. If the transaction fails, the rows are retried one
+// by one so that only the failing rows are reported.
+func (u *UserMgm) importUserBatch(
+	ctx context.Context, principal *UserPrincipal, tenantID string, passwordHash string, rows []userImportRow,
+) (int, []swagger.UserImportRowError) {
+	err := u.txPort.Run(ctx, func(tx pgx.Tx) error {
+		for _, row := range rows {
+			if err := u.importUser(ctx, tx, principal, tenantID, passwordHash, &row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		return len(rows), nil
+	}
+	if len(rows) == 1 {
+		katapp.Logger(ctx).Warn("failed to import user", "line", rows[0].line, "error", err)
+		msg := "failed to create user"
+		var appErr *katapp.Err
+		if errors.As(err, &appErr) && appErr.Scope != katapp.ErrInternal {
+			msg = appErr.Msg
+		}
+		return 0, []swagger.UserImportRowError{userImportRowError(rows[0].line, rows[0].record.Email, msg)}
+	}
+
+	imported := 0
+	var rowErrors []swagger.UserImportRowError
+	for i := range rows {
+		rowImported, errs := u.importUserBatch(ctx, principal, tenantID, passwordHash, rows[i:i+1])
+		imported += rowImported
+		rowErrors = append(rowErrors, errs...)
+	}
+	return imported, rowErrors
+}
+
+func (u *UserMgm) importUser(
+	ctx context.Context, tx pgx.Tx, principal *UserPrincipal, tenantID string, passwordHash string,
+	row *userImportRow,
+) error {
+	user, err := u.authUserPort.CreateUser(ctx, tx, &swagger.SignUpRequest{
+		Email:     row.record.Email,
+		FirstName: row.record.FirstName,
+		LastName:  row.record.LastName,
+		Password:  passwordHash,
+		Source:    swagger.Web,
+		TenantId:  tenantID,
+	}, tenantID)
+	if err != nil {
+		return err
+	}
+	emailVerified := lo.FromPtr(row.record.EmailVerified)
+	if emailVerified {
+		if err := u.authUserPort.SetUserEmailVerified(ctx, tx, user.ID, true); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to verify email")
+		}
+	}
+	roles := append([]string{model.RoleUser}, row.roles...)
+	for _, roleName := range roles {
+		if err := u.authUserPort.AssignUserRole(ctx, tx, user.ID, roleName); err != nil {
+			return katapp.NewErr(katapp.ErrInternal, "failed to assign role")
+		}
+	}
+	user.EmailVerified = emailVerified
+	err = queueWebhookEvent(ctx, u.webhookPort, tx, tenantID, model.WebhookEventUserCreated, webhookUserData(user))
+	if err != nil {
+		return err
+	}
+	return recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+		action:     model.AuditActionUserImported,
+		principal:  principal,
+		tenantID:   tenantID,
+		targetType: model.AuditTargetUser,
+		targetID:   user.ID,
+		diff: auditDiff{}.
+			created("email", user.Email).
+			created("roles", roles).
+			created("email_verified", emailVerified),
+	})
+}
+
+// readUserImportCsv reads records of CSV data with a header row naming the columns, unknown columns are ignored
+func readUserImportCsv(data io.Reader) ([]userImportRow, []swagger.UserImportRowError, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, katapp.NewErr(katapp.ErrInvalidInput, "CSV header row is missing or invalid")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // byte order mark written by spreadsheets
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if lo.ContainsBy(userTransferColumns, func(column string) bool { return strings.ToLower(column) == name }) {
+			columns[name] = i
+		}
+	}
+	for _, column := range userTransferColumns[:3] {
+		if _, ok := columns[strings.ToLower(column)]; !ok {
+			return nil, nil, katapp.NewErr(katapp.ErrInvalidInput,
+				"CSV header must have email, firstName and lastName columns, "+column+" is missing")
+		}
+	}
+
+	var rows []userImportRow
+	var rowErrors []swagger.UserImportRowError
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows)+len(rowErrors) >= maxUserImportRecords {
+			return nil, nil, tooManyUserImportRecordsErr()
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rowErrors = append(rowErrors, userImportRowError(parseErr.StartLine, "", "invalid CSV: "+parseErr.Err.Error()))
+			continue
+		} else if err != nil {
+			return nil, nil, katapp.NewErr(katapp.ErrInvalidInput, "failed to read CSV data")
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(column string) string {
+			if i, ok := columns[strings.ToLower(column)]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := swagger.UserImportRecord{
+			Email:     field("email"),
+			FirstName: field("firstName"),
+			LastName:  field("lastName"),
+		}
+		if roles := field("roles"); roles != "" {
+			record.Roles = lo.ToPtr(strings.Split(roles, ";"))
+		}
+		if emailVerified := field("emailVerified"); emailVerified != "" {
+			verified, err := strconv.ParseBool(emailVerified)
+			if err != nil {
+				rowErrors = append(rowErrors, userImportRowError(line, record.Email,
+					"invalid emailVerified, true or false expected"))
+				continue
+			}
+			record.EmailVerified = &verified
+		}
+		rows = append(rows, userImportRow{line: line, record: record})
+	}
+	return rows, rowErrors, nil
+}
+
+// readUserImportNdjson reads a JSON record from every line that is not blank
+func readUserImportNdjson(data io.Reader) ([]userImportRow, []swagger.UserImportRowError, error) {
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 4096), maxUserImportLineSize)
+
+	var rows []userImportRow
+	var rowErrors []swagger.UserImportRowError
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(rows)+len(rowErrors) >= maxUserImportRecords {
+			return nil, nil, tooManyUserImportRecordsErr()
+		}
+		var record swagger.UserImportRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			rowErrors = append(rowErrors, userImportRowError(line, "", "invalid JSON record"))
+			continue
+		}
+		rows = append(rows, userImportRow{line: line, record: record})
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, nil, katapp.NewErr(katapp.ErrInvalidInput, "NDJSON line is too long")
+		}
+		return nil, nil, katapp.NewErr(katapp.ErrInvalidInput, "failed to read NDJSON data")
+	}
+	return rows, rowErrors, nil
+}
+
+func tooManyUserImportRecordsErr() error {
+	return katapp.NewErr(katapp.ErrInvalidInput,
+		"at most "+strconv.Itoa(maxUserImportRecords)+" users can be imported at once")
+}
+
+func userImportRowError(line int, email string, message string) swagger.UserImportRowError {
+	return *swagger.NewUserImportRowErrorBuilder().
+		Email(lo.EmptyableToPtr(email)).
+		Line(line).
+		Message(message).
+		Build()
+}
+
+// userRecordEncoder writes exported users in one of the transfer formats
+type userRecordEncoder interface {
+	Encode(record *swagger.UserImportRecord) error
+	Flush() error
+}
+
+// csvUserRecordEncoder writes users as CSV rows, the header row is written before the first user
+type csvUserRecordEncoder struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvUserRecordEncoder) Encode(record *swagger.UserImportRecord) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.writer.Write([]string{
+		record.Email,
+		record.FirstName,
+		record.LastName,
+		strings.Join(lo.FromPtr(record.Roles), ";"),
+		strconv.FormatBool(lo.FromPtr(record.EmailVerified)),
+	})
+}
+
+// Flush writes the header row also when there are no users, so that the export can be imported
+func (e *csvUserRecordEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvUserRecordEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write(userTransferColumns)
+}
+
+// ndjsonUserRecordEncoder writes every user as a JSON object on its own line
+type ndjsonUserRecordEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonUserRecordEncoder) Encode(record *swagger.UserImportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonUserRecordEncoder) Flush() error {
+	return nil
+}
@@ -6,25 +6,40 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
 	"github.com/oapi-codegen/runtime/types"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
 )
 
 // UserMgm handles user management use cases
 type UserMgm struct {
-	authUserPort outport.AuthUserPersist
-	txPort       outport.TxPort
+	authUserPort        outport.AuthUserPersist
+	rolePort            outport.RolePersist
+	auditPort           outport.AuditPersist
+	tokenRevocationPort outport.TokenRevocationPersist
+	webhookPort         outport.WebhookPersist
+	txPort              outport.TxPort
 }
 
 // NewUserMgm creates a new UserMgm use case
-func NewUserMgm(authUserPort outport.AuthUserPersist, databasePort outport.TxPort) *UserMgm {
+func NewUserMgm(
+	authUserPort outport.AuthUserPersist, rolePort outport.RolePersist, auditPort outport.AuditPersist,
+	tokenRevocationPort outport.TokenRevocationPersist, webhookPort outport.WebhookPersist,
+	databasePort outport.TxPort,
+) *UserMgm {
 	return &UserMgm{
-		authUserPort: authUserPort,
-		txPort:       databasePort,
+		authUserPort:        authUserPort,
+		rolePort:            rolePort,
+		auditPort:           auditPort,
+		tokenRevocationPort: tokenRevocationPort,
+		webhookPort:         webhookPort,
+		txPort:              databasePort,
 	}
 }
 
@@ -45,7 +60,7 @@ func (u *UserMgm) LoadUserByID(
 	if err != nil {
 		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
 	}
-	if !principal.CanFetchUser(userID, user.TenantID) {
+	if !principal.HasUserPermission(model.PermissionUsersRead, userID, user.TenantID) {
 		msg := "insufficient permissions to fetch user profile"
 		katapp.Logger(ctx).Warn(msg,
 			"principal", principal.String(),
@@ -56,16 +71,14 @@ func (u *UserMgm) LoadUserByID(
 	return authUserToAuthUserResponse(user), nil
 }
 
-// ListAllUsersByTenant returns a paginated list of users within principal's tenant (admin role only)
-// if userPrincipal is user role only, then only the user's own profile is returned
+// ListAllUsersByTenant returns a filtered and sorted page of users within principal's tenant (users:read permission
+// only). If userPrincipal has no users:read permission, only the user's own profile is returned.
 func (u *UserMgm) ListAllUsersByTenant(
-	ctx context.Context, userPrincipal *UserPrincipal, tenantID string, page, limit int,
+	ctx context.Context, userPrincipal *UserPrincipal, tenantID string, params *swagger.ListUsersByTenantParams,
 ) (*swagger.AuthUsersResponse, error) {
 	katapp.Logger(ctx).Info("listing users by tenant",
 		"principal", userPrincipal.String(),
 		"tenantID", tenantID,
-		"page", page,
-		"limit", limit,
 	)
 
 	if tenantID == "" {
@@ -74,87 +87,129 @@ func (u *UserMgm) ListAllUsersByTenant(
 		return nil, katapp.NewErr(katapp.ErrInvalidInput, msg)
 	}
 
-	if page < 1 {
-		page = 1
+	var userID *string
+	if !userPrincipal.CanListUsersForTenant(tenantID) {
+		userID = &userPrincipal.UserID
 	}
-	if limit < 1 || limit > 100 {
-		limit = 100
+	return u.listUsers(ctx, &tenantID, userID, params)
+}
+
+// ListAllUsers returns a filtered and sorted page of users of all tenants (users:read permission in all tenants only)
+func (u *UserMgm) ListAllUsers(
+	ctx context.Context, principal *UserPrincipal, params *swagger.ListAllUsersParams,
+) (*swagger.AuthUsersResponse, error) {
+	katapp.Logger(ctx).Info("listing all users", "principal", principal.String())
+
+	if !principal.HasPermissionInAllTenants(model.PermissionUsersRead) {
+		msg := "insufficient permissions to list all users"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String())
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return u.listUsers(ctx, nil, nil, (*swagger.ListUsersByTenantParams)(params))
+}
+
+// SearchUsers returns users whose email or names are similar to the query, most similar first. Principals with
+// users:read permission in all tenants search all tenants unless a tenant is given, others their own tenant.
+func (u *UserMgm) SearchUsers(
+	ctx context.Context, principal *UserPrincipal, params *swagger.SearchUsersParams,
+) (*swagger.UserSearchResponse, error) {
+	katapp.Logger(ctx).Info("searching users", "principal", principal.String(), "tenantID", params.TenantId)
+
+	query := strings.TrimSpace(params.Q)
+	if utf8.RuneCountInString(query) < 2 {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "search query must have at least 2 characters")
+	}
+	limit := lo.FromPtrOr(params.Limit, 10)
+	if limit < 1 || limit > 50 {
+		limit = 50
+	}
+
+	tenantID := lo.EmptyableToPtr(lo.FromPtr(params.TenantId))
+	if tenantID == nil && !principal.HasPermissionInAllTenants(model.PermissionUsersRead) {
+		tenantID = &principal.TenantID
+	}
+	if tenantID != nil && !principal.CanListUsersForTenant(*tenantID) {
+		msg := "insufficient permissions to search users"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", *tenantID)
+		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
 	}
 
 	users, err := outport.TxWithResult(ctx, u.txPort, func(tx pgx.Tx) ([]*model.AuthUser, error) {
-		if userPrincipal.CanListUsersForTenant(tenantID) {
-			return u.authUserPort.GetAllUsersByTenantID(ctx, tx, tenantID)
-		} else {
-			var user *model.AuthUser
-			user, err := u.authUserPort.GetUserByID(ctx, tx, userPrincipal.UserID)
-			if err != nil {
-				return nil, err
-			}
-			return []*model.AuthUser{user}, nil
-		}
+		return u.authUserPort.SearchUsers(ctx, tx, query, tenantID, limit)
 	})
 	if err != nil {
-		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get users")
+		return nil, katapp.NewErr(katapp.ErrInternal, "failed to search users")
 	}
 
-	// TODO For now, we'll implement a simple version without pagination in the persist layer
-	// In a real system, you'd add pagination support to the persist layer
-
-	// Convert to swagger models
 	userResponses := make([]swagger.AuthUserResponse, len(users))
 	for i, user := range users {
 		userResponses[i] = *authUserToAuthUserResponse(user)
 	}
-
-	paginatedUsers, pagination := internal.Paginate(userResponses, page, limit)
-	return swagger.NewAuthUsersResponseBuilder().
-		Items(paginatedUsers).
-		Pagination(*pagination).
+	return swagger.NewUserSearchResponseBuilder().
+		Items(userResponses).
 		Build(), nil
 }
 
-// ListAllUsers returns a paginated list of all users in the system (sysadmin role only)
-func (u *UserMgm) ListAllUsers(
-	ctx context.Context, principal *UserPrincipal, page, limit int,
-) (*swagger.AuthUsersResponse, error) {
-	katapp.Logger(ctx).Info("listing all users",
-		"principal", principal.String(),
-		"page", page,
-		"limit", limit)
-
-	if !principal.IsSysAdmin() {
-		msg := "insufficient permissions to list all users"
-		katapp.Logger(ctx).Warn(msg, "principal", principal.String())
-		return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
-	}
+// userSortFields are the fields users can be sorted by, the first one is the default
+var userSortFields = []string{model.UserSortCreatedAt, model.UserSortEmail, model.UserSortFirstName, model.UserSortLastName}
 
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 100
+func (u *UserMgm) listUsers(
+	ctx context.Context, tenantID *string, userID *string, params *swagger.ListUsersByTenantParams,
+) (*swagger.AuthUsersResponse, error) {
+	pageRequest, page, err := newPageRequest(params.Page, params.Limit, params.Cursor,
+		string(lo.FromPtr(params.SortBy)), params.SortOrder, userSortFields)
+	if err != nil {
+		return nil, err
 	}
+	filter := model.NewUserFilterBuilder().
+		TenantID(tenantID).
+		UserID(userID).
+		Email(nil).
+		Search(lo.EmptyableToPtr(strings.TrimSpace(lo.FromPtr(params.Search)))).
+		Role(lo.EmptyableToPtr(lo.FromPtr(params.Role))).
+		EmailVerified(params.EmailVerified).
+		IsActive(params.Active).
+		Build()
 
-	users, err := outport.TxWithResult(ctx, u.txPort, func(tx pgx.Tx) ([]*model.AuthUser, error) {
-		return u.authUserPort.GetAllUsers(ctx, tx)
+	var users []*model.AuthUser
+	var total int
+	err = u.txPort.Run(ctx, func(tx pgx.Tx) error {
+		var err error
+		users, total, err = u.authUserPort.ListUsers(ctx, tx, filter, pageRequest)
+		return err
 	})
 	if err != nil {
 		return nil, katapp.NewErr(katapp.ErrInternal, "failed to get users")
 	}
 
-	// Convert to swagger models
+	users, nextCursor := pageItems(users, pageRequest, func(user *model.AuthUser) model.PageCursor {
+		return model.PageCursor{Value: userSortValue(user, pageRequest.SortBy), ID: user.ID}
+	})
 	userResponses := make([]swagger.AuthUserResponse, len(users))
 	for i, user := range users {
 		userResponses[i] = *authUserToAuthUserResponse(user)
 	}
-
-	paginatedUsers, pagination := internal.Paginate(userResponses, page, limit)
 	return swagger.NewAuthUsersResponseBuilder().
-		Items(paginatedUsers).
-		Pagination(*pagination).
+		Items(userResponses).
+		NextCursor(nextCursor).
+		Pagination(*paginationInfo(page, pageRequest, total)).
 		Build(), nil
 }
 
+// userSortValue returns the value the user is sorted by in a listing
+func userSortValue(user *model.AuthUser, sortBy string) string {
+	switch sortBy {
+	case model.UserSortEmail:
+		return user.Email
+	case model.UserSortFirstName:
+		return user.FirstName
+	case model.UserSortLastName:
+		return user.LastName
+	default:
+		return user.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
 // GetUserRoles returns the roles assigned to a user
 func (u *UserMgm) GetUserRoles(
 	ctx context.Context, principal *UserPrincipal, userID string,
@@ -175,7 +230,7 @@ func (u *UserMgm) GetUserRoles(
 			return nil, katapp.NewErr(katapp.ErrInternal, "failed to get user")
 		}
 
-		if !principal.CanFetchUser(userID, user.TenantID) {
+		if !principal.HasUserPermission(model.PermissionUsersRead, userID, user.TenantID) {
 			msg := "insufficient permissions to get user roles"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "targetUserID", userID)
 			return nil, katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -220,16 +275,13 @@ func (u *UserMgm) AssignUserRole(ctx context.Context, principal *UserPrincipal,
 		if err != nil {
 			return err
 		}
-		if !principal.CanManageUser(user.TenantID) {
+		if !principal.HasTenantPermission(model.PermissionUsersAssignRoles, user.TenantID) {
 			msg := "insufficient permissions to assign roles"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
 			return katapp.NewErr(katapp.ErrNoPermissions, msg)
 		}
-
-		if roleName == "sysadmin" {
-			msg := "cannot assign sysadmin role"
-			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
-			return katapp.NewErr(katapp.ErrNoPermissions, msg)
+		if err := checkCanGrantRole(ctx, u.rolePort, tx, principal, user.TenantID, roleName); err != nil {
+			return err
 		}
 
 		err = u.authUserPort.AssignUserRole(ctx, tx, userID, roleName)
@@ -240,11 +292,23 @@ func (u *UserMgm) AssignUserRole(ctx context.Context, principal *UserPrincipal,
 			}
 			return katapp.NewErr(katapp.ErrInternal, "failed to assign role")
 		}
-		return nil
+		err = recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+			action:     model.AuditActionUserRoleAssigned,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.created("role", roleName),
+		})
+		if err != nil {
+			return err
+		}
+		return queueWebhookEvent(ctx, u.webhookPort, tx, user.TenantID, model.WebhookEventRoleAssigned,
+			map[string]any{"userId": user.ID, "role": roleName})
 	})
 }
 
-// DeleteUserRole removes a role from a user (admin only)
+// DeleteUserRole removes a role from a user and rejects access tokens issued to the user so far (admin only)
 func (u *UserMgm) DeleteUserRole(ctx context.Context, principal *UserPrincipal, userID string, roleName string) error {
 	katapp.Logger(ctx).Info("removing role from user",
 		"principal", principal.String(),
@@ -268,11 +332,14 @@ func (u *UserMgm) DeleteUserRole(ctx context.Context, principal *UserPrincipal,
 		if err != nil {
 			return err
 		}
-		if !principal.CanManageUser(user.TenantID) {
+		if !principal.HasTenantPermission(model.PermissionUsersAssignRoles, user.TenantID) {
 			msg := "insufficient permissions to assign roles"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
 			return katapp.NewErr(katapp.ErrNoPermissions, msg)
 		}
+		if err := checkCanGrantRole(ctx, u.rolePort, tx, principal, user.TenantID, roleName); err != nil {
+			return err
+		}
 
 		err = u.authUserPort.DeleteUserRole(ctx, tx, userID, roleName)
 		if err != nil {
@@ -282,12 +349,23 @@ func (u *UserMgm) DeleteUserRole(ctx context.Context, principal *UserPrincipal,
 			}
 			return katapp.NewErr(katapp.ErrInternal, "failed to remove role")
 		}
-
-		return nil
+		// Access tokens carry the roles of the user, the user has to refresh them to drop the removed role
+		err = revokeAccessTokens(ctx, u.tokenRevocationPort, tx, model.AccessTokenRevocationUser, user.ID)
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+			action:     model.AuditActionUserRoleRemoved,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.deleted("role", roleName),
+		})
 	})
 }
 
-// DeleteUser deletes a user from the system (admin only)
+// DeleteUser deletes a user from the system and rejects access tokens issued to the user so far (admin only)
 func (u *UserMgm) DeleteUser(
 	ctx context.Context, principal *UserPrincipal, userID string,
 ) error {
@@ -307,7 +385,7 @@ func (u *UserMgm) DeleteUser(
 			return err
 		}
 
-		if !principal.CanManageUser(user.TenantID) {
+		if !principal.HasTenantPermission(model.PermissionUsersDelete, user.TenantID) {
 			msg := "insufficient permissions to delete user"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
 			return katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -317,7 +395,23 @@ func (u *UserMgm) DeleteUser(
 		if err != nil {
 			return katapp.NewErr(katapp.ErrInternal, "failed to delete user")
 		}
-		return nil
+		err = revokeAccessTokens(ctx, u.tokenRevocationPort, tx, model.AccessTokenRevocationUser, user.ID)
+		if err != nil {
+			return err
+		}
+		err = queueWebhookEvent(ctx, u.webhookPort, tx, user.TenantID, model.WebhookEventUserDeleted,
+			webhookUserData(user))
+		if err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+			action:     model.AuditActionUserDeleted,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff:       auditDiff{}.deleted("email", user.Email),
+		})
 	})
 	return err
 }
@@ -353,7 +447,7 @@ func (u *UserMgm) UpdateUserDetails(
 		}
 
 		// Check if the principal can manage this user
-		if !principal.CanUpdateUserDetails(userID, user.TenantID) {
+		if !principal.HasUserPermission(model.PermissionUsersUpdate, userID, user.TenantID) {
 			msg := "insufficient permissions to update user details"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
 			return katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -370,7 +464,16 @@ func (u *UserMgm) UpdateUserDetails(
 		if err != nil {
 			return katapp.NewErr(katapp.ErrInternal, "failed to update user details")
 		}
-		return nil
+		return recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+			action:     model.AuditActionUserUpdated,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+			diff: auditDiff{}.
+				changed("firstName", user.FirstName, firstName).
+				changed("lastName", user.LastName, lastName),
+		})
 	})
 
 	return err
@@ -402,7 +505,7 @@ func (u *UserMgm) ChangeUserPassword(
 			return err
 		}
 
-		if !principal.CanUpdateUserDetails(userID, user.TenantID) {
+		if !principal.HasUserPermission(model.PermissionUsersUpdate, userID, user.TenantID) {
 			msg := "insufficient permissions to change user password"
 			katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "userID", userID)
 			return katapp.NewErr(katapp.ErrNoPermissions, msg)
@@ -424,7 +527,14 @@ func (u *UserMgm) ChangeUserPassword(
 		if err != nil {
 			return katapp.NewErr(katapp.ErrInternal, "failed to update password")
 		}
-		return nil
+		// Password hashes are never recorded
+		return recordAuditEvent(ctx, u.auditPort, tx, auditEntry{
+			action:     model.AuditActionUserPasswordChanged,
+			principal:  principal,
+			tenantID:   user.TenantID,
+			targetType: model.AuditTargetUser,
+			targetID:   user.ID,
+		})
 	})
 
 	return err
@@ -1,98 +1,81 @@
 package usecase
 
-import "fmt"
-
-// UserPrincipal represents the authenticated user context for use case operations
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+// Principal types, access tokens without the principalType claim are issued to users
+const (
+	PrincipalTypeUser          = "user"
+	PrincipalTypeServiceClient = "service_client"
+)
+
+// UserPrincipal represents the authenticated user context for use case operations. Service clients
+// (machine clients of the client_credentials grant) are principals too, with their client ID as UserID.
+// Authorization checks the permissions of the principal, never its role names.
 type UserPrincipal struct {
-	UserID   string   `json:"user_id"`
-	TenantID string   `json:"tenant_id"`
-	Email    string   `json:"email"`
-	Roles    []string `json:"roles"`
-}
-
-// HasRole checks if the user principal has a specific role
-func (up *UserPrincipal) HasRole(role string) bool {
-	for _, r := range up.Roles {
-		if r == role {
-			return true
-		}
-	}
-	return false
+	UserID      string   `json:"user_id"` // user ID, or client ID of a service client
+	TenantID    string   `json:"tenant_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"` // permissions granted by the roles
+	SessionID   string   `json:"session_id"`  // session of the access token, empty for tokens without session
+	TokenID     string   `json:"token_id"`    // jti of the access token, empty for tokens without ID
+	Type        string   `json:"type"`        // PrincipalTypeUser or PrincipalTypeServiceClient
+	Scopes      []string `json:"scopes"`      // scopes granted to a service client
+	APIKeyID    string   `json:"api_key_id"`  // API key the user authenticated with, empty for access tokens
 }
 
 func (up *UserPrincipal) String() string {
 	if up == nil {
 		return "UserPrincipal{nil}"
 	}
-	return fmt.Sprintf("UserPrincipal{UserID: %s, TenantID: %s, Email: %s, Roles: %v}",
-		up.UserID, up.TenantID, up.Email, up.Roles)
+	return fmt.Sprintf("UserPrincipal{UserID: %s, TenantID: %s, Email: %s, Roles: %v, Type: %s}",
+		up.UserID, up.TenantID, up.Email, up.Roles, up.Type)
 }
 
-// IsSysAdmin checks if the user principal has sysadmin role
-func (up *UserPrincipal) IsSysAdmin() bool {
-	return up.HasRole("sysadmin")
+// IsServiceClient checks if the principal is a service client rather than a user
+func (up *UserPrincipal) IsServiceClient() bool {
+	return up.Type == PrincipalTypeServiceClient
 }
 
-// IsAdmin checks if the user principal has admin role
-func (up *UserPrincipal) IsAdmin() bool {
-	return up.HasRole("admin") || up.IsSysAdmin()
+// IsSignedInUser checks if the principal is a user authenticated with an access token of a sign in session,
+// rather than a service client or a user authenticated with an API key
+func (up *UserPrincipal) IsSignedInUser() bool {
+	return !up.IsServiceClient() && up.APIKeyID == "" && up.SessionID != ""
 }
 
-// IsUser checks if the user principal has user role
-func (up *UserPrincipal) IsUser() bool {
-	return up.HasRole("user")
+// HasPermission checks if the principal has a permission, in its own tenant at least
+func (up *UserPrincipal) HasPermission(permission string) bool {
+	return slices.Contains(up.Permissions, permission)
 }
 
-// IsUserOnly checks if the user principal has only user role
-func (up *UserPrincipal) IsUserOnly() bool {
-	return up.IsUser() && !up.IsAdmin() && !up.IsSysAdmin()
+// HasTenantPermission checks if the principal has a permission in the given tenant
+func (up *UserPrincipal) HasTenantPermission(permission string, targetTenantID string) bool {
+	return up.HasPermission(permission) &&
+		(up.TenantID == targetTenantID || up.HasPermission(model.PermissionAllTenants))
 }
 
-func (up *UserPrincipal) CanFetchUser(targetUserID string, targetTenantID string) bool {
-	return up.IsSysAdmin() || (up.IsAdmin() && up.TenantID == targetTenantID) ||
-		(up.IsUserOnly() && up.UserID == targetUserID)
+// HasPermissionInAllTenants checks if the principal has a permission in every tenant
+func (up *UserPrincipal) HasPermissionInAllTenants(permission string) bool {
+	return up.HasPermission(permission) && up.HasPermission(model.PermissionAllTenants)
 }
 
-// CanUpdateUserDetails checks if the user principal can update users (update user does not include changing roles)
-func (up *UserPrincipal) CanUpdateUserDetails(targetUserID string, targetTenantID string) bool {
-	return up.IsSysAdmin() || (up.IsAdmin() && up.TenantID == targetTenantID) ||
-		(up.IsUserOnly() && up.UserID == targetUserID)
+// HasUserPermission checks if the principal has a permission on the given user. Users need no permissions
+// for their own account.
+func (up *UserPrincipal) HasUserPermission(permission string, targetUserID string, targetTenantID string) bool {
+	return (!up.IsServiceClient() && up.UserID == targetUserID) || up.HasTenantPermission(permission, targetTenantID)
 }
 
+// CanListUsersForTenant checks if the principal can list and search other users of the given tenant
 func (up *UserPrincipal) CanListUsersForTenant(targetTenantID string) bool {
-	return up.IsSysAdmin() || (up.IsAdmin() && up.TenantID == targetTenantID)
-}
-
-// CanManageUser checks if the user principal can assign roles to users
-func (up *UserPrincipal) CanManageUser(targetTenantID string) bool {
-	if up.IsSysAdmin() {
-		return true
-	}
-	if up.IsAdmin() && up.TenantID == targetTenantID {
-		return true
-	}
-	return false
-}
-
-func (up *UserPrincipal) CanManageTenant(targetTenantID string) bool {
-	return up.IsSysAdmin() || (up.IsAdmin() && up.TenantID == targetTenantID)
+	return up.HasTenantPermission(model.PermissionUsersRead, targetTenantID)
 }
 
+// CanReadTenant checks if the principal can read the given tenant, its own tenant can always be read
 func (up *UserPrincipal) CanReadTenant(targetTenantID string) bool {
-	return up.IsSysAdmin() || (up.TenantID == targetTenantID)
-}
-
-// CanChangePasswords checks if the user can change other users' passwords
-func (up *UserPrincipal) CanChangePasswords() bool {
-	return up.IsSysAdmin() || up.IsAdmin()
-}
-
-// CanCreateUsers checks if the user can create new users
-func (up *UserPrincipal) CanCreateUsers() bool {
-	return up.IsSysAdmin() || up.IsAdmin()
-}
-
-// CanManageUsers checks if the user can manage users (sysadmin or admin)
-func (up *UserPrincipal) CanManageUsers() bool {
-	return up.IsSysAdmin() || up.IsAdmin()
+	return up.TenantID == targetTenantID || up.HasTenantPermission(model.PermissionTenantsRead, targetTenantID)
 }
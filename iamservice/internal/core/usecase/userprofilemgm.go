@@ -53,7 +53,7 @@ func (u *UserProfileMgm) GetUserProfileByUserID(
 	if err != nil {
 		return nil, err
 	}
-	if !principal.CanFetchUser(userID, userWithProfile.A.TenantID) {
+	if !principal.HasUserPermission(model.PermissionUsersRead, userID, userWithProfile.A.TenantID) {
 		msg := "insufficient permissions to fetch user profile"
 		katapp.Logger(ctx).Warn(msg,
 			"principal", principal.String(),
@@ -84,8 +84,8 @@ func (u *UserProfileMgm) UpdateUserProfileByUserID(
 				return t, katapp.NewErr(katapp.ErrNotFound, "user not found")
 			}
 
-			// Check authorization - users can update their own profile, admin/sysadmin can update any profile in their tenant
-			if !principal.CanUpdateUserDetails(userID, user.TenantID) {
+			// Check authorization - users can update their own profile, users:update permission allows any profile in the tenant
+			if !principal.HasUserPermission(model.PermissionUsersUpdate, userID, user.TenantID) {
 				return t, katapp.NewErr(katapp.ErrNoPermissions, "insufficient permissions to update user profile")
 			}
 			// Update the user profile
@@ -0,0 +1,638 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase/internal"
+	"github.com/mobiletoly/gokatana/katapp"
+	"github.com/samber/lo"
+)
+
+const (
+	// minWebhookSecretLength is the shortest secret accepted for signing webhook events
+	minWebhookSecretLength = 16
+	// webhookSignatureHeader carries the timestamp and the HMAC-SHA256 signature of a delivered event
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// queueWebhookEvent queues the event for delivery to every webhook of the tenant subscribed to its type. Like
+// queued emails, deliveries are written in the given transaction and only sent if the change is committed.
+// Webhooks that are inactive get the event too, it is delivered once they are activated again.
+func queueWebhookEvent(
+	ctx context.Context, webhookPersist outport.WebhookPersist, tx pgx.Tx, tenantID string, eventType string,
+	data map[string]any,
+) error {
+	webhooks, err := webhookPersist.GetWebhooksByEventType(ctx, tx, tenantID, eventType)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	eventID := "evt-" + uuid.NewString()
+	payload, err := json.Marshal(swagger.NewWebhookEventBuilder().
+		CreatedAt(now).
+		Data(data).
+		Id(eventID).
+		TenantId(tenantID).
+		Type(swagger.WebhookEventType(eventType)).
+		Build())
+	if err != nil {
+		return katapp.NewErr(katapp.ErrInternal, "failed to encode webhook event")
+	}
+	deliveries := make([]*model.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = newWebhookDelivery(webhook.ID, eventID, eventType, payload, now)
+	}
+	return webhookPersist.CreateWebhookDeliveries(ctx, tx, deliveries)
+}
+
+// webhookUserData returns the data of user events delivered to webhooks
+func webhookUserData(user *model.AuthUser) map[string]any {
+	return map[string]any{
+		"id":            user.ID,
+		"email":         user.Email,
+		"firstName":     user.FirstName,
+		"lastName":      user.LastName,
+		"emailVerified": user.EmailVerified,
+		"active":        user.IsActive,
+	}
+}
+
+func newWebhookDelivery(
+	webhookID string, eventID string, eventType string, payload []byte, now time.Time,
+) *model.WebhookDelivery {
+	return model.NewWebhookDeliveryBuilder().
+		ID("whd-" + uuid.NewString()).
+		WebhookID(webhookID).
+		EventID(eventID).
+		EventType(eventType).
+		Payload(payload).
+		Status(model.WebhookDeliveryStatusPending).
+		Attempts(0).
+		NextAttemptAt(now).
+		LastStatusCode(nil).
+		LastError(nil).
+		CreatedAt(now).
+		DeliveredAt(nil).
+		Build()
+}
+
+// WebhookMgm manages webhook subscriptions of tenants and delivers their queued events
+type WebhookMgm struct {
+	webhookConfig   *app.WebhookConfig
+	webhookPersist  outport.WebhookPersist
+	authUserPersist outport.AuthUserPersist
+	auditPersist    outport.AuditPersist
+	txPort          outport.TxPort
+	webhookClient   outport.WebhookClient
+}
+
+// NewWebhookMgm creates a new WebhookMgm use case
+func NewWebhookMgm(
+	webhookConfig *app.WebhookConfig, webhookPort outport.WebhookPersist, authUserPort outport.AuthUserPersist,
+	auditPort outport.AuditPersist, databasePort outport.TxPort, webhookClient outport.WebhookClient,
+) *WebhookMgm {
+	return &WebhookMgm{
+		webhookConfig:   webhookConfig,
+		webhookPersist:  webhookPort,
+		authUserPersist: authUserPort,
+		auditPersist:    auditPort,
+		txPort:          databasePort,
+		webhookClient:   webhookClient,
+	}
+}
+
+// RunDispatcher delivers due webhook events every poll interval until the context is cancelled
+func (w *WebhookMgm) RunDispatcher(ctx context.Context) {
+	katapp.Logger(ctx).Info("starting webhook dispatcher", "pollInterval", w.webhookConfig.PollInterval)
+	ticker := time.NewTicker(w.webhookConfig.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			katapp.Logger(ctx).Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			// More events may be due after a full batch, they are delivered without waiting for the next tick
+			for ctx.Err() == nil {
+				claimed, err := w.DispatchDueDeliveries(ctx)
+				if err != nil || claimed < w.webhookConfig.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DispatchDueDeliveries claims a batch of due deliveries, posts their events and records the results. Events are
+// posted outside of any transaction, a slow receiver does not hold database locks. Returns the number of claimed
+// deliveries.
+func (w *WebhookMgm) DispatchDueDeliveries(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) ([]*model.DueWebhookDelivery, error) {
+		return w.webhookPersist.ClaimDueWebhookDeliveries(
+			ctx, tx, now, now.Add(w.webhookConfig.ClaimTimeout), w.webhookConfig.BatchSize)
+	})
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to claim due webhook deliveries", "error", err)
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		w.deliver(ctx, delivery)
+	}
+	return len(deliveries), nil
+}
+
+// deliver posts the event of a claimed delivery to its webhook and records the result. Any 2xx response counts
+// as delivered. Failures are logged only, a delivery whose result cannot be recorded is posted again once its
+// claim times out.
+func (w *WebhookMgm) deliver(ctx context.Context, delivery *model.DueWebhookDelivery) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"X-Webhook-Event":       delivery.EventType,
+		"X-Webhook-Event-Id":    delivery.EventID,
+		"X-Webhook-Delivery-Id": delivery.ID,
+		webhookSignatureHeader:  "t=" + timestamp + ",v1=" + signWebhookPayload(delivery.Secret, timestamp, delivery.Payload),
+	}
+	statusCode, postErr := w.webhookClient.PostWebhook(ctx, delivery.URL, headers, delivery.Payload)
+	if postErr == nil && (statusCode < 200 || statusCode > 299) {
+		postErr = fmt.Errorf("unexpected HTTP status %d", statusCode)
+	}
+
+	now := time.Now()
+	var err error
+	if postErr == nil {
+		err = w.txPort.Run(ctx, func(tx pgx.Tx) error {
+			return w.webhookPersist.MarkWebhookDeliveryAsDelivered(ctx, tx, delivery.ID, statusCode, now)
+		})
+	} else {
+		nextAttemptAt := nextRetryAt(
+			delivery.Attempts, w.webhookConfig.MaxAttempts, w.webhookConfig.RetryBackoff,
+			w.webhookConfig.MaxRetryBackoff, now,
+		)
+		if nextAttemptAt == nil {
+			katapp.Logger(ctx).Error("giving up webhook delivery after the last delivery attempt",
+				"deliveryID", delivery.ID, "webhookID", delivery.WebhookID, "attempts", delivery.Attempts,
+				"error", postErr)
+		} else {
+			katapp.Logger(ctx).Warn("failed to deliver webhook event, retrying later",
+				"deliveryID", delivery.ID, "webhookID", delivery.WebhookID, "attempts", delivery.Attempts,
+				"nextAttemptAt", *nextAttemptAt, "error", postErr)
+		}
+		// No status code is recorded if the receiver could not be reached at all
+		lastStatusCode := lo.Ternary(statusCode > 0, &statusCode, nil)
+		lastError := truncateDeliveryError(postErr.Error())
+		err = w.txPort.Run(ctx, func(tx pgx.Tx) error {
+			return w.webhookPersist.MarkWebhookDeliveryAsFailed(
+				ctx, tx, delivery.ID, lastStatusCode, lastError, nextAttemptAt)
+		})
+	}
+	if err != nil {
+		katapp.Logger(ctx).Error("failed to record webhook delivery", "deliveryID", delivery.ID, "error", err)
+	}
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" keyed with the secret.
+// Signing the timestamp lets receivers reject replayed events.
+func signWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ListWebhooks returns the webhooks of a tenant, most recently created first
+func (w *WebhookMgm) ListWebhooks(
+	ctx context.Context, principal *UserPrincipal, tenantID string,
+) (*swagger.WebhooksResponse, error) {
+	katapp.Logger(ctx).Debug("listing webhooks", "principal", principal.String(), "tenantID", tenantID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	webhooks, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) ([]*model.Webhook, error) {
+		if err := internal.EnsureTenantExistsById(ctx, w.authUserPersist, tx, tenantID); err != nil {
+			return nil, err
+		}
+		return w.webhookPersist.GetWebhooksByTenantID(ctx, tx, tenantID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]swagger.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		items[i] = *webhookToWebhookResponse(webhook)
+	}
+	return swagger.NewWebhooksResponseBuilder().
+		Items(items).
+		Build(), nil
+}
+
+// GetWebhook returns a webhook of a tenant
+func (w *WebhookMgm) GetWebhook(
+	ctx context.Context, principal *UserPrincipal, tenantID string, webhookID string,
+) (*swagger.WebhookResponse, error) {
+	katapp.Logger(ctx).Debug("getting webhook",
+		"principal", principal.String(), "tenantID", tenantID, "webhookID", webhookID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	webhook, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) (*model.Webhook, error) {
+		return w.getExistingWebhook(ctx, tx, tenantID, webhookID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhookToWebhookResponse(webhook), nil
+}
+
+// CreateWebhook subscribes a URL to events of the tenant. A secret is generated unless one is given, it is
+// returned only once.
+func (w *WebhookMgm) CreateWebhook(
+	ctx context.Context, principal *UserPrincipal, tenantID string, req *swagger.CreateWebhookRequest,
+) (*swagger.CreateWebhookResponse, error) {
+	katapp.Logger(ctx).Info("creating webhook", "principal", principal.String(), "tenantID", tenantID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	webhookURL, err := validateWebhookURL(req.Url)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret := generateWebhookSecret()
+	if req.Secret != nil {
+		if secret, err = validateWebhookSecret(*req.Secret); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) (*model.Webhook, error) {
+		if err := internal.EnsureTenantExistsById(ctx, w.authUserPersist, tx, tenantID); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		webhook := model.NewWebhookBuilder().
+			ID("wh-" + uuid.NewString()).
+			TenantID(tenantID).
+			URL(webhookURL).
+			Secret(secret).
+			EventTypes(eventTypes).
+			Active(lo.FromPtrOr(req.Active, true)).
+			CreatedAt(now).
+			UpdatedAt(now).
+			Build()
+		if err := w.webhookPersist.CreateWebhook(ctx, tx, webhook); err != nil {
+			return nil, err
+		}
+		err := recordAuditEvent(ctx, w.auditPersist, tx, auditEntry{
+			action:     model.AuditActionWebhookCreated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetWebhook,
+			targetID:   webhook.ID,
+			diff: auditDiff{}.
+				created("url", webhook.URL).
+				created("eventTypes", webhook.EventTypes).
+				created("active", webhook.Active),
+		})
+		return webhook, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return swagger.NewCreateWebhookResponseBuilder().
+		Secret(webhook.Secret).
+		Webhook(*webhookToWebhookResponse(webhook)).
+		Build(), nil
+}
+
+// UpdateWebhook replaces URL, event types and active state of a webhook, and its secret if one is given
+func (w *WebhookMgm) UpdateWebhook(
+	ctx context.Context, principal *UserPrincipal, tenantID string, webhookID string,
+	req *swagger.UpdateWebhookRequest,
+) (*swagger.WebhookResponse, error) {
+	katapp.Logger(ctx).Info("updating webhook",
+		"principal", principal.String(), "tenantID", tenantID, "webhookID", webhookID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	webhookURL, err := validateWebhookURL(req.Url)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := validateWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	var secret *string
+	if req.Secret != nil {
+		validSecret, err := validateWebhookSecret(*req.Secret)
+		if err != nil {
+			return nil, err
+		}
+		secret = &validSecret
+	}
+
+	webhook, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) (*model.Webhook, error) {
+		webhook, err := w.getExistingWebhook(ctx, tx, tenantID, webhookID)
+		if err != nil {
+			return nil, err
+		}
+		diff := auditDiff{}.
+			changed("url", webhook.URL, webhookURL).
+			changed("active", webhook.Active, req.Active)
+		if !slices.Equal(webhook.EventTypes, eventTypes) {
+			diff["eventTypes"] = model.AuditChange{Old: webhook.EventTypes, New: eventTypes}
+		}
+		if secret != nil && *secret != webhook.Secret {
+			// The secret itself is not audited
+			diff["secret"] = model.AuditChange{New: "changed"}
+		}
+
+		webhook.URL = webhookURL
+		webhook.EventTypes = eventTypes
+		webhook.Active = req.Active
+		webhook.Secret = lo.FromPtrOr(secret, webhook.Secret)
+		webhook.UpdatedAt = time.Now()
+		if err := w.webhookPersist.UpdateWebhook(ctx, tx, webhook); err != nil {
+			return nil, err
+		}
+		err = recordAuditEvent(ctx, w.auditPersist, tx, auditEntry{
+			action:     model.AuditActionWebhookUpdated,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetWebhook,
+			targetID:   webhook.ID,
+			diff:       diff,
+		})
+		return webhook, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhookToWebhookResponse(webhook), nil
+}
+
+// DeleteWebhook deletes a webhook with its deliveries
+func (w *WebhookMgm) DeleteWebhook(
+	ctx context.Context, principal *UserPrincipal, tenantID string, webhookID string,
+) error {
+	katapp.Logger(ctx).Info("deleting webhook",
+		"principal", principal.String(), "tenantID", tenantID, "webhookID", webhookID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return err
+	}
+	return w.txPort.Run(ctx, func(tx pgx.Tx) error {
+		webhook, err := w.getExistingWebhook(ctx, tx, tenantID, webhookID)
+		if err != nil {
+			return err
+		}
+		if err := w.webhookPersist.DeleteWebhook(ctx, tx, tenantID, webhookID); err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, w.auditPersist, tx, auditEntry{
+			action:     model.AuditActionWebhookDeleted,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetWebhook,
+			targetID:   webhookID,
+			diff: auditDiff{}.
+				deleted("url", webhook.URL).
+				deleted("eventTypes", webhook.EventTypes),
+		})
+	})
+}
+
+// ListWebhookDeliveries returns a paginated delivery log of a webhook, newest first
+func (w *WebhookMgm) ListWebhookDeliveries(
+	ctx context.Context, principal *UserPrincipal, tenantID string, webhookID string,
+	params *swagger.ListWebhookDeliveriesParams,
+) (*swagger.WebhookDeliveriesResponse, error) {
+	katapp.Logger(ctx).Debug("listing webhook deliveries",
+		"principal", principal.String(), "tenantID", tenantID, "webhookID", webhookID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	var status *string
+	if params.Status != nil {
+		switch *params.Status {
+		case swagger.ListWebhookDeliveriesParamsStatusPending, swagger.ListWebhookDeliveriesParamsStatusDelivered,
+			swagger.ListWebhookDeliveriesParamsStatusDead:
+			status = lo.ToPtr(string(*params.Status))
+		default:
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, "invalid status, pending, delivered or dead expected")
+		}
+	}
+
+	page := lo.FromPtrOr(params.Page, 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := lo.FromPtrOr(params.Limit, 20)
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	var deliveries []*model.WebhookDelivery
+	var total int
+	err := w.txPort.Run(ctx, func(tx pgx.Tx) error {
+		if _, err := w.getExistingWebhook(ctx, tx, tenantID, webhookID); err != nil {
+			return err
+		}
+		var err error
+		deliveries, total, err = w.webhookPersist.ListWebhookDeliveries(
+			ctx, tx, webhookID, status, (page-1)*limit, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]swagger.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = *webhookDeliveryToWebhookDeliveryResponse(delivery)
+	}
+	pagination := swagger.NewPaginationInfoBuilder().
+		Limit(limit).
+		Page(page).
+		Total(total).
+		TotalPages((total + limit - 1) / limit).
+		Build()
+	return swagger.NewWebhookDeliveriesResponseBuilder().
+		Items(items).
+		Pagination(*pagination).
+		Build(), nil
+}
+
+// RedeliverWebhookDelivery queues the event of a delivery as a new delivery due right away, with all attempts
+// available. The event keeps its ID, so that receivers can tell duplicates.
+func (w *WebhookMgm) RedeliverWebhookDelivery(
+	ctx context.Context, principal *UserPrincipal, tenantID string, webhookID string, deliveryID string,
+) (*swagger.WebhookDeliveryResponse, error) {
+	katapp.Logger(ctx).Info("re-delivering webhook event", "principal", principal.String(),
+		"tenantID", tenantID, "webhookID", webhookID, "deliveryID", deliveryID)
+
+	if err := checkWebhookPermission(ctx, principal, tenantID); err != nil {
+		return nil, err
+	}
+	redelivery, err := outport.TxWithResult(ctx, w.txPort, func(tx pgx.Tx) (*model.WebhookDelivery, error) {
+		if _, err := w.getExistingWebhook(ctx, tx, tenantID, webhookID); err != nil {
+			return nil, err
+		}
+		delivery, err := w.webhookPersist.GetWebhookDeliveryByID(ctx, tx, webhookID, deliveryID)
+		if err != nil {
+			return nil, err
+		}
+		if delivery == nil {
+			return nil, katapp.NewErr(katapp.ErrNotFound, "webhook delivery not found")
+		}
+
+		redelivery := newWebhookDelivery(webhookID, delivery.EventID, delivery.EventType, delivery.Payload, time.Now())
+		err = w.webhookPersist.CreateWebhookDeliveries(ctx, tx, []*model.WebhookDelivery{redelivery})
+		if err != nil {
+			return nil, err
+		}
+		err = recordAuditEvent(ctx, w.auditPersist, tx, auditEntry{
+			action:     model.AuditActionWebhookRedelivered,
+			principal:  principal,
+			tenantID:   tenantID,
+			targetType: model.AuditTargetWebhook,
+			targetID:   webhookID,
+			diff: auditDiff{}.
+				created("eventId", delivery.EventID).
+				created("deliveryId", redelivery.ID),
+		})
+		return redelivery, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhookDeliveryToWebhookDeliveryResponse(redelivery), nil
+}
+
+func (w *WebhookMgm) getExistingWebhook(
+	ctx context.Context, tx pgx.Tx, tenantID string, webhookID string,
+) (*model.Webhook, error) {
+	webhook, err := w.webhookPersist.GetWebhookByID(ctx, tx, tenantID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, katapp.NewErr(katapp.ErrNotFound, "webhook not found")
+	}
+	return webhook, nil
+}
+
+// checkWebhookPermission allows managing webhooks of a tenant only to principals with webhooks:manage in it
+func checkWebhookPermission(ctx context.Context, principal *UserPrincipal, tenantID string) error {
+	if !principal.HasTenantPermission(model.PermissionWebhooksManage, tenantID) {
+		msg := "insufficient permissions to manage webhooks of the tenant"
+		katapp.Logger(ctx).Warn(msg, "principal", principal.String(), "tenantID", tenantID)
+		return katapp.NewErr(katapp.ErrNoPermissions, msg)
+	}
+	return nil
+}
+
+// validateWebhookURL accepts absolute HTTP and HTTPS URLs only
+func validateWebhookURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", katapp.NewErr(katapp.ErrInvalidInput, "invalid webhook URL, absolute HTTP or HTTPS URL expected")
+	}
+	return rawURL, nil
+}
+
+// validateWebhookEventTypes requires at least one known event type and drops duplicates
+func validateWebhookEventTypes(eventTypes []swagger.WebhookEventType) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, katapp.NewErr(katapp.ErrInvalidInput, "at least one event type is required")
+	}
+	result := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !slices.Contains(model.WebhookEventTypes, string(eventType)) {
+			return nil, katapp.NewErr(katapp.ErrInvalidInput, fmt.Sprintf("unknown event type %q", eventType))
+		}
+		result = append(result, string(eventType))
+	}
+	return lo.Uniq(result), nil
+}
+
+func validateWebhookSecret(secret string) (string, error) {
+	if len(secret) < minWebhookSecretLength {
+		return "", katapp.NewErr(katapp.ErrInvalidInput,
+			fmt.Sprintf("webhook secret must be at least %d characters long", minWebhookSecretLength))
+	}
+	return secret, nil
+}
+
+// generateWebhookSecret generates a secure random secret for signing webhook events
+func generateWebhookSecret() string {
+	b := make([]byte, 32) // 256-bit secret
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func webhookToWebhookResponse(webhook *model.Webhook) *swagger.WebhookResponse {
+	eventTypes := make([]swagger.WebhookEventType, len(webhook.EventTypes))
+	for i, eventType := range webhook.EventTypes {
+		eventTypes[i] = swagger.WebhookEventType(eventType)
+	}
+	return swagger.NewWebhookResponseBuilder().
+		Active(webhook.Active).
+		CreatedAt(webhook.CreatedAt).
+		EventTypes(eventTypes).
+		Id(webhook.ID).
+		TenantId(webhook.TenantID).
+		UpdatedAt(webhook.UpdatedAt).
+		Url(webhook.URL).
+		Build()
+}
+
+func webhookDeliveryToWebhookDeliveryResponse(delivery *model.WebhookDelivery) *swagger.WebhookDeliveryResponse {
+	return swagger.NewWebhookDeliveryResponseBuilder().
+		Attempts(delivery.Attempts).
+		CreatedAt(delivery.CreatedAt).
+		DeliveredAt(delivery.DeliveredAt).
+		EventId(delivery.EventID).
+		EventType(swagger.WebhookEventType(delivery.EventType)).
+		Id(delivery.ID).
+		LastError(delivery.LastError).
+		LastStatusCode(delivery.LastStatusCode).
+		NextAttemptAt(delivery.NextAttemptAt).
+		Status(swagger.WebhookDeliveryResponseStatus(delivery.Status)).
+		WebhookId(delivery.WebhookID).
+		Build()
+}
@@ -1,6 +1,8 @@
 package infra
 
 import (
+	"context"
+	"fmt"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/apiserver"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
@@ -8,6 +10,7 @@ import (
 	"github.com/samber/slog-zap/v2"
 	"go.uber.org/zap"
 	"log/slog"
+	"net"
 	"time"
 )
 
@@ -30,6 +33,11 @@ func Start(deployment string, overrides func(cfg *app.Config), loaded chan struc
 
 	uc := usecase.NewUseCases(cfg, di.Ports)
 
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+	defer stopDispatcher()
+	go uc.OutboxMgm.RunDispatcher(dispatcherCtx)
+	go uc.WebhookMgm.RunDispatcher(dispatcherCtx)
+
 	server := apiserver.Start(ctx, uc)
 
 	// needed for integration tests only
@@ -52,16 +60,98 @@ func validateMandatoryConfig(cfg *app.Config) {
 	if cfg.Database.Password == "_" || cfg.Database.Password == "" {
 		panic("database.password is not set")
 	}
-	if cfg.Credentials.JwtSecret == "_" || cfg.Credentials.JwtSecret == "" {
-		panic("credentials.secret is not set")
+	switch cfg.Credentials.JwtAlgorithm {
+	case "RS256", "EdDSA":
+		if cfg.Credentials.JwtPrivateKey == "_" || cfg.Credentials.JwtPrivateKey == "" {
+			panic("credentials.jwtPrivateKey is not set")
+		}
+	default:
+		if cfg.Credentials.JwtSecret == "_" || cfg.Credentials.JwtSecret == "" {
+			panic("credentials.secret is not set")
+		}
+	}
+	validateMailerConfig(cfg)
+	throttle := cfg.SignInThrottle
+	if throttle.MaxUserFailures > 0 || throttle.MaxIPFailures > 0 {
+		if throttle.FailureWindow <= 0 || throttle.LockoutDuration <= 0 {
+			panic("signInThrottle: failureWindow and lockoutDuration must be set")
+		}
+		if throttle.MaxLockoutDuration != 0 && throttle.MaxLockoutDuration < throttle.LockoutDuration {
+			panic("signInThrottle: maxLockoutDuration must not be less than lockoutDuration")
+		}
+	}
+	confirmation := cfg.EmailConfirmation
+	if confirmation.MaxFailedAttempts < 0 || confirmation.MaxSendsPerDay < 0 || confirmation.ResendCooldown < 0 {
+		panic("emailConfirmation: maxFailedAttempts, resendCooldown and maxSendsPerDay must not be negative")
+	}
+	outbox := cfg.Outbox
+	if outbox.PollInterval <= 0 || outbox.BatchSize <= 0 || outbox.MaxAttempts <= 0 || outbox.ClaimTimeout <= 0 {
+		panic("outbox: pollInterval, batchSize, maxAttempts and claimTimeout must be positive")
+	}
+	if outbox.RetryBackoff <= 0 || outbox.MaxRetryBackoff < outbox.RetryBackoff {
+		panic("outbox: retryBackoff must be positive and maxRetryBackoff must not be less than retryBackoff")
 	}
-	if cfg.GCloud.ServiceJson == "_" || cfg.GCloud.ServiceJson == "" {
-		panic("gcloud.serviceJson is not set")
+	webhook := cfg.Webhook
+	if webhook.PollInterval <= 0 || webhook.BatchSize <= 0 || webhook.MaxAttempts <= 0 || webhook.ClaimTimeout <= 0 ||
+		webhook.RequestTimeout <= 0 {
+		panic("webhook: pollInterval, batchSize, maxAttempts, claimTimeout and requestTimeout must be positive")
 	}
-	if cfg.GCloud.Email.User == "_" || cfg.GCloud.Email.User == "" {
-		panic("gcloud.email.user is not set")
+	if webhook.RetryBackoff <= 0 || webhook.MaxRetryBackoff < webhook.RetryBackoff {
+		panic("webhook: retryBackoff must be positive and maxRetryBackoff must not be less than retryBackoff")
 	}
-	if cfg.GCloud.Email.From == "_" || cfg.GCloud.Email.From == "" {
-		panic("gcloud.email.from is not set")
+	if webhook.ClaimTimeout <= webhook.RequestTimeout {
+		panic("webhook: claimTimeout must be greater than requestTimeout")
+	}
+	for _, proxy := range cfg.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			panic(fmt.Sprintf("trustedProxies: invalid CIDR range %q", proxy))
+		}
+	}
+	providerIDs := make(map[string]bool)
+	for _, provider := range cfg.IdentityProviders {
+		if provider.ID == "" || provider.TenantID == "" || provider.ClientID == "" {
+			panic("identityProviders: id, tenantId and clientId must be set")
+		}
+		if provider.AuthURL == "" || provider.TokenURL == "" || provider.UserInfoURL == "" {
+			panic(fmt.Sprintf("identityProviders.%s: authUrl, tokenUrl and userInfoUrl must be set", provider.ID))
+		}
+		if providerIDs[provider.ID] {
+			panic(fmt.Sprintf("identityProviders.%s: duplicate provider id", provider.ID))
+		}
+		providerIDs[provider.ID] = true
+	}
+}
+
+func validateMailerConfig(cfg *app.Config) {
+	switch cfg.Mailer.Provider {
+	case app.MailerProviderGmail:
+		if cfg.GCloud.ServiceJson == "_" || cfg.GCloud.ServiceJson == "" {
+			panic("gcloud.serviceJson is not set")
+		}
+		if cfg.GCloud.Email.User == "_" || cfg.GCloud.Email.User == "" {
+			panic("gcloud.email.user is not set")
+		}
+		if cfg.GCloud.Email.From == "_" || cfg.GCloud.Email.From == "" {
+			panic("gcloud.email.from is not set")
+		}
+	case app.MailerProviderSMTP:
+		smtp := cfg.Mailer.SMTP
+		if smtp.Host == "_" || smtp.Host == "" {
+			panic("mailer.smtp.host is not set")
+		}
+		if smtp.Port <= 0 {
+			panic("mailer.smtp.port is not set")
+		}
+		if smtp.From == "_" || smtp.From == "" {
+			panic("mailer.smtp.from is not set")
+		}
+		switch smtp.TLS {
+		case "", app.SMTPTLSStartTLS, app.SMTPTLSImplicit, app.SMTPTLSNone:
+		default:
+			panic(fmt.Sprintf("mailer.smtp.tls: unknown mode %q", smtp.TLS))
+		}
+	case app.MailerProviderFile, app.MailerProviderMemory:
+	default:
+		panic(fmt.Sprintf("mailer.provider: unknown provider %q", cfg.Mailer.Provider))
 	}
 }
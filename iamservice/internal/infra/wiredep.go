@@ -2,8 +2,10 @@ package infra
 
 import (
 	"context"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/federation"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/mailer"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/persist"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webhook"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
 	"github.com/mobiletoly/gokatana/katapp"
@@ -31,8 +33,21 @@ func WireDependencies(ctx context.Context, cfg *app.Config) *Dependencies {
 		Ports: outport.NewPortsBuilder().
 			AuthUserPersist(persist.NewAuthUserAdapter(db)).
 			UserProfilePersist(persist.NewUserProfileAdapter(db)).
+			OAuthPersist(persist.NewOAuthAdapter(db)).
+			MFAPersist(persist.NewMFAAdapter(db)).
+			SignInThrottlePersist(persist.NewSignInThrottleAdapter(db)).
+			AuditPersist(persist.NewAuditAdapter(db)).
+			TokenRevocationPersist(persist.NewTokenRevocationAdapter(db)).
+			ServiceClientPersist(persist.NewServiceClientAdapter(db)).
+			APIKeyPersist(persist.NewAPIKeyAdapter(db)).
+			RolePersist(persist.NewRoleAdapter(db)).
+			InvitationPersist(persist.NewInvitationAdapter(db)).
+			OutboxPersist(persist.NewOutboxAdapter(db)).
+			WebhookPersist(persist.NewWebhookAdapter(db)).
+			Federation(federation.NewFederationClient()).
 			Tx(persist.NewTxAdapter(db)).
-			Mailer(mailer.NewMailer(ctx, &cfg.GCloud)).
+			Mailer(mailer.NewMailer(ctx, &cfg.Mailer, &cfg.GCloud)).
+			WebhookClient(webhook.NewWebhookClient(&cfg.Webhook)).
 			Build(),
 	}
 }
@@ -0,0 +1,191 @@
+package intgr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runAPIKeyTests runs tests for personal API keys of users
+func runAPIKeyTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) *swagger.SignInResponse {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		require.NoError(t, err)
+		return authResp
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	apiKeyHeader := func(key string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"ApiKey " + key},
+		}
+	}
+	createKey := func(
+		t *testing.T, accessToken string, userID string, req *swagger.CreateApiKeyRequest,
+	) (*swagger.CreateApiKeyResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateApiKeyRequest, swagger.CreateApiKeyResponse](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys", bearer(accessToken), req)
+		return resp, err
+	}
+	listKeys := func(t *testing.T, headers map[string][]string, userID string) *swagger.ApiKeysResponse {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.ApiKeysResponse](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys", headers)
+		require.NoError(t, err)
+		return resp
+	}
+	getMe := func(headers map[string][]string) (*swagger.AuthUserResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+			ctx, &appConfig.Server, "api/v1/users/me", headers)
+		return resp, err
+	}
+
+	adminAuth := signIn(t, "testadmin@example.com")
+	userID := createAndConfirmUser(t, env, "apikey-user@example.com", "qazwsxedc", "Api", "Key")
+	_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, any](
+		ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles", bearer(adminAuth.AccessToken),
+		&map[string]string{"roleName": "admin"})
+	require.NoError(t, err)
+	userAuth := signIn(t, "apikey-user@example.com")
+
+	created, err := createKey(t, userAuth.AccessToken, userID, &swagger.CreateApiKeyRequest{
+		Name: "deploy-script",
+	})
+	require.NoError(t, err)
+
+	t.Run("POST /users/{userId}/api-keys", func(t *testing.T) {
+		t.Run("must create key with all roles of the user", func(t *testing.T) {
+			assert.True(t, strings.HasPrefix(created.Key, "iamk_"))
+			assert.True(t, strings.HasPrefix(created.Key, created.ApiKey.Prefix))
+			assert.Equal(t, "deploy-script", created.ApiKey.Name)
+			assert.ElementsMatch(t, []string{"user", "admin"}, created.ApiKey.Roles)
+			assert.Nil(t, created.ApiKey.ExpiresAt)
+			assert.Nil(t, created.ApiKey.LastUsedAt)
+		})
+
+		t.Run("must fail with role not assigned to the user", func(t *testing.T) {
+			_, err := createKey(t, userAuth.AccessToken, userID, &swagger.CreateApiKeyRequest{
+				Name:  "root-script",
+				Roles: &[]string{"sysadmin"},
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with past expiresAt", func(t *testing.T) {
+			past := time.Now().Add(-time.Minute)
+			_, err := createKey(t, userAuth.AccessToken, userID, &swagger.CreateApiKeyRequest{
+				Name:      "expired-script",
+				ExpiresAt: &past,
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with 403 Forbidden when authenticated with api key", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateApiKeyRequest, swagger.CreateApiKeyResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys", apiKeyHeader(created.Key),
+				&swagger.CreateApiKeyRequest{Name: "minted-script"})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for other user", func(t *testing.T) {
+			_, err := createKey(t, adminAuth.AccessToken, userID, &swagger.CreateApiKeyRequest{
+				Name: "foreign-script",
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("ApiKey authorization", func(t *testing.T) {
+		t.Run("must authenticate as the user", func(t *testing.T) {
+			me, err := getMe(apiKeyHeader(created.Key))
+			require.NoError(t, err)
+			assert.Equal(t, userID, me.Id)
+		})
+
+		t.Run("must be limited to the roles of the key", func(t *testing.T) {
+			userOnly, err := createKey(t, userAuth.AccessToken, userID, &swagger.CreateApiKeyRequest{
+				Name:  "read-script",
+				Roles: &[]string{"user"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"user"}, userOnly.ApiKey.Roles)
+
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/lockout", apiKeyHeader(userOnly.Key))
+			kathttpc.AssertStatusForbidden(t, err)
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/lockout", apiKeyHeader(created.Key))
+			assert.NoError(t, err)
+		})
+
+		t.Run("must fail with unknown key", func(t *testing.T) {
+			_, err := getMe(apiKeyHeader("iamk_0000000000000000"))
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("must record last use", func(t *testing.T) {
+			keys := listKeys(t, bearer(userAuth.AccessToken), userID)
+			item, ok := lo.Find(keys.Items, func(item swagger.ApiKeyResponse) bool {
+				return item.Id == created.ApiKey.Id
+			})
+			require.True(t, ok)
+			require.NotNil(t, item.LastUsedAt)
+			assert.WithinDuration(t, time.Now(), *item.LastUsedAt, time.Minute)
+		})
+	})
+
+	t.Run("GET /users/{userId}/api-keys", func(t *testing.T) {
+		t.Run("admin must list keys of users in the tenant without the keys", func(t *testing.T) {
+			resp, _, err := kathttpc.LocalHttpJsonGetRequest[map[string][]map[string]any](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys", bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			require.Len(t, (*resp)["items"], 2)
+			for _, item := range (*resp)["items"] {
+				assert.NotContains(t, item, "key")
+				assert.NotContains(t, item, "keyHash")
+			}
+		})
+
+		t.Run("regular user must fail with 403 Forbidden for other user", func(t *testing.T) {
+			otherAuth := signIn(t, "testuser@example.com")
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.ApiKeysResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys", bearer(otherAuth.AccessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("DELETE /users/{userId}/api-keys/{keyId}", func(t *testing.T) {
+		t.Run("revoked key must be rejected", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys/"+created.ApiKey.Id, apiKeyHeader(created.Key))
+			require.NoError(t, err)
+
+			_, err = getMe(apiKeyHeader(created.Key))
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("must fail with 404 Not Found for unknown key", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/api-keys/"+created.ApiKey.Id,
+				bearer(userAuth.AccessToken))
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+
+}
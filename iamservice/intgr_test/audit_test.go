@@ -0,0 +1,133 @@
+package intgr_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runAuditTests runs tests for the audit log API
+func runAuditTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string, tenantID string) map[string][]string {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: tenantID,
+			})
+		require.NoError(t, err)
+		return map[string][]string{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+	}
+	listAudit := func(t *testing.T, headers map[string][]string, query url.Values) *swagger.AuditEventsResponse {
+		events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+			ctx, &appConfig.Server, "api/v1/audit?"+query.Encode(), headers)
+		require.NoError(t, err)
+		return events
+	}
+
+	sysadminHeaders := signIn(t, "john.doe.sysadmin@example.com", "default-tenant")
+	adminHeaders := signIn(t, "testadmin@example.com", "default-tenant")
+	userHeaders := signIn(t, "testuser@example.com", "default-tenant")
+
+	userID := createAndConfirmUser(t, env, "audited-user@example.com", "qazwsxedc", "Audited", "User")
+
+	t.Run("role changes must be recorded with actor and diff", func(t *testing.T) {
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, any](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles", adminHeaders,
+			&map[string]string{"roleName": "admin"})
+		require.NoError(t, err)
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles/admin", adminHeaders)
+		require.NoError(t, err)
+
+		events := listAudit(t, adminHeaders, url.Values{"targetId": {userID}})
+		actions := make([]string, len(events.Items))
+		for i, event := range events.Items {
+			actions[i] = event.Action
+		}
+		assert.Contains(t, actions, model.AuditActionUserRoleAssigned)
+		assert.Contains(t, actions, model.AuditActionUserRoleRemoved)
+
+		assigned := listAudit(t, adminHeaders, url.Values{
+			"targetId": {userID},
+			"action":   {model.AuditActionUserRoleAssigned},
+		})
+		require.Len(t, assigned.Items, 1)
+		event := assigned.Items[0]
+		assert.Equal(t, "default-tenant", *event.TenantId)
+		assert.NotNil(t, event.ActorUserId)
+		assert.Contains(t, event.ActorRoles, "admin")
+		assert.NotNil(t, event.IpAddress)
+		assert.Contains(t, event.Diff, "role")
+	})
+
+	t.Run("failed sign in must be recorded", func(t *testing.T) {
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    "testuser_different_tenant@example.com",
+				Password: "wrongpassword",
+				TenantId: "test-tenant",
+			})
+		kathttpc.AssertStatusUnauthorized(t, err)
+
+		events := listAudit(t, sysadminHeaders, url.Values{
+			"tenantId": {"test-tenant"},
+			"action":   {model.AuditActionSignInFailed},
+		})
+		require.NotEmpty(t, events.Items)
+		event := events.Items[0]
+		assert.Nil(t, event.ActorUserId)
+		assert.Equal(t, "invalid_credentials", *event.Reason)
+		assert.Equal(t, model.AuditTargetUser, *event.TargetType)
+	})
+
+	t.Run("admin must see own tenant only", func(t *testing.T) {
+		events := listAudit(t, adminHeaders, url.Values{"limit": {"100"}})
+		require.NotEmpty(t, events.Items)
+		for _, event := range events.Items {
+			assert.Equal(t, "default-tenant", *event.TenantId)
+		}
+
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+			ctx, &appConfig.Server, "api/v1/audit?tenantId=test-tenant", adminHeaders)
+		kathttpc.AssertStatusForbidden(t, err)
+	})
+
+	t.Run("sysadmin must be able to filter by tenant", func(t *testing.T) {
+		events := listAudit(t, sysadminHeaders, url.Values{"tenantId": {"test-tenant"}, "limit": {"100"}})
+		require.NotEmpty(t, events.Items)
+		for _, event := range events.Items {
+			assert.Equal(t, "test-tenant", *event.TenantId)
+		}
+	})
+
+	t.Run("pagination must be applied", func(t *testing.T) {
+		events := listAudit(t, sysadminHeaders, url.Values{"page": {"1"}, "limit": {"1"}})
+		assert.Len(t, events.Items, 1)
+		assert.Equal(t, 1, events.Pagination.Limit)
+		assert.Greater(t, events.Pagination.Total, 1)
+		assert.Equal(t, events.Pagination.Total, events.Pagination.TotalPages)
+	})
+
+	t.Run("invalid time range must fail with 400 Bad Request", func(t *testing.T) {
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+			ctx, &appConfig.Server, "api/v1/audit?from=yesterday", adminHeaders)
+		kathttpc.AssertStatusBadRequest(t, err)
+	})
+
+	t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+			ctx, &appConfig.Server, "api/v1/audit", userHeaders)
+		kathttpc.AssertStatusForbidden(t, err)
+	})
+}
@@ -248,6 +248,14 @@ func runAuthenticationTests(t *testing.T, env *TestEnvironment) {
 			// UserID should remain the same
 			assert.Equal(t, authResp.UserId, newAuthResp.UserId)
 		})
+		t.Run("refresh token must not authenticate API requests", func(t *testing.T) {
+			refreshHeaders := map[string][]string{
+				"Authorization": {"Bearer " + authResp.RefreshToken},
+			}
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", refreshHeaders)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
 		t.Run("with invalid refresh token must fail with 401 Unauthorized", func(t *testing.T) {
 			invalidRefreshReq := &swagger.TokenRefreshRequest{
 				RefreshToken: "invalid-token",
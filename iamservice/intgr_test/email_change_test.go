@@ -0,0 +1,236 @@
+package intgr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runEmailChangeTests runs tests for changing the email address of a user using mock emails
+func runEmailChangeTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) (*swagger.SignInResponse, error) {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		return authResp, err
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	requestChange := func(
+		accessToken string, userID string, req *swagger.EmailChangeRequest,
+	) (*swagger.EmailChangeResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.EmailChangeRequest, swagger.EmailChangeResponse](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/email-change", bearer(accessToken), req)
+		return resp, err
+	}
+	confirmChange := func(accessToken string, userID string, code string) (*swagger.AuthUserResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ConfirmEmailChangeRequest, swagger.AuthUserResponse](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/email-change/confirm", bearer(accessToken),
+			&swagger.ConfirmEmailChangeRequest{Code: code})
+		return resp, err
+	}
+	lastEmailTo := func(t *testing.T, to string) MockEmail {
+		emails, err := getMockEmailsTo(to)
+		require.NoError(t, err)
+		require.NotEmpty(t, emails)
+		return emails[len(emails)-1]
+	}
+
+	oldEmail := "email-change@example.com"
+	newEmail := "email-changed@example.com"
+	userID := createAndConfirmUser(t, env, oldEmail, "qazwsxedc", "Email", "Change")
+	currentAuth, err := signIn(t, oldEmail)
+	require.NoError(t, err)
+	otherAuth, err := signIn(t, oldEmail)
+	require.NoError(t, err)
+	password := "qazwsxedc"
+
+	t.Run("POST /users/{userId}/email-change", func(t *testing.T) {
+		t.Run("must fail with 400 Bad Request for wrong password", func(t *testing.T) {
+			wrongPassword := "wrong-password"
+			_, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+				NewEmail:        types.Email(newEmail),
+				CurrentPassword: &wrongPassword,
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with 400 Bad Request for current email", func(t *testing.T) {
+			_, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+				NewEmail:        types.Email(oldEmail),
+				CurrentPassword: &password,
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with 409 Conflict for email of another user", func(t *testing.T) {
+			_, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+				NewEmail:        "testuser@example.com",
+				CurrentPassword: &password,
+			})
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("other user must fail with 403 Forbidden", func(t *testing.T) {
+			testUserAuth, err := signIn(t, "testuser@example.com")
+			require.NoError(t, err)
+			_, err = requestChange(testUserAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+				NewEmail: types.Email(newEmail),
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must send code to new address and notify old address", func(t *testing.T) {
+			clearMockEmails()
+			source := "ios"
+			resp, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+				NewEmail:        types.Email(newEmail),
+				CurrentPassword: &password,
+				Source:          &source,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, newEmail, string(resp.PendingEmail))
+			require.NoError(t, waitForMockEmail(2, 5))
+
+			confirmation := lastEmailTo(t, newEmail)
+			assert.Contains(t, confirmation.Subject, "Confirm Your New Email Address")
+			code := extractSixDigitCode(confirmation.Body)
+			require.NotEmpty(t, code)
+			notice := lastEmailTo(t, oldEmail)
+			assert.Contains(t, notice.Subject, "Email Address Change Requested")
+			assert.Contains(t, notice.Body, newEmail)
+
+			// Codes of email changes do not confirm sign ups
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.EmailConfirmationRequest, swagger.EmailConfirmationResponse](
+				ctx, &appConfig.Server, "api/v1/auth/confirm-email", nil,
+				&swagger.EmailConfirmationRequest{UserId: userID, Code: code})
+			kathttpc.AssertStatusNotFound(t, err)
+
+			// The address stays unchanged until the change is confirmed
+			_, err = signIn(t, oldEmail)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("POST /users/{userId}/email-change/confirm", func(t *testing.T) {
+		clearMockEmails()
+		_, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+			NewEmail:        types.Email(newEmail),
+			CurrentPassword: &password,
+		})
+		require.NoError(t, err)
+		require.NoError(t, waitForMockEmail(2, 5))
+		code := extractEmailChangeCode(lastEmailTo(t, newEmail).Body)
+		require.NotEmpty(t, code)
+
+		t.Run("must fail with 400 Bad Request for invalid code", func(t *testing.T) {
+			_, err := confirmChange(currentAuth.AccessToken, userID, "invalid-code")
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden", func(t *testing.T) {
+			adminAuth, err := signIn(t, "testadmin@example.com")
+			require.NoError(t, err)
+			_, err = confirmChange(adminAuth.AccessToken, userID, code)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must change email and sign out other sessions", func(t *testing.T) {
+			user, err := confirmChange(currentAuth.AccessToken, userID, code)
+			require.NoError(t, err)
+			assert.Equal(t, newEmail, string(user.Email))
+
+			_, err = signIn(t, newEmail)
+			require.NoError(t, err)
+			_, err = signIn(t, oldEmail)
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			// The current session stays signed in
+			me, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", bearer(currentAuth.AccessToken))
+			require.NoError(t, err)
+			assert.Equal(t, newEmail, string(me.Email))
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+				&swagger.TokenRefreshRequest{RefreshToken: currentAuth.RefreshToken})
+			require.NoError(t, err)
+
+			// Other sessions are signed out
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", bearer(otherAuth.AccessToken))
+			kathttpc.AssertStatusUnauthorized(t, err)
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+				&swagger.TokenRefreshRequest{RefreshToken: otherAuth.RefreshToken})
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("must fail with 400 Bad Request for used code", func(t *testing.T) {
+			_, err := confirmChange(currentAuth.AccessToken, userID, code)
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must record audit events", func(t *testing.T) {
+			adminAuth, err := signIn(t, "testadmin@example.com")
+			require.NoError(t, err)
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?targetId="+userID, bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			assert.True(t, lo.ContainsBy(events.Items, func(e swagger.AuditEventResponse) bool {
+				return e.Action == "user.email_changed"
+			}))
+		})
+	})
+
+	t.Run("POST /users/{userId}/email-change/confirm with wrong codes", func(t *testing.T) {
+		lockedEmail := "email-change-locked@example.com"
+		clearMockEmails()
+		source := "android"
+		_, err := requestChange(currentAuth.AccessToken, userID, &swagger.EmailChangeRequest{
+			NewEmail:        types.Email(lockedEmail),
+			CurrentPassword: &password,
+			Source:          &source,
+		})
+		require.NoError(t, err)
+		require.NoError(t, waitForMockEmail(2, 5))
+		code := extractSixDigitCode(lastEmailTo(t, lockedEmail).Body)
+		require.Len(t, code, 6)
+
+		var wrongCodes []string
+		for i := 0; len(wrongCodes) < appConfig.EmailConfirmation.MaxFailedAttempts; i++ {
+			if wrongCode := fmt.Sprintf("%06d", i); wrongCode != code {
+				wrongCodes = append(wrongCodes, wrongCode)
+			}
+		}
+
+		t.Run("must invalidate the change after too many wrong codes", func(t *testing.T) {
+			for _, wrongCode := range wrongCodes {
+				_, err := confirmChange(currentAuth.AccessToken, userID, wrongCode)
+				kathttpc.AssertStatusBadRequest(t, err)
+			}
+			// Even the correct code is rejected afterwards
+			_, err := confirmChange(currentAuth.AccessToken, userID, code)
+			kathttpc.AssertStatusBadRequest(t, err)
+
+			me, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", bearer(currentAuth.AccessToken))
+			require.NoError(t, err)
+			assert.Equal(t, newEmail, string(me.Email))
+		})
+	})
+}
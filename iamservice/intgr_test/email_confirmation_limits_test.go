@@ -0,0 +1,188 @@
+package intgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttp"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runEmailConfirmationLimitTests runs tests for resending of confirmation codes and limits of wrong guesses
+func runEmailConfirmationLimitTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+	limits := appConfig.EmailConfirmation
+
+	// postJSON sends the request and returns the raw response with the decoded error body (if any)
+	postJSON := func(t *testing.T, path string, reqBody any) (*http.Response, *kathttp.ErrResponse) {
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, path), bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var errResp kathttp.ErrResponse
+		if resp.StatusCode >= http.StatusBadRequest {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+		}
+		return resp, &errResp
+	}
+	resend := func(t *testing.T, email string) (*http.Response, *kathttp.ErrResponse) {
+		return postJSON(t, "api/v1/auth/resend-confirmation", &swagger.ResendConfirmationRequest{
+			Email:    email,
+			TenantId: "default-tenant",
+			Source:   "android",
+		})
+	}
+	confirm := func(t *testing.T, userID string, code string) (*http.Response, *kathttp.ErrResponse) {
+		return postJSON(t, "api/v1/auth/confirm-email", &swagger.EmailConfirmationRequest{
+			UserId: userID,
+			Code:   code,
+		})
+	}
+	signUp := func(t *testing.T, email string) string {
+		clearMockEmails()
+		signupResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignUpRequest, swagger.SignUpResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signup", nil, &swagger.SignUpRequest{
+				Email:     email,
+				Password:  "qazwsxedc",
+				FirstName: "Limited",
+				LastName:  "User",
+				TenantId:  "default-tenant",
+				Source:    "android",
+			})
+		require.NoError(t, err)
+		return signupResp.UserId
+	}
+	lastCode := func(t *testing.T, email string) string {
+		emails, err := getMockEmailsTo(email)
+		require.NoError(t, err)
+		require.NotEmpty(t, emails)
+		code := extractSixDigitCode(emails[len(emails)-1].Body)
+		require.Len(t, code, 6)
+		return code
+	}
+	// wrongCode returns a 6-digit code that differs from the code sent
+	wrongCode := func(code string) string {
+		n, _ := strconv.Atoi(code)
+		return strconv.Itoa(100000 + (n+1)%900000)
+	}
+
+	t.Run("resend confirmation", func(t *testing.T) {
+		email := "resend-limits@example.com"
+		userID := signUp(t, email)
+		firstCode := lastCode(t, email)
+
+		t.Run("resend within cooldown must succeed without sending email", func(t *testing.T) {
+			// Throttled requests look the same as requests for unknown users
+			resp, _ := resend(t, email)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			emails, err := getMockEmailsTo(email)
+			require.NoError(t, err)
+			assert.Len(t, emails, 1)
+		})
+		t.Run("resend after cooldown must send a new code", func(t *testing.T) {
+			time.Sleep(limits.ResendCooldown)
+			resp, _ := resend(t, email)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			emails, err := getMockEmailsTo(email)
+			require.NoError(t, err)
+			assert.Len(t, emails, 2)
+			assert.Contains(t, emails[1].Subject, "Your Confirmation Code")
+		})
+		t.Run("previous code must not confirm email", func(t *testing.T) {
+			if firstCode == lastCode(t, email) {
+				t.Skip("new code matches the previous one")
+			}
+			resp, errResp := confirm(t, userID, firstCode)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			assert.Equal(t, int64(1001), errResp.AppCode)
+		})
+		t.Run("resend over daily limit must succeed without sending email", func(t *testing.T) {
+			for sent := 2; sent < limits.MaxSendsPerDay; sent++ {
+				time.Sleep(limits.ResendCooldown)
+				resp, _ := resend(t, email)
+				require.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+			time.Sleep(limits.ResendCooldown)
+			resp, _ := resend(t, email)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			emails, err := getMockEmailsTo(email)
+			require.NoError(t, err)
+			assert.Len(t, emails, limits.MaxSendsPerDay)
+		})
+		t.Run("latest code must confirm email", func(t *testing.T) {
+			resp, _ := confirm(t, userID, lastCode(t, email))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+		t.Run("resend for confirmed user must succeed without sending email", func(t *testing.T) {
+			resp, _ := resend(t, email)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			emails, err := getMockEmailsTo(email)
+			require.NoError(t, err)
+			assert.Len(t, emails, limits.MaxSendsPerDay)
+		})
+		t.Run("resend for unknown user must succeed", func(t *testing.T) {
+			resp, _ := resend(t, "resend-unknown@example.com")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+		t.Run("resend with invalid source must fail with 400 Bad Request", func(t *testing.T) {
+			resp, _ := postJSON(t, "api/v1/auth/resend-confirmation", &swagger.ResendConfirmationRequest{
+				Email:    email,
+				TenantId: "default-tenant",
+				Source:   "desktop",
+			})
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+
+	t.Run("failed confirmation attempts", func(t *testing.T) {
+		email := "confirm-attempts@example.com"
+		userID := signUp(t, email)
+		code := lastCode(t, email)
+
+		t.Run("wrong codes below the limit must fail with 404 Not Found", func(t *testing.T) {
+			for i := 0; i < limits.MaxFailedAttempts-1; i++ {
+				resp, errResp := confirm(t, userID, wrongCode(code))
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+				assert.Equal(t, int64(1001), errResp.AppCode)
+			}
+		})
+		t.Run("reaching the limit must invalidate the code", func(t *testing.T) {
+			resp, errResp := confirm(t, userID, wrongCode(code))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, int64(1004), errResp.AppCode)
+
+			// Correct code is rejected as well once invalidated
+			resp, errResp = confirm(t, userID, code)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, int64(1004), errResp.AppCode)
+		})
+		t.Run("new code must confirm email", func(t *testing.T) {
+			time.Sleep(limits.ResendCooldown)
+			resp, _ := resend(t, email)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			resp, _ = confirm(t, userID, lastCode(t, email))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			// Used code is reported as such
+			resp, errResp := confirm(t, userID, lastCode(t, email))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, int64(1003), errResp.AppCode)
+		})
+	})
+}
@@ -2,6 +2,7 @@ package intgr_test
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,17 +17,43 @@ type MockEmail struct {
 	Subject     string    `json:"subject"`
 	Body        string    `json:"body"`
 	ContentType string    `json:"contentType"`
+	TextBody    string    `json:"textBody"`
+	ReplyTo     string    `json:"replyTo"`
+	Raw         string    `json:"raw"`
 	SentAt      time.Time `json:"sentAt"`
 }
 
+// waitForOutboxToDrain waits until the dispatcher has delivered all queued emails. Emails are sent in the
+// background after the request that queued them completes.
+func waitForOutboxToDrain() error {
+	for i := 0; i < 100; i++ { // Check every 100ms
+		var pending int
+		err := testDB.QueryRow(context.Background(),
+			"SELECT count(*) FROM iam.outbox_email WHERE status = 'pending'").Scan(&pending)
+		if err != nil {
+			return fmt.Errorf("failed to count queued emails: %w", err)
+		}
+		if pending == 0 {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("timeout waiting for queued emails to be delivered")
+}
+
 // clearMockEmails removes all mock email files to start fresh
 func clearMockEmails() {
+	// Emails still being delivered would be written after the file is removed
+	_ = waitForOutboxToDrain()
 	// Remove the emails file
 	_ = os.Remove("test-emails/emails.json")
 }
 
-// getAllMockEmails reads all mock emails from the file
+// getAllMockEmails reads all mock emails from the file once the queued emails are delivered
 func getAllMockEmails() ([]MockEmail, error) {
+	if err := waitForOutboxToDrain(); err != nil {
+		return nil, err
+	}
 	file, err := os.Open("test-emails/emails.json")
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -116,6 +143,35 @@ func extractConfirmationURL(emailBody string) string {
 	return strings.TrimSpace(matches)
 }
 
+// extractPasswordResetURL extracts the password reset URL from email body
+func extractPasswordResetURL(emailBody string) string {
+	re := regexp.MustCompile(`/web/user/auth/reset-password\?tenantId=[^"&\s]+(?:&amp;|&)email=[^"&\s]+(?:&amp;|&)code=[^"&\s]+`)
+	matches := re.FindString(emailBody)
+	// Replace HTML-encoded ampersands with regular ones
+	matches = strings.ReplaceAll(matches, "&amp;", "&")
+	return strings.TrimSpace(matches)
+}
+
+// extractInvitationToken extracts the token of the invitation link from email body
+func extractInvitationToken(emailBody string) string {
+	re := regexp.MustCompile(`/web/user/auth/accept-invitation\?token=([^"&\s<]+)`)
+	matches := re.FindStringSubmatch(emailBody)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// extractEmailChangeCode extracts the code of the email change confirmation link from email body
+func extractEmailChangeCode(emailBody string) string {
+	re := regexp.MustCompile(`/web/user/account/confirm-email-change\?code=([^"&\s<]+)`)
+	matches := re.FindStringSubmatch(emailBody)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
 // extractUserIDFromConfirmationURL extracts the user ID from a confirmation URL
 func extractUserIDFromConfirmationURL(confirmationURL string) string {
 	re := regexp.MustCompile(`userId=([^&]+)`)
@@ -175,7 +231,12 @@ func validateEmailContent(email *MockEmail, expectedRecipient string, expectedSu
 		return false
 	}
 
-	return true
+	// Plain-text alternative is sent next to the HTML body
+	if !strings.Contains(email.TextBody, "IAMService") || strings.Contains(email.TextBody, "<") {
+		return false
+	}
+
+	return strings.Contains(email.Raw, "multipart/alternative")
 }
 
 // validateWebEmailContent validates web-specific email content
@@ -0,0 +1,456 @@
+package intgr_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stubIdPAddr         = "127.0.0.1:18089"
+	stubIdPClientID     = "iamservice"
+	stubIdPClientSecret = "stub-secret"
+)
+
+// stubIdPUser holds the claims returned by the stub identity provider's userinfo endpoint
+type stubIdPUser struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+type stubIdPGrant struct {
+	codeChallenge string
+	redirectURI   string
+	user          stubIdPUser
+}
+
+// stubIdentityProvider is a minimal upstream OAuth2 provider with token and userinfo endpoints.
+// Authorization codes are minted directly by tests instead of going through an authorize page.
+type stubIdentityProvider struct {
+	mu     sync.Mutex
+	codes  map[string]stubIdPGrant
+	tokens map[string]stubIdPUser
+	seq    int
+}
+
+func startStubIdentityProvider(t *testing.T) *stubIdentityProvider {
+	idp := &stubIdentityProvider{
+		codes:  make(map[string]stubIdPGrant),
+		tokens: make(map[string]stubIdPUser),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", idp.tokenHandler)
+	mux.HandleFunc("GET /userinfo", idp.userInfoHandler)
+
+	listener, err := net.Listen("tcp", stubIdPAddr)
+	require.NoError(t, err)
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.Logf("stub identity provider stopped: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	})
+	return idp
+}
+
+// mintCode registers an authorization code as if the user had signed in at the identity provider
+func (s *stubIdentityProvider) mintCode(codeChallenge string, redirectURI string, user stubIdPUser) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	code := fmt.Sprintf("stub-code-%d", s.seq)
+	s.codes[code] = stubIdPGrant{codeChallenge: codeChallenge, redirectURI: redirectURI, user: user}
+	return code
+}
+
+func (s *stubIdentityProvider) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.FormValue("client_id"), r.FormValue("client_secret")
+	}
+	if clientID != stubIdPClientID || clientSecret != stubIdPClientSecret {
+		writeStubIdPError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if r.FormValue("grant_type") != "authorization_code" {
+		writeStubIdPError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	s.mu.Lock()
+	grant, found := s.codes[r.FormValue("code")]
+	delete(s.codes, r.FormValue("code"))
+	s.mu.Unlock()
+	if !found || grant.redirectURI != r.FormValue("redirect_uri") {
+		writeStubIdPError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+	verifierHash := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != grant.codeChallenge {
+		writeStubIdPError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	accessToken := "stub-access-" + grant.user.Sub + "-" + r.FormValue("code")
+	s.mu.Lock()
+	s.tokens[accessToken] = grant.user
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  accessToken,
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+		"refresh_token": "stub-refresh-" + grant.user.Sub,
+	})
+}
+
+func (s *stubIdentityProvider) userInfoHandler(w http.ResponseWriter, r *http.Request) {
+	const bearerPrefix = "Bearer "
+	authHeader := r.Header.Get("Authorization")
+	if len(authHeader) <= len(bearerPrefix) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	user, found := s.tokens[authHeader[len(bearerPrefix):]]
+	s.mu.Unlock()
+	if !found {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(user)
+}
+
+func writeStubIdPError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+// runFederationTests runs tests for sign in through upstream identity providers
+func runFederationTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+	idp := startStubIdentityProvider(t)
+
+	// Do not follow redirects, the tests inspect the Location header
+	noRedirectClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	doGet := func(t *testing.T, path string, cookies ...*http.Cookie) (*http.Response, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, kathttpc.LocalURL(appConfig.Server.Port, path), nil)
+		require.NoError(t, err)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		resp, err := noRedirectClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	findCookie := func(resp *http.Response, name string) *http.Cookie {
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == name && cookie.MaxAge >= 0 {
+				return cookie
+			}
+		}
+		return nil
+	}
+
+	type federatedStart struct {
+		authURL        *url.URL
+		state          string
+		codeChallenge  string
+		redirectURI    string
+		verifierCookie *http.Cookie
+	}
+	start := func(t *testing.T, returnTo string) *federatedStart {
+		query := url.Values{"tenantId": {"default-tenant"}}
+		if returnTo != "" {
+			query.Set("returnTo", returnTo)
+		}
+		resp, _ := doGet(t, "web/user/auth/federated/stub-idp/start?"+query.Encode())
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		authURL, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		verifierCookie := findCookie(resp, "federation_verifier")
+		require.NotNil(t, verifierCookie)
+		return &federatedStart{
+			authURL:        authURL,
+			state:          authURL.Query().Get("state"),
+			codeChallenge:  authURL.Query().Get("code_challenge"),
+			redirectURI:    authURL.Query().Get("redirect_uri"),
+			verifierCookie: verifierCookie,
+		}
+	}
+	callback := func(t *testing.T, state string, code string, verifierCookie *http.Cookie) (*http.Response, string) {
+		query := url.Values{"state": {state}, "code": {code}}
+		if verifierCookie == nil {
+			return doGet(t, "web/user/auth/federated/stub-idp/callback?"+query.Encode())
+		}
+		return doGet(t, "web/user/auth/federated/stub-idp/callback?"+query.Encode(), verifierCookie)
+	}
+	signInWith := func(t *testing.T, user stubIdPUser) (*http.Response, string) {
+		started := start(t, "")
+		code := idp.mintCode(started.codeChallenge, started.redirectURI, user)
+		return callback(t, started.state, code, started.verifierCookie)
+	}
+	getMe := func(t *testing.T, accessToken string) *swagger.AuthUserResponse {
+		headers := map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+		me, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+			ctx, &appConfig.Server, "api/v1/users/me", headers)
+		require.NoError(t, err)
+		return me
+	}
+
+	t.Run("sign-in page", func(t *testing.T) {
+		t.Run("must offer providers configured for the tenant", func(t *testing.T) {
+			_, body := doGet(t, "web/user/auth/signin?tenantId=default-tenant")
+			assert.Contains(t, body, "Sign in with Stub IdP")
+			assert.Contains(t, body, "/web/user/auth/federated/stub-idp/start?tenantId=default-tenant")
+		})
+		t.Run("must not offer providers of other tenants", func(t *testing.T) {
+			_, body := doGet(t, "web/user/auth/signin?tenantId=test-tenant")
+			assert.NotContains(t, body, "Sign in with Stub IdP")
+		})
+	})
+
+	t.Run("GET /web/user/auth/federated/:providerId/start", func(t *testing.T) {
+		t.Run("must redirect to the identity provider with state and PKCE", func(t *testing.T) {
+			started := start(t, "")
+			assert.Equal(t, stubIdPAddr, started.authURL.Host)
+			assert.Equal(t, "/authorize", started.authURL.Path)
+			query := started.authURL.Query()
+			assert.Equal(t, "code", query.Get("response_type"))
+			assert.Equal(t, stubIdPClientID, query.Get("client_id"))
+			assert.Equal(t, "openid email profile", query.Get("scope"))
+			assert.Equal(t, "S256", query.Get("code_challenge_method"))
+			assert.Equal(t, "http://localhost:8080/web/user/auth/federated/stub-idp/callback", started.redirectURI)
+			assert.NotEmpty(t, started.state)
+			assert.NotEmpty(t, started.codeChallenge)
+			assert.True(t, started.verifierCookie.HttpOnly)
+		})
+		t.Run("provider of a different tenant must fail", func(t *testing.T) {
+			resp, body := doGet(t, "web/user/auth/federated/stub-idp/start?tenantId=test-tenant")
+			assert.NotEqual(t, http.StatusFound, resp.StatusCode)
+			assert.Contains(t, body, "identity provider not found for tenant")
+		})
+		t.Run("unknown provider must fail", func(t *testing.T) {
+			resp, body := doGet(t, "web/user/auth/federated/unknown-idp/start?tenantId=default-tenant")
+			assert.NotEqual(t, http.StatusFound, resp.StatusCode)
+			assert.Contains(t, body, "identity provider not found")
+		})
+	})
+
+	t.Run("GET /web/user/auth/federated/:providerId/callback", func(t *testing.T) {
+		t.Run("verified email must link the existing user", func(t *testing.T) {
+			resp, _ := signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-existing",
+				Email:         "testuser@example.com",
+				EmailVerified: true,
+			})
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Equal(t, "/web/user", resp.Header.Get("Location"))
+			accessCookie := findCookie(resp, "access_token")
+			require.NotNil(t, accessCookie)
+			assert.NotNil(t, findCookie(resp, "refresh_token"))
+
+			me := getMe(t, accessCookie.Value)
+			assert.Equal(t, "test-user-5", me.Id)
+			assert.Equal(t, "testuser@example.com", string(me.Email))
+		})
+		t.Run("linked identity must sign in the same user even if the email changed upstream", func(t *testing.T) {
+			resp, _ := signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-existing",
+				Email:         "renamed-upstream@example.com",
+				EmailVerified: false,
+			})
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			accessCookie := findCookie(resp, "access_token")
+			require.NotNil(t, accessCookie)
+			assert.Equal(t, "test-user-5", getMe(t, accessCookie.Value).Id)
+		})
+		t.Run("unknown verified email must create a new user", func(t *testing.T) {
+			resp, _ := signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-new",
+				Email:         "federated-new@example.com",
+				EmailVerified: true,
+				GivenName:     "Federated",
+				FamilyName:    "Newcomer",
+			})
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			accessCookie := findCookie(resp, "access_token")
+			require.NotNil(t, accessCookie)
+
+			me := getMe(t, accessCookie.Value)
+			assert.Equal(t, "federated-new@example.com", string(me.Email))
+			assert.Equal(t, "Federated", me.FirstName)
+			assert.Equal(t, "Newcomer", me.LastName)
+			assert.Equal(t, "default-tenant", me.TenantId)
+
+			// Signing in again must not create another user
+			resp, _ = signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-new",
+				Email:         "federated-new@example.com",
+				EmailVerified: true,
+			})
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Equal(t, me.Id, getMe(t, findCookie(resp, "access_token").Value).Id)
+		})
+		t.Run("enrolled authenticator must be required after upstream sign in", func(t *testing.T) {
+			userID := createAndConfirmUser(t, env, "federated-mfa@example.com", "qazwsxedc", "Federated", "MFA")
+			authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+					Email:    "federated-mfa@example.com",
+					Password: "qazwsxedc",
+					TenantId: "default-tenant",
+				})
+			require.NoError(t, err)
+			userHeaders := map[string][]string{
+				"Authorization": {"Bearer " + authResp.AccessToken},
+			}
+			enrollment, _, err := kathttpc.LocalHttpJsonPostRequest[any, swagger.MfaEnrollmentResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/mfa/totp", userHeaders, nil)
+			require.NoError(t, err)
+			now := time.Now()
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.MfaConfirmRequest, swagger.MfaStatusResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+userID+"/mfa/totp/confirm", userHeaders,
+				&swagger.MfaConfirmRequest{Code: totpCodeAt(t, enrollment.Secret, now)})
+			require.NoError(t, err)
+
+			resp, body := signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-mfa",
+				Email:         "federated-mfa@example.com",
+				EmailVerified: true,
+			})
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Nil(t, findCookie(resp, "refresh_token"))
+			assert.Contains(t, body, "Two-Factor Authentication")
+			match := regexp.MustCompile(`name="mfaToken" value="([^"]+)"`).FindStringSubmatch(body)
+			require.Len(t, match, 2)
+
+			// The confirmation consumed the current step, the next one is still inside the accepted window
+			verifyResp, err := noRedirectClient.PostForm(
+				kathttpc.LocalURL(appConfig.Server.Port, "web/user/auth/mfa/verify"), url.Values{
+					"mfaToken": {match[1]},
+					"code":     {totpCodeAt(t, enrollment.Secret, now.Add(30*time.Second))},
+					"email":    {"federated-mfa@example.com"},
+				})
+			require.NoError(t, err)
+			defer verifyResp.Body.Close()
+			require.Equal(t, http.StatusSeeOther, verifyResp.StatusCode)
+			accessCookie := findCookie(verifyResp, "access_token")
+			require.NotNil(t, accessCookie)
+			assert.Equal(t, userID, getMe(t, accessCookie.Value).Id)
+		})
+		t.Run("unverified email must be rejected", func(t *testing.T) {
+			resp, body := signInWith(t, stubIdPUser{
+				Sub:           "stub-sub-unverified",
+				Email:         "unverified-upstream@example.com",
+				EmailVerified: false,
+			})
+			assert.NotEqual(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "did not return a verified email address")
+		})
+		t.Run("return location must be preserved", func(t *testing.T) {
+			returnTo := "/oauth2/authorize?client_id=test-spa-client&response_type=code"
+			started := start(t, returnTo)
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, _ := callback(t, started.state, code, started.verifierCookie)
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Equal(t, returnTo, resp.Header.Get("Location"))
+		})
+		t.Run("unsafe return location must be ignored", func(t *testing.T) {
+			started := start(t, "https://evil.example.com/")
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, _ := callback(t, started.state, code, started.verifierCookie)
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+			assert.Equal(t, "/web/user", resp.Header.Get("Location"))
+		})
+		t.Run("tampered state must be rejected", func(t *testing.T) {
+			started := start(t, "")
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, body := callback(t, started.state+"x", code, started.verifierCookie)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "invalid or expired state")
+		})
+		t.Run("missing code verifier cookie must be rejected", func(t *testing.T) {
+			started := start(t, "")
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, body := callback(t, started.state, code, nil)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "code verifier is missing")
+		})
+		t.Run("code verifier of another sign in must be rejected", func(t *testing.T) {
+			started := start(t, "")
+			other := start(t, "")
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, body := callback(t, started.state, code, other.verifierCookie)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "state does not match code verifier")
+		})
+		t.Run("reused authorization code must be rejected", func(t *testing.T) {
+			started := start(t, "")
+			code := idp.mintCode(started.codeChallenge, started.redirectURI, stubIdPUser{
+				Sub: "stub-sub-existing",
+			})
+			resp, _ := callback(t, started.state, code, started.verifierCookie)
+			require.Equal(t, http.StatusSeeOther, resp.StatusCode)
+
+			resp, body := callback(t, started.state, code, started.verifierCookie)
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "failed to exchange authorization code")
+		})
+		t.Run("error returned by the identity provider must be reported", func(t *testing.T) {
+			resp, body := doGet(t, "web/user/auth/federated/stub-idp/callback?error=access_denied")
+			assert.Nil(t, findCookie(resp, "access_token"))
+			assert.Contains(t, body, "access_denied")
+		})
+	})
+}
@@ -30,23 +30,123 @@ func TestAPIRoutes(t *testing.T) {
 		runAuthenticationTests(t, env)
 	})
 
+	// Run JWKS and asymmetric signing tests
+	t.Run("JWKS", func(t *testing.T) {
+		runJWKSTests(t, env)
+	})
+
+	// Run OpenID Connect provider tests
+	t.Run("OpenID Connect", func(t *testing.T) {
+		runOIDCTests(t, env)
+	})
+
+	// Run federated sign in tests against a stub identity provider
+	t.Run("Federated Sign In", func(t *testing.T) {
+		runFederationTests(t, env)
+	})
+
+	// Run TOTP two-factor authentication tests
+	t.Run("Two-Factor Authentication", func(t *testing.T) {
+		runMFATests(t, env)
+	})
+
+	// Run failed sign in lockout and throttling tests
+	t.Run("Sign In Throttling", func(t *testing.T) {
+		runSignInThrottleTests(t, env)
+	})
+
 	// Run refresh token tests
 	t.Run("Refresh Token API", func(t *testing.T) {
 		runRefreshTokenTests(t, env)
 	})
 
+	// Run session listing and revocation tests
+	t.Run("Sessions", func(t *testing.T) {
+		runSessionTests(t, env)
+	})
+
+	// Run access token revocation and introspection tests
+	t.Run("Token Revocation", func(t *testing.T) {
+		runTokenRevocationTests(t, env)
+	})
+
+	// Run service client and client_credentials grant tests
+	t.Run("Service Clients", func(t *testing.T) {
+		runServiceClientTests(t, env)
+	})
+
+	// Run personal API key tests
+	t.Run("API Keys", func(t *testing.T) {
+		runAPIKeyTests(t, env)
+	})
+
+	// Run permission and custom role tests
+	t.Run("Roles", func(t *testing.T) {
+		runRoleTests(t, env)
+	})
+
+	// Run user invitation tests with mock emails
+	t.Run("Invitations", func(t *testing.T) {
+		runInvitationTests(t, env)
+	})
+
 	// Run signup and email confirmation tests with mock emails
 	t.Run("Signup with Email Confirmation", func(t *testing.T) {
 		runSignupEmailTests(t, env)
 	})
 
+	// Run confirmation code resend and failed attempt limit tests with mock emails
+	t.Run("Email Confirmation Limits", func(t *testing.T) {
+		runEmailConfirmationLimitTests(t, env)
+	})
+
+	// Run email change tests with mock emails
+	t.Run("Email Change", func(t *testing.T) {
+		runEmailChangeTests(t, env)
+	})
+
+	// Run password reset tests with mock emails
+	t.Run("Password Reset", func(t *testing.T) {
+		runPasswordResetTests(t, env)
+	})
+
+	// Run SMTP mailer tests against an in-process SMTP server
+	t.Run("SMTP Mailer", func(t *testing.T) {
+		runSMTPMailerTests(t, env)
+	})
+
+	// Run email outbox delivery, retry and listing tests
+	t.Run("Email Outbox", func(t *testing.T) {
+		runOutboxTests(t, env)
+	})
+
 	// Run user management tests
 	t.Run("User Management API", func(t *testing.T) {
 		runUserManagementTests(t, env)
 	})
 
+	// Run bulk user import and export tests
+	t.Run("User Import and Export API", func(t *testing.T) {
+		runUserImportTests(t, env)
+	})
+
+	// Run SCIM provisioning tests
+	t.Run("SCIM Provisioning API", func(t *testing.T) {
+		runScimTests(t, env)
+	})
+
+	// Run webhook subscription and event delivery tests against an in-process receiver
+	t.Run("Webhooks", func(t *testing.T) {
+		runWebhookTests(t, env)
+	})
+
 	// Run tenant management tests
 	t.Run("Tenant Management API", func(t *testing.T) {
 		runTenantManagementTests(t, env)
 	})
+
+	// Run audit log tests
+	t.Run("Audit Log", func(t *testing.T) {
+		runAuditTests(t, env)
+	})
 }
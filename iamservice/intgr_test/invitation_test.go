@@ -0,0 +1,248 @@
+package intgr_test
+
+import (
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runInvitationTests runs tests for invitations of users to tenants using mock emails
+func runInvitationTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string, password string) (*swagger.SignInResponse, error) {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: password,
+				TenantId: "default-tenant",
+			})
+		return authResp, err
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	invite := func(
+		accessToken string, tenantID string, req *swagger.CreateInvitationRequest,
+	) (*swagger.InvitationResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateInvitationRequest, swagger.InvitationResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/"+tenantID+"/invitations", bearer(accessToken), req)
+		return resp, err
+	}
+	listInvitations := func(t *testing.T, accessToken string) []swagger.InvitationResponse {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.InvitationsResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations", bearer(accessToken))
+		require.NoError(t, err)
+		return resp.Items
+	}
+	accept := func(token string, password string) (*swagger.AcceptInvitationResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.AcceptInvitationRequest, swagger.AcceptInvitationResponse](
+			ctx, &appConfig.Server, "api/v1/invitations/accept", nil, &swagger.AcceptInvitationRequest{
+				Token:     token,
+				FirstName: "Invited",
+				LastName:  "User",
+				Password:  password,
+			})
+		return resp, err
+	}
+	lastInvitationToken := func(t *testing.T, to string) string {
+		emails, err := getMockEmailsTo(to)
+		require.NoError(t, err)
+		require.NotEmpty(t, emails)
+		email := emails[len(emails)-1]
+		assert.Contains(t, email.Subject, "You Are Invited")
+		token := extractInvitationToken(email.Body)
+		require.NotEmpty(t, token)
+		assert.Equal(t, token, extractInvitationToken(email.TextBody))
+		return token
+	}
+
+	adminAuth, err := signIn(t, "testadmin@example.com", "qazwsxedc")
+	require.NoError(t, err)
+	userAuth, err := signIn(t, "testuser@example.com", "qazwsxedc")
+	require.NoError(t, err)
+
+	t.Run("POST /tenants/{tenantId}/invitations", func(t *testing.T) {
+		t.Run("must create invitation and send email", func(t *testing.T) {
+			clearMockEmails()
+			invitation, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-admin@example.com",
+				Roles: &[]string{"admin"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "default-tenant", invitation.TenantId)
+			assert.ElementsMatch(t, []string{"user", "admin"}, invitation.Roles)
+			assert.False(t, invitation.Expired)
+			require.NoError(t, waitForMockEmail(1, 5))
+			lastInvitationToken(t, "invited-admin@example.com")
+
+			// Replies to the invitation go to the inviter
+			emails, err := getMockEmailsTo("invited-admin@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, "testadmin@example.com", emails[len(emails)-1].ReplyTo)
+		})
+
+		t.Run("must fail with 409 Conflict for pending invitation", func(t *testing.T) {
+			_, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-admin@example.com",
+			})
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("must fail with 409 Conflict for existing user", func(t *testing.T) {
+			_, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "testuser@example.com",
+			})
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("must fail with 400 Bad Request for invalid email", func(t *testing.T) {
+			_, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "not-an-email",
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for sysadmin role", func(t *testing.T) {
+			_, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-sysadmin@example.com",
+				Roles: &[]string{"sysadmin"},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			_, err := invite(adminAuth.AccessToken, "test-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-elsewhere@example.com",
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("user must fail with 403 Forbidden", func(t *testing.T) {
+			_, err := invite(userAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-by-user@example.com",
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("GET /tenants/{tenantId}/invitations", func(t *testing.T) {
+		t.Run("must return pending invitations", func(t *testing.T) {
+			invitations := listInvitations(t, adminAuth.AccessToken)
+			assert.True(t, lo.ContainsBy(invitations, func(i swagger.InvitationResponse) bool {
+				return i.Email == "invited-admin@example.com"
+			}))
+		})
+
+		t.Run("user must fail with 403 Forbidden", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.InvitationsResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations", bearer(userAuth.AccessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("POST /tenants/{tenantId}/invitations/{invitationId}/resend", func(t *testing.T) {
+		t.Run("must send new link and reject the old one", func(t *testing.T) {
+			invitation, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-resend@example.com",
+			})
+			require.NoError(t, err)
+			oldToken := lastInvitationToken(t, "invited-resend@example.com")
+
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[any, swagger.InvitationResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations/"+invitation.Id+"/resend",
+				bearer(adminAuth.AccessToken), nil)
+			require.NoError(t, err)
+			newToken := lastInvitationToken(t, "invited-resend@example.com")
+			assert.NotEqual(t, oldToken, newToken)
+
+			_, err = accept(oldToken, "qazwsxedc")
+			kathttpc.AssertStatusBadRequest(t, err)
+			_, err = accept(newToken, "qazwsxedc")
+			require.NoError(t, err)
+		})
+
+		t.Run("must fail with 404 Not Found for unknown invitation", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[any, swagger.InvitationResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations/nonexistent/resend",
+				bearer(adminAuth.AccessToken), nil)
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+
+	t.Run("DELETE /tenants/{tenantId}/invitations/{invitationId}", func(t *testing.T) {
+		t.Run("must revoke invitation and reject its link", func(t *testing.T) {
+			invitation, err := invite(adminAuth.AccessToken, "default-tenant", &swagger.CreateInvitationRequest{
+				Email: "invited-revoked@example.com",
+			})
+			require.NoError(t, err)
+			token := lastInvitationToken(t, "invited-revoked@example.com")
+
+			_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations/"+invitation.Id,
+				bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+
+			invitations := listInvitations(t, adminAuth.AccessToken)
+			assert.False(t, lo.ContainsBy(invitations, func(i swagger.InvitationResponse) bool {
+				return i.Id == invitation.Id
+			}))
+			_, err = accept(token, "qazwsxedc")
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with 404 Not Found for unknown invitation", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/invitations/nonexistent",
+				bearer(adminAuth.AccessToken))
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+
+	t.Run("POST /invitations/accept", func(t *testing.T) {
+		token := lastInvitationToken(t, "invited-admin@example.com")
+
+		t.Run("must fail with 400 Bad Request for short password", func(t *testing.T) {
+			_, err := accept(token, "short")
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with 400 Bad Request for invalid token", func(t *testing.T) {
+			_, err := accept("invalid-token", "qazwsxedc")
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must create verified user with invited roles", func(t *testing.T) {
+			resp, err := accept(token, "invitedpassword")
+			require.NoError(t, err)
+			assert.Equal(t, "invited-admin@example.com", resp.Email)
+			assert.Equal(t, "default-tenant", resp.TenantId)
+
+			invitedAuth, err := signIn(t, "invited-admin@example.com", "invitedpassword")
+			require.NoError(t, err)
+			validateSignInResponse(t, invitedAuth)
+
+			rolesResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserRolesResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+resp.UserId+"/roles", bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"user", "admin"}, rolesResp.Roles)
+
+			invitations := listInvitations(t, adminAuth.AccessToken)
+			assert.False(t, lo.ContainsBy(invitations, func(i swagger.InvitationResponse) bool {
+				return i.Email == "invited-admin@example.com"
+			}))
+		})
+
+		t.Run("must fail with 400 Bad Request for accepted invitation", func(t *testing.T) {
+			_, err := accept(token, "qazwsxedc")
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+	})
+}
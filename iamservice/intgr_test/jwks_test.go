@@ -0,0 +1,102 @@
+package intgr_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/usecase"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runJWKSTests runs tests for asymmetric token signing and the published JWKS
+func runJWKSTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	t.Run("GET /.well-known/jwks.json must publish the signing key", func(t *testing.T) {
+		jwks, _, err := kathttpc.LocalHttpJsonGetRequest[usecase.JSONWebKeySet](
+			ctx, &appConfig.Server, ".well-known/jwks.json", nil)
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 1)
+
+		key := jwks.Keys[0]
+		assert.Equal(t, "OKP", key.Kty)
+		assert.Equal(t, "Ed25519", key.Crv)
+		assert.Equal(t, "EdDSA", key.Alg)
+		assert.Equal(t, "sig", key.Use)
+		assert.Equal(t, "test-ed25519", key.Kid)
+		assert.NotEmpty(t, key.X)
+
+		t.Run("issued access token must verify with the published key", func(t *testing.T) {
+			publicKey, err := base64.RawURLEncoding.DecodeString(key.X)
+			require.NoError(t, err)
+
+			signinReq := &swagger.SignInRequest{
+				Email:    "john.doe.default.user1@example.com",
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			}
+			authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+			require.NoError(t, err)
+
+			token, err := jwt.Parse(authResp.AccessToken, func(token *jwt.Token) (interface{}, error) {
+				return ed25519.PublicKey(publicKey), nil
+			}, jwt.WithValidMethods([]string{"EdDSA"}))
+			require.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, "test-ed25519", token.Header["kid"])
+		})
+	})
+
+	t.Run("Key rotation", func(t *testing.T) {
+		newAccessToken := func(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+			now := time.Now()
+			token := jwt.NewWithClaims(method, jwt.MapClaims{
+				"sub":      "default-user-1",
+				"iat":      now.Unix(),
+				"exp":      now.Add(time.Hour).Unix(),
+				"type":     "access",
+				"roles":    []string{"user"},
+				"tenantId": "default-tenant",
+			})
+			if kid != "" {
+				token.Header["kid"] = kid
+			}
+			signed, err := token.SignedString(key)
+			require.NoError(t, err)
+			return signed
+		}
+		getMe := func(accessToken string) error {
+			headers := map[string][]string{
+				"Authorization": {"Bearer " + accessToken},
+			}
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", headers)
+			return err
+		}
+
+		t.Run("token signed with verify-only key must be accepted", func(t *testing.T) {
+			accessToken := newAccessToken(t, jwt.SigningMethodHS256, "test-hs256-legacy", []byte("legacy-secret"))
+			require.NoError(t, getMe(accessToken))
+		})
+		t.Run("token with unknown kid must fail", func(t *testing.T) {
+			accessToken := newAccessToken(t, jwt.SigningMethodHS256, "unknown-key", []byte("legacy-secret"))
+			kathttpc.AssertStatusUnauthorized(t, getMe(accessToken))
+		})
+		t.Run("token with algorithm not matching its kid must fail", func(t *testing.T) {
+			accessToken := newAccessToken(t, jwt.SigningMethodHS256, "test-ed25519", []byte("legacy-secret"))
+			kathttpc.AssertStatusUnauthorized(t, getMe(accessToken))
+		})
+		t.Run("token without kid signed with verify-only key must fail", func(t *testing.T) {
+			accessToken := newAccessToken(t, jwt.SigningMethodHS256, "", []byte("legacy-secret"))
+			kathttpc.AssertStatusUnauthorized(t, getMe(accessToken))
+		})
+	})
+}
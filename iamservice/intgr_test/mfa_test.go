@@ -0,0 +1,288 @@
+package intgr_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// totpCodeAt computes the RFC 6238 code an authenticator app would show at the given time
+func totpCodeAt(t *testing.T, secret string, at time.Time) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1_000_000)
+}
+
+// runMFATests runs tests for TOTP enrollment, recovery codes and two-step sign in
+func runMFATests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string, tenantID string) (*swagger.SignInResponse, *swagger.MfaChallengeResponse) {
+		signinReq := &swagger.SignInRequest{
+			Email:    email,
+			Password: "qazwsxedc",
+			TenantId: tenantID,
+		}
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+		require.NoError(t, err)
+		challengeResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.MfaChallengeResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+		require.NoError(t, err)
+		return authResp, challengeResp
+	}
+	verify := func(mfaToken string, code string) (*swagger.SignInResponse, error) {
+		verifyReq := &swagger.MfaVerifyRequest{
+			MfaToken: mfaToken,
+			Code:     code,
+		}
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.MfaVerifyRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/mfa/verify", nil, verifyReq)
+		return authResp, err
+	}
+
+	t.Run("User enrolled authenticator", func(t *testing.T) {
+		authResp, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+		validateSignInResponse(t, authResp)
+		assert.False(t, challenge.MfaRequired)
+		userHeaders := http.Header{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+
+		adminAuth, _ := signIn(t, "testadmin@example.com", "default-tenant")
+		adminHeaders := http.Header{
+			"Authorization": {"Bearer " + adminAuth.AccessToken},
+		}
+
+		status, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.MfaStatusResponse](
+			ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa", userHeaders)
+		require.NoError(t, err)
+		assert.False(t, status.Enabled)
+
+		t.Run("Other users must not enroll authenticators", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[any, swagger.MfaEnrollmentResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa/totp", adminHeaders, nil)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		enrollment, _, err := kathttpc.LocalHttpJsonPostRequest[any, swagger.MfaEnrollmentResponse](
+			ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa/totp", userHeaders, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, enrollment.Secret)
+		assert.Contains(t, enrollment.OtpauthUri, "otpauth://totp/")
+		assert.Contains(t, enrollment.OtpauthUri, "secret="+enrollment.Secret)
+		require.Len(t, enrollment.RecoveryCodes, 10)
+
+		t.Run("Pending authenticator must not be required at sign in", func(t *testing.T) {
+			authResp, _ := signIn(t, "mfauser@example.com", "default-tenant")
+			validateSignInResponse(t, authResp)
+		})
+
+		t.Run("Confirmation with invalid code must fail", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.MfaConfirmRequest, swagger.MfaStatusResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa/totp/confirm", userHeaders,
+				&swagger.MfaConfirmRequest{Code: "000000x"})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		now := time.Now()
+		status, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.MfaConfirmRequest, swagger.MfaStatusResponse](
+			ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa/totp/confirm", userHeaders,
+			&swagger.MfaConfirmRequest{Code: totpCodeAt(t, enrollment.Secret, now)})
+		require.NoError(t, err)
+		assert.True(t, status.Enabled)
+		assert.Equal(t, 10, status.RecoveryCodesRemaining)
+
+		t.Run("Enrolling again must be rejected", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[any, swagger.MfaEnrollmentResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa/totp", userHeaders, nil)
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("Sign in must return challenge instead of tokens", func(t *testing.T) {
+			authResp, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			assert.Empty(t, authResp.AccessToken)
+			assert.Empty(t, authResp.RefreshToken)
+			assert.True(t, challenge.MfaRequired)
+			assert.False(t, challenge.EnrollmentRequired)
+			assert.NotEmpty(t, challenge.MfaToken)
+			assert.Greater(t, challenge.ExpiresIn, int64(0))
+		})
+
+		t.Run("Challenge token must not authenticate API requests", func(t *testing.T) {
+			_, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			challengeHeaders := http.Header{
+				"Authorization": {"Bearer " + challenge.MfaToken},
+			}
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", challengeHeaders)
+			kathttpc.AssertStatusUnauthorized(t, err)
+			_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa", challengeHeaders)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("Invalid code must be rejected", func(t *testing.T) {
+			_, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			_, err := verify(challenge.MfaToken, "123")
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("Invalid challenge token must be rejected", func(t *testing.T) {
+			_, err := verify(authResp.AccessToken, totpCodeAt(t, enrollment.Secret, now))
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("TOTP code must complete sign in once", func(t *testing.T) {
+			// The confirmation consumed the current step, the next one is still inside the accepted window
+			code := totpCodeAt(t, enrollment.Secret, now.Add(30*time.Second))
+
+			_, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			authResp, err := verify(challenge.MfaToken, code)
+			require.NoError(t, err)
+			validateSignInResponse(t, authResp)
+			assert.Equal(t, "test-mfa-user-5", authResp.UserId)
+
+			_, challenge = signIn(t, "mfauser@example.com", "default-tenant")
+			_, err = verify(challenge.MfaToken, code)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("Recovery code must complete sign in once", func(t *testing.T) {
+			_, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			authResp, err := verify(challenge.MfaToken, enrollment.RecoveryCodes[0])
+			require.NoError(t, err)
+			validateSignInResponse(t, authResp)
+
+			_, challenge = signIn(t, "mfauser@example.com", "default-tenant")
+			_, err = verify(challenge.MfaToken, enrollment.RecoveryCodes[0])
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			status, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.MfaStatusResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa", adminHeaders)
+			require.NoError(t, err)
+			assert.True(t, status.Enabled)
+			assert.Equal(t, 9, status.RecoveryCodesRemaining)
+		})
+
+		t.Run("Challenge token must complete sign in once", func(t *testing.T) {
+			_, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			authResp, err := verify(challenge.MfaToken, enrollment.RecoveryCodes[1])
+			require.NoError(t, err)
+			validateSignInResponse(t, authResp)
+
+			_, err = verify(challenge.MfaToken, enrollment.RecoveryCodes[2])
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			status, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.MfaStatusResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa", adminHeaders)
+			require.NoError(t, err)
+			assert.Equal(t, 8, status.RecoveryCodesRemaining)
+		})
+
+		t.Run("Disabling must restore password-only sign in", func(t *testing.T) {
+			disable := func(req *swagger.MfaDisableRequest) error {
+				_, err := kathttpc.DoJsonRequest[swagger.MfaDisableRequest, any, any](
+					ctx, http.DefaultClient, http.MethodDelete,
+					kathttpc.LocalURL(appConfig.Server.Port, "api/v1/users/test-mfa-user-5/mfa"),
+					kathttpc.JsonRequest[swagger.MfaDisableRequest]{
+						Body:                    req,
+						Headers:                 userHeaders,
+						ExpectSuccessStatusOnly: true,
+					})
+				return err
+			}
+
+			// The access token alone must not be enough to turn off the second factor
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/users/test-mfa-user-5/mfa", userHeaders)
+			kathttpc.AssertStatusBadRequest(t, err)
+			err = disable(&swagger.MfaDisableRequest{CurrentPassword: lo.ToPtr("wrongpassword")})
+			kathttpc.AssertStatusBadRequest(t, err)
+			err = disable(&swagger.MfaDisableRequest{Code: lo.ToPtr("000000x")})
+			kathttpc.AssertStatusBadRequest(t, err)
+
+			err = disable(&swagger.MfaDisableRequest{CurrentPassword: lo.ToPtr("qazwsxedc")})
+			require.NoError(t, err)
+
+			authResp, challenge := signIn(t, "mfauser@example.com", "default-tenant")
+			validateSignInResponse(t, authResp)
+			assert.False(t, challenge.MfaRequired)
+		})
+	})
+
+	t.Run("Tenant requiring MFA for admins", func(t *testing.T) {
+		authResp, _ := signIn(t, "mfaadmin@example.com", "mfa-tenant")
+		validateSignInResponse(t, authResp)
+		adminHeaders := http.Header{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+
+		requireAdminMfa := true
+		tenantResp, _, err := kathttpc.LocalHttpJsonPutRequest[swagger.UpdateTenantRequest, swagger.TenantResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/mfa-tenant", adminHeaders, &swagger.UpdateTenantRequest{
+				Name:            "MFA Tenant",
+				Description:     "Tenant for multi-factor authentication tests",
+				RequireAdminMfa: &requireAdminMfa,
+			})
+		require.NoError(t, err)
+		assert.True(t, tenantResp.RequireAdminMfa)
+
+		_, challenge := signIn(t, "mfaadmin@example.com", "mfa-tenant")
+		assert.True(t, challenge.MfaRequired)
+		assert.True(t, challenge.EnrollmentRequired)
+
+		t.Run("Verification without enrolled authenticator must fail", func(t *testing.T) {
+			_, err := verify(challenge.MfaToken, "123456")
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		enrollment, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.MfaEnrollRequest, swagger.MfaEnrollmentResponse](
+			ctx, &appConfig.Server, "api/v1/auth/mfa/enroll", nil, &swagger.MfaEnrollRequest{MfaToken: challenge.MfaToken})
+		require.NoError(t, err)
+		require.NotEmpty(t, enrollment.Secret)
+
+		verified, err := verify(challenge.MfaToken, totpCodeAt(t, enrollment.Secret, time.Now()))
+		require.NoError(t, err)
+		validateSignInResponse(t, verified)
+		assert.Equal(t, "test-mfa-admin-7", verified.UserId)
+
+		t.Run("Enrolled admin must get a regular challenge", func(t *testing.T) {
+			_, challenge := signIn(t, "mfaadmin@example.com", "mfa-tenant")
+			assert.True(t, challenge.MfaRequired)
+			assert.False(t, challenge.EnrollmentRequired)
+
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.MfaEnrollRequest, swagger.MfaEnrollmentResponse](
+				ctx, &appConfig.Server, "api/v1/auth/mfa/enroll", nil, &swagger.MfaEnrollRequest{MfaToken: challenge.MfaToken})
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("Admins of other tenants must not change the requirement", func(t *testing.T) {
+			otherAdmin, _ := signIn(t, "testadmin@example.com", "default-tenant")
+			_, _, err := kathttpc.LocalHttpJsonPutRequest[swagger.UpdateTenantRequest, swagger.TenantResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/mfa-tenant", http.Header{
+					"Authorization": {"Bearer " + otherAdmin.AccessToken},
+				}, &swagger.UpdateTenantRequest{Name: "MFA Tenant"})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+}
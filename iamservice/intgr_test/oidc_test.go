@@ -0,0 +1,465 @@
+package intgr_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runOIDCTests runs tests for the OpenID Connect provider endpoints
+func runOIDCTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	const (
+		spaClientID     = "test-spa-client"
+		spaRedirectURI  = "http://localhost:3000/callback"
+		codeVerifier    = "dBjftJeZ4CQP-0-test-verifier-with-enough-entropy-1234567890"
+		backendClientID = "test-backend-client"
+		backendRedirect = "http://localhost:4000/callback"
+	)
+	verifierHash := sha256.Sum256([]byte(codeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(verifierHash[:])
+
+	// Do not follow redirects, the tests inspect the Location header
+	noRedirectClient := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	signIn := func(t *testing.T, email string, tenantID string) string {
+		signinReq := &swagger.SignInRequest{
+			Email:    email,
+			Password: "qazwsxedc",
+			TenantId: tenantID,
+		}
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+		require.NoError(t, err)
+		return authResp.AccessToken
+	}
+
+	authorize := func(t *testing.T, clientID string, redirectURI string, params url.Values, accessToken string) *http.Response {
+		query := url.Values{
+			"response_type":         {"code"},
+			"client_id":             {clientID},
+			"redirect_uri":          {redirectURI},
+			"scope":                 {"openid profile email"},
+			"state":                 {"xyz-state"},
+			"nonce":                 {"n-0S6_WzA2Mj"},
+			"code_challenge":        {codeChallenge},
+			"code_challenge_method": {"S256"},
+		}
+		for key, values := range params {
+			query[key] = values
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			kathttpc.LocalURL(appConfig.Server.Port, "oauth2/authorize?"+query.Encode()), nil)
+		require.NoError(t, err)
+		if accessToken != "" {
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: accessToken})
+		}
+		resp, err := noRedirectClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	authorizeCode := func(t *testing.T, clientID string, redirectURI string, accessToken string) string {
+		resp := authorize(t, clientID, redirectURI, nil, accessToken)
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(location.String(), redirectURI+"?"), location.String())
+		assert.Equal(t, "xyz-state", location.Query().Get("state"))
+		code := location.Query().Get("code")
+		require.NotEmpty(t, code)
+		return code
+	}
+
+	postToken := func(t *testing.T, form url.Values, basicAuth []string) (int, map[string]interface{}) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, "oauth2/token"), strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if len(basicAuth) == 2 {
+			req.SetBasicAuth(basicAuth[0], basicAuth[1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	exchangeCodeForm := func(code string) url.Values {
+		return url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {spaRedirectURI},
+			"client_id":     {spaClientID},
+			"code_verifier": {codeVerifier},
+		}
+	}
+
+	t.Run("GET /.well-known/openid-configuration must return provider metadata", func(t *testing.T) {
+		discovery, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OIDCDiscoveryResponse](
+			ctx, &appConfig.Server, ".well-known/openid-configuration", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080", discovery.Issuer)
+		assert.Equal(t, "http://localhost:8080/oauth2/authorize", discovery.AuthorizationEndpoint)
+		assert.Equal(t, "http://localhost:8080/oauth2/token", discovery.TokenEndpoint)
+		assert.Equal(t, "http://localhost:8080/oauth2/userinfo", discovery.UserinfoEndpoint)
+		assert.Equal(t, "http://localhost:8080/.well-known/jwks.json", discovery.JwksUri)
+		assert.Equal(t, []string{"code"}, discovery.ResponseTypesSupported)
+		assert.Equal(t, []string{"S256"}, discovery.CodeChallengeMethodsSupported)
+		assert.Equal(t, []string{"EdDSA"}, discovery.IdTokenSigningAlgValuesSupported)
+		assert.Contains(t, discovery.GrantTypesSupported, "authorization_code")
+		assert.Contains(t, discovery.GrantTypesSupported, "refresh_token")
+		assert.Contains(t, discovery.ScopesSupported, "openid")
+	})
+
+	t.Run("GET /oauth2/authorize", func(t *testing.T) {
+		t.Run("must redirect to the web sign-in page without a session", func(t *testing.T) {
+			resp := authorize(t, spaClientID, spaRedirectURI, nil, "")
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			location, err := url.Parse(resp.Header.Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "/web/user/auth/signin", location.Path)
+			assert.Equal(t, "default-tenant", location.Query().Get("tenantId"))
+			assert.True(t, strings.HasPrefix(location.Query().Get("returnTo"), "/oauth2/authorize?"))
+		})
+
+		t.Run("must redirect to the web sign-in page when signed in to another tenant", func(t *testing.T) {
+			accessToken := signIn(t, "testuser_different_tenant@example.com", "test-tenant")
+			resp := authorize(t, spaClientID, spaRedirectURI, nil, accessToken)
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/web/user/auth/signin?"))
+		})
+
+		t.Run("must fail without redirect for unknown client", func(t *testing.T) {
+			resp := authorize(t, "unknown-client", spaRedirectURI, nil, "")
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
+		t.Run("must fail without redirect for unregistered redirect URI", func(t *testing.T) {
+			resp := authorize(t, spaClientID, "https://evil.example.com/callback", nil, "")
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+
+		t.Run("must redirect with error when PKCE is missing", func(t *testing.T) {
+			resp := authorize(t, spaClientID, spaRedirectURI, url.Values{"code_challenge": {""}}, "")
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			location, err := url.Parse(resp.Header.Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "invalid_request", location.Query().Get("error"))
+			assert.Equal(t, "xyz-state", location.Query().Get("state"))
+		})
+
+		t.Run("must redirect with error when openid scope is missing", func(t *testing.T) {
+			resp := authorize(t, spaClientID, spaRedirectURI, url.Values{"scope": {"profile"}}, "")
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			location, err := url.Parse(resp.Header.Get("Location"))
+			require.NoError(t, err)
+			assert.Equal(t, "invalid_scope", location.Query().Get("error"))
+		})
+	})
+
+	t.Run("Authorization code flow with PKCE", func(t *testing.T) {
+		accessToken := signIn(t, "testuser@example.com", "default-tenant")
+		code := authorizeCode(t, spaClientID, spaRedirectURI, accessToken)
+
+		t.Run("must reject wrong code_verifier", func(t *testing.T) {
+			form := exchangeCodeForm(code)
+			form.Set("code_verifier", "wrong-verifier-wrong-verifier-wrong-verifier-wrong")
+			status, body := postToken(t, form, nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_grant", body["error"])
+		})
+
+		status, body := postToken(t, exchangeCodeForm(code), nil)
+		require.Equal(t, http.StatusOK, status, body)
+		assert.Equal(t, "Bearer", body["token_type"])
+		assert.Equal(t, "openid profile email", body["scope"])
+		assert.NotEmpty(t, body["access_token"])
+		assert.NotEmpty(t, body["refresh_token"])
+
+		t.Run("ID token must carry OIDC claims", func(t *testing.T) {
+			idToken, ok := body["id_token"].(string)
+			require.True(t, ok)
+			claims := jwt.MapClaims{}
+			_, _, err := jwt.NewParser().ParseUnverified(idToken, claims)
+			require.NoError(t, err)
+			assert.Equal(t, "http://localhost:8080", claims["iss"])
+			assert.Equal(t, "test-user-5", claims["sub"])
+			assert.Equal(t, spaClientID, claims["aud"])
+			assert.Equal(t, "n-0S6_WzA2Mj", claims["nonce"])
+			assert.Equal(t, "testuser@example.com", claims["email"])
+			assert.Equal(t, "default-tenant", claims["tenantId"])
+		})
+
+		t.Run("ID token must not be accepted as access token", func(t *testing.T) {
+			headers := map[string][]string{
+				"Authorization": {"Bearer " + body["id_token"].(string)},
+			}
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OIDCUserInfoResponse](
+				ctx, &appConfig.Server, "oauth2/userinfo", headers)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("authorization code must not be reusable", func(t *testing.T) {
+			status, body := postToken(t, exchangeCodeForm(code), nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_grant", body["error"])
+		})
+
+		t.Run("GET /oauth2/userinfo must return user claims", func(t *testing.T) {
+			headers := map[string][]string{
+				"Authorization": {"Bearer " + body["access_token"].(string)},
+			}
+			userInfo, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OIDCUserInfoResponse](
+				ctx, &appConfig.Server, "oauth2/userinfo", headers)
+			require.NoError(t, err)
+			assert.Equal(t, "test-user-5", userInfo.Sub)
+			assert.Equal(t, "testuser@example.com", userInfo.Email)
+			assert.True(t, userInfo.EmailVerified)
+			assert.Equal(t, "Test", userInfo.GivenName)
+			assert.Equal(t, "User", userInfo.FamilyName)
+			assert.Equal(t, "default-tenant", userInfo.TenantId)
+		})
+
+		t.Run("refresh_token grant must rotate tokens and return ID token", func(t *testing.T) {
+			form := url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {body["refresh_token"].(string)},
+				"client_id":     {spaClientID},
+			}
+			status, refreshed := postToken(t, form, nil)
+			require.Equal(t, http.StatusOK, status, refreshed)
+			assert.NotEmpty(t, refreshed["access_token"])
+			assert.NotEmpty(t, refreshed["id_token"])
+			assert.NotEqual(t, body["refresh_token"], refreshed["refresh_token"])
+
+			// The old refresh token was revoked by rotation
+			status, errBody := postToken(t, form, nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_grant", errBody["error"])
+		})
+
+		t.Run("refresh_token grant must reject client of another tenant", func(t *testing.T) {
+			form := url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {body["refresh_token"].(string)},
+				"client_id":     {"test-tenant-spa-client"},
+			}
+			status, errBody := postToken(t, form, nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_grant", errBody["error"])
+		})
+	})
+
+	t.Run("ID token auth_time must be the sign in time of the session", func(t *testing.T) {
+		accessToken := signIn(t, "testuser@example.com", "default-tenant")
+		signedInAt := time.Now().Unix()
+		// Codes issued later from the same session must not move auth_time forward
+		time.Sleep(1100 * time.Millisecond)
+
+		code := authorizeCode(t, spaClientID, spaRedirectURI, accessToken)
+		status, body := postToken(t, exchangeCodeForm(code), nil)
+		require.Equal(t, http.StatusOK, status, body)
+		claims := jwt.MapClaims{}
+		_, _, err := jwt.NewParser().ParseUnverified(body["id_token"].(string), claims)
+		require.NoError(t, err)
+		authTime, ok := claims["auth_time"].(float64)
+		require.True(t, ok)
+		assert.LessOrEqual(t, int64(authTime), signedInAt)
+		assert.Less(t, int64(authTime), int64(claims["iat"].(float64)))
+	})
+
+	t.Run("Confidential client", func(t *testing.T) {
+		accessToken := signIn(t, "testuser@example.com", "default-tenant")
+		form := func(code string) url.Values {
+			return url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {code},
+				"redirect_uri":  {backendRedirect},
+				"code_verifier": {codeVerifier},
+			}
+		}
+
+		t.Run("must fail with wrong client secret", func(t *testing.T) {
+			code := authorizeCode(t, backendClientID, backendRedirect, accessToken)
+			status, body := postToken(t, form(code), []string{backendClientID, "wrong-secret"})
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+		})
+
+		t.Run("must fail without client secret", func(t *testing.T) {
+			code := authorizeCode(t, backendClientID, backendRedirect, accessToken)
+			f := form(code)
+			f.Set("client_id", backendClientID)
+			status, body := postToken(t, f, nil)
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+		})
+
+		t.Run("must succeed with client_secret_basic", func(t *testing.T) {
+			code := authorizeCode(t, backendClientID, backendRedirect, accessToken)
+			status, body := postToken(t, form(code), []string{backendClientID, "qazwsxedc"})
+			require.Equal(t, http.StatusOK, status, body)
+			assert.NotEmpty(t, body["id_token"])
+		})
+
+		t.Run("refresh_token grant must reject another client of the same tenant", func(t *testing.T) {
+			code := authorizeCode(t, backendClientID, backendRedirect, accessToken)
+			status, body := postToken(t, form(code), []string{backendClientID, "qazwsxedc"})
+			require.Equal(t, http.StatusOK, status, body)
+			refreshForm := url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {body["refresh_token"].(string)},
+			}
+
+			status, errBody := postToken(t, url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {body["refresh_token"].(string)},
+				"client_id":     {spaClientID},
+			}, nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_grant", errBody["error"])
+
+			// The token is still usable by the client it was issued to
+			status, refreshed := postToken(t, refreshForm, []string{backendClientID, "qazwsxedc"})
+			require.Equal(t, http.StatusOK, status, refreshed)
+			assert.NotEmpty(t, refreshed["access_token"])
+		})
+
+		t.Run("must succeed with client_secret_post", func(t *testing.T) {
+			code := authorizeCode(t, backendClientID, backendRedirect, accessToken)
+			f := form(code)
+			f.Set("client_id", backendClientID)
+			f.Set("client_secret", "qazwsxedc")
+			status, body := postToken(t, f, nil)
+			require.Equal(t, http.StatusOK, status, body)
+			assert.NotEmpty(t, body["id_token"])
+		})
+	})
+
+	t.Run("OAuth client registration", func(t *testing.T) {
+		const appRedirect = "http://localhost:5000/callback"
+		adminHeaders := http.Header{"Authorization": {"Bearer " + signIn(t, "testadmin@example.com", "default-tenant")}}
+		createClient := func(req *swagger.CreateOAuthClientRequest) (*swagger.CreateOAuthClientResponse, error) {
+			resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateOAuthClientRequest, swagger.CreateOAuthClientResponse](
+				ctx, &appConfig.Server, "api/v1/oauth-clients", adminHeaders, req)
+			return resp, err
+		}
+
+		created, err := createClient(&swagger.CreateOAuthClientRequest{
+			Name:         "Registered App",
+			RedirectUris: []string{appRedirect},
+			Public:       true,
+		})
+		require.NoError(t, err)
+		clientID := created.Client.ClientId
+		assert.True(t, created.Client.Public)
+		assert.Nil(t, created.ClientSecret)
+		assert.Equal(t, "default-tenant", created.Client.TenantId)
+		assert.Equal(t, []string{appRedirect}, created.Client.RedirectUris)
+
+		t.Run("confidential client must get a secret", func(t *testing.T) {
+			confidential, err := createClient(&swagger.CreateOAuthClientRequest{
+				Name:         "Registered Backend",
+				RedirectUris: []string{"https://backend.example.com/callback"},
+			})
+			require.NoError(t, err)
+			assert.False(t, confidential.Client.Public)
+			require.NotNil(t, confidential.ClientSecret)
+			assert.NotEmpty(t, *confidential.ClientSecret)
+		})
+
+		t.Run("invalid redirect URIs must fail with 400 Bad Request", func(t *testing.T) {
+			for _, redirectURI := range []string{
+				"/callback", "http://example.com/callback", "https://example.com/callback#fragment", "javascript:alert(1)",
+			} {
+				_, err := createClient(&swagger.CreateOAuthClientRequest{
+					Name:         "Invalid App",
+					RedirectUris: []string{redirectURI},
+					Public:       true,
+				})
+				kathttpc.AssertStatusBadRequest(t, err)
+			}
+		})
+
+		t.Run("user must fail with 403 Forbidden", func(t *testing.T) {
+			userHeaders := http.Header{"Authorization": {"Bearer " + signIn(t, "testuser@example.com", "default-tenant")}}
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OAuthClientsResponse](
+				ctx, &appConfig.Server, "api/v1/oauth-clients", userHeaders)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("GET /oauth-clients must list the client", func(t *testing.T) {
+			clients, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OAuthClientsResponse](
+				ctx, &appConfig.Server, "api/v1/oauth-clients", adminHeaders)
+			require.NoError(t, err)
+			ids := make([]string, len(clients.Items))
+			for i, client := range clients.Items {
+				ids[i] = client.ClientId
+			}
+			assert.Contains(t, ids, clientID)
+			assert.Contains(t, ids, spaClientID)
+		})
+
+		accessToken := signIn(t, "testuser@example.com", "default-tenant")
+		code := authorizeCode(t, clientID, appRedirect, accessToken)
+		status, body := postToken(t, url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {appRedirect},
+			"client_id":     {clientID},
+			"code_verifier": {codeVerifier},
+		}, nil)
+		require.Equal(t, http.StatusOK, status, body)
+
+		t.Run("DELETE /oauth-clients/{clientId} must end sessions of the client", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/oauth-clients/"+clientID, adminHeaders)
+			require.NoError(t, err)
+
+			status, errBody := postToken(t, url.Values{
+				"grant_type":    {"refresh_token"},
+				"refresh_token": {body["refresh_token"].(string)},
+				"client_id":     {clientID},
+			}, nil)
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", errBody["error"])
+
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.OIDCUserInfoResponse](
+				ctx, &appConfig.Server, "oauth2/userinfo",
+				http.Header{"Authorization": {"Bearer " + body["access_token"].(string)}})
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/oauth-clients/"+clientID, adminHeaders)
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+
+	t.Run("POST /oauth2/token must reject unsupported grant type", func(t *testing.T) {
+		status, body := postToken(t, url.Values{"grant_type": {"password"}, "client_id": {spaClientID}}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "unsupported_grant_type", body["error"])
+	})
+}
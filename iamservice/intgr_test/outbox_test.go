@@ -0,0 +1,159 @@
+package intgr_test
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runOutboxTests runs tests for delivery of queued emails and the email outbox API
+func runOutboxTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) map[string][]string {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		require.NoError(t, err)
+		return map[string][]string{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+	}
+	listFailed := func(t *testing.T, headers map[string][]string, query url.Values) *swagger.OutboxEmailsResponse {
+		emails, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OutboxEmailsResponse](
+			ctx, &appConfig.Server, "api/v1/outbox/emails?"+query.Encode(), headers)
+		require.NoError(t, err)
+		return emails
+	}
+	findByRecipient := func(emails *swagger.OutboxEmailsResponse, recipient string) *swagger.OutboxEmailResponse {
+		for i := range emails.Items {
+			if emails.Items[i].Recipient == recipient {
+				return &emails.Items[i]
+			}
+		}
+		return nil
+	}
+	retry := func(headers map[string][]string, emailID string) error {
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[struct{}, any](
+			ctx, &appConfig.Server, "api/v1/outbox/emails/"+emailID+"/retry", headers, &struct{}{})
+		return err
+	}
+
+	sysadminHeaders := signIn(t, "john.doe.sysadmin@example.com")
+	adminHeaders := signIn(t, "testadmin@example.com")
+
+	t.Run("failed delivery must not fail the request and must be retried", func(t *testing.T) {
+		email := "outbox-retried@example.com"
+		clearMockEmails()
+		// File mailer fails to append to a directory
+		require.NoError(t, os.MkdirAll("test-emails/emails.json", 0755))
+		defer os.Remove("test-emails/emails.json")
+
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignUpRequest, swagger.SignUpResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signup", nil, &swagger.SignUpRequest{
+				Email:     email,
+				Password:  "qazwsxedc",
+				FirstName: "Outbox",
+				LastName:  "User",
+				TenantId:  "default-tenant",
+				Source:    "web",
+			})
+		require.NoError(t, err)
+
+		var failed *swagger.OutboxEmailResponse
+		require.Eventually(t, func() bool {
+			failed = findByRecipient(listFailed(t, sysadminHeaders, url.Values{"status": {"pending"}}), email)
+			return failed != nil
+		}, 5*time.Second, 100*time.Millisecond)
+		assert.Equal(t, swagger.OutboxEmailResponseStatusPending, failed.Status)
+		assert.GreaterOrEqual(t, failed.Attempts, 1)
+		assert.NotNil(t, failed.LastError)
+		assert.True(t, failed.NextAttemptAt.After(failed.CreatedAt))
+
+		require.NoError(t, os.Remove("test-emails/emails.json"))
+		emails, err := getMockEmailsTo(email)
+		require.NoError(t, err)
+		require.Len(t, emails, 1)
+		assert.True(t, validateWebEmailContent(&emails[0], email, "Outbox"))
+		assert.Nil(t, findByRecipient(listFailed(t, sysadminHeaders, url.Values{}), email))
+	})
+
+	t.Run("dead email", func(t *testing.T) {
+		email := "outbox-dead@example.com"
+		emailID := uuid.NewString()
+		_, err := testDB.Exec(ctx, `
+			INSERT INTO iam.outbox_email (id, recipient, subject, html_body, text_body, status, attempts, last_error)
+			VALUES ($1, $2, 'Dead Email - IAMService', '<p>IAMService</p>', 'IAMService', 'dead', 8,
+			        '550 mailbox unavailable')`, emailID, email)
+		require.NoError(t, err)
+
+		t.Run("sysadmin must see dead email", func(t *testing.T) {
+			emails := listFailed(t, sysadminHeaders, url.Values{"status": {"dead"}})
+			dead := findByRecipient(emails, email)
+			require.NotNil(t, dead)
+			assert.Equal(t, emailID, dead.Id)
+			assert.Equal(t, swagger.OutboxEmailResponseStatusDead, dead.Status)
+			assert.Equal(t, 8, dead.Attempts)
+			assert.Equal(t, "550 mailbox unavailable", *dead.LastError)
+			assert.Equal(t, "Dead Email - IAMService", dead.Subject)
+			assert.GreaterOrEqual(t, emails.Pagination.Total, 1)
+
+			assert.Nil(t, findByRecipient(listFailed(t, sysadminHeaders, url.Values{"status": {"pending"}}), email))
+		})
+		t.Run("listing with invalid status must fail with 400 Bad Request", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OutboxEmailsResponse](
+				ctx, &appConfig.Server, "api/v1/outbox/emails?status=sent", sysadminHeaders)
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+		t.Run("admin must fail with 403 Forbidden", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.OutboxEmailsResponse](
+				ctx, &appConfig.Server, "api/v1/outbox/emails", adminHeaders)
+			kathttpc.AssertStatusForbidden(t, err)
+
+			kathttpc.AssertStatusForbidden(t, retry(adminHeaders, emailID))
+		})
+		t.Run("retried email must be delivered", func(t *testing.T) {
+			clearMockEmails()
+			require.NoError(t, retry(sysadminHeaders, emailID))
+
+			emails, err := getMockEmailsTo(email)
+			require.NoError(t, err)
+			require.Len(t, emails, 1)
+			assert.Equal(t, "Dead Email - IAMService", emails[0].Subject)
+
+			assert.Equal(t, "IAMService", emails[0].TextBody)
+
+			var status, htmlBody, textBody string
+			err = testDB.QueryRow(ctx, "SELECT status, html_body, text_body FROM iam.outbox_email WHERE id = $1",
+				emailID).Scan(&status, &htmlBody, &textBody)
+			require.NoError(t, err)
+			assert.Equal(t, model.OutboxEmailStatusSent, status)
+			assert.Empty(t, htmlBody)
+			assert.Empty(t, textBody)
+			assert.Nil(t, findByRecipient(listFailed(t, sysadminHeaders, url.Values{}), email))
+
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?"+url.Values{
+					"action":   {model.AuditActionOutboxEmailRetried},
+					"targetId": {emailID},
+				}.Encode(), sysadminHeaders)
+			require.NoError(t, err)
+			assert.Len(t, events.Items, 1)
+		})
+		t.Run("retry of sent email must fail with 404 Not Found", func(t *testing.T) {
+			kathttpc.AssertStatusNotFound(t, retry(sysadminHeaders, emailID))
+		})
+	})
+}
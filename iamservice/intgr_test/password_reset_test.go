@@ -0,0 +1,254 @@
+package intgr_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runPasswordResetTests runs tests for forgot/reset password flow using mock emails
+func runPasswordResetTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	t.Run("Web password reset must change password and revoke refresh tokens", func(t *testing.T) {
+		createAndConfirmUser(t, env, "reset-web@example.com", "qazwsxedc", "ResetWeb", "User")
+
+		signinReq := &swagger.SignInRequest{
+			Email:    "reset-web@example.com",
+			Password: "qazwsxedc",
+			TenantId: "default-tenant",
+		}
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+		require.NoError(t, err)
+
+		clearMockEmails()
+		forgotReq := &swagger.ForgotPasswordRequest{
+			Email:    "reset-web@example.com",
+			TenantId: "default-tenant",
+			Source:   "web",
+		}
+		forgotResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+			ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, forgotReq)
+		require.NoError(t, err)
+		assert.NotEmpty(t, forgotResp.Message)
+
+		err = waitForMockEmail(1, 5)
+		require.NoError(t, err)
+		email, err := getLastMockEmail()
+		require.NoError(t, err)
+		assert.Equal(t, "reset-web@example.com", email.To)
+		assert.Contains(t, email.Subject, "Reset Your Password")
+		assert.Contains(t, email.Body, "ResetWeb")
+
+		resetURL := extractPasswordResetURL(email.Body)
+		require.NotEmpty(t, resetURL)
+		code := extractCodeFromConfirmationURL(resetURL)
+		require.NotEmpty(t, code)
+
+		resetReq := &swagger.ResetPasswordRequest{
+			Email:       "reset-web@example.com",
+			TenantId:    "default-tenant",
+			Code:        code,
+			NewPassword: "newpassword123",
+		}
+		resetResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+			ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, resetReq)
+		require.NoError(t, err)
+		assert.Contains(t, resetResp.Message, "reset successfully")
+
+		t.Run("Old refresh token must be revoked", func(t *testing.T) {
+			refreshReq := &swagger.TokenRefreshRequest{
+				RefreshToken: authResp.RefreshToken,
+			}
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("Old password must be rejected", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+			kathttpc.AssertStatusUnauthorized(t, err)
+		})
+
+		t.Run("New password must be accepted", func(t *testing.T) {
+			newSigninReq := &swagger.SignInRequest{
+				Email:    "reset-web@example.com",
+				Password: "newpassword123",
+				TenantId: "default-tenant",
+			}
+			newAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, newSigninReq)
+			require.NoError(t, err)
+			validateSignInResponse(t, newAuth)
+		})
+
+		t.Run("Reset code must not be reusable", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, resetReq)
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+	})
+
+	t.Run("Android password reset must send 6-digit code", func(t *testing.T) {
+		createAndConfirmUser(t, env, "reset-android@example.com", "qazwsxedc", "ResetAndroid", "User")
+
+		clearMockEmails()
+		forgotReq := &swagger.ForgotPasswordRequest{
+			Email:    "reset-android@example.com",
+			TenantId: "default-tenant",
+			Source:   "android",
+		}
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+			ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, forgotReq)
+		require.NoError(t, err)
+
+		err = waitForMockEmail(1, 5)
+		require.NoError(t, err)
+		email, err := getLastMockEmail()
+		require.NoError(t, err)
+		assert.Equal(t, "reset-android@example.com", email.To)
+		assert.Contains(t, email.Subject, "Your Password Reset Code")
+		assert.Contains(t, email.Subject, "Android")
+
+		code := extractSixDigitCode(email.Body)
+		require.Len(t, code, 6)
+
+		t.Run("Invalid code must fail", func(t *testing.T) {
+			wrongCode := "000000"
+			if code == wrongCode {
+				wrongCode = "111111"
+			}
+			resetReq := &swagger.ResetPasswordRequest{
+				Email:       "reset-android@example.com",
+				TenantId:    "default-tenant",
+				Code:        wrongCode,
+				NewPassword: "newpassword123",
+			}
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, resetReq)
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+
+		t.Run("Short password must fail", func(t *testing.T) {
+			resetReq := &swagger.ResetPasswordRequest{
+				Email:       "reset-android@example.com",
+				TenantId:    "default-tenant",
+				Code:        code,
+				NewPassword: "short",
+			}
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, resetReq)
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("Valid code must reset password", func(t *testing.T) {
+			resetReq := &swagger.ResetPasswordRequest{
+				Email:       "reset-android@example.com",
+				TenantId:    "default-tenant",
+				Code:        code,
+				NewPassword: "newpassword123",
+			}
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, resetReq)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("Repeated wrong codes must invalidate the code", func(t *testing.T) {
+		createAndConfirmUser(t, env, "reset-guess@example.com", "qazwsxedc", "ResetGuess", "User")
+		requestCode := func(t *testing.T) string {
+			clearMockEmails()
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, &swagger.ForgotPasswordRequest{
+					Email:    "reset-guess@example.com",
+					TenantId: "default-tenant",
+					Source:   "ios",
+				})
+			require.NoError(t, err)
+			require.NoError(t, waitForMockEmail(1, 5))
+			email, err := getLastMockEmail()
+			require.NoError(t, err)
+			code := extractSixDigitCode(email.Body)
+			require.Len(t, code, 6)
+			return code
+		}
+		reset := func(code string) error {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ResetPasswordRequest, swagger.ResetPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/reset-password", nil, &swagger.ResetPasswordRequest{
+					Email:       "reset-guess@example.com",
+					TenantId:    "default-tenant",
+					Code:        code,
+					NewPassword: "newpassword123",
+				})
+			return err
+		}
+
+		code := requestCode(t)
+		var wrongCodes []string
+		for i := 0; len(wrongCodes) < 5; i++ {
+			if wrongCode := fmt.Sprintf("%06d", i); wrongCode != code {
+				wrongCodes = append(wrongCodes, wrongCode)
+			}
+		}
+		for _, wrongCode := range wrongCodes[:4] {
+			kathttpc.AssertStatusNotFound(t, reset(wrongCode))
+		}
+		// The last allowed guess invalidates the code, even the correct code is rejected afterwards
+		kathttpc.AssertStatusBadRequest(t, reset(wrongCodes[4]))
+		kathttpc.AssertStatusBadRequest(t, reset(code))
+
+		t.Run("Code requested within the cooldown must not be sent", func(t *testing.T) {
+			clearMockEmails()
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+				ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, &swagger.ForgotPasswordRequest{
+					Email:    "reset-guess@example.com",
+					TenantId: "default-tenant",
+					Source:   "ios",
+				})
+			require.NoError(t, err)
+			emails, err := getMockEmailsTo("reset-guess@example.com")
+			require.NoError(t, err)
+			assert.Empty(t, emails)
+		})
+
+		t.Run("New code must not reset the failed attempts within a day", func(t *testing.T) {
+			time.Sleep(appConfig.EmailConfirmation.ResendCooldown)
+			kathttpc.AssertStatusBadRequest(t, reset(requestCode(t)))
+		})
+	})
+
+	t.Run("Forgot password for unknown email must succeed without sending email", func(t *testing.T) {
+		clearMockEmails()
+		forgotReq := &swagger.ForgotPasswordRequest{
+			Email:    "no-such-user@example.com",
+			TenantId: "default-tenant",
+			Source:   "web",
+		}
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+			ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, forgotReq)
+		require.NoError(t, err)
+
+		count, err := getMockEmailCount()
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("Forgot password with invalid source must fail", func(t *testing.T) {
+		forgotReq := &swagger.ForgotPasswordRequest{
+			Email:    "reset-web@example.com",
+			TenantId: "default-tenant",
+			Source:   "desktop",
+		}
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.ForgotPasswordRequest, swagger.ForgotPasswordResponse](
+			ctx, &appConfig.Server, "api/v1/auth/forgot-password", nil, forgotReq)
+		kathttpc.AssertStatusBadRequest(t, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana/kathttpc"
 	"github.com/stretchr/testify/assert"
@@ -266,9 +267,8 @@ func runRefreshTokenTests(t *testing.T, env *TestEnvironment) {
 			}
 			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
 				ctx, &appConfig.Server, "api/v1/users/me", headers)
-			// Refresh tokens don't have the "access" type, so they get rejected with 403 (insufficient role)
-			// This is actually correct behavior - refresh tokens shouldn't be usable for API access
-			kathttpc.AssertStatusForbidden(t, err)
+			// Refresh tokens don't have the "access" type, so they are rejected like any other invalid token
+			kathttpc.AssertStatusUnauthorized(t, err)
 		})
 	})
 
@@ -330,40 +330,17 @@ func runRefreshTokenTests(t *testing.T, env *TestEnvironment) {
 			TenantId: "default-tenant",
 		}
 
-		t.Run("Cleanup removes old tokens during signin", func(t *testing.T) {
-			// Create 3 tokens through signin - each signin should trigger cleanup
+		t.Run("Sign ins must start independent sessions", func(t *testing.T) {
+			// Each sign in starts its own token family, which is not affected by other sign ins
 			var tokens []string
-
-			// First signin - no cleanup expected (no tokens to clean)
-			auth1, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
-			require.NoError(t, err)
-			validateSignInResponse(t, auth1)
-			tokens = append(tokens, auth1.RefreshToken)
-			time.Sleep(10 * time.Millisecond)
-
-			// Second signin - still no cleanup (only 1 token exists)
-			auth2, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
-			require.NoError(t, err)
-			validateSignInResponse(t, auth2)
-			tokens = append(tokens, auth2.RefreshToken)
-			time.Sleep(10 * time.Millisecond)
-
-			// Third signin - still no cleanup (only 2 tokens exist, within limit)
-			auth3, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
-			require.NoError(t, err)
-			validateSignInResponse(t, auth3)
-			tokens = append(tokens, auth3.RefreshToken)
-			time.Sleep(10 * time.Millisecond)
-
-			// Fourth signin - NOW cleanup should happen (3 tokens exist, need to keep only 2 most recent)
-			auth4, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
-			require.NoError(t, err)
-			validateSignInResponse(t, auth4)
-			tokens = append(tokens, auth4.RefreshToken)
+			for i := 0; i < 4; i++ {
+				authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+					ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+				require.NoError(t, err)
+				validateSignInResponse(t, authResp)
+				tokens = append(tokens, authResp.RefreshToken)
+				time.Sleep(10 * time.Millisecond)
+			}
 
 			// All tokens should be different
 			for i := 0; i < len(tokens); i++ {
@@ -372,43 +349,33 @@ func runRefreshTokenTests(t *testing.T, env *TestEnvironment) {
 				}
 			}
 
-			// After 4th signin, the 1st token should be cleaned up
-			// Test token 0 (should be invalid due to cleanup)
-			refreshReq0 := &swagger.TokenRefreshRequest{RefreshToken: tokens[0]}
-			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq0)
-			if err != nil {
-				kathttpc.AssertStatusUnauthorized(t, err)
-				t.Log("Token 0 correctly cleaned up and invalid")
-			} else {
-				t.Error("Token 0 should be invalid due to cleanup, but it still works")
-			}
-
-			// Test token 1 (should still work - within 2 most recent before new token)
-			refreshReq1 := &swagger.TokenRefreshRequest{RefreshToken: tokens[1]}
-			newAuth1, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
-				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq1)
-			if err != nil {
-				t.Logf("Token 1 was cleaned up (this is acceptable behavior)")
-			} else {
-				validateSignInResponse(t, newAuth1)
-				t.Log("Token 1 still works (within recent limit)")
+			// All sessions must remain usable
+			for i, token := range tokens {
+				refreshReq := &swagger.TokenRefreshRequest{RefreshToken: token}
+				newAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+					ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq)
+				require.NoError(t, err, "Session %d should still be usable", i)
+				validateSignInResponse(t, newAuth)
 			}
 		})
 
-		t.Run("Token cleanup should limit active tokens", func(t *testing.T) {
+		t.Run("Reuse of a rotated token must revoke the whole family", func(t *testing.T) {
 			// Sign in to get a fresh token
 			authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
 				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
 			require.NoError(t, err)
 			validateSignInResponse(t, authResp)
 
+			// Another session of the same user, which must not be affected
+			otherAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
+			require.NoError(t, err)
+
 			// Perform multiple refresh operations
 			currentToken := authResp.RefreshToken
+			currentAccessToken := authResp.AccessToken
 			var allGeneratedTokens []string
 			allGeneratedTokens = append(allGeneratedTokens, currentToken)
-
-			// Generate 5 more tokens through refresh
 			for i := 0; i < 5; i++ {
 				refreshReq := &swagger.TokenRefreshRequest{
 					RefreshToken: currentToken,
@@ -420,30 +387,93 @@ func runRefreshTokenTests(t *testing.T, env *TestEnvironment) {
 
 				allGeneratedTokens = append(allGeneratedTokens, newAuth.RefreshToken)
 				currentToken = newAuth.RefreshToken
-
+				currentAccessToken = newAuth.AccessToken
 				time.Sleep(10 * time.Millisecond) // Ensure different timestamps
 			}
 
-			// Due to cleanup logic, only the most recent tokens should be valid
-			// All previous tokens should be invalid due to cleanup
-			for i := 0; i < len(allGeneratedTokens)-1; i++ {
-				refreshReq := &swagger.TokenRefreshRequest{
-					RefreshToken: allGeneratedTokens[i],
-				}
-				_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
-					ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq)
-				kathttpc.AssertStatusUnauthorized(t, err)
-				t.Logf("Token %d correctly cleaned up and invalid", i)
+			// Replay a rotated token, e.g. one stolen from the client
+			replayReq := &swagger.TokenRefreshRequest{
+				RefreshToken: allGeneratedTokens[2],
 			}
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, replayReq)
+			kathttpc.AssertStatusUnauthorized(t, err)
 
-			// Only the current (last) token should still work
+			// The newest token of the family must be revoked as well
 			refreshReq := &swagger.TokenRefreshRequest{
 				RefreshToken: currentToken,
 			}
-			finalAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
 				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, refreshReq)
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			// Access tokens of the session must be revoked as well
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/me", map[string][]string{
+					"Authorization": {"Bearer " + currentAccessToken},
+				})
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			// Other sessions of the user must still work
+			otherReq := &swagger.TokenRefreshRequest{
+				RefreshToken: otherAuth.RefreshToken,
+			}
+			otherNewAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, otherReq)
+			require.NoError(t, err)
+			validateSignInResponse(t, otherNewAuth)
+
+			// The reuse must be recorded as security event
+			sysadminAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+					Email:    "john.doe.sysadmin@example.com",
+					Password: "qazwsxedc",
+					TenantId: "default-tenant",
+				})
+			require.NoError(t, err)
+			sysadminHeaders := map[string][]string{
+				"Authorization": {"Bearer " + sysadminAuth.AccessToken},
+			}
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server,
+				"api/v1/audit?action="+model.AuditActionRefreshTokenReused+"&targetId="+authResp.UserId,
+				sysadminHeaders)
+			require.NoError(t, err)
+			var reasons []string
+			for _, event := range events.Items {
+				reasons = append(reasons, *event.Reason)
+			}
+			assert.Contains(t, reasons, "family_revoked")
+		})
+
+		t.Run("Reuse of a token removed from its family must revoke the whole family", func(t *testing.T) {
+			authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, signinReq)
 			require.NoError(t, err)
-			validateSignInResponse(t, finalAuth)
+			firstToken := authResp.RefreshToken
+
+			// Rotate often enough for the first token to be removed from the family
+			currentToken := firstToken
+			for i := 0; i < 25; i++ {
+				newAuth, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+					ctx, &appConfig.Server, "api/v1/auth/refresh", nil, &swagger.TokenRefreshRequest{
+						RefreshToken: currentToken,
+					})
+				require.NoError(t, err, "Refresh %d should succeed", i+1)
+				currentToken = newAuth.RefreshToken
+			}
+
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, &swagger.TokenRefreshRequest{
+					RefreshToken: firstToken,
+				})
+			kathttpc.AssertStatusUnauthorized(t, err)
+
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil, &swagger.TokenRefreshRequest{
+					RefreshToken: currentToken,
+				})
+			kathttpc.AssertStatusUnauthorized(t, err)
 		})
 
 		t.Run("Cleanup should not affect other users", func(t *testing.T) {
@@ -0,0 +1,258 @@
+package intgr_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runRoleTests runs tests for permissions and custom roles of tenants
+func runRoleTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string, tenantID string) *swagger.SignInResponse {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: tenantID,
+			})
+		require.NoError(t, err)
+		return authResp
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	createRole := func(
+		t *testing.T, accessToken string, tenantID string, req *swagger.CreateRoleRequest,
+	) (*swagger.RoleResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateRoleRequest, swagger.RoleResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/"+tenantID+"/roles", bearer(accessToken), req)
+		return resp, err
+	}
+	updateRole := func(
+		t *testing.T, accessToken string, tenantID string, roleName string, req *swagger.UpdateRoleRequest,
+	) (*swagger.RoleResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPutRequest[swagger.UpdateRoleRequest, swagger.RoleResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/"+tenantID+"/roles/"+roleName, bearer(accessToken), req)
+		return resp, err
+	}
+	getUser := func(accessToken string, userID string) error {
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+			ctx, &appConfig.Server, "api/v1/users/"+userID, bearer(accessToken))
+		return err
+	}
+	// Revocations cover tokens issued before the second they are made in
+	waitForNextSecond := func() {
+		time.Sleep(time.Second)
+	}
+
+	adminAuth := signIn(t, "testadmin@example.com", "default-tenant")
+	userAuth := signIn(t, "testuser@example.com", "default-tenant")
+
+	t.Run("GET /permissions", func(t *testing.T) {
+		t.Run("must return permissions with system-only flag", func(t *testing.T) {
+			resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.PermissionsResponse](
+				ctx, &appConfig.Server, "api/v1/permissions", bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			permissions := lo.KeyBy(resp.Items, func(p swagger.PermissionResponse) string { return p.Name })
+			require.Contains(t, permissions, "users:read")
+			assert.False(t, permissions["users:read"].SystemOnly)
+			require.Contains(t, permissions, "tenants:all")
+			assert.True(t, permissions["tenants:all"].SystemOnly)
+		})
+
+		t.Run("user must fail with 403 Forbidden", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.PermissionsResponse](
+				ctx, &appConfig.Server, "api/v1/permissions", bearer(userAuth.AccessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("GET /tenants/{tenantId}/roles", func(t *testing.T) {
+		t.Run("must return system roles", func(t *testing.T) {
+			resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.RolesResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/roles", bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			roles := lo.KeyBy(resp.Items, func(r swagger.RoleResponse) string { return r.Name })
+			require.Contains(t, roles, "admin")
+			assert.True(t, roles["admin"].System)
+			assert.Contains(t, roles["admin"].Permissions, "users:update")
+			assert.NotContains(t, roles["admin"].Permissions, "tenants:all")
+			require.Contains(t, roles, "user")
+			assert.Empty(t, roles["user"].Permissions)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.RolesResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/test-tenant/roles", bearer(adminAuth.AccessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("POST /tenants/{tenantId}/roles", func(t *testing.T) {
+		t.Run("must create custom role", func(t *testing.T) {
+			role, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "support",
+				Description: lo.ToPtr("Support staff"),
+				Permissions: []string{"users:read", "users:read"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "support", role.Name)
+			assert.Equal(t, []string{"users:read"}, role.Permissions)
+			assert.False(t, role.System)
+			assert.Equal(t, lo.ToPtr("default-tenant"), role.TenantId)
+		})
+
+		t.Run("must fail with 409 Conflict for existing role", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "support",
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("must fail with 409 Conflict for system role name", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "sysadmin",
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusConflict(t, err)
+		})
+
+		t.Run("must fail with system-only permission", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "tenant-creator",
+				Permissions: []string{"tenants:create"},
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with unknown permission", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "unknown",
+				Permissions: []string{"billing:read"},
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with invalid name", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "Support Staff",
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			_, err := createRole(t, adminAuth.AccessToken, "test-tenant", &swagger.CreateRoleRequest{
+				Name:        "support",
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("user must fail with 403 Forbidden", func(t *testing.T) {
+			_, err := createRole(t, userAuth.AccessToken, "default-tenant", &swagger.CreateRoleRequest{
+				Name:        "self-service",
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("PUT /tenants/{tenantId}/roles/{roleName}", func(t *testing.T) {
+		t.Run("must fail with 403 Forbidden for system role", func(t *testing.T) {
+			_, err := updateRole(t, adminAuth.AccessToken, "default-tenant", "user", &swagger.UpdateRoleRequest{
+				Permissions: []string{"users:read"},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must fail with 404 Not Found for unknown role", func(t *testing.T) {
+			_, err := updateRole(t, adminAuth.AccessToken, "default-tenant", "nonexistent", &swagger.UpdateRoleRequest{
+				Permissions: []string{},
+			})
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+
+	t.Run("Custom role authorization", func(t *testing.T) {
+		supportUserID := createAndConfirmUser(t, env, "support-user@example.com", "qazwsxedc", "Support", "User")
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, any](
+			ctx, &appConfig.Server, "api/v1/users/"+supportUserID+"/roles", bearer(adminAuth.AccessToken),
+			&map[string]string{"roleName": "support"})
+		require.NoError(t, err)
+		supportAuth := signIn(t, "support-user@example.com", "default-tenant")
+
+		t.Run("must grant permissions of the role", func(t *testing.T) {
+			require.NoError(t, getUser(supportAuth.AccessToken, "test-user-5"))
+		})
+
+		t.Run("must not grant other permissions", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPutRequest[swagger.UpdateAuthUserRequest, swagger.AuthUserResponse](
+				ctx, &appConfig.Server, "api/v1/users/test-user-5", bearer(supportAuth.AccessToken),
+				&swagger.UpdateAuthUserRequest{FirstName: "Changed", LastName: "ByRole"})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must reject access tokens of holders when role is updated", func(t *testing.T) {
+			waitForNextSecond()
+			role, err := updateRole(t, adminAuth.AccessToken, "default-tenant", "support", &swagger.UpdateRoleRequest{
+				Description: lo.ToPtr("Support staff without access to users"),
+				Permissions: []string{},
+			})
+			require.NoError(t, err)
+			assert.Empty(t, role.Permissions)
+
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?"+url.Values{
+					"action":   {model.AuditActionRoleUpdated},
+					"targetId": {"support"},
+				}.Encode(), bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+			require.NotEmpty(t, events.Items)
+			assert.Contains(t, events.Items[0].Diff, "permissions")
+
+			kathttpc.AssertStatusUnauthorized(t, getUser(supportAuth.AccessToken, "test-user-5"))
+			waitForNextSecond()
+			supportAuth = signIn(t, "support-user@example.com", "default-tenant")
+			kathttpc.AssertStatusForbidden(t, getUser(supportAuth.AccessToken, "test-user-5"))
+		})
+
+		t.Run("admin must fail with 403 Forbidden when assigning sysadmin role", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, any](
+				ctx, &appConfig.Server, "api/v1/users/"+supportUserID+"/roles", bearer(adminAuth.AccessToken),
+				&map[string]string{"roleName": "sysadmin"})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("DELETE /tenants/{tenantId}/roles/{roleName}", func(t *testing.T) {
+		t.Run("must fail with 403 Forbidden for system role", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/roles/admin", bearer(adminAuth.AccessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must delete custom role", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/roles/support", bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.RoleResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/roles/support", bearer(adminAuth.AccessToken))
+			kathttpc.AssertStatusNotFound(t, err)
+		})
+	})
+}
@@ -0,0 +1,380 @@
+package intgr_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runScimTests runs conformance checks of the SCIM 2.0 provisioning API
+func runScimTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) (*swagger.SignInResponse, error) {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		return authResp, err
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	doRequest := func(
+		t *testing.T, method string, path string, headers map[string][]string, body string,
+	) (int, http.Header, []byte) {
+		req, err := http.NewRequestWithContext(ctx, method, kathttpc.LocalURL(appConfig.Server.Port, path),
+			strings.NewReader(body))
+		require.NoError(t, err)
+		for name, values := range headers {
+			req.Header[name] = values
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/scim+json")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header, respBody
+	}
+	scimRequest := func(
+		t *testing.T, method string, path string, headers map[string][]string, body string, expectedStatus int,
+		result any,
+	) {
+		status, header, respBody := doRequest(t, method, path, headers, body)
+		require.Equal(t, expectedStatus, status, string(respBody))
+		if result != nil {
+			assert.Equal(t, "application/scim+json", header.Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(respBody, result))
+		}
+	}
+	scimError := func(t *testing.T, method string, path string, headers map[string][]string, body string,
+		expectedStatus int,
+	) *swagger.ScimError {
+		var scimErr swagger.ScimError
+		scimRequest(t, method, path, headers, body, expectedStatus, &scimErr)
+		assert.Equal(t, []string{"urn:ietf:params:scim:api:messages:2.0:Error"}, scimErr.Schemas)
+		return &scimErr
+	}
+	memberIDs := func(group *swagger.ScimGroup) []string {
+		return lo.Map(lo.FromPtr(group.Members), func(member swagger.ScimMemberRef, _ int) string {
+			return member.Value
+		})
+	}
+
+	adminAuth, err := signIn(t, "testadmin@example.com")
+	require.NoError(t, err)
+	userAuth, err := signIn(t, "testuser@example.com")
+	require.NoError(t, err)
+	adminHeaders := bearer(adminAuth.AccessToken)
+
+	// SCIM clients are service clients of the tenant authenticated with the client_credentials grant
+	client, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateServiceClientRequest, swagger.CreateServiceClientResponse](
+		ctx, &appConfig.Server, "api/v1/service-clients", adminHeaders, &swagger.CreateServiceClientRequest{
+			Name:   "scim-provisioner",
+			Roles:  []string{"admin"},
+			Scopes: []string{},
+		})
+	require.NoError(t, err)
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		kathttpc.LocalURL(appConfig.Server.Port, "api/v1/auth/token"),
+		strings.NewReader(url.Values{"grant_type": {"client_credentials"}}.Encode()))
+	require.NoError(t, err)
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.SetBasicAuth(client.Client.ClientId, client.ClientSecret)
+	tokenResp, err := http.DefaultClient.Do(tokenReq)
+	require.NoError(t, err)
+	defer tokenResp.Body.Close()
+	var token map[string]interface{}
+	require.NoError(t, json.NewDecoder(tokenResp.Body).Decode(&token))
+	require.Equal(t, http.StatusOK, tokenResp.StatusCode, token)
+	scimHeaders := bearer(token["access_token"].(string))
+
+	t.Run("GET /scim/v2/ServiceProviderConfig must describe supported features", func(t *testing.T) {
+		var config swagger.ScimServiceProviderConfig
+		scimRequest(t, http.MethodGet, "scim/v2/ServiceProviderConfig", nil, "", http.StatusOK, &config)
+		assert.True(t, config.Patch.Supported)
+		assert.True(t, config.Filter.Supported)
+		assert.False(t, config.Bulk.Supported)
+		assert.Equal(t, "oauthbearertoken", config.AuthenticationSchemes[0].Type)
+	})
+
+	var userID string
+	t.Run("Users", func(t *testing.T) {
+		newUser := `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName": "scim.user1@example.com",
+			"name": {"givenName": "Scim", "familyName": "Provisioned"},
+			"emails": [{"value": "scim.user1@example.com", "type": "work", "primary": true}],
+			"password": "qazwsxedc",
+			"active": true
+		}`
+
+		t.Run("POST must create a verified user", func(t *testing.T) {
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodPost, "scim/v2/Users", scimHeaders, newUser, http.StatusCreated, &user)
+			userID = lo.FromPtr(user.Id)
+			require.NotEmpty(t, userID)
+			assert.Equal(t, "scim.user1@example.com", user.UserName)
+			assert.Equal(t, "Scim", lo.FromPtr(user.Name.GivenName))
+			assert.True(t, lo.FromPtr(user.Active))
+			assert.Nil(t, user.Password)
+			assert.Equal(t, "User", user.Meta.ResourceType)
+			assert.True(t, strings.HasSuffix(user.Meta.Location, "/scim/v2/Users/"+userID))
+			assert.Contains(t, lo.Map(lo.FromPtr(user.Groups), func(group swagger.ScimMemberRef, _ int) string {
+				return group.Value
+			}), "user")
+
+			// Emails of provisioned users are verified, so they can sign in right away
+			_, err := signIn(t, "scim.user1@example.com")
+			assert.NoError(t, err)
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?action=user.provisioned&targetId="+userID, adminHeaders)
+			require.NoError(t, err)
+			assert.Len(t, events.Items, 1)
+		})
+		t.Run("POST with existing userName must fail with 409 uniqueness error", func(t *testing.T) {
+			scimErr := scimError(t, http.MethodPost, "scim/v2/Users", scimHeaders,
+				strings.Replace(newUser, "scim.user1@", "SCIM.USER1@", 1), http.StatusConflict)
+			assert.Equal(t, "409", scimErr.Status)
+			assert.Equal(t, "uniqueness", lo.FromPtr(scimErr.ScimType))
+		})
+		t.Run("POST without name must fail with 400 Bad Request", func(t *testing.T) {
+			scimError(t, http.MethodPost, "scim/v2/Users", scimHeaders,
+				`{"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "scim.noname@example.com"}`,
+				http.StatusBadRequest)
+		})
+		t.Run("GET with userName filter must find the user", func(t *testing.T) {
+			var list swagger.ScimUserListResponse
+			scimRequest(t, http.MethodGet, "scim/v2/Users?filter="+url.QueryEscape(`userName eq "Scim.User1@example.com"`),
+				scimHeaders, "", http.StatusOK, &list)
+			assert.Equal(t, 1, list.TotalResults)
+			require.Len(t, list.Resources, 1)
+			assert.Equal(t, userID, lo.FromPtr(list.Resources[0].Id))
+
+			scimRequest(t, http.MethodGet, "scim/v2/Users?filter="+url.QueryEscape(`userName eq "nobody@example.com"`),
+				scimHeaders, "", http.StatusOK, &list)
+			assert.Equal(t, 0, list.TotalResults)
+			assert.Empty(t, list.Resources)
+		})
+		t.Run("GET must page users", func(t *testing.T) {
+			var list swagger.ScimUserListResponse
+			scimRequest(t, http.MethodGet, "scim/v2/Users?startIndex=2&count=1", scimHeaders, "", http.StatusOK, &list)
+			assert.Equal(t, 2, list.StartIndex)
+			assert.Equal(t, 1, list.ItemsPerPage)
+			assert.Greater(t, list.TotalResults, 1)
+		})
+		t.Run("GET with unsupported filter must fail with 400 Bad Request", func(t *testing.T) {
+			scimError(t, http.MethodGet, "scim/v2/Users?filter="+url.QueryEscape(`userName sw "scim"`), scimHeaders, "",
+				http.StatusBadRequest)
+		})
+		t.Run("GET /{userId} must return the user", func(t *testing.T) {
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodGet, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusOK, &user)
+			assert.Equal(t, "scim.user1@example.com", user.UserName)
+		})
+		t.Run("GET /{userId} of unknown user must fail with 404 Not Found", func(t *testing.T) {
+			scimErr := scimError(t, http.MethodGet, "scim/v2/Users/00000000-0000-0000-0000-000000000000", scimHeaders, "",
+				http.StatusNotFound)
+			assert.Equal(t, "404", scimErr.Status)
+		})
+		t.Run("PUT /{userId} must replace the user", func(t *testing.T) {
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodPut, "scim/v2/Users/"+userID, scimHeaders,
+				strings.Replace(newUser, `"Provisioned"`, `"Replaced"`, 1), http.StatusOK, &user)
+			assert.Equal(t, "Replaced", lo.FromPtr(user.Name.FamilyName))
+			assert.Equal(t, "Scim Replaced", lo.FromPtr(user.DisplayName))
+		})
+		t.Run("PATCH /{userId} must deactivate the user", func(t *testing.T) {
+			// Some identity providers send booleans as strings
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodPatch, "scim/v2/Users/"+userID, scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [
+					{"op": "Replace", "path": "active", "value": "False"},
+					{"op": "replace", "value": {"name.givenName": "Patched", "externalId": "ext-1"}}
+				]
+			}`, http.StatusOK, &user)
+			assert.False(t, lo.FromPtr(user.Active))
+			assert.Equal(t, "Patched", lo.FromPtr(user.Name.GivenName))
+
+			scimRequest(t, http.MethodGet, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusOK, &user)
+			assert.False(t, lo.FromPtr(user.Active))
+			_, err := signIn(t, "scim.user1@example.com")
+			assert.Error(t, err)
+
+			var list swagger.ScimUserListResponse
+			scimRequest(t, http.MethodGet, "scim/v2/Users?filter="+url.QueryEscape(`active eq false`), scimHeaders, "",
+				http.StatusOK, &list)
+			assert.Contains(t, lo.Map(list.Resources, func(user swagger.ScimUser, _ int) string {
+				return lo.FromPtr(user.Id)
+			}), userID)
+		})
+		t.Run("PATCH /{userId} with remove operation must fail with 400 Bad Request", func(t *testing.T) {
+			scimError(t, http.MethodPatch, "scim/v2/Users/"+userID, scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "remove", "path": "name.givenName"}]
+			}`, http.StatusBadRequest)
+		})
+		t.Run("PATCH /{userId} must reactivate the user", func(t *testing.T) {
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodPatch, "scim/v2/Users/"+userID, scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "replace", "value": {"active": true}}]
+			}`, http.StatusOK, &user)
+			assert.True(t, lo.FromPtr(user.Active))
+			_, err := signIn(t, "scim.user1@example.com")
+			assert.NoError(t, err)
+		})
+		t.Run("PATCH /{userId} with userName of another user must fail with 409 uniqueness error", func(t *testing.T) {
+			scimErr := scimError(t, http.MethodPatch, "scim/v2/Users/"+userID, scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "replace", "path": "userName", "value": "testuser@example.com"}]
+			}`, http.StatusConflict)
+			assert.Equal(t, "uniqueness", lo.FromPtr(scimErr.ScimType))
+		})
+		t.Run("PATCH /{userId} must change the email and notify the previous address", func(t *testing.T) {
+			clearMockEmails()
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodPatch, "scim/v2/Users/"+userID, scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "replace", "path": "userName", "value": "scim.renamed@example.com"}]
+			}`, http.StatusOK, &user)
+			assert.Equal(t, "scim.renamed@example.com", user.UserName)
+
+			// Emails set by the identity provider stay verified
+			_, err := signIn(t, "scim.renamed@example.com")
+			assert.NoError(t, err)
+			_, err = signIn(t, "scim.user1@example.com")
+			assert.Error(t, err)
+
+			require.NoError(t, waitForMockEmail(1, 5))
+			emails, err := getMockEmailsTo("scim.user1@example.com")
+			require.NoError(t, err)
+			require.Len(t, emails, 1)
+			assert.Contains(t, emails[0].Subject, "Email Address Changed")
+			assert.Contains(t, emails[0].Body, "scim.renamed@example.com")
+
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?action=user.updated&targetId="+userID, adminHeaders)
+			require.NoError(t, err)
+			require.NotEmpty(t, events.Items)
+			emailChange, ok := events.Items[0].Diff["email"]
+			require.True(t, ok)
+			assert.Equal(t, "scim.user1@example.com", lo.FromPtr(emailChange.Old))
+			assert.Equal(t, "scim.renamed@example.com", lo.FromPtr(emailChange.New))
+		})
+	})
+
+	t.Run("Groups", func(t *testing.T) {
+		t.Run("GET must list roles of the tenant as groups", func(t *testing.T) {
+			var list swagger.ScimGroupListResponse
+			scimRequest(t, http.MethodGet, "scim/v2/Groups?filter="+url.QueryEscape(`displayName eq "user"`),
+				scimHeaders, "", http.StatusOK, &list)
+			require.Len(t, list.Resources, 1)
+			assert.Equal(t, "user", list.Resources[0].DisplayName)
+			assert.Contains(t, memberIDs(&list.Resources[0]), userID)
+
+			scimRequest(t, http.MethodGet, "scim/v2/Groups?excludedAttributes=members", scimHeaders, "", http.StatusOK,
+				&list)
+			assert.GreaterOrEqual(t, list.TotalResults, 2)
+			for _, group := range list.Resources {
+				assert.Nil(t, group.Members)
+			}
+		})
+		t.Run("POST by service client must fail with 403 Forbidden", func(t *testing.T) {
+			scimError(t, http.MethodPost, "scim/v2/Groups", scimHeaders,
+				`{"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"], "displayName": "scim-engineers"}`,
+				http.StatusForbidden)
+		})
+		t.Run("POST must create a role with members", func(t *testing.T) {
+			var group swagger.ScimGroup
+			scimRequest(t, http.MethodPost, "scim/v2/Groups", adminHeaders, `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"displayName": "scim-engineers",
+				"members": [{"value": "`+userID+`"}]
+			}`, http.StatusCreated, &group)
+			assert.Equal(t, "scim-engineers", lo.FromPtr(group.Id))
+			assert.Equal(t, []string{userID}, memberIDs(&group))
+		})
+		t.Run("POST with unknown member must fail with 400 Bad Request", func(t *testing.T) {
+			scimError(t, http.MethodPost, "scim/v2/Groups", adminHeaders, `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"displayName": "scim-nobody",
+				"members": [{"value": "00000000-0000-0000-0000-000000000000"}]
+			}`, http.StatusBadRequest)
+			scimError(t, http.MethodGet, "scim/v2/Groups/scim-nobody", scimHeaders, "", http.StatusNotFound)
+		})
+		t.Run("PATCH must remove and add members", func(t *testing.T) {
+			var group swagger.ScimGroup
+			scimRequest(t, http.MethodPatch, "scim/v2/Groups/scim-engineers", scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "remove", "path": "members[value eq \"`+userID+`\"]"}]
+			}`, http.StatusOK, &group)
+			assert.Empty(t, memberIDs(&group))
+
+			scimRequest(t, http.MethodPatch, "scim/v2/Groups/scim-engineers", scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "add", "path": "members", "value": [{"value": "`+userID+`"}]}]
+			}`, http.StatusOK, &group)
+			assert.Equal(t, []string{userID}, memberIDs(&group))
+
+			var user swagger.ScimUser
+			scimRequest(t, http.MethodGet, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusOK, &user)
+			assert.Contains(t, lo.Map(lo.FromPtr(user.Groups), func(group swagger.ScimMemberRef, _ int) string {
+				return group.Value
+			}), "scim-engineers")
+		})
+		t.Run("PUT must replace members but not rename the role", func(t *testing.T) {
+			var group swagger.ScimGroup
+			scimRequest(t, http.MethodPut, "scim/v2/Groups/scim-engineers", scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"displayName": "scim-engineers",
+				"members": []
+			}`, http.StatusOK, &group)
+			assert.Empty(t, memberIDs(&group))
+
+			scimError(t, http.MethodPut, "scim/v2/Groups/scim-engineers", scimHeaders, `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"displayName": "scim-renamed"
+			}`, http.StatusBadRequest)
+		})
+		t.Run("DELETE must delete the role", func(t *testing.T) {
+			scimRequest(t, http.MethodDelete, "scim/v2/Groups/scim-engineers", adminHeaders, "", http.StatusNoContent, nil)
+			scimError(t, http.MethodGet, "scim/v2/Groups/scim-engineers", scimHeaders, "", http.StatusNotFound)
+		})
+	})
+
+	t.Run("DELETE /scim/v2/Users/{userId} must delete the user", func(t *testing.T) {
+		scimRequest(t, http.MethodDelete, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusNoContent, nil)
+		scimError(t, http.MethodGet, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusNotFound)
+		scimError(t, http.MethodDelete, "scim/v2/Users/"+userID, scimHeaders, "", http.StatusNotFound)
+	})
+
+	t.Run("request without token must fail with 401 Unauthorized", func(t *testing.T) {
+		scimErr := scimError(t, http.MethodGet, "scim/v2/Users", nil, "", http.StatusUnauthorized)
+		assert.Equal(t, "401", scimErr.Status)
+	})
+	t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+		scimError(t, http.MethodGet, "scim/v2/Users", bearer(userAuth.AccessToken), "", http.StatusForbidden)
+		scimError(t, http.MethodPost, "scim/v2/Users", bearer(userAuth.AccessToken),
+			`{"userName": "scim.user2@example.com", "name": {"givenName": "Scim", "familyName": "Denied"}}`,
+			http.StatusForbidden)
+	})
+}
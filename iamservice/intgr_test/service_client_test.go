@@ -0,0 +1,257 @@
+package intgr_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runServiceClientTests runs tests for service clients and the client_credentials grant
+func runServiceClientTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string, tenantID string) *swagger.SignInResponse {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: tenantID,
+			})
+		require.NoError(t, err)
+		return authResp
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	postForm := func(t *testing.T, path string, form url.Values, basicAuth []string) (int, map[string]interface{}) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, path), strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if len(basicAuth) == 2 {
+			req.SetBasicAuth(basicAuth[0], basicAuth[1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+	requestToken := func(t *testing.T, form url.Values, basicAuth []string) (int, map[string]interface{}) {
+		return postForm(t, "api/v1/auth/token", form, basicAuth)
+	}
+	introspect := func(t *testing.T, token string) map[string]interface{} {
+		status, body := postForm(t, "api/v1/auth/introspect", url.Values{"token": {token}},
+			[]string{"test-backend-client", "qazwsxedc"})
+		require.Equal(t, http.StatusOK, status, body)
+		return body
+	}
+	createClient := func(
+		t *testing.T, accessToken string, req *swagger.CreateServiceClientRequest,
+	) (*swagger.CreateServiceClientResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateServiceClientRequest, swagger.CreateServiceClientResponse](
+			ctx, &appConfig.Server, "api/v1/service-clients", bearer(accessToken), req)
+		return resp, err
+	}
+
+	adminAuth := signIn(t, "testadmin@example.com", "default-tenant")
+	created, err := createClient(t, adminAuth.AccessToken, &swagger.CreateServiceClientRequest{
+		Name:   "billing-worker",
+		Roles:  []string{"admin"},
+		Scopes: []string{"billing:read", "billing:write"},
+	})
+	require.NoError(t, err)
+	clientID := created.Client.ClientId
+	clientSecret := created.ClientSecret
+
+	t.Run("POST /service-clients", func(t *testing.T) {
+		t.Run("admin must create client with generated credentials", func(t *testing.T) {
+			assert.True(t, strings.HasPrefix(clientID, "svc-"))
+			assert.NotEmpty(t, clientSecret)
+			assert.Equal(t, "default-tenant", created.Client.TenantId)
+			assert.Equal(t, "billing-worker", created.Client.Name)
+			assert.Equal(t, []string{"admin"}, created.Client.Roles)
+			assert.Equal(t, []string{"billing:read", "billing:write"}, created.Client.Scopes)
+			assert.Nil(t, created.Client.ExpiresAt)
+		})
+
+		t.Run("must fail with sysadmin role", func(t *testing.T) {
+			_, err := createClient(t, adminAuth.AccessToken, &swagger.CreateServiceClientRequest{
+				Name:   "root-worker",
+				Roles:  []string{"sysadmin"},
+				Scopes: []string{},
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must fail with past expiresAt", func(t *testing.T) {
+			past := time.Now().Add(-time.Minute)
+			_, err := createClient(t, adminAuth.AccessToken, &swagger.CreateServiceClientRequest{
+				Name:      "expired-worker",
+				Roles:     []string{"user"},
+				Scopes:    []string{},
+				ExpiresAt: &past,
+			})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("admin must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			_, err := createClient(t, adminAuth.AccessToken, &swagger.CreateServiceClientRequest{
+				TenantId: lo.ToPtr("test-tenant"),
+				Name:     "foreign-worker",
+				Roles:    []string{"user"},
+				Scopes:   []string{},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+			userAuth := signIn(t, "testuser@example.com", "default-tenant")
+			_, err := createClient(t, userAuth.AccessToken, &swagger.CreateServiceClientRequest{
+				Name:   "user-worker",
+				Roles:  []string{"user"},
+				Scopes: []string{},
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("GET /service-clients must list clients of the tenant", func(t *testing.T) {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.ServiceClientsResponse](
+			ctx, &appConfig.Server, "api/v1/service-clients", bearer(adminAuth.AccessToken))
+		require.NoError(t, err)
+		ids := make([]string, len(resp.Items))
+		for i, item := range resp.Items {
+			ids[i] = item.ClientId
+		}
+		assert.Contains(t, ids, clientID)
+	})
+
+	t.Run("POST /auth/token", func(t *testing.T) {
+		t.Run("must issue access token with all client scopes", func(t *testing.T) {
+			status, body := requestToken(t, url.Values{"grant_type": {"client_credentials"}},
+				[]string{clientID, clientSecret})
+			require.Equal(t, http.StatusOK, status, body)
+			assert.Equal(t, "Bearer", body["token_type"])
+			assert.Equal(t, "billing:read billing:write", body["scope"])
+			assert.NotEmpty(t, body["access_token"])
+			assert.Nil(t, body["refresh_token"])
+		})
+
+		t.Run("must accept client credentials in form parameters and narrow scopes", func(t *testing.T) {
+			status, body := requestToken(t, url.Values{
+				"grant_type":    {"client_credentials"},
+				"scope":         {"billing:read"},
+				"client_id":     {clientID},
+				"client_secret": {clientSecret},
+			}, nil)
+			require.Equal(t, http.StatusOK, status, body)
+			assert.Equal(t, "billing:read", body["scope"])
+		})
+
+		t.Run("must fail with scope not granted to the client", func(t *testing.T) {
+			status, body := requestToken(t, url.Values{
+				"grant_type": {"client_credentials"},
+				"scope":      {"billing:read users:delete"},
+			}, []string{clientID, clientSecret})
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_scope", body["error"])
+		})
+
+		t.Run("must fail with wrong secret", func(t *testing.T) {
+			status, body := requestToken(t, url.Values{"grant_type": {"client_credentials"}},
+				[]string{clientID, "wrong-secret"})
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+		})
+
+		t.Run("must fail with other grant types", func(t *testing.T) {
+			status, body := requestToken(t, url.Values{"grant_type": {"password"}},
+				[]string{clientID, clientSecret})
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "unsupported_grant_type", body["error"])
+		})
+
+		t.Run("token of expiring client must not outlive the client", func(t *testing.T) {
+			expiresAt := time.Now().Add(10 * time.Minute)
+			expiring, err := createClient(t, adminAuth.AccessToken, &swagger.CreateServiceClientRequest{
+				Name:      "expiring-worker",
+				Roles:     []string{"user"},
+				Scopes:    []string{},
+				ExpiresAt: &expiresAt,
+			})
+			require.NoError(t, err)
+
+			status, body := requestToken(t, url.Values{"grant_type": {"client_credentials"}},
+				[]string{expiring.Client.ClientId, expiring.ClientSecret})
+			require.Equal(t, http.StatusOK, status, body)
+			assert.LessOrEqual(t, body["expires_in"], float64(10*60))
+			assert.Equal(t, "", body["scope"])
+		})
+	})
+
+	t.Run("service client access token", func(t *testing.T) {
+		status, body := requestToken(t, url.Values{"grant_type": {"client_credentials"}},
+			[]string{clientID, clientSecret})
+		require.Equal(t, http.StatusOK, status, body)
+		accessToken := body["access_token"].(string)
+
+		t.Run("must be accepted by APIs of its roles", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+				ctx, &appConfig.Server, "api/v1/users", bearer(accessToken))
+			assert.NoError(t, err)
+		})
+
+		t.Run("must be introspected as service client token", func(t *testing.T) {
+			body := introspect(t, accessToken)
+			assert.Equal(t, true, body["active"])
+			assert.Equal(t, clientID, body["sub"])
+			assert.Equal(t, clientID, body["client_id"])
+			assert.Equal(t, "billing:read billing:write", body["scope"])
+			assert.Equal(t, "default-tenant", body["tenantId"])
+			assert.Contains(t, body["roles"], "admin")
+			assert.Nil(t, body["sid"])
+		})
+
+		t.Run("must not manage service clients", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.ServiceClientsResponse](
+				ctx, &appConfig.Server, "api/v1/service-clients", bearer(accessToken))
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("must not sign out", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, map[string]interface{}](
+				ctx, &appConfig.Server, "api/v1/auth/signout", bearer(accessToken), &map[string]string{})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("must be rejected after the client is deleted", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+				ctx, &appConfig.Server, "api/v1/service-clients/"+clientID, bearer(adminAuth.AccessToken))
+			require.NoError(t, err)
+
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+				ctx, &appConfig.Server, "api/v1/users", bearer(accessToken))
+			kathttpc.AssertStatusUnauthorized(t, err)
+			assert.Equal(t, false, introspect(t, accessToken)["active"])
+
+			status, body := requestToken(t, url.Values{"grant_type": {"client_credentials"}},
+				[]string{clientID, clientSecret})
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+		})
+	})
+}
@@ -0,0 +1,159 @@
+package intgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSessionTests runs tests for listing and revoking sessions of a user
+func runSessionTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	// signIn signs in from the given platform and user agent
+	signIn := func(t *testing.T, email string, source string, userAgent string) *swagger.SignInResponse {
+		body, err := json.Marshal(&swagger.SignInRequest{
+			Email:    email,
+			Password: "qazwsxedc",
+			TenantId: "default-tenant",
+			Source:   &source,
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, "api/v1/auth/signin"), bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var authResp swagger.SignInResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&authResp))
+		return &authResp
+	}
+	bearer := func(authResp *swagger.SignInResponse) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+	}
+
+	email := "sessions-user@example.com"
+	userID := createAndConfirmUser(t, env, email, "qazwsxedc", "Sessions", "User")
+	sessionsPath := "api/v1/users/" + userID + "/sessions"
+
+	webAuth := signIn(t, email, "web", "SessionTest/Web")
+	iosAuth := signIn(t, email, "ios", "SessionTest/iOS")
+	androidAuth := signIn(t, email, "android", "SessionTest/Android")
+
+	t.Run("GET /users/{userId}/sessions must list sessions with device metadata", func(t *testing.T) {
+		sessions, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(webAuth))
+		require.NoError(t, err)
+		require.Len(t, sessions.Items, 3)
+
+		// Most recently used first
+		assert.Equal(t, "android", sessions.Items[0].Source)
+		assert.Equal(t, "ios", sessions.Items[1].Source)
+		assert.Equal(t, "web", sessions.Items[2].Source)
+		require.NotNil(t, sessions.Items[2].UserAgent)
+		assert.Equal(t, "SessionTest/Web", *sessions.Items[2].UserAgent)
+		assert.NotNil(t, sessions.Items[2].IpAddress)
+		assert.True(t, sessions.Items[2].Current)
+		assert.False(t, sessions.Items[0].Current)
+	})
+
+	t.Run("token refresh must update last use of the session", func(t *testing.T) {
+		refreshed, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+			&swagger.TokenRefreshRequest{RefreshToken: webAuth.RefreshToken})
+		require.NoError(t, err)
+		webAuth = refreshed
+
+		sessions, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(webAuth))
+		require.NoError(t, err)
+		require.Len(t, sessions.Items, 3)
+		assert.Equal(t, "web", sessions.Items[0].Source)
+		assert.True(t, sessions.Items[0].Current)
+	})
+
+	t.Run("DELETE /users/{userId}/sessions/{sessionId} must sign out one device", func(t *testing.T) {
+		sessions, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(iosAuth))
+		require.NoError(t, err)
+		var iosSessionID string
+		for _, session := range sessions.Items {
+			if session.Current {
+				iosSessionID = session.Id
+			}
+		}
+		require.NotEmpty(t, iosSessionID)
+
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, sessionsPath+"/"+iosSessionID, bearer(webAuth))
+		require.NoError(t, err)
+
+		_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+			&swagger.TokenRefreshRequest{RefreshToken: iosAuth.RefreshToken})
+		kathttpc.AssertStatusUnauthorized(t, err)
+
+		sessions, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(webAuth))
+		require.NoError(t, err)
+		assert.Len(t, sessions.Items, 2)
+
+		// Already revoked session is not found
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, sessionsPath+"/"+iosSessionID, bearer(webAuth))
+		kathttpc.AssertStatusNotFound(t, err)
+	})
+
+	t.Run("sessions of other users must not be accessible to users", func(t *testing.T) {
+		otherAuth := signIn(t, "testuser@example.com", "web", "SessionTest/Other")
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(otherAuth))
+		kathttpc.AssertStatusForbidden(t, err)
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, sessionsPath, bearer(otherAuth))
+		kathttpc.AssertStatusForbidden(t, err)
+	})
+
+	t.Run("DELETE /users/{userId}/sessions by admin must sign out all devices", func(t *testing.T) {
+		adminAuth := signIn(t, "testadmin@example.com", "web", "SessionTest/Admin")
+		_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, sessionsPath, bearer(adminAuth))
+		require.NoError(t, err)
+
+		for _, auth := range []*swagger.SignInResponse{webAuth, androidAuth} {
+			_, _, err = kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+				&swagger.TokenRefreshRequest{RefreshToken: auth.RefreshToken})
+			kathttpc.AssertStatusUnauthorized(t, err)
+		}
+		sessions, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSessionsResponse](
+			ctx, &appConfig.Server, sessionsPath, bearer(adminAuth))
+		require.NoError(t, err)
+		assert.Empty(t, sessions.Items)
+	})
+
+	t.Run("sign in with unknown source must fail with 400 Bad Request", func(t *testing.T) {
+		source := "desktop"
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+				Source:   &source,
+			})
+		kathttpc.AssertStatusBadRequest(t, err)
+	})
+}
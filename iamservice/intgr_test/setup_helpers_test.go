@@ -28,6 +28,9 @@ type TestEnvironment struct {
 	T         *testing.T
 }
 
+// testDB is a connection to the database of the test server, used to inspect the email outbox
+var testDB *katpg.DBLink
+
 // SetupTestEnvironment initializes the test environment with database and server
 func SetupTestEnvironment(t *testing.T) *TestEnvironment {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -56,6 +59,9 @@ func SetupTestEnvironment(t *testing.T) *TestEnvironment {
 	<-started
 	kathttpc.WaitForURLToBecomeReady(ctx, kathttpc.LocalURL(appConfig.Server.Port, "api/v1/version"))
 
+	testDB = katpg.MustConnect(ctx, &appConfig.Database)
+	t.Cleanup(testDB.Close)
+
 	return &TestEnvironment{
 		Context:   ctx,
 		AppConfig: appConfig,
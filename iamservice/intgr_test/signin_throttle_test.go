@@ -0,0 +1,177 @@
+package intgr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSignInThrottleTests runs tests for failed sign in tracking, lockout and admin unlock
+func runSignInThrottleTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+	throttle := appConfig.SignInThrottle
+
+	// postSignIn sends sign in request from the given client IP and returns the raw response
+	postSignIn := func(t *testing.T, email string, password string, clientIP string) *http.Response {
+		body, err := json.Marshal(&swagger.SignInRequest{
+			Email:    email,
+			Password: password,
+			TenantId: "default-tenant",
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, "api/v1/auth/signin"), bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", clientIP)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	adminSigninReq := &swagger.SignInRequest{
+		Email:    "testadmin@example.com",
+		Password: "qazwsxedc",
+		TenantId: "default-tenant",
+	}
+	adminAuthResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+		ctx, &appConfig.Server, "api/v1/auth/signin", nil, adminSigninReq)
+	require.NoError(t, err)
+	adminHeaders := map[string][]string{
+		"Authorization": {"Bearer " + adminAuthResp.AccessToken},
+	}
+
+	t.Run("user lockout", func(t *testing.T) {
+		email := "throttled-user@example.com"
+		userID := createAndConfirmUser(t, env, email, "qazwsxedc", "Throttled", "User")
+		lockoutPath := "api/v1/users/" + userID + "/lockout"
+
+		t.Run("failed attempts below the limit must fail with 401 Unauthorized", func(t *testing.T) {
+			for i := 0; i < throttle.MaxUserFailures-1; i++ {
+				resp := postSignIn(t, email, "wrongpassword", "198.51.100.1")
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			}
+			lockout, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, lockoutPath, adminHeaders)
+			require.NoError(t, err)
+			assert.Equal(t, throttle.MaxUserFailures-1, lockout.FailedAttempts)
+			assert.False(t, lockout.Locked)
+		})
+		t.Run("reaching the limit must lock the user out", func(t *testing.T) {
+			resp := postSignIn(t, email, "wrongpassword", "198.51.100.2")
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+			// Correct password from another client is rejected as well while locked out
+			resp = postSignIn(t, email, "qazwsxedc", "198.51.100.3")
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+			require.NoError(t, err)
+			assert.Greater(t, retryAfter, 0)
+			assert.LessOrEqual(t, retryAfter, int(throttle.LockoutDuration.Seconds()))
+
+			lockout, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, lockoutPath, adminHeaders)
+			require.NoError(t, err)
+			assert.True(t, lockout.Locked)
+			assert.NotNil(t, lockout.LockedUntil)
+		})
+		t.Run("lockout must not be visible to non-admin user", func(t *testing.T) {
+			userAuthResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+					Email:    "testuser@example.com",
+					Password: "qazwsxedc",
+					TenantId: "default-tenant",
+				})
+			require.NoError(t, err)
+			userHeaders := map[string][]string{
+				"Authorization": {"Bearer " + userAuthResp.AccessToken},
+			}
+			_, _, err = kathttpc.LocalHttpJsonDeleteRequest[struct{}](ctx, &appConfig.Server, lockoutPath, userHeaders)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+		t.Run("admin unlock must allow sign in again", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonDeleteRequest[struct{}](ctx, &appConfig.Server, lockoutPath, adminHeaders)
+			require.NoError(t, err)
+
+			lockout, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, lockoutPath, adminHeaders)
+			require.NoError(t, err)
+			assert.False(t, lockout.Locked)
+			assert.Equal(t, 0, lockout.FailedAttempts)
+
+			resp := postSignIn(t, email, "qazwsxedc", "198.51.100.4")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+		t.Run("successful sign in must reset failed attempts", func(t *testing.T) {
+			resp := postSignIn(t, email, "wrongpassword", "198.51.100.5")
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			resp = postSignIn(t, email, "qazwsxedc", "198.51.100.5")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			lockout, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserLockoutResponse](
+				ctx, &appConfig.Server, lockoutPath, adminHeaders)
+			require.NoError(t, err)
+			assert.Equal(t, 0, lockout.FailedAttempts)
+		})
+	})
+
+	t.Run("repeated lockouts must escalate", func(t *testing.T) {
+		email := "escalated-user@example.com"
+		createAndConfirmUser(t, env, email, "qazwsxedc", "Escalated", "User")
+
+		// lockOut fails sign in up to the limit and returns Retry-After seconds of the resulting lockout
+		lockOut := func(t *testing.T, clientIP string) int {
+			for i := 0; i < throttle.MaxUserFailures; i++ {
+				resp := postSignIn(t, email, "wrongpassword", clientIP)
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			}
+			resp := postSignIn(t, email, "qazwsxedc", clientIP)
+			require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+			require.NoError(t, err)
+			return retryAfter
+		}
+
+		retryAfter := lockOut(t, "198.51.100.11")
+		assert.LessOrEqual(t, retryAfter, int(throttle.LockoutDuration.Seconds()))
+
+		time.Sleep(throttle.LockoutDuration + 500*time.Millisecond)
+		retryAfter = lockOut(t, "198.51.100.12")
+		assert.Greater(t, retryAfter, int(throttle.LockoutDuration.Seconds()))
+		assert.LessOrEqual(t, retryAfter, int(throttle.MaxLockoutDuration.Seconds()))
+	})
+
+	t.Run("client IP lockout", func(t *testing.T) {
+		clientIP := "203.0.113.7"
+
+		t.Run("reaching the limit must lock the client out", func(t *testing.T) {
+			for i := 0; i < throttle.MaxIPFailures; i++ {
+				resp := postSignIn(t, "nonexistent-throttle@example.com", "qazwsxedc", clientIP)
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			}
+			// Valid credentials are rejected from the locked out client
+			resp := postSignIn(t, "testuser@example.com", "qazwsxedc", clientIP)
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+		})
+		t.Run("other clients must not be affected", func(t *testing.T) {
+			resp := postSignIn(t, "testuser@example.com", "qazwsxedc", "203.0.113.8")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+		t.Run("client must be able to sign in after the lockout expires", func(t *testing.T) {
+			time.Sleep(throttle.LockoutDuration + 500*time.Millisecond)
+			resp := postSignIn(t, "testuser@example.com", "qazwsxedc", clientIP)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	})
+}
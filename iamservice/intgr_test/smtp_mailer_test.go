@@ -0,0 +1,284 @@
+package intgr_test
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/mailer"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/outport"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpTestMessage is a message accepted by smtpTestServer
+type smtpTestMessage struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// smtpTestServer is a minimal in-process SMTP relay without TLS that accepts AUTH PLAIN credentials
+type smtpTestServer struct {
+	listener net.Listener
+	username string
+	password string
+
+	mu          sync.Mutex
+	connections int
+	messages    []smtpTestMessage
+}
+
+func startSMTPTestServer(t *testing.T, username string, password string) *smtpTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpTestServer{listener: listener, username: username, password: password}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpTestServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpTestServer) stats() (int, []smtpTestMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections, append([]smtpTestMessage(nil), s.messages...)
+}
+
+func (s *smtpTestServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tc := textproto.NewConn(conn)
+	_ = tc.PrintfLine("220 localhost ESMTP test server")
+
+	authenticated := false
+	var msg smtpTestMessage
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = tc.PrintfLine("250-localhost")
+			_ = tc.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			mechanism, initialResponse, _ := strings.Cut(arg, " ")
+			credentials, _ := base64.StdEncoding.DecodeString(initialResponse)
+			parts := strings.Split(string(credentials), "\x00")
+			if mechanism == "PLAIN" && len(parts) == 3 && parts[1] == s.username && parts[2] == s.password {
+				authenticated = true
+				_ = tc.PrintfLine("235 2.7.0 Authentication successful")
+			} else {
+				_ = tc.PrintfLine("535 5.7.8 Authentication credentials invalid")
+			}
+		case "MAIL":
+			if !authenticated {
+				_ = tc.PrintfLine("530 5.7.0 Authentication required")
+				continue
+			}
+			msg = smtpTestMessage{From: strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")}
+			_ = tc.PrintfLine("250 2.1.0 OK")
+		case "RCPT":
+			msg.To = append(msg.To, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			_ = tc.PrintfLine("250 2.1.5 OK")
+		case "DATA":
+			_ = tc.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tc.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = tc.PrintfLine("250 2.0.0 OK queued")
+		case "RSET", "NOOP":
+			_ = tc.PrintfLine("250 2.0.0 OK")
+		case "QUIT":
+			_ = tc.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// runSMTPMailerTests runs tests of the SMTP mailer adapter against an in-process SMTP server
+func runSMTPMailerTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	server := startSMTPTestServer(t, "mailer", "mailer-secret")
+	newSMTPMailer := func(password string) outport.Mailer {
+		return mailer.NewMailer(ctx, &app.MailerConfig{
+			Provider: app.MailerProviderSMTP,
+			SMTP: app.SMTPConfig{
+				Host:        "127.0.0.1",
+				Port:        server.port(),
+				TLS:         app.SMTPTLSNone,
+				Username:    "mailer",
+				Password:    password,
+				From:        "noreply@example.com",
+				IdleTimeout: time.Second,
+			},
+		}, nil)
+	}
+	smtpMailer := newSMTPMailer("mailer-secret")
+
+	t.Run("emails must be delivered over a reused connection", func(t *testing.T) {
+		for _, to := range []string{"smtp-first@example.com", "smtp-second@example.com"} {
+			err := smtpMailer.SendEmail(ctx, to, outport.NewMailContentBuilder().
+				Title("Grüße from IAMService").
+				HTMLBody("<p>Hello "+to+"</p><p>"+strings.Repeat("long line ", 200)+"</p>").
+				TextBody("Hello "+to+"\n\n"+strings.Repeat("long line ", 200)).
+				ReplyTo(lo.ToPtr("support@example.com")).
+				Headers(map[string]string{"List-Unsubscribe": "<https://example.com/unsubscribe>"}).
+				Attachments([]model.MailAttachment{
+					{Filename: "report.csv", ContentType: "text/csv", Data: []byte("id,name\n1,Grüße\n")},
+				}).
+				Build())
+			require.NoError(t, err)
+		}
+
+		connections, messages := server.stats()
+		assert.Equal(t, 1, connections)
+		require.Len(t, messages, 2)
+		assert.Equal(t, "noreply@example.com", messages[0].From)
+		assert.Equal(t, []string{"smtp-first@example.com"}, messages[0].To)
+		assert.Equal(t, []string{"smtp-second@example.com"}, messages[1].To)
+		for _, line := range strings.Split(string(messages[1].Data), "\n") {
+			assert.LessOrEqual(t, len(line), 998)
+		}
+
+		parsed, err := mail.ReadMessage(strings.NewReader(string(messages[1].Data)))
+		require.NoError(t, err)
+		assert.Equal(t, "smtp-second@example.com", parsed.Header.Get("To"))
+		assert.Equal(t, "<support@example.com>", parsed.Header.Get("Reply-To"))
+		assert.Equal(t, "<https://example.com/unsubscribe>", parsed.Header.Get("List-Unsubscribe"))
+		assert.NotEmpty(t, parsed.Header.Get("Message-ID"))
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Grüße from IAMService", subject)
+
+		mixed := readMultipart(t, parsed.Header.Get("Content-Type"), parsed.Body)
+		require.Len(t, mixed, 2)
+		alternative := readMultipart(t, mixed[0].header.Get("Content-Type"), strings.NewReader(mixed[0].body))
+		require.Len(t, alternative, 2)
+		assert.Contains(t, alternative[0].header.Get("Content-Type"), "text/plain")
+		assert.Contains(t, alternative[0].body, "Hello smtp-second@example.com")
+		assert.Contains(t, alternative[1].header.Get("Content-Type"), "text/html")
+		assert.Contains(t, alternative[1].body, "<p>Hello smtp-second@example.com</p>")
+
+		_, params, err := mime.ParseMediaType(mixed[1].header.Get("Content-Disposition"))
+		require.NoError(t, err)
+		assert.Equal(t, "report.csv", params["filename"])
+		assert.Equal(t, "base64", mixed[1].header.Get("Content-Transfer-Encoding"))
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(mixed[1].body, "\r\n", ""))
+		require.NoError(t, err)
+		assert.Equal(t, "id,name\n1,Grüße\n", string(data))
+	})
+	t.Run("idle connection must be closed and reopened", func(t *testing.T) {
+		time.Sleep(1500 * time.Millisecond)
+		err := smtpMailer.SendEmail(ctx, "smtp-third@example.com", outport.NewMailContentBuilder().
+			Title("Third").
+			HTMLBody("<p>Third</p>").
+			TextBody("").
+			ReplyTo(nil).
+			Headers(nil).
+			Attachments(nil).
+			Build())
+		require.NoError(t, err)
+
+		connections, messages := server.stats()
+		assert.Equal(t, 2, connections)
+		require.Len(t, messages, 3)
+
+		// Email without plain-text alternative is sent as a single part
+		parsed, err := mail.ReadMessage(strings.NewReader(string(messages[2].Data)))
+		require.NoError(t, err)
+		assert.Contains(t, parsed.Header.Get("Content-Type"), "text/html")
+		body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		require.NoError(t, err)
+		assert.Equal(t, "<p>Third</p>", string(body))
+	})
+	t.Run("header with line breaks must be rejected", func(t *testing.T) {
+		err := smtpMailer.SendEmail(ctx, "smtp-injected@example.com", outport.NewMailContentBuilder().
+			Title("Injected").
+			HTMLBody("<p>Injected</p>").
+			TextBody("Injected").
+			ReplyTo(nil).
+			Headers(map[string]string{"X-Campaign": "welcome\r\nBcc: attacker@example.com"}).
+			Attachments(nil).
+			Build())
+		assert.Error(t, err)
+
+		_, messages := server.stats()
+		assert.Len(t, messages, 3)
+	})
+	t.Run("wrong credentials must fail", func(t *testing.T) {
+		err := newSMTPMailer("wrong-secret").SendEmail(ctx, "smtp-rejected@example.com", outport.NewMailContentBuilder().
+			Title("Rejected").
+			HTMLBody("<p>Rejected</p>").
+			TextBody("Rejected").
+			ReplyTo(nil).
+			Headers(nil).
+			Attachments(nil).
+			Build())
+		assert.Error(t, err)
+
+		_, messages := server.stats()
+		assert.Len(t, messages, 3)
+	})
+}
+
+// mimePart is a decoded part of a multipart body
+type mimePart struct {
+	header textproto.MIMEHeader
+	body   string
+}
+
+// readMultipart reads all parts of a multipart body, quoted-printable parts are decoded by multipart.Reader
+func readMultipart(t *testing.T, contentType string, body io.Reader) []mimePart {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(mediaType, "multipart/"), "multipart expected, got %s", mediaType)
+
+	var parts []mimePart
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, mimePart{header: part.Header, body: string(data)})
+	}
+}
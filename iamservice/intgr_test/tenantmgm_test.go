@@ -6,6 +6,7 @@ import (
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana/kathttpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // runTenantManagementTests runs all tenant management-related tests
@@ -93,6 +94,40 @@ func runTenantManagementTests(t *testing.T, env *TestEnvironment) {
 					ctx, &appConfig.Server, "api/v1/tenants", nil)
 				kathttpc.AssertStatusUnauthorized(t, err)
 			})
+			t.Run("sysadmin user must search tenants by ID and name", func(t *testing.T) {
+				tenantListResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantsResponse](
+					ctx, &appConfig.Server, "api/v1/tenants?search=MFA", sysadminHeaders)
+				require.NoError(t, err)
+				require.Len(t, tenantListResp.Items, 1)
+				assert.Equal(t, "mfa-tenant", tenantListResp.Items[0].Id)
+				assert.Equal(t, 1, tenantListResp.Pagination.Total)
+				assert.Nil(t, tenantListResp.NextCursor)
+			})
+			t.Run("sysadmin user must page through tenants sorted by name", func(t *testing.T) {
+				firstResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantsResponse](
+					ctx, &appConfig.Server, "api/v1/tenants?limit=1&sortBy=name", sysadminHeaders)
+				require.NoError(t, err)
+				require.Len(t, firstResp.Items, 1)
+				require.NotNil(t, firstResp.NextCursor)
+				secondResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantsResponse](
+					ctx, &appConfig.Server, "api/v1/tenants?limit=1&sortBy=name&cursor="+*firstResp.NextCursor,
+					sysadminHeaders)
+				require.NoError(t, err)
+				require.Len(t, secondResp.Items, 1)
+				assert.NotEqual(t, firstResp.Items[0].Id, secondResp.Items[0].Id)
+
+				allResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantsResponse](
+					ctx, &appConfig.Server, "api/v1/tenants?limit=100&sortBy=name", sysadminHeaders)
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, len(allResp.Items), 2)
+				assert.Equal(t, allResp.Items[:2], append(firstResp.Items, secondResp.Items...))
+			})
+			t.Run("admin user must not find other tenants", func(t *testing.T) {
+				tenantListResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantsResponse](
+					ctx, &appConfig.Server, "api/v1/tenants?search=mfa", adminHeaders)
+				require.NoError(t, err)
+				assert.Empty(t, tenantListResp.Items)
+			})
 		})
 
 		t.Run("POST /tenants", func(t *testing.T) {
@@ -0,0 +1,229 @@
+package intgr_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runTokenRevocationTests runs tests for access token revocation and token introspection
+func runTokenRevocationTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	const backendClientID = "test-backend-client"
+
+	signIn := func(t *testing.T, email string, tenantID string) *swagger.SignInResponse {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: tenantID,
+			})
+		require.NoError(t, err)
+		return authResp
+	}
+	bearer := func(accessToken string) map[string][]string {
+		return map[string][]string{
+			"Authorization": {"Bearer " + accessToken},
+		}
+	}
+	getMe := func(accessToken string) error {
+		_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUserResponse](
+			ctx, &appConfig.Server, "api/v1/users/me", bearer(accessToken))
+		return err
+	}
+	introspect := func(t *testing.T, form url.Values, basicAuth []string) (int, map[string]interface{}) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+			kathttpc.LocalURL(appConfig.Server.Port, "api/v1/auth/introspect"), strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if len(basicAuth) == 2 {
+			req.SetBasicAuth(basicAuth[0], basicAuth[1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+	introspectToken := func(t *testing.T, token string) map[string]interface{} {
+		status, body := introspect(t, url.Values{"token": {token}}, []string{backendClientID, "qazwsxedc"})
+		require.Equal(t, http.StatusOK, status, body)
+		return body
+	}
+	// Revocations cover tokens issued before the second they are made in
+	waitForNextSecond := func() {
+		time.Sleep(time.Second)
+	}
+
+	t.Run("POST /auth/introspect", func(t *testing.T) {
+		authResp := signIn(t, "testadmin@example.com", "default-tenant")
+
+		t.Run("must report active token with its claims", func(t *testing.T) {
+			body := introspectToken(t, authResp.AccessToken)
+			assert.Equal(t, true, body["active"])
+			assert.Equal(t, authResp.UserId, body["sub"])
+			assert.Equal(t, "default-tenant", body["tenantId"])
+			assert.Equal(t, "Bearer", body["token_type"])
+			assert.Contains(t, body["roles"], "admin")
+			assert.NotEmpty(t, body["jti"])
+			assert.NotEmpty(t, body["sid"])
+			assert.NotEmpty(t, body["exp"])
+		})
+
+		t.Run("must accept client credentials in form parameters", func(t *testing.T) {
+			status, body := introspect(t, url.Values{
+				"token":         {authResp.AccessToken},
+				"client_id":     {backendClientID},
+				"client_secret": {"qazwsxedc"},
+			}, nil)
+			require.Equal(t, http.StatusOK, status, body)
+			assert.Equal(t, true, body["active"])
+		})
+
+		t.Run("must report refresh and malformed tokens as inactive", func(t *testing.T) {
+			for _, token := range []string{authResp.RefreshToken, "invalid.jwt.token"} {
+				body := introspectToken(t, token)
+				assert.Equal(t, map[string]interface{}{"active": false}, body)
+			}
+		})
+
+		t.Run("must report tokens of other tenants as inactive", func(t *testing.T) {
+			otherAuth := signIn(t, "testuser_different_tenant@example.com", "test-tenant")
+			body := introspectToken(t, otherAuth.AccessToken)
+			assert.Equal(t, false, body["active"])
+		})
+
+		t.Run("must fail for public clients and wrong secrets", func(t *testing.T) {
+			status, body := introspect(t, url.Values{
+				"token":     {authResp.AccessToken},
+				"client_id": {"test-spa-client"},
+			}, nil)
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+
+			status, body = introspect(t, url.Values{"token": {authResp.AccessToken}},
+				[]string{backendClientID, "wrong-secret"})
+			assert.Equal(t, http.StatusUnauthorized, status)
+			assert.Equal(t, "invalid_client", body["error"])
+		})
+
+		t.Run("must fail without token", func(t *testing.T) {
+			status, body := introspect(t, url.Values{}, []string{backendClientID, "qazwsxedc"})
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, "invalid_request", body["error"])
+		})
+	})
+
+	t.Run("access token must be rejected after sign out", func(t *testing.T) {
+		createAndConfirmUser(t, env, "revoke-signout@example.com", "qazwsxedc", "Revoke", "Signout")
+		olderAuth := signIn(t, "revoke-signout@example.com", "default-tenant")
+		waitForNextSecond()
+		authResp := signIn(t, "revoke-signout@example.com", "default-tenant")
+		require.NoError(t, getMe(authResp.AccessToken))
+
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, map[string]interface{}](
+			ctx, &appConfig.Server, "api/v1/auth/signout", bearer(authResp.AccessToken), &map[string]string{})
+		require.NoError(t, err)
+
+		// Token of the sign out by its ID, other tokens of the user issued before
+		kathttpc.AssertStatusUnauthorized(t, getMe(authResp.AccessToken))
+		kathttpc.AssertStatusUnauthorized(t, getMe(olderAuth.AccessToken))
+		assert.Equal(t, false, introspectToken(t, authResp.AccessToken)["active"])
+
+		// Signing in again right away must work
+		newAuth := signIn(t, "revoke-signout@example.com", "default-tenant")
+		assert.NoError(t, getMe(newAuth.AccessToken))
+	})
+
+	t.Run("access token must be rejected after its session is revoked", func(t *testing.T) {
+		userID := createAndConfirmUser(t, env, "revoke-session@example.com", "qazwsxedc", "Revoke", "Session")
+		revokedAuth := signIn(t, "revoke-session@example.com", "default-tenant")
+		keptAuth := signIn(t, "revoke-session@example.com", "default-tenant")
+		waitForNextSecond()
+
+		body := introspectToken(t, revokedAuth.AccessToken)
+		sessionID, ok := body["sid"].(string)
+		require.True(t, ok)
+		_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/sessions/"+sessionID, bearer(keptAuth.AccessToken))
+		require.NoError(t, err)
+
+		kathttpc.AssertStatusUnauthorized(t, getMe(revokedAuth.AccessToken))
+		assert.NoError(t, getMe(keptAuth.AccessToken))
+	})
+
+	t.Run("access token must be rejected after role removal", func(t *testing.T) {
+		adminAuth := signIn(t, "testadmin@example.com", "default-tenant")
+		userID := createAndConfirmUser(t, env, "revoke-role@example.com", "qazwsxedc", "Revoke", "Role")
+		_, _, err := kathttpc.LocalHttpJsonPostRequest[map[string]string, any](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles", bearer(adminAuth.AccessToken),
+			&map[string]string{"roleName": "admin"})
+		require.NoError(t, err)
+		authResp := signIn(t, "revoke-role@example.com", "default-tenant")
+		waitForNextSecond()
+
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+			ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles/admin", bearer(adminAuth.AccessToken))
+		require.NoError(t, err)
+
+		kathttpc.AssertStatusUnauthorized(t, getMe(authResp.AccessToken))
+		assert.Equal(t, false, introspectToken(t, authResp.AccessToken)["active"])
+
+		// A new token no longer carries the removed role
+		newAuth := signIn(t, "revoke-role@example.com", "default-tenant")
+		body := introspectToken(t, newAuth.AccessToken)
+		assert.Equal(t, true, body["active"])
+		assert.NotContains(t, body["roles"], "admin")
+	})
+
+	t.Run("POST /auth/revoke-access-tokens", func(t *testing.T) {
+		t.Run("admin must fail with 403 Forbidden", func(t *testing.T) {
+			adminAuth := signIn(t, "testadmin@example.com", "default-tenant")
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.AccessTokenRevocationRequest, any](
+				ctx, &appConfig.Server, "api/v1/auth/revoke-access-tokens", bearer(adminAuth.AccessToken),
+				&swagger.AccessTokenRevocationRequest{})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+
+		t.Run("sysadmin must fail with future issuedBefore", func(t *testing.T) {
+			sysadminAuth := signIn(t, "john.doe.sysadmin@example.com", "default-tenant")
+			future := time.Now().Add(time.Hour)
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.AccessTokenRevocationRequest, any](
+				ctx, &appConfig.Server, "api/v1/auth/revoke-access-tokens", bearer(sysadminAuth.AccessToken),
+				&swagger.AccessTokenRevocationRequest{IssuedBefore: &future})
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+
+		t.Run("sysadmin must reject access tokens of all users", func(t *testing.T) {
+			userAuth := signIn(t, "testadmin@example.com", "default-tenant")
+			sysadminAuth := signIn(t, "john.doe.sysadmin@example.com", "default-tenant")
+			waitForNextSecond()
+
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.AccessTokenRevocationRequest, any](
+				ctx, &appConfig.Server, "api/v1/auth/revoke-access-tokens", bearer(sysadminAuth.AccessToken),
+				&swagger.AccessTokenRevocationRequest{})
+			require.NoError(t, err)
+
+			kathttpc.AssertStatusUnauthorized(t, getMe(userAuth.AccessToken))
+			kathttpc.AssertStatusUnauthorized(t, getMe(sysadminAuth.AccessToken))
+
+			// Refresh tokens stay valid
+			refreshed, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.TokenRefreshRequest, swagger.SignInResponse](
+				ctx, &appConfig.Server, "api/v1/auth/refresh", nil,
+				&swagger.TokenRefreshRequest{RefreshToken: userAuth.RefreshToken})
+			require.NoError(t, err)
+			assert.NoError(t, getMe(refreshed.AccessToken))
+		})
+	})
+}
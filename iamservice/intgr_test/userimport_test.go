@@ -0,0 +1,211 @@
+package intgr_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runUserImportTests runs tests for bulk import and export of users of a tenant
+func runUserImportTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) map[string][]string {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		require.NoError(t, err)
+		return map[string][]string{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+	}
+	doRequest := func(
+		t *testing.T, method string, path string, headers map[string][]string, contentType string, body string,
+	) (int, []byte) {
+		req, err := http.NewRequestWithContext(ctx, method, kathttpc.LocalURL(appConfig.Server.Port, path),
+			strings.NewReader(body))
+		require.NoError(t, err)
+		for name, values := range headers {
+			req.Header[name] = values
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, respBody
+	}
+	importUsers := func(
+		t *testing.T, headers map[string][]string, query string, contentType string, body string,
+	) *swagger.UserImportResponse {
+		status, respBody := doRequest(t, http.MethodPost, "api/v1/tenants/default-tenant/users:import"+query,
+			headers, contentType, body)
+		require.Equal(t, http.StatusOK, status, string(respBody))
+		var importResp swagger.UserImportResponse
+		require.NoError(t, json.Unmarshal(respBody, &importResp))
+		return &importResp
+	}
+	findUser := func(t *testing.T, headers map[string][]string, email string) *swagger.AuthUserResponse {
+		usersResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+			ctx, &appConfig.Server, "api/v1/users?search="+email, headers)
+		require.NoError(t, err)
+		user, found := lo.Find(usersResp.Items, func(user swagger.AuthUserResponse) bool {
+			return string(user.Email) == email
+		})
+		return lo.Ternary(found, &user, nil)
+	}
+	errorLines := func(importResp *swagger.UserImportResponse) []int {
+		return lo.Map(importResp.Errors, func(rowError swagger.UserImportRowError, _ int) int {
+			return rowError.Line
+		})
+	}
+
+	adminHeaders := signIn(t, "testadmin@example.com")
+	userHeaders := signIn(t, "testuser@example.com")
+
+	csvData := "lastName,email,firstName,roles,emailVerified\n" +
+		"Importer,csv.import1@example.com,Casey,admin,true\n" +
+		"Importer,csv.import2@example.com,Charlie,,\n" +
+		",csv.import3@example.com,Nolast,,\n" +
+		"Importer,CSV.IMPORT1@example.com,Twice,,\n" +
+		"Existing,testuser@example.com,Test,,\n" +
+		"Importer,csv.import4@example.com,Norole,no-such-role,\n" +
+		"Importer,csv.import5@example.com,Maybe,,maybe\n"
+
+	t.Run("POST /tenants/{tenantId}/users:import", func(t *testing.T) {
+		t.Run("dry run must validate all records without creating users", func(t *testing.T) {
+			importResp := importUsers(t, adminHeaders, "?dryRun=true", "text/csv", csvData)
+			assert.True(t, importResp.DryRun)
+			assert.Equal(t, 7, importResp.Total)
+			assert.Equal(t, 2, importResp.Valid)
+			assert.Equal(t, 0, importResp.Imported)
+			assert.Equal(t, []int{4, 5, 6, 7, 8}, errorLines(importResp))
+			assert.Contains(t, importResp.Errors[1].Message, "line 2")
+			assert.Equal(t, "user with this email already exists", importResp.Errors[2].Message)
+			assert.Nil(t, findUser(t, adminHeaders, "csv.import1@example.com"))
+		})
+		t.Run("CSV import must create valid users with their roles", func(t *testing.T) {
+			importResp := importUsers(t, adminHeaders, "", "text/csv; charset=utf-8", csvData)
+			assert.False(t, importResp.DryRun)
+			assert.Equal(t, 2, importResp.Imported)
+			assert.Equal(t, []int{4, 5, 6, 7, 8}, errorLines(importResp))
+
+			user := findUser(t, adminHeaders, "csv.import1@example.com")
+			require.NotNil(t, user)
+			assert.Equal(t, "Casey", user.FirstName)
+			rolesResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserRolesResponse](
+				ctx, &appConfig.Server, "api/v1/users/"+user.Id+"/roles", adminHeaders)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"user", "admin"}, rolesResp.Roles)
+			events, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuditEventsResponse](
+				ctx, &appConfig.Server, "api/v1/audit?action=user.imported&targetId="+user.Id, adminHeaders)
+			require.NoError(t, err)
+			assert.Len(t, events.Items, 1)
+			assert.NotNil(t, findUser(t, adminHeaders, "csv.import2@example.com"))
+		})
+		t.Run("importing the same users again must report them as existing", func(t *testing.T) {
+			importResp := importUsers(t, adminHeaders, "", "text/csv", csvData)
+			assert.Equal(t, 0, importResp.Imported)
+			assert.Equal(t, []int{2, 3, 4, 5, 6, 7, 8}, errorLines(importResp))
+		})
+		t.Run("NDJSON import must report invalid lines", func(t *testing.T) {
+			ndjsonData := `{"email": "ndjson.import1@example.com", "firstName": "Nora", "lastName": "Json"}` + "\n" +
+				"\n" +
+				`{"email": "ndjson.import2@example.com", "firstName": "Ned", "lastName": "Json", "emailVerified": true}` + "\n" +
+				`{"email": "broken` + "\n" +
+				`{"email": "ndjson.import3@example.com", "firstName": "Nina", "lastName": "Json", "roles": ["user"]}`
+			importResp := importUsers(t, adminHeaders, "", "application/x-ndjson", ndjsonData)
+			assert.Equal(t, 4, importResp.Total)
+			assert.Equal(t, 3, importResp.Imported)
+			assert.Equal(t, []int{4}, errorLines(importResp))
+			assert.NotNil(t, findUser(t, adminHeaders, "ndjson.import3@example.com"))
+		})
+		t.Run("invalid data must fail with 400 Bad Request", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodPost, "api/v1/tenants/default-tenant/users:import", adminHeaders,
+				"application/json", "[]")
+			assert.Equal(t, http.StatusBadRequest, status)
+			status, _ = doRequest(t, http.MethodPost, "api/v1/tenants/default-tenant/users:import", adminHeaders,
+				"text/csv", "email,firstName\ncsv.import9@example.com,Nolast\n")
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+		t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodPost, "api/v1/tenants/default-tenant/users:import", userHeaders,
+				"text/csv", csvData)
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+		t.Run("admin user must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodPost, "api/v1/tenants/test-tenant/users:import", adminHeaders,
+				"text/csv", csvData)
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+	})
+
+	t.Run("GET /tenants/{tenantId}/users:export", func(t *testing.T) {
+		t.Run("CSV export must contain users in the import format", func(t *testing.T) {
+			status, body := doRequest(t, http.MethodGet, "api/v1/tenants/default-tenant/users:export", adminHeaders, "", "")
+			require.Equal(t, http.StatusOK, status)
+			records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+			require.NoError(t, err)
+			require.NotEmpty(t, records)
+			assert.Equal(t, []string{"email", "firstName", "lastName", "roles", "emailVerified"}, records[0])
+			assert.Contains(t, records, []string{"csv.import1@example.com", "Casey", "Importer", "admin", "true"})
+			assert.Contains(t, records, []string{"csv.import2@example.com", "Charlie", "Importer", "", "false"})
+			for _, record := range records[1:] {
+				assert.NotEqual(t, "testuser_different_tenant@example.com", record[0])
+			}
+
+			// Exported users can be imported into another tenant as they are
+			importResp := importUsers(t, adminHeaders, "?dryRun=true", "text/csv", string(body))
+			assert.Equal(t, len(records)-1, importResp.Total)
+			assert.Equal(t, 0, importResp.Valid)
+		})
+		t.Run("NDJSON export must contain a record on every line", func(t *testing.T) {
+			status, body := doRequest(t, http.MethodGet, "api/v1/tenants/default-tenant/users:export?format=ndjson",
+				adminHeaders, "", "")
+			require.Equal(t, http.StatusOK, status)
+			var emails []string
+			scanner := bufio.NewScanner(bytes.NewReader(body))
+			for scanner.Scan() {
+				var record swagger.UserImportRecord
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+				emails = append(emails, record.Email)
+				if record.Email == "ndjson.import2@example.com" {
+					assert.True(t, lo.FromPtr(record.EmailVerified))
+					assert.Empty(t, lo.FromPtr(record.Roles))
+				}
+			}
+			assert.Contains(t, emails, "ndjson.import2@example.com")
+		})
+		t.Run("invalid format must fail with 400 Bad Request", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodGet, "api/v1/tenants/default-tenant/users:export?format=xml",
+				adminHeaders, "", "")
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+		t.Run("admin user must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodGet, "api/v1/tenants/test-tenant/users:export", adminHeaders, "", "")
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+		t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+			status, _ := doRequest(t, http.MethodGet, "api/v1/tenants/default-tenant/users:export", userHeaders, "", "")
+			assert.Equal(t, http.StatusForbidden, status)
+		})
+	})
+}
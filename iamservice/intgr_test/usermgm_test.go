@@ -3,11 +3,13 @@ package intgr_test
 import (
 	"github.com/oapi-codegen/runtime/types"
 	"github.com/samber/lo"
+	"net/url"
 	"time"
 
 	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
 	"github.com/mobiletoly/gokatana/kathttpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -75,6 +77,17 @@ func runUserManagementTests(t *testing.T, env *TestEnvironment) {
 				}
 				assert.GreaterOrEqual(t, len(tenants), 2)
 			})
+			t.Run("sysadmin user must filter users of all tenants", func(t *testing.T) {
+				userListResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users/all?role=admin&sortBy=email&sortOrder=asc", sysadminHeaders)
+				require.NoError(t, err)
+				emails := lo.Map(userListResp.Items, func(user swagger.AuthUserResponse, _ int) string {
+					return string(user.Email)
+				})
+				assert.Contains(t, emails, "testadmin@example.com")
+				assert.Contains(t, emails, "mfaadmin@example.com")
+				assert.NotContains(t, emails, "testuser@example.com")
+			})
 			t.Run("admin user must fail with 403 Forbidden", func(t *testing.T) {
 				_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
 					ctx, &appConfig.Server, "api/v1/users/all", adminHeaders)
@@ -115,6 +128,164 @@ func runUserManagementTests(t *testing.T, env *TestEnvironment) {
 					ctx, &appConfig.Server, "api/v1/users", nil)
 				kathttpc.AssertStatusUnauthorized(t, err)
 			})
+			t.Run("filters must narrow down users", func(t *testing.T) {
+				emailsOf := func(query url.Values) []string {
+					query.Set("limit", "100")
+					userListResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+						ctx, &appConfig.Server, "api/v1/users?"+query.Encode(), adminHeaders)
+					require.NoError(t, err)
+					emails := make([]string, len(userListResp.Items))
+					for i, user := range userListResp.Items {
+						emails[i] = string(user.Email)
+					}
+					assert.Equal(t, len(emails), userListResp.Pagination.Total)
+					return emails
+				}
+
+				emails := emailsOf(url.Values{"search": {"TESTADMIN@"}})
+				assert.Equal(t, []string{"testadmin@example.com"}, emails)
+				emails = emailsOf(url.Values{"search": {"mfa"}})
+				assert.Equal(t, []string{"mfauser@example.com"}, emails)
+				emails = emailsOf(url.Values{"search": {"%"}})
+				assert.Empty(t, emails)
+
+				emails = emailsOf(url.Values{"role": {"admin"}})
+				assert.Contains(t, emails, "testadmin@example.com")
+				assert.NotContains(t, emails, "testuser@example.com")
+
+				emails = emailsOf(url.Values{"emailVerified": {"false"}})
+				assert.NotContains(t, emails, "testadmin@example.com")
+				emails = emailsOf(url.Values{"active": {"true"}, "search": {"testuser@"}})
+				assert.Equal(t, []string{"testuser@example.com"}, emails)
+			})
+			t.Run("regular user must not see other users with filters", func(t *testing.T) {
+				userListResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users?search=testadmin", userHeaders)
+				require.NoError(t, err)
+				assert.Empty(t, userListResp.Items)
+			})
+			t.Run("cursor pages must return all users in sort order", func(t *testing.T) {
+				for _, sortBy := range []string{"createdAt", "email", "lastName"} {
+					allResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+						ctx, &appConfig.Server, "api/v1/users?limit=100&sortBy="+sortBy, adminHeaders)
+					require.NoError(t, err)
+					require.Greater(t, len(allResp.Items), 2)
+					assert.Nil(t, allResp.NextCursor)
+
+					var pagedIDs []string
+					query := url.Values{"limit": {"2"}, "sortBy": {sortBy}}
+					for {
+						pageResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+							ctx, &appConfig.Server, "api/v1/users?"+query.Encode(), adminHeaders)
+						require.NoError(t, err)
+						for _, user := range pageResp.Items {
+							pagedIDs = append(pagedIDs, user.Id)
+						}
+						if pageResp.NextCursor == nil {
+							break
+						}
+						query.Set("cursor", *pageResp.NextCursor)
+					}
+					assert.Equal(t, lo.Map(allResp.Items, func(user swagger.AuthUserResponse, _ int) string {
+						return user.Id
+					}), pagedIDs, "sortBy=%s", sortBy)
+				}
+			})
+			t.Run("offset pages must match cursor pages", func(t *testing.T) {
+				firstResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users?limit=1&sortBy=email&sortOrder=desc", adminHeaders)
+				require.NoError(t, err)
+				require.NotNil(t, firstResp.NextCursor)
+				cursorResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users?"+url.Values{
+						"limit": {"1"}, "sortBy": {"email"}, "sortOrder": {"desc"}, "cursor": {*firstResp.NextCursor},
+					}.Encode(), adminHeaders)
+				require.NoError(t, err)
+				offsetResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users?limit=1&page=2&sortBy=email&sortOrder=desc", adminHeaders)
+				require.NoError(t, err)
+				assert.Equal(t, offsetResp.Items, cursorResp.Items)
+				assert.Equal(t, 2, offsetResp.Pagination.Page)
+				assert.Equal(t, offsetResp.Pagination.Total, offsetResp.Pagination.TotalPages)
+			})
+			t.Run("invalid sorting must fail with 400 Bad Request", func(t *testing.T) {
+				firstResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+					ctx, &appConfig.Server, "api/v1/users?limit=1&sortBy=email", adminHeaders)
+				require.NoError(t, err)
+				require.NotNil(t, firstResp.NextCursor)
+
+				for _, query := range []url.Values{
+					{"sortBy": {"password"}},
+					{"sortOrder": {"random"}},
+					{"emailVerified": {"maybe"}},
+					{"cursor": {"not-a-cursor"}},
+					{"cursor": {*firstResp.NextCursor}, "sortBy": {"lastName"}},
+					{"cursor": {*firstResp.NextCursor}, "sortBy": {"email"}, "sortOrder": {"desc"}},
+				} {
+					_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.AuthUsersResponse](
+						ctx, &appConfig.Server, "api/v1/users?"+query.Encode(), adminHeaders)
+					kathttpc.AssertStatusBadRequest(t, err)
+				}
+			})
+		})
+
+		t.Run("GET /users/search", func(t *testing.T) {
+			search := func(query url.Values, headers map[string][]string) []swagger.AuthUserResponse {
+				searchResp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSearchResponse](
+					ctx, &appConfig.Server, "api/v1/users/search?"+query.Encode(), headers)
+				require.NoError(t, err)
+				return searchResp.Items
+			}
+			emailsOf := func(users []swagger.AuthUserResponse) []string {
+				return lo.Map(users, func(user swagger.AuthUserResponse, _ int) string {
+					return string(user.Email)
+				})
+			}
+
+			t.Run("admin user must find misspelled email first", func(t *testing.T) {
+				users := search(url.Values{"q": {"testadmn@exmple.com"}}, adminHeaders)
+				require.NotEmpty(t, users)
+				assert.Equal(t, "testadmin@example.com", string(users[0].Email))
+			})
+			t.Run("admin user must find users by full name in own tenant only", func(t *testing.T) {
+				users := search(url.Values{"q": {"Test Admin"}}, adminHeaders)
+				require.NotEmpty(t, users)
+				assert.Equal(t, "testadmin@example.com", string(users[0].Email))
+				for _, user := range users {
+					assert.Equal(t, "default-tenant", user.TenantId)
+				}
+				assert.NotContains(t, emailsOf(users), "testuser_different_tenant@example.com")
+			})
+			t.Run("admin user must fail with 403 Forbidden for other tenant", func(t *testing.T) {
+				_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSearchResponse](
+					ctx, &appConfig.Server, "api/v1/users/search?q=test&tenantId=test-tenant", adminHeaders)
+				kathttpc.AssertStatusForbidden(t, err)
+			})
+			t.Run("sysadmin user must search all tenants or the given tenant", func(t *testing.T) {
+				users := search(url.Values{"q": {"testuser_diferent_tenant"}}, sysadminHeaders)
+				assert.Contains(t, emailsOf(users), "testuser_different_tenant@example.com")
+
+				users = search(url.Values{"q": {"mfa"}, "tenantId": {"mfa-tenant"}, "limit": {"50"}}, sysadminHeaders)
+				assert.Contains(t, emailsOf(users), "mfaadmin@example.com")
+				for _, user := range users {
+					assert.Equal(t, "mfa-tenant", user.TenantId)
+				}
+			})
+			t.Run("too short query must fail with 400 Bad Request", func(t *testing.T) {
+				_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSearchResponse](
+					ctx, &appConfig.Server, "api/v1/users/search?q=+t+", adminHeaders)
+				kathttpc.AssertStatusBadRequest(t, err)
+			})
+			t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
+				_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSearchResponse](
+					ctx, &appConfig.Server, "api/v1/users/search?q=testadmin", userHeaders)
+				kathttpc.AssertStatusForbidden(t, err)
+			})
+			t.Run("unauthenticated request must fail with 401 Unauthorized", func(t *testing.T) {
+				_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.UserSearchResponse](
+					ctx, &appConfig.Server, "api/v1/users/search?q=testadmin", nil)
+				kathttpc.AssertStatusUnauthorized(t, err)
+			})
 		})
 
 		t.Run("GET /users/{userId}", func(t *testing.T) {
@@ -467,8 +638,14 @@ func runUserManagementTests(t *testing.T, env *TestEnvironment) {
 				kathttpc.AssertStatusForbidden(t, err)
 			})
 			t.Run("regular user must fail with 403 Forbidden", func(t *testing.T) {
-				_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](
-					ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles/"+roleName, userHeaders)
+				// Removing the role revoked the user's access tokens, sign in again
+				userAuthResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+					ctx, &appConfig.Server, "api/v1/auth/signin", nil, userSigninReq)
+				require.NoError(t, err)
+				_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](
+					ctx, &appConfig.Server, "api/v1/users/"+userID+"/roles/"+roleName, map[string][]string{
+						"Authorization": {"Bearer " + userAuthResp.AccessToken},
+					})
 				kathttpc.AssertStatusForbidden(t, err)
 			})
 			t.Run("unauthenticated request must fail with 401 Unauthorized", func(t *testing.T) {
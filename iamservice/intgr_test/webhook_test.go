@@ -0,0 +1,434 @@
+package intgr_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/adapters/webhook"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/app"
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/swagger"
+	"github.com/mobiletoly/gokatana/kathttpc"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookTestSecret = "webhook-test-secret-0001"
+
+// webhookReceiver records the events posted to it and answers with a configurable status code
+type webhookReceiver struct {
+	mu         sync.Mutex
+	events     []swagger.WebhookEvent
+	statusCode atomic.Int32
+}
+
+func startWebhookReceiver(t *testing.T) (*webhookReceiver, *httptest.Server) {
+	receiver := &webhookReceiver{}
+	receiver.statusCode.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || !validWebhookSignature(r.Header.Get("X-Webhook-Signature"), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		statusCode := int(receiver.statusCode.Load())
+		if statusCode == http.StatusOK {
+			var event swagger.WebhookEvent
+			if err := json.Unmarshal(body, &event); err != nil || r.Header.Get("X-Webhook-Event-Id") != event.Id {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			receiver.mu.Lock()
+			receiver.events = append(receiver.events, event)
+			receiver.mu.Unlock()
+		}
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return receiver, server
+}
+
+// validWebhookSignature verifies the "t=<unix time>,v1=<signature>" header the way receivers are documented to
+func validWebhookSignature(header string, body []byte) bool {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		if key, value, found := strings.Cut(part, "="); found {
+			parts[key] = value
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(webhookTestSecret))
+	mac.Write([]byte(parts["t"] + "."))
+	mac.Write(body)
+	return parts["t"] != "" && hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(parts["v1"]))
+}
+
+// findEvent returns the last received event of the type matching the predicate
+func (r *webhookReceiver) findEvent(
+	eventType swagger.WebhookEventType, match func(event *swagger.WebhookEvent) bool,
+) *swagger.WebhookEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.events) - 1; i >= 0; i-- {
+		if r.events[i].Type == eventType && match(&r.events[i]) {
+			event := r.events[i]
+			return &event
+		}
+	}
+	return nil
+}
+
+// runWebhookTests runs tests for webhook subscriptions and the delivery of identity events
+func runWebhookTests(t *testing.T, env *TestEnvironment) {
+	ctx := env.Context
+	appConfig := env.AppConfig
+
+	signIn := func(t *testing.T, email string) map[string][]string {
+		authResp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignInRequest, swagger.SignInResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signin", nil, &swagger.SignInRequest{
+				Email:    email,
+				Password: "qazwsxedc",
+				TenantId: "default-tenant",
+			})
+		require.NoError(t, err)
+		return map[string][]string{
+			"Authorization": {"Bearer " + authResp.AccessToken},
+		}
+	}
+	createWebhook := func(
+		headers map[string][]string, tenantID string, req *swagger.CreateWebhookRequest,
+	) (*swagger.CreateWebhookResponse, error) {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.CreateWebhookRequest, swagger.CreateWebhookResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/"+tenantID+"/webhooks", headers, req)
+		return resp, err
+	}
+	listDeliveries := func(
+		t *testing.T, headers map[string][]string, webhookID string, query string,
+	) *swagger.WebhookDeliveriesResponse {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.WebhookDeliveriesResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID+"/deliveries"+query, headers)
+		require.NoError(t, err)
+		return resp
+	}
+	signUp := func(t *testing.T, email string) string {
+		resp, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.SignUpRequest, swagger.SignUpResponse](
+			ctx, &appConfig.Server, "api/v1/auth/signup", nil, &swagger.SignUpRequest{
+				Email:     email,
+				Password:  "qazwsxedc",
+				FirstName: "Webhook",
+				LastName:  "User",
+				TenantId:  "default-tenant",
+				Source:    "web",
+			})
+		require.NoError(t, err)
+		return resp.UserId
+	}
+	updateTenant := func(t *testing.T, headers map[string][]string, description string) {
+		tenant, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.TenantResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant", headers)
+		require.NoError(t, err)
+		_, _, err = kathttpc.LocalHttpJsonPutRequest[swagger.UpdateTenantRequest, swagger.TenantResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant", headers, &swagger.UpdateTenantRequest{
+				Name:        tenant.Name,
+				Description: description,
+			})
+		require.NoError(t, err)
+	}
+	withEmail := func(email string) func(event *swagger.WebhookEvent) bool {
+		return func(event *swagger.WebhookEvent) bool { return event.Data["email"] == email }
+	}
+	withDescription := func(description string) func(event *swagger.WebhookEvent) bool {
+		return func(event *swagger.WebhookEvent) bool { return event.Data["description"] == description }
+	}
+
+	receiver, server := startWebhookReceiver(t)
+	adminHeaders := signIn(t, "testadmin@example.com")
+	userHeaders := signIn(t, "testuser@example.com")
+	allEventTypes := []swagger.WebhookEventType{
+		swagger.WebhookEventTypeUserCreated,
+		swagger.WebhookEventTypeUserEmailVerified,
+		swagger.WebhookEventTypeUserDeleted,
+		swagger.WebhookEventTypeRoleAssigned,
+		swagger.WebhookEventTypeTenantUpdated,
+	}
+
+	var webhookID string
+	t.Run("POST /tenants/{tenantId}/webhooks", func(t *testing.T) {
+		t.Run("admin must succeed in creating webhook", func(t *testing.T) {
+			resp, err := createWebhook(adminHeaders, "default-tenant", &swagger.CreateWebhookRequest{
+				Url:        server.URL + "/hooks",
+				EventTypes: append(allEventTypes, swagger.WebhookEventTypeUserCreated),
+				Secret:     lo.ToPtr(webhookTestSecret),
+			})
+			require.NoError(t, err)
+			webhookID = resp.Webhook.Id
+			assert.Equal(t, webhookTestSecret, resp.Secret)
+			assert.Equal(t, "default-tenant", resp.Webhook.TenantId)
+			assert.Equal(t, server.URL+"/hooks", resp.Webhook.Url)
+			assert.ElementsMatch(t, allEventTypes, resp.Webhook.EventTypes)
+			assert.True(t, resp.Webhook.Active)
+		})
+		t.Run("secret must be generated if not given", func(t *testing.T) {
+			resp, err := createWebhook(adminHeaders, "default-tenant", &swagger.CreateWebhookRequest{
+				Url:        server.URL + "/generated",
+				EventTypes: []swagger.WebhookEventType{swagger.WebhookEventTypeTenantUpdated},
+				Active:     lo.ToPtr(false),
+			})
+			require.NoError(t, err)
+			assert.Len(t, resp.Secret, 64)
+			assert.False(t, resp.Webhook.Active)
+
+			_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](ctx, &appConfig.Server,
+				"api/v1/tenants/default-tenant/webhooks/"+resp.Webhook.Id, adminHeaders)
+			require.NoError(t, err)
+		})
+		t.Run("invalid webhook must fail with 400 Bad Request", func(t *testing.T) {
+			for _, req := range []*swagger.CreateWebhookRequest{
+				{Url: "ftp://example.com/hooks", EventTypes: allEventTypes},
+				{Url: "/hooks", EventTypes: allEventTypes},
+				{Url: server.URL, EventTypes: nil},
+				{Url: server.URL, EventTypes: []swagger.WebhookEventType{"user.updated"}},
+				{Url: server.URL, EventTypes: allEventTypes, Secret: lo.ToPtr("too-short")},
+			} {
+				_, err := createWebhook(adminHeaders, "default-tenant", req)
+				kathttpc.AssertStatusBadRequest(t, err)
+			}
+		})
+		t.Run("user without webhooks:manage must fail with 403 Forbidden", func(t *testing.T) {
+			_, err := createWebhook(userHeaders, "default-tenant", &swagger.CreateWebhookRequest{
+				Url: server.URL, EventTypes: allEventTypes,
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+		t.Run("admin of other tenant must fail with 403 Forbidden", func(t *testing.T) {
+			_, err := createWebhook(adminHeaders, "test-tenant", &swagger.CreateWebhookRequest{
+				Url: server.URL, EventTypes: allEventTypes,
+			})
+			kathttpc.AssertStatusForbidden(t, err)
+
+			_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.WebhooksResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/test-tenant/webhooks", adminHeaders)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+	require.NotEmpty(t, webhookID)
+
+	t.Run("GET /tenants/{tenantId}/webhooks", func(t *testing.T) {
+		resp, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.WebhooksResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks", adminHeaders)
+		require.NoError(t, err)
+		assert.True(t, lo.ContainsBy(resp.Items, func(webhook swagger.WebhookResponse) bool {
+			return webhook.Id == webhookID
+		}))
+
+		webhook, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.WebhookResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID, adminHeaders)
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/hooks", webhook.Url)
+
+		_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.WebhookResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/wh-unknown", adminHeaders)
+		kathttpc.AssertStatusNotFound(t, err)
+	})
+
+	t.Run("events must be delivered signed", func(t *testing.T) {
+		email := "webhook-user@example.com"
+		firstUserID := signUp(t, email)
+
+		t.Run("user.created", func(t *testing.T) {
+			var event *swagger.WebhookEvent
+			require.Eventually(t, func() bool {
+				event = receiver.findEvent(swagger.WebhookEventTypeUserCreated, withEmail(email))
+				return event != nil
+			}, 5*time.Second, 100*time.Millisecond)
+			assert.Equal(t, "default-tenant", event.TenantId)
+			assert.Equal(t, firstUserID, event.Data["id"])
+			assert.Equal(t, "Webhook", event.Data["firstName"])
+			assert.Equal(t, false, event.Data["emailVerified"])
+		})
+
+		secondUserID := signUp(t, email)
+		t.Run("user.deleted of replaced unverified user", func(t *testing.T) {
+			require.Eventually(t, func() bool {
+				return receiver.findEvent(swagger.WebhookEventTypeUserDeleted, func(event *swagger.WebhookEvent) bool {
+					return event.Data["id"] == firstUserID
+				}) != nil && receiver.findEvent(swagger.WebhookEventTypeUserCreated, func(event *swagger.WebhookEvent) bool {
+					return event.Data["id"] == secondUserID
+				}) != nil
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+
+		t.Run("role.assigned", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonPostRequest[swagger.AssignUserRoleRequest, any](
+				ctx, &appConfig.Server, "api/v1/users/"+secondUserID+"/roles", adminHeaders,
+				&swagger.AssignUserRoleRequest{RoleName: swagger.Moderator})
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				return receiver.findEvent(swagger.WebhookEventTypeRoleAssigned, func(event *swagger.WebhookEvent) bool {
+					return event.Data["userId"] == secondUserID && event.Data["role"] == "moderator"
+				}) != nil
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+
+		t.Run("tenant.updated", func(t *testing.T) {
+			updateTenant(t, adminHeaders, "Updated by webhook test")
+			require.Eventually(t, func() bool {
+				return receiver.findEvent(swagger.WebhookEventTypeTenantUpdated,
+					withDescription("Updated by webhook test")) != nil
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+
+		t.Run("delivery log must list delivered events", func(t *testing.T) {
+			deliveries := listDeliveries(t, adminHeaders, webhookID, "?status=delivered&limit=100")
+			assert.GreaterOrEqual(t, deliveries.Pagination.Total, 5)
+			for _, delivery := range deliveries.Items {
+				assert.Equal(t, swagger.WebhookDeliveryResponseStatusDelivered, delivery.Status)
+				assert.Equal(t, 200, lo.FromPtr(delivery.LastStatusCode))
+				assert.NotNil(t, delivery.DeliveredAt)
+			}
+		})
+	})
+
+	t.Run("PUT /tenants/{tenantId}/webhooks/{webhookId}", func(t *testing.T) {
+		webhook, _, err := kathttpc.LocalHttpJsonPutRequest[swagger.UpdateWebhookRequest, swagger.WebhookResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID, adminHeaders,
+			&swagger.UpdateWebhookRequest{
+				Url:        server.URL + "/hooks",
+				EventTypes: []swagger.WebhookEventType{swagger.WebhookEventTypeTenantUpdated},
+				Active:     true,
+			})
+		require.NoError(t, err)
+		assert.Equal(t, []swagger.WebhookEventType{swagger.WebhookEventTypeTenantUpdated}, webhook.EventTypes)
+
+		// The sign up happens before the tenant update, so its event would have arrived by then if it was queued
+		email := "webhook-unsubscribed@example.com"
+		signUp(t, email)
+		updateTenant(t, adminHeaders, "Updated by webhook test again")
+		require.Eventually(t, func() bool {
+			return receiver.findEvent(swagger.WebhookEventTypeTenantUpdated,
+				withDescription("Updated by webhook test again")) != nil
+		}, 5*time.Second, 100*time.Millisecond)
+		assert.Nil(t, receiver.findEvent(swagger.WebhookEventTypeUserCreated, withEmail(email)))
+	})
+
+	t.Run("failed delivery must be retried until dead and re-delivered", func(t *testing.T) {
+		receiver.statusCode.Store(http.StatusInternalServerError)
+		updateTenant(t, adminHeaders, "Updated by failing webhook test")
+
+		var dead *swagger.WebhookDeliveryResponse
+		require.Eventually(t, func() bool {
+			deliveries := listDeliveries(t, adminHeaders, webhookID, "?status=dead")
+			if len(deliveries.Items) == 0 {
+				return false
+			}
+			dead = &deliveries.Items[0]
+			return true
+		}, 15*time.Second, 200*time.Millisecond)
+		assert.Equal(t, appConfig.Webhook.MaxAttempts, dead.Attempts)
+		assert.Equal(t, 500, lo.FromPtr(dead.LastStatusCode))
+		assert.Equal(t, "unexpected HTTP status 500", lo.FromPtr(dead.LastError))
+		assert.Nil(t, dead.DeliveredAt)
+
+		receiver.statusCode.Store(http.StatusOK)
+		redelivery, _, err := kathttpc.LocalHttpJsonPostRequest[struct{}, swagger.WebhookDeliveryResponse](
+			ctx, &appConfig.Server,
+			"api/v1/tenants/default-tenant/webhooks/"+webhookID+"/deliveries/"+dead.Id+"/redeliver",
+			adminHeaders, &struct{}{})
+		require.NoError(t, err)
+		assert.NotEqual(t, dead.Id, redelivery.Id)
+		assert.Equal(t, dead.EventId, redelivery.EventId)
+		assert.Equal(t, swagger.WebhookDeliveryResponseStatusPending, redelivery.Status)
+
+		require.Eventually(t, func() bool {
+			return receiver.findEvent(swagger.WebhookEventTypeTenantUpdated, func(event *swagger.WebhookEvent) bool {
+				return event.Id == dead.EventId
+			}) != nil
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("GET /tenants/{tenantId}/webhooks/{webhookId}/deliveries", func(t *testing.T) {
+		t.Run("invalid status must fail with 400 Bad Request", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.WebhookDeliveriesResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID+"/deliveries?status=sent",
+				adminHeaders)
+			kathttpc.AssertStatusBadRequest(t, err)
+		})
+		t.Run("user without webhooks:manage must fail with 403 Forbidden", func(t *testing.T) {
+			_, _, err := kathttpc.LocalHttpJsonGetRequest[swagger.WebhookDeliveriesResponse](
+				ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID+"/deliveries",
+				userHeaders)
+			kathttpc.AssertStatusForbidden(t, err)
+		})
+	})
+
+	t.Run("DELETE /tenants/{tenantId}/webhooks/{webhookId}", func(t *testing.T) {
+		_, _, err := kathttpc.LocalHttpJsonDeleteRequest[any](ctx, &appConfig.Server,
+			"api/v1/tenants/default-tenant/webhooks/"+webhookID, userHeaders)
+		kathttpc.AssertStatusForbidden(t, err)
+
+		_, _, err = kathttpc.LocalHttpJsonDeleteRequest[any](ctx, &appConfig.Server,
+			"api/v1/tenants/default-tenant/webhooks/"+webhookID, adminHeaders)
+		require.NoError(t, err)
+
+		_, _, err = kathttpc.LocalHttpJsonGetRequest[swagger.WebhookResponse](
+			ctx, &appConfig.Server, "api/v1/tenants/default-tenant/webhooks/"+webhookID, adminHeaders)
+		kathttpc.AssertStatusNotFound(t, err)
+	})
+
+	t.Run("client must refuse addresses that are not publicly routable", func(t *testing.T) {
+		// The integration tests allow private networks, so the address check is tested with a client of its own
+		client := webhook.NewWebhookClient(&app.WebhookConfig{RequestTimeout: 5 * time.Second})
+		hosts := map[string]string{
+			"this network":              "0.1.2.3",
+			"private 10/8":              "10.0.0.1",
+			"carrier-grade NAT":         "100.64.0.1",
+			"loopback":                  "127.0.0.1",
+			"link-local":                "169.254.169.254",
+			"private 172.16/12":         "172.16.0.1",
+			"IETF protocol assignments": "192.0.0.1",
+			"TEST-NET-1":                "192.0.2.1",
+			"6to4 relay anycast":        "192.88.99.1",
+			"private 192.168/16":        "192.168.1.1",
+			"benchmarking":              "198.19.0.1",
+			"TEST-NET-2":                "198.51.100.1",
+			"TEST-NET-3":                "203.0.113.1",
+			"multicast":                 "224.0.0.1",
+			"reserved":                  "240.0.0.1",
+			"limited broadcast":         "255.255.255.255",
+			"IPv6 unspecified":          "[::]",
+			"IPv6 loopback":             "[::1]",
+			"IPv4-compatible":           "[::a00:1]",
+			"IPv4-mapped loopback":      "[::ffff:127.0.0.1]",
+			"IPv4-mapped private":       "[::ffff:10.0.0.1]",
+			"IPv4-mapped carrier-grade": "[::ffff:100.64.0.1]",
+			"IPv4-mapped this network":  "[::ffff:0.1.2.3]",
+			"IPv4-mapped benchmarking":  "[::ffff:198.18.0.1]",
+			"NAT64 well-known prefix":   "[64:ff9b::a00:1]",
+			"NAT64 local-use":           "[64:ff9b:1::a00:1]",
+			"discard-only":              "[100::1]",
+			"Teredo":                    "[2001::1]",
+			"IPv6 documentation":        "[2001:db8::1]",
+			"6to4":                      "[2002:a00:1::1]",
+			"unique local":              "[fd00::1]",
+			"IPv6 link-local":           "[fe80::1]",
+			"site-local":                "[fec0::1]",
+			"IPv6 multicast":            "[ff02::1]",
+		}
+		for name, host := range hosts {
+			t.Run(name, func(t *testing.T) {
+				_, err := client.PostWebhook(ctx, "http://"+host+"/hook", nil, []byte("{}"))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "webhook address is not publicly routable")
+			})
+		}
+	})
+}
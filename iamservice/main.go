@@ -6,14 +6,34 @@ import (
 )
 
 //go:generate go tool oapi-codegen -config swagger/cfg-common.yaml swagger/common.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-audit.yaml swagger/audit.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-oidc.yaml swagger/oidc.yaml
 //go:generate go tool oapi-codegen -config swagger/cfg-auth.yaml swagger/auth.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-invitation.yaml swagger/invitation.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-mfa.yaml swagger/mfa.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-oauthclient.yaml swagger/oauthclient.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-outbox.yaml swagger/outbox.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-role.yaml swagger/role.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-scim.yaml swagger/scim.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-serviceclient.yaml swagger/serviceclient.yaml
 //go:generate go tool oapi-codegen -config swagger/cfg-tenant.yaml swagger/tenant.yaml
 //go:generate go tool oapi-codegen -config swagger/cfg-user.yaml swagger/user.yaml
+//go:generate go tool oapi-codegen -config swagger/cfg-webhook.yaml swagger/webhook.yaml
 
+//go:generate go tool gobetter -input=./internal/core/swagger/audit.gen.go -generate-for=exported -receiver=pointer
 //go:generate go tool gobetter -input=./internal/core/swagger/auth.gen.go -generate-for=exported -receiver=pointer
 //go:generate go tool gobetter -input=./internal/core/swagger/common.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/invitation.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/mfa.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/oauthclient.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/oidc.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/outbox.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/role.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/scim.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/serviceclient.gen.go -generate-for=exported -receiver=pointer
 //go:generate go tool gobetter -input=./internal/core/swagger/tenant.gen.go -generate-for=exported -receiver=pointer
 //go:generate go tool gobetter -input=./internal/core/swagger/user.gen.go -generate-for=exported -receiver=pointer
+//go:generate go tool gobetter -input=./internal/core/swagger/webhook.gen.go -generate-for=exported -receiver=pointer
 
 //go:generate go tool templ generate
 
@@ -0,0 +1,154 @@
+// Code generated by templ - DO NOT EDIT.
+
+// templ: version: v0.3.943
+package email
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
+type EmailChangeConfirmationData struct {
+	FirstName        string
+	NewEmail         string
+	ConfirmationURL  string // link for web users, empty for mobile users
+	ConfirmationCode string // 6-digit code for mobile users, empty for web users
+	ExpiresIn        string
+}
+
+func EmailChangeConfirmation(data *EmailChangeConfirmationData) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var1 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var1 == nil {
+			templ_7745c5c3_Var1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>Confirm Your New Email Address</title><style>\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;\n\t\t\t\t\tline-height: 1.6;\n\t\t\t\t\tcolor: #333;\n\t\t\t\t\tmax-width: 600px;\n\t\t\t\t\tmargin: 0 auto;\n\t\t\t\t\tpadding: 20px;\n\t\t\t\t\tbackground-color: #f8f9fa;\n\t\t\t\t}\n\t\t\t\t.container {\n\t\t\t\t\tbackground-color: white;\n\t\t\t\t\tpadding: 40px;\n\t\t\t\t\tborder-radius: 8px;\n\t\t\t\t\tbox-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);\n\t\t\t\t}\n\t\t\t\t.header {\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.logo {\n\t\t\t\t\tfont-size: 24px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\tcolor: #2563eb;\n\t\t\t\t\tmargin-bottom: 10px;\n\t\t\t\t}\n\t\t\t\t.title {\n\t\t\t\t\tfont-size: 28px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tcolor: #1f2937;\n\t\t\t\t\tmargin-bottom: 10px;\n\t\t\t\t}\n\t\t\t\t.subtitle {\n\t\t\t\t\tfont-size: 16px;\n\t\t\t\t\tcolor: #6b7280;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.content {\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.greeting {\n\t\t\t\t\tfont-size: 18px;\n\t\t\t\t\tmargin-bottom: 20px;\n\t\t\t\t}\n\t\t\t\t.message {\n\t\t\t\t\tfont-size: 16px;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t\tline-height: 1.7;\n\t\t\t\t}\n\t\t\t\t.button-container {\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tmargin: 40px 0;\n\t\t\t\t}\n\t\t\t\t.confirm-button {\n\t\t\t\t\tdisplay: inline-block;\n\t\t\t\t\tbackground-color: #2563eb;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tpadding: 16px 32px;\n\t\t\t\t\ttext-decoration: none;\n\t\t\t\t\tborder-radius: 6px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tfont-size: 16px;\n\t\t\t\t\ttransition: background-color 0.2s;\n\t\t\t\t}\n\t\t\t\t.confirm-button:hover {\n\t\t\t\t\tbackground-color: #1d4ed8;\n\t\t\t\t}\n\t\t\t\t.alternative-link {\n\t\t\t\t\tmargin-top: 30px;\n\t\t\t\t\tpadding: 20px;\n\t\t\t\t\tbackground-color: #f3f4f6;\n\t\t\t\t\tborder-radius: 6px;\n\t\t\t\t\tborder-left: 4px solid #2563eb;\n\t\t\t\t}\n\t\t\t\t.alternative-link p {\n\t\t\t\t\tmargin: 0 0 10px 0;\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tcolor: #4b5563;\n\t\t\t\t}\n\t\t\t\t.alternative-link code {\n\t\t\t\t\tbackground-color: #e5e7eb;\n\t\t\t\t\tpadding: 2px 6px;\n\t\t\t\t\tborder-radius: 3px;\n\t\t\t\t\tfont-family: 'Monaco', 'Menlo', 'Ubuntu Mono', monospace;\n\t\t\t\t\tfont-size: 13px;\n\t\t\t\t\tword-break: break-all;\n\t\t\t\t}\n\t\t\t\t.code-container {\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tmargin: 40px 0;\n\t\t\t\t\tpadding: 30px;\n\t\t\t\t\tbackground: linear-gradient(135deg, #667eea 0%, #764ba2 100%);\n\t\t\t\t\tborder-radius: 12px;\n\t\t\t\t\tcolor: white;\n\t\t\t\t}\n\t\t\t\t.code-label {\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 1px;\n\t\t\t\t\tmargin-bottom: 15px;\n\t\t\t\t\topacity: 0.9;\n\t\t\t\t}\n\t\t\t\t.confirmation-code {\n\t\t\t\t\tfont-size: 48px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\tletter-spacing: 8px;\n\t\t\t\t\tfont-family: 'Monaco', 'Menlo', 'Ubuntu Mono', monospace;\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\ttext-shadow: 0 2px 4px rgba(0, 0, 0, 0.3);\n\t\t\t\t}\n\t\t\t\t.code-note {\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tmargin-top: 15px;\n\t\t\t\t\topacity: 0.9;\n\t\t\t\t}\n\t\t\t\t.footer {\n\t\t\t\t\tmargin-top: 40px;\n\t\t\t\t\tpadding-top: 20px;\n\t\t\t\t\tborder-top: 1px solid #e5e7eb;\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tcolor: #6b7280;\n\t\t\t\t}\n\t\t\t\t.security-note {\n\t\t\t\t\tmargin-top: 20px;\n\t\t\t\t\tpadding: 15px;\n\t\t\t\t\tbackground-color: #fef3c7;\n\t\t\t\t\tborder-radius: 6px;\n\t\t\t\t\tborder-left: 4px solid #f59e0b;\n\t\t\t\t}\n\t\t\t\t.security-note p {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tcolor: #92400e;\n\t\t\t\t}\n\t\t\t</style></head><body><div class=\"container\"><div class=\"header\"><div class=\"logo\">IAMService</div><h1 class=\"title\">Confirm Your New Email Address</h1><p class=\"subtitle\">One more step to change the email address of your account</p></div><div class=\"content\"><p class=\"greeting\">Hello ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var2 string
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(data.FirstName)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 165, Col: 47}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, ",</p><p class=\"message\">You asked to change the email address of your IAMService account to ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(data.NewEmail)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 168, Col: 89}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, ". Your address stays unchanged until you confirm that this mailbox belongs to you.</p>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if data.ConfirmationURL != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<div class=\"button-container\"><a href=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var4 templ.SafeURL
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinURLErrs(templ.URL(data.ConfirmationURL))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 174, Col: 48}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" class=\"confirm-button text-white\">Confirm Email Address</a></div><div class=\"alternative-link\"><p><strong>Can't click the button?</strong> Copy and paste this link into your browser:</p><code>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(data.ConfirmationURL)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 181, Col: 35}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</code></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<div class=\"code-container\"><div class=\"code-label\">Your Confirmation Code</div><div class=\"confirmation-code\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 string
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(data.ConfirmationCode)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 186, Col: 61}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</div></div><div class=\"code-note\">Enter this code in your app while signed in</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "<div class=\"security-note\"><p><strong>Security Note:</strong> This confirmation will expire in ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(data.ExpiresIn)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 193, Col: 88}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, ".  Once confirmed, you will be signed out on all other devices. If you did not request this change, please ignore this email.</p></div></div><div class=\"footer\"><p>This email was sent to ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(data.NewEmail)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/email_change.templ`, Line: 202, Col: 44}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, " because it was entered as the new address of an IAMService account.</p><p>If you have any questions, please contact our support team.</p><p>© 2024 IAMService. All rights reserved.</p></div></div></body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+var _ = templruntime.GeneratedTemplate
@@ -0,0 +1,164 @@
+// Code generated by templ - DO NOT EDIT.
+
+// templ: version: v0.3.943
+package email
+
+//lint:file-ignore SA4006 This context is only used if a nested component is present.
+
+import "github.com/a-h/templ"
+import templruntime "github.com/a-h/templ/runtime"
+
+import (
+	"github.com/mobiletoly/gokatana-samples/iamservice/internal/core/model"
+)
+
+type MobilePasswordResetData struct {
+	User      *model.AuthUser
+	ResetCode string
+	ExpiresIn string
+	Platform  string // "android" or "ios"
+}
+
+func MobilePasswordReset(data *MobilePasswordResetData) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var1 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var1 == nil {
+			templ_7745c5c3_Var1 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>Your Password Reset Code</title><style>\n\t\t\t\tbody {\n\t\t\t\t\tfont-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;\n\t\t\t\t\tline-height: 1.6;\n\t\t\t\t\tcolor: #333;\n\t\t\t\t\tmax-width: 600px;\n\t\t\t\t\tmargin: 0 auto;\n\t\t\t\t\tpadding: 20px;\n\t\t\t\t\tbackground-color: #f8f9fa;\n\t\t\t\t}\n\t\t\t\t.container {\n\t\t\t\t\tbackground-color: white;\n\t\t\t\t\tpadding: 40px;\n\t\t\t\t\tborder-radius: 8px;\n\t\t\t\t\tbox-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);\n\t\t\t\t}\n\t\t\t\t.header {\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.logo {\n\t\t\t\t\tfont-size: 24px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\tcolor: #2563eb;\n\t\t\t\t\tmargin-bottom: 10px;\n\t\t\t\t}\n\t\t\t\t.title {\n\t\t\t\t\tfont-size: 28px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\tcolor: #1f2937;\n\t\t\t\t\tmargin-bottom: 10px;\n\t\t\t\t}\n\t\t\t\t.subtitle {\n\t\t\t\t\tfont-size: 16px;\n\t\t\t\t\tcolor: #6b7280;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.content {\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t}\n\t\t\t\t.greeting {\n\t\t\t\t\tfont-size: 18px;\n\t\t\t\t\tmargin-bottom: 20px;\n\t\t\t\t}\n\t\t\t\t.message {\n\t\t\t\t\tfont-size: 16px;\n\t\t\t\t\tmargin-bottom: 30px;\n\t\t\t\t\tline-height: 1.7;\n\t\t\t\t}\n\t\t\t\t.code-container {\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tmargin: 40px 0;\n\t\t\t\t\tpadding: 30px;\n\t\t\t\t\tbackground: linear-gradient(135deg, #667eea 0%, #764ba2 100%);\n\t\t\t\t\tborder-radius: 12px;\n\t\t\t\t\tcolor: white;\n\t\t\t\t}\n\t\t\t\t.code-label {\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 1px;\n\t\t\t\t\tmargin-bottom: 15px;\n\t\t\t\t\topacity: 0.9;\n\t\t\t\t}\n\t\t\t\t.reset-code {\n\t\t\t\t\tfont-size: 48px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\tletter-spacing: 8px;\n\t\t\t\t\tfont-family: 'Monaco', 'Menlo', 'Ubuntu Mono', monospace;\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\ttext-shadow: 0 2px 4px rgba(0, 0, 0, 0.3);\n\t\t\t\t}\n\t\t\t\t.code-note {\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tmargin-top: 15px;\n\t\t\t\t\topacity: 0.9;\n\t\t\t\t}\n\t\t\t\t.instructions {\n\t\t\t\t\tbackground-color: #f8fafc;\n\t\t\t\t\tpadding: 25px;\n\t\t\t\t\tborder-radius: 8px;\n\t\t\t\t\tborder-left: 4px solid #2563eb;\n\t\t\t\t\tmargin: 30px 0;\n\t\t\t\t}\n\t\t\t\t.instructions h3 {\n\t\t\t\t\tmargin: 0 0 15px 0;\n\t\t\t\t\tfont-size: 18px;\n\t\t\t\t\tcolor: #1f2937;\n\t\t\t\t}\n\t\t\t\t.instructions ol {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\tpadding-left: 20px;\n\t\t\t\t}\n\t\t\t\t.instructions li {\n\t\t\t\t\tmargin-bottom: 8px;\n\t\t\t\t\tfont-size: 15px;\n\t\t\t\t\tcolor: #4b5563;\n\t\t\t\t}\n\t\t\t\t.platform-badge {\n\t\t\t\t\tdisplay: inline-block;\n\t\t\t\t\tpadding: 4px 12px;\n\t\t\t\t\tborder-radius: 20px;\n\t\t\t\t\tfont-size: 12px;\n\t\t\t\t\tfont-weight: 600;\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 0.5px;\n\t\t\t\t}\n\t\t\t\t.platform-android {\n\t\t\t\t\tbackground-color: #a4da22;\n\t\t\t\t\tcolor: #2d5016;\n\t\t\t\t}\n\t\t\t\t.platform-ios {\n\t\t\t\t\tbackground-color: #007aff;\n\t\t\t\t\tcolor: white;\n\t\t\t\t}\n\t\t\t\t.footer {\n\t\t\t\t\tmargin-top: 40px;\n\t\t\t\t\tpadding-top: 20px;\n\t\t\t\t\tborder-top: 1px solid #e5e7eb;\n\t\t\t\t\ttext-align: center;\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tcolor: #6b7280;\n\t\t\t\t}\n\t\t\t\t.security-note {\n\t\t\t\t\tmargin-top: 20px;\n\t\t\t\t\tpadding: 15px;\n\t\t\t\t\tbackground-color: #fef3c7;\n\t\t\t\t\tborder-radius: 6px;\n\t\t\t\t\tborder-left: 4px solid #f59e0b;\n\t\t\t\t}\n\t\t\t\t.security-note p {\n\t\t\t\t\tmargin: 0;\n\t\t\t\t\tfont-size: 14px;\n\t\t\t\t\tcolor: #92400e;\n\t\t\t\t}\n\t\t\t</style></head><body><div class=\"container\"><div class=\"header\"><div class=\"logo\">IAMService</div><h1 class=\"title\">Your Password Reset Code</h1><p class=\"subtitle\">Enter this code in your  ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var2 = []any{"platform-badge", templ.KV("platform-android", data.Platform == "android"), templ.KV("platform-ios", data.Platform == "ios")}
+		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var2...)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<span class=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var3 string
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var2).String())
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 1, Col: 0}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(data.Platform)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 167, Col: 22}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span> app to choose a new password</p></div><div class=\"content\"><p class=\"greeting\">Hello ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var5 string
+		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(data.User.FirstName)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 174, Col: 52}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, ",</p><p class=\"message\">Someone (hopefully you) asked to reset the password for your IAMService account. To choose a new password in your ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var6 string
+		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(data.Platform)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 178, Col: 54}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, " app, please enter the code below.</p><div class=\"code-container\"><div class=\"code-label\">Your Password Reset Code</div><div class=\"reset-code\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var7 string
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(data.ResetCode)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 183, Col: 46}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</div><div class=\"code-note\">Enter this code in your app</div></div><div class=\"instructions\"><h3>How to reset your password:</h3><ol><li>In your ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(data.Platform)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 190, Col: 34}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, " app locate the Reset Password screen</li><li>Enter the 6-digit code shown above</li><li>Choose a new password and tap [Reset] to complete</li></ol></div><div class=\"security-note\"><p><strong>Security Note:</strong> This password reset code will expire in ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var9 string
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(data.ExpiresIn)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 198, Col: 95}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, ".  If you didn't request a password reset, please ignore this email. Your password will not be changed.</p></div></div><div class=\"footer\"><p>This email was sent to ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var10 string
+		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(data.User.Email)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `gokatana-samples/iamservice/templates/email/mobile_password_reset.templ`, Line: 206, Col: 46}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, " because a password reset was requested for your IAMService account.</p><p>If you have any questions, please contact our support team.</p><p>© 2024 IAMService. All rights reserved.</p></div></div></body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+var _ = templruntime.GeneratedTemplate
@@ -0,0 +1,58 @@
+package email
+
+import (
+	"embed"
+	"strings"
+	"text/template"
+)
+
+// Plain-text versions of the emails are kept next to their templ templates as *.txt files. They are sent as
+// the text/plain alternative of the HTML email, so text/template is used, values must not be HTML escaped.
+//
+//go:embed *.txt
+var textTemplateFS embed.FS
+
+var textTemplates = template.Must(template.ParseFS(textTemplateFS, "*.txt"))
+
+func renderText(name string, data any) (string, error) {
+	var buf strings.Builder
+	if err := textTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WebConfirmationText renders the plain-text version of WebConfirmation
+func WebConfirmationText(data *WebConfirmationData) (string, error) {
+	return renderText("web_confirmation.txt", data)
+}
+
+// MobileConfirmationText renders the plain-text version of MobileConfirmation
+func MobileConfirmationText(data *MobileConfirmationData) (string, error) {
+	return renderText("mobile_confirmation.txt", data)
+}
+
+// WebPasswordResetText renders the plain-text version of WebPasswordReset
+func WebPasswordResetText(data *WebPasswordResetData) (string, error) {
+	return renderText("web_password_reset.txt", data)
+}
+
+// MobilePasswordResetText renders the plain-text version of MobilePasswordReset
+func MobilePasswordResetText(data *MobilePasswordResetData) (string, error) {
+	return renderText("mobile_password_reset.txt", data)
+}
+
+// EmailChangeConfirmationText renders the plain-text version of EmailChangeConfirmation
+func EmailChangeConfirmationText(data *EmailChangeConfirmationData) (string, error) {
+	return renderText("email_change.txt", data)
+}
+
+// EmailChangeNoticeText renders the plain-text version of EmailChangeNotice
+func EmailChangeNoticeText(data *EmailChangeNoticeData) (string, error) {
+	return renderText("email_change_notice.txt", data)
+}
+
+// WebInvitationText renders the plain-text version of WebInvitation
+func WebInvitationText(data *WebInvitationData) (string, error) {
+	return renderText("web_invitation.txt", data)
+}